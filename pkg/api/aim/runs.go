@@ -1144,6 +1144,9 @@ func UpdateRun(c *fiber.Ctx) error {
 			}
 			return nil
 		}); err != nil {
+			if errors.As(err, &repositories.RunVersionConflictError{}) {
+				return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("unable to update run %q: %s", params.ID, err))
+			}
 			return fiber.NewError(fiber.StatusInternalServerError,
 				fmt.Sprintf("unable to update run %q: %s", params.ID, err))
 		}
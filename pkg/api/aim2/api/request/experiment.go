@@ -10,7 +10,8 @@ type UpdateExperimentRequest struct {
 
 // GetExperimentRequest is a request object for `GET /aim/experiments/:id` endpoint.
 type GetExperimentRequest struct {
-	ID int32 `params:"id"`
+	ID     int32  `params:"id"`
+	Fields string `query:"fields"`
 }
 
 // GetExperimentRunsRequest is a request object for `GET /aim/experiments/:id/runs` endpoint.
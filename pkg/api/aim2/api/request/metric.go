@@ -22,3 +22,49 @@ type AlignedMetricTraceRequest struct {
 	Name    string    `json:"name"`
 	Slice   []int     `json:"slice"`
 }
+
+// AggregateMetricsRequest is a request object for `POST /runs/search/metric/aggregate` endpoint.
+type AggregateMetricsRequest struct {
+	RunIDs      []string  `json:"run_ids"`
+	Name        string    `json:"name"`
+	Context     fiber.Map `json:"context"`
+	Percentiles []float64 `json:"percentiles"`
+	Fill        string    `json:"fill"`
+}
+
+// JoinMetricsRequest is a request object for `POST /runs/search/metric/join` endpoint.
+type JoinMetricsRequest struct {
+	RunIDs      []string  `json:"run_ids"`
+	XMetric     string    `json:"x_metric"`
+	YMetric     string    `json:"y_metric"`
+	Context     fiber.Map `json:"context"`
+	Interpolate bool      `json:"interpolate"`
+}
+
+// GetRunsMetricsRequest is a request object for `POST /runs/search/metric/batch` endpoint.
+type GetRunsMetricsRequest struct {
+	MetricHistoryWindow
+	RunIDs  []string                     `json:"run_ids"`
+	Metrics []GetRunsMetricsTraceRequest `json:"metrics"`
+	Steps   int                          `json:"steps"`
+}
+
+// MetricHistoryWindow limits metric histories to the given range of steps and/or timestamps.
+// All the bounds are optional and inclusive.
+type MetricHistoryWindow struct {
+	MinStep   *int64 `json:"min_step"`
+	MaxStep   *int64 `json:"max_step"`
+	StartTime *int64 `json:"start_time"`
+	EndTime   *int64 `json:"end_time"`
+}
+
+// IsEmpty checks that no bound of the window was provided.
+func (w MetricHistoryWindow) IsEmpty() bool {
+	return w.MinStep == nil && w.MaxStep == nil && w.StartTime == nil && w.EndTime == nil
+}
+
+// GetRunsMetricsTraceRequest is a partial request object for GetRunsMetricsRequest.
+type GetRunsMetricsTraceRequest struct {
+	Name    string    `json:"name"`
+	Context fiber.Map `json:"context"`
+}
@@ -6,4 +6,11 @@ type GetProjectParamsRequest struct {
 	Experiments     []int    `query:"experiments"`
 	ExcludeParams   bool     `query:"exclude_params"`
 	ExperimentNames []string `query:"experiment_names"`
+	// Context is an optional JSON encoded context object, which narrows returned metrics down to this context.
+	Context string `query:"context"`
+}
+
+// GetProjectParamValuesRequest is a request object for `GET /projects/params/values` endpoint.
+type GetProjectParamValuesRequest struct {
+	Key string `query:"key"`
 }
@@ -12,6 +12,7 @@ type GetRunInfoRequest struct {
 	ID         string   `params:"id"`
 	SkipSystem bool     `query:"skip_system"`
 	Sequences  []string `query:"sequence"`
+	Fields     string   `query:"fields"`
 }
 
 // GetRunMetricsRequest is a request object for `POST /runs/:id/metric/get-batch` endpoint.
@@ -23,6 +24,8 @@ type GetRunMetricsRequest []struct {
 // GetRunsActiveRequest is a request object for `GET /runs/active` endpoint.
 type GetRunsActiveRequest struct {
 	BaseSearchRequest
+	Limit  int    `query:"limit"`
+	Offset string `query:"offset"`
 }
 
 // UpdateRunRequest is a request struct for `PUT /runs/:id` endpoint.
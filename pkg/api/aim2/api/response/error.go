@@ -2,6 +2,7 @@ package response
 
 // Error represents the response json in api errors
 type Error struct {
-	Message string `json:"message"`
-	Detail  string `json:"detail"`
+	Message   string `json:"message"`
+	Detail    string `json:"detail"`
+	ErrorCode string `json:"error_code"`
 }
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // Experiment represents the response object to hold models.ExperimentExtended data.
@@ -17,24 +19,48 @@ type Experiment struct {
 	CreationTime float64 `json:"creation_time"`
 }
 
+// GetExperimentResponse represents the response object for `GET /experiments/:id` endpoint.
+// Fields of the sections, which haven't been requested, are omitted.
+type GetExperimentResponse struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Description  *string  `json:"description,omitempty"`
+	Archived     *bool    `json:"archived,omitempty"`
+	RunCount     *int     `json:"run_count,omitempty"`
+	CreationTime *float64 `json:"creation_time,omitempty"`
+}
+
 // NewGetExperimentResponse creates new response object for `GET /experiments/:id` endpoint.
-func NewGetExperimentResponse(experiment *models.ExperimentExtended) Experiment {
-	return Experiment{
-		ID:           strconv.Itoa(int(*experiment.ID)),
-		Name:         experiment.Name,
-		Description:  experiment.Description,
-		Archived:     experiment.LifecycleStage == models.LifecycleStageDeleted,
-		RunCount:     experiment.RunCount,
-		CreationTime: float64(experiment.CreationTime.Int64) / 1000,
+func NewGetExperimentResponse(
+	experiment *models.ExperimentExtended, fields commonModels.ResponseFields,
+) *GetExperimentResponse {
+	resp := GetExperimentResponse{
+		ID:   strconv.Itoa(int(*experiment.ID)),
+		Name: experiment.Name,
+	}
+	if fields.Has(commonModels.ResponseFieldTags) {
+		resp.Description = &experiment.Description
 	}
+	if fields.Has(commonModels.ResponseFieldSummary) {
+		resp.Archived = common.GetPointer(experiment.LifecycleStage == models.LifecycleStageDeleted)
+		resp.RunCount = &experiment.RunCount
+		resp.CreationTime = common.GetPointer(float64(experiment.CreationTime.Int64) / 1000)
+	}
+	return &resp
 }
 
 // NewGetExperimentsResponse creates new response object for `GET /experiments` endpoint.
 func NewGetExperimentsResponse(experiments []models.ExperimentExtended) []Experiment {
 	resp := make([]Experiment, len(experiments))
 	for i, experiment := range experiments {
-		//nolint:gosec
-		resp[i] = NewGetExperimentResponse(&experiment)
+		resp[i] = Experiment{
+			ID:           strconv.Itoa(int(*experiment.ID)),
+			Name:         experiment.Name,
+			Description:  experiment.Description,
+			Archived:     experiment.LifecycleStage == models.LifecycleStageDeleted,
+			RunCount:     experiment.RunCount,
+			CreationTime: float64(experiment.CreationTime.Int64) / 1000,
+		}
 	}
 	return resp
 }
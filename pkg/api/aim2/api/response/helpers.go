@@ -1,11 +1,17 @@
 package response
 
 import (
+	"bufio"
 	"bytes"
+	"database/sql"
 	"encoding/binary"
+	"encoding/json"
 	"math"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
 )
 
 func toNumpy(values []float64) fiber.Map {
@@ -27,3 +33,58 @@ func toNumpy(values []float64) fiber.Map {
 		"blob":  buf.Bytes(),
 	}
 }
+
+// streamJSONArray streams rows as a JSON array. Items are read by next until it returns nil, converted
+// into response objects and flushed one by one, so client gets them as soon as they are ready.
+func streamJSONArray[T, R any](
+	ctx *fiber.Ctx,
+	rows *sql.Rows,
+	name string,
+	next func(*sql.Rows) (*T, error),
+	convert func(*T) R,
+) {
+	ctx.Set("Content-Type", fiber.MIMEApplicationJSON)
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		//nolint:errcheck
+		defer rows.Close()
+
+		start := time.Now()
+		if err := func() error {
+			if _, err := w.WriteString("["); err != nil {
+				return eris.Wrap(err, "error writing response")
+			}
+			for i := 0; ; i++ {
+				item, err := next(rows)
+				if err != nil {
+					return eris.Wrapf(err, "error getting next %s", name)
+				}
+				if item == nil {
+					break
+				}
+
+				data, err := json.Marshal(convert(item))
+				if err != nil {
+					return eris.Wrapf(err, "error encoding %s", name)
+				}
+				if i > 0 {
+					if _, err := w.WriteString(","); err != nil {
+						return eris.Wrap(err, "error writing response")
+					}
+				}
+				if _, err := w.Write(data); err != nil {
+					return eris.Wrap(err, "error writing response")
+				}
+				if err := w.Flush(); err != nil {
+					return eris.Wrap(err, "error flushing output stream")
+				}
+			}
+			if _, err := w.WriteString("]"); err != nil {
+				return eris.Wrap(err, "error writing response")
+			}
+			return w.Flush()
+		}(); err != nil {
+			log.Errorf("error encountered in %s %s: error streaming %s: %s", ctx.Method(), ctx.Path(), name, err)
+		}
+		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+	})
+}
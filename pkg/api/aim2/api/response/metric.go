@@ -0,0 +1,128 @@
+package response
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// AggregateMetricsResponse is a response object for a single step of `POST /runs/search/metric/aggregate` endpoint.
+type AggregateMetricsResponse struct {
+	Step        int64                      `json:"step"`
+	Count       int                        `json:"count"`
+	Mean        api.MetricValue            `json:"mean"`
+	Min         api.MetricValue            `json:"min"`
+	Max         api.MetricValue            `json:"max"`
+	Percentiles map[string]api.MetricValue `json:"percentiles,omitempty"`
+}
+
+// NewAggregateMetricsStreamResponse streams response for `POST /runs/search/metric/aggregate` endpoint.
+// Aggregates are streamed as a JSON array, one step at a time.
+func NewAggregateMetricsStreamResponse(
+	ctx *fiber.Ctx,
+	rows *sql.Rows,
+	next func(*sql.Rows) (*models.MetricAggregate, error),
+	percentiles []float64,
+	metricValuePrecision int,
+) {
+	streamJSONArray(ctx, rows, "aggregates", next, func(aggregate *models.MetricAggregate) AggregateMetricsResponse {
+		resp := AggregateMetricsResponse{
+			Step:  aggregate.Step,
+			Count: aggregate.Count,
+			Mean:  api.NewMetricValue(aggregate.Mean, metricValuePrecision),
+			Min:   api.NewMetricValue(aggregate.Min, metricValuePrecision),
+			Max:   api.NewMetricValue(aggregate.Max, metricValuePrecision),
+		}
+		if len(percentiles) > 0 {
+			resp.Percentiles = make(map[string]api.MetricValue, len(percentiles))
+			for i, percentile := range percentiles {
+				resp.Percentiles[strconv.FormatFloat(percentile, 'f', -1, 64)] = api.NewMetricValue(
+					aggregate.Percentiles[i], metricValuePrecision,
+				)
+			}
+		}
+		return resp
+	})
+}
+
+// JoinedMetricPointResponse is a partial response object for JoinMetricsResponse.
+type JoinedMetricPointResponse struct {
+	Step int64           `json:"step"`
+	X    api.MetricValue `json:"x"`
+	Y    api.MetricValue `json:"y"`
+}
+
+// JoinMetricsResponse is a response object for a single run of `POST /runs/search/metric/join` endpoint.
+type JoinMetricsResponse struct {
+	RunID  string                      `json:"run_id"`
+	Points []JoinedMetricPointResponse `json:"points"`
+}
+
+// NewJoinMetricsStreamResponse streams response for `POST /runs/search/metric/join` endpoint.
+// Joined metrics are streamed as a JSON array, one run at a time.
+func NewJoinMetricsStreamResponse(
+	ctx *fiber.Ctx,
+	rows *sql.Rows,
+	next func(*sql.Rows) (*models.JoinedMetrics, error),
+	metricValuePrecision int,
+) {
+	streamJSONArray(ctx, rows, "joined metrics", next, func(joined *models.JoinedMetrics) JoinMetricsResponse {
+		resp := JoinMetricsResponse{
+			RunID:  joined.RunID,
+			Points: make([]JoinedMetricPointResponse, len(joined.Points)),
+		}
+		for i, point := range joined.Points {
+			resp.Points[i] = JoinedMetricPointResponse{
+				Step: point.Step,
+				X:    api.NewMetricValue(point.X, metricValuePrecision),
+				Y:    api.NewMetricValue(point.Y, metricValuePrecision),
+			}
+		}
+		return resp
+	})
+}
+
+// GetRunsMetricsResponse is a response object for `POST /runs/search/metric/batch` endpoint.
+// Metric histories are grouped by run id.
+type GetRunsMetricsResponse map[string][]GetRunMetricsResponse
+
+// NewGetRunsMetricsResponse creates new response object for `POST /runs/search/metric/batch` endpoint.
+// Metrics are expected to be ordered by run, key and context.
+func NewGetRunsMetricsResponse(
+	runIDs []string, metrics []models.AlignedMetric, metricValuePrecision int,
+) GetRunsMetricsResponse {
+	resp := make(GetRunsMetricsResponse, len(runIDs))
+	for _, runID := range runIDs {
+		resp[runID] = []GetRunMetricsResponse{}
+	}
+
+	var current *GetRunMetricsResponse
+	for i, metric := range metrics {
+		if i == 0 ||
+			metric.RunID != metrics[i-1].RunID ||
+			metric.Key != metrics[i-1].Key ||
+			metric.ContextID != metrics[i-1].ContextID {
+			resp[metric.RunID] = append(resp[metric.RunID], GetRunMetricsResponse{
+				Name:    metric.Key,
+				Iters:   []int{},
+				Values:  []*api.MetricValue{},
+				Context: json.RawMessage(metric.Context),
+			})
+			current = &resp[metric.RunID][len(resp[metric.RunID])-1]
+		}
+
+		var value *api.MetricValue
+		if !metric.IsNan {
+			value = common.GetPointer(api.NewMetricValue(metric.Value, metricValuePrecision))
+		}
+		current.Iters = append(current.Iters, int(metric.Iter))
+		current.Values = append(current.Values, value)
+	}
+	return resp
+}
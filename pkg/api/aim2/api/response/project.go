@@ -133,3 +133,20 @@ func NewProjectParamsResponse(projectParams *models.ProjectParams,
 	}
 	return &rsp, nil
 }
+
+// ProjectParamValuesResponse is a response object for `GET /projects/params/values` endpoint.
+type ProjectParamValuesResponse struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+// NewProjectParamValuesResponse creates new response object for `GET /projects/params/values` endpoint.
+func NewProjectParamValuesResponse(key string, values []string) *ProjectParamValuesResponse {
+	if values == nil {
+		values = []string{}
+	}
+	return &ProjectParamValuesResponse{
+		Key:    key,
+		Values: values,
+	}
+}
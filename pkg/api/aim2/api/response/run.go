@@ -20,6 +20,8 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/encoding"
 	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -66,35 +68,39 @@ type GetRunInfoPropsPartial struct {
 
 // GetRunInfoResponse represents the response struct for GetRunInfoResponse endpoint
 type GetRunInfoResponse struct {
-	Params GetRunInfoParamsPartial `json:"params"`
-	Traces GetRunInfoTracesPartial `json:"traces"`
-	Props  GetRunInfoPropsPartial  `json:"props"`
+	Params GetRunInfoParamsPartial  `json:"params,omitempty"`
+	Traces *GetRunInfoTracesPartial `json:"traces,omitempty"`
+	Props  *GetRunInfoPropsPartial  `json:"props,omitempty"`
 }
 
 // NewGetRunInfoResponse creates new response object for `GER runs/:id/info` endpoint.
-func NewGetRunInfoResponse(run *models.Run) *GetRunInfoResponse {
-	metrics := make([]GetRunInfoTracesMetricPartial, len(run.LatestMetrics))
-	for i, metric := range run.LatestMetrics {
-		metrics[i] = GetRunInfoTracesMetricPartial{
-			Name:      metric.Key,
-			Context:   json.RawMessage(metric.Context.Json),
-			LastValue: 0.1,
+// Sections, which haven't been requested, are omitted.
+func NewGetRunInfoResponse(run *models.Run, fields commonModels.ResponseFields) *GetRunInfoResponse {
+	resp := GetRunInfoResponse{}
+	if fields.Has(commonModels.ResponseFieldParams) || fields.Has(commonModels.ResponseFieldTags) {
+		resp.Params = make(GetRunInfoParamsPartial, len(run.Params)+1)
+		for _, p := range run.Params {
+			resp.Params[p.Key] = p.Value
+		}
+		if fields.Has(commonModels.ResponseFieldTags) {
+			tags := make(GetRunInfoParamsPartial, len(run.Tags))
+			for _, t := range run.Tags {
+				tags[t.Key] = t.Value
+			}
+			resp.Params["tags"] = tags
 		}
 	}
 
-	params := make(GetRunInfoParamsPartial, len(run.Params)+1)
-	for _, p := range run.Params {
-		params[p.Key] = p.Value
-	}
-	tags := make(GetRunInfoParamsPartial, len(run.Tags))
-	for _, t := range run.Tags {
-		tags[t.Key] = t.Value
-	}
-	params["tags"] = tags
-
-	return &GetRunInfoResponse{
-		Params: params,
-		Traces: GetRunInfoTracesPartial{
+	if fields.Has(commonModels.ResponseFieldMetrics) {
+		metrics := make([]GetRunInfoTracesMetricPartial, len(run.LatestMetrics))
+		for i, metric := range run.LatestMetrics {
+			metrics[i] = GetRunInfoTracesMetricPartial{
+				Name:      metric.Key,
+				Context:   json.RawMessage(metric.Context.Json),
+				LastValue: 0.1,
+			}
+		}
+		resp.Traces = &GetRunInfoTracesPartial{
 			Tags:          map[string]string{},
 			Logs:          map[string]string{},
 			Texts:         map[string]string{},
@@ -104,8 +110,11 @@ func NewGetRunInfoResponse(run *models.Run) *GetRunInfoResponse {
 			Figures:       map[string]string{},
 			LogRecords:    map[string]string{},
 			Distributions: map[string]string{},
-		},
-		Props: GetRunInfoPropsPartial{
+		}
+	}
+
+	if fields.Has(commonModels.ResponseFieldSummary) {
+		resp.Props = &GetRunInfoPropsPartial{
 			Name: run.Name,
 			Experiment: GetRunInfoExperimentPartial{
 				ID:   fmt.Sprintf("%d", *run.Experiment.ID),
@@ -116,27 +125,30 @@ func NewGetRunInfoResponse(run *models.Run) *GetRunInfoResponse {
 			EndTime:      float64(run.EndTime.Int64) / 1000,
 			Archived:     run.LifecycleStage == models.LifecycleStageDeleted,
 			Active:       run.Status == models.StatusRunning,
-		},
+		}
 	}
+	return &resp
 }
 
 // GetRunMetricsResponse is a response object to hold response data for `GET /runs/:id/metric/get-batch` endpoint.
 type GetRunMetricsResponse struct {
-	Name    string          `json:"name"`
-	Iters   []int           `json:"iters"`
-	Values  []*float64      `json:"values"`
-	Context json.RawMessage `json:"context"`
+	Name    string             `json:"name"`
+	Iters   []int              `json:"iters"`
+	Values  []*api.MetricValue `json:"values"`
+	Context json.RawMessage    `json:"context"`
 }
 
 // NewGetRunMetricsResponse creates new response object for `GET /runs/:id/metric/get-batch` endpoint.
-func NewGetRunMetricsResponse(metrics []models.Metric, metricKeysMap models.MetricKeysMap) []GetRunMetricsResponse {
+func NewGetRunMetricsResponse(
+	metrics []models.Metric, metricKeysMap models.MetricKeysMap, metricValuePrecision int,
+) []GetRunMetricsResponse {
 	data := make(map[models.MetricKeysItem]struct {
 		iters  []int
-		values []*float64
+		values []*api.MetricValue
 	}, len(metricKeysMap))
 
 	for _, item := range metrics {
-		v := common.GetPointer(item.Value)
+		v := common.GetPointer(api.NewMetricValue(item.Value, metricValuePrecision))
 		if item.IsNan {
 			v = nil
 		}
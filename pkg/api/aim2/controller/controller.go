@@ -7,10 +7,12 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/services/project"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/services/run"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/services/tag"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 // Controller handles all the input HTTP requests.
 type Controller struct {
+	config            *config.Config
 	tagService        *tag.Service
 	appService        *app.Service
 	runService        *run.Service
@@ -21,6 +23,7 @@ type Controller struct {
 
 // NewController creates new Controller instance.
 func NewController(
+	config *config.Config,
 	tagService *tag.Service,
 	appService *app.Service,
 	runService *run.Service,
@@ -29,6 +32,7 @@ func NewController(
 	experimentService *experiment.Service,
 ) *Controller {
 	return &Controller{
+		config:            config,
 		tagService:        tagService,
 		appService:        appService,
 		runService:        runService,
@@ -9,6 +9,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 )
 
@@ -20,7 +21,7 @@ func (c Controller) GetExperiments(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getExperiments namespace: %s", ns.Code)
 
-	experiments, err := c.experimentService.GetExperiments(ctx.Context(), ns.ID)
+	experiments, err := c.experimentService.GetExperiments(ctx.UserContext(), ns.ID)
 	if err != nil {
 		return err
 	}
@@ -28,6 +29,10 @@ func (c Controller) GetExperiments(ctx *fiber.Ctx) error {
 	resp := response.NewGetExperimentsResponse(experiments)
 	log.Debugf("getExperiments response: %#v", resp)
 
+	if api.IsPaginatedResponseAccepted(ctx) {
+		total := int64(len(resp))
+		return api.SendPaginatedResponse(ctx, api.NewPaginatedResponse(resp, "", &total))
+	}
 	return ctx.JSON(resp)
 }
 
@@ -43,13 +48,16 @@ func (c Controller) GetExperiment(ctx *fiber.Ctx) error {
 	if err = ctx.ParamsParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
+	if err = ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
 
-	experiment, err := c.experimentService.GetExperiment(ctx.Context(), ns.ID, &req)
+	experiment, err := c.experimentService.GetExperiment(ctx.UserContext(), ns.ID, &req)
 	if err != nil {
 		return err
 	}
 
-	resp := response.NewGetExperimentResponse(experiment)
+	resp := response.NewGetExperimentResponse(experiment, models.NewResponseFields(req.Fields))
 	log.Debugf("getExperiment response: %#v", resp)
 
 	return ctx.JSON(resp)
@@ -72,7 +80,7 @@ func (c Controller) GetExperimentRuns(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	runs, err := c.experimentService.GetExperimentRuns(ctx.Context(), ns.ID, &req)
+	runs, err := c.experimentService.GetExperimentRuns(ctx.UserContext(), ns.ID, &req)
 	if err != nil {
 		return err
 	}
@@ -80,6 +88,15 @@ func (c Controller) GetExperimentRuns(ctx *fiber.Ctx) error {
 	resp := response.NewGetExperimentRunsResponse(req.ID, runs)
 	log.Debugf("getExperimentRuns response: %#v", resp)
 
+	ctx.Set(api.PageSizeHeader, strconv.Itoa(req.Limit))
+	if api.IsPaginatedResponseAccepted(ctx) {
+		// runs are paginated by ID of the last run of the previous page.
+		var nextToken string
+		if req.Limit > 0 && len(runs) == req.Limit {
+			nextToken = runs[len(runs)-1].ID
+		}
+		return api.SendPaginatedResponse(ctx, api.NewPaginatedResponse(resp.Runs, nextToken, nil))
+	}
 	return ctx.JSON(resp)
 }
 
@@ -101,7 +118,7 @@ func (c Controller) GetExperimentActivity(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	activity, err := c.experimentService.GetExperimentActivity(ctx.Context(), ns.ID, &req, tzOffset)
+	activity, err := c.experimentService.GetExperimentActivity(ctx.UserContext(), ns.ID, &req, tzOffset)
 	if err != nil {
 		return err
 	}
@@ -125,7 +142,7 @@ func (c Controller) DeleteExperiment(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	if err := c.experimentService.DeleteExperiment(ctx.Context(), ns.ID, ns.DefaultExperimentID, &req); err != nil {
+	if err := c.experimentService.DeleteExperiment(ctx.UserContext(), ns.ID, ns.DefaultExperimentID, &req); err != nil {
 		return err
 	}
 
@@ -151,7 +168,7 @@ func (c Controller) UpdateExperiment(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	if err := c.experimentService.UpdateExperiment(ctx.Context(), ns.ID, &req); err != nil {
+	if err := c.experimentService.UpdateExperiment(ctx.UserContext(), ns.ID, &req); err != nil {
 		return err
 	}
 
@@ -18,9 +18,9 @@ func (c Controller) GetProject(ctx *fiber.Ctx) error {
 	if err != nil {
 		return api.NewInternalError("error getting namespace from context")
 	}
-	log.Debugf("getProjectActivity namespace: %s", ns.Code)
+	log.Debugf("getProject namespace: %s", ns.Code)
 
-	name, dialector, liveUpdatesEnabled := c.projectService.GetProjectInformation()
+	name, dialector, liveUpdatesEnabled := c.projectService.GetProjectInformation(ns.Code)
 	return ctx.JSON(response.NewGetProjectResponse(name, dialector, liveUpdatesEnabled))
 }
 
@@ -37,7 +37,7 @@ func (c Controller) GetProjectActivity(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, "x-timezone-offset header is not a valid integer")
 	}
 
-	activity, err := c.projectService.GetProjectActivity(ctx.Context(), ns.ID, tzOffset)
+	activity, err := c.projectService.GetProjectActivity(ctx.UserContext(), ns.ID, tzOffset)
 	if err != nil {
 		return err
 	}
@@ -75,7 +75,7 @@ func (c Controller) GetProjectParams(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	params, err := c.projectService.GetProjectParams(ctx.Context(), ns.ID, &req)
+	params, err := c.projectService.GetProjectParams(ctx.UserContext(), ns.ID, &req)
 	if err != nil {
 		return err
 	}
@@ -89,6 +89,30 @@ func (c Controller) GetProjectParams(ctx *fiber.Ctx) error {
 	return ctx.JSON(resp)
 }
 
+// GetProjectParamValues handles `GET /projects/params/values` endpoint.
+func (c Controller) GetProjectParamValues(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getProjectParamValues namespace: %s", ns.Code)
+
+	req := request.GetProjectParamValuesRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	values, err := c.projectService.GetProjectParamValues(ctx.UserContext(), ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewProjectParamValuesResponse(req.Key, values)
+	log.Debugf("getProjectParamValues response: %#v", resp)
+
+	return ctx.JSON(resp)
+}
+
 // GetProjectStatus handles `PUT /projects/status` endpoint.
 func (c Controller) GetProjectStatus(ctx *fiber.Ctx) error {
 	return ctx.JSON("up-to-date")
@@ -10,6 +10,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/services/run"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 )
 
@@ -29,12 +30,12 @@ func (c Controller) GetRunInfo(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	runInfo, err := c.runService.GetRunInfo(ctx.Context(), ns.ID, &req)
+	runInfo, err := c.runService.GetRunInfo(ctx.UserContext(), ns.ID, &req)
 	if err != nil {
 		return err
 	}
 
-	resp := response.NewGetRunInfoResponse(runInfo)
+	resp := response.NewGetRunInfoResponse(runInfo, models.NewResponseFields(req.Fields))
 	log.Debugf("getRunInfo response: %#v", resp)
 	return ctx.JSON(resp)
 }
@@ -52,12 +53,12 @@ func (c Controller) GetRunMetrics(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	metrics, metricKeysMap, err := c.runService.GetRunMetrics(ctx.Context(), ns.ID, ctx.Params("id"), &req)
+	metrics, metricKeysMap, err := c.runService.GetRunMetrics(ctx.UserContext(), ns.ID, ctx.Params("id"), &req)
 	if err != nil {
 		return err
 	}
 
-	resp := response.NewGetRunMetricsResponse(metrics, metricKeysMap)
+	resp := response.NewGetRunMetricsResponse(metrics, metricKeysMap, c.config.MetricValuePrecision)
 	log.Debugf("getRunMetrics response: %#v", resp)
 	return ctx.JSON(resp)
 }
@@ -79,7 +80,7 @@ func (c Controller) GetRunsActive(ctx *fiber.Ctx) error {
 		req.ReportProgress = true
 	}
 
-	runs, err := c.runService.GetRunsActive(ctx.Context(), ns.ID, &req)
+	runs, err := c.runService.GetRunsActive(ctx.UserContext(), ns.ID, &req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -110,11 +111,12 @@ func (c Controller) SearchRuns(ctx *fiber.Ctx) error {
 	}
 
 	// Search runs
-	runs, total, err := c.runService.SearchRuns(ctx.Context(), ns.ID, tzOffset, req)
+	runs, total, err := c.runService.SearchRuns(ctx.UserContext(), ns.ID, tzOffset, &req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 	log.Debugf("found %d runs", len(runs))
+	ctx.Set(api.PageSizeHeader, strconv.Itoa(req.Limit))
 
 	// Choose response
 	switch req.Action {
@@ -153,7 +155,7 @@ func (c Controller) SearchMetrics(ctx *fiber.Ctx) error {
 	}
 
 	//nolint:rowserrcheck
-	rows, totalRuns, result, err := c.runService.SearchMetrics(ctx.Context(), ns.ID, tzOffset, req)
+	rows, totalRuns, result, err := c.runService.SearchMetrics(ctx.UserContext(), ns.ID, tzOffset, req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -176,7 +178,7 @@ func (c Controller) SearchAlignedMetrics(ctx *fiber.Ctx) error {
 	}
 
 	//nolint:rowserrcheck
-	rows, next, capacity, err := c.runService.SearchAlignedMetrics(ctx.Context(), ns.ID, &req)
+	rows, next, capacity, err := c.runService.SearchAlignedMetrics(ctx.UserContext(), ns.ID, &req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -185,6 +187,73 @@ func (c Controller) SearchAlignedMetrics(ctx *fiber.Ctx) error {
 	return nil
 }
 
+// AggregateMetrics handles `POST /runs/search/metric/aggregate` endpoint.
+func (c Controller) AggregateMetrics(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("aggregateMetrics namespace: %s", ns.Code)
+
+	req := request.AggregateMetricsRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	//nolint:rowserrcheck
+	rows, next, err := c.runService.AggregateMetrics(ctx.UserContext(), ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	response.NewAggregateMetricsStreamResponse(ctx, rows, next, req.Percentiles, c.config.MetricValuePrecision)
+	return nil
+}
+
+// JoinMetrics handles `POST /runs/search/metric/join` endpoint.
+func (c Controller) JoinMetrics(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("joinMetrics namespace: %s", ns.Code)
+
+	req := request.JoinMetricsRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	//nolint:rowserrcheck
+	rows, next, err := c.runService.JoinMetrics(ctx.UserContext(), ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	response.NewJoinMetricsStreamResponse(ctx, rows, next, c.config.MetricValuePrecision)
+	return nil
+}
+
+// GetRunsMetrics handles `POST /runs/search/metric/batch` endpoint.
+func (c Controller) GetRunsMetrics(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getRunsMetrics namespace: %s", ns.Code)
+
+	req := request.GetRunsMetricsRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	runIDs, metrics, err := c.runService.GetRunsMetrics(ctx.UserContext(), ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(response.NewGetRunsMetricsResponse(runIDs, metrics, c.config.MetricValuePrecision))
+}
+
 // DeleteRun handles `DELETE /runs/:id` endpoint.
 func (c Controller) DeleteRun(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -198,7 +267,7 @@ func (c Controller) DeleteRun(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	if err := c.runService.DeleteRun(ctx.Context(), ns.ID, &req); err != nil {
+	if err := c.runService.DeleteRun(ctx.UserContext(), ns.ID, &req); err != nil {
 		return err
 	}
 
@@ -222,7 +291,7 @@ func (c Controller) UpdateRun(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	if err := c.runService.UpdateRun(ctx.Context(), ns.ID, &req); err != nil {
+	if err := c.runService.UpdateRun(ctx.UserContext(), ns.ID, &req); err != nil {
 		return err
 	}
 
@@ -247,7 +316,7 @@ func (c Controller) ArchiveBatch(ctx *fiber.Ctx) error {
 		action = run.BatchActionArchive
 	}
 
-	if err := c.runService.ProcessBatch(ctx.Context(), ns.ID, action, req); err != nil {
+	if err := c.runService.ProcessBatch(ctx.UserContext(), ns.ID, action, req); err != nil {
 		return err
 	}
 
@@ -267,7 +336,7 @@ func (c Controller) DeleteBatch(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
 
-	if err := c.runService.ProcessBatch(ctx.Context(), ns.ID, run.BatchActionDelete, req); err != nil {
+	if err := c.runService.ProcessBatch(ctx.UserContext(), ns.ID, run.BatchActionDelete, req); err != nil {
 		return err
 	}
 
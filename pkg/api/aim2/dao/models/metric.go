@@ -38,6 +38,29 @@ func (m AlignedMetric) TableName() string {
 	return "metrics"
 }
 
+// MetricAggregate represents metric values of a single step aggregated across several runs.
+type MetricAggregate struct {
+	Step        int64
+	Count       int
+	Mean        float64
+	Min         float64
+	Max         float64
+	Percentiles []float64
+}
+
+// JoinedMetricPoint represents values of two metrics logged by a run at the same step.
+type JoinedMetricPoint struct {
+	Step int64
+	X    float64
+	Y    float64
+}
+
+// JoinedMetrics represents two metric series of a single run joined on step.
+type JoinedMetrics struct {
+	RunID  string
+	Points []JoinedMetricPoint
+}
+
 // LatestMetric represents model to work with `last_metrics` table.
 type LatestMetric struct {
 	Key       string  `gorm:"type:varchar(250);not null;primaryKey"`
@@ -76,3 +99,9 @@ type MetricKeysItem struct {
 
 // MetricKeysMap represents map with composite keys.
 type MetricKeysMap map[MetricKeysItem]any
+
+// MetricSeriesKey represents key and context of a single metric series.
+type MetricSeriesKey struct {
+	Key       string
+	ContextID uint
+}
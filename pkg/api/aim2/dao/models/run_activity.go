@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// RunActivityBucketSize is a size of time bucket, in milliseconds, the runs are summarized by.
+const RunActivityBucketSize int64 = 60 * 60 * 1000
+
+// RunActivity represents model to work with `run_activities` table,
+// which summarizes runs of a namespace, started within the same bucket.
+type RunActivity struct {
+	NamespaceID     uint  `gorm:"not null;primaryKey"`
+	Bucket          int64 `gorm:"not null;primaryKey"`
+	NumRuns         int64 `gorm:"not null"`
+	NumActiveRuns   int64 `gorm:"not null"`
+	NumArchivedRuns int64 `gorm:"not null"`
+}
+
+// GetStartTime returns start time of the bucket.
+func (a RunActivity) GetStartTime() time.Time {
+	return time.UnixMilli(a.Bucket * RunActivityBucketSize)
+}
+
+// GetRunActivityBucket returns bucket of the run start time.
+func GetRunActivityBucket(startTime int64) int64 {
+	return startTime / RunActivityBucketSize
+}
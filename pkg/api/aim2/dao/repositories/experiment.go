@@ -13,6 +13,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/common"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -36,9 +37,11 @@ type ExperimentRepositoryProvider interface {
 	) (*models.Experiment, error)
 	// GetCountOfActiveExperiments returns count of active experiments.
 	GetCountOfActiveExperiments(ctx context.Context, namespaceID uint) (int64, error)
+	// GetNamespaceIDByExperimentID returns Namespace ID of experiment by Experiment ID.
+	GetNamespaceIDByExperimentID(ctx context.Context, experimentID int32) (uint, error)
 	// GetExtendedExperimentByNamespaceIDAndExperimentID returns extended experiment by Namespace ID and Experiment ID.
 	GetExtendedExperimentByNamespaceIDAndExperimentID(
-		ctx context.Context, namespaceID uint, experimentID int32,
+		ctx context.Context, namespaceID uint, experimentID int32, fields commonModels.ResponseFields,
 	) (*models.ExperimentExtended, error)
 }
 
@@ -281,26 +284,49 @@ func (r ExperimentRepository) GetCountOfActiveExperiments(ctx context.Context, n
 	return count, nil
 }
 
+// GetNamespaceIDByExperimentID returns Namespace ID of experiment by Experiment ID.
+func (r ExperimentRepository) GetNamespaceIDByExperimentID(ctx context.Context, experimentID int32) (uint, error) {
+	var experiment models.Experiment
+	if err := r.db.WithContext(ctx).Select(
+		"namespace_id",
+	).Where(
+		"experiment_id = ?", experimentID,
+	).First(
+		&experiment,
+	).Error; err != nil {
+		return 0, eris.Wrapf(err, "error getting experiment with id: %d", experimentID)
+	}
+	return experiment.NamespaceID, nil
+}
+
 // GetExtendedExperimentByNamespaceIDAndExperimentID returns experiment by Namespace ID and Experiment ID.
+// Run count and description are calculated only when `summary` and `tags` sections have been requested.
 // TODO:dsuhinin this moment needs to be discussed.
 func (r ExperimentRepository) GetExtendedExperimentByNamespaceIDAndExperimentID(
-	ctx context.Context, namespaceID uint, experimentID int32,
+	ctx context.Context, namespaceID uint, experimentID int32, fields commonModels.ResponseFields,
 ) (*models.ExperimentExtended, error) {
-	var experiment models.ExperimentExtended
-	if err := r.db.WithContext(ctx).Model(
-		&models.ExperimentExtended{},
-	).Select(
+	columns := []string{
 		"experiments.experiment_id",
 		"experiments.name",
 		"experiments.lifecycle_stage",
 		"experiments.creation_time",
-		"COUNT(runs.run_uuid) AS run_count",
-		"COALESCE(MAX(experiment_tags.value), '') AS description",
-	).Joins(
-		"LEFT JOIN runs USING(experiment_id)",
-	).Joins(
-		"LEFT JOIN experiment_tags ON experiments.experiment_id = experiment_tags.experiment_id AND"+
-			" experiment_tags.key = ?", common.DescriptionTagKey,
+	}
+	query := r.db.WithContext(ctx).Model(&models.ExperimentExtended{})
+	if fields.Has(commonModels.ResponseFieldSummary) {
+		columns = append(columns, "COUNT(runs.run_uuid) AS run_count")
+		query = query.Joins("LEFT JOIN runs USING(experiment_id)")
+	}
+	if fields.Has(commonModels.ResponseFieldTags) {
+		columns = append(columns, "COALESCE(MAX(experiment_tags.value), '') AS description")
+		query = query.Joins(
+			"LEFT JOIN experiment_tags ON experiments.experiment_id = experiment_tags.experiment_id AND"+
+				" experiment_tags.key = ?", common.DescriptionTagKey,
+		)
+	}
+
+	var experiment models.ExperimentExtended
+	if err := query.Select(
+		columns,
 	).Where(
 		"experiments.namespace_id = ?", namespaceID,
 	).Where(
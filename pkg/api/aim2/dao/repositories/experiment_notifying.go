@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// ExperimentNotifyingRepository repository to work with `experiment` entity, which triggers database event
+// each time runs of the experiment are changed.
+type ExperimentNotifyingRepository struct {
+	ExperimentRepositoryProvider
+	db               *gorm.DB
+	runEventListener dao.EventListenerProvider
+}
+
+// NewExperimentNotifyingRepository creates new instance of repository to work with `experiment` entity,
+// which triggers database event each time runs of the experiment are changed.
+func NewExperimentNotifyingRepository(
+	db *gorm.DB, runEventListener dao.EventListenerProvider,
+) *ExperimentNotifyingRepository {
+	return &ExperimentNotifyingRepository{
+		ExperimentRepositoryProvider: NewExperimentRepository(db),
+		db:                           db,
+		runEventListener:             runEventListener,
+	}
+}
+
+// Update updates existing experiment.
+func (r ExperimentNotifyingRepository) Update(ctx context.Context, experiment *models.Experiment) error {
+	if err := r.ExperimentRepositoryProvider.Update(ctx, experiment); err != nil {
+		return err
+	}
+	// runs are archived together with the experiment.
+	if experiment.LifecycleStage != models.LifecycleStageDeleted {
+		return nil
+	}
+	return sendRunEvent(ctx, r.runEventListener, r.db, events.RunEvent{
+		NamespaceID: experiment.NamespaceID,
+	})
+}
+
+// Delete deletes existing experiment.
+func (r ExperimentNotifyingRepository) Delete(ctx context.Context, experiment *models.Experiment) error {
+	if err := r.ExperimentRepositoryProvider.Delete(ctx, experiment); err != nil {
+		return err
+	}
+	// runs are removed together with the experiment.
+	return sendRunEvent(ctx, r.runEventListener, r.db, events.RunEvent{
+		NamespaceID: experiment.NamespaceID,
+	})
+}
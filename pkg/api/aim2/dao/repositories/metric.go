@@ -30,9 +30,10 @@ type SearchResultMap = map[string]SearchResult
 // MetricRepositoryProvider provides an interface to work with models.Metric entity.
 type MetricRepositoryProvider interface {
 	repositories.BaseRepositoryProvider
-	// GetMetricKeysAndContextsByExperiments returns metric keys and contexts by provided experiments.
+	// GetMetricKeysAndContextsByExperiments returns metric keys and contexts by provided experiments
+	// and optional context filter.
 	GetMetricKeysAndContextsByExperiments(
-		ctx context.Context, namespaceID uint, experimentNames []string,
+		ctx context.Context, namespaceID uint, experimentNames []string, metricContext types.JSONB,
 	) ([]models.LatestMetric, error)
 	// SearchMetrics returns a sql.Rows cursor for streaming the metrics matching the request.
 	SearchMetrics(
@@ -42,6 +43,25 @@ type MetricRepositoryProvider interface {
 	GetContextListByContextObjects(
 		ctx context.Context, contextsMap map[string]types.JSONB,
 	) ([]models.Context, error)
+	// GetMetricHistoriesByRunIDs returns a sql.Rows cursor for streaming the metric histories of provided runs.
+	GetMetricHistoriesByRunIDs(
+		ctx context.Context, namespaceID uint, runIDs []string, key string, contextID uint,
+	) (*sql.Rows, func(*sql.Rows) (*models.Metric, error), error)
+	// GetMetricHistoriesByRunIDsAndKeys returns a sql.Rows cursor for streaming the histories
+	// of several metrics of provided runs.
+	GetMetricHistoriesByRunIDsAndKeys(
+		ctx context.Context, namespaceID uint, runIDs []string, keys []string, contextID uint,
+	) (*sql.Rows, func(*sql.Rows) (*models.Metric, error), error)
+	// GetSampledMetricHistoriesByRunIDs returns the histories of several metric series of provided runs
+	// narrowed down to the requested window and downsampled to the requested number of steps.
+	GetSampledMetricHistoriesByRunIDs(
+		ctx context.Context,
+		namespaceID uint,
+		runIDs []string,
+		series []models.MetricSeriesKey,
+		window request.MetricHistoryWindow,
+		steps int,
+	) ([]models.AlignedMetric, error)
 }
 
 // MetricRepository repository to work with models.Metric entity.
@@ -56,9 +76,10 @@ func NewMetricRepository(db *gorm.DB) *MetricRepository {
 	}
 }
 
-// GetMetricKeysAndContextsByExperiments returns metric keys and contexts by provided experiments.
+// GetMetricKeysAndContextsByExperiments returns metric keys and contexts by provided experiments
+// and optional context filter. Empty metricContext means that metrics of all the contexts are returned.
 func (r MetricRepository) GetMetricKeysAndContextsByExperiments(
-	ctx context.Context, namespaceID uint, experimentNames []string,
+	ctx context.Context, namespaceID uint, experimentNames []string, metricContext types.JSONB,
 ) ([]models.LatestMetric, error) {
 	query := r.GetDB().WithContext(ctx).Distinct().Select(
 		"key", "context_id",
@@ -77,6 +98,13 @@ func (r MetricRepository) GetMetricKeysAndContextsByExperiments(
 	if len(experimentNames) != 0 {
 		query = query.Where("experiments.name IN ?", experimentNames)
 	}
+	if len(metricContext) != 0 {
+		query = query.Joins(
+			"INNER JOIN contexts ON contexts.id = latest_metrics.context_id",
+		).Where(
+			"contexts.json = ?", metricContext,
+		)
+	}
 	var metrics []models.LatestMetric
 	if err := query.Find(&metrics).Error; err != nil {
 		return nil, eris.Wrap(err, "error getting metrics by provided experiments")
@@ -253,6 +281,202 @@ func (r MetricRepository) GetContextListByContextObjects(
 	return contexts, nil
 }
 
+// GetMetricHistoriesByRunIDs returns a sql.Rows cursor for streaming the metric histories of provided runs.
+// Metrics are ordered by step, so the histories of different runs are aligned by step.
+func (r MetricRepository) GetMetricHistoriesByRunIDs(
+	ctx context.Context, namespaceID uint, runIDs []string, key string, contextID uint,
+) (*sql.Rows, func(*sql.Rows) (*models.Metric, error), error) {
+	rows, err := r.GetDB().WithContext(ctx).Select(
+		"metrics.run_uuid", "metrics.step", "metrics.iter", "metrics.value", "metrics.is_nan",
+	).Model(
+		&models.Metric{},
+	).Joins(
+		"INNER JOIN runs ON runs.run_uuid = metrics.run_uuid",
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Where(
+		"metrics.run_uuid IN ?", runIDs,
+	).Where(
+		"metrics.key = ?", key,
+	).Where(
+		"metrics.context_id = ?", contextID,
+	).Order(
+		"metrics.step",
+	).Order(
+		"metrics.run_uuid",
+	).Order(
+		"metrics.iter",
+	).Rows()
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "error getting metric histories")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, eris.Wrap(err, "error getting metric histories rows cursor")
+	}
+	return rows, func(rows *sql.Rows) (*models.Metric, error) {
+		var metric models.Metric
+		if err := r.GetDB().ScanRows(rows, &metric); err != nil {
+			return nil, eris.Wrap(err, "error getting metric")
+		}
+		return &metric, nil
+	}, nil
+}
+
+// GetMetricHistoriesByRunIDsAndKeys returns a sql.Rows cursor for streaming the histories
+// of several metrics of provided runs. Metrics are ordered by run and step, so all the metrics
+// of a single run are returned one after another.
+func (r MetricRepository) GetMetricHistoriesByRunIDsAndKeys(
+	ctx context.Context, namespaceID uint, runIDs []string, keys []string, contextID uint,
+) (*sql.Rows, func(*sql.Rows) (*models.Metric, error), error) {
+	rows, err := r.GetDB().WithContext(ctx).Select(
+		"metrics.run_uuid", "metrics.key", "metrics.step", "metrics.iter", "metrics.value", "metrics.is_nan",
+	).Model(
+		&models.Metric{},
+	).Joins(
+		"INNER JOIN runs ON runs.run_uuid = metrics.run_uuid",
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Where(
+		"metrics.run_uuid IN ?", runIDs,
+	).Where(
+		"metrics.key IN ?", keys,
+	).Where(
+		"metrics.context_id = ?", contextID,
+	).Order(
+		"metrics.run_uuid",
+	).Order(
+		"metrics.step",
+	).Order(
+		"metrics.iter",
+	).Rows()
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "error getting metric histories")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, eris.Wrap(err, "error getting metric histories rows cursor")
+	}
+	return rows, func(rows *sql.Rows) (*models.Metric, error) {
+		var metric models.Metric
+		if err := r.GetDB().ScanRows(rows, &metric); err != nil {
+			return nil, eris.Wrap(err, "error getting metric")
+		}
+		return &metric, nil
+	}, nil
+}
+
+// GetSampledMetricHistoriesByRunIDs returns the histories of several metric series of provided runs
+// downsampled to the requested number of steps. All the series are fetched with a single query and
+// ordered by run, key, context and iter, so the points of each series are returned one after another.
+// When window is provided, only the points inside of it are sampled, so the requested number of steps
+// is spread over the window instead of the whole series.
+func (r MetricRepository) GetSampledMetricHistoriesByRunIDs(
+	ctx context.Context,
+	namespaceID uint,
+	runIDs []string,
+	series []models.MetricSeriesKey,
+	window request.MetricHistoryWindow,
+	steps int,
+) ([]models.AlignedMetric, error) {
+	seriesCondition := r.GetDB().WithContext(ctx)
+	for _, item := range series {
+		seriesCondition = seriesCondition.Or("metrics.key = ? AND metrics.context_id = ?", item.Key, item.ContextID)
+	}
+
+	// bounds of each series are taken from latest metrics, unless window is requested. in that case
+	// they are calculated from the points inside of the window.
+	bounds := r.GetDB().WithContext(ctx).Select(
+		"run_uuid", "key", "context_id", "0 AS first_iter", "last_iter",
+	).Table(
+		"latest_metrics",
+	)
+	if !window.IsEmpty() {
+		bounds = applyMetricHistoryWindow(
+			r.GetDB().WithContext(ctx).Select(
+				"run_uuid", "key", "context_id", "MIN(iter) AS first_iter", "MAX(iter) AS last_iter",
+			).Table(
+				"metrics",
+			).Where(
+				"metrics.run_uuid IN ?", runIDs,
+			).Where(
+				seriesCondition,
+			).Group(
+				"run_uuid",
+			).Group(
+				"key",
+			).Group(
+				"context_id",
+			),
+			window,
+		)
+	}
+
+	var metrics []models.AlignedMetric
+	if err := applyMetricHistoryWindow(
+		r.GetDB().WithContext(ctx).Select(
+			"metrics.run_uuid",
+			"metrics.key",
+			"metrics.context_id",
+			"metrics.step",
+			"metrics.iter",
+			"metrics.value",
+			"metrics.is_nan",
+			"contexts.json AS context_json",
+		).Table(
+			"metrics",
+		).Joins(
+			"INNER JOIN (?) AS bounds USING(run_uuid, key, context_id)", bounds,
+		).Joins(
+			"INNER JOIN contexts ON contexts.id = metrics.context_id",
+		).Joins(
+			"INNER JOIN runs ON runs.run_uuid = metrics.run_uuid",
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+			namespaceID,
+		).Where(
+			"metrics.run_uuid IN ?", runIDs,
+		).Where(
+			seriesCondition,
+		).Where(
+			fmt.Sprintf(
+				"MOD(metrics.iter - bounds.first_iter + 1 + ((bounds.last_iter - bounds.first_iter + 1) / %[1]f) / 2, "+
+					"(bounds.last_iter - bounds.first_iter + 1) / %[1]f) < 1",
+				float32(steps),
+			),
+		).Order(
+			"metrics.run_uuid",
+		).Order(
+			"metrics.key",
+		).Order(
+			"metrics.context_id",
+		).Order(
+			"metrics.iter",
+		),
+		window,
+	).Find(&metrics).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting sampled metric histories")
+	}
+	return metrics, nil
+}
+
+// applyMetricHistoryWindow narrows down metrics query to the requested range of steps and timestamps.
+func applyMetricHistoryWindow(query *gorm.DB, window request.MetricHistoryWindow) *gorm.DB {
+	if window.MinStep != nil {
+		query = query.Where("metrics.step >= ?", *window.MinStep)
+	}
+	if window.MaxStep != nil {
+		query = query.Where("metrics.step <= ?", *window.MaxStep)
+	}
+	if window.StartTime != nil {
+		query = query.Where("metrics.timestamp >= ?", *window.StartTime)
+	}
+	if window.EndTime != nil {
+		query = query.Where("metrics.timestamp <= ?", *window.EndTime)
+	}
+	return query
+}
+
 func (r MetricRepository) findContextIDs(ctx context.Context, req *request.SearchMetricsRequest) ([]uint, error) {
 	contextList := []types.JSONB{}
 	contextsMap := map[string]types.JSONB{}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestMetricRepository_GetMetricHistoriesByRunIDs_ContextCancelled(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	//nolint:errcheck
+	defer mockDb.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDb,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.Nil(t, err)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "metrics"`).WillReturnRows(
+		sqlmock.NewRows(
+			[]string{"run_uuid", "step", "iter", "value", "is_nan"},
+		).AddRow(
+			"run1", 0, 0, 1.1, false,
+		).AddRow(
+			"run1", 1, 1, 1.2, false,
+		).AddRow(
+			"run1", 2, 2, 1.3, false,
+		),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//nolint:rowserrcheck
+	rows, next, err := NewMetricRepository(db).GetMetricHistoriesByRunIDs(ctx, 1, []string{"run1"}, "loss", 1)
+	require.Nil(t, err)
+	//nolint:errcheck
+	defer rows.Close()
+
+	require.True(t, rows.Next())
+	metric, err := next(rows)
+	require.Nil(t, err)
+	assert.Equal(t, 1.1, metric.Value)
+
+	// cancelling the context in the middle of the scan closes the cursor, so the rest of rows is not read.
+	cancel()
+	assert.Eventually(t, func() bool {
+		return rows.Err() != nil
+	}, time.Second, time.Millisecond)
+	assert.ErrorIs(t, rows.Err(), context.Canceled)
+	assert.False(t, rows.Next())
+}
@@ -2,6 +2,9 @@ package repositories
 
 import (
 	"context"
+	"math"
+	"slices"
+	"strconv"
 
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
@@ -10,10 +13,15 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
 )
 
+// ParamValuesLimit is a maximum number of distinct param values returned by GetParamValuesByKey.
+const ParamValuesLimit = 1000
+
 // ParamRepositoryProvider provides an interface to work with models.Param entity.
 type ParamRepositoryProvider interface {
 	// GetParamKeysByParameters returns list of param keys by requested parameters.
 	GetParamKeysByParameters(ctx context.Context, namespaceID uint, experimentNames []string) ([]string, error)
+	// GetParamValuesByKey returns list of distinct values of the param with key.
+	GetParamValuesByKey(ctx context.Context, namespaceID uint, key string) ([]string, error)
 }
 
 // ParamRepository repository to work with models.Param entity.
@@ -51,3 +59,55 @@ func (r ParamRepository) GetParamKeysByParameters(
 	}
 	return keys, nil
 }
+
+// GetParamValuesByKey returns list of distinct values of the param with key among the active runs of
+// the namespace. Values are ordered numerically, when all of them are numbers, and lexicographically
+// otherwise. Number of values is capped by ParamValuesLimit only after ordering, so all the values are
+// fetched to pick the smallest numbers rather than the lexicographically first ones.
+func (r ParamRepository) GetParamValuesByKey(ctx context.Context, namespaceID uint, key string) ([]string, error) {
+	var values []string
+	if err := r.GetDB().WithContext(ctx).Distinct().Model(
+		&models.Param{},
+	).Joins(
+		"JOIN runs USING(run_uuid)",
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Where(
+		"runs.lifecycle_stage = ?", models.LifecycleStageActive,
+	).Where(
+		"params.key = ?", key,
+	).Order(
+		"params.value",
+	).Pluck("params.value", &values).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting values of param: %s", key)
+	}
+	sortNumericParamValues(values)
+	if len(values) > ParamValuesLimit {
+		values = values[:ParamValuesLimit]
+	}
+	return values, nil
+}
+
+// sortNumericParamValues sorts values numerically, when all of them are finite numbers, and keeps them
+// unchanged otherwise. NaN and Inf can't be ordered together with the other numbers, so they are
+// treated as non-numeric values.
+func sortNumericParamValues(values []string) {
+	numbers := make(map[string]float64, len(values))
+	for _, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return
+		}
+		numbers[value] = number
+	}
+	slices.SortStableFunc(values, func(a, b string) int {
+		switch {
+		case numbers[a] < numbers[b]:
+			return -1
+		case numbers[a] > numbers[b]:
+			return 1
+		}
+		return 0
+	})
+}
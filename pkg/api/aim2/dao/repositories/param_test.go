@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sortNumericParamValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected []string
+	}{
+		{
+			name:     "NumericValues",
+			values:   []string{"0.01", "1e-3", "10", "2", "-1"},
+			expected: []string{"-1", "1e-3", "0.01", "2", "10"},
+		},
+		{
+			name:     "MixedValues",
+			values:   []string{"10", "2", "adam"},
+			expected: []string{"10", "2", "adam"},
+		},
+		{
+			name:     "NotFiniteValues",
+			values:   []string{"10", "2", "NaN", "Inf"},
+			expected: []string{"10", "2", "NaN", "Inf"},
+		},
+		{
+			name:     "NoValues",
+			values:   []string{},
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortNumericParamValues(tt.values)
+			assert.Equal(t, tt.expected, tt.values)
+		})
+	}
+}
@@ -16,11 +16,23 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/query"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
+// RunVersionConflictError is returned when models.Run entity has been changed by another update
+// since it was read.
+type RunVersionConflictError struct {
+	Message string
+}
+
+// Error returns the RunVersionConflictError message.
+func (e RunVersionConflictError) Error() string {
+	return e.Message
+}
+
 // RunRepositoryProvider provides an interface to work with models.Run entity.
 type RunRepositoryProvider interface {
 	repositories.BaseRepositoryProvider
@@ -34,11 +46,18 @@ type RunRepositoryProvider interface {
 	) (*sql.Rows, func(*sql.Rows) (*models.AlignedMetric, error), error)
 	// GetRunByNamespaceIDAndRunID returns experiment by Namespace ID and Run ID.
 	GetRunByNamespaceIDAndRunID(ctx context.Context, namespaceID uint, runID string) (*models.Run, error)
+	// GetRunIDsByNamespaceIDAndRunIDs returns IDs of existing runs by Namespace ID and provided Run IDs.
+	GetRunIDsByNamespaceIDAndRunIDs(ctx context.Context, namespaceID uint, runIDs []string) ([]string, error)
 	// GetByNamespaceID returns list of models.Run by requested namespace ID.
 	GetByNamespaceID(ctx context.Context, namespaceID uint) ([]models.Run, error)
-	// GetByNamespaceIDAndStatus returns []models.Run by Namespace ID and status.
-	GetByNamespaceIDAndStatus(ctx context.Context, namespaceID uint, status models.Status) ([]models.Run, error)
-	// Update updates existing models.Experiment entity.
+	// IterateByNamespaceID streams models.Run by requested namespace ID into the provided callback.
+	IterateByNamespaceID(ctx context.Context, namespaceID uint, fn func(run *models.Run) error) error
+	// GetActiveByNamespaceID returns the page of running, not archived runs by Namespace ID.
+	GetActiveByNamespaceID(
+		ctx context.Context, namespaceID uint, req request.GetRunsActiveRequest,
+	) ([]models.Run, error)
+	// Update updates existing models.Run entity, returning RunVersionConflictError
+	// if the run has been changed since it was read.
 	Update(ctx context.Context, run *models.Run) error
 	// ArchiveBatch marks existing models.Run entities as archived.
 	ArchiveBatch(ctx context.Context, namespaceID uint, ids []string) error
@@ -68,10 +87,14 @@ func NewRunRepository(db *gorm.DB) *RunRepository {
 func (r RunRepository) GetRunInfo(
 	ctx context.Context, namespaceID uint, req *request.GetRunInfoRequest,
 ) (*models.Run, error) {
+	fields := commonModels.NewResponseFields(req.Fields)
 	query := r.GetDB().WithContext(ctx)
 	for _, s := range req.Sequences {
 		switch s {
 		case "metric":
+			if !fields.Has(commonModels.ResponseFieldMetrics) {
+				continue
+			}
 			query = query.Preload("LatestMetrics", func(db *gorm.DB) *gorm.DB {
 				return db.Select("RunID", "Key", "ContextID")
 			}).Preload(
@@ -79,6 +102,12 @@ func (r RunRepository) GetRunInfo(
 			)
 		}
 	}
+	if fields.Has(commonModels.ResponseFieldParams) {
+		query = query.Preload("Params")
+	}
+	if fields.Has(commonModels.ResponseFieldTags) {
+		query = query.Preload("Tags")
+	}
 
 	run := models.Run{ID: req.ID}
 	if err := query.InnerJoins(
@@ -88,10 +117,6 @@ func (r RunRepository) GetRunInfo(
 		).Where(
 			&models.Experiment{NamespaceID: namespaceID},
 		),
-	).Preload(
-		"Params",
-	).Preload(
-		"Tags",
 	).First(&run).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -112,7 +137,7 @@ func (r RunRepository) GetRunMetrics(
 
 	// fetch run metrics based on provided criteria.
 	var metrics []models.Metric
-	if err := r.GetDB().InnerJoins(
+	if err := r.GetDB().WithContext(ctx).InnerJoins(
 		"Context",
 	).Order(
 		"iter",
@@ -139,7 +164,7 @@ func (r RunRepository) GetAlignedMetrics(
 		}
 	}
 	values = append(values, namespaceID, alignBy)
-	rows, err := r.GetDB().Raw(
+	rows, err := r.GetDB().WithContext(ctx).Raw(
 		fmt.Sprintf("WITH params(run_uuid, key, context_id, steps) AS (VALUES %s)", &valuesStmt)+
 			"        SELECT m.run_uuid, "+
 			"				rm.key, "+
@@ -189,7 +214,7 @@ func (r RunRepository) GetRunByNamespaceIDAndRunID(
 ) (*models.Run, error) {
 	var run models.Run
 	if err := r.GetDB().WithContext(ctx).Select(
-		"ID",
+		"ID", "Version",
 	).InnerJoins(
 		"Experiment",
 		database.DB.Select(
@@ -208,6 +233,26 @@ func (r RunRepository) GetRunByNamespaceIDAndRunID(
 	return &run, nil
 }
 
+// GetRunIDsByNamespaceIDAndRunIDs returns IDs of existing runs by Namespace ID and provided Run IDs.
+func (r RunRepository) GetRunIDsByNamespaceIDAndRunIDs(
+	ctx context.Context, namespaceID uint, runIDs []string,
+) ([]string, error) {
+	var ids []string
+	if err := r.GetDB().WithContext(ctx).Model(
+		&models.Run{},
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Where(
+		"runs.run_uuid IN ?", runIDs,
+	).Pluck(
+		"runs.run_uuid", &ids,
+	).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting runs by ids: %s", runIDs)
+	}
+	return ids, nil
+}
+
 // GetByNamespaceID returns list of models.Run by requested namespace ID.
 func (r RunRepository) GetByNamespaceID(ctx context.Context, namespaceID uint) ([]models.Run, error) {
 	var runs []models.Run
@@ -222,36 +267,89 @@ func (r RunRepository) GetByNamespaceID(ctx context.Context, namespaceID uint) (
 	return runs, nil
 }
 
-// GetByNamespaceIDAndStatus returns []models.Run by Namespace ID and Lifecycle Stage.
-func (r RunRepository) GetByNamespaceIDAndStatus(
-	ctx context.Context, namespaceID uint, status models.Status,
+// IterateByNamespaceID streams models.Run by requested namespace ID into the provided callback.
+// Runs are read one by one from the cursor, so memory usage doesn't depend on the number of runs.
+// Iteration stops on the first error returned by the callback.
+func (r RunRepository) IterateByNamespaceID(
+	ctx context.Context, namespaceID uint, fn func(run *models.Run) error,
+) error {
+	rows, err := r.GetDB().WithContext(ctx).Model(
+		&models.Run{},
+	).Select(
+		"runs.*",
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Rows()
+	if err != nil {
+		return eris.Wrap(err, "error getting runs")
+	}
+	//nolint:errcheck
+	defer rows.Close()
+
+	for rows.Next() {
+		var run models.Run
+		if err := r.GetDB().ScanRows(rows, &run); err != nil {
+			return eris.Wrap(err, "error scanning run")
+		}
+		if err := fn(&run); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return eris.Wrap(err, "error iterating runs")
+	}
+	return nil
+}
+
+// GetActiveByNamespaceID returns the page of running, not archived runs by Namespace ID.
+// Runs are filtered by `idx_runs_status_experiment_id` index, so finished runs are never read.
+// Page starts after the run with `offset` ID, if it is provided.
+func (r RunRepository) GetActiveByNamespaceID(
+	ctx context.Context, namespaceID uint, req request.GetRunsActiveRequest,
 ) ([]models.Run, error) {
-	var runs []models.Run
-	if err := r.GetDB().WithContext(ctx).
-		Where("status = ?", status).
+	tx := r.GetDB().WithContext(ctx).
+		Where("runs.status = ?", models.StatusRunning).
+		Where("runs.lifecycle_stage = ?", models.LifecycleStageActive).
 		InnerJoins(
 			"Experiment",
-			database.DB.Select(
+			r.GetDB().Select(
 				"ID", "Name",
 			).Where(
 				&models.Experiment{NamespaceID: namespaceID},
 			),
 		).
 		Preload("LatestMetrics.Context").
-		Limit(50).
-		Order("start_time DESC").
-		Find(&runs).Error; err != nil {
-		return nil, eris.Wrapf(err, "error retrieving runs by lifecycle stage")
+		Order("runs.row_num DESC")
+
+	if req.Limit > 0 {
+		tx.Limit(req.Limit)
+	}
+	if req.Offset != "" {
+		run := database.Run{
+			ID: req.Offset,
+		}
+		if err := r.GetDB().WithContext(ctx).Select(
+			"row_num",
+		).First(
+			&run,
+		).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eris.Wrapf(err, "unable to find active runs offset %q", req.Offset)
+		}
+		tx.Where("runs.row_num < ?", run.RowNum)
+	}
+
+	var runs []models.Run
+	if err := tx.Find(&runs).Error; err != nil {
+		return nil, eris.Wrap(err, "error retrieving active runs")
 	}
 	return runs, nil
 }
 
-// Update updates existing models.Run entity.
+// Update updates existing models.Run entity, returning RunVersionConflictError
+// if the run has been changed since it was read.
 func (r RunRepository) Update(ctx context.Context, run *models.Run) error {
-	if err := r.GetDB().WithContext(ctx).Model(&run).Omit("Experiment").Updates(run).Error; err != nil {
-		return eris.Wrapf(err, "error updating run with id: %s", run.ID)
-	}
-	return nil
+	return r.UpdateWithTransaction(ctx, r.GetDB(), run)
 }
 
 // ArchiveBatch marks existing models.Run entities as archived.
@@ -272,12 +370,14 @@ func (r RunRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids [
 		).Where(
 			"run_uuid IN (?)", ids,
 		),
-	).Updates(models.Run{
-		DeletedTime: sql.NullInt64{
+	).Updates(map[string]any{
+		"deleted_time": sql.NullInt64{
 			Int64: time.Now().UTC().UnixMilli(),
 			Valid: true,
 		},
-		LifecycleStage: models.LifecycleStageDeleted,
+		"lifecycle_stage": models.LifecycleStageDeleted,
+		// concurrent updates of the runs, which have been read before, have to fail.
+		"version": gorm.Expr("version + 1"),
 	}).Error; err != nil {
 		return eris.Wrapf(err, "error updating existing runs with ids: %s", ids)
 	}
@@ -292,7 +392,7 @@ func (r RunRepository) Delete(ctx context.Context, namespaceID uint, run *models
 
 // DeleteBatch removes existing models.Run from the db.
 func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		runs := make([]models.Run, 0, len(ids))
 		if err := tx.Clauses(
 			clause.Returning{Columns: []clause.Column{{Name: "row_num"}}},
@@ -336,6 +436,8 @@ func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []
 func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error {
 	if err := r.GetDB().WithContext(
 		ctx,
+	).Model(
+		models.Run{},
 	).Where(
 		"run_uuid IN (?)",
 		r.GetDB().Model(
@@ -348,9 +450,11 @@ func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids [
 		).Where(
 			"run_uuid IN (?)", ids,
 		),
-	).Updates(models.Run{
-		DeletedTime:    sql.NullInt64{},
-		LifecycleStage: models.LifecycleStageActive,
+	).Updates(map[string]any{
+		"deleted_time":    sql.NullInt64{},
+		"lifecycle_stage": models.LifecycleStageActive,
+		// concurrent updates of the runs, which have been read before, have to fail.
+		"version": gorm.Expr("version + 1"),
 	}).Error; err != nil {
 		return eris.Wrapf(err, "error updating existing runs with ids: %s", ids)
 	}
@@ -359,9 +463,23 @@ func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids [
 }
 
 // UpdateWithTransaction updates existing models.Run entity in scope of transaction.
+// The update only succeeds if the run still has the version it was read with,
+// otherwise RunVersionConflictError is returned.
 func (r RunRepository) UpdateWithTransaction(ctx context.Context, tx *gorm.DB, run *models.Run) error {
-	if err := tx.WithContext(ctx).Model(&run).Updates(run).Error; err != nil {
-		return eris.Wrapf(err, "error updating existing run with id: %s", run.ID)
+	version := run.Version
+	run.Version = version + 1
+	result := tx.WithContext(ctx).Model(&run).Where(
+		"version = ?", version,
+	).Omit("Experiment", "LatestMetrics", "Metrics", "Params", "Tags").Updates(run)
+	if result.Error != nil {
+		run.Version = version
+		return eris.Wrapf(result.Error, "error updating existing run with id: %s", run.ID)
+	}
+	if result.RowsAffected == 0 {
+		run.Version = version
+		return RunVersionConflictError{
+			Message: fmt.Sprintf("run '%s' has been modified by another request", run.ID),
+		}
 	}
 	return nil
 }
@@ -423,7 +541,7 @@ func (r RunRepository) SearchRuns(
 		run := &database.Run{
 			ID: req.Offset,
 		}
-		if err := database.DB.Select(
+		if err := r.GetDB().WithContext(ctx).Select(
 			"row_num",
 		).First(
 			&run,
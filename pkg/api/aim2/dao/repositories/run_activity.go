@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// RunActivityRepositoryProvider provides an interface to work with models.RunActivity entity.
+type RunActivityRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// GetByNamespaceID returns list of models.RunActivity by requested namespace ID.
+	GetByNamespaceID(ctx context.Context, namespaceID uint) ([]models.RunActivity, error)
+	// Rebuild rebuilds models.RunActivity of all the namespaces from scratch.
+	Rebuild(ctx context.Context) error
+	// RebuildByNamespaceID rebuilds models.RunActivity of requested namespace ID from scratch.
+	RebuildByNamespaceID(ctx context.Context, namespaceID uint) error
+	// RefreshByNamespaceIDAndBuckets recalculates models.RunActivity of requested namespace ID and buckets.
+	RefreshByNamespaceIDAndBuckets(ctx context.Context, namespaceID uint, buckets []int64) error
+}
+
+// RunActivityRepository repository to work with models.RunActivity entity.
+type RunActivityRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewRunActivityRepository creates repository to work with models.RunActivity entity.
+func NewRunActivityRepository(db *gorm.DB) *RunActivityRepository {
+	return &RunActivityRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// GetByNamespaceID returns list of models.RunActivity by requested namespace ID.
+func (r RunActivityRepository) GetByNamespaceID(
+	ctx context.Context, namespaceID uint,
+) ([]models.RunActivity, error) {
+	var activities []models.RunActivity
+	if err := r.GetDB().WithContext(ctx).Where(
+		"namespace_id = ?", namespaceID,
+	).Order(
+		"bucket",
+	).Find(
+		&activities,
+	).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting run activity by namespace id: %d", namespaceID)
+	}
+	return activities, nil
+}
+
+// Rebuild rebuilds models.RunActivity of all the namespaces from scratch.
+func (r RunActivityRepository) Rebuild(ctx context.Context) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.RunActivity{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(buildRunActivityQuery("1 = 1")).Error
+	}); err != nil {
+		return eris.Wrap(err, "error rebuilding run activity")
+	}
+	return nil
+}
+
+// RebuildByNamespaceID rebuilds models.RunActivity of requested namespace ID from scratch.
+func (r RunActivityRepository) RebuildByNamespaceID(ctx context.Context, namespaceID uint) error {
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(
+			"namespace_id = ?", namespaceID,
+		).Delete(
+			&models.RunActivity{},
+		).Error; err != nil {
+			return err
+		}
+		return tx.Exec(
+			buildRunActivityQuery("experiments.namespace_id = ?"), namespaceID,
+		).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error rebuilding run activity by namespace id: %d", namespaceID)
+	}
+	return nil
+}
+
+// RefreshByNamespaceIDAndBuckets recalculates models.RunActivity of requested namespace ID and buckets.
+func (r RunActivityRepository) RefreshByNamespaceIDAndBuckets(
+	ctx context.Context, namespaceID uint, buckets []int64,
+) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(
+			"namespace_id = ? AND bucket IN ?", namespaceID, buckets,
+		).Delete(
+			&models.RunActivity{},
+		).Error; err != nil {
+			return err
+		}
+		return tx.Exec(
+			buildRunActivityQuery(fmt.Sprintf(
+				"experiments.namespace_id = ? AND COALESCE(runs.start_time, 0) / %d IN ?", models.RunActivityBucketSize,
+			)),
+			namespaceID,
+			buckets,
+		).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error refreshing run activity by namespace id: %d", namespaceID)
+	}
+	return nil
+}
+
+// buildRunActivityQuery builds query, which calculates models.RunActivity of the runs satisfying
+// provided condition and upserts them. The query is idempotent, so concurrent calculations are safe.
+func buildRunActivityQuery(condition string) string {
+	bucket := fmt.Sprintf("COALESCE(runs.start_time, 0) / %d", models.RunActivityBucketSize)
+	return fmt.Sprintf(
+		"INSERT INTO run_activities"+
+			"  (namespace_id, bucket, num_runs, num_active_runs, num_archived_runs)"+
+			"  SELECT"+
+			"    experiments.namespace_id,"+
+			"    %s,"+
+			"    COUNT(*),"+
+			"    SUM(CASE WHEN runs.lifecycle_stage <> '%s' AND runs.status = '%s' THEN 1 ELSE 0 END),"+
+			"    SUM(CASE WHEN runs.lifecycle_stage = '%s' THEN 1 ELSE 0 END)"+
+			"  FROM runs"+
+			"  INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id"+
+			"  WHERE %s"+
+			"  GROUP BY experiments.namespace_id, %s"+
+			"  ON CONFLICT (namespace_id, bucket) DO UPDATE SET"+
+			"    num_runs = excluded.num_runs,"+
+			"    num_active_runs = excluded.num_active_runs,"+
+			"    num_archived_runs = excluded.num_archived_runs",
+		bucket,
+		models.LifecycleStageDeleted,
+		models.StatusRunning,
+		models.LifecycleStageDeleted,
+		condition,
+		bucket,
+	)
+}
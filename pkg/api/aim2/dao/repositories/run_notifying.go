@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// RunNotifyingRepository repository to work with models.Run entity, which triggers database event
+// on each change of runs.
+type RunNotifyingRepository struct {
+	RunRepositoryProvider
+	runEventListener dao.EventListenerProvider
+}
+
+// NewRunNotifyingRepository creates new instance of repository to work with models.Run entity,
+// which triggers database event on each change of runs.
+func NewRunNotifyingRepository(
+	db *gorm.DB, runEventListener dao.EventListenerProvider,
+) *RunNotifyingRepository {
+	return &RunNotifyingRepository{
+		RunRepositoryProvider: NewRunRepository(db),
+		runEventListener:      runEventListener,
+	}
+}
+
+// Update updates existing models.Run entity.
+func (r RunNotifyingRepository) Update(ctx context.Context, run *models.Run) error {
+	if err := r.RunRepositoryProvider.Update(ctx, run); err != nil {
+		return err
+	}
+	return sendRunEvent(ctx, r.runEventListener, r.GetDB(), events.RunEvent{
+		ExperimentID: &run.ExperimentID,
+		StartTimes:   []int64{run.StartTime.Int64},
+	})
+}
+
+// ArchiveBatch marks existing models.Run entities as archived.
+func (r RunNotifyingRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids []string) error {
+	return r.doBatch(ctx, namespaceID, ids, r.RunRepositoryProvider.ArchiveBatch)
+}
+
+// DeleteBatch removes the existing models.Run from the db.
+func (r RunNotifyingRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
+	return r.doBatch(ctx, namespaceID, ids, r.RunRepositoryProvider.DeleteBatch)
+}
+
+// RestoreBatch marks existing models.Run entities as active.
+func (r RunNotifyingRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error {
+	return r.doBatch(ctx, namespaceID, ids, r.RunRepositoryProvider.RestoreBatch)
+}
+
+// doBatch collects start times of the runs before applying batch operation, as runs could be gone after it.
+func (r RunNotifyingRepository) doBatch(
+	ctx context.Context,
+	namespaceID uint,
+	ids []string,
+	fn func(ctx context.Context, namespaceID uint, ids []string) error,
+) error {
+	var values []sql.NullInt64
+	if err := r.GetDB().WithContext(ctx).Model(
+		&models.Run{},
+	).Where(
+		"run_uuid IN ?", ids,
+	).Pluck(
+		"start_time", &values,
+	).Error; err != nil {
+		return eris.Wrapf(err, "error getting start time of runs with ids: %s", ids)
+	}
+
+	if err := fn(ctx, namespaceID, ids); err != nil {
+		return err
+	}
+
+	startTimes := make([]int64, len(values))
+	for i, value := range values {
+		startTimes[i] = value.Int64
+	}
+	return sendRunEvent(ctx, r.runEventListener, r.GetDB(), events.RunEvent{
+		NamespaceID: namespaceID,
+		StartTimes:  startTimes,
+	})
+}
+
+// sendRunEvent sends database event about changed runs.
+func sendRunEvent(
+	ctx context.Context, runEventListener dao.EventListenerProvider, db *gorm.DB, event events.RunEvent,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return eris.Wrap(err, "error serializing RunEvent event")
+	}
+	if err := runEventListener.Publish(ctx, db, string(data)); err != nil {
+		return eris.Wrap(err, "error sending database event")
+	}
+	return nil
+}
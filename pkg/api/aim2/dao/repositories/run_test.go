@@ -1,7 +1,9 @@
 package repositories
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -69,3 +71,57 @@ func Test_renumberRows(t *testing.T) {
 		})
 	}
 }
+
+func newMockRunRepository(t *testing.T) (*RunRepository, sqlmock.Sqlmock) {
+	mockDb, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		//nolint:errcheck
+		mockDb.Close()
+	})
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDb,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.Nil(t, err)
+	return NewRunRepository(db), mock
+}
+
+func TestRunRepository_GetByNamespaceID_ContextCancelled(t *testing.T) {
+	repo, mock := newMockRunRepository(t)
+	mock.ExpectQuery(`SELECT (.+) FROM "runs"`).WillDelayFor(
+		time.Minute,
+	).WillReturnRows(
+		sqlmock.NewRows([]string{"run_uuid"}).AddRow("run1"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	runs, err := repo.GetByNamespaceID(ctx, 1)
+	assert.Nil(t, runs)
+	assert.ErrorIs(t, err, sqlmock.ErrCancelled)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestRunRepository_GetRunMetrics_ContextCancelled(t *testing.T) {
+	repo, mock := newMockRunRepository(t)
+	mock.ExpectQuery(`SELECT (.+) FROM "metrics"`).WillDelayFor(
+		time.Minute,
+	).WillReturnRows(
+		sqlmock.NewRows([]string{"run_uuid"}).AddRow("run1"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	metrics, err := repo.GetRunMetrics(ctx, "run1", models.MetricKeysMap{
+		models.MetricKeysItem{Name: "loss", Context: "{}"}: nil,
+	})
+	assert.Nil(t, metrics)
+	assert.ErrorIs(t, err, sqlmock.ErrCancelled)
+	assert.Less(t, time.Since(start), time.Minute)
+}
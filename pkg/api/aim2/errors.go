@@ -17,12 +17,14 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 	case errors.As(err, &f):
 		e = &api.ErrorResponse{
 			StatusCode: f.Code,
+			ErrorCode:  api.ErrorCodeFromStatus(f.Code),
 			Message:    f.Message,
 		}
 	case errors.As(err, &e):
 	default:
 		e = &api.ErrorResponse{
 			StatusCode: fiber.StatusInternalServerError,
+			ErrorCode:  api.ErrorCodeInternalError,
 			Message:    err.Error(),
 		}
 	}
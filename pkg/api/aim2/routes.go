@@ -57,6 +57,7 @@ func (r *Router) Init(server fiber.Router) {
 	projects.Get("/pinned-sequences/", r.controller.GetProjectPinnedSequences)
 	projects.Post("/pinned-sequences/", r.controller.UpdateProjectPinnedSequences)
 	projects.Get("/params/", r.controller.GetProjectParams)
+	projects.Get("/params/values/", r.controller.GetProjectParamValues)
 	projects.Get("/status/", r.controller.GetProjectStatus)
 
 	runs := mainGroup.Group("/runs")
@@ -64,6 +65,9 @@ func (r *Router) Init(server fiber.Router) {
 	runs.Get("/search/run/", r.controller.SearchRuns)
 	runs.Post("/search/metric/", r.controller.SearchMetrics)
 	runs.Post("/search/metric/align/", r.controller.SearchAlignedMetrics)
+	runs.Post("/search/metric/aggregate/", r.controller.AggregateMetrics)
+	runs.Post("/search/metric/join/", r.controller.JoinMetrics)
+	runs.Post("/search/metric/batch/", r.controller.GetRunsMetrics)
 	runs.Get("/:id/info/", r.controller.GetRunInfo)
 	runs.Post("/:id/metric/get-batch/", r.controller.GetRunMetrics)
 	runs.Put("/:id/", r.controller.UpdateRun)
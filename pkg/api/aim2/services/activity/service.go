@@ -0,0 +1,105 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// Service provides service layer to maintain run activity summary.
+type Service struct {
+	runActivityRepository repositories.RunActivityRepositoryProvider
+	experimentRepository  repositories.ExperimentRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	runActivityRepository repositories.RunActivityRepositoryProvider,
+	experimentRepository repositories.ExperimentRepositoryProvider,
+) *Service {
+	return &Service{
+		runActivityRepository: runActivityRepository,
+		experimentRepository:  experimentRepository,
+	}
+}
+
+// Subscribe subscribes to run events and keeps run activity summary up-to-date, until context is done.
+func (s Service) Subscribe(ctx context.Context, runEventListener dao.EventListenerProvider) {
+	ch := make(chan string)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data := <-ch:
+				if err := s.ProcessEvent(ctx, data); err != nil {
+					log.Errorf(`error processing incoming event: %s, error: %+v`, data, err)
+				}
+			}
+		}
+	}()
+
+	// subscribe to incoming events.
+	runEventListener.Subscribe(ch)
+}
+
+// Start rebuilds run activity summary periodically, with the provided interval, until context is done.
+// Summary is rebuilt straight away to correct any drift accumulated while the server was not running.
+func (s Service) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := s.runActivityRepository.Rebuild(ctx); err != nil {
+				log.Errorf("error rebuilding run activity: %+v", err)
+			}
+			select {
+			case <-ctx.Done():
+				log.Debugf("run activity reconciliation finished. exiting.")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ProcessEvent recalculates run activity summary affected by incoming run event.
+func (s Service) ProcessEvent(ctx context.Context, data string) error {
+	log.Debugf("got incoming run event: %s", data)
+	event := events.RunEvent{}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return eris.Wrap(err, "error unmarshaling incoming database event")
+	}
+
+	namespaceID := event.NamespaceID
+	if namespaceID == 0 {
+		if event.ExperimentID == nil {
+			return eris.New("event has neither namespace id nor experiment id")
+		}
+		id, err := s.experimentRepository.GetNamespaceIDByExperimentID(ctx, *event.ExperimentID)
+		if err != nil {
+			return eris.Wrap(err, "error getting namespace of the runs")
+		}
+		namespaceID = id
+	}
+
+	if len(event.StartTimes) == 0 {
+		return s.runActivityRepository.RebuildByNamespaceID(ctx, namespaceID)
+	}
+
+	buckets := make([]int64, len(event.StartTimes))
+	for i, startTime := range event.StartTimes {
+		buckets[i] = models.GetRunActivityBucket(startTime)
+	}
+	slices.Sort(buckets)
+	return s.runActivityRepository.RefreshByNamespaceIDAndBuckets(ctx, namespaceID, slices.Compact(buckets))
+}
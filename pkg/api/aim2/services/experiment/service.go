@@ -9,20 +9,25 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // Service provides service layer to work with `experiment` business logic.
 type Service struct {
+	config               *config.Config
 	tagRepository        repositories.TagRepositoryProvider
 	experimentRepository repositories.ExperimentRepositoryProvider
 }
 
 // NewService creates new Service instance.
 func NewService(
+	config *config.Config,
 	tagRepository repositories.TagRepositoryProvider,
 	experimentRepository repositories.ExperimentRepositoryProvider,
 ) *Service {
 	return &Service{
+		config:               config,
 		tagRepository:        tagRepository,
 		experimentRepository: experimentRepository,
 	}
@@ -32,8 +37,12 @@ func NewService(
 func (s Service) GetExperiment(
 	ctx context.Context, namespaceID uint, req *request.GetExperimentRequest,
 ) (*models.ExperimentExtended, error) {
+	if err := ValidateGetExperimentRequest(req); err != nil {
+		return nil, err
+	}
+
 	experiment, err := s.experimentRepository.GetExtendedExperimentByNamespaceIDAndExperimentID(
-		ctx, namespaceID, req.ID,
+		ctx, namespaceID, req.ID, commonModels.NewResponseFields(req.Fields),
 	)
 	if err != nil {
 		return nil, api.NewInternalError("unable to find experiment by id %d: %s", req.ID, err)
@@ -83,6 +92,7 @@ func (s Service) GetExperimentRuns(
 	if experiment == nil {
 		return nil, api.NewResourceDoesNotExistError("experiment '%d' not found", req.ID)
 	}
+	req.Limit = s.config.GetPageSize(req.Limit)
 	runs, err := s.experimentRepository.GetExperimentRuns(ctx, req)
 	if err != nil {
 		return nil, api.NewInternalError("unable to find experiment runs")
@@ -101,6 +111,13 @@ func (s Service) UpdateExperiment(
 	if experiment == nil {
 		return api.NewResourceDoesNotExistError("experiment '%d' not found", req.ID)
 	}
+	if req.Name != nil {
+		if err := api.ValidateName(
+			"experiment", *req.Name, s.config.GetMaxExperimentNameLength(),
+		); err != nil {
+			return err
+		}
+	}
 
 	experiment = convertors.ConvertUpdateExperimentToDBModel(req, experiment)
 	if req.Archived != nil || req.Name != nil {
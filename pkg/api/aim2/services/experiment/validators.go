@@ -0,0 +1,25 @@
+package experiment
+
+import (
+	"slices"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
+
+// SupportedExperimentFields list of supported Fields for `GET /experiments/:id` request.
+var SupportedExperimentFields = []models.ResponseField{
+	models.ResponseFieldTags,
+	models.ResponseFieldSummary,
+}
+
+// ValidateGetExperimentRequest validates `GET /experiments/:id` request.
+func ValidateGetExperimentRequest(req *request.GetExperimentRequest) error {
+	for _, field := range models.NewResponseFields(req.Fields) {
+		if !slices.Contains(SupportedExperimentFields, field) {
+			return api.NewInvalidParameterValueError("%q is not a valid field", field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package project
+
+import (
+	"time"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+)
+
+// ActivityAccumulator accumulates project activity run by run.
+type ActivityAccumulator struct {
+	tzOffset        int
+	numRuns         int64
+	activityMap     map[string]int
+	numActiveRuns   int64
+	numArchivedRuns int64
+}
+
+// NewActivityAccumulator creates new ActivityAccumulator instance.
+func NewActivityAccumulator(tzOffset int) *ActivityAccumulator {
+	return &ActivityAccumulator{
+		tzOffset:    tzOffset,
+		activityMap: map[string]int{},
+	}
+}
+
+// Add adds run to the activity.
+func (a *ActivityAccumulator) Add(run *models.Run) {
+	a.numRuns += 1
+	switch {
+	case run.LifecycleStage == models.LifecycleStageDeleted:
+		a.numArchivedRuns += 1
+	case run.Status == models.StatusRunning:
+		a.numActiveRuns += 1
+	}
+	a.activityMap[a.getKey(time.UnixMilli(run.StartTime.Int64))] += 1
+}
+
+// AddRunActivity adds summary of the runs started within the same bucket. Returns false, without adding
+// anything, if runs of the bucket could fall into different hours of the client timezone, like when the
+// timezone offset is not a whole number of hours. Such runs have to be added one by one.
+func (a *ActivityAccumulator) AddRunActivity(activity *models.RunActivity) bool {
+	startTime := activity.GetStartTime()
+	key := a.getKey(startTime)
+	endTime := startTime.Add(time.Duration(models.RunActivityBucketSize-1) * time.Millisecond)
+	if a.getKey(endTime) != key {
+		return false
+	}
+	a.numRuns += activity.NumRuns
+	a.numActiveRuns += activity.NumActiveRuns
+	a.numArchivedRuns += activity.NumArchivedRuns
+	a.activityMap[key] += int(activity.NumRuns)
+	return true
+}
+
+// getKey returns key of activity map for the provided time.
+func (a *ActivityAccumulator) getKey(t time.Time) string {
+	return t.Add(time.Duration(-a.tzOffset) * time.Minute).Format("2006-01-02T15:00:00")
+}
+
+// Result returns accumulated project activity.
+func (a *ActivityAccumulator) Result(numExperiments int64) *models.ProjectActivity {
+	return &models.ProjectActivity{
+		NumRuns:         a.numRuns,
+		ActivityMap:     a.activityMap,
+		NumActiveRuns:   a.numActiveRuns,
+		NumExperiments:  numExperiments,
+		NumArchivedRuns: a.numArchivedRuns,
+	}
+}
@@ -0,0 +1,157 @@
+package project
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+)
+
+func newActivityTestRuns(num int) []models.Run {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := make([]models.Run, num)
+	for i := range runs {
+		runs[i] = models.Run{
+			ID:             fmt.Sprintf("run%d", i),
+			Status:         models.StatusFinished,
+			LifecycleStage: models.LifecycleStageActive,
+			StartTime:      sql.NullInt64{Int64: start.Add(time.Duration(i) * 17 * time.Minute).UnixMilli(), Valid: true},
+		}
+		switch i % 3 {
+		case 0:
+			runs[i].Status = models.StatusRunning
+		case 1:
+			runs[i].LifecycleStage = models.LifecycleStageDeleted
+		}
+	}
+	return runs
+}
+
+func TestActivityAccumulator_Ok(t *testing.T) {
+	runs := newActivityTestRuns(100)
+
+	tests := []struct {
+		name     string
+		tzOffset int
+	}{
+		{
+			name:     "NoTimezoneOffset",
+			tzOffset: 0,
+		},
+		{
+			name:     "WithTimezoneOffset",
+			tzOffset: -90,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// calculate expected activity with all the runs in memory.
+			expected := &models.ProjectActivity{
+				NumRuns:        int64(len(runs)),
+				ActivityMap:    map[string]int{},
+				NumExperiments: 2,
+			}
+			for _, run := range runs {
+				switch {
+				case run.LifecycleStage == models.LifecycleStageDeleted:
+					expected.NumArchivedRuns += 1
+				case run.Status == models.StatusRunning:
+					expected.NumActiveRuns += 1
+				}
+				key := time.UnixMilli(run.StartTime.Int64).Add(
+					time.Duration(-tt.tzOffset) * time.Minute,
+				).Format("2006-01-02T15:00:00")
+				expected.ActivityMap[key] += 1
+			}
+
+			accumulator := NewActivityAccumulator(tt.tzOffset)
+			for i := range runs {
+				accumulator.Add(&runs[i])
+			}
+			assert.Equal(t, expected, accumulator.Result(2))
+		})
+	}
+}
+
+func TestActivityAccumulator_AddRunActivity(t *testing.T) {
+	// activity map keys are formatted in the server timezone, so pin it.
+	local := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = local }()
+
+	runs := newActivityTestRuns(100)
+
+	// summarize runs the same way as `run_activities` table does.
+	summary := map[int64]*models.RunActivity{}
+	for _, run := range runs {
+		bucket := models.GetRunActivityBucket(run.StartTime.Int64)
+		activity, ok := summary[bucket]
+		if !ok {
+			activity = &models.RunActivity{Bucket: bucket}
+			summary[bucket] = activity
+		}
+		activity.NumRuns += 1
+		switch {
+		case run.LifecycleStage == models.LifecycleStageDeleted:
+			activity.NumArchivedRuns += 1
+		case run.Status == models.StatusRunning:
+			activity.NumActiveRuns += 1
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tzOffset int
+		ok       bool
+	}{
+		{
+			name:     "NoTimezoneOffset",
+			tzOffset: 0,
+			ok:       true,
+		},
+		{
+			name:     "WholeHoursTimezoneOffset",
+			tzOffset: -120,
+			ok:       true,
+		},
+		{
+			name:     "PartialHourTimezoneOffset",
+			tzOffset: -90,
+			ok:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := NewActivityAccumulator(tt.tzOffset)
+			for i := range runs {
+				expected.Add(&runs[i])
+			}
+
+			accumulator := NewActivityAccumulator(tt.tzOffset)
+			for _, activity := range summary {
+				assert.Equal(t, tt.ok, accumulator.AddRunActivity(activity))
+			}
+			if tt.ok {
+				assert.Equal(t, expected.Result(2), accumulator.Result(2))
+			} else {
+				assert.Equal(t, NewActivityAccumulator(tt.tzOffset).Result(2), accumulator.Result(2))
+			}
+		})
+	}
+}
+
+func BenchmarkActivityAccumulator(b *testing.B) {
+	runs := newActivityTestRuns(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		accumulator := NewActivityAccumulator(0)
+		for j := range runs {
+			accumulator.Add(&runs[j])
+		}
+		accumulator.Result(1)
+	}
+}
@@ -2,67 +2,155 @@ package project
 
 import (
 	"context"
+	"encoding/json"
 	"slices"
-	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
 )
 
+// projectInformationCacheSize is a maximum number of namespaces, which project information is cached.
+const projectInformationCacheSize = 1000
+
+// projectInformation represents project information of the namespace.
+type projectInformation struct {
+	name               string
+	dialector          string
+	liveUpdatesEnabled bool
+}
+
 // Service provides service layer to work with `project` business logic.
 type Service struct {
-	tagRepository        repositories.TagRepositoryProvider
-	runRepository        repositories.RunRepositoryProvider
-	paramRepository      repositories.ParamRepositoryProvider
-	metricRepository     repositories.MetricRepositoryProvider
-	experimentRepository repositories.ExperimentRepositoryProvider
-	liveUpdatesEnabled   bool
+	config                *config.Config
+	tagRepository         repositories.TagRepositoryProvider
+	runRepository         repositories.RunRepositoryProvider
+	paramRepository       repositories.ParamRepositoryProvider
+	metricRepository      repositories.MetricRepositoryProvider
+	experimentRepository  repositories.ExperimentRepositoryProvider
+	runActivityRepository repositories.RunActivityRepositoryProvider
+	projectInformation    *expirable.LRU[string, projectInformation]
 }
 
 // NewService creates new Service instance.
 func NewService(
+	config *config.Config,
 	tagRepository repositories.TagRepositoryProvider,
 	runRepository repositories.RunRepositoryProvider,
 	paramRepository repositories.ParamRepositoryProvider,
 	metricRepository repositories.MetricRepositoryProvider,
 	experimentRepository repositories.ExperimentRepositoryProvider,
-	liveUpdatesEnabled bool,
+	runActivityRepository repositories.RunActivityRepositoryProvider,
 ) *Service {
+	// project information is cached only when it is enabled by configuration.
+	var cache *expirable.LRU[string, projectInformation]
+	if config.ProjectCacheTTL > 0 {
+		cache = expirable.NewLRU[string, projectInformation](projectInformationCacheSize, nil, config.ProjectCacheTTL)
+	}
 	return &Service{
-		tagRepository:        tagRepository,
-		runRepository:        runRepository,
-		paramRepository:      paramRepository,
-		metricRepository:     metricRepository,
-		experimentRepository: experimentRepository,
-		liveUpdatesEnabled:   liveUpdatesEnabled,
+		config:                config,
+		tagRepository:         tagRepository,
+		runRepository:         runRepository,
+		paramRepository:       paramRepository,
+		metricRepository:      metricRepository,
+		experimentRepository:  experimentRepository,
+		runActivityRepository: runActivityRepository,
+		projectInformation:    cache,
+	}
+}
+
+// GetProjectInformation returns project information with effective feature settings of the namespace.
+func (s Service) GetProjectInformation(namespaceCode string) (string, string, bool) {
+	if s.projectInformation != nil {
+		if info, ok := s.projectInformation.Get(namespaceCode); ok {
+			return info.name, info.dialector, info.liveUpdatesEnabled
+		}
+	}
+
+	info := projectInformation{
+		name:               "FastTrackML",
+		dialector:          s.runRepository.GetDB().Dialector.Name(),
+		liveUpdatesEnabled: s.config.IsFeatureEnabled(namespaceCode, config.FeatureLiveUpdates),
+	}
+	if s.projectInformation != nil {
+		s.projectInformation.Add(namespaceCode, info)
 	}
+	return info.name, info.dialector, info.liveUpdatesEnabled
+}
+
+// Subscribe subscribes to namespace events and invalidates cached project information, until context is done.
+func (s Service) Subscribe(ctx context.Context, namespaceEventListener dao.EventListenerProvider) {
+	ch := make(chan string)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data := <-ch:
+				if err := s.ProcessEvent(data); err != nil {
+					log.Errorf(`error processing incoming event: %s, error: %+v`, data, err)
+				}
+			}
+		}
+	}()
+
+	// subscribe to incoming events.
+	namespaceEventListener.Subscribe(ch)
 }
 
-// GetProjectInformation returns project information.
-func (s Service) GetProjectInformation() (string, string, bool) {
-	return "FastTrackML", s.runRepository.GetDB().Dialector.Name(), s.liveUpdatesEnabled
+// ProcessEvent invalidates cached project information affected by incoming namespace event.
+func (s Service) ProcessEvent(data string) error {
+	if s.projectInformation == nil {
+		return nil
+	}
+
+	log.Debugf("got incoming namespace event: %s", data)
+	event := events.NamespaceEvent{}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return eris.Wrap(err, "error unmarshaling incoming database event")
+	}
+	switch event.Action {
+	case events.NamespaceEventActionUpdated:
+		// namespace code could have been changed too, so the previous code is unknown.
+		s.projectInformation.Purge()
+	case events.NamespaceEventActionDeleted:
+		s.projectInformation.Remove(event.Namespace.Code)
+	}
+	return nil
 }
 
 // GetProjectActivity returns project activity.
 func (s Service) GetProjectActivity(
 	ctx context.Context, namespaceID uint, tzOffset int,
 ) (*models.ProjectActivity, error) {
-	runs, err := s.runRepository.GetByNamespaceID(ctx, namespaceID)
+	activities, err := s.runActivityRepository.GetByNamespaceID(ctx, namespaceID)
 	if err != nil {
-		return nil, api.NewInternalError("error getting runs: %s", err)
-	}
-	activity, numActiveRuns, numArchivedRuns := map[string]int{}, int64(0), int64(0)
-	for _, run := range runs {
-		switch {
-		case run.LifecycleStage == models.LifecycleStageDeleted:
-			numArchivedRuns += 1
-		case run.Status == models.StatusRunning:
-			numActiveRuns += 1
+		return nil, api.NewInternalError("error getting run activity: %s", err)
+	}
+
+	accumulator := NewActivityAccumulator(tzOffset)
+	for i := range activities {
+		if accumulator.AddRunActivity(&activities[i]) {
+			continue
 		}
-		key := time.UnixMilli(run.StartTime.Int64).Add(time.Duration(-tzOffset) * time.Minute).Format("2006-01-02T15:00:00")
-		activity[key] += 1
+		// summary doesn't fit client timezone, so fall back to scanning all the runs.
+		accumulator = NewActivityAccumulator(tzOffset)
+		if err := s.runRepository.IterateByNamespaceID(ctx, namespaceID, func(run *models.Run) error {
+			accumulator.Add(run)
+			return nil
+		}); err != nil {
+			return nil, api.NewInternalError("error getting runs: %s", err)
+		}
+		break
 	}
 
 	numActiveExperiments, err := s.experimentRepository.GetCountOfActiveExperiments(ctx, namespaceID)
@@ -70,13 +158,7 @@ func (s Service) GetProjectActivity(
 		return nil, api.NewInternalError("error getting number of active experiments: %s", err)
 	}
 
-	return &models.ProjectActivity{
-		NumRuns:         int64(len(runs)),
-		ActivityMap:     activity,
-		NumActiveRuns:   numActiveRuns,
-		NumExperiments:  numActiveExperiments,
-		NumArchivedRuns: numArchivedRuns,
-	}, nil
+	return accumulator.Result(numActiveExperiments), nil
 }
 
 // GetProjectParams returns project params.
@@ -104,9 +186,19 @@ func (s Service) GetProjectParams(
 	}
 
 	if slices.Contains(req.Sequences, "metric") {
+		// canonicalize context filter, so it has the same keys order and formatting as stored contexts.
+		var metricContext types.JSONB
+		if req.Context != "" {
+			data, err := types.JSONB(req.Context).Canonical()
+			if err != nil {
+				return nil, api.NewInvalidParameterValueError("error parsing context: %s", err)
+			}
+			metricContext = data
+		}
+
 		// fetch metrics only when Experiments or ExperimentNames were provided.
 		metrics, err := s.metricRepository.GetMetricKeysAndContextsByExperiments(
-			ctx, namespaceID, req.ExperimentNames,
+			ctx, namespaceID, req.ExperimentNames, metricContext,
 		)
 		if err != nil {
 			return nil, api.NewInternalError("error getting metrics: %s", err)
@@ -115,3 +207,18 @@ func (s Service) GetProjectParams(
 	}
 	return &projectParams, nil
 }
+
+// GetProjectParamValues returns distinct values of the project param.
+func (s Service) GetProjectParamValues(
+	ctx context.Context, namespaceID uint, req *request.GetProjectParamValuesRequest,
+) ([]string, error) {
+	if err := ValidateGetProjectParamValuesRequest(req); err != nil {
+		return nil, err
+	}
+
+	values, err := s.paramRepository.GetParamValuesByKey(ctx, namespaceID, req.Key)
+	if err != nil {
+		return nil, api.NewInternalError("error getting param values: %s", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,124 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
+	mlflowModels "github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+func newProjectService(cfg *config.Config) *Service {
+	db := &gorm.DB{Config: &gorm.Config{Dialector: postgres.Dialector{}}}
+	return NewService(cfg, nil, repositories.NewRunRepository(db), nil, nil, nil, nil)
+}
+
+func newNamespaceEvent(t *testing.T, action events.NamespaceEventAction, code string) string {
+	data, err := json.Marshal(events.NamespaceEvent{
+		Action:    action,
+		Namespace: mlflowModels.Namespace{Code: code},
+	})
+	require.Nil(t, err)
+	return string(data)
+}
+
+func TestService_GetProjectInformation_Ok(t *testing.T) {
+	cfg := &config.Config{
+		LiveUpdatesEnabled:    true,
+		NamespaceFeatureFlags: []string{"disabled:live-updates=false"},
+	}
+	service := newProjectService(cfg)
+
+	name, dialector, liveUpdatesEnabled := service.GetProjectInformation("default")
+	assert.Equal(t, "FastTrackML", name)
+	assert.Equal(t, "postgres", dialector)
+	assert.True(t, liveUpdatesEnabled)
+
+	// without cache, changes of the configuration are reflected straight away.
+	cfg.LiveUpdatesEnabled = false
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("default")
+	assert.False(t, liveUpdatesEnabled)
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("disabled")
+	assert.False(t, liveUpdatesEnabled)
+}
+
+func TestService_GetProjectInformation_Cached(t *testing.T) {
+	cfg := &config.Config{
+		LiveUpdatesEnabled:    true,
+		NamespaceFeatureFlags: []string{"disabled:live-updates=false"},
+		ProjectCacheTTL:       time.Hour,
+	}
+	service := newProjectService(cfg)
+
+	// effective per-namespace value is cached.
+	_, _, liveUpdatesEnabled := service.GetProjectInformation("default")
+	assert.True(t, liveUpdatesEnabled)
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("disabled")
+	assert.False(t, liveUpdatesEnabled)
+
+	// cache hit doesn't reflect changes of the configuration.
+	cfg.LiveUpdatesEnabled = false
+	cfg.NamespaceFeatureFlags = []string{"disabled:live-updates=true"}
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("default")
+	assert.True(t, liveUpdatesEnabled)
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("disabled")
+	assert.False(t, liveUpdatesEnabled)
+
+	// fetched namespace doesn't invalidate cache.
+	require.Nil(t, service.ProcessEvent(newNamespaceEvent(t, events.NamespaceEventActionFetched, "default")))
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("default")
+	assert.True(t, liveUpdatesEnabled)
+
+	// deleted namespace invalidates only its own cached project information.
+	require.Nil(t, service.ProcessEvent(newNamespaceEvent(t, events.NamespaceEventActionDeleted, "default")))
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("default")
+	assert.False(t, liveUpdatesEnabled)
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("disabled")
+	assert.False(t, liveUpdatesEnabled)
+
+	// updated namespace invalidates the whole cache, as the previous namespace code is unknown.
+	require.Nil(t, service.ProcessEvent(newNamespaceEvent(t, events.NamespaceEventActionUpdated, "renamed")))
+	_, _, liveUpdatesEnabled = service.GetProjectInformation("disabled")
+	assert.True(t, liveUpdatesEnabled)
+}
+
+func TestService_Subscribe_Ok(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := &config.Config{
+		LiveUpdatesEnabled: true,
+		ProjectCacheTTL:    time.Hour,
+	}
+	service := newProjectService(cfg)
+	listener, err := dao.NewNamespaceListener(ctx, &gorm.DB{Config: &gorm.Config{Dialector: sqlite.Dialector{}}})
+	require.Nil(t, err)
+	service.Subscribe(ctx, listener)
+
+	_, _, liveUpdatesEnabled := service.GetProjectInformation("default")
+	assert.True(t, liveUpdatesEnabled)
+
+	// cached project information is invalidated by namespace event delivered via listener.
+	cfg.LiveUpdatesEnabled = false
+	require.Nil(t, listener.Publish(ctx, nil, newNamespaceEvent(t, events.NamespaceEventActionUpdated, "default")))
+	assert.Eventually(t, func() bool {
+		_, _, liveUpdatesEnabled := service.GetProjectInformation("default")
+		return !liveUpdatesEnabled
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestService_ProcessEvent_Error(t *testing.T) {
+	service := newProjectService(&config.Config{ProjectCacheTTL: time.Hour})
+	assert.NotNil(t, service.ProcessEvent("incorrect"))
+}
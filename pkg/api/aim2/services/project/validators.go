@@ -1,6 +1,7 @@
 package project
 
 import (
+	"encoding/json"
 	"slices"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
@@ -24,5 +25,19 @@ func ValidateGetProjectsRequest(req *request.GetProjectParamsRequest) error {
 			return api.NewInvalidParameterValueError("%q is not a valid Sequence", sequence)
 		}
 	}
+	if req.Context != "" {
+		var metricContext map[string]any
+		if err := json.Unmarshal([]byte(req.Context), &metricContext); err != nil {
+			return api.NewInvalidParameterValueError("context should be a valid JSON object: %s", err)
+		}
+	}
+	return nil
+}
+
+// ValidateGetProjectParamValuesRequest validates `GET /projects/params/values` request.
+func ValidateGetProjectParamValuesRequest(req *request.GetProjectParamValuesRequest) error {
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("param key should be provided")
+	}
 	return nil
 }
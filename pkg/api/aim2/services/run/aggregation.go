@@ -0,0 +1,108 @@
+package run
+
+import (
+	"math"
+	"slices"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+)
+
+// supported fill modes for metric aggregation.
+const (
+	// AggregateFillNone aggregates only steps which have values for every run.
+	AggregateFillNone = "none"
+	// AggregateFillLast carries forward the last known value of a run into the steps it has no value for.
+	AggregateFillLast = "last"
+)
+
+// MetricAggregator aggregates metric values of several runs step by step.
+// Values have to be added ordered by step, so only values of the current step are kept in memory.
+type MetricAggregator struct {
+	runsCount   int
+	fill        string
+	percentiles []float64
+	step        int64
+	current     map[string]float64
+	last        map[string]float64
+}
+
+// NewMetricAggregator creates new MetricAggregator instance.
+func NewMetricAggregator(runsCount int, fill string, percentiles []float64) *MetricAggregator {
+	return &MetricAggregator{
+		runsCount:   runsCount,
+		fill:        fill,
+		percentiles: percentiles,
+		current:     make(map[string]float64, runsCount),
+		last:        make(map[string]float64, runsCount),
+	}
+}
+
+// Add adds run metric value of the provided step. When value belongs to the next step,
+// the aggregate of the previous step is returned, if the previous step could be aggregated.
+func (a *MetricAggregator) Add(runID string, step int64, value float64) *models.MetricAggregate {
+	var aggregate *models.MetricAggregate
+	if len(a.current) > 0 && step != a.step {
+		aggregate = a.Flush()
+	}
+	a.step = step
+	// if run has several values for the same step, the latest one wins.
+	a.current[runID] = value
+	return aggregate
+}
+
+// Flush returns the aggregate of the current step and starts a new one.
+func (a *MetricAggregator) Flush() *models.MetricAggregate {
+	if len(a.current) == 0 {
+		return nil
+	}
+
+	values := a.current
+	if a.fill == AggregateFillLast {
+		for runID, value := range a.current {
+			a.last[runID] = value
+		}
+		values = a.last
+	}
+
+	var aggregate *models.MetricAggregate
+	if len(values) == a.runsCount {
+		aggregate = a.aggregate(values)
+	}
+	a.current = make(map[string]float64, a.runsCount)
+	return aggregate
+}
+
+// aggregate calculates aggregate of the provided values.
+func (a *MetricAggregator) aggregate(values map[string]float64) *models.MetricAggregate {
+	sorted := make([]float64, 0, len(values))
+	for _, value := range values {
+		sorted = append(sorted, value)
+	}
+	slices.Sort(sorted)
+
+	// use incremental mean to avoid overflow on huge values.
+	mean := 0.0
+	for i, value := range sorted {
+		mean += (value - mean) / float64(i+1)
+	}
+
+	aggregate := models.MetricAggregate{
+		Step:        a.step,
+		Count:       len(sorted),
+		Mean:        mean,
+		Min:         sorted[0],
+		Max:         sorted[len(sorted)-1],
+		Percentiles: make([]float64, len(a.percentiles)),
+	}
+	for i, percentile := range a.percentiles {
+		aggregate.Percentiles[i] = calculatePercentile(sorted, percentile)
+	}
+	return &aggregate
+}
+
+// calculatePercentile calculates percentile of sorted values using linear interpolation between closest ranks.
+func calculatePercentile(sorted []float64, percentile float64) float64 {
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower, upper := int(math.Floor(rank)), int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
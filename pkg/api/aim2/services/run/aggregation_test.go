@@ -0,0 +1,104 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+)
+
+type metricValue struct {
+	runID string
+	step  int64
+	value float64
+}
+
+func aggregate(aggregator *MetricAggregator, values []metricValue) []models.MetricAggregate {
+	var result []models.MetricAggregate
+	for _, v := range values {
+		if aggregate := aggregator.Add(v.runID, v.step, v.value); aggregate != nil {
+			result = append(result, *aggregate)
+		}
+	}
+	if aggregate := aggregator.Flush(); aggregate != nil {
+		result = append(result, *aggregate)
+	}
+	return result
+}
+
+func TestMetricAggregator_Ok(t *testing.T) {
+	// run3 is shorter than run1 and run2 and has no value for step 1.
+	values := []metricValue{
+		{runID: "run1", step: 0, value: 1},
+		{runID: "run2", step: 0, value: 2},
+		{runID: "run3", step: 0, value: 6},
+		{runID: "run1", step: 1, value: 2},
+		{runID: "run2", step: 1, value: 4},
+		{runID: "run1", step: 2, value: 3},
+		{runID: "run2", step: 2, value: 6},
+		{runID: "run3", step: 2, value: 12},
+		{runID: "run1", step: 3, value: 4},
+		{runID: "run2", step: 3, value: 8},
+	}
+
+	tests := []struct {
+		name     string
+		fill     string
+		expected []models.MetricAggregate
+	}{
+		{
+			name: "FillNone",
+			fill: AggregateFillNone,
+			expected: []models.MetricAggregate{
+				{Step: 0, Count: 3, Mean: 3, Min: 1, Max: 6, Percentiles: []float64{2, 5.2}},
+				{Step: 2, Count: 3, Mean: 7, Min: 3, Max: 12, Percentiles: []float64{6, 10.8}},
+			},
+		},
+		{
+			name: "FillLast",
+			fill: AggregateFillLast,
+			expected: []models.MetricAggregate{
+				{Step: 0, Count: 3, Mean: 3, Min: 1, Max: 6, Percentiles: []float64{2, 5.2}},
+				{Step: 1, Count: 3, Mean: 4, Min: 2, Max: 6, Percentiles: []float64{4, 5.6}},
+				{Step: 2, Count: 3, Mean: 7, Min: 3, Max: 12, Percentiles: []float64{6, 10.8}},
+				{Step: 3, Count: 3, Mean: 8, Min: 4, Max: 12, Percentiles: []float64{8, 11.2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := aggregate(NewMetricAggregator(3, tt.fill, []float64{50, 90}), values)
+			assert.Equal(t, len(tt.expected), len(result))
+			for i := range tt.expected {
+				assert.Equal(t, tt.expected[i].Step, result[i].Step)
+				assert.Equal(t, tt.expected[i].Count, result[i].Count)
+				assert.InDelta(t, tt.expected[i].Mean, result[i].Mean, 1e-9)
+				assert.Equal(t, tt.expected[i].Min, result[i].Min)
+				assert.Equal(t, tt.expected[i].Max, result[i].Max)
+				assert.InDeltaSlice(t, tt.expected[i].Percentiles, result[i].Percentiles, 1e-9)
+			}
+		})
+	}
+}
+
+func TestMetricAggregator_FillLast_RunStartsLater_Ok(t *testing.T) {
+	// steps before run2 has any value can't be aggregated even with `last` fill mode.
+	result := aggregate(NewMetricAggregator(2, AggregateFillLast, nil), []metricValue{
+		{runID: "run1", step: 0, value: 1},
+		{runID: "run1", step: 1, value: 3},
+		{runID: "run2", step: 1, value: 5},
+	})
+	assert.Equal(t, []models.MetricAggregate{
+		{Step: 1, Count: 2, Mean: 4, Min: 3, Max: 5, Percentiles: []float64{}},
+	}, result)
+}
+
+func TestCalculatePercentile_Ok(t *testing.T) {
+	values := []float64{1, 2, 3, 4}
+	assert.Equal(t, 1.0, calculatePercentile(values, 0))
+	assert.Equal(t, 2.5, calculatePercentile(values, 50))
+	assert.Equal(t, 4.0, calculatePercentile(values, 100))
+	assert.Equal(t, 7.0, calculatePercentile([]float64{7}, 75))
+}
@@ -0,0 +1,74 @@
+package run
+
+import (
+	"slices"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+)
+
+// MetricSeries represents metric values of a single run ordered by step.
+type MetricSeries struct {
+	steps  []int64
+	values []float64
+}
+
+// Add adds metric value of the provided step. Values have to be added ordered by step.
+func (s *MetricSeries) Add(step int64, value float64) {
+	// if run has several values for the same step, the latest one wins.
+	if n := len(s.steps); n > 0 && s.steps[n-1] == step {
+		s.values[n-1] = value
+		return
+	}
+	s.steps = append(s.steps, step)
+	s.values = append(s.values, value)
+}
+
+// Len returns number of steps in the series.
+func (s *MetricSeries) Len() int {
+	return len(s.steps)
+}
+
+// ValueAt returns value of the provided step. When interpolate is set and the series has no value
+// for the step, the value is linearly interpolated between the closest steps around it.
+// Steps outside the range of the series can't be interpolated.
+func (s *MetricSeries) ValueAt(step int64, interpolate bool) (float64, bool) {
+	i, found := slices.BinarySearch(s.steps, step)
+	if found {
+		return s.values[i], true
+	}
+	if !interpolate || i == 0 || i == len(s.steps) {
+		return 0, false
+	}
+	lowerStep, upperStep := s.steps[i-1], s.steps[i]
+	lowerValue, upperValue := s.values[i-1], s.values[i]
+	return lowerValue + (upperValue-lowerValue)*float64(step-lowerStep)/float64(upperStep-lowerStep), true
+}
+
+// JoinMetricSeries joins two metric series on step. Steps present in only one series are either
+// dropped or, when interpolate is set, joined with the value interpolated from the other series.
+func JoinMetricSeries(x, y *MetricSeries, interpolate bool) []models.JoinedMetricPoint {
+	steps := x.steps
+	if interpolate {
+		steps = append(slices.Clone(x.steps), y.steps...)
+		slices.Sort(steps)
+		steps = slices.Compact(steps)
+	}
+
+	points := make([]models.JoinedMetricPoint, 0, len(steps))
+	for _, step := range steps {
+		xValue, ok := x.ValueAt(step, interpolate)
+		if !ok {
+			continue
+		}
+		yValue, ok := y.ValueAt(step, interpolate)
+		if !ok {
+			continue
+		}
+		points = append(points, models.JoinedMetricPoint{
+			Step: step,
+			X:    xValue,
+			Y:    yValue,
+		})
+	}
+	return points
+}
@@ -0,0 +1,91 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+)
+
+func newMetricSeries(values map[int64]float64, steps ...int64) *MetricSeries {
+	series := &MetricSeries{}
+	for _, step := range steps {
+		series.Add(step, values[step])
+	}
+	return series
+}
+
+func TestJoinMetricSeries_Ok(t *testing.T) {
+	tests := []struct {
+		name        string
+		x           *MetricSeries
+		y           *MetricSeries
+		interpolate bool
+		expected    []models.JoinedMetricPoint
+	}{
+		{
+			name:        "SameSteps",
+			x:           newMetricSeries(map[int64]float64{0: 0.1, 1: 0.2, 2: 0.3}, 0, 1, 2),
+			y:           newMetricSeries(map[int64]float64{0: 10, 1: 20, 2: 30}, 0, 1, 2),
+			interpolate: false,
+			expected: []models.JoinedMetricPoint{
+				{Step: 0, X: 0.1, Y: 10},
+				{Step: 1, X: 0.2, Y: 20},
+				{Step: 2, X: 0.3, Y: 30},
+			},
+		},
+		{
+			name:        "DifferentStepsDropped",
+			x:           newMetricSeries(map[int64]float64{0: 1, 2: 3, 4: 5}, 0, 2, 4),
+			y:           newMetricSeries(map[int64]float64{0: 10, 1: 15, 4: 50, 5: 60}, 0, 1, 4, 5),
+			interpolate: false,
+			expected: []models.JoinedMetricPoint{
+				{Step: 0, X: 1, Y: 10},
+				{Step: 4, X: 5, Y: 50},
+			},
+		},
+		{
+			name:        "DifferentStepsInterpolated",
+			x:           newMetricSeries(map[int64]float64{0: 1, 2: 3, 4: 5}, 0, 2, 4),
+			y:           newMetricSeries(map[int64]float64{0: 10, 1: 15, 4: 50, 5: 60}, 0, 1, 4, 5),
+			interpolate: true,
+			expected: []models.JoinedMetricPoint{
+				{Step: 0, X: 1, Y: 10},
+				{Step: 1, X: 2, Y: 15},
+				{Step: 2, X: 3, Y: 15 + 35.0/3},
+				{Step: 4, X: 5, Y: 50},
+			},
+		},
+		{
+			name:        "NoOverlappingSteps",
+			x:           newMetricSeries(map[int64]float64{0: 1, 1: 2}, 0, 1),
+			y:           newMetricSeries(map[int64]float64{2: 1, 3: 2}, 2, 3),
+			interpolate: true,
+			expected:    []models.JoinedMetricPoint{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := JoinMetricSeries(tt.x, tt.y, tt.interpolate)
+			assert.Equal(t, len(tt.expected), len(points))
+			for i := range tt.expected {
+				assert.Equal(t, tt.expected[i].Step, points[i].Step)
+				assert.InDelta(t, tt.expected[i].X, points[i].X, 1e-9)
+				assert.InDelta(t, tt.expected[i].Y, points[i].Y, 1e-9)
+			}
+		})
+	}
+}
+
+func TestMetricSeries_Add_SameStep(t *testing.T) {
+	series := &MetricSeries{}
+	series.Add(0, 1)
+	series.Add(0, 2)
+	series.Add(1, 3)
+
+	assert.Equal(t, 2, series.Len())
+	value, ok := series.ValueAt(0, false)
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, value)
+}
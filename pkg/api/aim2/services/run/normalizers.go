@@ -11,3 +11,11 @@ func NormaliseGetRunInfoRequest(req *request.GetRunInfoRequest) *request.GetRunI
 	}
 	return req
 }
+
+// NormaliseGetRunsMetricsRequest normalizes request object for `POST /runs/search/metric/batch` endpoint.
+func NormaliseGetRunsMetricsRequest(req *request.GetRunsMetricsRequest) *request.GetRunsMetricsRequest {
+	if req.Steps == 0 {
+		req.Steps = RunsMetricsDefaultSteps
+	}
+	return req
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"slices"
 
 	"github.com/rotisserie/eris"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
 )
 
@@ -24,16 +27,19 @@ const (
 
 // Service provides service layer to work with `run` business logic.
 type Service struct {
+	config           *config.Config
 	runRepository    repositories.RunRepositoryProvider
 	metricRepository repositories.MetricRepositoryProvider
 }
 
 // NewService creates new Service instance.
 func NewService(
+	config *config.Config,
 	runRepository repositories.RunRepositoryProvider,
 	metricRepository repositories.MetricRepositoryProvider,
 ) *Service {
 	return &Service{
+		config:           config,
 		runRepository:    runRepository,
 		metricRepository: metricRepository,
 	}
@@ -88,18 +94,20 @@ func (s Service) GetRunMetrics(
 func (s Service) GetRunsActive(
 	ctx context.Context, namespaceID uint, req *request.GetRunsActiveRequest,
 ) ([]models.Run, error) {
-	runs, err := s.runRepository.GetByNamespaceIDAndStatus(ctx, namespaceID, models.StatusRunning)
+	req.Limit = s.config.GetPageSize(req.Limit)
+	runs, err := s.runRepository.GetActiveByNamespaceID(ctx, namespaceID, *req)
 	if err != nil {
-		return nil, api.NewInternalError("error ative runs: %s", err)
+		return nil, api.NewInternalError("error getting active runs: %s", err)
 	}
 	return runs, nil
 }
 
 // SearchRuns returns the list of runs by provided search criteria.
 func (s Service) SearchRuns(
-	ctx context.Context, namespaceID uint, tzOffset int, req request.SearchRunsRequest,
+	ctx context.Context, namespaceID uint, tzOffset int, req *request.SearchRunsRequest,
 ) ([]models.Run, int64, error) {
-	runs, total, err := s.runRepository.SearchRuns(ctx, namespaceID, tzOffset, req)
+	req.Limit = s.config.GetPageSize(req.Limit)
+	runs, total, err := s.runRepository.SearchRuns(ctx, namespaceID, tzOffset, *req)
 	if err != nil {
 		return nil, 0, api.NewInternalError("error searching runs: %s", err)
 	}
@@ -159,6 +167,156 @@ func (s Service) SearchAlignedMetrics(
 	return rows, next, capacity, nil
 }
 
+// AggregateMetrics returns the cursor and the iterator over per-step aggregates of requested runs metric.
+func (s Service) AggregateMetrics(
+	ctx context.Context, namespaceID uint, req *request.AggregateMetricsRequest,
+) (*sql.Rows, func(*sql.Rows) (*models.MetricAggregate, error), error) {
+	if err := ValidateAggregateMetricsRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	runIDs, err := s.getExistingRunIDs(ctx, namespaceID, req.RunIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	contextID, err := s.getMetricContextID(ctx, req.Context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, scan, err := s.metricRepository.GetMetricHistoriesByRunIDs(
+		ctx, namespaceID, runIDs, req.Name, contextID,
+	)
+	if err != nil {
+		return nil, nil, api.NewInternalError("error getting metric histories: %s", err)
+	}
+
+	aggregator := NewMetricAggregator(len(runIDs), req.Fill, req.Percentiles)
+	return rows, func(rows *sql.Rows) (*models.MetricAggregate, error) {
+		for rows.Next() {
+			metric, err := scan(rows)
+			if err != nil {
+				return nil, eris.Wrap(err, "error getting next metric")
+			}
+			if metric.IsNan {
+				continue
+			}
+			if aggregate := aggregator.Add(metric.RunID, metric.Step, metric.Value); aggregate != nil {
+				return aggregate, nil
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return nil, eris.Wrap(err, "error iterating metrics")
+		}
+		return aggregator.Flush(), nil
+	}, nil
+}
+
+// JoinMetrics returns the cursor and the iterator over requested pair of metrics joined on step
+// within each run. Runs without joined points are skipped.
+func (s Service) JoinMetrics(
+	ctx context.Context, namespaceID uint, req *request.JoinMetricsRequest,
+) (*sql.Rows, func(*sql.Rows) (*models.JoinedMetrics, error), error) {
+	if err := ValidateJoinMetricsRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	runIDs, err := s.getExistingRunIDs(ctx, namespaceID, req.RunIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	contextID, err := s.getMetricContextID(ctx, req.Context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, scan, err := s.metricRepository.GetMetricHistoriesByRunIDsAndKeys(
+		ctx, namespaceID, runIDs, []string{req.XMetric, req.YMetric}, contextID,
+	)
+	if err != nil {
+		return nil, nil, api.NewInternalError("error getting metric histories: %s", err)
+	}
+
+	// metrics are ordered by run, so the series of the current run are joined
+	// as soon as the first metric of the next run is read.
+	var pending *models.Metric
+	return rows, func(rows *sql.Rows) (*models.JoinedMetrics, error) {
+		var err error
+		for {
+			if pending == nil {
+				if !rows.Next() {
+					break
+				}
+				if pending, err = scan(rows); err != nil {
+					return nil, eris.Wrap(err, "error getting next metric")
+				}
+			}
+
+			runID, x, y := pending.RunID, &MetricSeries{}, &MetricSeries{}
+			for pending != nil && pending.RunID == runID {
+				if !pending.IsNan {
+					// the same metric could be requested for both axes.
+					if pending.Key == req.XMetric {
+						x.Add(pending.Step, pending.Value)
+					}
+					if pending.Key == req.YMetric {
+						y.Add(pending.Step, pending.Value)
+					}
+				}
+				pending = nil
+				if rows.Next() {
+					if pending, err = scan(rows); err != nil {
+						return nil, eris.Wrap(err, "error getting next metric")
+					}
+				}
+			}
+
+			if points := JoinMetricSeries(x, y, req.Interpolate); len(points) > 0 {
+				return &models.JoinedMetrics{
+					RunID:  runID,
+					Points: points,
+				}, nil
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return nil, eris.Wrap(err, "error iterating metrics")
+		}
+		return nil, nil
+	}, nil
+}
+
+// GetRunsMetrics returns downsampled histories of requested metrics for several runs at once.
+func (s Service) GetRunsMetrics(
+	ctx context.Context, namespaceID uint, req *request.GetRunsMetricsRequest,
+) ([]string, []models.AlignedMetric, error) {
+	req = NormaliseGetRunsMetricsRequest(req)
+	if err := ValidateGetRunsMetricsRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	runIDs, err := s.getExistingRunIDs(ctx, namespaceID, req.RunIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series := make([]models.MetricSeriesKey, len(req.Metrics))
+	for i, metric := range req.Metrics {
+		contextID, err := s.getMetricContextID(ctx, metric.Context)
+		if err != nil {
+			return nil, nil, err
+		}
+		series[i] = models.MetricSeriesKey{Key: metric.Name, ContextID: contextID}
+	}
+
+	metrics, err := s.metricRepository.GetSampledMetricHistoriesByRunIDs(
+		ctx, namespaceID, runIDs, series, req.MetricHistoryWindow, req.Steps,
+	)
+	if err != nil {
+		return nil, nil, api.NewInternalError("error getting metric histories: %s", err)
+	}
+	return runIDs, metrics, nil
+}
+
 // DeleteRun deletes requested run.
 func (s Service) DeleteRun(
 	ctx context.Context, namespaceID uint, req *request.DeleteRunRequest,
@@ -190,7 +348,22 @@ func (s Service) UpdateRun(
 	if run == nil {
 		return api.NewResourceDoesNotExistError("run '%s' not found", req.ID)
 	}
+	if req.Name != nil {
+		if err := api.ValidateName("run", *req.Name, s.config.GetMaxRunNameLength()); err != nil {
+			return err
+		}
+	}
 
+	// rename goes first, as archiving changes the version of the run, which has been read.
+	if req.Name != nil {
+		run.Name = *req.Name
+		if err := s.runRepository.Update(ctx, run); err != nil {
+			if errors.As(err, &repositories.RunVersionConflictError{}) {
+				return api.NewResourceConflictError("error updating run %s: %s", req.ID, err)
+			}
+			return api.NewInternalError("error updating run %s: %s", req.ID, err)
+		}
+	}
 	if req.Archived != nil {
 		if *req.Archived {
 			if err := s.runRepository.ArchiveBatch(ctx, namespaceID, []string{run.ID}); err != nil {
@@ -203,12 +376,6 @@ func (s Service) UpdateRun(
 		}
 	}
 
-	if req.Name != nil {
-		run.Name = *req.Name
-		if err := s.runRepository.Update(ctx, run); err != nil {
-			return api.NewInternalError("error updating run %s: %s", req.ID, err)
-		}
-	}
 	return nil
 }
 
@@ -234,3 +401,44 @@ func (s Service) ProcessBatch(
 	}
 	return nil
 }
+
+// getExistingRunIDs returns sorted unique run ids, making sure that all of them exist in the namespace.
+func (s Service) getExistingRunIDs(ctx context.Context, namespaceID uint, ids []string) ([]string, error) {
+	runIDs := slices.Clone(ids)
+	slices.Sort(runIDs)
+	runIDs = slices.Compact(runIDs)
+	existingIDs, err := s.runRepository.GetRunIDsByNamespaceIDAndRunIDs(ctx, namespaceID, runIDs)
+	if err != nil {
+		return nil, api.NewInternalError("error getting runs: %s", err)
+	}
+	for _, runID := range runIDs {
+		if !slices.Contains(existingIDs, runID) {
+			return nil, api.NewResourceDoesNotExistError("run '%s' not found", runID)
+		}
+	}
+	return runIDs, nil
+}
+
+// getMetricContextID returns id of the provided metric context. Empty context is used by default.
+func (s Service) getMetricContextID(ctx context.Context, metricContext map[string]any) (uint, error) {
+	if metricContext == nil {
+		metricContext = map[string]any{}
+	}
+	data, err := json.Marshal(metricContext)
+	if err != nil {
+		return 0, api.NewBadRequestError("error serializing context: %s", err)
+	}
+	contexts, err := s.metricRepository.GetContextListByContextObjects(
+		ctx, map[string]types.JSONB{string(data): data},
+	)
+	if err != nil {
+		return 0, api.NewInternalError("error getting context list: %s", err)
+	}
+	contextIndex := slices.IndexFunc(contexts, func(context models.Context) bool {
+		return common.CompareJson(data, context.Json)
+	})
+	if contextIndex == -1 {
+		return 0, api.NewResourceDoesNotExistError("metric context '%s' not found", data)
+	}
+	return contexts[contextIndex].ID, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // SupportedSequences list of supported Sequences for `GET /runs/:id/info` request.
@@ -19,6 +20,21 @@ var SupportedSequences = []string{
 	"metric",
 }
 
+// SupportedRunInfoFields list of supported Fields for `GET /runs/:id/info` request.
+var SupportedRunInfoFields = []models.ResponseField{
+	models.ResponseFieldParams,
+	models.ResponseFieldMetrics,
+	models.ResponseFieldTags,
+	models.ResponseFieldSummary,
+}
+
+// limits of `POST /runs/search/metric/batch` request, which bound the size of the response.
+const (
+	RunsMetricsMaxRuns      = 100
+	RunsMetricsMaxSteps     = 5000
+	RunsMetricsDefaultSteps = 500
+)
+
 // ValidateGetRunInfoRequest validates `GET /runs/:id/info` request.
 func ValidateGetRunInfoRequest(req *request.GetRunInfoRequest) error {
 	for _, sequence := range req.Sequences {
@@ -26,5 +42,75 @@ func ValidateGetRunInfoRequest(req *request.GetRunInfoRequest) error {
 			return api.NewInvalidParameterValueError("%q is not a valid Sequence", sequence)
 		}
 	}
+	for _, field := range models.NewResponseFields(req.Fields) {
+		if !slices.Contains(SupportedRunInfoFields, field) {
+			return api.NewInvalidParameterValueError("%q is not a valid field", field)
+		}
+	}
+	return nil
+}
+
+// ValidateAggregateMetricsRequest validates `POST /runs/search/metric/aggregate` request.
+func ValidateAggregateMetricsRequest(req *request.AggregateMetricsRequest) error {
+	if len(req.RunIDs) == 0 {
+		return api.NewInvalidParameterValueError("at least one run id should be provided")
+	}
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("metric name should be provided")
+	}
+	if !slices.Contains([]string{"", AggregateFillNone, AggregateFillLast}, req.Fill) {
+		return api.NewInvalidParameterValueError("%q is not a valid fill mode", req.Fill)
+	}
+	for _, percentile := range req.Percentiles {
+		if percentile < 0 || percentile > 100 {
+			return api.NewInvalidParameterValueError("percentile %v should be in range [0, 100]", percentile)
+		}
+	}
+	return nil
+}
+
+// ValidateJoinMetricsRequest validates `POST /runs/search/metric/join` request.
+func ValidateJoinMetricsRequest(req *request.JoinMetricsRequest) error {
+	if len(req.RunIDs) == 0 {
+		return api.NewInvalidParameterValueError("at least one run id should be provided")
+	}
+	if req.XMetric == "" {
+		return api.NewInvalidParameterValueError("x metric name should be provided")
+	}
+	if req.YMetric == "" {
+		return api.NewInvalidParameterValueError("y metric name should be provided")
+	}
+	return nil
+}
+
+// ValidateGetRunsMetricsRequest validates `POST /runs/search/metric/batch` request.
+func ValidateGetRunsMetricsRequest(req *request.GetRunsMetricsRequest) error {
+	if len(req.RunIDs) == 0 {
+		return api.NewInvalidParameterValueError("at least one run id should be provided")
+	}
+	if len(req.RunIDs) > RunsMetricsMaxRuns {
+		return api.NewInvalidParameterValueError("no more than %d run ids could be provided", RunsMetricsMaxRuns)
+	}
+	if len(req.Metrics) == 0 {
+		return api.NewInvalidParameterValueError("at least one metric should be provided")
+	}
+	for _, metric := range req.Metrics {
+		if metric.Name == "" {
+			return api.NewInvalidParameterValueError("metric name should be provided")
+		}
+	}
+	if req.Steps < 1 || req.Steps > RunsMetricsMaxSteps {
+		return api.NewInvalidParameterValueError("steps %d should be in range [1, %d]", req.Steps, RunsMetricsMaxSteps)
+	}
+	if req.MinStep != nil && req.MaxStep != nil && *req.MinStep > *req.MaxStep {
+		return api.NewInvalidParameterValueError(
+			"min_step %d should be less than or equal to max_step %d", *req.MinStep, *req.MaxStep,
+		)
+	}
+	if req.StartTime != nil && req.EndTime != nil && *req.StartTime > *req.EndTime {
+		return api.NewInvalidParameterValueError(
+			"start_time %d should be less than or equal to end_time %d", *req.StartTime, *req.EndTime,
+		)
+	}
 	return nil
 }
@@ -0,0 +1,12 @@
+package request
+
+// CreateAPIKeyRequest is a request object for `POST /mlflow/api-keys/create` endpoint.
+type CreateAPIKeyRequest struct {
+	Name        string `json:"name"`
+	AccessLevel string `json:"access_level"`
+}
+
+// RevokeAPIKeyRequest is a request object for `POST /mlflow/api-keys/revoke` endpoint.
+type RevokeAPIKeyRequest struct {
+	ID string `json:"id"`
+}
@@ -2,9 +2,12 @@ package request
 
 // ListArtifactsRequest is a request object for `GET /mlflow/artifacts/list` endpoint.
 type ListArtifactsRequest struct {
-	Path    string `query:"path"`
-	RunID   string `query:"run_id"`
-	RunUUID string `query:"run_uuid"`
+	Path       string `query:"path"`
+	RunID      string `query:"run_id"`
+	RunUUID    string `query:"run_uuid"`
+	Recursive  bool   `query:"recursive"`
+	MaxResults int64  `query:"max_results"`
+	PageToken  string `query:"page_token"`
 }
 
 // GetRunID returns Run ID.
@@ -17,9 +20,12 @@ func (r ListArtifactsRequest) GetRunID() string {
 
 // GetArtifactRequest is for retrieving an individual artifact item.
 type GetArtifactRequest struct {
-	Path    string `query:"path"`
-	RunID   string `query:"run_id"`
-	RunUUID string `query:"run_uuid"`
+	Path            string `query:"path"`
+	RunID           string `query:"run_id"`
+	RunUUID         string `query:"run_uuid"`
+	Inline          bool   `query:"inline"`
+	IfNoneMatch     string `reqHeader:"If-None-Match"`
+	IfModifiedSince string `reqHeader:"If-Modified-Since"`
 }
 
 // GetRunID returns RunID if available, otherwise RunUUID.
@@ -29,3 +35,36 @@ func (r GetArtifactRequest) GetRunID() string {
 	}
 	return r.RunUUID
 }
+
+// CreateArtifactUploadRequest is a request object for `POST /artifacts/uploads/create` endpoint.
+type CreateArtifactUploadRequest struct {
+	Path    string `json:"path"`
+	RunID   string `json:"run_id"`
+	RunUUID string `json:"run_uuid"`
+}
+
+// GetRunID returns RunID if available, otherwise RunUUID.
+func (r CreateArtifactUploadRequest) GetRunID() string {
+	if r.RunID != "" {
+		return r.RunID
+	}
+	return r.RunUUID
+}
+
+// UploadArtifactPartRequest is a request object for `PUT /artifacts/uploads/part` endpoint.
+// Content of the part is sent as a request body.
+type UploadArtifactPartRequest struct {
+	UploadID   string `query:"upload_id"`
+	PartNumber int    `query:"part_number"`
+}
+
+// CompleteArtifactUploadRequest is a request object for `POST /artifacts/uploads/complete` endpoint.
+type CompleteArtifactUploadRequest struct {
+	UploadID  string `json:"upload_id"`
+	PartCount int    `json:"part_count"`
+}
+
+// AbortArtifactUploadRequest is a request object for `POST /artifacts/uploads/abort` endpoint.
+type AbortArtifactUploadRequest struct {
+	UploadID string `json:"upload_id"`
+}
@@ -11,3 +11,23 @@ const (
 type PageToken struct {
 	Offset int32 `json:"offset"`
 }
+
+// MetricHistoryPageToken points to the last metric of the previous page of metric history.
+// Unlike PageToken, it stays valid when new metrics are logged while paginating through the history.
+type MetricHistoryPageToken struct {
+	Step      int64 `json:"step"`
+	Timestamp int64 `json:"timestamp"`
+	ContextID uint  `json:"context_id"`
+	Iter      int64 `json:"iter"`
+}
+
+// MetricHistoryWindow limits metric history to the given range of steps and/or timestamps.
+// All the bounds are optional and inclusive. When Stride is provided, only every Nth point of
+// each metric series within the window is returned, while the first and the last ones are always kept.
+type MetricHistoryWindow struct {
+	MinStep   *int64 `query:"min_step" json:"min_step"`
+	MaxStep   *int64 `query:"max_step" json:"max_step"`
+	StartTime *int64 `query:"start_time" json:"start_time"`
+	EndTime   *int64 `query:"end_time" json:"end_time"`
+	Stride    int64  `query:"stride" json:"stride"`
+}
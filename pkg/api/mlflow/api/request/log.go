@@ -62,4 +62,6 @@ type LogBatchRequest struct {
 	Tags    []TagPartialRequest    `json:"tags,omitempty"`
 	Params  []ParamPartialRequest  `json:"params,omitempty"`
 	Metrics []MetricPartialRequest `json:"metrics,omitempty"`
+	// ValidateOnly makes request to be fully validated without persisting anything.
+	ValidateOnly bool `json:"validate_only,omitempty"`
 }
@@ -2,9 +2,12 @@ package request
 
 // GetMetricHistoryRequest is a request object for `GET /mlflow/metrics/get-history` endpoint.
 type GetMetricHistoryRequest struct {
-	RunID     string `query:"run_id"`
-	RunUUID   string `query:"run_uuid"`
-	MetricKey string `query:"metric_key"`
+	MetricHistoryWindow
+	RunID      string `query:"run_id"`
+	RunUUID    string `query:"run_uuid"`
+	MetricKey  string `query:"metric_key"`
+	PageToken  string `query:"page_token"`
+	MaxResults int    `query:"max_results"`
 }
 
 // GetRunID returns Run RunID.
@@ -17,6 +20,7 @@ func (r GetMetricHistoryRequest) GetRunID() string {
 
 // GetMetricHistoryBulkRequest is a request object for `GET /mlflow/metrics/get-history-bulk` endpoint.
 type GetMetricHistoryBulkRequest struct {
+	MetricHistoryWindow
 	RunIDs     []string `query:"run_id"`
 	MetricKey  string   `query:"metric_key"`
 	MaxResults int      `query:"max_results"`
@@ -24,6 +28,7 @@ type GetMetricHistoryBulkRequest struct {
 
 // GetMetricHistoriesRequest is a request object for `POST /mlflow/metrics/get-histories` endpoint.
 type GetMetricHistoriesRequest struct {
+	MetricHistoryWindow
 	ExperimentIDs []string          `json:"experiment_ids"`
 	RunIDs        []string          `json:"run_ids"`
 	MetricKeys    []string          `json:"metric_keys"`
@@ -31,3 +36,31 @@ type GetMetricHistoriesRequest struct {
 	MaxResults    int32             `json:"max_results"`
 	Context       map[string]string `json:"context"`
 }
+
+// DeleteMetricRequest is a request object for `POST /mlflow/metrics/delete` endpoint.
+// When Context is omitted, metric is deleted in all the contexts, while an empty Context
+// means the default one.
+type DeleteMetricRequest struct {
+	RunID   string         `json:"run_id"`
+	RunUUID string         `json:"run_uuid"`
+	Key     string         `json:"key"`
+	Context map[string]any `json:"context"`
+	Force   bool           `json:"force"`
+}
+
+// GetRunID returns Run RunID.
+func (r DeleteMetricRequest) GetRunID() string {
+	if r.RunID != "" {
+		return r.RunID
+	}
+	return r.RunUUID
+}
+
+// GetBestMetricsRequest is a request object for `POST /mlflow/metrics/get-best` endpoint.
+// When Context is omitted, metric is looked up in the default context.
+type GetBestMetricsRequest struct {
+	ExperimentIDs []string       `json:"experiment_ids"`
+	MetricKey     string         `json:"metric_key"`
+	Context       map[string]any `json:"context"`
+	Direction     string         `json:"direction"`
+}
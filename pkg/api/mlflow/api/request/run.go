@@ -10,6 +10,9 @@ type RunTagPartialRequest struct {
 type GetRunRequest struct {
 	RunID   string `query:"run_id"`
 	RunUUID string `query:"run_uuid"`
+	Fields  string `query:"fields"`
+	// IncludeArtifactStats requests total size and count of the run artifacts, which requires storage listing.
+	IncludeArtifactStats bool `query:"include_artifact_stats"`
 }
 
 // GetRunID returns Run RunID.
@@ -20,6 +23,20 @@ func (r GetRunRequest) GetRunID() string {
 	return r.RunUUID
 }
 
+// ExportRunRequest is a request object for `GET /mlflow/runs/export` endpoint.
+type ExportRunRequest struct {
+	RunID   string `query:"run_id"`
+	RunUUID string `query:"run_uuid"`
+}
+
+// GetRunID returns Run RunID.
+func (r ExportRunRequest) GetRunID() string {
+	if r.RunID != "" {
+		return r.RunID
+	}
+	return r.RunUUID
+}
+
 // CreateRunRequest is a request object for `POST /mlflow/runs/create` endpoint.
 type CreateRunRequest struct {
 	ExperimentID string                 `json:"experiment_id"`
@@ -29,6 +46,14 @@ type CreateRunRequest struct {
 	Tags         []RunTagPartialRequest `json:"tags"`
 }
 
+// CloneRunRequest is a request object for `POST /mlflow/runs/clone` endpoint.
+type CloneRunRequest struct {
+	RunID        string `json:"run_id"`
+	ExperimentID string `json:"experiment_id"`
+	Name         string `json:"run_name"`
+	ResetStatus  bool   `json:"reset_status"`
+}
+
 // UpdateRunRequest is a request object for `POST /mlflow/runs/update` endpoint.
 type UpdateRunRequest struct {
 	RunID   string `json:"run_id"`
@@ -82,6 +107,33 @@ func (r SetRunTagRequest) GetRunID() string {
 	return r.RunUUID
 }
 
+// LogModelRequest is a request object for `POST /mlflow/runs/log-model` endpoint.
+type LogModelRequest struct {
+	RunID     string `json:"run_id"`
+	ModelJSON string `json:"model_json"`
+}
+
+// RunSelectionPartialRequest is a partial request object to select runs either by their IDs
+// or by the search filter across the experiments.
+type RunSelectionPartialRequest struct {
+	RunIDs        []string `json:"run_ids"`
+	ExperimentIDs []string `json:"experiment_ids"`
+	Filter        string   `json:"filter"`
+}
+
+// SetRunsTagRequest is a request object for `POST /mlflow/runs/set-tag-bulk` endpoint.
+type SetRunsTagRequest struct {
+	RunSelectionPartialRequest
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// DeleteRunsTagRequest is a request object for `POST /mlflow/runs/delete-tag-bulk` endpoint.
+type DeleteRunsTagRequest struct {
+	RunSelectionPartialRequest
+	Key string `json:"key"`
+}
+
 // DeleteRunTagRequest is a request object for `POST /mlflow/runs/delete-tag` endpoint.
 type DeleteRunTagRequest struct {
 	RunID string `json:"run_id"`
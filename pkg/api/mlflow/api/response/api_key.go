@@ -0,0 +1,63 @@
+package response
+
+import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// APIKeyPartialResponse is a partial response object for different responses.
+// Only the prefix of the key is returned, because the key itself isn't stored.
+type APIKeyPartialResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Prefix      string `json:"prefix"`
+	AccessLevel string `json:"access_level"`
+	CreatedAt   int64  `json:"created_at"`
+	LastUsedAt  *int64 `json:"last_used_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is a response object for `POST /mlflow/api-keys/create` endpoint.
+// It is the only response, which contains the whole API key.
+type CreateAPIKeyResponse struct {
+	APIKey APIKeyPartialResponse `json:"api_key"`
+	Key    string                `json:"key"`
+}
+
+// NewCreateAPIKeyResponse creates new CreateAPIKeyResponse object.
+func NewCreateAPIKeyResponse(apiKey *models.APIKey, key string) *CreateAPIKeyResponse {
+	return &CreateAPIKeyResponse{
+		APIKey: NewAPIKeyPartialResponse(apiKey),
+		Key:    key,
+	}
+}
+
+// ListAPIKeysResponse is a response object for `GET /mlflow/api-keys/list` endpoint.
+type ListAPIKeysResponse struct {
+	APIKeys []APIKeyPartialResponse `json:"api_keys"`
+}
+
+// NewListAPIKeysResponse creates new ListAPIKeysResponse object.
+func NewListAPIKeysResponse(apiKeys []models.APIKey) *ListAPIKeysResponse {
+	resp := ListAPIKeysResponse{
+		APIKeys: make([]APIKeyPartialResponse, len(apiKeys)),
+	}
+	for i := range apiKeys {
+		resp.APIKeys[i] = NewAPIKeyPartialResponse(&apiKeys[i])
+	}
+	return &resp
+}
+
+// NewAPIKeyPartialResponse creates new APIKeyPartialResponse object.
+func NewAPIKeyPartialResponse(apiKey *models.APIKey) APIKeyPartialResponse {
+	resp := APIKeyPartialResponse{
+		ID:          apiKey.ID.String(),
+		Name:        apiKey.Name,
+		Prefix:      apiKey.Prefix,
+		AccessLevel: string(apiKey.AccessLevel),
+		CreatedAt:   apiKey.CreatedAt.UnixMilli(),
+	}
+	if apiKey.LastUsedAt.Valid {
+		lastUsedAt := apiKey.LastUsedAt.Time.UnixMilli()
+		resp.LastUsedAt = &lastUsedAt
+	}
+	return resp
+}
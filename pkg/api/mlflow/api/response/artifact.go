@@ -1,6 +1,15 @@
 package response
 
-import "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+)
 
 // FilePartialResponse is a partial response object for different responses.
 type FilePartialResponse struct {
@@ -11,14 +20,15 @@ type FilePartialResponse struct {
 
 // ListArtifactsResponse is a response object for `GET mlflow/artifacts/list` endpoint.
 type ListArtifactsResponse struct {
-	Files   []FilePartialResponse `json:"files"`
-	RootURI string                `json:"root_uri"`
+	Files         []FilePartialResponse `json:"files"`
+	RootURI       string                `json:"root_uri"`
+	NextPageToken string                `json:"next_page_token,omitempty"`
 }
 
 // NewListArtifactsResponse creates new instance of ListArtifactsResponse.
 func NewListArtifactsResponse(
-	rootURI string, artifacts []storage.ArtifactObject,
-) *ListArtifactsResponse {
+	rootURI string, artifacts []storage.ArtifactObject, nextOffset int,
+) (*ListArtifactsResponse, error) {
 	response := ListArtifactsResponse{
 		Files:   make([]FilePartialResponse, len(artifacts)),
 		RootURI: rootURI,
@@ -32,5 +42,23 @@ func NewListArtifactsResponse(
 		}
 	}
 
-	return &response
+	// encode `nextPageToken` value.
+	if nextOffset > 0 {
+		var token strings.Builder
+		if err := json.NewEncoder(
+			base64.NewEncoder(base64.StdEncoding, &token),
+		).Encode(request.PageToken{
+			Offset: int32(nextOffset),
+		}); err != nil {
+			return nil, eris.Wrap(err, "error encoding 'nextPageToken' value")
+		}
+		response.NextPageToken = token.String()
+	}
+
+	return &response, nil
+}
+
+// CreateArtifactUploadResponse is a response object for `POST mlflow/artifacts/uploads/create` endpoint.
+type CreateArtifactUploadResponse struct {
+	UploadID string `json:"upload_id"`
 }
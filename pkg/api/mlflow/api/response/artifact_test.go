@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
 )
 
 func TestNewListArtifactsResponse_Ok(t *testing.T) {
-	response := NewListArtifactsResponse("rootUri", []storage.ArtifactObject{
+	response, err := NewListArtifactsResponse("rootUri", []storage.ArtifactObject{
 		{
 			Path:  "path1",
 			Size:  1234567890,
@@ -20,8 +21,9 @@ func TestNewListArtifactsResponse_Ok(t *testing.T) {
 			Size:  0,
 			IsDir: true,
 		},
-	})
+	}, 0)
 
+	require.Nil(t, err)
 	assert.Equal(t, &ListArtifactsResponse{
 		Files: []FilePartialResponse{
 			{
@@ -38,3 +40,26 @@ func TestNewListArtifactsResponse_Ok(t *testing.T) {
 		RootURI: "rootUri",
 	}, response)
 }
+
+func TestNewListArtifactsResponse_WithNextPageToken(t *testing.T) {
+	response, err := NewListArtifactsResponse("rootUri", []storage.ArtifactObject{
+		{
+			Path:  "path1",
+			Size:  1,
+			IsDir: false,
+		},
+	}, 2)
+
+	require.Nil(t, err)
+	assert.Equal(t, &ListArtifactsResponse{
+		Files: []FilePartialResponse{
+			{
+				Path:     "path1",
+				IsDir:    false,
+				FileSize: 1,
+			},
+		},
+		RootURI:       "rootUri",
+		NextPageToken: "eyJvZmZzZXQiOjJ9",
+	}, response)
+}
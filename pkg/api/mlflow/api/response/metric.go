@@ -1,12 +1,17 @@
 package response
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 
 	"github.com/rotisserie/eris"
 
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
 // MetricPartialResponseBulk is a partial response object for GetMetricHistoryBulkResponse.
@@ -28,15 +33,47 @@ type MetricPartialResponse struct {
 	Context   map[string]any `json:"context"`
 }
 
+// MetricHistoriesLineResponse is a single line of NDJSON response for `POST mlflow/metrics/get-histories` endpoint.
+type MetricHistoriesLineResponse struct {
+	RunID     string          `json:"run_id"`
+	Key       string          `json:"key"`
+	Step      int64           `json:"step"`
+	Timestamp int64           `json:"timestamp"`
+	Value     any             `json:"value"`
+	Context   json.RawMessage `json:"context,omitempty"`
+}
+
 // GetMetricHistoryResponse is a response object for `GET mlflow/metrics/get-history` endpoint.
 type GetMetricHistoryResponse struct {
-	Metrics []MetricPartialResponse `json:"metrics"`
+	Metrics       []MetricPartialResponse `json:"metrics"`
+	NextPageToken string                  `json:"next_page_token,omitempty"`
 }
 
 // NewMetricHistoryResponse creates new GetMetricHistoryResponse object.
-func NewMetricHistoryResponse(metrics []models.Metric) (*GetMetricHistoryResponse, error) {
+// If there are more metrics than requested `maxResults`, they are cut and `nextPageToken` is provided.
+func NewMetricHistoryResponse(
+	metrics []models.Metric, maxResults, metricValuePrecision int,
+) (*GetMetricHistoryResponse, error) {
+	// encode `nextPageToken` value.
+	var token string
+	if maxResults > 0 && len(metrics) > maxResults {
+		metrics = metrics[:maxResults]
+		last := metrics[len(metrics)-1]
+		data, err := json.Marshal(request.MetricHistoryPageToken{
+			Step:      last.Step,
+			Timestamp: last.Timestamp,
+			ContextID: last.ContextID,
+			Iter:      last.Iter,
+		})
+		if err != nil {
+			return nil, eris.Wrap(err, "error encoding 'nextPageToken' value")
+		}
+		token = base64.StdEncoding.EncodeToString(data)
+	}
+
 	resp := GetMetricHistoryResponse{
-		Metrics: make([]MetricPartialResponse, len(metrics)),
+		Metrics:       make([]MetricPartialResponse, len(metrics)),
+		NextPageToken: token,
 	}
 
 	mappedContext := map[string]map[string]any{}
@@ -44,7 +81,7 @@ func NewMetricHistoryResponse(metrics []models.Metric) (*GetMetricHistoryRespons
 		resp.Metrics[n] = MetricPartialResponse{
 			Key:       m.Key,
 			Step:      m.Step,
-			Value:     m.Value,
+			Value:     api.NewMetricValue(m.Value, metricValuePrecision),
 			Timestamp: m.Timestamp,
 		}
 
@@ -73,7 +110,7 @@ type GetMetricHistoryBulkResponse struct {
 }
 
 // NewMetricHistoryBulkResponse creates new GetMetricHistoryBulkResponse object.
-func NewMetricHistoryBulkResponse(metrics []models.Metric) *GetMetricHistoryBulkResponse {
+func NewMetricHistoryBulkResponse(metrics []models.Metric, metricValuePrecision int) *GetMetricHistoryBulkResponse {
 	resp := GetMetricHistoryBulkResponse{
 		Metrics: make([]MetricPartialResponseBulk, len(metrics)),
 	}
@@ -83,7 +120,7 @@ func NewMetricHistoryBulkResponse(metrics []models.Metric) *GetMetricHistoryBulk
 			RunID:     m.RunID,
 			Key:       m.Key,
 			Step:      m.Step,
-			Value:     m.Value,
+			Value:     api.NewMetricValue(m.Value, metricValuePrecision),
 			Timestamp: m.Timestamp,
 		}
 		if m.IsNan {
@@ -92,3 +129,35 @@ func NewMetricHistoryBulkResponse(metrics []models.Metric) *GetMetricHistoryBulk
 	}
 	return &resp
 }
+
+// ExperimentBestMetricPartialResponse is a partial response object for GetBestMetricsResponse.
+// RunID and Value are omitted, when none of the experiment runs has the metric.
+type ExperimentBestMetricPartialResponse struct {
+	ExperimentID string `json:"experiment_id"`
+	RunID        string `json:"run_id,omitempty"`
+	Value        any    `json:"value,omitempty"`
+}
+
+// GetBestMetricsResponse is a response object for `POST mlflow/metrics/get-best` endpoint.
+type GetBestMetricsResponse struct {
+	Experiments []ExperimentBestMetricPartialResponse `json:"experiments"`
+}
+
+// NewGetBestMetricsResponse creates new GetBestMetricsResponse object.
+func NewGetBestMetricsResponse(
+	bestMetrics []repositories.ExperimentBestMetric, metricValuePrecision int,
+) *GetBestMetricsResponse {
+	resp := GetBestMetricsResponse{
+		Experiments: make([]ExperimentBestMetricPartialResponse, len(bestMetrics)),
+	}
+	for n, bestMetric := range bestMetrics {
+		resp.Experiments[n] = ExperimentBestMetricPartialResponse{
+			ExperimentID: fmt.Sprintf("%d", bestMetric.ExperimentID),
+		}
+		if bestMetric.RunID != "" {
+			resp.Experiments[n].RunID = bestMetric.RunID
+			resp.Experiments[n].Value = api.NewMetricValue(bestMetric.Value, metricValuePrecision)
+		}
+	}
+	return &resp
+}
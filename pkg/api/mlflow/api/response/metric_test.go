@@ -8,6 +8,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
 func TestNewMetricHistoryResponse_Ok(t *testing.T) {
@@ -62,7 +63,7 @@ func TestNewMetricHistoryResponse_Ok(t *testing.T) {
 						Key:       "key",
 						Timestamp: 1234567890,
 						Step:      1,
-						Value:     123.4,
+						Value:     api.NewMetricValue(123.4, 0),
 						Context:   map[string]any{},
 					},
 				},
@@ -91,7 +92,7 @@ func TestNewMetricHistoryResponse_Ok(t *testing.T) {
 						Key:       "key",
 						Timestamp: 1234567890,
 						Step:      1,
-						Value:     123.4,
+						Value:     api.NewMetricValue(123.4, 0),
 						Context: map[string]interface{}{
 							"key": "value",
 						},
@@ -103,13 +104,57 @@ func TestNewMetricHistoryResponse_Ok(t *testing.T) {
 
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
-			actualResponse, err := NewMetricHistoryResponse(tt.metrics)
+			actualResponse, err := NewMetricHistoryResponse(tt.metrics, 0, 0)
 			require.Nil(t, err)
 			assert.Equal(t, tt.expectedResponse, actualResponse)
 		})
 	}
 }
 
+func TestNewMetricHistoryResponse_WithNextPageToken(t *testing.T) {
+	metrics := make([]models.Metric, 3)
+	for i := range metrics {
+		metrics[i] = models.Metric{
+			Key:       "key",
+			Value:     float64(i + 1),
+			Timestamp: int64(1234567890 + i + 1),
+			RunID:     "run_id",
+			Step:      int64(i + 1),
+			Iter:      int64(i + 1),
+			ContextID: 1,
+			Context:   models.DefaultContext,
+		}
+	}
+
+	response, err := NewMetricHistoryResponse(metrics, 2, 0)
+	require.Nil(t, err)
+	assert.Equal(t, &GetMetricHistoryResponse{
+		Metrics: []MetricPartialResponse{
+			{
+				Key:       "key",
+				Timestamp: 1234567891,
+				Step:      1,
+				Value:     api.NewMetricValue(1, 0),
+				Context:   map[string]any{},
+			},
+			{
+				Key:       "key",
+				Timestamp: 1234567892,
+				Step:      2,
+				Value:     api.NewMetricValue(2, 0),
+				Context:   map[string]any{},
+			},
+		},
+		NextPageToken: "eyJzdGVwIjoyLCJ0aW1lc3RhbXAiOjEyMzQ1Njc4OTIsImNvbnRleHRfaWQiOjEsIml0ZXIiOjJ9",
+	}, response)
+
+	// no token, when all the metrics fit into the page.
+	response, err = NewMetricHistoryResponse(metrics, 3, 0)
+	require.Nil(t, err)
+	assert.Len(t, response.Metrics, 3)
+	assert.Empty(t, response.NextPageToken)
+}
+
 func TestNewMetricHistoryBulkResponse_Ok(t *testing.T) {
 	testData := []struct {
 		name             string
@@ -161,7 +206,7 @@ func TestNewMetricHistoryBulkResponse_Ok(t *testing.T) {
 						Key:       "key",
 						Timestamp: 1234567890,
 						Step:      1,
-						Value:     123.4,
+						Value:     api.NewMetricValue(123.4, 0),
 					},
 				},
 			},
@@ -170,7 +215,7 @@ func TestNewMetricHistoryBulkResponse_Ok(t *testing.T) {
 
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
-			actualResponse := NewMetricHistoryBulkResponse(tt.metrics)
+			actualResponse := NewMetricHistoryBulkResponse(tt.metrics, 0)
 			assert.Equal(t, tt.expectedResponse, actualResponse)
 		})
 	}
@@ -11,6 +11,8 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // RunTagPartialResponse is a partial response object for different responses.
@@ -33,11 +35,22 @@ type RunMetricPartialResponse struct {
 	Step      int64  `json:"step"`
 }
 
+// RunModelPartialResponse is a partial response object for different responses.
+type RunModelPartialResponse struct {
+	ArtifactPath   string         `json:"artifact_path"`
+	ModelURI       string         `json:"model_uri"`
+	ModelUUID      string         `json:"model_uuid,omitempty"`
+	UTCTimeCreated string         `json:"utc_time_created,omitempty"`
+	Flavors        map[string]any `json:"flavors"`
+	Signature      map[string]any `json:"signature,omitempty"`
+}
+
 // RunDataPartialResponse is a partial response object for different responses.
 type RunDataPartialResponse struct {
 	Metrics []RunMetricPartialResponse `json:"metrics,omitempty"`
 	Params  []RunParamPartialResponse  `json:"params,omitempty"`
 	Tags    []RunTagPartialResponse    `json:"tags,omitempty"`
+	Models  []RunModelPartialResponse  `json:"models,omitempty"`
 }
 
 // RunInfoPartialResponse is a partial response object for different responses.
@@ -52,6 +65,8 @@ type RunInfoPartialResponse struct {
 	EndTime        int64  `json:"end_time,omitempty"`
 	ArtifactURI    string `json:"artifact_uri,omitempty"`
 	LifecycleStage string `json:"lifecycle_stage"`
+	ArtifactSize   *int64 `json:"artifact_size,omitempty"`
+	ArtifactCount  *int64 `json:"artifact_count,omitempty"`
 }
 
 // RunPartialResponse is a partial response object for different responses.
@@ -94,6 +109,18 @@ func NewCreateRunResponse(run *models.Run) *CreateRunResponse {
 	return &resp
 }
 
+// CloneRunResponse is a response object for `POST mlflow/runs/clone` endpoint.
+type CloneRunResponse struct {
+	Run *RunPartialResponse `json:"run"`
+}
+
+// NewCloneRunResponse creates new CloneRunResponse object.
+func NewCloneRunResponse(run *models.Run, metricValuePrecision int) *CloneRunResponse {
+	return &CloneRunResponse{
+		Run: NewRunPartialResponse(run, metricValuePrecision),
+	}
+}
+
 // UpdateRunResponse is a response object for `POST mlflow/runs/update` endpoint.
 type UpdateRunResponse struct {
 	RunInfo RunInfoPartialResponse `json:"run_info"`
@@ -121,13 +148,57 @@ func NewUpdateRunResponse(run *models.Run) *UpdateRunResponse {
 // GetRunResponse is a response object for `GET mlflow/runs/get` endpoint.
 type GetRunResponse struct {
 	Run *RunPartialResponse `json:"run"`
+	// fields are the requested sections of the run. All the sections are returned, when it is empty.
+	fields commonModels.ResponseFields
 }
 
-// NewGetRunResponse creates new GetRunResponse object.
-func NewGetRunResponse(run *models.Run) *GetRunResponse {
+// NewGetRunResponse creates new GetRunResponse object, which contains only the requested sections.
+func NewGetRunResponse(
+	run *models.Run, fields commonModels.ResponseFields, metricValuePrecision int,
+) *GetRunResponse {
 	return &GetRunResponse{
-		Run: NewRunPartialResponse(run),
+		Run:    NewRunPartialResponse(run, metricValuePrecision),
+		fields: fields,
+	}
+}
+
+// MarshalJSON omits run summary from `info`, when it hasn't been requested. `data` sections are empty
+// when they haven't been requested, so they are omitted automatically.
+func (r GetRunResponse) MarshalJSON() ([]byte, error) {
+	if r.Run == nil || r.fields.Has(commonModels.ResponseFieldSummary) {
+		return json.Marshal(struct {
+			Run *RunPartialResponse `json:"run"`
+		}{
+			Run: r.Run,
+		})
 	}
+	type runIdentifiers struct {
+		ID           string `json:"run_id"`
+		UUID         string `json:"run_uuid"`
+		ExperimentID string `json:"experiment_id"`
+	}
+	type run struct {
+		Info runIdentifiers         `json:"info"`
+		Data RunDataPartialResponse `json:"data"`
+	}
+	return json.Marshal(struct {
+		Run run `json:"run"`
+	}{
+		Run: run{
+			Info: runIdentifiers{
+				ID:           r.Run.Info.ID,
+				UUID:         r.Run.Info.UUID,
+				ExperimentID: r.Run.Info.ExperimentID,
+			},
+			Data: r.Run.Data,
+		},
+	})
+}
+
+// UpdateRunsTagResponse is a response object for `POST mlflow/runs/set-tag-bulk`
+// and `POST mlflow/runs/delete-tag-bulk` endpoints.
+type UpdateRunsTagResponse struct {
+	RunIDs []string `json:"run_ids"`
 }
 
 // SearchRunsResponse is a response object for `POST mlflow/runs/search` endpoint.
@@ -137,7 +208,7 @@ type SearchRunsResponse struct {
 }
 
 // NewSearchRunsResponse creates new SearchRunsResponse object.
-func NewSearchRunsResponse(runs []models.Run, limit, offset int) (*SearchRunsResponse, error) {
+func NewSearchRunsResponse(runs []models.Run, limit, offset, metricValuePrecision int) (*SearchRunsResponse, error) {
 	resp := SearchRunsResponse{
 		Runs: make([]*RunPartialResponse, len(runs)),
 	}
@@ -145,7 +216,7 @@ func NewSearchRunsResponse(runs []models.Run, limit, offset int) (*SearchRunsRes
 	// transform each models.Run entity.
 	for i, run := range runs {
 		//nolint:gosec
-		resp.Runs[i] = NewRunPartialResponse(&run)
+		resp.Runs[i] = NewRunPartialResponse(&run, metricValuePrecision)
 	}
 
 	// encode `nextPageToken` value.
@@ -165,13 +236,14 @@ func NewSearchRunsResponse(runs []models.Run, limit, offset int) (*SearchRunsRes
 }
 
 // NewRunPartialResponse is a helper function for NewSearchRunsResponse and NewGetRunResponse functions,
-// because the use almost the same response structure.
-func NewRunPartialResponse(run *models.Run) *RunPartialResponse {
+// because the use almost the same response structure. Metric values are serialized with
+// metricValuePrecision significant digits.
+func NewRunPartialResponse(run *models.Run, metricValuePrecision int) *RunPartialResponse {
 	metrics := make([]RunMetricPartialResponse, len(run.LatestMetrics))
 	for n, m := range run.LatestMetrics {
 		metrics[n] = RunMetricPartialResponse{
 			Key:       m.Key,
-			Value:     m.Value,
+			Value:     api.NewMetricValue(m.Value, metricValuePrecision),
 			Timestamp: m.Timestamp,
 			Step:      m.Step,
 		}
@@ -188,6 +260,7 @@ func NewRunPartialResponse(run *models.Run) *RunPartialResponse {
 		}
 	}
 
+	var runModels []RunModelPartialResponse
 	tags := make([]RunTagPartialResponse, len(run.Tags))
 	for n, t := range run.Tags {
 		tags[n] = RunTagPartialResponse{
@@ -199,6 +272,8 @@ func NewRunPartialResponse(run *models.Run) *RunPartialResponse {
 			run.Name = t.Value
 		case "mlflow.user":
 			run.UserID = t.Value
+		case models.TagKeyLogModelHistory:
+			runModels = NewRunModelsPartialResponse(run.ID, t.Value)
 		}
 	}
 
@@ -219,6 +294,28 @@ func NewRunPartialResponse(run *models.Run) *RunPartialResponse {
 			Metrics: metrics,
 			Params:  params,
 			Tags:    tags,
+			Models:  runModels,
 		},
 	}
 }
+
+// NewRunModelsPartialResponse creates the list of models logged by the run from the value of the history tag.
+// The tag could be modified by the users directly, so malformed history is just not exposed.
+func NewRunModelsPartialResponse(runID, history string) []RunModelPartialResponse {
+	loggedModels, err := models.DecodeLoggedModels(history)
+	if err != nil {
+		return nil
+	}
+	runModels := make([]RunModelPartialResponse, len(loggedModels))
+	for n, m := range loggedModels {
+		runModels[n] = RunModelPartialResponse{
+			ArtifactPath:   m.ArtifactPath,
+			ModelURI:       fmt.Sprintf("runs:/%s/%s", runID, m.ArtifactPath),
+			ModelUUID:      m.ModelUUID,
+			UTCTimeCreated: m.UTCTimeCreated,
+			Flavors:        m.Flavors,
+			Signature:      m.Signature,
+		}
+	}
+	return runModels
+}
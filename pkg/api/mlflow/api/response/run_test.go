@@ -8,6 +8,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
 func TestNewRunPartialResponse(t *testing.T) {
@@ -164,7 +165,7 @@ func TestNewRunPartialResponse(t *testing.T) {
 					Metrics: []RunMetricPartialResponse{
 						{
 							Key:       "Key",
-							Value:     float64(123),
+							Value:     api.NewMetricValue(123, 0),
 							Timestamp: 1234567890,
 							Step:      1,
 						},
@@ -240,7 +241,7 @@ func TestNewRunPartialResponse(t *testing.T) {
 
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
-			actualResponse := NewRunPartialResponse(tt.run)
+			actualResponse := NewRunPartialResponse(tt.run, 0)
 			assert.Equal(t, tt.expectedResponse, actualResponse)
 		})
 	}
@@ -23,7 +23,6 @@ var textTypes = []string{
 	".yaml",
 	".yml",
 	".xml",
-	".json",
 	".js",
 	".py",
 	".py3",
@@ -40,6 +39,15 @@ func GetPointer[T any](str T) *T {
 	return &str
 }
 
+// DefaultContentType is a content type of the file, which can't be determined.
+const DefaultContentType = "application/octet-stream"
+
+// genericContentTypes are content types, which object storages assign to objects by default.
+var genericContentTypes = []string{
+	DefaultContentType,
+	"binary/octet-stream",
+}
+
 // GetContentType will determine the content type of the file.
 func GetContentType(filename string) string {
 	fileExt := path.Ext(filename)
@@ -50,5 +58,26 @@ func GetContentType(filename string) string {
 	if mimeType != "" {
 		return mimeType
 	}
-	return "application/octet-stream"
+	return DefaultContentType
+}
+
+// GetArtifactContentType will determine the content type of the artifact.
+// Content type stored by object storage is preferred, unless it is a generic one.
+func GetArtifactContentType(storedContentType, filename string) string {
+	if storedContentType != "" && !slices.Contains(genericContentTypes, storedContentType) {
+		return storedContentType
+	}
+	return GetContentType(filename)
+}
+
+// GetContentDisposition will build `Content-Disposition` header value for the file.
+func GetContentDisposition(filename string, inline bool) string {
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+	if value := mime.FormatMediaType(disposition, map[string]string{"filename": filename}); value != "" {
+		return value
+	}
+	return disposition
 }
@@ -27,6 +27,16 @@ func TestGetContentType(t *testing.T) {
 			filename: "script.pdf",
 			expected: "application/pdf",
 		},
+		{
+			name:     "JSONFromMimePackage",
+			filename: "metrics.json",
+			expected: "application/json",
+		},
+		{
+			name:     "ImageFromMimePackage",
+			filename: "plot.png",
+			expected: "image/png",
+		},
 		{
 			name:     "DefaultUnknownType",
 			filename: "unknown.unknown",
@@ -39,3 +49,69 @@ func TestGetContentType(t *testing.T) {
 		assert.Equal(t, tt.expected, result, "Unexpected content type for filename: %s", tt.filename)
 	}
 }
+
+func TestGetArtifactContentType(t *testing.T) {
+	tests := []struct {
+		name              string
+		storedContentType string
+		filename          string
+		expected          string
+	}{
+		{
+			name:              "StoredContentType",
+			storedContentType: "image/svg+xml",
+			filename:          "plot.unknown",
+			expected:          "image/svg+xml",
+		},
+		{
+			name:              "GenericStoredContentType",
+			storedContentType: "binary/octet-stream",
+			filename:          "plot.png",
+			expected:          "image/png",
+		},
+		{
+			name:              "EmptyStoredContentType",
+			storedContentType: "",
+			filename:          "metrics.json",
+			expected:          "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetArtifactContentType(tt.storedContentType, tt.filename))
+		})
+	}
+}
+
+func TestGetContentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		inline   bool
+		expected string
+	}{
+		{
+			name:     "Attachment",
+			filename: "plot.png",
+			expected: "attachment; filename=plot.png",
+		},
+		{
+			name:     "Inline",
+			filename: "plot.png",
+			inline:   true,
+			expected: "inline; filename=plot.png",
+		},
+		{
+			name:     "FilenameWithSpecialCharacters",
+			filename: `my "plot".png`,
+			expected: `attachment; filename="my \"plot\".png"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetContentDisposition(tt.filename, tt.inline))
+		})
+	}
+}
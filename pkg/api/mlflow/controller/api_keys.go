@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+)
+
+// CreateAPIKey handles `POST /api-keys/create` endpoint.
+func (c Controller) CreateAPIKey(ctx *fiber.Ctx) error {
+	var req request.CreateAPIKeyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("createAPIKey request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("createAPIKey namespace: %s", ns.Code)
+
+	apiKey, key, err := c.apiKeyService.CreateAPIKey(ctx.UserContext(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	// the raw key is deliberately not logged.
+	return ctx.JSON(response.NewCreateAPIKeyResponse(apiKey, key))
+}
+
+// ListAPIKeys handles `GET /api-keys/list` endpoint.
+func (c Controller) ListAPIKeys(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("listAPIKeys namespace: %s", ns.Code)
+
+	apiKeys, err := c.apiKeyService.ListAPIKeys(ctx.UserContext(), ns)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewListAPIKeysResponse(apiKeys)
+	log.Debugf("listAPIKeys response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// RevokeAPIKey handles `POST /api-keys/revoke` endpoint.
+func (c Controller) RevokeAPIKey(ctx *fiber.Ctx) error {
+	var req request.RevokeAPIKeyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("revokeAPIKey request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("revokeAPIKey namespace: %s", ns.Code)
+
+	if err := c.apiKeyService.RevokeAPIKey(ctx.UserContext(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
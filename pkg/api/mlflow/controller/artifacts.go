@@ -2,8 +2,9 @@ package controller
 
 import (
 	"bufio"
-	"fmt"
+	"bytes"
 	"io"
+	"net/http"
 	"path/filepath"
 	"time"
 
@@ -32,22 +33,40 @@ func (c Controller) ListArtifacts(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("listArtifacts namespace: %s", ns.Code)
 
-	rootURI, artifacts, err := c.artifactService.ListArtifacts(ctx.Context(), ns, &req)
+	rootURI, artifacts, nextOffset, err := c.artifactService.ListArtifacts(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
 
-	resp := response.NewListArtifactsResponse(rootURI, artifacts)
+	resp, err := response.NewListArtifactsResponse(rootURI, artifacts, nextOffset)
+	if err != nil {
+		return api.NewInternalError("unable to build next_page_token: %s", err)
+	}
+
+	// browsers get a simple HTML listing, while JSON clients keep getting the structured one.
+	if ctx.Accepts(fiber.MIMEApplicationJSON, fiber.MIMETextHTML) == fiber.MIMETextHTML {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		if err := c.artifactService.RenderArtifactIndex(ctx, &req, artifacts, resp.NextPageToken); err != nil {
+			return api.NewInternalError("unable to render artifact index: %s", err)
+		}
+		return nil
+	}
 	log.Debugf("artifactList response: %#v", resp)
 	return ctx.JSON(resp)
 }
 
+// artifactSniffLen is a number of bytes used to detect content type of the artifact.
+const artifactSniffLen = 512
+
 // GetArtifact handles `GET /artifacts/get` endpoint.
 func (c Controller) GetArtifact(ctx *fiber.Ctx) error {
 	req := request.GetArtifactRequest{}
 	if err := ctx.QueryParser(&req); err != nil {
 		return api.NewBadRequestError(err.Error())
 	}
+	if err := ctx.ReqHeaderParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
 	log.Debugf("GetArtifact request: %#v", req)
 
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -56,22 +75,44 @@ func (c Controller) GetArtifact(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getArtifact namespace: %s", ns.Code)
 
-	artifact, err := c.artifactService.GetArtifact(ctx.Context(), ns, &req)
+	artifactObject, artifact, err := c.artifactService.GetArtifact(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
 
+	if etag := artifactObject.GetETag(); etag != "" {
+		ctx.Set(fiber.HeaderETag, etag)
+	}
+	if !artifactObject.LastModified.IsZero() {
+		ctx.Set(fiber.HeaderLastModified, artifactObject.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if artifact == nil {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+
 	filename := filepath.Base(req.Path)
-	ctx.Set("Content-Type", common.GetContentType(filename))
-	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	reader := bufio.NewReaderSize(artifact, artifactSniffLen)
+	contentType := common.GetArtifactContentType(artifactObject.ContentType, filename)
+	if contentType == common.DefaultContentType {
+		// content type can't be determined by the file name, so try to detect it by the content.
+		if head, _ := reader.Peek(artifactSniffLen); len(head) > 0 {
+			contentType = http.DetectContentType(head)
+		}
+	}
+	ctx.Set("Content-Type", contentType)
+	ctx.Set("Content-Disposition", common.GetContentDisposition(filename, req.Inline))
 	ctx.Set("X-Content-Type-Options", "nosniff")
+	if req.Inline {
+		// inline artifacts are rendered by the browser, so don't let them run scripts in UI origin.
+		ctx.Set("Content-Security-Policy", "sandbox")
+	}
 	ctx.Context().Response.SetBodyStreamWriter(func(w *bufio.Writer) {
 		//nolint:errcheck
 		defer artifact.Close()
 
 		start := time.Now()
 		if err := func() error {
-			bytesWritten, err := io.CopyBuffer(w, artifact, make([]byte, 4096))
+			bytesWritten, err := io.CopyBuffer(w, reader, make([]byte, 4096))
 			if err != nil {
 				return eris.Wrap(err, "error copying artifact Reader to output stream")
 			}
@@ -92,3 +133,96 @@ func (c Controller) GetArtifact(ctx *fiber.Ctx) error {
 	})
 	return nil
 }
+
+// CreateArtifactUpload handles `POST /artifacts/uploads/create` endpoint.
+func (c Controller) CreateArtifactUpload(ctx *fiber.Ctx) error {
+	var req request.CreateArtifactUploadRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("createArtifactUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("createArtifactUpload namespace: %s", ns.Code)
+
+	uploadID, err := c.artifactService.CreateArtifactUpload(ctx.UserContext(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(response.CreateArtifactUploadResponse{
+		UploadID: uploadID,
+	})
+}
+
+// UploadArtifactPart handles `PUT /artifacts/uploads/part` endpoint.
+// Request body is a raw content of the part.
+func (c Controller) UploadArtifactPart(ctx *fiber.Ctx) error {
+	var req request.UploadArtifactPartRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("uploadArtifactPart request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("uploadArtifactPart namespace: %s", ns.Code)
+
+	// body is streamed by the server, so the part is passed to the storage without holding it in memory.
+	body := ctx.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(ctx.Body())
+	}
+	if err := c.artifactService.UploadArtifactPart(ctx.UserContext(), ns, &req, body); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// CompleteArtifactUpload handles `POST /artifacts/uploads/complete` endpoint.
+func (c Controller) CompleteArtifactUpload(ctx *fiber.Ctx) error {
+	var req request.CompleteArtifactUploadRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("completeArtifactUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("completeArtifactUpload namespace: %s", ns.Code)
+
+	if err := c.artifactService.CompleteArtifactUpload(ctx.UserContext(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// AbortArtifactUpload handles `POST /artifacts/uploads/abort` endpoint.
+func (c Controller) AbortArtifactUpload(ctx *fiber.Ctx) error {
+	var req request.AbortArtifactUploadRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("abortArtifactUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("abortArtifactUpload namespace: %s", ns.Code)
+
+	if err := c.artifactService.AbortArtifactUpload(ctx.UserContext(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
@@ -1,35 +1,43 @@
 package controller
 
 import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/apikey"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/experiment"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/metric"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/model"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/run"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 // Controller handles all the input HTTP requests.
 type Controller struct {
+	config            *config.Config
 	runService        *run.Service
 	modelService      *model.Service
 	metricService     *metric.Service
 	artifactService   *artifact.Service
 	experimentService *experiment.Service
+	apiKeyService     *apikey.Service
 }
 
 // NewController creates new Controller instance.
 func NewController(
+	config *config.Config,
 	runService *run.Service,
 	modelService *model.Service,
 	metricService *metric.Service,
 	artifactService *artifact.Service,
 	experimentService *experiment.Service,
+	apiKeyService *apikey.Service,
 ) *Controller {
 	return &Controller{
+		config:            config,
 		runService:        runService,
 		modelService:      modelService,
 		metricService:     metricService,
 		artifactService:   artifactService,
 		experimentService: experimentService,
+		apiKeyService:     apiKeyService,
 	}
 }
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	log "github.com/sirupsen/logrus"
@@ -31,7 +32,7 @@ func (c Controller) CreateExperiment(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("createExperiment namespace: %s", ns.Code)
-	experiment, err := c.experimentService.CreateExperiment(ctx.Context(), ns, &req)
+	experiment, err := c.experimentService.CreateExperiment(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
@@ -54,7 +55,7 @@ func (c Controller) UpdateExperiment(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("createExperiment namespace: %s", ns.Code)
-	if err := c.experimentService.UpdateExperiment(ctx.Context(), ns, &req); err != nil {
+	if err := c.experimentService.UpdateExperiment(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
@@ -74,7 +75,7 @@ func (c Controller) GetExperiment(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getExperiment namespace: %s", ns.Code)
 
-	experiment, err := c.experimentService.GetExperiment(ctx.Context(), ns, &req)
+	experiment, err := c.experimentService.GetExperiment(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
@@ -96,7 +97,7 @@ func (c Controller) GetExperimentByName(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getExperimentByName namespace: %s", ns.Code)
 
-	experiment, err := c.experimentService.GetExperimentByName(ctx.Context(), ns, &req)
+	experiment, err := c.experimentService.GetExperimentByName(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
@@ -117,7 +118,7 @@ func (c Controller) DeleteExperiment(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("deleteExperiment namespace: %s", ns.Code)
-	if err := c.experimentService.DeleteExperiment(ctx.Context(), ns, &req); err != nil {
+	if err := c.experimentService.DeleteExperiment(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
@@ -136,7 +137,7 @@ func (c Controller) RestoreExperiment(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("restoreExperiment namespace: %s", ns.Code)
-	if err := c.experimentService.RestoreExperiment(ctx.Context(), ns, &req); err != nil {
+	if err := c.experimentService.RestoreExperiment(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 	return ctx.JSON(fiber.Map{})
@@ -154,7 +155,7 @@ func (c Controller) SetExperimentTag(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("setExperimentTag namespace: %s", ns.Code)
-	if err := c.experimentService.SetExperimentTag(ctx.Context(), ns, &req); err != nil {
+	if err := c.experimentService.SetExperimentTag(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 	return ctx.JSON(fiber.Map{})
@@ -179,7 +180,7 @@ func (c Controller) SearchExperiments(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("searchExperiments namespace: %s", ns.Code)
-	experiments, limit, offset, err := c.experimentService.SearchExperiments(ctx.Context(), ns, &req)
+	experiments, limit, offset, err := c.experimentService.SearchExperiments(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
@@ -189,5 +190,9 @@ func (c Controller) SearchExperiments(ctx *fiber.Ctx) error {
 		return api.NewInternalError("unable to build next_page_token: %s", err)
 	}
 	log.Debugf("searchExperiments response: %#v", resp)
+	ctx.Set(api.PageSizeHeader, strconv.Itoa(limit))
+	if api.IsPaginatedResponseAccepted(ctx) {
+		return api.SendPaginatedResponse(ctx, api.NewPaginatedResponse(resp.Experiments, resp.NextPageToken, nil))
+	}
 	return ctx.JSON(resp)
 }
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"bufio"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -15,11 +17,15 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
+// mimeApplicationNDJSON is a content type of newline-delimited JSON stream.
+const mimeApplicationNDJSON = "application/x-ndjson"
+
 // GetMetricHistory handles `GET /metrics/get-history` endpoint.
 func (c Controller) GetMetricHistory(ctx *fiber.Ctx) error {
 	req := request.GetMetricHistoryRequest{}
@@ -32,12 +38,12 @@ func (c Controller) GetMetricHistory(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("getMetricHistory namespace: %s", ns.Code)
-	metrics, err := c.metricService.GetMetricHistory(ctx.Context(), ns, &req)
+	metrics, err := c.metricService.GetMetricHistory(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
 
-	resp, err := response.NewMetricHistoryResponse(metrics)
+	resp, err := response.NewMetricHistoryResponse(metrics, req.MaxResults, c.config.MetricValuePrecision)
 	if err != nil {
 		return err
 	}
@@ -60,12 +66,12 @@ func (c Controller) GetMetricHistoryBulk(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getMetricHistoryBulk namespace: %s", ns.Code)
 
-	metrics, err := c.metricService.GetMetricHistoryBulk(ctx.Context(), ns, &req)
+	metrics, err := c.metricService.GetMetricHistoryBulk(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
 
-	resp := response.NewMetricHistoryBulkResponse(metrics)
+	resp := response.NewMetricHistoryBulkResponse(metrics, c.config.MetricValuePrecision)
 	log.Debugf("getMetricHistoryBulk response: %#v", resp)
 
 	return ctx.JSON(resp)
@@ -85,7 +91,7 @@ func (c Controller) GetMetricHistories(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getMetricHistories namespace: %s", ns.Code)
 
-	rows, iterator, err := c.metricService.GetMetricHistories(ctx.Context(), ns, &req)
+	rows, iterator, err := c.metricService.GetMetricHistories(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
@@ -93,6 +99,23 @@ func (c Controller) GetMetricHistories(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting query result: %s", err)
 	}
 
+	if ctx.Accepts(fiber.MIMEOctetStream, mimeApplicationNDJSON) == mimeApplicationNDJSON {
+		// compressor buffers the stream, so lines are sent uncompressed to reach client as soon as flushed.
+		middleware.SkipCompression(ctx)
+		ctx.Set("Content-Type", mimeApplicationNDJSON)
+		ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			//nolint:errcheck
+			defer rows.Close()
+
+			start := time.Now()
+			if err := writeMetricHistoriesNDJSON(w, rows, iterator, c.config.MetricValuePrecision); err != nil {
+				log.Errorf("error encountered in %s %s: error streaming metrics: %s", ctx.Method(), ctx.Path(), err)
+			}
+			log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+		})
+		return nil
+	}
+
 	ctx.Set("Content-Type", "application/octet-stream")
 	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
 		//nolint:errcheck
@@ -154,3 +177,82 @@ func (c Controller) GetMetricHistories(ctx *fiber.Ctx) error {
 	})
 	return nil
 }
+
+// writeMetricHistoriesNDJSON writes metrics as newline-delimited JSON, one metric per line.
+// Lines are flushed in batches, so client could process them as they arrive.
+func writeMetricHistoriesNDJSON(
+	w *bufio.Writer, rows *sql.Rows, iterator func(*sql.Rows, interface{}) error, metricValuePrecision int,
+) error {
+	encoder := json.NewEncoder(w)
+	for i := 0; rows.Next(); i++ {
+		var m database.Metric
+		if err := iterator(rows, &m); err != nil {
+			return eris.Wrap(err, "error reading metric from iterator")
+		}
+		line := response.MetricHistoriesLineResponse{
+			RunID:     m.RunID,
+			Key:       m.Key,
+			Step:      m.Step,
+			Timestamp: m.Timestamp,
+			Value:     api.NewMetricValue(m.Value, metricValuePrecision),
+			Context:   json.RawMessage(m.Context.Json),
+		}
+		if m.IsNan {
+			line.Value = common.NANValue
+		}
+		if err := encoder.Encode(line); err != nil {
+			return eris.Wrap(err, "error encoding metric")
+		}
+		if (i+1)%1000 == 0 {
+			if err := w.Flush(); err != nil {
+				return eris.Wrap(err, "error flushing metrics")
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteMetric handles `POST /metrics/delete` endpoint.
+func (c Controller) DeleteMetric(ctx *fiber.Ctx) error {
+	var req request.DeleteMetricRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("deleteMetric request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteMetric namespace: %s", ns.Code)
+
+	if err := c.metricService.DeleteMetric(ctx.UserContext(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// GetBestMetrics handles `POST /metrics/get-best` endpoint.
+func (c Controller) GetBestMetrics(ctx *fiber.Ctx) error {
+	var req request.GetBestMetricsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("getBestMetrics request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getBestMetrics namespace: %s", ns.Code)
+
+	bestMetrics, err := c.metricService.GetBestMetrics(ctx.UserContext(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewGetBestMetricsResponse(bestMetrics, c.config.MetricValuePrecision)
+	log.Debugf("getBestMetrics response: %#v", resp)
+	return ctx.JSON(resp)
+}
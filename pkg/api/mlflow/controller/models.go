@@ -4,7 +4,7 @@ import "github.com/gofiber/fiber/v2"
 
 // SearchModelVersions handles `GET /model-versions/search` endpoint.
 func (c Controller) SearchModelVersions(ctx *fiber.Ctx) error {
-	models, err := c.modelService.SearchModelVersions(ctx.Context())
+	models, err := c.modelService.SearchModelVersions(ctx.UserContext())
 	if err != nil {
 		return err
 	}
@@ -13,7 +13,7 @@ func (c Controller) SearchModelVersions(ctx *fiber.Ctx) error {
 
 // SearchRegisteredModels handles `GET /registered-models/search` endpoint.
 func (c Controller) SearchRegisteredModels(ctx *fiber.Ctx) error {
-	models, err := c.modelService.SearchRegisteredModels(ctx.Context())
+	models, err := c.modelService.SearchRegisteredModels(ctx.UserContext())
 	if err != nil {
 		return err
 	}
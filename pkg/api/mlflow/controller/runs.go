@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	log "github.com/sirupsen/logrus"
@@ -9,6 +11,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 )
 
@@ -25,7 +28,7 @@ func (c Controller) CreateRun(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("createRun namespace: %s", ns.Code)
-	run, err := c.runService.CreateRun(ctx.Context(), ns, &req)
+	run, err := c.runService.CreateRun(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
@@ -35,6 +38,30 @@ func (c Controller) CreateRun(ctx *fiber.Ctx) error {
 	return ctx.JSON(resp)
 }
 
+// CloneRun handles `POST /runs/clone` endpoint.
+func (c Controller) CloneRun(ctx *fiber.Ctx) error {
+	var req request.CloneRunRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("cloneRun request: %#v", &req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("cloneRun namespace: %s", ns.Code)
+
+	run, err := c.runService.CloneRun(ctx.UserContext(), ns, &req)
+	if err != nil {
+		return err
+	}
+	resp := response.NewCloneRunResponse(run, c.config.MetricValuePrecision)
+	log.Debugf("cloneRun response: %#v", resp)
+
+	return ctx.JSON(resp)
+}
+
 // UpdateRun handles `POST /runs/update` endpoint.
 func (c Controller) UpdateRun(ctx *fiber.Ctx) error {
 	var req request.UpdateRunRequest
@@ -49,7 +76,7 @@ func (c Controller) UpdateRun(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("updateRun namespace: %s", ns.Code)
 
-	run, err := c.runService.UpdateRun(ctx.Context(), ns, &req)
+	run, err := c.runService.UpdateRun(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
@@ -74,12 +101,17 @@ func (c Controller) GetRun(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getRun namespace: %s", ns.Code)
 
-	run, err := c.runService.GetRun(ctx.Context(), ns, &req)
+	run, err := c.runService.GetRun(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
 
-	resp := response.NewGetRunResponse(run)
+	resp := response.NewGetRunResponse(run, models.NewResponseFields(req.Fields), c.config.MetricValuePrecision)
+	if req.IncludeArtifactStats {
+		if err := c.setArtifactStats(ctx.UserContext(), &resp.Run.Info, run.ArtifactURI); err != nil {
+			return err
+		}
+	}
 	log.Debugf("getRun response: %#v", resp)
 
 	return ctx.JSON(resp)
@@ -99,17 +131,28 @@ func (c Controller) SearchRuns(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("searchRuns namespace: %s", ns.Code)
 
-	runs, limit, offset, err := c.runService.SearchRuns(ctx.Context(), ns, &req)
+	runs, limit, offset, err := c.runService.SearchRuns(ctx.UserContext(), ns, &req)
 	if err != nil {
 		return err
 	}
 
-	resp, err := response.NewSearchRunsResponse(runs, limit, offset)
+	resp, err := response.NewSearchRunsResponse(runs, limit, offset, c.config.MetricValuePrecision)
 	if err != nil {
 		return api.NewInternalError("Unable to build next_page_token: %s", err)
 	}
+	if ctx.QueryBool("include_artifact_stats") {
+		for i, run := range runs {
+			if err := c.setArtifactStats(ctx.UserContext(), &resp.Runs[i].Info, run.ArtifactURI); err != nil {
+				return err
+			}
+		}
+	}
 	log.Debugf("searchRuns response: %#v", resp)
 
+	ctx.Set(api.PageSizeHeader, strconv.Itoa(limit))
+	if api.IsPaginatedResponseAccepted(ctx) {
+		return api.SendPaginatedResponse(ctx, api.NewPaginatedResponse(resp.Runs, resp.NextPageToken, nil))
+	}
 	return ctx.JSON(resp)
 }
 
@@ -127,7 +170,7 @@ func (c Controller) DeleteRun(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("deleteRun namespace: %s", ns.Code)
 
-	if err := c.runService.DeleteRun(ctx.Context(), ns, &req); err != nil {
+	if err := c.runService.DeleteRun(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
@@ -148,7 +191,7 @@ func (c Controller) RestoreRun(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("restoreRun namespace: %s", ns.Code)
 
-	if err := c.runService.RestoreRun(ctx.Context(), ns, &req); err != nil {
+	if err := c.runService.RestoreRun(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
@@ -176,7 +219,7 @@ func (c Controller) LogMetric(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("logMetric namespace: %s", ns.Code)
 
-	if err := c.runService.LogMetric(ctx.Context(), ns, &req); err != nil {
+	if err := c.runService.LogMetric(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
@@ -204,7 +247,35 @@ func (c Controller) LogParam(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("logParam namespace: %s", ns.Code)
 
-	if err := c.runService.LogParam(ctx.Context(), ns, &req); err != nil {
+	if err := c.runService.LogParam(ctx.UserContext(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// LogModel handles `POST /runs/log-model` endpoint.
+func (c Controller) LogModel(ctx *fiber.Ctx) error {
+	var req request.LogModelRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		if err, ok := err.(*json.UnmarshalTypeError); ok {
+			return api.NewInvalidParameterValueError(
+				`Invalid value for parameter '%s' supplied. Hint: Value was of type '%s'. `+
+					`See the API docs for more information about request parameters.`,
+				err.Field, err.Value,
+			)
+		}
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("logModel request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("logModel namespace: %s", ns.Code)
+
+	if err := c.runService.LogModel(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
@@ -232,7 +303,7 @@ func (c Controller) SetRunTag(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("setRunTag namespace: %s", ns.Code)
 
-	if err := c.runService.SetRunTag(ctx.Context(), ns, &req); err != nil {
+	if err := c.runService.SetRunTag(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
@@ -253,12 +324,61 @@ func (c Controller) DeleteRunTag(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("deleteRunTag namespace: %s", ns.Code)
 
-	if err := c.runService.DeleteRunTag(ctx.Context(), ns, &req); err != nil {
+	if err := c.runService.DeleteRunTag(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 	return ctx.JSON(fiber.Map{})
 }
 
+// SetRunsTag handles `POST /runs/set-tag-bulk` endpoint.
+func (c Controller) SetRunsTag(ctx *fiber.Ctx) error {
+	var req request.SetRunsTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		if err, ok := err.(*json.UnmarshalTypeError); ok {
+			return api.NewInvalidParameterValueError(
+				`Invalid value for parameter '%s' supplied. Hint: Value was of type '%s'. `+
+					`See the API docs for more information about request parameters.`,
+				err.Field, err.Value,
+			)
+		}
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("setRunsTag request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("setRunsTag namespace: %s", ns.Code)
+
+	runIDs, err := c.runService.SetRunsTag(ctx.UserContext(), ns, &req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.UpdateRunsTagResponse{RunIDs: runIDs})
+}
+
+// DeleteRunsTag handles `POST /runs/delete-tag-bulk` endpoint.
+func (c Controller) DeleteRunsTag(ctx *fiber.Ctx) error {
+	var req request.DeleteRunsTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("deleteRunsTag request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteRunsTag namespace: %s", ns.Code)
+
+	runIDs, err := c.runService.DeleteRunsTag(ctx.UserContext(), ns, &req)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.UpdateRunsTagResponse{RunIDs: runIDs})
+}
+
 // LogBatch handles `POST /runs/log-batch` endpoint.
 func (c Controller) LogBatch(ctx *fiber.Ctx) error {
 	var req request.LogBatchRequest
@@ -280,9 +400,21 @@ func (c Controller) LogBatch(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("logBatch namespace: %s", ns.Code)
 
-	if err := c.runService.LogBatch(ctx.Context(), ns, &req); err != nil {
+	if err := c.runService.LogBatch(ctx.UserContext(), ns, &req); err != nil {
 		return err
 	}
 
 	return ctx.JSON(fiber.Map{})
 }
+
+// setArtifactStats sets total size and count of the run artifacts in run info response.
+func (c Controller) setArtifactStats(
+	ctx context.Context, info *response.RunInfoPartialResponse, artifactURI string,
+) error {
+	stats, err := c.artifactService.GetArtifactStats(ctx, artifactURI)
+	if err != nil {
+		return err
+	}
+	info.ArtifactSize, info.ArtifactCount = &stats.Size, &stats.Count
+	return nil
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/metric"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+)
+
+// Layout of the run bundle, exported by `GET /runs/export` endpoint.
+const (
+	// runBundleMetadataFile contains the run in the same format as `GET /runs/get` endpoint returns it.
+	runBundleMetadataFile = "run.json"
+	// runBundleMetricsFile contains all the metric histories of the run as newline-delimited JSON,
+	// in the same format as NDJSON stream of `POST /metrics/get-histories` endpoint.
+	runBundleMetricsFile = "metrics.jsonl"
+	// runBundleArtifactsDir contains the run artifacts under their artifact paths.
+	runBundleArtifactsDir = "artifacts"
+)
+
+// ExportRun handles `GET /runs/export` endpoint.
+// The run is streamed as a zip bundle with its metadata, metric histories and artifacts.
+func (c Controller) ExportRun(ctx *fiber.Ctx) error {
+	req := request.ExportRunRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("exportRun request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("exportRun namespace: %s", ns.Code)
+
+	// everything, which could fail with a proper API error, is done before the stream is started.
+	run, err := c.runService.GetRun(ctx.UserContext(), ns, &request.GetRunRequest{RunID: req.GetRunID()})
+	if err != nil {
+		return err
+	}
+	_, artifacts, _, err := c.artifactService.ListArtifacts(ctx.UserContext(), ns, &request.ListArtifactsRequest{
+		RunID:     run.ID,
+		Recursive: true,
+	})
+	if err != nil {
+		return err
+	}
+	rows, iterator, err := c.metricService.GetMetricHistories(ctx.UserContext(), ns, &request.GetMetricHistoriesRequest{
+		RunIDs:     []string{run.ID},
+		MaxResults: metric.MaxResultsForMetricHistoriesRequest,
+	})
+	if err != nil {
+		return err
+	}
+
+	// zip is compressed already.
+	middleware.SkipCompression(ctx)
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"run-%s.zip\"", run.ID))
+	userContext := ctx.UserContext()
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		//nolint:errcheck
+		defer rows.Close()
+
+		start := time.Now()
+		if err := c.writeRunBundle(userContext, w, ns, run, rows, iterator, artifacts); err != nil {
+			log.Errorf("error encountered in %s %s: error streaming run bundle: %s", ctx.Method(), ctx.Path(), err)
+		}
+		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+	})
+	return nil
+}
+
+// writeRunBundle writes zip bundle of the run. Metrics and artifacts are copied one by one,
+// so the bundle is never held in memory as a whole.
+func (c Controller) writeRunBundle(
+	ctx context.Context,
+	w *bufio.Writer,
+	ns *models.Namespace,
+	run *models.Run,
+	rows *sql.Rows,
+	iterator func(*sql.Rows, interface{}) error,
+	artifacts []storage.ArtifactObject,
+) error {
+	bundle := zip.NewWriter(w)
+
+	// 1. write run metadata.
+	entry, err := bundle.Create(runBundleMetadataFile)
+	if err != nil {
+		return eris.Wrapf(err, "error creating %s", runBundleMetadataFile)
+	}
+	// bundles are imported back, so metric values are always written in the shortest exact form.
+	if err := json.NewEncoder(entry).Encode(
+		response.NewGetRunResponse(run, commonModels.ResponseFields{}, 0),
+	); err != nil {
+		return eris.Wrap(err, "error encoding run")
+	}
+
+	// 2. write metric histories.
+	entry, err = bundle.Create(runBundleMetricsFile)
+	if err != nil {
+		return eris.Wrapf(err, "error creating %s", runBundleMetricsFile)
+	}
+	metrics := bufio.NewWriter(entry)
+	if err := writeMetricHistoriesNDJSON(metrics, rows, iterator, 0); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return eris.Wrap(err, "error getting query result")
+	}
+	if err := metrics.Flush(); err != nil {
+		return eris.Wrap(err, "error flushing metrics")
+	}
+
+	// 3. write artifacts.
+	for _, artifact := range artifacts {
+		if artifact.IsDirectory() {
+			continue
+		}
+		if err := c.writeRunBundleArtifact(ctx, bundle, ns, run.ID, artifact.Path); err != nil {
+			return err
+		}
+	}
+
+	if err := bundle.Close(); err != nil {
+		return eris.Wrap(err, "error closing bundle")
+	}
+	return w.Flush()
+}
+
+// writeRunBundleArtifact copies a single artifact from the storage into the bundle.
+func (c Controller) writeRunBundleArtifact(
+	ctx context.Context, bundle *zip.Writer, ns *models.Namespace, runID, artifactPath string,
+) error {
+	_, artifact, err := c.artifactService.GetArtifact(ctx, ns, &request.GetArtifactRequest{
+		RunID: runID,
+		Path:  artifactPath,
+	})
+	if err != nil {
+		return eris.Wrapf(err, "error getting artifact '%s'", artifactPath)
+	}
+	//nolint:errcheck
+	defer artifact.Close()
+
+	entry, err := bundle.Create(path.Join(runBundleArtifactsDir, filepath.ToSlash(artifactPath)))
+	if err != nil {
+		return eris.Wrapf(err, "error creating bundle entry for artifact '%s'", artifactPath)
+	}
+	if _, err := io.Copy(entry, artifact); err != nil {
+		return eris.Wrapf(err, "error copying artifact '%s'", artifactPath)
+	}
+	return nil
+}
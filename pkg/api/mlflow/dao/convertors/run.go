@@ -82,6 +82,60 @@ func ConvertCreateRunRequestToDBModel(
 	return &run, nil
 }
 
+// ConvertCloneRunRequestToDBModel converts request.CloneRunRequest into new models.Run model,
+// which has the params and tags, but not the metrics, of the source run.
+func ConvertCloneRunRequestToDBModel(
+	experiment *models.Experiment, source *models.Run, req *request.CloneRunRequest, now int64,
+) (*models.Run, error) {
+	runID := database.NewUUID()
+	artifactURI, err := url.JoinPath(experiment.ArtifactLocation, runID, "artifacts")
+	if err != nil {
+		return nil, eris.Wrap(err, "error constructing artifact_uri")
+	}
+	run := models.Run{
+		ID:             runID,
+		Name:           source.Name,
+		SourceType:     source.SourceType,
+		SourceName:     source.SourceName,
+		EntryPointName: source.EntryPointName,
+		UserID:         source.UserID,
+		Status:         source.Status,
+		StartTime:      source.StartTime,
+		EndTime:        source.EndTime,
+		SourceVersion:  source.SourceVersion,
+		LifecycleStage: models.LifecycleStageActive,
+		ArtifactURI:    artifactURI,
+		ExperimentID:   *experiment.ID,
+		Params:         make([]models.Param, len(source.Params)),
+		Tags:           make([]models.Tag, len(source.Tags)),
+	}
+	if req.Name != "" {
+		run.Name = req.Name
+	}
+	if req.ResetStatus {
+		run.Status = models.StatusRunning
+		run.StartTime = sql.NullInt64{Int64: now, Valid: true}
+		run.EndTime = sql.NullInt64{}
+	}
+
+	for n, param := range source.Params {
+		run.Params[n] = models.Param{
+			Key:   param.Key,
+			Value: param.Value,
+		}
+	}
+	for n, tag := range source.Tags {
+		run.Tags[n] = models.Tag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		}
+		if tag.Key == TagKeyRunName {
+			run.Tags[n].Value = run.Name
+		}
+	}
+	return &run, nil
+}
+
 // ConvertUpdateRunRequestToDBModel converts request.UpdateRunRequest into actual models.Run model.
 func ConvertUpdateRunRequestToDBModel(run *models.Run, req *request.UpdateRunRequest) *models.Run {
 	run.Name = req.Name
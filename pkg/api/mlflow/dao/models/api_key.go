@@ -0,0 +1,83 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+)
+
+// APIKeyAccessLevel represents level of access granted by API key to its namespace.
+type APIKeyAccessLevel string
+
+// Supported list of API key access levels.
+const (
+	APIKeyAccessLevelRead  APIKeyAccessLevel = "read"
+	APIKeyAccessLevelWrite APIKeyAccessLevel = "write"
+)
+
+const (
+	// APIKeyTokenPrefix is a prefix of each API key, which helps to distinguish them from other bearer tokens.
+	APIKeyTokenPrefix = "ftml_"
+	// apiKeySecretSize is a number of random bytes in API key.
+	apiKeySecretSize = 32
+	// apiKeyDisplayPrefixLength is a number of leading API key characters, which are stored as is,
+	// so keys could be recognized by users in the list.
+	apiKeyDisplayPrefixLength = len(APIKeyTokenPrefix) + 8
+)
+
+// APIKey represents model to work with `api_keys` table.
+type APIKey struct {
+	ID          uuid.UUID         `gorm:"type:uuid;primaryKey"`
+	NamespaceID uint              `gorm:"not null;index"`
+	Name        string            `gorm:"type:varchar(256);not null"`
+	Prefix      string            `gorm:"type:varchar(16);not null"`
+	Hash        string            `gorm:"type:varchar(64);not null;uniqueIndex"`
+	AccessLevel APIKeyAccessLevel `gorm:"type:varchar(16);not null"`
+	CreatedAt   time.Time
+	LastUsedAt  sql.NullTime
+}
+
+// IsValid makes check that access level is supported.
+func (l APIKeyAccessLevel) IsValid() bool {
+	return l == APIKeyAccessLevelRead || l == APIKeyAccessLevelWrite
+}
+
+// HasWriteAccess makes check that API key allows to modify resources of its namespace.
+func (k APIKey) HasWriteAccess() bool {
+	return k.AccessLevel == APIKeyAccessLevelWrite
+}
+
+// NewAPIKey generates new API key for the namespace. Only hash of the key is kept in the model,
+// so the returned raw key has to be shown to the user straight away, because it can't be restored.
+func NewAPIKey(namespaceID uint, name string, accessLevel APIKeyAccessLevel) (*APIKey, string, error) {
+	secret := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", eris.Wrap(err, "error generating api key")
+	}
+	key := APIKeyTokenPrefix + hex.EncodeToString(secret)
+	return &APIKey{
+		ID:          uuid.New(),
+		NamespaceID: namespaceID,
+		Name:        name,
+		Prefix:      key[:apiKeyDisplayPrefixLength],
+		Hash:        HashAPIKey(key),
+		AccessLevel: accessLevel,
+	}, key, nil
+}
+
+// HashAPIKey returns hash of raw API key, which is used to store and look up the key.
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// IsAPIKey makes check that token looks like API key, so it shouldn't be verified by other auth methods.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyTokenPrefix)
+}
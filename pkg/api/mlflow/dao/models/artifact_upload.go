@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// ArtifactUpload represents model to work with `artifact_uploads` table.
+// It keeps chunked upload of the artifact, which is in progress, so the upload could be continued
+// by any instance of the server and survives its restarts.
+type ArtifactUpload struct {
+	ID              string `gorm:"type:varchar(32);primaryKey"`
+	NamespaceID     uint   `gorm:"not null;index"`
+	ArtifactURI     string `gorm:"type:varchar(200);not null"`
+	Path            string `gorm:"type:text;not null"`
+	StorageUploadID string `gorm:"type:text;not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time `gorm:"index"`
+}
+
+// ArtifactUploadPart represents model to work with `artifact_upload_parts` table.
+// Sizes of the uploaded parts are kept, so the size of the whole artifact is known during the upload.
+type ArtifactUploadPart struct {
+	UploadID   string `gorm:"type:varchar(32);primaryKey"`
+	PartNumber int    `gorm:"primaryKey;autoIncrement:false"`
+	Size       int64  `gorm:"not null"`
+}
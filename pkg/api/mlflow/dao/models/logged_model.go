@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/rotisserie/eris"
+)
+
+// TagKeyLogModelHistory is the key of the run tag, which keeps the history of models logged by the run.
+const TagKeyLogModelHistory = "mlflow.log-model.history"
+
+// LoggedModel represents MLmodel metadata of the model logged by the run.
+type LoggedModel struct {
+	RunID          string         `json:"run_id"`
+	ArtifactPath   string         `json:"artifact_path"`
+	UTCTimeCreated string         `json:"utc_time_created"`
+	ModelUUID      string         `json:"model_uuid,omitempty"`
+	MLflowVersion  string         `json:"mlflow_version,omitempty"`
+	Flavors        map[string]any `json:"flavors"`
+	Signature      map[string]any `json:"signature,omitempty"`
+}
+
+// DecodeLoggedModels decodes the history of logged models from the value of TagKeyLogModelHistory tag.
+func DecodeLoggedModels(value string) ([]LoggedModel, error) {
+	var loggedModels []LoggedModel
+	if err := json.Unmarshal([]byte(value), &loggedModels); err != nil {
+		return nil, eris.Wrap(err, "error decoding logged models")
+	}
+	return loggedModels, nil
+}
+
+// EncodeLoggedModels encodes the history of logged models into the value of TagKeyLogModelHistory tag.
+func EncodeLoggedModels(loggedModels []LoggedModel) (string, error) {
+	data, err := json.Marshal(loggedModels)
+	if err != nil {
+		return "", eris.Wrap(err, "error encoding logged models")
+	}
+	return string(data), nil
+}
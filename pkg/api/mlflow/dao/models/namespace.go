@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
 )
 
@@ -12,14 +13,77 @@ const DefaultNamespaceCode = "default"
 
 // Namespace represents model to work with `namespaces` table.
 type Namespace struct {
-	ID                  uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	Code                string         `gorm:"unique;index;not null" json:"code"`
-	Description         string         `json:"description"`
-	CreatedAt           time.Time      `json:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at"`
-	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at"`
-	DefaultExperimentID *int32         `gorm:"not null" json:"default_experiment_id"`
-	Experiments         []Experiment   `gorm:"constraint:OnDelete:CASCADE" json:"experiments"`
+	ID                  uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code                string          `gorm:"unique;index;not null" json:"code"`
+	Description         string          `json:"description"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt  `gorm:"index" json:"deleted_at"`
+	DefaultExperimentID *int32          `gorm:"not null" json:"default_experiment_id"`
+	Experiments         []Experiment    `gorm:"constraint:OnDelete:CASCADE" json:"experiments"`
+	RetentionPolicy     RetentionPolicy `gorm:"embedded;embeddedPrefix:retention_" json:"retention_policy"`
+	PublicRead          bool            `gorm:"not null;default:false" json:"public_read"`
+}
+
+// MetricDirection represents the direction in which a metric value is considered better.
+type MetricDirection string
+
+// Supported list of metric directions.
+const (
+	MetricDirectionMin MetricDirection = "min"
+	MetricDirectionMax MetricDirection = "max"
+)
+
+// RetentionPolicy represents Namespace run retention policy.
+type RetentionPolicy struct {
+	// MaxAgeDays archives runs started more than MaxAgeDays days ago.
+	MaxAgeDays *int32 `json:"max_age_days"`
+	// KeepBest keeps only KeepBest best runs by MetricKey and archives the rest.
+	KeepBest        *int32          `json:"keep_best"`
+	MetricKey       string          `gorm:"type:varchar(250)" json:"metric_key"`
+	MetricDirection MetricDirection `gorm:"type:varchar(3)" json:"metric_direction"`
+}
+
+// Validate makes check that retention policy has valid values.
+func (p RetentionPolicy) Validate() error {
+	if p.MaxAgeDays != nil && *p.MaxAgeDays < 0 {
+		return eris.New("retention max_age_days should not be negative")
+	}
+	if p.KeepBest != nil && *p.KeepBest < 0 {
+		return eris.New("retention keep_best should not be negative")
+	}
+	if p.KeepBest != nil && *p.KeepBest > 0 && p.MetricKey == "" {
+		return eris.New("retention metric_key is required when keep_best is set")
+	}
+	switch p.MetricDirection {
+	case "", MetricDirectionMin, MetricDirectionMax:
+		return nil
+	default:
+		return eris.Errorf("unsupported retention metric direction '%s'", p.MetricDirection)
+	}
+}
+
+// IsEnabled makes check that at least one retention rule is configured.
+func (p RetentionPolicy) IsEnabled() bool {
+	return p.HasMaxAge() || p.HasKeepBest()
+}
+
+// HasMaxAge makes check that age based retention is configured.
+func (p RetentionPolicy) HasMaxAge() bool {
+	return p.MaxAgeDays != nil && *p.MaxAgeDays > 0
+}
+
+// HasKeepBest makes check that metric based retention is configured.
+func (p RetentionPolicy) HasKeepBest() bool {
+	return p.KeepBest != nil && *p.KeepBest > 0 && p.MetricKey != ""
+}
+
+// GetMetricDirection returns the direction in which MetricKey value is considered better, `min` by default.
+func (p RetentionPolicy) GetMetricDirection() MetricDirection {
+	if p.MetricDirection == "" {
+		return MetricDirectionMin
+	}
+	return p.MetricDirection
 }
 
 // DisplayName returns Namespace display name.
@@ -30,16 +30,17 @@ type Run struct {
 	SourceName     string         `gorm:"<-:create;type:varchar(500)"`
 	EntryPointName string         `gorm:"<-:create;type:varchar(50)"`
 	UserID         string         `gorm:"<-:create;type:varchar(256)"`
-	Status         Status         `gorm:"type:varchar(9);check:status IN ('SCHEDULED', 'FAILED', 'FINISHED', 'RUNNING', 'KILLED')"`
+	Status         Status         `gorm:"type:varchar(9);index:idx_runs_status_experiment_id,priority:1;check:status IN ('SCHEDULED', 'FAILED', 'FINISHED', 'RUNNING', 'KILLED')"`
 	StartTime      sql.NullInt64  `gorm:"<-:create;type:bigint"`
 	EndTime        sql.NullInt64  `gorm:"type:bigint"`
 	SourceVersion  string         `gorm:"<-:create;type:varchar(50)"`
 	LifecycleStage LifecycleStage `gorm:"type:varchar(20);check:lifecycle_stage IN ('active', 'deleted')"`
 	ArtifactURI    string         `gorm:"<-:create;type:varchar(200)"`
-	ExperimentID   int32
+	ExperimentID   int32          `gorm:"index:idx_runs_status_experiment_id,priority:2"`
 	Experiment     Experiment
 	DeletedTime    sql.NullInt64  `gorm:"type:bigint"`
 	RowNum         RowNum         `gorm:"<-:create;index"`
+	Version        int64          `gorm:"not null;default:0"`
 	Params         []Param        `gorm:"constraint:OnDelete:CASCADE"`
 	Tags           []Tag          `gorm:"constraint:OnDelete:CASCADE"`
 	Metrics        []Metric       `gorm:"constraint:OnDelete:CASCADE"`
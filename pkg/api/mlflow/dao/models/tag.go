@@ -2,7 +2,7 @@ package models
 
 // Tag represents model to work with `tags` table.
 type Tag struct {
-	Key   string `gorm:"type:varchar(250);not null;primaryKey"`
+	Key   string `gorm:"type:varchar(250);not null;primaryKey;index:idx_tags_run_uuid_key,priority:2"`
 	Value string `gorm:"type:varchar(5000)"`
-	RunID string `gorm:"column:run_uuid;not null;primaryKey;index"`
+	RunID string `gorm:"column:run_uuid;not null;primaryKey;index;index:idx_tags_run_uuid_key,priority:1"`
 }
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// APIKeyRepositoryProvider provides an interface to work with `api_key` entity.
+type APIKeyRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.APIKey entity.
+	Create(ctx context.Context, apiKey *models.APIKey) error
+	// GetByHash returns API key by hash of its raw value.
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	// ListByNamespaceID returns all API keys of the namespace.
+	ListByNamespaceID(ctx context.Context, namespaceID uint) ([]models.APIKey, error)
+	// Delete removes API key of the namespace by its ID. It returns false, if there was no such key.
+	Delete(ctx context.Context, namespaceID uint, id uuid.UUID) (bool, error)
+	// UpdateLastUsedAt sets time when API key was used last time.
+	UpdateLastUsedAt(ctx context.Context, apiKey *models.APIKey, lastUsedAt time.Time) error
+}
+
+// APIKeyRepository repository to work with `api_key` entity.
+type APIKeyRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewAPIKeyRepository creates repository to work with `api_key` entity.
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.APIKey entity.
+func (r APIKeyRepository) Create(ctx context.Context, apiKey *models.APIKey) error {
+	if err := r.GetDB().WithContext(ctx).Create(apiKey).Error; err != nil {
+		return eris.Wrap(err, "error creating api key entity")
+	}
+	return nil
+}
+
+// GetByHash returns API key by hash of its raw value.
+func (r APIKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	if err := r.GetDB().WithContext(ctx).Where(
+		"hash = ?", hash,
+	).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrap(err, "error getting api key by hash")
+	}
+	return &apiKey, nil
+}
+
+// ListByNamespaceID returns all API keys of the namespace.
+func (r APIKeyRepository) ListByNamespaceID(ctx context.Context, namespaceID uint) ([]models.APIKey, error) {
+	var apiKeys []models.APIKey
+	if err := r.GetDB().WithContext(ctx).Where(
+		"namespace_id = ?", namespaceID,
+	).Order(
+		"created_at",
+	).Find(&apiKeys).Error; err != nil {
+		return nil, eris.Wrapf(err, "error listing api keys of namespace: %d", namespaceID)
+	}
+	return apiKeys, nil
+}
+
+// Delete removes API key of the namespace by its ID. It returns false, if there was no such key.
+func (r APIKeyRepository) Delete(ctx context.Context, namespaceID uint, id uuid.UUID) (bool, error) {
+	result := r.GetDB().WithContext(ctx).Where(
+		"namespace_id = ? AND id = ?", namespaceID, id,
+	).Delete(&models.APIKey{})
+	if result.Error != nil {
+		return false, eris.Wrapf(result.Error, "error deleting api key with id: %s", id)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateLastUsedAt sets time when API key was used last time.
+func (r APIKeyRepository) UpdateLastUsedAt(ctx context.Context, apiKey *models.APIKey, lastUsedAt time.Time) error {
+	apiKey.LastUsedAt = sql.NullTime{Time: lastUsedAt, Valid: true}
+	if err := r.GetDB().WithContext(ctx).Model(
+		apiKey,
+	).Update(
+		"last_used_at", apiKey.LastUsedAt,
+	).Error; err != nil {
+		return eris.Wrapf(err, "error updating last_used_at of api key with id: %s", apiKey.ID)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// ArtifactUploadRepositoryProvider provides an interface to work with `artifact_upload` entity.
+type ArtifactUploadRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.ArtifactUpload entity.
+	Create(ctx context.Context, upload *models.ArtifactUpload) error
+	// GetByNamespaceIDAndID returns artifact upload of the namespace by its ID.
+	GetByNamespaceIDAndID(ctx context.Context, namespaceID uint, id string) (*models.ArtifactUpload, error)
+	// GetUpdatedBefore returns up to limit artifact uploads, which haven't been updated since updatedAt.
+	GetUpdatedBefore(ctx context.Context, updatedAt time.Time, limit int) ([]models.ArtifactUpload, error)
+	// GetPartSizes returns sizes of the uploaded parts by their numbers.
+	GetPartSizes(ctx context.Context, upload *models.ArtifactUpload) (map[int]int64, error)
+	// SetPartSize stores size of the uploaded part and sets time when the upload was updated.
+	// It returns sizes of all the uploaded parts, or nil if the upload doesn't exist anymore.
+	SetPartSize(
+		ctx context.Context, upload *models.ArtifactUpload, partNumber int, size int64, updatedAt time.Time,
+	) (map[int]int64, error)
+	// Delete removes artifact upload together with sizes of its parts. It returns false, if there was no such upload.
+	Delete(ctx context.Context, upload *models.ArtifactUpload) (bool, error)
+}
+
+// ArtifactUploadRepository repository to work with `artifact_upload` entity.
+type ArtifactUploadRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewArtifactUploadRepository creates repository to work with `artifact_upload` entity.
+func NewArtifactUploadRepository(db *gorm.DB) *ArtifactUploadRepository {
+	return &ArtifactUploadRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.ArtifactUpload entity.
+func (r ArtifactUploadRepository) Create(ctx context.Context, upload *models.ArtifactUpload) error {
+	if err := r.GetDB().WithContext(ctx).Create(upload).Error; err != nil {
+		return eris.Wrap(err, "error creating artifact upload entity")
+	}
+	return nil
+}
+
+// GetByNamespaceIDAndID returns artifact upload of the namespace by its ID.
+func (r ArtifactUploadRepository) GetByNamespaceIDAndID(
+	ctx context.Context, namespaceID uint, id string,
+) (*models.ArtifactUpload, error) {
+	var upload models.ArtifactUpload
+	if err := r.GetDB().WithContext(ctx).Where(
+		"namespace_id = ? AND id = ?", namespaceID, id,
+	).First(&upload).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting artifact upload by id: %s", id)
+	}
+	return &upload, nil
+}
+
+// GetUpdatedBefore returns up to limit artifact uploads, which haven't been updated since updatedAt.
+func (r ArtifactUploadRepository) GetUpdatedBefore(
+	ctx context.Context, updatedAt time.Time, limit int,
+) ([]models.ArtifactUpload, error) {
+	var uploads []models.ArtifactUpload
+	if err := r.GetDB().WithContext(ctx).Where(
+		"updated_at < ?", updatedAt,
+	).Order(
+		"updated_at",
+	).Limit(
+		limit,
+	).Find(&uploads).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting expired artifact uploads")
+	}
+	return uploads, nil
+}
+
+// GetPartSizes returns sizes of the uploaded parts by their numbers.
+func (r ArtifactUploadRepository) GetPartSizes(
+	ctx context.Context, upload *models.ArtifactUpload,
+) (map[int]int64, error) {
+	return getArtifactUploadPartSizes(r.GetDB().WithContext(ctx), upload)
+}
+
+// SetPartSize stores size of the uploaded part and sets time when the upload was updated.
+// It returns sizes of all the uploaded parts, or nil if the upload doesn't exist anymore.
+// Upload is updated firstly, so parts of the same upload, which are stored concurrently,
+// wait for each other and sizes of all of them are returned to the last one.
+func (r ArtifactUploadRepository) SetPartSize(
+	ctx context.Context, upload *models.ArtifactUpload, partNumber int, size int64, updatedAt time.Time,
+) (map[int]int64, error) {
+	var sizes map[int]int64
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(upload).Update("updated_at", updatedAt)
+		if result.Error != nil {
+			return eris.Wrapf(result.Error, "error updating artifact upload with id: %s", upload.ID)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "upload_id"}, {Name: "part_number"}},
+			DoUpdates: clause.AssignmentColumns([]string{"size"}),
+		}).Create(&models.ArtifactUploadPart{
+			UploadID:   upload.ID,
+			PartNumber: partNumber,
+			Size:       size,
+		}).Error; err != nil {
+			return eris.Wrapf(err, "error storing size of part %d of artifact upload with id: %s", partNumber, upload.ID)
+		}
+
+		var err error
+		sizes, err = getArtifactUploadPartSizes(tx, upload)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return sizes, nil
+}
+
+// Delete removes artifact upload together with sizes of its parts. It returns false, if there was no such upload.
+func (r ArtifactUploadRepository) Delete(ctx context.Context, upload *models.ArtifactUpload) (bool, error) {
+	var deleted bool
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("upload_id = ?", upload.ID).Delete(&models.ArtifactUploadPart{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting parts of artifact upload with id: %s", upload.ID)
+		}
+		result := tx.Where("id = ?", upload.ID).Delete(&models.ArtifactUpload{})
+		if result.Error != nil {
+			return eris.Wrapf(result.Error, "error deleting artifact upload with id: %s", upload.ID)
+		}
+		deleted = result.RowsAffected > 0
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	return deleted, nil
+}
+
+// getArtifactUploadPartSizes returns sizes of the uploaded parts by their numbers.
+func getArtifactUploadPartSizes(db *gorm.DB, upload *models.ArtifactUpload) (map[int]int64, error) {
+	var parts []models.ArtifactUploadPart
+	if err := db.Where("upload_id = ?", upload.ID).Find(&parts).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting parts of artifact upload with id: %s", upload.ID)
+	}
+	sizes := make(map[int]int64, len(parts))
+	for _, part := range parts {
+		sizes[part.PartNumber] = part.Size
+	}
+	return sizes, nil
+}
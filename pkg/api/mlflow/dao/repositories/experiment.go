@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
@@ -19,16 +22,25 @@ type ExperimentRepositoryProvider interface {
 	Create(ctx context.Context, experiment *models.Experiment) error
 	// Update updates existing models.Experiment entity.
 	Update(ctx context.Context, experiment *models.Experiment) error
+	// Restore marks existing models.Experiment entity and its runs as active.
+	Restore(ctx context.Context, experiment *models.Experiment) error
 	// Delete removes the existing models.Experiment from the db.
 	Delete(ctx context.Context, experiment *models.Experiment) error
 	// DeleteBatch removes existing []models.Experiment in batch from the db.
 	DeleteBatch(ctx context.Context, ids []*int32) error
+	// GetByID returns experiment by Experiment ID regardless of its namespace, or nil if it doesn't exist.
+	GetByID(ctx context.Context, experimentID int32) (*models.Experiment, error)
 	// GetByNamespaceIDAndName returns experiment by Namespace ID and Experiment name.
 	GetByNamespaceIDAndName(ctx context.Context, namespaceID uint, name string) (*models.Experiment, error)
 	// GetByNamespaceIDAndExperimentID returns experiment by Namespace ID and Experiment ID.
 	GetByNamespaceIDAndExperimentID(
 		ctx context.Context, namespaceID uint, experimentID int32,
 	) (*models.Experiment, error)
+	// UpdateNamespaceID reassigns existing models.Experiment entity, together with its runs, to another namespace
+	// and moves artifact locations of the experiment and its runs under artifactLocation.
+	UpdateNamespaceID(
+		ctx context.Context, experiment *models.Experiment, namespaceID uint, artifactLocation string,
+	) error
 	// UpdateWithTransaction updates existing models.Experiment entity in scope of transaction.
 	UpdateWithTransaction(ctx context.Context, tx *gorm.DB, experiment *models.Experiment) error
 }
@@ -47,11 +59,11 @@ func NewExperimentRepository(db *gorm.DB) *ExperimentRepository {
 
 // Create creates new models.Experiment entity.
 func (r ExperimentRepository) Create(ctx context.Context, experiment *models.Experiment) error {
-	if err := r.GetDB().WithContext(ctx).Create(&experiment).Error; err != nil {
+	if err := r.GetDBWithContext(ctx).Create(&experiment).Error; err != nil {
 		return eris.Wrap(err, "error creating experiment entity")
 	}
 	if experiment.ArtifactLocation == "" {
-		if err := r.GetDB().Model(
+		if err := r.GetDBWithContext(ctx).Model(
 			&experiment,
 		).Update(
 			"ArtifactLocation", experiment.ArtifactLocation,
@@ -67,7 +79,7 @@ func (r ExperimentRepository) GetByNamespaceIDAndExperimentID(
 	ctx context.Context, namespaceID uint, experimentID int32,
 ) (*models.Experiment, error) {
 	var experiment models.Experiment
-	if err := r.GetDB().WithContext(ctx).Preload(
+	if err := r.GetDBWithContext(ctx).Preload(
 		"Tags",
 	).Where(
 		models.Experiment{ID: &experimentID},
@@ -79,17 +91,30 @@ func (r ExperimentRepository) GetByNamespaceIDAndExperimentID(
 	return &experiment, nil
 }
 
+// GetByID returns experiment by Experiment ID regardless of its namespace, or nil if it doesn't exist.
+func (r ExperimentRepository) GetByID(ctx context.Context, experimentID int32) (*models.Experiment, error) {
+	var experiment models.Experiment
+	if err := r.GetDBWithContext(ctx).Where(
+		models.Experiment{ID: &experimentID},
+	).First(&experiment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting experiment by id: %d", experimentID)
+	}
+	return &experiment, nil
+}
+
 // GetByNamespaceIDAndName returns experiment by Namespace ID and Experiment name.
+// Name is matched case-sensitively using the unique (name, namespace_id) index.
 func (r ExperimentRepository) GetByNamespaceIDAndName(
 	ctx context.Context, namespaceID uint, name string,
 ) (*models.Experiment, error) {
 	var experiment models.Experiment
-	if err := r.GetDB().WithContext(ctx).Preload(
+	if err := r.GetDBWithContext(ctx).Preload(
 		"Tags",
 	).Where(
-		models.Experiment{Name: name},
-	).Where(
-		"experiments.namespace_id = ?", namespaceID,
+		"experiments.namespace_id = ? AND experiments.name = ?", namespaceID, name,
 	).First(&experiment).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -101,7 +126,7 @@ func (r ExperimentRepository) GetByNamespaceIDAndName(
 
 // Update updates existing models.Experiment entity.
 func (r ExperimentRepository) Update(ctx context.Context, experiment *models.Experiment) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.WithContext(ctx).Model(&experiment).Updates(experiment).Error; err != nil {
 			return eris.Wrapf(err, "error updating experiment with id: %d", *experiment.ID)
 		}
@@ -131,6 +156,36 @@ func (r ExperimentRepository) Update(ctx context.Context, experiment *models.Exp
 	return nil
 }
 
+// Restore marks existing models.Experiment entity and its runs as active.
+func (r ExperimentRepository) Restore(ctx context.Context, experiment *models.Experiment) error {
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		experiment.LifecycleStage = models.LifecycleStageActive
+		if err := tx.WithContext(ctx).Model(&experiment).Updates(experiment).Error; err != nil {
+			return eris.Wrapf(err, "error updating experiment with id: %d", *experiment.ID)
+		}
+
+		// runs have been archived together with experiment, so restore them as well.
+		// Use UpdateColumns so we can reset DeletedTime to null.
+		if err := tx.WithContext(
+			ctx,
+		).Model(
+			&models.Run{},
+		).Where(
+			"experiment_id = ? AND lifecycle_stage = ?", experiment.ID, models.LifecycleStageDeleted,
+		).UpdateColumns(map[string]any{
+			"DeletedTime":    sql.NullInt64{},
+			"LifecycleStage": models.LifecycleStageActive,
+		}).Error; err != nil {
+			return eris.Wrapf(err, "error restoring existing runs with experiment id: %d", *experiment.ID)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Delete removes the existing models.Experiment from the db.
 func (r ExperimentRepository) Delete(ctx context.Context, experiment *models.Experiment) error {
 	return r.DeleteBatch(ctx, []*int32{experiment.ID})
@@ -138,7 +193,7 @@ func (r ExperimentRepository) Delete(ctx context.Context, experiment *models.Exp
 
 // DeleteBatch removes existing []models.Experiment in batch from the db.
 func (r ExperimentRepository) DeleteBatch(ctx context.Context, ids []*int32) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// finding all the runs
 		var minRowNum sql.NullInt64
 		if err := tx.Model(
@@ -179,6 +234,45 @@ func (r ExperimentRepository) DeleteBatch(ctx context.Context, ids []*int32) err
 	return nil
 }
 
+// UpdateNamespaceID reassigns existing models.Experiment entity, together with its runs, to another namespace
+// and moves artifact locations of the experiment and its runs under artifactLocation. Runs reference only
+// the experiment, so only artifact URIs of the runs, which are located under the experiment, are updated.
+func (r ExperimentRepository) UpdateNamespaceID(
+	ctx context.Context, experiment *models.Experiment, namespaceID uint, artifactLocation string,
+) error {
+	lastUpdateTime := sql.NullInt64{Int64: time.Now().UTC().UnixMilli(), Valid: true}
+	// experiment is updated by the query too, so the old location is kept to find artifact URIs of the runs.
+	sourceArtifactLocation := experiment.ArtifactLocation
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(
+			experiment,
+		).Updates(map[string]any{
+			"namespace_id":      namespaceID,
+			"artifact_location": artifactLocation,
+			"last_update_time":  lastUpdateTime,
+		}).Error; err != nil {
+			return err
+		}
+		if artifactLocation == sourceArtifactLocation {
+			return nil
+		}
+		// runs.artifact_uri is create only field of models.Run, so it is updated by the raw query.
+		prefix := strings.TrimRight(sourceArtifactLocation, "/") + "/"
+		return tx.Exec(
+			`UPDATE runs SET artifact_uri = ? || SUBSTR(artifact_uri, ?)
+			 WHERE experiment_id = ? AND SUBSTR(artifact_uri, 1, ?) = ?`,
+			strings.TrimRight(artifactLocation, "/")+"/", utf8.RuneCountInString(prefix)+1,
+			*experiment.ID, utf8.RuneCountInString(prefix), prefix,
+		).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error updating namespace of experiment with id: %d", *experiment.ID)
+	}
+	experiment.NamespaceID = namespaceID
+	experiment.ArtifactLocation = artifactLocation
+	experiment.LastUpdateTime = lastUpdateTime
+	return nil
+}
+
 // UpdateWithTransaction updates existing models.Experiment entity in scope of transaction.
 func (r ExperimentRepository) UpdateWithTransaction(
 	ctx context.Context,
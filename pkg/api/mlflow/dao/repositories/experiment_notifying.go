@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// ExperimentNotifyingRepository repository to work with `experiment` entity, which triggers database event
+// each time runs of the experiment are changed.
+type ExperimentNotifyingRepository struct {
+	ExperimentRepositoryProvider
+	db               *gorm.DB
+	runEventListener dao.EventListenerProvider
+}
+
+// NewExperimentNotifyingRepository creates new instance of repository to work with `experiment` entity,
+// which triggers database event each time runs of the experiment are changed.
+func NewExperimentNotifyingRepository(
+	db *gorm.DB, runEventListener dao.EventListenerProvider,
+) *ExperimentNotifyingRepository {
+	return &ExperimentNotifyingRepository{
+		ExperimentRepositoryProvider: NewExperimentRepository(db),
+		db:                           db,
+		runEventListener:             runEventListener,
+	}
+}
+
+// Update updates existing models.Experiment entity.
+func (r ExperimentNotifyingRepository) Update(ctx context.Context, experiment *models.Experiment) error {
+	if err := r.ExperimentRepositoryProvider.Update(ctx, experiment); err != nil {
+		return err
+	}
+	// runs are archived together with the experiment.
+	if experiment.LifecycleStage != models.LifecycleStageDeleted {
+		return nil
+	}
+	return sendRunEvent(ctx, r.runEventListener, r.db, events.RunEvent{
+		NamespaceID: experiment.NamespaceID,
+	})
+}
+
+// Restore marks existing models.Experiment entity and its runs as active.
+func (r ExperimentNotifyingRepository) Restore(ctx context.Context, experiment *models.Experiment) error {
+	if err := r.ExperimentRepositoryProvider.Restore(ctx, experiment); err != nil {
+		return err
+	}
+	// runs are restored together with the experiment.
+	return sendRunEvent(ctx, r.runEventListener, r.db, events.RunEvent{
+		NamespaceID: experiment.NamespaceID,
+	})
+}
+
+// Delete removes the existing models.Experiment from the db.
+func (r ExperimentNotifyingRepository) Delete(ctx context.Context, experiment *models.Experiment) error {
+	return r.DeleteBatch(ctx, []*int32{experiment.ID})
+}
+
+// DeleteBatch removes existing []models.Experiment in batch from the db.
+func (r ExperimentNotifyingRepository) DeleteBatch(ctx context.Context, ids []*int32) error {
+	var namespaceIDs []uint
+	if err := r.db.WithContext(ctx).Model(
+		&models.Experiment{},
+	).Distinct().Where(
+		"experiment_id IN ?", ids,
+	).Pluck(
+		"namespace_id", &namespaceIDs,
+	).Error; err != nil {
+		return eris.Wrapf(err, "error getting namespaces of experiments with ids: %d", ids)
+	}
+
+	if err := r.ExperimentRepositoryProvider.DeleteBatch(ctx, ids); err != nil {
+		return err
+	}
+
+	// runs are removed together with the experiments.
+	for _, namespaceID := range namespaceIDs {
+		if err := sendRunEvent(ctx, r.runEventListener, r.db, events.RunEvent{
+			NamespaceID: namespaceID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
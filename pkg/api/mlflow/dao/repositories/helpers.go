@@ -1,10 +1,13 @@
 package repositories
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/rotisserie/eris"
 	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 )
@@ -68,3 +71,51 @@ func BuildJsonCondition(
 	sql = strings.Repeat(conditionTemplate+" AND ", len(jsonPathValueMap)-1) + conditionTemplate
 	return sql, args
 }
+
+// KeyCardinality represents the number of distinct keys stored in some scope
+// and how many of the requested keys are already among them.
+type KeyCardinality struct {
+	Total    int64
+	Existing int64
+}
+
+// getKeyCardinalityByRunID counts distinct keys of the given table for the run.
+func getKeyCardinalityByRunID(
+	ctx context.Context, db *gorm.DB, table, runID string, keys []string,
+) (*KeyCardinality, error) {
+	var cardinality KeyCardinality
+	if err := db.WithContext(ctx).Table(table).Select(
+		fmt.Sprintf(
+			"COUNT(DISTINCT %[1]s.key) AS total, COUNT(DISTINCT CASE WHEN %[1]s.key IN ? THEN %[1]s.key END) AS existing",
+			table,
+		), keys,
+	).Where(
+		fmt.Sprintf("%s.run_uuid = ?", table), runID,
+	).Scan(&cardinality).Error; err != nil {
+		return nil, eris.Wrapf(err, "error counting distinct keys in %s for run with id: %s", table, runID)
+	}
+	return &cardinality, nil
+}
+
+// getKeyCardinalityByNamespaceID counts distinct keys of the given table across all the runs of the namespace.
+func getKeyCardinalityByNamespaceID(
+	ctx context.Context, db *gorm.DB, table string, namespaceID uint, keys []string,
+) (*KeyCardinality, error) {
+	var cardinality KeyCardinality
+	if err := db.WithContext(ctx).Table(table).Select(
+		fmt.Sprintf(
+			"COUNT(DISTINCT %[1]s.key) AS total, COUNT(DISTINCT CASE WHEN %[1]s.key IN ? THEN %[1]s.key END) AS existing",
+			table,
+		), keys,
+	).Joins(
+		fmt.Sprintf("INNER JOIN runs ON runs.run_uuid = %s.run_uuid", table),
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Scan(&cardinality).Error; err != nil {
+		return nil, eris.Wrapf(
+			err, "error counting distinct keys in %s for namespace with id: %d", table, namespaceID,
+		)
+	}
+	return &cardinality, nil
+}
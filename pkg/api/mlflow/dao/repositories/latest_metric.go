@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// LatestMetricCompaction represents result of the latest metrics compaction of one batch of runs.
+type LatestMetricCompaction struct {
+	// LastRunID is ID of the last compacted run of the batch. Empty means there were no more runs.
+	LastRunID string
+	Runs      int64
+	Created   int64
+	Updated   int64
+	Deleted   int64
+}
+
+// LatestMetricRepositoryProvider provides an interface to work with models.LatestMetric entity.
+type LatestMetricRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Compact recomputes the latest metrics of at most limit runs, following the run with afterRunID,
+	// from the metrics history and fixes the ones which drifted from it.
+	Compact(ctx context.Context, afterRunID string, limit int) (*LatestMetricCompaction, error)
+}
+
+// LatestMetricRepository repository to work with models.LatestMetric entity.
+type LatestMetricRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewLatestMetricRepository creates repository to work with models.LatestMetric entity.
+func NewLatestMetricRepository(db *gorm.DB) *LatestMetricRepository {
+	return &LatestMetricRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Compact recomputes the latest metrics of at most limit runs, following the run with afterRunID,
+// from the metrics history and fixes the ones which drifted from it. Runs are processed in order of ID.
+// Latest metrics are read before the history and each fix is applied only if `last_iter` is still the
+// same, so metrics logged concurrently are never overwritten by stale values. Such latest metrics are
+// just skipped, as they have been updated by logging anyway.
+func (r LatestMetricRepository) Compact(
+	ctx context.Context, afterRunID string, limit int,
+) (*LatestMetricCompaction, error) {
+	var runIDs []string
+	if err := r.GetDBWithContext(ctx).Model(
+		&models.Run{},
+	).Where(
+		"run_uuid > ?", afterRunID,
+	).Order(
+		"run_uuid",
+	).Limit(
+		limit,
+	).Pluck("run_uuid", &runIDs).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting runs to compact")
+	}
+	if len(runIDs) == 0 {
+		return &LatestMetricCompaction{}, nil
+	}
+
+	var currentLatestMetrics []models.LatestMetric
+	if err := r.GetDBWithContext(ctx).Where(
+		"run_uuid IN ?", runIDs,
+	).Find(&currentLatestMetrics).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting current latest metrics")
+	}
+
+	var expectedLatestMetrics []models.LatestMetric
+	if err := r.GetDBWithContext(ctx).Raw(
+		`SELECT run_uuid, key, context_id, value, timestamp, step, is_nan, last_iter FROM (
+			SELECT run_uuid, key, context_id, value, timestamp, step, is_nan,
+				MAX(iter) OVER (PARTITION BY run_uuid, key, context_id) AS last_iter,
+				ROW_NUMBER() OVER (
+					PARTITION BY run_uuid, key, context_id ORDER BY step DESC, timestamp DESC, value DESC
+				) AS row_num
+			FROM metrics WHERE run_uuid IN ?
+		) AS ranked WHERE row_num = 1`,
+		runIDs,
+	).Scan(&expectedLatestMetrics).Error; err != nil {
+		return nil, eris.Wrap(err, "error calculating latest metrics from metrics history")
+	}
+
+	compaction := LatestMetricCompaction{
+		LastRunID: runIDs[len(runIDs)-1],
+		Runs:      int64(len(runIDs)),
+	}
+	currentLatestMetricsMap := make(map[string]models.LatestMetric, len(currentLatestMetrics))
+	for _, latestMetric := range currentLatestMetrics {
+		currentLatestMetricsMap[latestMetric.UniqueKey()] = latestMetric
+	}
+	for _, expected := range expectedLatestMetrics {
+		current, ok := currentLatestMetricsMap[expected.UniqueKey()]
+		delete(currentLatestMetricsMap, expected.UniqueKey())
+		if !ok {
+			result := r.GetDBWithContext(ctx).Omit(
+				clause.Associations,
+			).Clauses(
+				clause.OnConflict{DoNothing: true},
+			).Create(&expected)
+			if result.Error != nil {
+				return nil, eris.Wrapf(result.Error, "error creating latest metric for run: %s", expected.RunID)
+			}
+			compaction.Created += result.RowsAffected
+			continue
+		}
+		if current.Value == expected.Value &&
+			current.Timestamp == expected.Timestamp &&
+			current.Step == expected.Step &&
+			current.IsNan == expected.IsNan &&
+			current.LastIter == expected.LastIter {
+			continue
+		}
+		result := r.GetDBWithContext(ctx).Model(
+			&models.LatestMetric{},
+		).Where(
+			"run_uuid = ? AND key = ? AND context_id = ? AND last_iter = ?",
+			current.RunID, current.Key, current.ContextID, current.LastIter,
+		).Updates(map[string]any{
+			"value":     expected.Value,
+			"timestamp": expected.Timestamp,
+			"step":      expected.Step,
+			"is_nan":    expected.IsNan,
+			"last_iter": expected.LastIter,
+		})
+		if result.Error != nil {
+			return nil, eris.Wrapf(result.Error, "error updating latest metric for run: %s", current.RunID)
+		}
+		compaction.Updated += result.RowsAffected
+	}
+
+	// the rest of the latest metrics don't have any history.
+	for _, current := range currentLatestMetricsMap {
+		result := r.GetDBWithContext(ctx).Where(
+			"run_uuid = ? AND key = ? AND context_id = ? AND last_iter = ?",
+			current.RunID, current.Key, current.ContextID, current.LastIter,
+		).Delete(&models.LatestMetric{})
+		if result.Error != nil {
+			return nil, eris.Wrapf(result.Error, "error deleting latest metric for run: %s", current.RunID)
+		}
+		compaction.Deleted += result.RowsAffected
+	}
+	return &compaction, nil
+}
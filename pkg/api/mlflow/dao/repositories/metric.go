@@ -3,6 +3,9 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"math"
 
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
@@ -19,6 +22,13 @@ const (
 	MetricHistoryBulkDefaultLimit = 25000
 )
 
+// ExperimentBestMetric represents the best latest metric value among the runs of the experiment.
+type ExperimentBestMetric struct {
+	ExperimentID int32
+	RunID        string
+	Value        float64
+}
+
 // MetricRepositoryProvider provides an interface to work with models.Metric entity.
 type MetricRepositoryProvider interface {
 	repositories.BaseRepositoryProvider
@@ -32,13 +42,41 @@ type MetricRepositoryProvider interface {
 		viewType request.ViewType,
 		limit int32,
 		jsonPathValueMap map[string]string,
+		window request.MetricHistoryWindow,
 	) (*sql.Rows, func(*sql.Rows, interface{}) error, error)
 	// GetMetricHistoryBulk returns metrics history bulk.
 	GetMetricHistoryBulk(
-		ctx context.Context, namespaceID uint, runIDs []string, key string, limit int,
+		ctx context.Context,
+		namespaceID uint,
+		runIDs []string,
+		key string,
+		window request.MetricHistoryWindow,
+		limit int,
 	) ([]models.Metric, error)
 	// GetMetricHistoryByRunIDAndKey returns metrics history by RunID and Key.
-	GetMetricHistoryByRunIDAndKey(ctx context.Context, runID, key string) ([]models.Metric, error)
+	GetMetricHistoryByRunIDAndKey(
+		ctx context.Context,
+		runID, key string,
+		window request.MetricHistoryWindow,
+		pageToken *request.MetricHistoryPageToken,
+		limit int,
+	) ([]models.Metric, error)
+	// GetKeyCardinalityByRunID returns cardinality of metric keys of the run.
+	GetKeyCardinalityByRunID(ctx context.Context, runID string, keys []string) (*KeyCardinality, error)
+	// GetKeyCardinalityByNamespaceID returns cardinality of metric keys of the namespace.
+	GetKeyCardinalityByNamespaceID(ctx context.Context, namespaceID uint, keys []string) (*KeyCardinality, error)
+	// DeleteByRunIDAndKey removes history and latest value of the run metric in all or only the given context.
+	DeleteByRunIDAndKey(ctx context.Context, runID, key string, metricContext *models.Context) (int64, error)
+	// GetBestByExperimentIDs returns the best latest value of the metric in the given context
+	// among the active runs of each of the experiments.
+	GetBestByExperimentIDs(
+		ctx context.Context,
+		namespaceID uint,
+		experimentIDs []int32,
+		key string,
+		metricContext *models.Context,
+		direction models.MetricDirection,
+	) ([]ExperimentBestMetric, error)
 }
 
 // MetricRepository repository to work with models.Metric entity.
@@ -53,7 +91,11 @@ func NewMetricRepository(db *gorm.DB) *MetricRepository {
 	}
 }
 
-// CreateBatch creates []models.Metric entities in batch.
+// CreateBatch creates []models.Metric entities in batch. Metrics are inserted together with
+// the latest metrics update in a single transaction, which is retried after transient failures.
+// Iters are calculated again on each attempt and already existing metrics are ignored, so rerun
+// of the transaction doesn't duplicate anything. The latest metrics are updated only by the points,
+// which have been actually inserted, so retried batches don't advance iters of the already logged points.
 // TODO:get back and fix `gocyclo` problem.
 //
 //nolint:gocyclo
@@ -73,21 +115,16 @@ func (r MetricRepository) CreateBatch(
 		metricKeys = append(metricKeys, k)
 	}
 
-	// get the latest metrics by requested Run ID and metric keys.
-	lastMetrics, err := r.getLatestMetricsByRunIDAndKeys(ctx, run.ID, metricKeys)
-	if err != nil {
-		return eris.Wrap(err, "error getting latest metrics")
-	}
-
-	lastIters := make(map[string]int64)
-	for _, lastMetric := range lastMetrics {
-		lastIters[lastMetric.UniqueKey()] = lastMetric.LastIter
-	}
+	// contexts are stored in canonical form, so semantically identical contexts map to the same row.
 	allContexts := make([]*models.Context, len(metrics))
 	uniqueContexts := make([]*models.Context, 0, len(metrics))
 	contextProcessed := make(map[string]*models.Context)
-	latestMetrics := make(map[string]models.LatestMetric)
 	for n := range metrics {
+		canonical, err := metrics[n].Context.Json.Canonical()
+		if err != nil {
+			return eris.Wrapf(err, "error normalizing context of metric '%s'", metrics[n].Key)
+		}
+		metrics[n].Context.Json = canonical
 		ctxHash := metrics[n].Context.GetJsonHash()
 		ctxRef, ok := contextProcessed[ctxHash]
 		if ok {
@@ -99,74 +136,140 @@ func (r MetricRepository) CreateBatch(
 		}
 	}
 
-	if err := r.GetDB().WithContext(ctx).Clauses(
-		clause.OnConflict{
-			Columns:   []clause.Column{{Name: "json"}},
-			UpdateAll: true,
-		},
-	).CreateInBatches(&uniqueContexts, batchSize).Error; err != nil {
+	if err := repositories.RetryOnTransientError(ctx, func() error {
+		return r.GetDBWithContext(ctx).Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "json"}},
+				UpdateAll: true,
+			},
+		).CreateInBatches(&uniqueContexts, batchSize).Error
+	}); err != nil {
 		return eris.Wrapf(err, "error creating contexts")
 	}
 
-	for n := range metrics {
-		metrics[n].ContextID = allContexts[n].ID
-		metrics[n].Context = *allContexts[n]
-		metrics[n].Iter = lastIters[metrics[n].UniqueKey()] + 1
-		lastIters[metrics[n].UniqueKey()] = metrics[n].Iter
-		lm, ok := latestMetrics[metrics[n].UniqueKey()]
-		if !ok ||
-			metrics[n].Step > lm.Step ||
-			(metrics[n].Step == lm.Step && metrics[n].Timestamp > lm.Timestamp) ||
-			(metrics[n].Step == lm.Step && metrics[n].Timestamp == lm.Timestamp && metrics[n].Value > lm.Value) {
-			latestMetrics[metrics[n].UniqueKey()] = models.LatestMetric{
-				RunID:     metrics[n].RunID,
-				Key:       metrics[n].Key,
-				Value:     metrics[n].Value,
-				Timestamp: metrics[n].Timestamp,
-				Step:      metrics[n].Step,
-				IsNan:     metrics[n].IsNan,
-				LastIter:  metrics[n].Iter,
-				ContextID: allContexts[n].ID,
-				Context:   *allContexts[n],
-			}
+	return repositories.TransactionWithRetry(ctx, r.GetDB(), func(tx *gorm.DB) error {
+		// get the latest metrics by requested Run ID and metric keys.
+		lastMetrics, err := r.getLatestMetricsByRunIDAndKeys(ctx, tx, run.ID, metricKeys)
+		if err != nil {
+			return eris.Wrap(err, "error getting latest metrics")
 		}
-	}
 
-	if err := r.GetDB().WithContext(ctx).Clauses(
-		clause.OnConflict{DoNothing: true},
-	).CreateInBatches(&metrics, batchSize).Error; err != nil {
-		return eris.Wrapf(err, "error creating metrics for run: %s", run.ID)
-	}
+		lastIters := make(map[string]int64)
+		for _, lastMetric := range lastMetrics {
+			lastIters[lastMetric.UniqueKey()] = lastMetric.LastIter
+		}
+		for n := range metrics {
+			metrics[n].ContextID = allContexts[n].ID
+			metrics[n].Context = *allContexts[n]
+			metrics[n].Iter = lastIters[metrics[n].UniqueKey()] + 1
+			lastIters[metrics[n].UniqueKey()] = metrics[n].Iter
+		}
 
-	// TODO update latest metrics in the background?
-	currentLatestMetricsMap := make(map[string]models.LatestMetric, len(latestMetrics))
-	for k, m := range latestMetrics {
-		currentLatestMetricsMap[k] = m
-	}
+		insertedMetrics, err := r.insertMetrics(ctx, tx, batchSize, metrics)
+		if err != nil {
+			return eris.Wrapf(err, "error creating metrics for run: %s", run.ID)
+		}
+
+		latestMetrics := make(map[string]models.LatestMetric)
+		for n := range insertedMetrics {
+			lm, ok := latestMetrics[insertedMetrics[n].UniqueKey()]
+			if !ok ||
+				insertedMetrics[n].Step > lm.Step ||
+				(insertedMetrics[n].Step == lm.Step && insertedMetrics[n].Timestamp > lm.Timestamp) ||
+				(insertedMetrics[n].Step == lm.Step && insertedMetrics[n].Timestamp == lm.Timestamp &&
+					insertedMetrics[n].Value > lm.Value) {
+				latestMetrics[insertedMetrics[n].UniqueKey()] = models.LatestMetric{
+					RunID:     insertedMetrics[n].RunID,
+					Key:       insertedMetrics[n].Key,
+					Value:     insertedMetrics[n].Value,
+					Timestamp: insertedMetrics[n].Timestamp,
+					Step:      insertedMetrics[n].Step,
+					IsNan:     insertedMetrics[n].IsNan,
+					LastIter:  insertedMetrics[n].Iter,
+					ContextID: insertedMetrics[n].ContextID,
+					Context:   insertedMetrics[n].Context,
+				}
+			}
+		}
+
+		// last iter is the iter of the last inserted point, which isn't necessarily the latest one.
+		insertedLastIters := make(map[string]int64, len(latestMetrics))
+		for _, metric := range insertedMetrics {
+			insertedLastIters[metric.UniqueKey()] = max(insertedLastIters[metric.UniqueKey()], metric.Iter)
+		}
 
-	updatedLatestMetrics := make([]models.LatestMetric, 0, len(latestMetrics))
-	for k, m := range latestMetrics {
-		lm, ok := currentLatestMetricsMap[k]
-		if !ok ||
-			m.Step > lm.Step ||
-			(m.Step == lm.Step && m.Timestamp > lm.Timestamp) ||
-			(m.Step == lm.Step && m.Timestamp == lm.Timestamp && m.Value > lm.Value) {
+		// TODO update latest metrics in the background?
+		updatedLatestMetrics := make([]models.LatestMetric, 0, len(latestMetrics))
+		for k, m := range latestMetrics {
+			m.LastIter = insertedLastIters[k]
 			updatedLatestMetrics = append(updatedLatestMetrics, m)
-		} else {
-			lm.LastIter = lastIters[k]
-			updatedLatestMetrics = append(updatedLatestMetrics, lm)
 		}
+
+		if len(updatedLatestMetrics) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "run_uuid"}, {Name: "key"}, {Name: "context_id"}},
+				UpdateAll: true,
+			}).Create(&updatedLatestMetrics).Error; err != nil {
+				return eris.Wrapf(err, "error updating latest metrics for run: %s", run.ID)
+			}
+		}
+		return nil
+	})
+}
+
+// insertMetrics inserts metrics in batches and returns the inserted ones. Points, which are already logged,
+// conflict with the metric primary key and are skipped by the database. Points repeated in the batch are
+// inserted once, with the iter of the last of them, so they still count as separate iterations.
+func (r MetricRepository) insertMetrics(
+	ctx context.Context, tx *gorm.DB, batchSize int, metrics []models.Metric,
+) ([]models.Metric, error) {
+	positions := make(map[string]int, len(metrics))
+	uniqueMetrics := make([]models.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		if n, ok := positions[metricPointKey(metric)]; ok {
+			uniqueMetrics[n].Iter = metric.Iter
+			continue
+		}
+		positions[metricPointKey(metric)] = len(uniqueMetrics)
+		uniqueMetrics = append(uniqueMetrics, metric)
+	}
+	if batchSize <= 0 {
+		batchSize = len(uniqueMetrics)
 	}
 
-	if len(updatedLatestMetrics) > 0 {
-		if err := r.GetDB().Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "run_uuid"}, {Name: "key"}, {Name: "context_id"}},
-			UpdateAll: true,
-		}).Create(&updatedLatestMetrics).Error; err != nil {
-			return eris.Wrapf(err, "error updating latest metrics for run: %s", run.ID)
+	insertedMetrics := make([]models.Metric, 0, len(uniqueMetrics))
+	for i := 0; i < len(uniqueMetrics); i += batchSize {
+		batch := uniqueMetrics[i:min(i+batchSize, len(uniqueMetrics))]
+		// statement is only built by gorm and executed as a query, as gorm matches returned rows
+		// to the created ones by their position, which is wrong, when some of them are skipped.
+		stmt := tx.WithContext(ctx).Session(&gorm.Session{DryRun: true}).Omit(
+			clause.Associations,
+		).Clauses(
+			clause.OnConflict{DoNothing: true},
+			clause.Returning{Columns: []clause.Column{
+				{Name: "key"}, {Name: "value"}, {Name: "timestamp"}, {Name: "step"}, {Name: "is_nan"},
+				{Name: "context_id"},
+			}},
+		).Create(&batch).Statement
+		var inserted []models.Metric
+		if err := tx.WithContext(ctx).Raw(stmt.SQL.String(), stmt.Vars...).Scan(&inserted).Error; err != nil {
+			return nil, eris.Wrap(err, "error inserting metrics")
+		}
+		for _, metric := range inserted {
+			metric.RunID = batch[0].RunID
+			insertedMetrics = append(insertedMetrics, uniqueMetrics[positions[metricPointKey(metric)]])
 		}
 	}
-	return nil
+	return insertedMetrics, nil
+}
+
+// metricPointKey returns key identifying the metric point by the columns of the metric primary key.
+func metricPointKey(metric models.Metric) string {
+	return fmt.Sprintf(
+		"%s-%s-%d-%d-%d-%t-%d",
+		metric.RunID, metric.Key, math.Float64bits(metric.Value), metric.Timestamp, metric.Step, metric.IsNan,
+		metric.ContextID,
+	)
 }
 
 // GetMetricHistories returns metric histories by request parameters.
@@ -178,10 +281,11 @@ func (r MetricRepository) GetMetricHistories(
 	viewType request.ViewType,
 	limit int32,
 	jsonPathValueMap map[string]string,
+	window request.MetricHistoryWindow,
 ) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
 	// if experimentIDs has been provided then firstly get the runs by provided experimentIDs.
 	if len(experimentIDs) > 0 {
-		query := r.GetDB().WithContext(ctx).Model(
+		query := r.GetDBWithContext(ctx).Model(
 			&database.Run{},
 		).Joins(
 			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
@@ -214,7 +318,7 @@ func (r MetricRepository) GetMetricHistories(
 
 	// if experimentIDs has been provided then runIDs contains values from previous step,
 	// otherwise runIDs may or may not contain values.
-	query := r.GetDB().WithContext(ctx).Model(
+	query := r.GetDBWithContext(ctx).Model(
 		&database.Metric{},
 	).Where(
 		"metrics.run_uuid IN ?", runIDs,
@@ -253,6 +357,8 @@ func (r MetricRepository) GetMetricHistories(
 		sql, args := BuildJsonCondition(query.Dialector.Name(), "contexts.json", jsonPathValueMap)
 		query.Where(sql, args...)
 	}
+	query = applyMetricHistoryWindow(query, window)
+	query = r.applyMetricHistoryStride(query, runIDs, metricKeys, window)
 
 	rows, err := query.Rows()
 	if err != nil {
@@ -269,10 +375,10 @@ func (r MetricRepository) GetMetricHistories(
 
 // getLatestMetricsByRunIDAndKeys returns the latest metrics by requested Run ID and keys.
 func (r MetricRepository) getLatestMetricsByRunIDAndKeys(
-	ctx context.Context, runID string, keys []string,
+	ctx context.Context, tx *gorm.DB, runID string, keys []string,
 ) ([]models.LatestMetric, error) {
 	var metrics []models.LatestMetric
-	if err := r.GetDB().WithContext(ctx).Where(
+	if err := tx.WithContext(ctx).Where(
 		"run_uuid = ?", runID,
 	).Where(
 		"key IN ?", keys,
@@ -282,20 +388,45 @@ func (r MetricRepository) getLatestMetricsByRunIDAndKeys(
 	return metrics, nil
 }
 
-// GetMetricHistoryByRunIDAndKey returns metrics history by RunID and Key.
+// GetMetricHistoryByRunIDAndKey returns metrics history by RunID and Key ordered by step, timestamp,
+// context and iter. When page token is provided, history starts right after the metric it points to.
+// Zero limit means that the whole (rest of) history is returned.
 func (r MetricRepository) GetMetricHistoryByRunIDAndKey(
-	ctx context.Context, runID, key string,
+	ctx context.Context,
+	runID, key string,
+	window request.MetricHistoryWindow,
+	pageToken *request.MetricHistoryPageToken,
+	limit int,
 ) ([]models.Metric, error) {
-	var metrics []models.Metric
-	if err := r.GetDB().WithContext(
-		ctx,
-	).Joins(
+	query := r.GetDBWithContext(ctx).Joins(
 		"Context",
 	).Where(
-		"run_uuid = ?", runID,
+		"metrics.run_uuid = ?", runID,
 	).Where(
-		"key = ?", key,
-	).Find(&metrics).Error; err != nil {
+		"metrics.key = ?", key,
+	).Order(
+		"metrics.step",
+	).Order(
+		"metrics.timestamp",
+	).Order(
+		"metrics.context_id",
+	).Order(
+		"metrics.iter",
+	)
+	query = applyMetricHistoryWindow(query, window)
+	query = r.applyMetricHistoryStride(query, []string{runID}, []string{key}, window)
+	if pageToken != nil {
+		query = query.Where(
+			"(metrics.step, metrics.timestamp, metrics.context_id, metrics.iter) > (?, ?, ?, ?)",
+			pageToken.Step, pageToken.Timestamp, pageToken.ContextID, pageToken.Iter,
+		)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var metrics []models.Metric
+	if err := query.Find(&metrics).Error; err != nil {
 		return nil, eris.Wrapf(err, "error getting metric history by run id: %s and key: %s", runID, key)
 	}
 	return metrics, nil
@@ -303,10 +434,15 @@ func (r MetricRepository) GetMetricHistoryByRunIDAndKey(
 
 // GetMetricHistoryBulk returns metrics history bulk.
 func (r MetricRepository) GetMetricHistoryBulk(
-	ctx context.Context, namespaceID uint, runIDs []string, key string, limit int,
+	ctx context.Context,
+	namespaceID uint,
+	runIDs []string,
+	key string,
+	window request.MetricHistoryWindow,
+	limit int,
 ) ([]models.Metric, error) {
 	var metrics []models.Metric
-	query := r.GetDB().WithContext(ctx).Where(
+	query := r.GetDBWithContext(ctx).Where(
 		"runs.run_uuid IN ?", runIDs,
 	).Joins(
 		"LEFT JOIN runs ON runs.run_uuid = metrics.run_uuid",
@@ -324,6 +460,8 @@ func (r MetricRepository) GetMetricHistoryBulk(
 	).Order(
 		"metrics.value",
 	)
+	query = applyMetricHistoryWindow(query, window)
+	query = r.applyMetricHistoryStride(query, runIDs, []string{key}, window)
 
 	if limit == 0 {
 		limit = MetricHistoryBulkDefaultLimit
@@ -337,3 +475,169 @@ func (r MetricRepository) GetMetricHistoryBulk(
 	}
 	return metrics, nil
 }
+
+// applyMetricHistoryWindow narrows down metrics query to the requested range of steps and timestamps.
+func applyMetricHistoryWindow(query *gorm.DB, window request.MetricHistoryWindow) *gorm.DB {
+	if window.MinStep != nil {
+		query = query.Where("metrics.step >= ?", *window.MinStep)
+	}
+	if window.MaxStep != nil {
+		query = query.Where("metrics.step <= ?", *window.MaxStep)
+	}
+	if window.StartTime != nil {
+		query = query.Where("metrics.timestamp >= ?", *window.StartTime)
+	}
+	if window.EndTime != nil {
+		query = query.Where("metrics.timestamp <= ?", *window.EndTime)
+	}
+	return query
+}
+
+// applyMetricHistoryStride narrows down metrics query to every Nth point of each metric series
+// within the requested window, always keeping the first and the last points of the series.
+// Points are numbered per run, key and context, so stride composes with context filtering.
+func (r MetricRepository) applyMetricHistoryStride(
+	query *gorm.DB, runIDs, keys []string, window request.MetricHistoryWindow,
+) *gorm.DB {
+	if window.Stride <= 1 {
+		return query
+	}
+
+	numbered := r.GetDB().Table(
+		"metrics",
+	).Select(
+		"metrics.run_uuid, metrics.key, metrics.context_id, metrics.step, metrics.timestamp, "+
+			"metrics.value, metrics.is_nan, "+
+			"ROW_NUMBER() OVER ("+
+			"PARTITION BY metrics.run_uuid, metrics.key, metrics.context_id "+
+			"ORDER BY metrics.step, metrics.timestamp, metrics.iter"+
+			") AS row_num, "+
+			"COUNT(*) OVER (PARTITION BY metrics.run_uuid, metrics.key, metrics.context_id) AS row_count",
+	).Where(
+		"metrics.run_uuid IN ?", runIDs,
+	)
+	if len(keys) > 0 {
+		numbered = numbered.Where("metrics.key IN ?", keys)
+	}
+	numbered = applyMetricHistoryWindow(numbered, window)
+
+	return query.Joins(
+		"INNER JOIN (?) AS strided_metrics ON strided_metrics.run_uuid = metrics.run_uuid "+
+			"AND strided_metrics.key = metrics.key "+
+			"AND strided_metrics.context_id = metrics.context_id "+
+			"AND strided_metrics.step = metrics.step "+
+			"AND strided_metrics.timestamp = metrics.timestamp "+
+			"AND strided_metrics.value = metrics.value "+
+			"AND strided_metrics.is_nan = metrics.is_nan "+
+			"AND ((strided_metrics.row_num - 1) % ? = 0 OR strided_metrics.row_num = strided_metrics.row_count)",
+		numbered, window.Stride,
+	)
+}
+
+// GetKeyCardinalityByRunID returns cardinality of metric keys of the run.
+func (r MetricRepository) GetKeyCardinalityByRunID(
+	ctx context.Context, runID string, keys []string,
+) (*KeyCardinality, error) {
+	return getKeyCardinalityByRunID(ctx, r.GetDBWithContext(ctx), "latest_metrics", runID, keys)
+}
+
+// GetKeyCardinalityByNamespaceID returns cardinality of metric keys of the namespace.
+func (r MetricRepository) GetKeyCardinalityByNamespaceID(
+	ctx context.Context, namespaceID uint, keys []string,
+) (*KeyCardinality, error) {
+	return getKeyCardinalityByNamespaceID(ctx, r.GetDBWithContext(ctx), "latest_metrics", namespaceID, keys)
+}
+
+// DeleteByRunIDAndKey removes history and latest value of the run metric in scope of one transaction.
+// When metricContext is nil, metric is removed in all the contexts. It returns number of removed
+// metric series, so zero means that there was no such metric.
+func (r MetricRepository) DeleteByRunIDAndKey(
+	ctx context.Context, runID, key string, metricContext *models.Context,
+) (int64, error) {
+	var deleted int64
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		latestMetricsQuery := tx.Where("run_uuid = ? AND key = ?", runID, key)
+		metricsQuery := tx.Where("run_uuid = ? AND key = ?", runID, key)
+		if metricContext != nil {
+			canonical, err := metricContext.Json.Canonical()
+			if err != nil {
+				return eris.Wrap(err, "error normalizing metric context")
+			}
+			var existingContext models.Context
+			if err := tx.Where("json = ?", canonical).First(&existingContext).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return eris.Wrap(err, "error getting metric context")
+			}
+			latestMetricsQuery = latestMetricsQuery.Where("context_id = ?", existingContext.ID)
+			metricsQuery = metricsQuery.Where("context_id = ?", existingContext.ID)
+		}
+
+		result := latestMetricsQuery.Delete(&models.LatestMetric{})
+		if result.Error != nil {
+			return eris.Wrap(result.Error, "error deleting latest metrics")
+		}
+		deleted = result.RowsAffected
+		if err := metricsQuery.Delete(&models.Metric{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting metrics")
+		}
+		return nil
+	}); err != nil {
+		return 0, eris.Wrapf(err, "error deleting metric '%s' of run: %s", key, runID)
+	}
+	return deleted, nil
+}
+
+// GetBestByExperimentIDs returns the best latest value of the metric in the given context among the active
+// runs of each of the experiments. The best value is the lowest one, unless the direction is max,
+// and ties are resolved by run ID. NaN values are never the best ones. Experiments without
+// such a metric are omitted.
+func (r MetricRepository) GetBestByExperimentIDs(
+	ctx context.Context,
+	namespaceID uint,
+	experimentIDs []int32,
+	key string,
+	metricContext *models.Context,
+	direction models.MetricDirection,
+) ([]ExperimentBestMetric, error) {
+	canonical, err := metricContext.Json.Canonical()
+	if err != nil {
+		return nil, eris.Wrap(err, "error normalizing metric context")
+	}
+	var existingContext models.Context
+	if err := r.GetDBWithContext(ctx).Where("json = ?", canonical).First(&existingContext).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrap(err, "error getting metric context")
+	}
+
+	order := "ASC"
+	if direction == models.MetricDirectionMax {
+		order = "DESC"
+	}
+	var bestMetrics []ExperimentBestMetric
+	if err := r.GetDBWithContext(ctx).Raw(
+		`SELECT experiment_id, run_id, value FROM (
+			SELECT runs.experiment_id, latest_metrics.run_uuid AS run_id, latest_metrics.value,
+				ROW_NUMBER() OVER (
+					PARTITION BY runs.experiment_id
+					ORDER BY latest_metrics.value `+order+`, latest_metrics.run_uuid
+				) AS row_num
+			FROM latest_metrics
+			INNER JOIN runs ON runs.run_uuid = latest_metrics.run_uuid
+			INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id
+			WHERE experiments.namespace_id = ?
+				AND runs.experiment_id IN ?
+				AND runs.lifecycle_stage = ?
+				AND latest_metrics.key = ?
+				AND latest_metrics.context_id = ?
+				AND NOT latest_metrics.is_nan
+		) AS ranked WHERE row_num = 1 ORDER BY experiment_id`,
+		namespaceID, experimentIDs, models.LifecycleStageActive, key, existingContext.ID,
+	).Scan(&bestMetrics).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting best values of metric '%s' of experiments", key)
+	}
+	return bestMetrics, nil
+}
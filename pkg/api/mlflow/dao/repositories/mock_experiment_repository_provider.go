@@ -59,6 +59,32 @@ func (_m *MockExperimentRepositoryProvider) DeleteBatch(ctx context.Context, ids
 	return r0
 }
 
+// GetByID provides a mock function with given fields: ctx, experimentID
+func (_m *MockExperimentRepositoryProvider) GetByID(ctx context.Context, experimentID int32) (*models.Experiment, error) {
+	ret := _m.Called(ctx, experimentID)
+
+	var r0 *models.Experiment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (*models.Experiment, error)); ok {
+		return rf(ctx, experimentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) *models.Experiment); ok {
+		r0 = rf(ctx, experimentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Experiment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, experimentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByNamespaceIDAndExperimentID provides a mock function with given fields: ctx, namespaceID, experimentID
 func (_m *MockExperimentRepositoryProvider) GetByNamespaceIDAndExperimentID(ctx context.Context, namespaceID uint, experimentID int32) (*models.Experiment, error) {
 	ret := _m.Called(ctx, namespaceID, experimentID)
@@ -111,6 +137,20 @@ func (_m *MockExperimentRepositoryProvider) GetByNamespaceIDAndName(ctx context.
 	return r0, r1
 }
 
+// Restore provides a mock function with given fields: ctx, experiment
+func (_m *MockExperimentRepositoryProvider) Restore(ctx context.Context, experiment *models.Experiment) error {
+	ret := _m.Called(ctx, experiment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Experiment) error); ok {
+		r0 = rf(ctx, experiment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, experiment
 func (_m *MockExperimentRepositoryProvider) Update(ctx context.Context, experiment *models.Experiment) error {
 	ret := _m.Called(ctx, experiment)
@@ -125,6 +165,20 @@ func (_m *MockExperimentRepositoryProvider) Update(ctx context.Context, experime
 	return r0
 }
 
+// UpdateNamespaceID provides a mock function with given fields: ctx, experiment, namespaceID, artifactLocation
+func (_m *MockExperimentRepositoryProvider) UpdateNamespaceID(ctx context.Context, experiment *models.Experiment, namespaceID uint, artifactLocation string) error {
+	ret := _m.Called(ctx, experiment, namespaceID, artifactLocation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Experiment, uint, string) error); ok {
+		r0 = rf(ctx, experiment, namespaceID, artifactLocation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateWithTransaction provides a mock function with given fields: ctx, tx, experiment
 func (_m *MockExperimentRepositoryProvider) UpdateWithTransaction(ctx context.Context, tx *gorm.DB, experiment *models.Experiment) error {
 	ret := _m.Called(ctx, tx, experiment)
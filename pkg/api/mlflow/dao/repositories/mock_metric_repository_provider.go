@@ -35,6 +35,56 @@ func (_m *MockMetricRepositoryProvider) CreateBatch(ctx context.Context, run *mo
 	return r0
 }
 
+// DeleteByRunIDAndKey provides a mock function with given fields: ctx, runID, key, metricContext
+func (_m *MockMetricRepositoryProvider) DeleteByRunIDAndKey(ctx context.Context, runID string, key string, metricContext *models.Context) (int64, error) {
+	ret := _m.Called(ctx, runID, key, metricContext)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.Context) (int64, error)); ok {
+		return rf(ctx, runID, key, metricContext)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.Context) int64); ok {
+		r0 = rf(ctx, runID, key, metricContext)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.Context) error); ok {
+		r1 = rf(ctx, runID, key, metricContext)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBestByExperimentIDs provides a mock function with given fields: ctx, namespaceID, experimentIDs, key, metricContext, direction
+func (_m *MockMetricRepositoryProvider) GetBestByExperimentIDs(ctx context.Context, namespaceID uint, experimentIDs []int32, key string, metricContext *models.Context, direction models.MetricDirection) ([]ExperimentBestMetric, error) {
+	ret := _m.Called(ctx, namespaceID, experimentIDs, key, metricContext, direction)
+
+	var r0 []ExperimentBestMetric
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []int32, string, *models.Context, models.MetricDirection) ([]ExperimentBestMetric, error)); ok {
+		return rf(ctx, namespaceID, experimentIDs, key, metricContext, direction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []int32, string, *models.Context, models.MetricDirection) []ExperimentBestMetric); ok {
+		r0 = rf(ctx, namespaceID, experimentIDs, key, metricContext, direction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ExperimentBestMetric)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []int32, string, *models.Context, models.MetricDirection) error); ok {
+		r1 = rf(ctx, namespaceID, experimentIDs, key, metricContext, direction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDB provides a mock function with given fields:
 func (_m *MockMetricRepositoryProvider) GetDB() *gorm.DB {
 	ret := _m.Called()
@@ -51,34 +101,102 @@ func (_m *MockMetricRepositoryProvider) GetDB() *gorm.DB {
 	return r0
 }
 
+// GetDBWithContext provides a mock function with given fields: ctx
+func (_m *MockMetricRepositoryProvider) GetDBWithContext(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
+// GetKeyCardinalityByNamespaceID provides a mock function with given fields: ctx, namespaceID, keys
+func (_m *MockMetricRepositoryProvider) GetKeyCardinalityByNamespaceID(ctx context.Context, namespaceID uint, keys []string) (*KeyCardinality, error) {
+	ret := _m.Called(ctx, namespaceID, keys)
+
+	var r0 *KeyCardinality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) (*KeyCardinality, error)); ok {
+		return rf(ctx, namespaceID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) *KeyCardinality); ok {
+		r0 = rf(ctx, namespaceID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*KeyCardinality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, namespaceID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeyCardinalityByRunID provides a mock function with given fields: ctx, runID, keys
+func (_m *MockMetricRepositoryProvider) GetKeyCardinalityByRunID(ctx context.Context, runID string, keys []string) (*KeyCardinality, error) {
+	ret := _m.Called(ctx, runID, keys)
+
+	var r0 *KeyCardinality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*KeyCardinality, error)); ok {
+		return rf(ctx, runID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *KeyCardinality); ok {
+		r0 = rf(ctx, runID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*KeyCardinality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, runID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMetricHistories provides a mock function with given fields: ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap
-func (_m *MockMetricRepositoryProvider) GetMetricHistories(ctx context.Context, namespaceID uint, experimentIDs []string, runIDs []string, metricKeys []string, viewType request.ViewType, limit int32, jsonPathValueMap map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
-	ret := _m.Called(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+func (_m *MockMetricRepositoryProvider) GetMetricHistories(ctx context.Context, namespaceID uint, experimentIDs []string, runIDs []string, metricKeys []string, viewType request.ViewType, limit int32, jsonPathValueMap map[string]string, window request.MetricHistoryWindow) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
+	ret := _m.Called(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap, window)
 
 	var r0 *sql.Rows
 	var r1 func(*sql.Rows, interface{}) error
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error)); ok {
-		return rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string, request.MetricHistoryWindow) (*sql.Rows, func(*sql.Rows, interface{}) error, error)); ok {
+		return rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap, window)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) *sql.Rows); ok {
-		r0 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string, request.MetricHistoryWindow) *sql.Rows); ok {
+		r0 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap, window)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sql.Rows)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) func(*sql.Rows, interface{}) error); ok {
-		r1 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string, request.MetricHistoryWindow) func(*sql.Rows, interface{}) error); ok {
+		r1 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap, window)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(func(*sql.Rows, interface{}) error)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) error); ok {
-		r2 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(2).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string, request.MetricHistoryWindow) error); ok {
+		r2 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap, window)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -87,24 +205,24 @@ func (_m *MockMetricRepositoryProvider) GetMetricHistories(ctx context.Context,
 }
 
 // GetMetricHistoryBulk provides a mock function with given fields: ctx, namespaceID, runIDs, key, limit
-func (_m *MockMetricRepositoryProvider) GetMetricHistoryBulk(ctx context.Context, namespaceID uint, runIDs []string, key string, limit int) ([]models.Metric, error) {
-	ret := _m.Called(ctx, namespaceID, runIDs, key, limit)
+func (_m *MockMetricRepositoryProvider) GetMetricHistoryBulk(ctx context.Context, namespaceID uint, runIDs []string, key string, window request.MetricHistoryWindow, limit int) ([]models.Metric, error) {
+	ret := _m.Called(ctx, namespaceID, runIDs, key, window, limit)
 
 	var r0 []models.Metric
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, string, int) ([]models.Metric, error)); ok {
-		return rf(ctx, namespaceID, runIDs, key, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, string, request.MetricHistoryWindow, int) ([]models.Metric, error)); ok {
+		return rf(ctx, namespaceID, runIDs, key, window, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, string, int) []models.Metric); ok {
-		r0 = rf(ctx, namespaceID, runIDs, key, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, string, request.MetricHistoryWindow, int) []models.Metric); ok {
+		r0 = rf(ctx, namespaceID, runIDs, key, window, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Metric)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, string, int) error); ok {
-		r1 = rf(ctx, namespaceID, runIDs, key, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, string, request.MetricHistoryWindow, int) error); ok {
+		r1 = rf(ctx, namespaceID, runIDs, key, window, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,25 +230,25 @@ func (_m *MockMetricRepositoryProvider) GetMetricHistoryBulk(ctx context.Context
 	return r0, r1
 }
 
-// GetMetricHistoryByRunIDAndKey provides a mock function with given fields: ctx, runID, key
-func (_m *MockMetricRepositoryProvider) GetMetricHistoryByRunIDAndKey(ctx context.Context, runID string, key string) ([]models.Metric, error) {
-	ret := _m.Called(ctx, runID, key)
+// GetMetricHistoryByRunIDAndKey provides a mock function with given fields: ctx, runID, key, pageToken, limit
+func (_m *MockMetricRepositoryProvider) GetMetricHistoryByRunIDAndKey(ctx context.Context, runID string, key string, window request.MetricHistoryWindow, pageToken *request.MetricHistoryPageToken, limit int) ([]models.Metric, error) {
+	ret := _m.Called(ctx, runID, key, window, pageToken, limit)
 
 	var r0 []models.Metric
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.Metric, error)); ok {
-		return rf(ctx, runID, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.MetricHistoryWindow, *request.MetricHistoryPageToken, int) ([]models.Metric, error)); ok {
+		return rf(ctx, runID, key, window, pageToken, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.Metric); ok {
-		r0 = rf(ctx, runID, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.MetricHistoryWindow, *request.MetricHistoryPageToken, int) []models.Metric); ok {
+		r0 = rf(ctx, runID, key, window, pageToken, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Metric)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, runID, key)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, request.MetricHistoryWindow, *request.MetricHistoryPageToken, int) error); ok {
+		r1 = rf(ctx, runID, key, window, pageToken, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -31,6 +31,20 @@ func (_m *MockNamespaceRepositoryProvider) Create(ctx context.Context, namespace
 	return r0
 }
 
+// CreateWithDefaultExperiment provides a mock function with given fields: ctx, namespace, experiment, artifactRoot
+func (_m *MockNamespaceRepositoryProvider) CreateWithDefaultExperiment(ctx context.Context, namespace *models.Namespace, experiment *models.Experiment, artifactRoot string) error {
+	ret := _m.Called(ctx, namespace, experiment, artifactRoot)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Namespace, *models.Experiment, string) error); ok {
+		r0 = rf(ctx, namespace, experiment, artifactRoot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, namespace
 func (_m *MockNamespaceRepositoryProvider) Delete(ctx context.Context, namespace *models.Namespace) error {
 	ret := _m.Called(ctx, namespace)
@@ -139,6 +153,22 @@ func (_m *MockNamespaceRepositoryProvider) GetDB() *gorm.DB {
 	return r0
 }
 
+// GetDBWithContext provides a mock function with given fields: ctx
+func (_m *MockNamespaceRepositoryProvider) GetDBWithContext(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
 // List provides a mock function with given fields: ctx
 func (_m *MockNamespaceRepositoryProvider) List(ctx context.Context) ([]models.Namespace, error) {
 	ret := _m.Called(ctx)
@@ -179,6 +209,20 @@ func (_m *MockNamespaceRepositoryProvider) Update(ctx context.Context, namespace
 	return r0
 }
 
+// UpdateRetentionPolicy provides a mock function with given fields: ctx, namespace
+func (_m *MockNamespaceRepositoryProvider) UpdateRetentionPolicy(ctx context.Context, namespace *models.Namespace) error {
+	ret := _m.Called(ctx, namespace)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Namespace) error); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockNamespaceRepositoryProvider creates a new instance of MockNamespaceRepositoryProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockNamespaceRepositoryProvider(t interface {
@@ -28,6 +28,72 @@ func (_m *MockParamRepositoryProvider) CreateBatch(ctx context.Context, batchSiz
 	return r0
 }
 
+// GetKeyCardinalityByNamespaceID provides a mock function with given fields: ctx, namespaceID, keys
+func (_m *MockParamRepositoryProvider) GetKeyCardinalityByNamespaceID(ctx context.Context, namespaceID uint, keys []string) (*KeyCardinality, error) {
+	ret := _m.Called(ctx, namespaceID, keys)
+
+	var r0 *KeyCardinality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) (*KeyCardinality, error)); ok {
+		return rf(ctx, namespaceID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) *KeyCardinality); ok {
+		r0 = rf(ctx, namespaceID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*KeyCardinality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, namespaceID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeyCardinalityByRunID provides a mock function with given fields: ctx, runID, keys
+func (_m *MockParamRepositoryProvider) GetKeyCardinalityByRunID(ctx context.Context, runID string, keys []string) (*KeyCardinality, error) {
+	ret := _m.Called(ctx, runID, keys)
+
+	var r0 *KeyCardinality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*KeyCardinality, error)); ok {
+		return rf(ctx, runID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *KeyCardinality); ok {
+		r0 = rf(ctx, runID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*KeyCardinality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, runID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateBatch provides a mock function with given fields: ctx, params
+func (_m *MockParamRepositoryProvider) ValidateBatch(ctx context.Context, params []models.Param) error {
+	ret := _m.Called(ctx, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Param) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockParamRepositoryProvider creates a new instance of MockParamRepositoryProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockParamRepositoryProvider(t interface {
@@ -10,6 +10,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // MockRunRepositoryProvider is an autogenerated mock type for the RunRepositoryProvider type
@@ -87,6 +88,58 @@ func (_m *MockRunRepositoryProvider) DeleteBatch(ctx context.Context, namespaceI
 	return r0
 }
 
+// GetActiveIDsByNamespaceIDAndStartTime provides a mock function with given fields: ctx, namespaceID, startTime
+func (_m *MockRunRepositoryProvider) GetActiveIDsByNamespaceIDAndStartTime(ctx context.Context, namespaceID uint, startTime int64) ([]string, error) {
+	ret := _m.Called(ctx, namespaceID, startTime)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) ([]string, error)); ok {
+		return rf(ctx, namespaceID, startTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) []string); ok {
+		r0 = rf(ctx, namespaceID, startTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int64) error); ok {
+		r1 = rf(ctx, namespaceID, startTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveIDsByNamespaceIDOrderedByMetric provides a mock function with given fields: ctx, namespaceID, metricKey, direction
+func (_m *MockRunRepositoryProvider) GetActiveIDsByNamespaceIDOrderedByMetric(ctx context.Context, namespaceID uint, metricKey string, direction models.MetricDirection) ([]string, error) {
+	ret := _m.Called(ctx, namespaceID, metricKey, direction)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, models.MetricDirection) ([]string, error)); ok {
+		return rf(ctx, namespaceID, metricKey, direction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, models.MetricDirection) []string); ok {
+		r0 = rf(ctx, namespaceID, metricKey, direction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, models.MetricDirection) error); ok {
+		r1 = rf(ctx, namespaceID, metricKey, direction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *MockRunRepositoryProvider) GetByID(ctx context.Context, id string) (*models.Run, error) {
 	ret := _m.Called(ctx, id)
@@ -139,6 +192,32 @@ func (_m *MockRunRepositoryProvider) GetByNamespaceIDAndRunID(ctx context.Contex
 	return r0, r1
 }
 
+// GetByNamespaceIDAndRunIDWithFields provides a mock function with given fields: ctx, namespaceID, runID, fields
+func (_m *MockRunRepositoryProvider) GetByNamespaceIDAndRunIDWithFields(ctx context.Context, namespaceID uint, runID string, fields commonModels.ResponseFields) (*models.Run, error) {
+	ret := _m.Called(ctx, namespaceID, runID, fields)
+
+	var r0 *models.Run
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, commonModels.ResponseFields) (*models.Run, error)); ok {
+		return rf(ctx, namespaceID, runID, fields)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, commonModels.ResponseFields) *models.Run); ok {
+		r0 = rf(ctx, namespaceID, runID, fields)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, commonModels.ResponseFields) error); ok {
+		r1 = rf(ctx, namespaceID, runID, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByNamespaceIDRunIDAndLifecycleStage provides a mock function with given fields: ctx, namespaceID, runID, lifecycleStage
 func (_m *MockRunRepositoryProvider) GetByNamespaceIDRunIDAndLifecycleStage(ctx context.Context, namespaceID uint, runID string, lifecycleStage models.LifecycleStage) (*models.Run, error) {
 	ret := _m.Called(ctx, namespaceID, runID, lifecycleStage)
@@ -165,6 +244,32 @@ func (_m *MockRunRepositoryProvider) GetByNamespaceIDRunIDAndLifecycleStage(ctx
 	return r0, r1
 }
 
+// GetByNamespaceIDRunIDsAndLifecycleStage provides a mock function with given fields: ctx, namespaceID, runIDs, lifecycleStage
+func (_m *MockRunRepositoryProvider) GetByNamespaceIDRunIDsAndLifecycleStage(ctx context.Context, namespaceID uint, runIDs []string, lifecycleStage models.LifecycleStage) ([]models.Run, error) {
+	ret := _m.Called(ctx, namespaceID, runIDs, lifecycleStage)
+
+	var r0 []models.Run
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, models.LifecycleStage) ([]models.Run, error)); ok {
+		return rf(ctx, namespaceID, runIDs, lifecycleStage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, models.LifecycleStage) []models.Run); ok {
+		r0 = rf(ctx, namespaceID, runIDs, lifecycleStage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, models.LifecycleStage) error); ok {
+		r1 = rf(ctx, namespaceID, runIDs, lifecycleStage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDB provides a mock function with given fields:
 func (_m *MockRunRepositoryProvider) GetDB() *gorm.DB {
 	ret := _m.Called()
@@ -181,6 +286,22 @@ func (_m *MockRunRepositoryProvider) GetDB() *gorm.DB {
 	return r0
 }
 
+// GetDBWithContext provides a mock function with given fields: ctx
+func (_m *MockRunRepositoryProvider) GetDBWithContext(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
 // Restore provides a mock function with given fields: ctx, run
 func (_m *MockRunRepositoryProvider) Restore(ctx context.Context, run *models.Run) error {
 	ret := _m.Called(ctx, run)
@@ -223,6 +344,20 @@ func (_m *MockRunRepositoryProvider) SetRunTagsBatch(ctx context.Context, run *m
 	return r0
 }
 
+// SetRunsTag provides a mock function with given fields: ctx, runs, key, value
+func (_m *MockRunRepositoryProvider) SetRunsTag(ctx context.Context, runs []models.Run, key string, value string) error {
+	ret := _m.Called(ctx, runs, key, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Run, string, string) error); ok {
+		r0 = rf(ctx, runs, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, run
 func (_m *MockRunRepositoryProvider) Update(ctx context.Context, run *models.Run) error {
 	ret := _m.Called(ctx, run)
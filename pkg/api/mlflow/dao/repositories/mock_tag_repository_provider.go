@@ -59,6 +59,20 @@ func (_m *MockTagRepositoryProvider) Delete(ctx context.Context, tag *models.Tag
 	return r0
 }
 
+// DeleteByRunIDsAndKey provides a mock function with given fields: ctx, runIDs, key
+func (_m *MockTagRepositoryProvider) DeleteByRunIDsAndKey(ctx context.Context, runIDs []string, key string) error {
+	ret := _m.Called(ctx, runIDs, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) error); ok {
+		r0 = rf(ctx, runIDs, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByRunIDAndKey provides a mock function with given fields: ctx, runID, key
 func (_m *MockTagRepositoryProvider) GetByRunIDAndKey(ctx context.Context, runID string, key string) (*models.Tag, error) {
 	ret := _m.Called(ctx, runID, key)
@@ -101,6 +115,74 @@ func (_m *MockTagRepositoryProvider) GetDB() *gorm.DB {
 	return r0
 }
 
+// GetDBWithContext provides a mock function with given fields: ctx
+func (_m *MockTagRepositoryProvider) GetDBWithContext(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
+// GetKeyCardinalityByNamespaceID provides a mock function with given fields: ctx, namespaceID, keys
+func (_m *MockTagRepositoryProvider) GetKeyCardinalityByNamespaceID(ctx context.Context, namespaceID uint, keys []string) (*KeyCardinality, error) {
+	ret := _m.Called(ctx, namespaceID, keys)
+
+	var r0 *KeyCardinality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) (*KeyCardinality, error)); ok {
+		return rf(ctx, namespaceID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) *KeyCardinality); ok {
+		r0 = rf(ctx, namespaceID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*KeyCardinality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, namespaceID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeyCardinalityByRunID provides a mock function with given fields: ctx, runID, keys
+func (_m *MockTagRepositoryProvider) GetKeyCardinalityByRunID(ctx context.Context, runID string, keys []string) (*KeyCardinality, error) {
+	ret := _m.Called(ctx, runID, keys)
+
+	var r0 *KeyCardinality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*KeyCardinality, error)); ok {
+		return rf(ctx, runID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *KeyCardinality); ok {
+		r0 = rf(ctx, runID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*KeyCardinality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, runID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockTagRepositoryProvider creates a new instance of MockTagRepositoryProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockTagRepositoryProvider(t interface {
@@ -6,8 +6,10 @@ import (
 
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
 )
 
@@ -16,8 +18,15 @@ type NamespaceRepositoryProvider interface {
 	repositories.BaseRepositoryProvider
 	// Create creates new models.Namespace entity.
 	Create(ctx context.Context, namespace *models.Namespace) error
+	// CreateWithDefaultExperiment creates new models.Namespace entity together with its default experiment
+	// and default metric context in scope of one transaction.
+	CreateWithDefaultExperiment(
+		ctx context.Context, namespace *models.Namespace, experiment *models.Experiment, artifactRoot string,
+	) error
 	// Update modifies the existing models.Namespace entity.
 	Update(ctx context.Context, namespace *models.Namespace) error
+	// UpdateRetentionPolicy modifies retention policy of the existing models.Namespace entity.
+	UpdateRetentionPolicy(ctx context.Context, namespace *models.Namespace) error
 	// Delete removes a namespace and it's associated experiments by its ID.
 	Delete(ctx context.Context, namespace *models.Namespace) error
 	// GetByCode returns namespace by its Code.
@@ -50,14 +59,77 @@ func (r NamespaceRepository) Create(ctx context.Context, namespace *models.Names
 	return nil
 }
 
+// CreateWithDefaultExperiment creates new models.Namespace entity together with its default experiment
+// and default metric context in scope of one transaction.
+// artifact location of the default experiment is built from artifactRoot, namespace code and experiment ID.
+func (r NamespaceRepository) CreateWithDefaultExperiment(
+	ctx context.Context, namespace *models.Namespace, experiment *models.Experiment, artifactRoot string,
+) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(namespace).Error; err != nil {
+			return eris.Wrap(err, "error creating namespace entity")
+		}
+
+		experiment.NamespaceID = namespace.ID
+		if err := tx.Create(experiment).Error; err != nil {
+			return eris.Wrap(err, "error creating default experiment entity")
+		}
+
+		path, err := common.BuildArtifactLocation(artifactRoot, namespace.Code, *experiment.ID)
+		if err != nil {
+			return eris.Wrapf(err, "error creating artifact_location for experiment '%s'", experiment.Name)
+		}
+		experiment.ArtifactLocation = path
+		if err := tx.Model(experiment).Update("artifact_location", experiment.ArtifactLocation).Error; err != nil {
+			return eris.Wrapf(err, "error updating artifact_location for experiment '%s'", experiment.Name)
+		}
+
+		namespace.DefaultExperimentID = experiment.ID
+		if err := tx.Model(namespace).Update("default_experiment_id", namespace.DefaultExperimentID).Error; err != nil {
+			return eris.Wrap(err, "error updating namespace default experiment id")
+		}
+
+		// metric contexts are shared between namespaces, so default one is created only once.
+		defaultContext := models.DefaultContext
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "json"}},
+			DoNothing: true,
+		}).Create(&defaultContext).Error; err != nil {
+			return eris.Wrap(err, "error creating default metric context")
+		}
+		return nil
+	}); err != nil {
+		return eris.Wrap(err, "error creating namespace with default experiment")
+	}
+	return nil
+}
+
 // Update modifies the existing models.Namespace entity.
 func (r NamespaceRepository) Update(ctx context.Context, namespace *models.Namespace) error {
-	if err := r.GetDB().WithContext(ctx).Updates(namespace).Error; err != nil {
+	// fields are selected explicitly, so zero values like an unset `public_read` flag are persisted too,
+	// while fields managed separately, like retention policy, are left untouched.
+	if err := r.GetDB().WithContext(ctx).Model(namespace).Select(
+		"code", "description", "default_experiment_id", "public_read", "updated_at",
+	).Updates(namespace).Error; err != nil {
 		return eris.Wrap(err, "error updating namespace entity")
 	}
 	return nil
 }
 
+// UpdateRetentionPolicy modifies retention policy of the existing models.Namespace entity.
+func (r NamespaceRepository) UpdateRetentionPolicy(ctx context.Context, namespace *models.Namespace) error {
+	policy := namespace.RetentionPolicy
+	if err := r.GetDB().WithContext(ctx).Model(namespace).Updates(map[string]any{
+		"retention_max_age_days":     policy.MaxAgeDays,
+		"retention_keep_best":        policy.KeepBest,
+		"retention_metric_key":       policy.MetricKey,
+		"retention_metric_direction": policy.MetricDirection,
+	}).Error; err != nil {
+		return eris.Wrap(err, "error updating namespace retention policy")
+	}
+	return nil
+}
+
 // Delete removes a namespace and it's associated experiments by its ID.
 func (r NamespaceRepository) Delete(ctx context.Context, namespace *models.Namespace) error {
 	if err := r.GetDB().WithContext(ctx).Delete(namespace).Error; err != nil {
@@ -95,14 +167,17 @@ func (r NamespaceRepository) GetByID(ctx context.Context, id uint) (*models.Name
 // GetByRoles returns namespaces OIDC roles.
 func (r NamespaceRepository) GetByRoles(ctx context.Context, roles []string) ([]models.Namespace, error) {
 	var namespaces []models.Namespace
+	// namespace could be shared by several roles of the user, so it has to be returned only once.
 	if err := r.GetDB().WithContext(
 		ctx,
+	).Distinct(
+		"namespaces.*",
 	).Order(
 		"code",
 	).Joins(
 		"INNER JOIN role_namespaces ON role_namespaces.namespace_id = namespaces.id",
 	).Joins(
-		"INNER JOIN roles ON roles.id = role_namespaces.role_id AND roles.role IN (?)",
+		"INNER JOIN roles ON roles.id = role_namespaces.role_id AND roles.name IN (?)",
 		roles,
 	).Find(&namespaces).Error; err != nil {
 		return nil, eris.Wrap(err, "error listing namespaces")
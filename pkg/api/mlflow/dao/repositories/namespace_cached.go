@@ -75,6 +75,23 @@ func (r NamespaceCachedRepository) Create(ctx context.Context, namespace *models
 	return nil
 }
 
+// CreateWithDefaultExperiment creates new models.Namespace entity together with its default experiment
+// and default metric context in scope of one transaction.
+func (r NamespaceCachedRepository) CreateWithDefaultExperiment(
+	ctx context.Context, namespace *models.Namespace, experiment *models.Experiment, artifactRoot string,
+) error {
+	if err := r.namespaceRepository.CreateWithDefaultExperiment(ctx, namespace, experiment, artifactRoot); err != nil {
+		return eris.Wrap(err, "error creating cached namespace entity")
+	}
+
+	// trigger database event to notify current instance and
+	// other instances to create record in theirs local cache.
+	if err := r.sendEvent(events.NamespaceEventActionCreated, namespace); err != nil {
+		return eris.Wrap(err, "error sending database event")
+	}
+	return nil
+}
+
 // Update updates existing models.Namespace entity.
 func (r NamespaceCachedRepository) Update(ctx context.Context, namespace *models.Namespace) error {
 	if err := r.namespaceRepository.Update(ctx, namespace); err != nil {
@@ -89,6 +106,20 @@ func (r NamespaceCachedRepository) Update(ctx context.Context, namespace *models
 	return nil
 }
 
+// UpdateRetentionPolicy modifies retention policy of the existing models.Namespace entity.
+func (r NamespaceCachedRepository) UpdateRetentionPolicy(ctx context.Context, namespace *models.Namespace) error {
+	if err := r.namespaceRepository.UpdateRetentionPolicy(ctx, namespace); err != nil {
+		return eris.Wrap(err, "error updating retention policy of cached namespace entity")
+	}
+
+	// trigger database event to notify current instance and
+	// other instances to update record in theirs local cache.
+	if err := r.sendEvent(events.NamespaceEventActionUpdated, namespace); err != nil {
+		return eris.Wrap(err, "error sending database event")
+	}
+	return nil
+}
+
 // GetByCode returns namespace by its Code.
 func (r NamespaceCachedRepository) GetByCode(
 	ctx context.Context, code string,
@@ -169,6 +200,11 @@ func (r NamespaceCachedRepository) GetDB() *gorm.DB {
 	return r.namespaceRepository.GetDB()
 }
 
+// GetDBWithContext returns DB instance bound to the context.
+func (r NamespaceCachedRepository) GetDBWithContext(ctx context.Context) *gorm.DB {
+	return r.namespaceRepository.GetDBWithContext(ctx)
+}
+
 // sendEvent sends database event.
 func (r NamespaceCachedRepository) sendEvent(action events.NamespaceEventAction, namespace *models.Namespace) error {
 	// skip event processing if current database is not a `postgres`.
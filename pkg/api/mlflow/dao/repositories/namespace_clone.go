@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// ClonedRun represents result of the run cloning.
+type ClonedRun struct {
+	ID                string
+	ArtifactURI       string
+	SourceArtifactURI string
+	// Created is false, when the run has been already cloned before.
+	Created bool
+}
+
+// NamespaceCloneRepositoryProvider provides an interface to clone entities of one namespace into another one.
+// Cloned entities get deterministic identifiers or are matched by name, so cloning is idempotent and
+// interrupted cloning could be resumed just by repeating it.
+type NamespaceCloneRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// ListExperiments returns all the experiments of the namespace, including the deleted ones, ordered by ID.
+	ListExperiments(ctx context.Context, namespaceID uint) ([]models.Experiment, error)
+	// CloneExperiment clones the experiment together with its tags into the namespace,
+	// unless the namespace already has an experiment with the same name, which is returned instead.
+	// Artifact location of the cloned experiment is built from artifactRoot, namespace code and experiment ID.
+	CloneExperiment(
+		ctx context.Context, experiment *models.Experiment, namespace *models.Namespace, artifactRoot string,
+	) (*models.Experiment, bool, error)
+	// ListRunIDs returns IDs of all the runs of the experiment, including the deleted ones, ordered by ID.
+	ListRunIDs(ctx context.Context, experimentID int32) ([]string, error)
+	// CloneRun clones the run together with its params, tags and metrics into the experiment.
+	CloneRun(ctx context.Context, runID string, experiment *models.Experiment) (*ClonedRun, error)
+	// CloneApps clones not archived apps of the source namespace together with their dashboards
+	// into the target namespace and returns the number of created apps and dashboards.
+	CloneApps(ctx context.Context, sourceNamespaceID, targetNamespaceID uint) (int64, int64, error)
+}
+
+// NamespaceCloneRepository repository to clone entities of one namespace into another one.
+type NamespaceCloneRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewNamespaceCloneRepository creates repository to clone entities of one namespace into another one.
+func NewNamespaceCloneRepository(db *gorm.DB) *NamespaceCloneRepository {
+	return &NamespaceCloneRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// ListExperiments returns all the experiments of the namespace, including the deleted ones, ordered by ID.
+func (r NamespaceCloneRepository) ListExperiments(ctx context.Context, namespaceID uint) ([]models.Experiment, error) {
+	var experiments []models.Experiment
+	if err := r.GetDB().WithContext(ctx).Where(
+		"namespace_id = ?", namespaceID,
+	).Order(
+		"experiment_id",
+	).Find(&experiments).Error; err != nil {
+		return nil, eris.Wrapf(err, "error listing experiments of namespace with id: %d", namespaceID)
+	}
+	return experiments, nil
+}
+
+// CloneExperiment clones the experiment together with its tags into the namespace,
+// unless the namespace already has an experiment with the same name, which is returned instead.
+// Artifact location of the cloned experiment is built from artifactRoot, namespace code and experiment ID.
+func (r NamespaceCloneRepository) CloneExperiment(
+	ctx context.Context, experiment *models.Experiment, namespace *models.Namespace, artifactRoot string,
+) (*models.Experiment, bool, error) {
+	var existing []models.Experiment
+	if err := r.GetDB().WithContext(ctx).Where(
+		"namespace_id = ? AND name = ?", namespace.ID, experiment.Name,
+	).Limit(1).Find(&existing).Error; err != nil {
+		return nil, false, eris.Wrapf(err, "error getting experiment by name: %s", experiment.Name)
+	}
+	if len(existing) > 0 {
+		return &existing[0], false, nil
+	}
+
+	cloned := models.Experiment{
+		Name:           experiment.Name,
+		LifecycleStage: experiment.LifecycleStage,
+		CreationTime:   experiment.CreationTime,
+		LastUpdateTime: experiment.LastUpdateTime,
+		NamespaceID:    namespace.ID,
+	}
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&cloned).Error; err != nil {
+			return eris.Wrap(err, "error creating experiment entity")
+		}
+
+		path, err := common.BuildArtifactLocation(artifactRoot, namespace.Code, *cloned.ID)
+		if err != nil {
+			return eris.Wrapf(err, "error creating artifact_location for experiment '%s'", cloned.Name)
+		}
+		cloned.ArtifactLocation = path
+		if err := tx.Model(&cloned).Update("artifact_location", cloned.ArtifactLocation).Error; err != nil {
+			return eris.Wrapf(err, "error updating artifact_location for experiment '%s'", cloned.Name)
+		}
+
+		if err := tx.Exec(
+			`INSERT INTO experiment_tags (key, value, experiment_id)
+			SELECT key, value, ? FROM experiment_tags WHERE experiment_id = ?`,
+			*cloned.ID, *experiment.ID,
+		).Error; err != nil {
+			return eris.Wrap(err, "error cloning experiment tags")
+		}
+		return nil
+	}); err != nil {
+		return nil, false, eris.Wrapf(err, "error cloning experiment with id: %d", *experiment.ID)
+	}
+	return &cloned, true, nil
+}
+
+// ListRunIDs returns IDs of all the runs of the experiment, including the deleted ones, ordered by ID.
+func (r NamespaceCloneRepository) ListRunIDs(ctx context.Context, experimentID int32) ([]string, error) {
+	var runIDs []string
+	if err := r.GetDB().WithContext(ctx).Model(
+		&models.Run{},
+	).Where(
+		"experiment_id = ?", experimentID,
+	).Order(
+		"run_uuid",
+	).Pluck("run_uuid", &runIDs).Error; err != nil {
+		return nil, eris.Wrapf(err, "error listing runs of experiment with id: %d", experimentID)
+	}
+	return runIDs, nil
+}
+
+// CloneRun clones the run together with its params, tags and metrics into the experiment.
+// ID of the cloned run is derived from IDs of the source run and the experiment, so the run
+// is cloned into the same experiment only once. Run and all its data are cloned in one transaction.
+func (r NamespaceCloneRepository) CloneRun(
+	ctx context.Context, runID string, experiment *models.Experiment,
+) (*ClonedRun, error) {
+	var source models.Run
+	if err := r.GetDB().WithContext(ctx).Where("run_uuid = ?", runID).First(&source).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting run by id: %s", runID)
+	}
+
+	clonedRunID := strings.ReplaceAll(
+		uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("%s/%d", runID, *experiment.ID))).String(), "-", "",
+	)
+	artifactURI, err := url.JoinPath(experiment.ArtifactLocation, clonedRunID, "artifacts")
+	if err != nil {
+		return nil, eris.Wrapf(err, "error creating artifact_uri for run: %s", clonedRunID)
+	}
+	clonedRun := ClonedRun{
+		ID:                clonedRunID,
+		ArtifactURI:       artifactURI,
+		SourceArtifactURI: source.ArtifactURI,
+	}
+
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(
+			`INSERT INTO runs (
+				run_uuid, name, source_type, source_name, entry_point_name, user_id, status, start_time,
+				end_time, source_version, lifecycle_stage, artifact_uri, experiment_id, deleted_time, row_num
+			)
+			SELECT ?, name, source_type, source_name, entry_point_name, user_id, status, start_time,
+				end_time, source_version, lifecycle_stage, ?, ?, deleted_time,
+				(SELECT COALESCE(MAX(row_num), -1) FROM runs) + 1
+			FROM runs WHERE run_uuid = ?
+			ON CONFLICT DO NOTHING`,
+			clonedRunID, artifactURI, *experiment.ID, runID,
+		)
+		if result.Error != nil {
+			return eris.Wrap(result.Error, "error creating run entity")
+		}
+		// the run has been already cloned, together with all its data.
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		clonedRun.Created = true
+
+		for _, query := range []struct {
+			entity string
+			sql    string
+		}{
+			{
+				entity: "params",
+				sql: `INSERT INTO params (key, value, run_uuid)
+					SELECT key, value, ? FROM params WHERE run_uuid = ?`,
+			},
+			{
+				entity: "tags",
+				sql: `INSERT INTO tags (key, value, run_uuid)
+					SELECT key, value, ? FROM tags WHERE run_uuid = ?`,
+			},
+			{
+				entity: "metrics",
+				sql: `INSERT INTO metrics (key, value, timestamp, run_uuid, step, is_nan, iter, context_id)
+					SELECT key, value, timestamp, ?, step, is_nan, iter, context_id FROM metrics WHERE run_uuid = ?`,
+			},
+			{
+				entity: "latest metrics",
+				sql: `INSERT INTO latest_metrics (key, value, timestamp, step, is_nan, run_uuid, last_iter, context_id)
+					SELECT key, value, timestamp, step, is_nan, ?, last_iter, context_id
+					FROM latest_metrics WHERE run_uuid = ?`,
+			},
+		} {
+			if err := tx.Exec(query.sql, clonedRunID, runID).Error; err != nil {
+				return eris.Wrapf(err, "error cloning %s", query.entity)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, eris.Wrapf(err, "error cloning run with id: %s", runID)
+	}
+	return &clonedRun, nil
+}
+
+// CloneApps clones not archived apps of the source namespace together with their dashboards
+// into the target namespace and returns the number of created apps and dashboards. IDs of the cloned
+// entities are derived from IDs of the source ones and the target namespace, so each of them is cloned only once.
+func (r NamespaceCloneRepository) CloneApps(
+	ctx context.Context, sourceNamespaceID, targetNamespaceID uint,
+) (int64, int64, error) {
+	var appIDs []uuid.UUID
+	if err := r.GetDB().WithContext(ctx).Table(
+		"apps",
+	).Where(
+		"namespace_id = ? AND NOT is_archived", sourceNamespaceID,
+	).Order(
+		"id",
+	).Pluck("id", &appIDs).Error; err != nil {
+		return 0, 0, eris.Wrapf(err, "error listing apps of namespace with id: %d", sourceNamespaceID)
+	}
+
+	var apps, dashboards int64
+	for _, appID := range appIDs {
+		if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			clonedAppID := newClonedID(appID, targetNamespaceID)
+			result := tx.Exec(
+				`INSERT INTO apps (id, created_at, updated_at, is_archived, type, state, namespace_id)
+				SELECT ?, created_at, updated_at, is_archived, type, state, ? FROM apps WHERE id = ?
+				ON CONFLICT DO NOTHING`,
+				clonedAppID, targetNamespaceID, appID,
+			)
+			if result.Error != nil {
+				return eris.Wrapf(result.Error, "error cloning app with id: %s", appID)
+			}
+			apps += result.RowsAffected
+
+			var dashboardIDs []uuid.UUID
+			if err := tx.Table(
+				"dashboards",
+			).Where(
+				"app_id = ? AND NOT is_archived", appID,
+			).Order(
+				"id",
+			).Pluck("id", &dashboardIDs).Error; err != nil {
+				return eris.Wrapf(err, "error listing dashboards of app with id: %s", appID)
+			}
+			for _, dashboardID := range dashboardIDs {
+				result := tx.Exec(
+					`INSERT INTO dashboards (id, created_at, updated_at, is_archived, name, description, app_id)
+					SELECT ?, created_at, updated_at, is_archived, name, description, ? FROM dashboards WHERE id = ?
+					ON CONFLICT DO NOTHING`,
+					newClonedID(dashboardID, targetNamespaceID), clonedAppID, dashboardID,
+				)
+				if result.Error != nil {
+					return eris.Wrapf(result.Error, "error cloning dashboard with id: %s", dashboardID)
+				}
+				dashboards += result.RowsAffected
+			}
+			return nil
+		}); err != nil {
+			return 0, 0, err
+		}
+	}
+	return apps, dashboards, nil
+}
+
+// newClonedID derives ID of the entity cloned into the namespace with namespaceID from ID of the source entity.
+func newClonedID(id uuid.UUID, namespaceID uint) uuid.UUID {
+	return uuid.NewSHA1(id, []byte(fmt.Sprintf("namespace/%d", namespaceID)))
+}
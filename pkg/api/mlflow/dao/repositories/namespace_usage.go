@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// NamespaceUsage represents usage of the namespace.
+type NamespaceUsage struct {
+	NamespaceID     uint
+	Code            string
+	RunCount        int64
+	ExperimentCount int64
+	MetricCount     int64
+	// LastActivityTime is the latest time, in milliseconds, when any run or experiment
+	// of the namespace has been updated or any metric has been logged. Zero means no activity.
+	LastActivityTime int64
+	// ArtifactLocations are artifact locations of all the experiments of the namespace.
+	ArtifactLocations []string
+}
+
+// NamespaceLifecycleStageCounts represents numbers of active and deleted experiments and runs of the namespace.
+type NamespaceLifecycleStageCounts struct {
+	ActiveExperimentCount  int64
+	DeletedExperimentCount int64
+	ActiveRunCount         int64
+	DeletedRunCount        int64
+}
+
+// NamespaceUsageRepositoryProvider provides an interface to work with namespace usage.
+type NamespaceUsageRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// List returns usage of namespaces ordered by ID, skipping offset namespaces and returning at most limit of them.
+	List(ctx context.Context, offset, limit int) ([]NamespaceUsage, error)
+	// GetLifecycleStageCounts returns numbers of active and deleted experiments and runs of the namespace.
+	GetLifecycleStageCounts(ctx context.Context, namespaceID uint) (*NamespaceLifecycleStageCounts, error)
+}
+
+// NamespaceUsageRepository repository to work with namespace usage.
+type NamespaceUsageRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewNamespaceUsageRepository creates repository to work with namespace usage.
+func NewNamespaceUsageRepository(db *gorm.DB) *NamespaceUsageRepository {
+	return &NamespaceUsageRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// namespaceAggregate represents aggregated values of one namespace.
+type namespaceAggregate struct {
+	NamespaceID  uint
+	Count        int64
+	LastActivity *int64
+}
+
+// List returns usage of namespaces ordered by ID, skipping offset namespaces and returning at most limit of them.
+// Numbers are calculated by aggregate queries grouped by namespace, one query per number for the whole page.
+func (r NamespaceUsageRepository) List(ctx context.Context, offset, limit int) ([]NamespaceUsage, error) {
+	var namespaces []models.Namespace
+	if err := r.GetDB().WithContext(ctx).Order("id").Offset(offset).Limit(limit).Find(&namespaces).Error; err != nil {
+		return nil, eris.Wrap(err, "error listing namespaces")
+	}
+	if len(namespaces) == 0 {
+		return []NamespaceUsage{}, nil
+	}
+
+	usage := make([]NamespaceUsage, len(namespaces))
+	usageByID := make(map[uint]*NamespaceUsage, len(namespaces))
+	namespaceIDs := make([]uint, len(namespaces))
+	for i, namespace := range namespaces {
+		usage[i] = NamespaceUsage{
+			NamespaceID: namespace.ID,
+			Code:        namespace.Code,
+		}
+		usageByID[namespace.ID] = &usage[i]
+		namespaceIDs[i] = namespace.ID
+	}
+
+	for _, item := range []struct {
+		name  string
+		query *gorm.DB
+		count func(usage *NamespaceUsage) *int64
+	}{
+		{
+			name: "experiments",
+			query: r.GetDB().Table("experiments").Select(
+				"namespace_id, COUNT(*) AS count, MAX(last_update_time) AS last_activity",
+			),
+			count: func(usage *NamespaceUsage) *int64 { return &usage.ExperimentCount },
+		},
+		{
+			name: "runs",
+			query: r.GetDB().Table("runs").Select(
+				"experiments.namespace_id, COUNT(*) AS count, MAX(COALESCE(runs.end_time, runs.start_time)) AS last_activity",
+			).Joins(
+				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+			),
+			count: func(usage *NamespaceUsage) *int64 { return &usage.RunCount },
+		},
+		{
+			name: "metrics",
+			query: r.GetDB().Table("metrics").Select(
+				"experiments.namespace_id, COUNT(*) AS count, NULL AS last_activity",
+			).Joins(
+				"INNER JOIN runs ON runs.run_uuid = metrics.run_uuid",
+			).Joins(
+				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+			),
+			count: func(usage *NamespaceUsage) *int64 { return &usage.MetricCount },
+		},
+		{
+			// latest metrics are much smaller than metrics, so the time of the last logged metric is taken from them.
+			name: "latest metrics",
+			query: r.GetDB().Table("latest_metrics").Select(
+				"experiments.namespace_id, 0 AS count, MAX(latest_metrics.timestamp) AS last_activity",
+			).Joins(
+				"INNER JOIN runs ON runs.run_uuid = latest_metrics.run_uuid",
+			).Joins(
+				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+			),
+			count: nil,
+		},
+	} {
+		var aggregates []namespaceAggregate
+		if err := item.query.WithContext(ctx).Where(
+			"experiments.namespace_id IN ?", namespaceIDs,
+		).Group(
+			"experiments.namespace_id",
+		).Scan(&aggregates).Error; err != nil {
+			return nil, eris.Wrapf(err, "error aggregating %s of namespaces", item.name)
+		}
+		for _, aggregate := range aggregates {
+			namespaceUsage := usageByID[aggregate.NamespaceID]
+			if item.count != nil {
+				*item.count(namespaceUsage) = aggregate.Count
+			}
+			if aggregate.LastActivity != nil && *aggregate.LastActivity > namespaceUsage.LastActivityTime {
+				namespaceUsage.LastActivityTime = *aggregate.LastActivity
+			}
+		}
+	}
+
+	var locations []struct {
+		NamespaceID      uint
+		ArtifactLocation string
+	}
+	if err := r.GetDB().WithContext(ctx).Table("experiments").Select(
+		"namespace_id, artifact_location",
+	).Where(
+		"namespace_id IN ? AND artifact_location != ''", namespaceIDs,
+	).Order(
+		"experiment_id",
+	).Scan(&locations).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting artifact locations of namespaces")
+	}
+	for _, location := range locations {
+		namespaceUsage := usageByID[location.NamespaceID]
+		namespaceUsage.ArtifactLocations = append(namespaceUsage.ArtifactLocations, location.ArtifactLocation)
+	}
+	return usage, nil
+}
+
+// GetLifecycleStageCounts returns numbers of active and deleted experiments and runs of the namespace.
+// Numbers are calculated by aggregate queries grouped by lifecycle stage, one query for experiments and one for runs.
+func (r NamespaceUsageRepository) GetLifecycleStageCounts(
+	ctx context.Context, namespaceID uint,
+) (*NamespaceLifecycleStageCounts, error) {
+	counts := NamespaceLifecycleStageCounts{}
+	for _, item := range []struct {
+		name    string
+		table   string
+		query   *gorm.DB
+		active  *int64
+		deleted *int64
+	}{
+		{
+			name:  "experiments",
+			table: "experiments",
+			query: r.GetDB().Table("experiments").Select(
+				"experiments.lifecycle_stage, COUNT(*) AS count",
+			),
+			active:  &counts.ActiveExperimentCount,
+			deleted: &counts.DeletedExperimentCount,
+		},
+		{
+			name:  "runs",
+			table: "runs",
+			query: r.GetDB().Table("runs").Select(
+				"runs.lifecycle_stage, COUNT(*) AS count",
+			).Joins(
+				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+			),
+			active:  &counts.ActiveRunCount,
+			deleted: &counts.DeletedRunCount,
+		},
+	} {
+		var aggregates []struct {
+			LifecycleStage models.LifecycleStage
+			Count          int64
+		}
+		if err := item.query.WithContext(ctx).Where(
+			"experiments.namespace_id = ?", namespaceID,
+		).Group(
+			fmt.Sprintf("%s.lifecycle_stage", item.table),
+		).Scan(&aggregates).Error; err != nil {
+			return nil, eris.Wrapf(err, "error counting %s of namespace by lifecycle stage", item.name)
+		}
+		for _, aggregate := range aggregates {
+			switch aggregate.LifecycleStage {
+			case models.LifecycleStageActive:
+				*item.active = aggregate.Count
+			case models.LifecycleStageDeleted:
+				*item.deleted = aggregate.Count
+			}
+		}
+	}
+	return &counts, nil
+}
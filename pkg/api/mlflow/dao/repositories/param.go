@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/rotisserie/eris"
@@ -39,6 +40,12 @@ func (pc paramConflict) String() string {
 type ParamRepositoryProvider interface {
 	// CreateBatch creates []models.Param entities in batch.
 	CreateBatch(ctx context.Context, batchSize int, params []models.Param) error
+	// ValidateBatch checks that []models.Param entities could be created without conflicts, without writing them.
+	ValidateBatch(ctx context.Context, params []models.Param) error
+	// GetKeyCardinalityByRunID returns cardinality of param keys of the run.
+	GetKeyCardinalityByRunID(ctx context.Context, runID string, keys []string) (*KeyCardinality, error)
+	// GetKeyCardinalityByNamespaceID returns cardinality of param keys of the namespace.
+	GetKeyCardinalityByNamespaceID(ctx context.Context, namespaceID uint, keys []string) (*KeyCardinality, error)
 }
 
 // ParamRepository repository to work with models.Param entity.
@@ -53,9 +60,10 @@ func NewParamRepository(db *gorm.DB) *ParamRepository {
 	}
 }
 
-// CreateBatch creates []models.Param entities in batch.
+// CreateBatch creates []models.Param entities in batch. Conflicting duplicates are ignored,
+// so the transaction is safely retried after transient failures.
 func (r ParamRepository) CreateBatch(ctx context.Context, batchSize int, params []models.Param) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+	if err := repositories.TransactionWithRetry(ctx, r.GetDB(), func(tx *gorm.DB) error {
 		if err := tx.WithContext(ctx).Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "run_uuid"}, {Name: "key"}},
 			DoNothing: true,
@@ -81,6 +89,63 @@ func (r ParamRepository) CreateBatch(ctx context.Context, batchSize int, params
 	return nil
 }
 
+// ValidateBatch checks that []models.Param entities could be created without conflicts, without writing them.
+// Params are checked against already existing params inside read-only transaction and against each other.
+func (r ParamRepository) ValidateBatch(ctx context.Context, params []models.Param) error {
+	if len(params) == 0 {
+		return nil
+	}
+	var conflictingParams []paramConflict
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		conflicts, err := findConflictingParams(tx, params)
+		if err != nil {
+			return eris.Wrap(err, "error checking for conflicting params")
+		}
+		conflictingParams = conflicts
+		return nil
+	}, &sql.TxOptions{ReadOnly: true}); err != nil {
+		return err
+	}
+	conflictingParams = append(conflictingParams, findConflictingParamsInBatch(params, conflictingParams)...)
+	if len(conflictingParams) > 0 {
+		return ParamConflictError{
+			Message: fmt.Sprintf("conflicting params found: %v", conflictingParams),
+		}
+	}
+	return nil
+}
+
+// findConflictingParamsInBatch checks if the same key is supplied more than once with different values.
+// The first supplied value is the one which would be stored, so any other value is a conflict. Keys which
+// already conflict with the stored values are skipped, as these conflicts have been reported already.
+func findConflictingParamsInBatch(params []models.Param, storedConflicts []paramConflict) []paramConflict {
+	reported := make(map[[2]string]bool, len(storedConflicts))
+	for _, conflict := range storedConflicts {
+		reported[[2]string{conflict.RunID, conflict.Key}] = true
+	}
+	var conflicts []paramConflict
+	values := make(map[[2]string]string, len(params))
+	for _, param := range params {
+		if reported[[2]string{param.RunID, param.Key}] {
+			continue
+		}
+		value, ok := values[[2]string{param.RunID, param.Key}]
+		if !ok {
+			values[[2]string{param.RunID, param.Key}] = param.Value
+			continue
+		}
+		if value != param.Value {
+			conflicts = append(conflicts, paramConflict{
+				RunID:    param.RunID,
+				Key:      param.Key,
+				OldValue: value,
+				NewValue: param.Value,
+			})
+		}
+	}
+	return conflicts
+}
+
 // findConflictingParams checks if there are conflicting values for the input params. If a key does not
 // yet exist in the db, or if the same key and value already exist for the run, it is not a conflict.
 // If the key already exists for the run but with a different value, it is a conflict. Conflicts are returned.
@@ -98,3 +163,17 @@ func findConflictingParams(tx *gorm.DB, params []models.Param) ([]paramConflict,
 	}
 	return conflicts, nil
 }
+
+// GetKeyCardinalityByRunID returns cardinality of param keys of the run.
+func (r ParamRepository) GetKeyCardinalityByRunID(
+	ctx context.Context, runID string, keys []string,
+) (*KeyCardinality, error) {
+	return getKeyCardinalityByRunID(ctx, r.GetDBWithContext(ctx), "params", runID, keys)
+}
+
+// GetKeyCardinalityByNamespaceID returns cardinality of param keys of the namespace.
+func (r ParamRepository) GetKeyCardinalityByNamespaceID(
+	ctx context.Context, namespaceID uint, keys []string,
+) (*KeyCardinality, error) {
+	return getKeyCardinalityByNamespaceID(ctx, r.GetDBWithContext(ctx), "params", namespaceID, keys)
+}
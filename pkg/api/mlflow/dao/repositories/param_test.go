@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+func TestParamRepository_CreateBatch_RetryOnSerializationFailure(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	//nolint:errcheck
+	defer mockDb.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDb,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.Nil(t, err)
+
+	// the first transaction fails because of the concurrent transaction, while the second one succeeds.
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "params"`).WillReturnError(&pgconn.PgError{Code: "40001"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "params"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`WITH new`).WillReturnRows(sqlmock.NewRows([]string{"run_uuid", "key", "old_value", "new_value"}))
+	mock.ExpectCommit()
+
+	assert.Nil(t, NewParamRepository(db).CreateBatch(context.Background(), 100, []models.Param{
+		{RunID: "run", Key: "key", Value: "value"},
+	}))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/rotisserie/eris"
@@ -11,10 +12,21 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
-	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
+// RunVersionConflictError is returned when models.Run entity has been changed by another update
+// since it was read.
+type RunVersionConflictError struct {
+	Message string
+}
+
+// Error returns the RunVersionConflictError message.
+func (e RunVersionConflictError) Error() string {
+	return e.Message
+}
+
 // RunRepositoryProvider provides an interface to work with models.Run entity.
 type RunRepositoryProvider interface {
 	repositories.BaseRepositoryProvider
@@ -24,10 +36,18 @@ type RunRepositoryProvider interface {
 	GetByNamespaceIDRunIDAndLifecycleStage(
 		ctx context.Context, namespaceID uint, runID string, lifecycleStage models.LifecycleStage,
 	) (*models.Run, error)
+	// GetByNamespaceIDRunIDsAndLifecycleStage returns models.Run entities by Namespace ID, their IDs and Lifecycle Stage.
+	GetByNamespaceIDRunIDsAndLifecycleStage(
+		ctx context.Context, namespaceID uint, runIDs []string, lifecycleStage models.LifecycleStage,
+	) ([]models.Run, error)
 	// GetByNamespaceIDAndRunID returns models.Run entity by Namespace ID and its ID.
 	GetByNamespaceIDAndRunID(
 		ctx context.Context, namespaceID uint, runID string,
 	) (*models.Run, error)
+	// GetByNamespaceIDAndRunIDWithFields returns models.Run entity, loading only the requested sections.
+	GetByNamespaceIDAndRunIDWithFields(
+		ctx context.Context, namespaceID uint, runID string, fields commonModels.ResponseFields,
+	) (*models.Run, error)
 	// Create creates new models.Run entity.
 	Create(ctx context.Context, run *models.Run) error
 	// Update updates existing models.Experiment entity.
@@ -46,8 +66,17 @@ type RunRepositoryProvider interface {
 	RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error
 	// SetRunTagsBatch sets Run tags in batch.
 	SetRunTagsBatch(ctx context.Context, run *models.Run, batchSize int, tags []models.Tag) error
-	// UpdateWithTransaction updates existing models.Run entity in scope of transaction.
+	// SetRunsTag sets the same tag of all the runs in scope of one transaction.
+	SetRunsTag(ctx context.Context, runs []models.Run, key, value string) error
+	// UpdateWithTransaction updates existing models.Run entity in scope of transaction,
+	// returning RunVersionConflictError if the run has been changed since it was read.
 	UpdateWithTransaction(ctx context.Context, tx *gorm.DB, run *models.Run) error
+	// GetActiveIDsByNamespaceIDAndStartTime returns IDs of active terminated runs started before provided time.
+	GetActiveIDsByNamespaceIDAndStartTime(ctx context.Context, namespaceID uint, startTime int64) ([]string, error)
+	// GetActiveIDsByNamespaceIDOrderedByMetric returns IDs of active terminated runs ordered by metric value.
+	GetActiveIDsByNamespaceIDOrderedByMetric(
+		ctx context.Context, namespaceID uint, metricKey string, direction models.MetricDirection,
+	) ([]string, error)
 }
 
 // RunRepository repository to work with models.Run entity.
@@ -65,9 +94,7 @@ func NewRunRepository(db *gorm.DB) *RunRepository {
 // GetByID returns models.Run entity by its ID.
 func (r RunRepository) GetByID(ctx context.Context, id string) (*models.Run, error) {
 	run := models.Run{ID: id}
-	if err := r.GetDB().WithContext(
-		ctx,
-	).Preload(
+	if err := r.GetDBWithContext(ctx).Preload(
 		"LatestMetrics",
 	).Preload(
 		"Params",
@@ -84,9 +111,7 @@ func (r RunRepository) GetByNamespaceIDRunIDAndLifecycleStage(
 	ctx context.Context, namespaceID uint, runID string, lifecycleStage models.LifecycleStage,
 ) (*models.Run, error) {
 	run := models.Run{ID: runID}
-	if err := r.GetDB().WithContext(
-		ctx,
-	).Preload(
+	if err := r.GetDBWithContext(ctx).Preload(
 		"LatestMetrics",
 	).Preload(
 		"Params",
@@ -106,20 +131,52 @@ func (r RunRepository) GetByNamespaceIDRunIDAndLifecycleStage(
 	return &run, nil
 }
 
+// GetByNamespaceIDRunIDsAndLifecycleStage returns models.Run entities by Namespace ID, their IDs and Lifecycle Stage.
+// Related entities are not loaded. Runs, which are not found, are silently skipped.
+func (r RunRepository) GetByNamespaceIDRunIDsAndLifecycleStage(
+	ctx context.Context, namespaceID uint, runIDs []string, lifecycleStage models.LifecycleStage,
+) ([]models.Run, error) {
+	var runs []models.Run
+	if err := r.GetDBWithContext(ctx).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Where(
+		"runs.run_uuid IN ?", runIDs,
+	).Where(
+		"runs.lifecycle_stage = ?", lifecycleStage,
+	).Order(
+		"runs.run_uuid",
+	).Find(&runs).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting 'run' entities by ids: %s", runIDs)
+	}
+	return runs, nil
+}
+
 // GetByNamespaceIDAndRunID returns models.Run entity by Namespace ID and its ID.
 func (r RunRepository) GetByNamespaceIDAndRunID(
 	ctx context.Context, namespaceID uint, runID string,
 ) (*models.Run, error) {
+	return r.GetByNamespaceIDAndRunIDWithFields(ctx, namespaceID, runID, nil)
+}
+
+// GetByNamespaceIDAndRunIDWithFields returns models.Run entity by Namespace ID and Run ID,
+// skipping the related entities of the sections which haven't been requested.
+func (r RunRepository) GetByNamespaceIDAndRunIDWithFields(
+	ctx context.Context, namespaceID uint, runID string, fields commonModels.ResponseFields,
+) (*models.Run, error) {
+	query := r.GetDBWithContext(ctx)
+	if fields.Has(commonModels.ResponseFieldMetrics) {
+		query = query.Preload("LatestMetrics")
+	}
+	if fields.Has(commonModels.ResponseFieldParams) {
+		query = query.Preload("Params")
+	}
+	if fields.Has(commonModels.ResponseFieldTags) {
+		query = query.Preload("Tags")
+	}
+
 	run := models.Run{ID: runID}
-	if err := r.GetDB().WithContext(
-		ctx,
-	).Preload(
-		"LatestMetrics",
-	).Preload(
-		"Params",
-	).Preload(
-		"Tags",
-	).Joins(
+	if err := query.Joins(
 		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
 		namespaceID,
 	).First(&run).Error; err != nil {
@@ -131,10 +188,80 @@ func (r RunRepository) GetByNamespaceIDAndRunID(
 	return &run, nil
 }
 
+// GetActiveIDsByNamespaceIDAndStartTime returns IDs of active terminated runs started before provided time.
+// Runs, which are still running or scheduled, are not included into the result.
+func (r RunRepository) GetActiveIDsByNamespaceIDAndStartTime(
+	ctx context.Context, namespaceID uint, startTime int64,
+) ([]string, error) {
+	var ids []string
+	if err := r.GetDBWithContext(ctx).Model(
+		models.Run{},
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Where(
+		"runs.lifecycle_stage = ?", models.LifecycleStageActive,
+	).Where(
+		"runs.status NOT IN (?)", []models.Status{models.StatusRunning, models.StatusScheduled},
+	).Where(
+		"runs.start_time < ?", startTime,
+	).Pluck(
+		"runs.run_uuid", &ids,
+	).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting runs started before %d", startTime)
+	}
+	return ids, nil
+}
+
+// GetActiveIDsByNamespaceIDOrderedByMetric returns IDs of active terminated runs ordered by metric value.
+// Runs which don't have the metric or are still running are not included into the result. If the metric
+// has been logged under several contexts, the best value across contexts is taken.
+func (r RunRepository) GetActiveIDsByNamespaceIDOrderedByMetric(
+	ctx context.Context, namespaceID uint, metricKey string, direction models.MetricDirection,
+) ([]string, error) {
+	var order string
+	switch direction {
+	case models.MetricDirectionMin:
+		order = "MIN(latest_metrics.value) ASC"
+	case models.MetricDirectionMax:
+		order = "MAX(latest_metrics.value) DESC"
+	default:
+		return nil, eris.Errorf("unsupported metric direction %q", direction)
+	}
+
+	var ids []string
+	if err := r.GetDBWithContext(ctx).Model(
+		models.Run{},
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Joins(
+		"INNER JOIN latest_metrics ON latest_metrics.run_uuid = runs.run_uuid AND latest_metrics.key = ?",
+		metricKey,
+	).Where(
+		"runs.lifecycle_stage = ?", models.LifecycleStageActive,
+	).Where(
+		"runs.status NOT IN (?)", []models.Status{models.StatusRunning, models.StatusScheduled},
+	).Where(
+		"NOT latest_metrics.is_nan",
+	).Group(
+		"runs.run_uuid",
+	).Order(
+		order,
+	).Order(
+		"runs.run_uuid",
+	).Pluck(
+		"runs.run_uuid", &ids,
+	).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting runs ordered by metric %q", metricKey)
+	}
+	return ids, nil
+}
+
 // Create creates new models.Run entity.
 func (r RunRepository) Create(ctx context.Context, run *models.Run) error {
 	// Lock need to calculate row_num
-	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	if err := repositories.TransactionWithRetry(ctx, r.GetDB(), func(tx *gorm.DB) error {
 		if tx.Dialector.Name() == "postgres" {
 			if err := tx.Exec("LOCK TABLE runs").Error; err != nil {
 				return err
@@ -147,33 +274,32 @@ func (r RunRepository) Create(ctx context.Context, run *models.Run) error {
 	return nil
 }
 
-// Update updates existing models.Run entity.
+// Update updates existing models.Run entity, returning RunVersionConflictError
+// if the run has been changed since it was read.
 func (r RunRepository) Update(ctx context.Context, run *models.Run) error {
-	if err := r.GetDB().WithContext(ctx).Model(&run).Updates(run).Error; err != nil {
-		return eris.Wrapf(err, "error updating run with id: %s", run.ID)
-	}
-	return nil
+	return r.UpdateWithTransaction(ctx, r.GetDBWithContext(ctx), run)
 }
 
-// Archive marks existing models.Run entity as archived.
+// Archive marks existing models.Run entity as archived, returning RunVersionConflictError
+// if the run has been changed since it was read.
 func (r RunRepository) Archive(ctx context.Context, run *models.Run) error {
-	run.DeletedTime = sql.NullInt64{
+	deletedTime := sql.NullInt64{
 		Int64: time.Now().UTC().UnixMilli(),
 		Valid: true,
 	}
-	run.LifecycleStage = models.LifecycleStageDeleted
-	if err := r.GetDB().WithContext(ctx).Model(&run).Updates(run).Error; err != nil {
-		return eris.Wrapf(err, "error updating existing run with id: %s", run.ID)
+	if err := r.updateColumns(ctx, run, map[string]any{
+		"deleted_time":    deletedTime,
+		"lifecycle_stage": models.LifecycleStageDeleted,
+	}); err != nil {
+		return err
 	}
-
+	run.DeletedTime, run.LifecycleStage = deletedTime, models.LifecycleStageDeleted
 	return nil
 }
 
 // ArchiveBatch marks existing models.Run entities as archived.
 func (r RunRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids []string) error {
-	if err := r.GetDB().WithContext(
-		ctx,
-	).Model(
+	if err := r.GetDBWithContext(ctx).Model(
 		models.Run{},
 	).Where(
 		"run_uuid IN (?)",
@@ -187,12 +313,14 @@ func (r RunRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids [
 		).Where(
 			"run_uuid IN (?)", ids,
 		),
-	).Updates(models.Run{
-		DeletedTime: sql.NullInt64{
+	).Updates(map[string]any{
+		"deleted_time": sql.NullInt64{
 			Int64: time.Now().UTC().UnixMilli(),
 			Valid: true,
 		},
-		LifecycleStage: models.LifecycleStageDeleted,
+		"lifecycle_stage": models.LifecycleStageDeleted,
+		// concurrent updates of the runs, which have been read before, have to fail.
+		"version": gorm.Expr("version + 1"),
 	}).Error; err != nil {
 		return eris.Wrapf(err, "error updating existing runs with ids: %s", ids)
 	}
@@ -207,7 +335,7 @@ func (r RunRepository) Delete(ctx context.Context, namespaceID uint, run *models
 
 // DeleteBatch removes existing models.Run from the db.
 func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+	if err := r.GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		runs := make([]models.Run, 0, len(ids))
 		if err := tx.Clauses(
 			clause.Returning{Columns: []clause.Column{{Name: "row_num"}}},
@@ -247,23 +375,24 @@ func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []
 	return nil
 }
 
-// Restore marks existing models.Run entity as active.
+// Restore marks existing models.Run entity as active, returning RunVersionConflictError
+// if the run has been changed since it was read.
 func (r RunRepository) Restore(ctx context.Context, run *models.Run) error {
-	// Use UpdateColumns so we can reset DeletedTime to null
-	if err := r.GetDB().WithContext(ctx).Model(&run).UpdateColumns(map[string]any{
-		"DeletedTime":    sql.NullInt64{},
-		"LifecycleStage": database.LifecycleStageActive,
-	}).Error; err != nil {
-		return eris.Wrapf(err, "error updating existing run with id: %s", run.ID)
+	// use columns map, so we can reset DeletedTime to null.
+	if err := r.updateColumns(ctx, run, map[string]any{
+		"deleted_time":    sql.NullInt64{},
+		"lifecycle_stage": models.LifecycleStageActive,
+	}); err != nil {
+		return err
 	}
-
+	run.DeletedTime, run.LifecycleStage = sql.NullInt64{}, models.LifecycleStageActive
 	return nil
 }
 
 // RestoreBatch marks existing models.Run entities as active.
 func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error {
-	if err := r.GetDB().WithContext(
-		ctx,
+	if err := r.GetDBWithContext(ctx).Model(
+		models.Run{},
 	).Where(
 		"run_uuid IN (?)",
 		r.GetDB().Model(
@@ -276,9 +405,11 @@ func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids [
 		).Where(
 			"run_uuid IN (?)", ids,
 		),
-	).Updates(models.Run{
-		DeletedTime:    sql.NullInt64{},
-		LifecycleStage: models.LifecycleStageActive,
+	).Updates(map[string]any{
+		"deleted_time":    sql.NullInt64{},
+		"lifecycle_stage": models.LifecycleStageActive,
+		// concurrent updates of the runs, which have been read before, have to fail.
+		"version": gorm.Expr("version + 1"),
 	}).Error; err != nil {
 		return eris.Wrapf(err, "error updating existing runs with ids: %s", ids)
 	}
@@ -287,35 +418,85 @@ func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids [
 }
 
 // UpdateWithTransaction updates existing models.Run entity in scope of transaction.
+// The update only succeeds if the run still has the version it was read with,
+// otherwise RunVersionConflictError is returned, so the caller could re-read the run and retry.
 func (r RunRepository) UpdateWithTransaction(ctx context.Context, tx *gorm.DB, run *models.Run) error {
-	if err := tx.WithContext(ctx).Model(&run).Omit("LatestMetrics", "Metrics", "Params").Updates(run).Error; err != nil {
-		return eris.Wrapf(err, "error updating existing run with id: %s", run.ID)
+	version := run.Version
+	run.Version = version + 1
+	result := tx.WithContext(ctx).Model(&run).Where(
+		"version = ?", version,
+	).Omit("LatestMetrics", "Metrics", "Params").Updates(run)
+	if result.Error != nil {
+		run.Version = version
+		return eris.Wrapf(result.Error, "error updating existing run with id: %s", run.ID)
+	}
+	if result.RowsAffected == 0 {
+		run.Version = version
+		return RunVersionConflictError{
+			Message: fmt.Sprintf("run '%s' has been modified by another request", run.ID),
+		}
 	}
 	return nil
 }
 
-// SetRunTagsBatch sets Run tags in batch.
-func (r RunRepository) SetRunTagsBatch(ctx context.Context, run *models.Run, batchSize int, tags []models.Tag) error {
-	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for _, tag := range tags {
-			switch tag.Key {
-			case "mlflow.user":
-				run.UserID = tag.Value
-				if err := r.UpdateWithTransaction(ctx, tx, run); err != nil {
-					return eris.Wrap(err, "error updating run 'user_id' field")
-				}
-			case "mlflow.runName":
-				run.Name = tag.Value
-				if err := r.UpdateWithTransaction(ctx, tx, run); err != nil {
-					return eris.Wrap(err, "error updating run 'name' field")
-				}
-			}
+// updateColumns updates provided columns of existing models.Run entity and increments its version.
+// The update only succeeds if the run still has the version it was read with,
+// otherwise RunVersionConflictError is returned.
+func (r RunRepository) updateColumns(ctx context.Context, run *models.Run, columns map[string]any) error {
+	columns["version"] = run.Version + 1
+	result := r.GetDBWithContext(ctx).Model(
+		models.Run{},
+	).Where(
+		"run_uuid = ? AND version = ?", run.ID, run.Version,
+	).Updates(columns)
+	if result.Error != nil {
+		return eris.Wrapf(result.Error, "error updating existing run with id: %s", run.ID)
+	}
+	if result.RowsAffected == 0 {
+		return RunVersionConflictError{
+			Message: fmt.Sprintf("run '%s' has been modified by another request", run.ID),
 		}
+	}
+	run.Version++
+	return nil
+}
 
-		if err := tx.Clauses(clause.OnConflict{
-			UpdateAll: true,
-		}).CreateInBatches(&tags, batchSize).Error; err != nil {
-			return err
+// SetRunTagsBatch sets Run tags in batch. Tags are upserted, so the transaction is safely retried
+// after transient failures.
+func (r RunRepository) SetRunTagsBatch(ctx context.Context, run *models.Run, batchSize int, tags []models.Tag) error {
+	version, userID, name := run.Version, run.UserID, run.Name
+	if err := repositories.TransactionWithRetry(ctx, r.GetDB(), func(tx *gorm.DB) error {
+		// changes of the rolled back attempt must not leak into the next one.
+		run.Version, run.UserID, run.Name = version, userID, name
+		return r.setRunTagsWithTransaction(ctx, tx, run, batchSize, tags)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetRunsTag sets the same tag of all the runs in scope of one transaction, so either all the runs
+// are tagged or none of them. Tags are upserted, so the transaction is safely retried after transient failures.
+func (r RunRepository) SetRunsTag(ctx context.Context, runs []models.Run, key, value string) error {
+	type runState struct {
+		version      int64
+		userID, name string
+	}
+	states := make([]runState, len(runs))
+	for i, run := range runs {
+		states[i] = runState{version: run.Version, userID: run.UserID, name: run.Name}
+	}
+	if err := repositories.TransactionWithRetry(ctx, r.GetDB(), func(tx *gorm.DB) error {
+		for i := range runs {
+			// changes of the rolled back attempt must not leak into the next one.
+			runs[i].Version, runs[i].UserID, runs[i].Name = states[i].version, states[i].userID, states[i].name
+			if err := r.setRunTagsWithTransaction(ctx, tx, &runs[i], 1, []models.Tag{{
+				Key:   key,
+				Value: value,
+				RunID: runs[i].ID,
+			}}); err != nil {
+				return eris.Wrapf(err, "error setting tag of run with id: %s", runs[i].ID)
+			}
 		}
 		return nil
 	}); err != nil {
@@ -324,6 +505,34 @@ func (r RunRepository) SetRunTagsBatch(ctx context.Context, run *models.Run, bat
 	return nil
 }
 
+// setRunTagsWithTransaction upserts Run tags in scope of transaction. Run fields
+// mirrored by system tags are updated together with the tags.
+func (r RunRepository) setRunTagsWithTransaction(
+	ctx context.Context, tx *gorm.DB, run *models.Run, batchSize int, tags []models.Tag,
+) error {
+	for _, tag := range tags {
+		switch tag.Key {
+		case "mlflow.user":
+			run.UserID = tag.Value
+			if err := r.UpdateWithTransaction(ctx, tx, run); err != nil {
+				return eris.Wrap(err, "error updating run 'user_id' field")
+			}
+		case "mlflow.runName":
+			run.Name = tag.Value
+			if err := r.UpdateWithTransaction(ctx, tx, run); err != nil {
+				return eris.Wrap(err, "error updating run 'name' field")
+			}
+		}
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).CreateInBatches(&tags, batchSize).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 // getMinRowNum will find the lowest row_num for the slice of runs
 // or 0 for an empty slice
 func getMinRowNum(runs []models.Run) models.RowNum {
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// RunNotifyingRepository repository to work with `run` entity, which triggers database event on each change of runs.
+type RunNotifyingRepository struct {
+	RunRepositoryProvider
+	runEventListener dao.EventListenerProvider
+}
+
+// NewRunNotifyingRepository creates new instance of repository to work with `run` entity,
+// which triggers database event on each change of runs.
+func NewRunNotifyingRepository(
+	db *gorm.DB, runEventListener dao.EventListenerProvider,
+) *RunNotifyingRepository {
+	return &RunNotifyingRepository{
+		RunRepositoryProvider: NewRunRepository(db),
+		runEventListener:      runEventListener,
+	}
+}
+
+// Create creates new models.Run entity.
+func (r RunNotifyingRepository) Create(ctx context.Context, run *models.Run) error {
+	if err := r.RunRepositoryProvider.Create(ctx, run); err != nil {
+		return err
+	}
+	return r.sendRunEvent(ctx, r.GetDBWithContext(ctx), run)
+}
+
+// Update updates existing models.Run entity.
+func (r RunNotifyingRepository) Update(ctx context.Context, run *models.Run) error {
+	if err := r.RunRepositoryProvider.Update(ctx, run); err != nil {
+		return err
+	}
+	return r.sendRunEvent(ctx, r.GetDBWithContext(ctx), run)
+}
+
+// Archive marks existing models.Run entity as archived.
+func (r RunNotifyingRepository) Archive(ctx context.Context, run *models.Run) error {
+	if err := r.RunRepositoryProvider.Archive(ctx, run); err != nil {
+		return err
+	}
+	return r.sendRunEvent(ctx, r.GetDBWithContext(ctx), run)
+}
+
+// Delete removes the existing models.Run from the db.
+func (r RunNotifyingRepository) Delete(ctx context.Context, namespaceID uint, run *models.Run) error {
+	if err := r.RunRepositoryProvider.Delete(ctx, namespaceID, run); err != nil {
+		return err
+	}
+	return sendRunEvent(ctx, r.runEventListener, r.GetDBWithContext(ctx), events.RunEvent{
+		NamespaceID: namespaceID,
+		StartTimes:  []int64{run.StartTime.Int64},
+	})
+}
+
+// Restore marks existing models.Run entity as active.
+func (r RunNotifyingRepository) Restore(ctx context.Context, run *models.Run) error {
+	if err := r.RunRepositoryProvider.Restore(ctx, run); err != nil {
+		return err
+	}
+	return r.sendRunEvent(ctx, r.GetDBWithContext(ctx), run)
+}
+
+// ArchiveBatch marks existing models.Run entities as archived.
+func (r RunNotifyingRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids []string) error {
+	return r.doBatch(ctx, namespaceID, ids, r.RunRepositoryProvider.ArchiveBatch)
+}
+
+// DeleteBatch removes the existing models.Run from the db.
+func (r RunNotifyingRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
+	return r.doBatch(ctx, namespaceID, ids, r.RunRepositoryProvider.DeleteBatch)
+}
+
+// RestoreBatch marks existing models.Run entities as active.
+func (r RunNotifyingRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error {
+	return r.doBatch(ctx, namespaceID, ids, r.RunRepositoryProvider.RestoreBatch)
+}
+
+// UpdateWithTransaction updates existing models.Run entity in scope of transaction.
+// Event is delivered to other instances only when transaction is committed.
+func (r RunNotifyingRepository) UpdateWithTransaction(ctx context.Context, tx *gorm.DB, run *models.Run) error {
+	if err := r.RunRepositoryProvider.UpdateWithTransaction(ctx, tx, run); err != nil {
+		return err
+	}
+	return r.sendRunEvent(ctx, tx, run)
+}
+
+// doBatch collects start times of the runs before applying batch operation, as runs could be gone after it.
+func (r RunNotifyingRepository) doBatch(
+	ctx context.Context,
+	namespaceID uint,
+	ids []string,
+	fn func(ctx context.Context, namespaceID uint, ids []string) error,
+) error {
+	startTimes, err := getRunStartTimes(ctx, r.GetDBWithContext(ctx), ids)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, namespaceID, ids); err != nil {
+		return err
+	}
+	return sendRunEvent(ctx, r.runEventListener, r.GetDBWithContext(ctx), events.RunEvent{
+		NamespaceID: namespaceID,
+		StartTimes:  startTimes,
+	})
+}
+
+// sendRunEvent sends database event about the changed run.
+func (r RunNotifyingRepository) sendRunEvent(ctx context.Context, db *gorm.DB, run *models.Run) error {
+	return sendRunEvent(ctx, r.runEventListener, db, events.RunEvent{
+		ExperimentID: &run.ExperimentID,
+		StartTimes:   []int64{run.StartTime.Int64},
+	})
+}
+
+// getRunStartTimes returns start times of the runs with provided ids.
+func getRunStartTimes(ctx context.Context, db *gorm.DB, ids []string) ([]int64, error) {
+	var values []sql.NullInt64
+	if err := db.WithContext(ctx).Model(
+		&models.Run{},
+	).Where(
+		"run_uuid IN ?", ids,
+	).Pluck(
+		"start_time", &values,
+	).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting start time of runs with ids: %s", ids)
+	}
+	startTimes := make([]int64, len(values))
+	for i, value := range values {
+		startTimes[i] = value.Int64
+	}
+	return startTimes, nil
+}
+
+// sendRunEvent sends database event about changed runs.
+func sendRunEvent(
+	ctx context.Context, runEventListener dao.EventListenerProvider, db *gorm.DB, event events.RunEvent,
+) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return eris.Wrap(err, "error serializing RunEvent event")
+	}
+	if err := runEventListener.Publish(ctx, db, string(data)); err != nil {
+		return eris.Wrap(err, "error sending database event")
+	}
+	return nil
+}
@@ -22,6 +22,12 @@ type TagRepositoryProvider interface {
 	GetByRunIDAndKey(ctx context.Context, runID, key string) (*models.Tag, error)
 	// Delete deletes existing models.Tag entity.
 	Delete(ctx context.Context, tag *models.Tag) error
+	// DeleteByRunIDsAndKey deletes tags with provided Key of all the runs.
+	DeleteByRunIDsAndKey(ctx context.Context, runIDs []string, key string) error
+	// GetKeyCardinalityByRunID returns cardinality of tag keys of the run.
+	GetKeyCardinalityByRunID(ctx context.Context, runID string, keys []string) (*KeyCardinality, error)
+	// GetKeyCardinalityByNamespaceID returns cardinality of tag keys of the namespace.
+	GetKeyCardinalityByNamespaceID(ctx context.Context, namespaceID uint, keys []string) (*KeyCardinality, error)
 }
 
 // TagRepository repository to work with models.Tag entity.
@@ -38,7 +44,7 @@ func NewTagRepository(db *gorm.DB) *TagRepository {
 
 // CreateExperimentTag creates new models.ExperimentTag entity connected to models.Experiment.
 func (r TagRepository) CreateExperimentTag(ctx context.Context, experimentTag *models.ExperimentTag) error {
-	if err := r.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+	if err := r.GetDBWithContext(ctx).Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(experimentTag).Error; err != nil {
 		return eris.Wrapf(err, "error creating tag for experiment with id: %d", experimentTag.ExperimentID)
@@ -65,7 +71,7 @@ func (r TagRepository) CreateRunTagWithTransaction(
 // GetByRunIDAndKey returns models.Tag by provided RunID and Tag Key.
 func (r TagRepository) GetByRunIDAndKey(ctx context.Context, runID, key string) (*models.Tag, error) {
 	tag := models.Tag{RunID: runID, Key: key}
-	if err := r.GetDB().WithContext(ctx).First(&tag).Error; err != nil {
+	if err := r.GetDBWithContext(ctx).First(&tag).Error; err != nil {
 		if eris.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -76,8 +82,32 @@ func (r TagRepository) GetByRunIDAndKey(ctx context.Context, runID, key string)
 
 // Delete deletes existing models.Tag entity.
 func (r TagRepository) Delete(ctx context.Context, tag *models.Tag) error {
-	if err := r.GetDB().Delete(tag).Error; err != nil {
+	if err := r.GetDBWithContext(ctx).Delete(tag).Error; err != nil {
 		return eris.Wrapf(err, "error deleting tag by run id: %s and key: %s", tag.RunID, tag.Key)
 	}
 	return nil
 }
+
+// DeleteByRunIDsAndKey deletes tags with provided Key of all the runs. Runs without the tag are skipped.
+func (r TagRepository) DeleteByRunIDsAndKey(ctx context.Context, runIDs []string, key string) error {
+	if err := r.GetDBWithContext(ctx).Where(
+		"run_uuid IN ? AND key = ?", runIDs, key,
+	).Delete(&models.Tag{}).Error; err != nil {
+		return eris.Wrapf(err, "error deleting tags by run ids: %s and key: %s", runIDs, key)
+	}
+	return nil
+}
+
+// GetKeyCardinalityByRunID returns cardinality of tag keys of the run.
+func (r TagRepository) GetKeyCardinalityByRunID(
+	ctx context.Context, runID string, keys []string,
+) (*KeyCardinality, error) {
+	return getKeyCardinalityByRunID(ctx, r.GetDBWithContext(ctx), "tags", runID, keys)
+}
+
+// GetKeyCardinalityByNamespaceID returns cardinality of tag keys of the namespace.
+func (r TagRepository) GetKeyCardinalityByNamespaceID(
+	ctx context.Context, namespaceID uint, keys []string,
+) (*KeyCardinality, error) {
+	return getKeyCardinalityByNamespaceID(ctx, r.GetDBWithContext(ctx), "tags", namespaceID, keys)
+}
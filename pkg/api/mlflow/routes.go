@@ -13,12 +13,24 @@ const (
 	MetricsRoutePrefix     = "/metrics"
 	ArtifactsRoutePrefix   = "/artifacts"
 	ExperimentsRoutePrefix = "/experiments"
+	APIKeysRoutePrefix     = "/api-keys"
+)
+
+// List of `/api-keys/*` routes.
+const (
+	APIKeysListRoute   = "/list"
+	APIKeysCreateRoute = "/create"
+	APIKeysRevokeRoute = "/revoke"
 )
 
 // List of `/artifact/*` routes.
 const (
-	ArtifactsGetRoute  = "/get"
-	ArtifactsListRoute = "/list"
+	ArtifactsGetRoute             = "/get"
+	ArtifactsListRoute            = "/list"
+	ArtifactsUploadsCreateRoute   = "/uploads/create"
+	ArtifactsUploadsPartRoute     = "/uploads/part"
+	ArtifactsUploadsCompleteRoute = "/uploads/complete"
+	ArtifactsUploadsAbortRoute    = "/uploads/abort"
 )
 
 // List of `/experiments/*` routes.
@@ -36,31 +48,39 @@ const (
 
 // List of `/metrics/*` routes.
 const (
+	MetricsGetBestRoute        = "/get-best"
 	MetricsGetHistoriesRoute   = "/get-histories"
 	MetricsGetHistoryRoute     = "/get-history"
 	MetricsGetHistoryBulkRoute = "/get-history-bulk"
+	MetricsDeleteRoute         = "/delete"
 )
 
 // List of `/runs/*` routes.
 const (
-	RunsGetRoute          = "/get"
-	RunsCreateRoute       = "/create"
-	RunsDeleteRoute       = "/delete"
-	RunsSearchRoute       = "/search"
-	RunsSetTagRoute       = "/set-tag"
-	RunsUpdateRoute       = "/update"
-	RunsRestoreRoute      = "/restore"
-	RunsDeleteTagRoute    = "/delete-tag"
-	RunsLogBatchRoute     = "/log-batch"
-	RunsLogMetricRoute    = "/log-metric"
-	RunsLogParameterRoute = "/log-parameter"
+	RunsGetRoute           = "/get"
+	RunsCloneRoute         = "/clone"
+	RunsCreateRoute        = "/create"
+	RunsDeleteRoute        = "/delete"
+	RunsExportRoute        = "/export"
+	RunsSearchRoute        = "/search"
+	RunsSetTagRoute        = "/set-tag"
+	RunsSetTagBulkRoute    = "/set-tag-bulk"
+	RunsUpdateRoute        = "/update"
+	RunsRestoreRoute       = "/restore"
+	RunsDeleteTagRoute     = "/delete-tag"
+	RunsDeleteTagBulkRoute = "/delete-tag-bulk"
+	RunsLogBatchRoute      = "/log-batch"
+	RunsLogMetricRoute     = "/log-metric"
+	RunsLogModelRoute      = "/log-model"
+	RunsLogParameterRoute  = "/log-parameter"
 )
 
 // Router represents `mlflow` router.
 type Router struct {
-	prefixList        []string
-	controller        *controller.Controller
-	globalMiddlewares []fiber.Handler
+	prefixList            []string
+	controller            *controller.Controller
+	globalMiddlewares     []fiber.Handler
+	transactionMiddleware func(handler fiber.Handler) fiber.Handler
 }
 
 // NewRouter creates new instance of `mlflow` router.
@@ -85,9 +105,18 @@ func (r *Router) Init(router fiber.Router) {
 		}
 
 		// setup related routes.
+		apiKeys := mainGroup.Group(APIKeysRoutePrefix)
+		apiKeys.Get(APIKeysListRoute, r.controller.ListAPIKeys)
+		apiKeys.Post(APIKeysCreateRoute, r.controller.CreateAPIKey)
+		apiKeys.Post(APIKeysRevokeRoute, r.controller.RevokeAPIKey)
+
 		artifacts := mainGroup.Group(ArtifactsRoutePrefix)
 		artifacts.Get(ArtifactsGetRoute, r.controller.GetArtifact)
 		artifacts.Get(ArtifactsListRoute, r.controller.ListArtifacts)
+		artifacts.Post(ArtifactsUploadsCreateRoute, r.controller.CreateArtifactUpload)
+		artifacts.Put(ArtifactsUploadsPartRoute, r.controller.UploadArtifactPart)
+		artifacts.Post(ArtifactsUploadsCompleteRoute, r.controller.CompleteArtifactUpload)
+		artifacts.Post(ArtifactsUploadsAbortRoute, r.controller.AbortArtifactUpload)
 
 		experiments := mainGroup.Group(ExperimentsRoutePrefix)
 		experiments.Post(ExperimentsCreateRoute, r.controller.CreateExperiment)
@@ -102,21 +131,28 @@ func (r *Router) Init(router fiber.Router) {
 		experiments.Post(ExperimentsUpdateRoute, r.controller.UpdateExperiment)
 
 		metrics := mainGroup.Group(MetricsRoutePrefix)
+		metrics.Post(MetricsGetBestRoute, r.controller.GetBestMetrics)
 		metrics.Get(MetricsGetHistoryRoute, r.controller.GetMetricHistory)
 		metrics.Get(MetricsGetHistoryBulkRoute, r.controller.GetMetricHistoryBulk)
 		metrics.Post(MetricsGetHistoriesRoute, r.controller.GetMetricHistories)
+		metrics.Post(MetricsDeleteRoute, r.controller.DeleteMetric)
 
 		runs := mainGroup.Group(RunsRoutePrefix)
+		runs.Post(RunsCloneRoute, r.controller.CloneRun)
 		runs.Post(RunsCreateRoute, r.controller.CreateRun)
 		runs.Post(RunsDeleteRoute, r.controller.DeleteRun)
 		runs.Post(RunsDeleteTagRoute, r.controller.DeleteRunTag)
+		runs.Post(RunsDeleteTagBulkRoute, r.controller.DeleteRunsTag)
+		runs.Get(RunsExportRoute, r.controller.ExportRun)
 		runs.Get(RunsGetRoute, r.controller.GetRun)
-		runs.Post(RunsLogBatchRoute, r.controller.LogBatch)
+		runs.Post(RunsLogBatchRoute, r.transactional(r.controller.LogBatch))
 		runs.Post(RunsLogMetricRoute, r.controller.LogMetric)
+		runs.Post(RunsLogModelRoute, r.controller.LogModel)
 		runs.Post(RunsLogParameterRoute, r.controller.LogParam)
 		runs.Post(RunsRestoreRoute, r.controller.RestoreRun)
 		runs.Post(RunsSearchRoute, r.controller.SearchRuns)
 		runs.Post(RunsSetTagRoute, r.controller.SetRunTag)
+		runs.Post(RunsSetTagBulkRoute, r.controller.SetRunsTag)
 		runs.Post(RunsUpdateRoute, r.controller.UpdateRun)
 
 		mainGroup.Get("/model-versions/search", r.controller.SearchModelVersions)
@@ -128,6 +164,20 @@ func (r *Router) Init(router fiber.Router) {
 	}
 }
 
+// WithTransactionMiddleware sets a middleware, which wraps handlers of multi-step routes into a transaction.
+func (r *Router) WithTransactionMiddleware(middleware func(handler fiber.Handler) fiber.Handler) *Router {
+	r.transactionMiddleware = middleware
+	return r
+}
+
+// transactional wraps the handler with transaction middleware, if any.
+func (r *Router) transactional(handler fiber.Handler) fiber.Handler {
+	if r.transactionMiddleware == nil {
+		return handler
+	}
+	return r.transactionMiddleware(handler)
+}
+
 // AddGlobalMiddleware adds a global middleware which will be applied for each route.
 func (r *Router) AddGlobalMiddleware(middleware fiber.Handler) *Router {
 	r.globalMiddlewares = append(r.globalMiddlewares, middleware)
@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// Service provides service layer to work with `api key` business logic.
+type Service struct {
+	apiKeyRepository repositories.APIKeyRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(apiKeyRepository repositories.APIKeyRepositoryProvider) *Service {
+	return &Service{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+// CreateAPIKey handles business logic of `POST /api-keys/create` endpoint.
+// Besides the created key, it returns the raw key value, which can't be requested again.
+func (s Service) CreateAPIKey(
+	ctx context.Context, namespace *models.Namespace, req *request.CreateAPIKeyRequest,
+) (*models.APIKey, string, error) {
+	if err := ValidateCreateAPIKeyRequest(req); err != nil {
+		return nil, "", err
+	}
+
+	apiKey, key, err := models.NewAPIKey(namespace.ID, req.Name, models.APIKeyAccessLevel(req.AccessLevel))
+	if err != nil {
+		return nil, "", api.NewInternalError("error generating api key: %s", err)
+	}
+	if err := s.apiKeyRepository.Create(ctx, apiKey); err != nil {
+		return nil, "", api.NewInternalError("error creating api key: %s", err)
+	}
+	return apiKey, key, nil
+}
+
+// ListAPIKeys handles business logic of `GET /api-keys/list` endpoint.
+func (s Service) ListAPIKeys(ctx context.Context, namespace *models.Namespace) ([]models.APIKey, error) {
+	apiKeys, err := s.apiKeyRepository.ListByNamespaceID(ctx, namespace.ID)
+	if err != nil {
+		return nil, api.NewInternalError("error listing api keys: %s", err)
+	}
+	return apiKeys, nil
+}
+
+// RevokeAPIKey handles business logic of `POST /api-keys/revoke` endpoint.
+func (s Service) RevokeAPIKey(
+	ctx context.Context, namespace *models.Namespace, req *request.RevokeAPIKeyRequest,
+) error {
+	if err := ValidateRevokeAPIKeyRequest(req); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'id' supplied.")
+	}
+	deleted, err := s.apiKeyRepository.Delete(ctx, namespace.ID, id)
+	if err != nil {
+		return api.NewInternalError("error revoking api key '%s': %s", req.ID, err)
+	}
+	if !deleted {
+		return api.NewResourceDoesNotExistError("unable to find api key '%s'", req.ID)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestService_CreateAPIKey_Ok(t *testing.T) {
+	ns := models.Namespace{ID: 1, Code: "code"}
+
+	// init repository mocks.
+	apiKeyRepository := repositories.MockAPIKeyRepositoryProvider{}
+	apiKeyRepository.On(
+		"Create", context.TODO(), mock.AnythingOfType("*models.APIKey"),
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(&apiKeyRepository)
+	apiKey, key, err := service.CreateAPIKey(context.TODO(), &ns, &request.CreateAPIKeyRequest{
+		Name:        "ci",
+		AccessLevel: "read",
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.True(t, models.IsAPIKey(key))
+	assert.Equal(t, ns.ID, apiKey.NamespaceID)
+	assert.Equal(t, "ci", apiKey.Name)
+	assert.Equal(t, models.APIKeyAccessLevelRead, apiKey.AccessLevel)
+	assert.False(t, apiKey.HasWriteAccess())
+	assert.True(t, len(apiKey.Prefix) < len(key))
+	assert.Equal(t, key[:len(apiKey.Prefix)], apiKey.Prefix)
+	// only hash of the key is stored.
+	assert.Equal(t, models.HashAPIKey(key), apiKey.Hash)
+	assert.NotContains(t, apiKey.Hash, key)
+}
+
+func TestService_CreateAPIKey_Error(t *testing.T) {
+	ns := models.Namespace{ID: 1, Code: "code"}
+
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateAPIKeyRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyName",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.CreateAPIKeyRequest{AccessLevel: "read"},
+			service: func() *Service {
+				return NewService(&repositories.MockAPIKeyRepositoryProvider{})
+			},
+		},
+		{
+			name:    "EmptyAccessLevel",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'access_level'"),
+			request: &request.CreateAPIKeyRequest{Name: "ci"},
+			service: func() *Service {
+				return NewService(&repositories.MockAPIKeyRepositoryProvider{})
+			},
+		},
+		{
+			name: "InvalidAccessLevel",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'access_level' supplied. Supported values are 'read' and 'write'.",
+			),
+			request: &request.CreateAPIKeyRequest{Name: "ci", AccessLevel: "admin"},
+			service: func() *Service {
+				return NewService(&repositories.MockAPIKeyRepositoryProvider{})
+			},
+		},
+		{
+			name:    "DatabaseError",
+			error:   api.NewInternalError("error creating api key: database error"),
+			request: &request.CreateAPIKeyRequest{Name: "ci", AccessLevel: "write"},
+			service: func() *Service {
+				apiKeyRepository := repositories.MockAPIKeyRepositoryProvider{}
+				apiKeyRepository.On(
+					"Create", context.TODO(), mock.AnythingOfType("*models.APIKey"),
+				).Return(errors.New("database error"))
+				return NewService(&apiKeyRepository)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.service().CreateAPIKey(context.TODO(), &ns, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_RevokeAPIKey_Ok(t *testing.T) {
+	ns := models.Namespace{ID: 1, Code: "code"}
+	id := uuid.New()
+
+	// init repository mocks.
+	apiKeyRepository := repositories.MockAPIKeyRepositoryProvider{}
+	apiKeyRepository.On("Delete", context.TODO(), ns.ID, id).Return(true, nil)
+
+	// call service under testing.
+	service := NewService(&apiKeyRepository)
+	err := service.RevokeAPIKey(context.TODO(), &ns, &request.RevokeAPIKeyRequest{ID: id.String()})
+
+	// compare results.
+	require.Nil(t, err)
+	apiKeyRepository.AssertExpectations(t)
+}
+
+func TestService_RevokeAPIKey_Error(t *testing.T) {
+	ns := models.Namespace{ID: 1, Code: "code"}
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.RevokeAPIKeyRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'id'"),
+			request: &request.RevokeAPIKeyRequest{},
+			service: func() *Service {
+				return NewService(&repositories.MockAPIKeyRepositoryProvider{})
+			},
+		},
+		{
+			name:    "InvalidID",
+			error:   api.NewInvalidParameterValueError("Invalid value for parameter 'id' supplied."),
+			request: &request.RevokeAPIKeyRequest{ID: "id"},
+			service: func() *Service {
+				return NewService(&repositories.MockAPIKeyRepositoryProvider{})
+			},
+		},
+		{
+			name:    "NotFoundOrOtherNamespace",
+			error:   api.NewResourceDoesNotExistError("unable to find api key '%s'", id),
+			request: &request.RevokeAPIKeyRequest{ID: id.String()},
+			service: func() *Service {
+				apiKeyRepository := repositories.MockAPIKeyRepositoryProvider{}
+				apiKeyRepository.On("Delete", context.TODO(), ns.ID, id).Return(false, nil)
+				return NewService(&apiKeyRepository)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.service().RevokeAPIKey(context.TODO(), &ns, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
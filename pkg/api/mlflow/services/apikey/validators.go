@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// MaxNameLength is a maximum length of the API key name.
+const MaxNameLength = 256
+
+// ValidateCreateAPIKeyRequest validates `POST /mlflow/api-keys/create` request.
+func ValidateCreateAPIKeyRequest(req *request.CreateAPIKeyRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+
+	if len(req.Name) > MaxNameLength {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'name' supplied. Name must be at most %d characters.", MaxNameLength,
+		)
+	}
+
+	if req.AccessLevel == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'access_level'")
+	}
+
+	if !models.APIKeyAccessLevel(req.AccessLevel).IsValid() {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'access_level' supplied. Supported values are '%s' and '%s'.",
+			models.APIKeyAccessLevelRead, models.APIKeyAccessLevelWrite,
+		)
+	}
+
+	return nil
+}
+
+// ValidateRevokeAPIKeyRequest validates `POST /mlflow/api-keys/revoke` request.
+func ValidateRevokeAPIKeyRequest(req *request.RevokeAPIKeyRequest) error {
+	if req.ID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'id'")
+	}
+	return nil
+}
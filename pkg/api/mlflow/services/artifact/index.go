@@ -0,0 +1,119 @@
+package artifact
+
+import (
+	"html/template"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+)
+
+// artifactIndexTemplate is a minimal HTML directory listing of the run artifacts.
+// Links are relative to `/artifacts/list` endpoint, so they keep namespace and API prefixes of the request.
+var artifactIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Artifacts of run {{ .RunID }}{{ if .Path }} - {{ .Path }}{{ end }}</title>
+</head>
+<body>
+<h1>Artifacts of run {{ .RunID }}{{ if .Path }} - {{ .Path }}{{ end }}</h1>
+<table>
+<thead><tr><th>Name</th><th>Size</th></tr></thead>
+<tbody>
+{{- if .ParentLink }}
+<tr><td><a href="{{ .ParentLink }}">../</a></td><td></td></tr>
+{{- end }}
+{{- range .Entries }}
+<tr><td><a href="{{ .Link }}">{{ .Name }}</a></td><td>{{ if not .IsDir }}{{ .Size }}{{ end }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- if .NextPageLink }}
+<p><a href="{{ .NextPageLink }}">Next page</a></p>
+{{- end }}
+</body>
+</html>
+`))
+
+// artifactIndexEntry represents a single artifact of the HTML listing.
+type artifactIndexEntry struct {
+	Name  string
+	Link  string
+	Size  int64
+	IsDir bool
+}
+
+// RenderArtifactIndex renders HTML listing of the artifacts, returned by ListArtifacts, for human browsing.
+// Directories link to their own listing, while files link to `/artifacts/get` endpoint.
+func (s Service) RenderArtifactIndex(
+	w io.Writer, req *request.ListArtifactsRequest, artifacts []storage.ArtifactObject, nextPageToken string,
+) error {
+	runID, dir := req.GetRunID(), strings.Trim(req.Path, "/")
+	link := func(endpoint, artifactPath string, extra url.Values) string {
+		query := url.Values{"run_id": {runID}}
+		if artifactPath != "" {
+			query.Set("path", artifactPath)
+		}
+		for key, values := range extra {
+			query[key] = values
+		}
+		return endpoint + "?" + query.Encode()
+	}
+
+	entries := make([]artifactIndexEntry, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		entry := artifactIndexEntry{
+			Name:  strings.TrimPrefix(strings.TrimPrefix(artifact.Path, dir), "/"),
+			Size:  artifact.Size,
+			IsDir: artifact.IsDir,
+		}
+		if artifact.IsDir {
+			entry.Name += "/"
+			entry.Link = link("list", artifact.Path, nil)
+		} else {
+			entry.Link = link("get", artifact.Path, nil)
+		}
+		entries = append(entries, entry)
+	}
+
+	data := struct {
+		RunID        string
+		Path         string
+		ParentLink   string
+		NextPageLink string
+		Entries      []artifactIndexEntry
+	}{
+		RunID:   runID,
+		Path:    dir,
+		Entries: entries,
+	}
+	if dir != "" {
+		parent := path.Dir(dir)
+		if parent == "." {
+			parent = ""
+		}
+		data.ParentLink = link("list", parent, nil)
+	}
+	if nextPageToken != "" {
+		extra := url.Values{"page_token": {nextPageToken}}
+		if req.MaxResults > 0 {
+			extra.Set("max_results", strconv.FormatInt(req.MaxResults, 10))
+		}
+		if req.Recursive {
+			extra.Set("recursive", "true")
+		}
+		data.NextPageLink = link("list", dir, extra)
+	}
+
+	if err := artifactIndexTemplate.Execute(w, data); err != nil {
+		return eris.Wrap(err, "error rendering artifact index")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package artifact
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestService_RenderArtifactIndex_Ok(t *testing.T) {
+	service := NewService(&config.Config{}, nil, nil, nil)
+
+	var out strings.Builder
+	require.Nil(t, service.RenderArtifactIndex(&out, &request.ListArtifactsRequest{
+		RunID:      "id",
+		Path:       "dir",
+		MaxResults: 2,
+	}, []storage.ArtifactObject{
+		{Path: "dir/<script>.txt", Size: 42},
+		{Path: "dir/sub", IsDir: true},
+	}, "token"))
+
+	html := out.String()
+	assert.Contains(t, html, `<a href="list?run_id=id">../</a>`)
+	assert.Contains(
+		t, html, `<a href="get?path=dir%2F%3Cscript%3E.txt&amp;run_id=id">&lt;script&gt;.txt</a></td><td>42</td>`,
+	)
+	assert.Contains(t, html, `<a href="list?path=dir%2Fsub&amp;run_id=id">sub/</a></td><td></td>`)
+	assert.Contains(t, html, `<a href="list?max_results=2&amp;page_token=token&amp;path=dir&amp;run_id=id">Next page</a>`)
+	assert.NotContains(t, html, "<script>")
+}
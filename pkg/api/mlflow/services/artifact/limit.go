@@ -0,0 +1,67 @@
+package artifact
+
+import (
+	"errors"
+	"io"
+)
+
+// errArtifactTooLarge is returned, when the uploaded artifact exceeds configured maximum size.
+var errArtifactTooLarge = errors.New("artifact is too large")
+
+// limitedPartReader counts bytes of the part body, while it is streamed to the storage,
+// and fails with errArtifactTooLarge once more than limit bytes are read.
+type limitedPartReader struct {
+	reader io.Reader
+	limit  int64
+	// offset is the current position in the body, while size is the maximum position reached.
+	// Storages could rewind the body, e.g. to retry the request, so the same bytes aren't counted twice.
+	offset int64
+	size   int64
+}
+
+// Read implements io.Reader interface.
+func (r *limitedPartReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	r.size = max(r.size, r.offset)
+	if r.exceeded() {
+		return n, errArtifactTooLarge
+	}
+	return n, err
+}
+
+// seekableLimitedPartReader is a limitedPartReader of the body, which supports seeking.
+// Some storages need to find out the length of the body or to rewind it, when it is seekable.
+type seekableLimitedPartReader struct {
+	*limitedPartReader
+	seeker io.Seeker
+}
+
+// Seek implements io.Seeker interface.
+func (r seekableLimitedPartReader) Seek(offset int64, whence int) (int64, error) {
+	position, err := r.seeker.Seek(offset, whence)
+	if err != nil {
+		return 0, err
+	}
+	r.offset = position
+	return position, nil
+}
+
+// newLimitedPartReader creates limitedPartReader of the body, which is returned as io.Reader as well.
+// Returned reader supports seeking only when the body supports it, so storages don't try to rewind
+// streamed bodies.
+func newLimitedPartReader(body io.Reader, limit int64) (*limitedPartReader, io.Reader) {
+	reader := &limitedPartReader{
+		reader: body,
+		limit:  limit,
+	}
+	if seeker, ok := body.(io.Seeker); ok {
+		return reader, seekableLimitedPartReader{limitedPartReader: reader, seeker: seeker}
+	}
+	return reader, reader
+}
+
+// exceeded checks whether more than limit bytes have been read.
+func (r *limitedPartReader) exceeded() bool {
+	return r.size > r.limit
+}
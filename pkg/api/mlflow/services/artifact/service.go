@@ -3,100 +3,245 @@ package artifact
 import (
 	"cmp"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"path/filepath"
 	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+const (
+	// artifactStatsCacheSize is a maximum number of runs, which artifact stats are cached.
+	artifactStatsCacheSize = 1000
+	// artifactStatsCacheTTL is a time after which cached artifact stats are listed from storage again.
+	artifactStatsCacheTTL = time.Minute
 )
 
+// ArtifactStats represents total size and count of the run artifact objects.
+type ArtifactStats struct {
+	Size  int64 // total artifact size in bytes.
+	Count int64
+}
+
 // Service provides service layer to work with `artifact` business logic.
 type Service struct {
-	runRepository          repositories.RunRepositoryProvider
-	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+	config                   *config.Config
+	runRepository            repositories.RunRepositoryProvider
+	artifactUploadRepository repositories.ArtifactUploadRepositoryProvider
+	artifactStorageFactory   storage.ArtifactStorageFactoryProvider
+	artifactStats            *expirable.LRU[string, ArtifactStats]
 }
 
 // NewService creates new Service instance.
 func NewService(
+	config *config.Config,
 	runRepository repositories.RunRepositoryProvider,
+	artifactUploadRepository repositories.ArtifactUploadRepositoryProvider,
 	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
 ) *Service {
 	return &Service{
-		runRepository:          runRepository,
-		artifactStorageFactory: artifactStorageFactory,
+		config:                   config,
+		runRepository:            runRepository,
+		artifactUploadRepository: artifactUploadRepository,
+		artifactStorageFactory:   artifactStorageFactory,
+		artifactStats: expirable.NewLRU[string, ArtifactStats](
+			artifactStatsCacheSize, nil, artifactStatsCacheTTL,
+		),
 	}
 }
 
+// GetArtifactStats returns total size and count of the artifact objects stored under run artifactURI.
+// Listing of the whole run artifact tree could be expensive for object stores, so results are cached.
+func (s Service) GetArtifactStats(ctx context.Context, artifactURI string) (*ArtifactStats, error) {
+	if stats, ok := s.artifactStats.Get(artifactURI); ok {
+		return &stats, nil
+	}
+
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, artifactURI)
+	if err != nil {
+		return nil, api.NewInternalError("artifact URI '%s' has unsupported artifact storage", artifactURI)
+	}
+	artifacts, err := artifactStorage.List(ctx, artifactURI, "", true)
+	if err != nil {
+		return nil, api.NewInternalError("error getting artifact list from storage for URI '%s'", artifactURI)
+	}
+
+	stats := ArtifactStats{}
+	for _, artifact := range artifacts {
+		if !artifact.IsDirectory() {
+			stats.Size += artifact.GetSize()
+			stats.Count++
+		}
+	}
+	s.artifactStats.Add(artifactURI, stats)
+	return &stats, nil
+}
+
 // ListArtifacts handles business logic of `GET /artifacts/list` endpoint.
+// Besides the page of artifacts, it returns an offset of the next page or 0, if there are no more pages.
 func (s Service) ListArtifacts(
 	ctx context.Context, namespace *models.Namespace, req *request.ListArtifactsRequest,
-) (string, []storage.ArtifactObject, error) {
+) (string, []storage.ArtifactObject, int, error) {
 	if err := ValidateListArtifactsRequest(req); err != nil {
-		return "", nil, err
+		return "", nil, 0, err
+	}
+
+	var offset int
+	if req.PageToken != "" {
+		var token request.PageToken
+		if err := json.NewDecoder(
+			base64.NewDecoder(
+				base64.StdEncoding,
+				strings.NewReader(req.PageToken),
+			),
+		).Decode(&token); err != nil || token.Offset < 0 {
+			return "", nil, 0, api.NewInvalidParameterValueError("invalid page_token '%s'", req.PageToken)
+		}
+		offset = int(token.Offset)
 	}
 
 	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.GetRunID())
 	if err != nil {
-		return "", nil, api.NewInternalError("unable to find run '%s': %s", req.GetRunID(), err)
+		return "", nil, 0, api.NewInternalError("unable to find run '%s': %s", req.GetRunID(), err)
 	}
 	if run == nil {
-		return "", nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
+		return "", nil, 0, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
 	}
 
-	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
-	if err != nil {
-		return "", nil, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
-	}
-
-	artifacts, err := artifactStorage.List(ctx, run.ArtifactURI, req.Path)
-	if err != nil {
-		return "", nil, api.NewInternalError("error getting artifact list from storage")
+	// artifacts are listed from the first of the run artifact roots, which has any under the path.
+	var artifacts []storage.ArtifactObject
+	for _, artifactURI := range s.config.GetArtifactRoots(run.ArtifactURI) {
+		artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, artifactURI)
+		if err != nil {
+			return "", nil, 0, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
+		}
+		artifacts, err = artifactStorage.List(ctx, artifactURI, req.Path, req.Recursive)
+		if err != nil {
+			if errors.Is(err, storage.ErrPathOutsideRoot) {
+				return "", nil, 0, newPathOutsideRootError(req.Path)
+			}
+			return "", nil, 0, api.NewInternalError("error getting artifact list from storage")
+		}
+		if len(artifacts) > 0 {
+			break
+		}
 	}
 
-	// sort artifacts by path
+	// sort artifacts by path, so pages are stable between the requests.
 	slices.SortFunc(artifacts, func(a, b storage.ArtifactObject) int {
 		return cmp.Compare(a.Path, b.Path)
 	})
 
-	return run.ArtifactURI, artifacts, nil
+	// paginate artifacts, if it was requested.
+	if req.MaxResults == 0 {
+		return run.ArtifactURI, artifacts, 0, nil
+	}
+	if offset >= len(artifacts) {
+		return run.ArtifactURI, []storage.ArtifactObject{}, 0, nil
+	}
+	nextOffset := offset + int(req.MaxResults)
+	if nextOffset >= len(artifacts) {
+		return run.ArtifactURI, artifacts[offset:], 0, nil
+	}
+	return run.ArtifactURI, artifacts[offset:nextOffset], nextOffset, nil
 }
 
 // GetArtifact handles business logic of `GET /artifacts/get` endpoint.
+// Artifact content is not opened and nil io.ReadCloser is returned, if artifact matches
+// the `If-None-Match` or `If-Modified-Since` conditions of the request.
 func (s Service) GetArtifact(
 	ctx context.Context, namespace *models.Namespace, req *request.GetArtifactRequest,
-) (io.ReadCloser, error) {
+) (*storage.ArtifactObject, io.ReadCloser, error) {
 	if err := ValidateGetArtifactRequest(req); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.GetRunID())
 	if err != nil {
-		return nil, api.NewInternalError("unable to find run '%s': %s", req.GetRunID(), err)
+		return nil, nil, api.NewInternalError("unable to find run '%s': %s", req.GetRunID(), err)
 	}
 	if run == nil {
-		return nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
+		return nil, nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
 	}
-	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
-	if err != nil {
-		return nil, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
+	msg := fmt.Sprintf("error getting artifact object for URI: %s", filepath.Join(run.ArtifactURI, req.Path))
+	// artifact is served from the first of the run artifact roots, where it exists.
+	for _, artifactURI := range s.config.GetArtifactRoots(run.ArtifactURI) {
+		artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, artifactURI)
+		if err != nil {
+			return nil, nil, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
+		}
+
+		artifactObject, err := artifactStorage.Stat(ctx, artifactURI, req.Path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, nil, newGetArtifactError(err, req.Path, msg)
+		}
+		if isArtifactNotModified(artifactObject, req) {
+			return artifactObject, nil, nil
+		}
+
+		artifactReader, err := artifactStorage.Get(ctx, artifactURI, req.Path)
+		if err != nil {
+			return nil, nil, newGetArtifactError(err, req.Path, msg)
+		}
+		return artifactObject, artifactReader, nil
 	}
+	return nil, nil, api.NewResourceDoesNotExistError(msg)
+}
 
-	artifactReader, err := artifactStorage.Get(
-		ctx, run.ArtifactURI, req.Path,
-	)
-	if err != nil {
-		msg := fmt.Sprintf("error getting artifact object for URI: %s", filepath.Join(run.ArtifactURI, req.Path))
-		if errors.Is(err, fs.ErrNotExist) {
-			return nil, api.NewResourceDoesNotExistError(msg)
+// newGetArtifactError converts error of artifact storage into API error.
+func newGetArtifactError(err error, path, msg string) *api.ErrorResponse {
+	if errors.Is(err, fs.ErrNotExist) {
+		return api.NewResourceDoesNotExistError(msg)
+	}
+	if errors.Is(err, storage.ErrPathOutsideRoot) {
+		return newPathOutsideRootError(path)
+	}
+	return api.NewInternalError(msg)
+}
+
+// isArtifactNotModified checks conditional headers of the request against the artifact object.
+// `If-Modified-Since` is ignored, when `If-None-Match` is provided.
+func isArtifactNotModified(artifactObject *storage.ArtifactObject, req *request.GetArtifactRequest) bool {
+	if req.IfNoneMatch != "" {
+		for _, etag := range strings.Split(req.IfNoneMatch, ",") {
+			// weak comparison is used for GET requests, so `W/` prefix is ignored.
+			etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+			if etag == "*" || (etag != "" && etag == artifactObject.GetETag()) {
+				return true
+			}
+		}
+		return false
+	}
+	if req.IfModifiedSince != "" && !artifactObject.LastModified.IsZero() {
+		modifiedSince, err := http.ParseTime(req.IfModifiedSince)
+		if err != nil {
+			return false
 		}
-		return nil, api.NewInternalError(msg)
+		// HTTP dates have a precision of one second.
+		return !artifactObject.LastModified.Truncate(time.Second).After(modifiedSince)
 	}
-	return artifactReader, nil
+	return false
+}
+
+// newPathOutsideRootError creates an error for the path, which escapes the run artifact root.
+func newPathOutsideRootError(path string) *api.ErrorResponse {
+	return api.NewInvalidParameterValueError("Invalid path '%s': path is outside of the run artifact root", path)
 }
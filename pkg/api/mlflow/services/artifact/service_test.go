@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/fs"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,12 +18,13 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 func TestService_ListArtifacts_Ok(t *testing.T) {
 	artifactStorage := storage.MockArtifactStorageProvider{}
 	artifactStorage.On(
-		"List", context.TODO(), "/artifact/uri", "",
+		"List", context.TODO(), "/artifact/uri", "", false,
 	).Return(
 		[]storage.ArtifactObject{
 			{
@@ -55,8 +58,13 @@ func TestService_ListArtifacts_Ok(t *testing.T) {
 	}, nil)
 
 	// call service under testing.
-	service := NewService(&runRepository, &artifactStorageFactory)
-	rootURI, artifacts, err := service.ListArtifacts(
+	service := NewService(
+		&config.Config{},
+		&runRepository,
+		&repositories.MockArtifactUploadRepositoryProvider{},
+		&artifactStorageFactory,
+	)
+	rootURI, artifacts, nextOffset, err := service.ListArtifacts(
 		context.TODO(),
 		&models.Namespace{
 			ID: 1,
@@ -68,6 +76,7 @@ func TestService_ListArtifacts_Ok(t *testing.T) {
 
 	require.Nil(t, err)
 	assert.Equal(t, "/artifact/uri", rootURI)
+	assert.Equal(t, 0, nextOffset)
 	assert.Equal(t, []storage.ArtifactObject{
 		{
 			Path:  "path1",
@@ -82,6 +91,91 @@ func TestService_ListArtifacts_Ok(t *testing.T) {
 	}, artifacts)
 }
 
+func TestService_ListArtifacts_Paginated(t *testing.T) {
+	testData := []struct {
+		name       string
+		pageToken  string
+		artifacts  []storage.ArtifactObject
+		nextOffset int
+	}{
+		{
+			name: "FirstPage",
+			artifacts: []storage.ArtifactObject{
+				{Path: "dir", IsDir: true},
+				{Path: "dir/file1", Size: 1},
+			},
+			nextOffset: 2,
+		},
+		{
+			name:      "LastPage",
+			pageToken: "eyJvZmZzZXQiOjJ9",
+			artifacts: []storage.ArtifactObject{
+				{Path: "dir/file2", Size: 2},
+			},
+		},
+		{
+			name:      "PageAfterLastPage",
+			pageToken: "eyJvZmZzZXQiOjR9",
+			artifacts: []storage.ArtifactObject{},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactStorage := storage.MockArtifactStorageProvider{}
+			artifactStorage.On(
+				"List", context.TODO(), "/artifact/uri", "", true,
+			).Return(
+				[]storage.ArtifactObject{
+					{Path: "dir/file2", Size: 2},
+					{Path: "dir", IsDir: true},
+					{Path: "dir/file1", Size: 1},
+				}, nil,
+			)
+
+			artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+			artifactStorageFactory.On(
+				"GetStorage", context.TODO(), "/artifact/uri",
+			).Return(&artifactStorage, nil)
+
+			runRepository := repositories.MockRunRepositoryProvider{}
+			runRepository.On(
+				"GetByNamespaceIDAndRunID",
+				context.TODO(),
+				uint(1),
+				"id",
+			).Return(&models.Run{
+				ID:          "id",
+				ArtifactURI: "/artifact/uri",
+			}, nil)
+
+			// call service under testing.
+			service := NewService(
+				&config.Config{},
+				&runRepository,
+				&repositories.MockArtifactUploadRepositoryProvider{},
+				&artifactStorageFactory,
+			)
+			_, artifacts, nextOffset, err := service.ListArtifacts(
+				context.TODO(),
+				&models.Namespace{
+					ID: 1,
+				},
+				&request.ListArtifactsRequest{
+					RunID:      "id",
+					Recursive:  true,
+					MaxResults: 2,
+					PageToken:  tt.pageToken,
+				},
+			)
+
+			require.Nil(t, err)
+			assert.Equal(t, tt.artifacts, artifacts)
+			assert.Equal(t, tt.nextOffset, nextOffset)
+		})
+	}
+}
+
 func TestService_ListArtifacts_Error(t *testing.T) {
 	testData := []struct {
 		name    string
@@ -95,7 +189,9 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 			request: &request.ListArtifactsRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -109,7 +205,41 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockArtifactUploadRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "InvalidMaxResults",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied."),
+			request: &request.ListArtifactsRequest{
+				RunID:      "id",
+				MaxResults: MaxResultsPerPage + 1,
+			},
+			service: func() *Service {
+				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockArtifactUploadRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "InvalidPageToken",
+			error: api.NewInvalidParameterValueError("invalid page_token 'invalid'"),
+			request: &request.ListArtifactsRequest{
+				RunID:     "id",
+				PageToken: "invalid",
+			},
+			service: func() *Service {
+				return NewService(
+					&config.Config{},
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -129,7 +259,9 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 					"id",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -143,7 +275,7 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 			service: func() *Service {
 				artifactStorage := storage.MockArtifactStorageProvider{}
 				artifactStorage.On(
-					"List", context.TODO(), "/artifact/uri", "",
+					"List", context.TODO(), "/artifact/uri", "", false,
 				).Return(
 					nil, errors.New("storage error"),
 				)
@@ -164,7 +296,9 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 					ArtifactURI: "/artifact/uri",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&artifactStorageFactory,
 				)
 			},
@@ -174,7 +308,7 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
 			// call service under testing.
-			_, _, err := tt.service().ListArtifacts(context.TODO(), &models.Namespace{
+			_, _, _, err := tt.service().ListArtifacts(context.TODO(), &models.Namespace{
 				ID: 1,
 			}, tt.request)
 			assert.Equal(t, tt.error, err)
@@ -182,8 +316,87 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 	}
 }
 
+func TestService_GetArtifactStats_Ok(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"List", context.TODO(), "/artifact/uri", "", true,
+	).Return(
+		[]storage.ArtifactObject{
+			{
+				Path: "path1",
+				Size: 10,
+			},
+			{
+				Path:  "path2",
+				IsDir: true,
+			},
+			{
+				Path: "path2/path3",
+				Size: 20,
+			},
+		}, nil,
+	).Once()
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "/artifact/uri",
+	).Return(&artifactStorage, nil)
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{},
+		&repositories.MockRunRepositoryProvider{},
+		&repositories.MockArtifactUploadRepositoryProvider{},
+		&artifactStorageFactory,
+	)
+	stats, err := service.GetArtifactStats(context.TODO(), "/artifact/uri")
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, &ArtifactStats{Size: 30, Count: 2}, stats)
+
+	// second call should be served from the cache.
+	stats, err = service.GetArtifactStats(context.TODO(), "/artifact/uri")
+	require.Nil(t, err)
+	assert.Equal(t, &ArtifactStats{Size: 30, Count: 2}, stats)
+	artifactStorage.AssertNumberOfCalls(t, "List", 1)
+}
+
+func TestService_GetArtifactStats_Error(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"List", context.TODO(), "/artifact/uri", "", true,
+	).Return(nil, errors.New("storage error"))
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "/artifact/uri",
+	).Return(&artifactStorage, nil)
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{},
+		&repositories.MockRunRepositoryProvider{},
+		&repositories.MockArtifactUploadRepositoryProvider{},
+		&artifactStorageFactory,
+	)
+	_, err := service.GetArtifactStats(context.TODO(), "/artifact/uri")
+
+	// compare results.
+	assert.Equal(
+		t,
+		api.NewInternalError("error getting artifact list from storage for URI '/artifact/uri'"),
+		err,
+	)
+}
+
 func TestService_GetArtifact_Ok(t *testing.T) {
 	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"Stat", context.TODO(), "/artifact/uri", "",
+	).Return(
+		&storage.ArtifactObject{ETag: "etag", Size: 7}, nil,
+	)
 	artifactStorage.On(
 		"Get", context.TODO(), "/artifact/uri", "",
 	).Return(
@@ -208,22 +421,161 @@ func TestService_GetArtifact_Ok(t *testing.T) {
 	}, nil)
 
 	// call service under testing.
-	service := NewService(&runRepository, &artifactStorageFactory)
-	data, err := service.GetArtifact(
+	service := NewService(
+		&config.Config{},
+		&runRepository,
+		&repositories.MockArtifactUploadRepositoryProvider{},
+		&artifactStorageFactory,
+	)
+	object, data, err := service.GetArtifact(
+		context.TODO(),
+		&models.Namespace{
+			ID: 1,
+		},
+		&request.GetArtifactRequest{
+			RunID:       "id",
+			IfNoneMatch: `"other-etag"`,
+		},
+	)
+
+	require.Nil(t, err)
+	assert.Equal(t, `"etag"`, object.GetETag())
+	result := new(bytes.Buffer)
+	_, err = result.ReadFrom(data)
+	require.Nil(t, err)
+	assert.Equal(t, "content", result.String())
+}
+
+func TestService_GetArtifact_Fallback(t *testing.T) {
+	primaryStorage := storage.MockArtifactStorageProvider{}
+	primaryStorage.On(
+		"Stat", context.TODO(), "/old/1/id/artifacts", "file",
+	).Return(
+		nil, fs.ErrNotExist,
+	)
+	secondaryStorage := storage.MockArtifactStorageProvider{}
+	secondaryStorage.On(
+		"Stat", context.TODO(), "s3://new/1/id/artifacts", "file",
+	).Return(
+		&storage.ArtifactObject{Path: "file", Size: 7}, nil,
+	)
+	secondaryStorage.On(
+		"Get", context.TODO(), "s3://new/1/id/artifacts", "file",
+	).Return(
+		io.NopCloser(strings.NewReader("content")), nil,
+	)
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "/old/1/id/artifacts",
+	).Return(&primaryStorage, nil)
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "s3://new/1/id/artifacts",
+	).Return(&secondaryStorage, nil)
+
+	// init repository mocks.
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetByNamespaceIDAndRunID",
+		context.TODO(),
+		uint(1),
+		"id",
+	).Return(&models.Run{
+		ID:          "id",
+		ArtifactURI: "/old/1/id/artifacts",
+	}, nil)
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{ArtifactRootFallbacks: []string{"/old=s3://new"}},
+		&runRepository,
+		&repositories.MockArtifactUploadRepositoryProvider{},
+		&artifactStorageFactory,
+	)
+	object, data, err := service.GetArtifact(
 		context.TODO(),
 		&models.Namespace{
 			ID: 1,
 		},
 		&request.GetArtifactRequest{
 			RunID: "id",
+			Path:  "file",
 		},
 	)
 
 	require.Nil(t, err)
+	assert.Equal(t, int64(7), object.GetSize())
 	result := new(bytes.Buffer)
 	_, err = result.ReadFrom(data)
 	require.Nil(t, err)
 	assert.Equal(t, "content", result.String())
+	primaryStorage.AssertExpectations(t)
+	secondaryStorage.AssertExpectations(t)
+}
+
+func TestService_GetArtifact_NotModified(t *testing.T) {
+	lastModified := time.Date(2024, 1, 1, 10, 0, 0, 500, time.UTC)
+	testData := []struct {
+		name    string
+		request *request.GetArtifactRequest
+	}{
+		{
+			name:    "IfNoneMatchWithSameETag",
+			request: &request.GetArtifactRequest{RunID: "id", IfNoneMatch: `"other", "etag"`},
+		},
+		{
+			name:    "IfNoneMatchWithWeakETag",
+			request: &request.GetArtifactRequest{RunID: "id", IfNoneMatch: `W/"etag"`},
+		},
+		{
+			name:    "IfNoneMatchWithWildcard",
+			request: &request.GetArtifactRequest{RunID: "id", IfNoneMatch: `*`},
+		},
+		{
+			name:    "IfModifiedSinceWithSameTime",
+			request: &request.GetArtifactRequest{RunID: "id", IfModifiedSince: "Mon, 01 Jan 2024 10:00:00 GMT"},
+		},
+		{
+			name:    "IfModifiedSinceWithLaterTime",
+			request: &request.GetArtifactRequest{RunID: "id", IfModifiedSince: "Tue, 02 Jan 2024 10:00:00 GMT"},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactStorage := storage.MockArtifactStorageProvider{}
+			artifactStorage.On(
+				"Stat", context.TODO(), "/artifact/uri", "",
+			).Return(
+				&storage.ArtifactObject{ETag: "etag", LastModified: lastModified}, nil,
+			)
+			artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+			artifactStorageFactory.On(
+				"GetStorage", context.TODO(), "/artifact/uri",
+			).Return(&artifactStorage, nil)
+			runRepository := repositories.MockRunRepositoryProvider{}
+			runRepository.On(
+				"GetByNamespaceIDAndRunID", context.TODO(), uint(1), "id",
+			).Return(&models.Run{
+				ID:          "id",
+				ArtifactURI: "/artifact/uri",
+			}, nil)
+
+			// call service under testing.
+			service := NewService(
+				&config.Config{},
+				&runRepository,
+				&repositories.MockArtifactUploadRepositoryProvider{},
+				&artifactStorageFactory,
+			)
+			object, data, err := service.GetArtifact(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+
+			require.Nil(t, err)
+			assert.Nil(t, data)
+			assert.Equal(t, "etag", object.ETag)
+			artifactStorage.AssertNotCalled(t, "Get", context.TODO(), "/artifact/uri", "")
+		})
+	}
 }
 
 func TestService_GetArtifact_Error(t *testing.T) {
@@ -239,7 +591,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 			request: &request.GetArtifactRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -253,7 +607,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -273,7 +629,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 					"id",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -286,6 +644,11 @@ func TestService_GetArtifact_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				artifactStorage := storage.MockArtifactStorageProvider{}
+				artifactStorage.On(
+					"Stat", context.TODO(), "/artifact/uri", "",
+				).Return(
+					&storage.ArtifactObject{ETag: "etag"}, nil,
+				)
 				artifactStorage.On(
 					"Get", context.TODO(), "/artifact/uri", "",
 				).Return(
@@ -308,7 +671,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 					ArtifactURI: "/artifact/uri",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&artifactStorageFactory,
 				)
 			},
@@ -343,7 +708,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 					ArtifactURI: "/artifact/uri",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockArtifactUploadRepositoryProvider{},
 					&artifactStorageFactory,
 				)
 			},
@@ -353,7 +720,7 @@ func TestService_GetArtifact_Error(t *testing.T) {
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
 			// call service under testing.
-			_, err := tt.service().GetArtifact(context.TODO(), &models.Namespace{
+			_, _, err := tt.service().GetArtifact(context.TODO(), &models.Namespace{
 				ID: 1,
 			}, tt.request)
 			assert.Equal(t, tt.error, err)
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+)
+
+// CopyArtifacts copies all the artifacts under sourceURI to targetURI and returns the number of copied files.
+// Artifacts are copied through uploads, so source and target could be stored in different storages.
+func CopyArtifacts(
+	ctx context.Context, artifactStorageFactory ArtifactStorageFactoryProvider, sourceURI, targetURI string,
+) (int64, error) {
+	sourceStorage, err := artifactStorageFactory.GetStorage(ctx, sourceURI)
+	if err != nil {
+		return 0, eris.Wrap(err, "error getting source artifact storage")
+	}
+	targetStorage, err := artifactStorageFactory.GetStorage(ctx, targetURI)
+	if err != nil {
+		return 0, eris.Wrap(err, "error getting target artifact storage")
+	}
+
+	objects, err := sourceStorage.List(ctx, sourceURI, "", true)
+	if err != nil {
+		return 0, eris.Wrap(err, "error listing artifacts")
+	}
+	var copied int64
+	for _, object := range objects {
+		if object.IsDirectory() {
+			continue
+		}
+		if err := copyArtifact(ctx, sourceStorage, targetStorage, sourceURI, targetURI, object.GetPath()); err != nil {
+			return 0, eris.Wrapf(err, "error copying artifact: %s", object.GetPath())
+		}
+		copied++
+	}
+	return copied, nil
+}
+
+// copyArtifact copies the artifact with path from the source storage to the target one as one part upload.
+func copyArtifact(
+	ctx context.Context,
+	sourceStorage, targetStorage ArtifactStorageProvider,
+	sourceURI, targetURI, path string,
+) error {
+	reader, err := sourceStorage.Get(ctx, sourceURI, path)
+	if err != nil {
+		return eris.Wrap(err, "error reading artifact")
+	}
+	//nolint:errcheck
+	defer reader.Close()
+
+	uploadID, err := targetStorage.CreateUpload(ctx, targetURI, path)
+	if err != nil {
+		return eris.Wrap(err, "error creating artifact upload")
+	}
+	if err := targetStorage.UploadPart(ctx, targetURI, path, uploadID, 1, reader); err != nil {
+		//nolint:errcheck
+		targetStorage.AbortUpload(ctx, targetURI, path, uploadID)
+		return eris.Wrap(err, "error uploading artifact")
+	}
+	if err := targetStorage.CompleteUpload(ctx, targetURI, path, uploadID, 1); err != nil {
+		//nolint:errcheck
+		targetStorage.AbortUpload(ctx, targetURI, path, uploadID)
+		return eris.Wrap(err, "error completing artifact upload")
+	}
+	return nil
+}
@@ -2,12 +2,16 @@ package storage
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"path/filepath"
+	"strings"
 
 	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
 	"github.com/rotisserie/eris"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
@@ -20,6 +24,13 @@ const (
 	GSStorageName = "gs"
 )
 
+const (
+	// gsUploadsPrefix is a prefix of the bucket, where parts of the chunked uploads are stored.
+	gsUploadsPrefix = ".fasttrackml-uploads"
+	// gsMaxComposeSources is a maximum number of source objects of the single compose request.
+	gsMaxComposeSources = 32
+)
+
 // GS represents adapter to work with GS storage artifacts.
 type GS struct {
 	client *storage.Client
@@ -27,8 +38,16 @@ type GS struct {
 
 // NewGS creates new Google Storage instance.
 func NewGS(ctx context.Context, config *config.Config) (*GS, error) {
+	// without explicit credentials the GCS SDK uses Application Default Credentials,
+	// which covers GKE workload identity, metadata server and `GOOGLE_APPLICATION_CREDENTIALS`.
 	var options []option.ClientOption
-	if config.GSEndpointURI != "" {
+	switch {
+	case config.GSCredentialsFile != "":
+		options = append(options, option.WithCredentialsFile(config.GSCredentialsFile))
+		if config.GSEndpointURI != "" {
+			options = append(options, option.WithEndpoint(config.GSEndpointURI))
+		}
+	case config.GSEndpointURI != "":
 		// we use option.WithoutAuthentication() in order to make the GCS SDK work with our fake server.
 		// this should be changed if we ever need to use an alternative GCS implementation in a production setting.
 		options = append(options, option.WithEndpoint(config.GSEndpointURI), option.WithoutAuthentication())
@@ -43,7 +62,7 @@ func NewGS(ctx context.Context, config *config.Config) (*GS, error) {
 }
 
 // List implements ArtifactStorageProvider interface.
-func (s GS) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+func (s GS) List(ctx context.Context, artifactURI, path string, recursive bool) ([]ArtifactObject, error) {
 	// 1. process input parameters.
 	bucket, rootPrefix, err := ExtractBucketAndPrefix(artifactURI)
 	if err != nil {
@@ -55,11 +74,15 @@ func (s GS) List(ctx context.Context, artifactURI, path string) ([]ArtifactObjec
 	}
 
 	// 2. read data from gs storage.
+	// without delimiter gs returns all the objects under the prefix, which is used for recursive listing.
+	query := storage.Query{
+		Prefix: prefix,
+	}
+	if !recursive {
+		query.Delimiter = "/"
+	}
 	var artifactList []ArtifactObject
-	it := s.client.Bucket(bucket).Objects(ctx, &storage.Query{
-		Prefix:    prefix,
-		Delimiter: "/",
-	})
+	it := s.client.Bucket(bucket).Objects(ctx, &query)
 	for {
 		object, err := it.Next()
 		if errors.Is(err, iterator.Done) {
@@ -80,16 +103,24 @@ func (s GS) List(ctx context.Context, artifactURI, path string) ([]ArtifactObjec
 		}
 
 		// filter current directory from the result set.
-		if relPath == path {
+		if relPath == filepath.Clean(path) {
 			continue
 		}
+		isDir := object.Size == 0
+		if recursive {
+			// all the objects are real files in recursive mode, except directory placeholder objects.
+			isDir = strings.HasSuffix(objectName, "/")
+		}
 		artifactList = append(artifactList, ArtifactObject{
 			Path:  relPath,
 			Size:  object.Size,
-			IsDir: object.Size == 0,
+			IsDir: isDir,
 		})
 	}
 
+	if recursive {
+		artifactList = addParentDirectories(path, artifactList)
+	}
 	return artifactList, nil
 }
 
@@ -112,3 +143,142 @@ func (s GS) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, e
 
 	return reader, nil
 }
+
+// Stat implements ArtifactStorageProvider interface.
+func (s GS) Stat(ctx context.Context, artifactURI, path string) (*ArtifactObject, error) {
+	// 1. create gcp request input.
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return nil, eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	// 2. get object attributes from gcp storage.
+	attrs, err := s.client.Bucket(bucketName).Object(filepath.Join(prefix, path)).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, eris.Wrap(fs.ErrNotExist, "object does not exist")
+		}
+		return nil, eris.Wrap(err, "error getting object attributes")
+	}
+
+	// 3. gcp Etag changes with each object generation, so use content hash instead.
+	// composite objects don't have MD5 hash, so CRC32C is used for them.
+	etag := hex.EncodeToString(attrs.MD5)
+	if len(attrs.MD5) == 0 {
+		etag = fmt.Sprintf("crc32c-%08x-%d", attrs.CRC32C, attrs.Size)
+	}
+	return &ArtifactObject{
+		Path:         path,
+		Size:         attrs.Size,
+		ETag:         etag,
+		LastModified: attrs.Updated,
+		ContentType:  attrs.ContentType,
+	}, nil
+}
+
+// CreateUpload implements ArtifactStorageProvider interface.
+// Parts are stored as temporary objects under `.fasttrackml-uploads/` prefix of the bucket
+// and composed into the artifact object, when the upload is completed.
+func (s GS) CreateUpload(ctx context.Context, artifactURI, path string) (string, error) {
+	if _, _, err := ExtractBucketAndPrefix(artifactURI); err != nil {
+		return "", eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	return strings.ReplaceAll(uuid.New().String(), "-", ""), nil
+}
+
+// UploadPart implements ArtifactStorageProvider interface.
+func (s GS) UploadPart(
+	ctx context.Context, artifactURI, path, uploadID string, partNumber int, body io.Reader,
+) error {
+	bucketName, _, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	writer := s.client.Bucket(bucketName).Object(getGSUploadPartName(uploadID, partNumber)).NewWriter(ctx)
+	if _, err := io.Copy(writer, body); err != nil {
+		//nolint:errcheck,gosec
+		writer.Close()
+		return eris.Wrapf(err, "error writing part %d", partNumber)
+	}
+	if err := writer.Close(); err != nil {
+		return eris.Wrapf(err, "error writing part %d", partNumber)
+	}
+	return nil
+}
+
+// CompleteUpload implements ArtifactStorageProvider interface.
+func (s GS) CompleteUpload(ctx context.Context, artifactURI, path, uploadID string, partCount int) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	bucket := s.client.Bucket(bucketName)
+
+	// 1. check that all the parts exist.
+	parts := make([]*storage.ObjectHandle, 0, partCount)
+	for partNumber := 1; partNumber <= partCount; partNumber++ {
+		part := bucket.Object(getGSUploadPartName(uploadID, partNumber))
+		if _, err := part.Attrs(ctx); err != nil {
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				return eris.Wrapf(ErrUploadPartMissing, "part %d of upload %s", partNumber, uploadID)
+			}
+			return eris.Wrapf(err, "error getting attributes of part %d", partNumber)
+		}
+		parts = append(parts, part)
+	}
+
+	// 2. compose the parts into the artifact object. gs composes at most 32 objects at once,
+	// so the parts are appended to the already composed object in batches.
+	object := bucket.Object(filepath.Join(prefix, path))
+	for i := 0; i < len(parts); {
+		var sources []*storage.ObjectHandle
+		if i > 0 {
+			sources = append(sources, object)
+		}
+		count := min(gsMaxComposeSources-len(sources), len(parts)-i)
+		sources = append(sources, parts[i:i+count]...)
+		if _, err := object.ComposerFrom(sources...).Run(ctx); err != nil {
+			return eris.Wrap(err, "error composing upload parts")
+		}
+		i += count
+	}
+
+	// 3. the parts are not needed anymore.
+	return s.AbortUpload(ctx, artifactURI, path, uploadID)
+}
+
+// AbortUpload implements ArtifactStorageProvider interface.
+func (s GS) AbortUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	bucketName, _, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	bucket := s.client.Bucket(bucketName)
+
+	it := bucket.Objects(ctx, &storage.Query{
+		Prefix: getGSUploadPartName(uploadID, 0),
+	})
+	for {
+		object, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return eris.Wrap(err, "error getting upload part information")
+		}
+		if err := bucket.Object(object.Name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return eris.Wrapf(err, "error deleting upload part %s", object.Name)
+		}
+	}
+	return nil
+}
+
+// getGSUploadPartName returns name of the object, where the part of the upload is stored,
+// or the prefix of all the parts of the upload, if partNumber is 0.
+func getGSUploadPartName(uploadID string, partNumber int) string {
+	if partNumber == 0 {
+		return fmt.Sprintf("%s/%s/", gsUploadsPrefix, uploadID)
+	}
+	return fmt.Sprintf("%s/%s/%d", gsUploadsPrefix, uploadID, partNumber)
+}
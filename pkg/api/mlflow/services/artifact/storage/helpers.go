@@ -2,6 +2,7 @@ package storage
 
 import (
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/rotisserie/eris"
@@ -12,11 +13,41 @@ import (
 // after processing of uri s3://fasttrackml/2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts result will be:
 // - bucket = fasttrackml
 // - prefix = 2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts
+// leading and trailing slashes of the prefix are trimmed, so gs://fasttrackml/prefix/ results in `prefix`.
 func ExtractBucketAndPrefix(uri string) (string, string, error) {
 	u, err := url.Parse(uri)
 	if err != nil {
 		return "", "", eris.Wrapf(err, "error parsing provided uri: %s", u)
 	}
+	if u.Host == "" {
+		return "", "", eris.Errorf("bucket name is missing in provided uri: %s", uri)
+	}
+
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
 
-	return u.Host, strings.TrimLeft(u.Path, "/"), nil
+// addParentDirectories adds directories, which are implied by paths of the objects, but are located
+// under provided path. It is used by object storages, which don't have real directories.
+func addParentDirectories(path string, objects []ArtifactObject) []ArtifactObject {
+	directories := map[string]struct{}{}
+	for _, object := range objects {
+		if object.IsDir {
+			directories[object.Path] = struct{}{}
+		}
+	}
+
+	result := objects
+	for _, object := range objects {
+		for dir := filepath.Dir(object.Path); dir != "." && dir != filepath.Clean(path); dir = filepath.Dir(dir) {
+			if _, ok := directories[dir]; ok {
+				break
+			}
+			directories[dir] = struct{}{}
+			result = append(result, ArtifactObject{
+				Path:  dir,
+				IsDir: true,
+			})
+		}
+	}
+	return result
 }
@@ -14,3 +14,62 @@ func TestExtractS3BucketAndPrefix_Ok(t *testing.T) {
 	assert.Equal(t, "fasttrackml", bucket)
 	assert.Equal(t, "2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts", prefix)
 }
+
+func TestExtractGSBucketAndPrefix_Ok(t *testing.T) {
+	testData := []struct {
+		name   string
+		uri    string
+		bucket string
+		prefix string
+	}{
+		{
+			name:   "WithPrefix",
+			uri:    "gs://fasttrackml/prefix/artifacts",
+			bucket: "fasttrackml",
+			prefix: "prefix/artifacts",
+		},
+		{
+			name:   "WithTrailingSlash",
+			uri:    "gs://fasttrackml/prefix/artifacts/",
+			bucket: "fasttrackml",
+			prefix: "prefix/artifacts",
+		},
+		{
+			name:   "WithoutPrefix",
+			uri:    "gs://fasttrackml/",
+			bucket: "fasttrackml",
+			prefix: "",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, prefix, err := ExtractBucketAndPrefix(tt.uri)
+			require.Nil(t, err)
+			assert.Equal(t, tt.bucket, bucket)
+			assert.Equal(t, tt.prefix, prefix)
+		})
+	}
+}
+
+func TestExtractBucketAndPrefix_Error(t *testing.T) {
+	_, _, err := ExtractBucketAndPrefix("gs:///prefix")
+	assert.EqualError(t, err, "bucket name is missing in provided uri: gs:///prefix")
+}
+
+func TestAddParentDirectories_Ok(t *testing.T) {
+	objects := addParentDirectories("root", []ArtifactObject{
+		{Path: "root/file", Size: 1},
+		{Path: "root/dir1/dir2/file", Size: 2},
+		{Path: "root/dir1/file", Size: 3},
+		{Path: "root/dir3", IsDir: true},
+	})
+	assert.Equal(t, []ArtifactObject{
+		{Path: "root/file", Size: 1},
+		{Path: "root/dir1/dir2/file", Size: 2},
+		{Path: "root/dir1/file", Size: 3},
+		{Path: "root/dir3", IsDir: true},
+		{Path: "root/dir1/dir2", IsDir: true},
+		{Path: "root/dir1", IsDir: true},
+	}, objects)
+}
@@ -2,13 +2,19 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
 
@@ -20,23 +26,48 @@ const (
 	LocalStorageName = "file"
 )
 
+// localUploadsDirName is a name of the directory under the system temporary directory,
+// where parts of the chunked uploads are kept until the upload is completed or aborted.
+const localUploadsDirName = "fasttrackml-uploads"
+
+// localETag represents cached ETag of the local file, which is valid until file is modified.
+type localETag struct {
+	value        string
+	size         int64
+	lastModified time.Time
+}
+
 // Local represents local file storage adapter to work with artifacts.
-type Local struct{}
+type Local struct {
+	etags *lru.Cache[string, localETag]
+}
 
 // NewLocal creates new Local storage instance.
 func NewLocal(config *config.Config) (*Local, error) {
-	return &Local{}, nil
+	etags, err := lru.New[string, localETag](1000)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating lru cache for local artifact etags")
+	}
+	// sessions of the chunked uploads are kept in memory, so uploads started before the restart can't be
+	// completed anymore. their parts are removed once they haven't been touched for the session ttl.
+	removeStaleLocalUploads(config.GetArtifactUploadSessionTTL())
+	return &Local{
+		etags: etags,
+	}, nil
 }
 
 // List implements ArtifactStorageProvider interface.
-func (s Local) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
-	// 1. trim the `file://` prefix if it exists.
-	artifactURI = strings.TrimPrefix(artifactURI, "file://")
-
-	// 2. process search `path` parameter.
-	absPath := filepath.Join(artifactURI, path)
+func (s Local) List(ctx context.Context, artifactURI, path string, recursive bool) ([]ArtifactObject, error) {
+	// 1. resolve search `path` parameter inside of the artifact root.
+	absPath, err := resolveLocalPath(artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
 
-	// 3. read data from local storage.
+	// 2. read data from local storage.
+	if recursive {
+		return s.listRecursive(absPath, path)
+	}
 	objects, err := os.ReadDir(absPath)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
@@ -46,37 +77,82 @@ func (s Local) List(ctx context.Context, artifactURI, path string) ([]ArtifactOb
 	}
 
 	log.Debugf("got %d objects from local storage for path %q", len(objects), absPath)
-	artifactList := make([]ArtifactObject, len(objects))
-	for i, object := range objects {
-		info, err := object.Info()
+	artifactList := make([]ArtifactObject, 0, len(objects))
+	for _, object := range objects {
+		artifact, err := newLocalArtifactObject(filepath.Join(path, object.Name()), object)
 		if err != nil {
 			if errors.Is(err, fs.ErrNotExist) {
 				// file has been removed since we read the directory
 				continue
 			}
-			return nil, eris.Wrapf(err, "error getting info for object: %s", object.Name())
+			return nil, err
 		}
-		artifactList[i] = ArtifactObject{
-			Path:  filepath.Join(path, info.Name()),
-			IsDir: object.IsDir(),
+		artifactList = append(artifactList, *artifact)
+	}
+
+	return artifactList, nil
+}
+
+// listRecursive lists all the objects in the subtree of absPath.
+func (s Local) listRecursive(absPath, path string) ([]ArtifactObject, error) {
+	artifactList := []ArtifactObject{}
+	if err := filepath.WalkDir(absPath, func(objectPath string, object fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// file has been removed since we read the directory
+				return nil
+			}
+			return eris.Wrapf(err, "error reading object from local storage")
 		}
-		if !object.IsDir() {
-			artifactList[i].Size = info.Size()
+		if objectPath == absPath {
+			return nil
 		}
+		relPath, err := filepath.Rel(absPath, objectPath)
+		if err != nil {
+			return eris.Wrapf(err, "error getting relative path for object: %s", objectPath)
+		}
+		artifact, err := newLocalArtifactObject(filepath.Join(path, relPath), object)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		artifactList = append(artifactList, *artifact)
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
+	log.Debugf("got %d objects from local storage for path %q recursively", len(artifactList), absPath)
 	return artifactList, nil
 }
 
+// newLocalArtifactObject creates ArtifactObject from the directory entry of local storage.
+func newLocalArtifactObject(path string, object fs.DirEntry) (*ArtifactObject, error) {
+	info, err := object.Info()
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting info for object: %s", object.Name())
+	}
+	artifact := ArtifactObject{
+		Path:  path,
+		IsDir: object.IsDir(),
+	}
+	if !object.IsDir() {
+		artifact.Size = info.Size()
+	}
+	return &artifact, nil
+}
+
 // Get returns actual file content at the storage location.
 func (s Local) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
-	// 1. trim the `file://` prefix if it exists.
-	artifactURI = strings.TrimPrefix(artifactURI, "file://")
-
-	// 2. process `path` parameter.
-	absPath := filepath.Join(artifactURI, path)
+	// 1. resolve `path` parameter inside of the artifact root.
+	absPath, err := resolveLocalPath(artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
 
-	// 3. check that the file exists and is not a directory.
+	// 2. check that the file exists and is not a directory.
 	fileInfo, err := os.Stat(absPath)
 	if err != nil {
 		return nil, eris.Wrap(err, "path could not be opened")
@@ -85,8 +161,8 @@ func (s Local) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser
 		return nil, eris.Wrap(fs.ErrNotExist, "path is a directory")
 	}
 
-	// 4. open the file.
-	// artifactURI and path are validated by the caller
+	// 3. open the file.
+	// path is resolved inside of the artifact root by resolveLocalPath
 	// #nosec G304
 	file, err := os.Open(absPath)
 	if err != nil {
@@ -95,3 +171,283 @@ func (s Local) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser
 
 	return file, nil
 }
+
+// Stat implements ArtifactStorageProvider interface.
+// ETag is a SHA-256 hash of the file content, so it stays the same for identical uploads.
+func (s Local) Stat(ctx context.Context, artifactURI, path string) (*ArtifactObject, error) {
+	// 1. resolve `path` parameter inside of the artifact root.
+	absPath, err := resolveLocalPath(artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. check that the file exists and is not a directory.
+	fileInfo, err := os.Stat(absPath)
+	if err != nil {
+		return nil, eris.Wrap(err, "path could not be opened")
+	}
+	if fileInfo.IsDir() {
+		return nil, eris.Wrap(fs.ErrNotExist, "path is a directory")
+	}
+
+	// 3. calculate content hash, unless file hasn't been changed since the last calculation.
+	etag, ok := s.etags.Get(absPath)
+	if !ok || etag.size != fileInfo.Size() || !etag.lastModified.Equal(fileInfo.ModTime()) {
+		// path is resolved inside of the artifact root by resolveLocalPath
+		// #nosec G304
+		file, err := os.Open(absPath)
+		if err != nil {
+			return nil, eris.Wrap(err, "unable to open file")
+		}
+		//nolint:errcheck
+		defer file.Close()
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			return nil, eris.Wrap(err, "error calculating file hash")
+		}
+		etag = localETag{
+			value:        hex.EncodeToString(hash.Sum(nil)),
+			size:         fileInfo.Size(),
+			lastModified: fileInfo.ModTime(),
+		}
+		s.etags.Add(absPath, etag)
+	}
+
+	return &ArtifactObject{
+		Path:         path,
+		Size:         fileInfo.Size(),
+		ETag:         etag.value,
+		LastModified: fileInfo.ModTime(),
+	}, nil
+}
+
+// CreateUpload implements ArtifactStorageProvider interface.
+// Parts are kept in the temporary directory and appended to the artifact file, when the upload is completed.
+func (s Local) CreateUpload(ctx context.Context, artifactURI, path string) (string, error) {
+	if _, err := resolveLocalPath(artifactURI, path); err != nil {
+		return "", err
+	}
+
+	uploadID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	uploadDir, err := getLocalUploadDir(uploadID)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(uploadDir, 0o750); err != nil {
+		return "", eris.Wrapf(err, "error creating directory for upload %s", uploadID)
+	}
+	return uploadID, nil
+}
+
+// UploadPart implements ArtifactStorageProvider interface.
+func (s Local) UploadPart(
+	ctx context.Context, artifactURI, path, uploadID string, partNumber int, body io.Reader,
+) error {
+	// 1. check that the upload exists.
+	uploadDir, err := getLocalUploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(uploadDir); err != nil {
+		return eris.Wrapf(err, "upload %s could not be opened", uploadID)
+	}
+
+	// 2. write the part into the temporary file first, so incomplete part is never assembled.
+	file, err := os.CreateTemp(uploadDir, "part-*")
+	if err != nil {
+		return eris.Wrapf(err, "error creating file for part %d of upload %s", partNumber, uploadID)
+	}
+	//nolint:errcheck
+	defer os.Remove(file.Name())
+	if _, err := io.Copy(file, body); err != nil {
+		//nolint:errcheck,gosec
+		file.Close()
+		return eris.Wrapf(err, "error writing part %d of upload %s", partNumber, uploadID)
+	}
+	if err := file.Close(); err != nil {
+		return eris.Wrapf(err, "error writing part %d of upload %s", partNumber, uploadID)
+	}
+	if err := os.Rename(file.Name(), filepath.Join(uploadDir, strconv.Itoa(partNumber))); err != nil {
+		return eris.Wrapf(err, "error storing part %d of upload %s", partNumber, uploadID)
+	}
+	return nil
+}
+
+// CompleteUpload implements ArtifactStorageProvider interface.
+func (s Local) CompleteUpload(ctx context.Context, artifactURI, path, uploadID string, partCount int) error {
+	// 1. resolve `path` parameter inside of the artifact root and check that all the parts exist.
+	absPath, err := resolveLocalPath(artifactURI, path)
+	if err != nil {
+		return err
+	}
+	uploadDir, err := getLocalUploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(uploadDir); err != nil {
+		return eris.Wrapf(err, "upload %s could not be opened", uploadID)
+	}
+	for partNumber := 1; partNumber <= partCount; partNumber++ {
+		if _, err := os.Stat(filepath.Join(uploadDir, strconv.Itoa(partNumber))); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return eris.Wrapf(ErrUploadPartMissing, "part %d of upload %s", partNumber, uploadID)
+			}
+			return eris.Wrapf(err, "part %d of upload %s could not be opened", partNumber, uploadID)
+		}
+	}
+
+	// 2. append the parts to the temporary file next to the artifact, so partial artifact is never visible.
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o750); err != nil {
+		return eris.Wrapf(err, "error creating directory for artifact %s", path)
+	}
+	file, err := os.CreateTemp(filepath.Dir(absPath), "."+filepath.Base(absPath)+".*")
+	if err != nil {
+		return eris.Wrapf(err, "error creating file for artifact %s", path)
+	}
+	//nolint:errcheck
+	defer os.Remove(file.Name())
+	for partNumber := 1; partNumber <= partCount; partNumber++ {
+		if err := appendLocalFile(file, filepath.Join(uploadDir, strconv.Itoa(partNumber))); err != nil {
+			//nolint:errcheck,gosec
+			file.Close()
+			return eris.Wrapf(err, "error appending part %d of upload %s", partNumber, uploadID)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return eris.Wrapf(err, "error writing artifact %s", path)
+	}
+	if err := os.Rename(file.Name(), absPath); err != nil {
+		return eris.Wrapf(err, "error storing artifact %s", path)
+	}
+
+	// 3. the upload is not needed anymore.
+	if err := os.RemoveAll(uploadDir); err != nil {
+		return eris.Wrapf(err, "error removing upload %s", uploadID)
+	}
+	return nil
+}
+
+// AbortUpload implements ArtifactStorageProvider interface.
+func (s Local) AbortUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	uploadDir, err := getLocalUploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(uploadDir); err != nil {
+		return eris.Wrapf(err, "error removing upload %s", uploadID)
+	}
+	return nil
+}
+
+// getLocalUploadDir returns the directory, where parts of the upload are kept.
+func getLocalUploadDir(uploadID string) (string, error) {
+	if uploadID == "" || strings.ContainsAny(uploadID, `./\`) {
+		return "", eris.Wrapf(fs.ErrNotExist, "invalid upload id %q", uploadID)
+	}
+	return filepath.Join(os.TempDir(), localUploadsDirName, uploadID), nil
+}
+
+// removeStaleLocalUploads removes the directories of the uploads, which haven't got new parts during the ttl.
+// Errors are only logged, because stale uploads don't prevent the storage from working.
+func removeStaleLocalUploads(ttl time.Duration) {
+	uploadsDir := filepath.Join(os.TempDir(), localUploadsDirName)
+	entries, err := os.ReadDir(uploadsDir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Warnf("error reading directory of artifact uploads %s: %s", uploadsDir, err)
+		}
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !entry.IsDir() || time.Since(info.ModTime()) < ttl {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(uploadsDir, entry.Name())); err != nil {
+			log.Warnf("error removing stale artifact upload %s: %s", entry.Name(), err)
+		}
+	}
+}
+
+// appendLocalFile appends content of the file located at path to the provided file.
+func appendLocalFile(file *os.File, path string) error {
+	// path is located inside of the upload directory by getLocalUploadDir
+	// #nosec G304
+	part, err := os.Open(path)
+	if err != nil {
+		return eris.Wrap(err, "unable to open file")
+	}
+	//nolint:errcheck
+	defer part.Close()
+	if _, err := io.Copy(file, part); err != nil {
+		return eris.Wrap(err, "error copying file")
+	}
+	return nil
+}
+
+// resolveLocalPath resolves provided artifact path to the absolute path and confirms that it stays
+// inside of the artifact root, including the case when the path goes through symbolic links.
+// ErrPathOutsideRoot is returned for absolute paths and for paths escaping the artifact root.
+func resolveLocalPath(artifactURI, path string) (string, error) {
+	// 1. trim the `file://` prefix if it exists.
+	root := filepath.Clean(strings.TrimPrefix(artifactURI, "file://"))
+
+	// 2. check the path lexically.
+	if filepath.IsAbs(path) {
+		return "", eris.Wrapf(ErrPathOutsideRoot, "absolute path %q is not allowed", path)
+	}
+	absPath := filepath.Join(root, path)
+	if !isLocalPathInside(root, absPath) {
+		return "", eris.Wrapf(ErrPathOutsideRoot, "path %q escapes artifact root", path)
+	}
+
+	// 3. check the path with resolved symbolic links.
+	// not existing parts of the paths are going to be created by the caller, so only the deepest existing
+	// ancestors are resolved. otherwise symbolic link in any existing ancestor could lead outside of the root.
+	realRoot, err := evalLocalSymlinks(root)
+	if err != nil {
+		return "", eris.Wrapf(err, "error resolving artifact root %q", root)
+	}
+	realPath, err := evalLocalSymlinks(absPath)
+	if err != nil {
+		return "", eris.Wrapf(err, "error resolving path %q", path)
+	}
+	if !isLocalPathInside(realRoot, realPath) {
+		return "", eris.Wrapf(ErrPathOutsideRoot, "path %q escapes artifact root via symbolic link", path)
+	}
+	return absPath, nil
+}
+
+// evalLocalSymlinks resolves symbolic links of the deepest existing ancestor of the path and appends
+// the not existing rest of the path to it. Dangling symbolic links are reported as ErrPathOutsideRoot,
+// because their target can't be checked, but it would be created through them.
+func evalLocalSymlinks(path string) (string, error) {
+	existing, rest := path, ""
+	for {
+		realPath, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			return filepath.Join(realPath, rest), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		if _, err := os.Lstat(existing); err == nil {
+			return "", eris.Wrapf(ErrPathOutsideRoot, "dangling symbolic link %q", existing)
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return path, nil
+		}
+		existing, rest = parent, filepath.Join(filepath.Base(existing), rest)
+	}
+}
+
+// isLocalPathInside checks that the path is the root itself or is located under the root.
+func isLocalPathInside(root, path string) bool {
+	relPath, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
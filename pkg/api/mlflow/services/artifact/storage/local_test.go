@@ -2,13 +2,18 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 func TestGetArtifact_Ok(t *testing.T) {
@@ -24,7 +29,7 @@ func TestGetArtifact_Ok(t *testing.T) {
 	require.Nil(t, err)
 
 	// invoke
-	storage, err := NewLocal(nil)
+	storage, err := NewLocal(&config.Config{})
 	require.Nil(t, err)
 
 	file, err := storage.Get(context.Background(), runArtifactRoot, fileName)
@@ -49,7 +54,7 @@ func TestGetArtifact_Error(t *testing.T) {
 	require.Nil(t, err)
 
 	// invoke
-	storage, err := NewLocal(nil)
+	storage, err := NewLocal(&config.Config{})
 	require.Nil(t, err)
 
 	file, err := storage.Get(context.Background(), runArtifactRoot, "non-existent-file")
@@ -66,6 +71,58 @@ func TestGetArtifact_Error(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestLocal_Stat_Ok(t *testing.T) {
+	// setup two identical uploads and one different.
+	runArtifactRoot := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(runArtifactRoot, "file1.txt"), []byte("content"), 0o600))
+	require.Nil(t, os.WriteFile(filepath.Join(runArtifactRoot, "file2.txt"), []byte("content"), 0o600))
+	require.Nil(t, os.WriteFile(filepath.Join(runArtifactRoot, "file3.txt"), []byte("other"), 0o600))
+
+	// invoke
+	storage, err := NewLocal(&config.Config{})
+	require.Nil(t, err)
+
+	object1, err := storage.Stat(context.Background(), "file://"+runArtifactRoot, "file1.txt")
+	require.Nil(t, err)
+	object2, err := storage.Stat(context.Background(), runArtifactRoot, "file2.txt")
+	require.Nil(t, err)
+	object3, err := storage.Stat(context.Background(), runArtifactRoot, "file3.txt")
+	require.Nil(t, err)
+
+	// verify
+	assert.Equal(t, "file1.txt", object1.Path)
+	assert.Equal(t, int64(7), object1.Size)
+	assert.False(t, object1.LastModified.IsZero())
+	assert.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", object1.ETag)
+	assert.Equal(t, object1.ETag, object2.ETag)
+	assert.NotEqual(t, object1.ETag, object3.ETag)
+
+	// verify that cached ETag is recalculated after file modification.
+	require.Nil(t, os.WriteFile(filepath.Join(runArtifactRoot, "file1.txt"), []byte("other"), 0o600))
+	object1, err = storage.Stat(context.Background(), runArtifactRoot, "file1.txt")
+	require.Nil(t, err)
+	assert.Equal(t, object3.ETag, object1.ETag)
+}
+
+func TestLocal_Stat_Error(t *testing.T) {
+	// setup
+	runArtifactRoot := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(runArtifactRoot, "subdir"), os.ModePerm))
+
+	// invoke
+	storage, err := NewLocal(&config.Config{})
+	require.Nil(t, err)
+
+	// verify
+	object, err := storage.Stat(context.Background(), runArtifactRoot, "non-existent-file")
+	assert.Nil(t, object)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	object, err = storage.Stat(context.Background(), runArtifactRoot, "subdir")
+	assert.Nil(t, object)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
 func TestLocal_ListArtifacts_Ok(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -95,11 +152,11 @@ func TestLocal_ListArtifacts_Ok(t *testing.T) {
 			require.Nil(t, err)
 
 			// 2. create storage.
-			storage, err := NewLocal(nil)
+			storage, err := NewLocal(&config.Config{})
 			require.Nil(t, err)
 
 			// 3. list artifacts for root dir.
-			rootDirResp, err := storage.List(context.Background(), runArtifactURI, "")
+			rootDirResp, err := storage.List(context.Background(), runArtifactURI, "", false)
 			assert.Equal(t, 2, len(rootDirResp))
 			assert.Equal(t, []ArtifactObject{
 				{
@@ -116,7 +173,7 @@ func TestLocal_ListArtifacts_Ok(t *testing.T) {
 			require.Nil(t, err)
 
 			// 4. list artifacts for sub dir.
-			subDirResp, err := storage.List(context.Background(), runArtifactURI, "artifact.dir")
+			subDirResp, err := storage.List(context.Background(), runArtifactURI, "artifact.dir", false)
 			assert.Equal(t, 1, len(subDirResp))
 			assert.Equal(t, ArtifactObject{
 				Path:  "artifact.dir/artifact.file2",
@@ -126,9 +183,272 @@ func TestLocal_ListArtifacts_Ok(t *testing.T) {
 			require.Nil(t, err)
 
 			// 5. list artifacts for non-existing dir.
-			nonExistingDirResp, err := storage.List(context.Background(), runArtifactURI, "non-existing-dir")
+			nonExistingDirResp, err := storage.List(context.Background(), runArtifactURI, "non-existing-dir", false)
 			assert.Equal(t, 0, len(nonExistingDirResp))
 			require.Nil(t, err)
+
+			// 6. list artifacts for root dir recursively.
+			err = os.Mkdir(filepath.Join(runArtifactDir, "artifact.dir", "artifact.subdir"), fs.ModePerm)
+			require.Nil(t, err)
+			err = os.WriteFile(
+				filepath.Join(runArtifactDir, "artifact.dir", "artifact.subdir", "artifact.file3"), []byte("c"), fs.ModePerm,
+			)
+			require.Nil(t, err)
+			recursiveResp, err := storage.List(context.Background(), runArtifactURI, "", true)
+			require.Nil(t, err)
+			assert.ElementsMatch(t, []ArtifactObject{
+				{
+					Path:  "artifact.dir",
+					IsDir: true,
+				},
+				{
+					Path: "artifact.dir/artifact.file2",
+					Size: 9,
+				},
+				{
+					Path:  "artifact.dir/artifact.subdir",
+					IsDir: true,
+				},
+				{
+					Path: "artifact.dir/artifact.subdir/artifact.file3",
+					Size: 1,
+				},
+				{
+					Path: "artifact.file1",
+					Size: 8,
+				},
+			}, recursiveResp)
+
+			// 7. list artifacts for sub dir recursively.
+			recursiveSubDirResp, err := storage.List(context.Background(), runArtifactURI, "artifact.dir", true)
+			require.Nil(t, err)
+			assert.ElementsMatch(t, []ArtifactObject{
+				{
+					Path: "artifact.dir/artifact.file2",
+					Size: 9,
+				},
+				{
+					Path:  "artifact.dir/artifact.subdir",
+					IsDir: true,
+				},
+				{
+					Path: "artifact.dir/artifact.subdir/artifact.file3",
+					Size: 1,
+				},
+			}, recursiveSubDirResp)
+
+			// 8. list artifacts for non-existing dir recursively.
+			nonExistingDirResp, err = storage.List(context.Background(), runArtifactURI, "non-existing-dir", true)
+			require.Nil(t, err)
+			assert.Equal(t, 0, len(nonExistingDirResp))
 		})
 	}
 }
+
+func TestLocal_PathTraversal_Error(t *testing.T) {
+	// 1. create artifact root with symbolic links escaping the root and outside secret file.
+	baseDir := t.TempDir()
+	runArtifactRoot := filepath.Join(baseDir, "artifacts")
+	require.Nil(t, os.MkdirAll(filepath.Join(runArtifactRoot, "dir"), fs.ModePerm))
+	require.Nil(t, os.WriteFile(filepath.Join(runArtifactRoot, "dir", "file.txt"), []byte("content"), fs.ModePerm))
+	require.Nil(t, os.WriteFile(filepath.Join(baseDir, "secret.txt"), []byte("secret"), fs.ModePerm))
+	require.Nil(t, os.Symlink(filepath.Join(baseDir, "secret.txt"), filepath.Join(runArtifactRoot, "file-link")))
+	require.Nil(t, os.Symlink(baseDir, filepath.Join(runArtifactRoot, "dir-link")))
+	require.Nil(t, os.Symlink(filepath.Join("..", "..", "secret.txt"), filepath.Join(runArtifactRoot, "dir", "rel-link")))
+
+	storage, err := NewLocal(&config.Config{})
+	require.Nil(t, err)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "ParentDirectory",
+			path: "..",
+		},
+		{
+			name: "ParentDirectorySegments",
+			path: "dir/../../secret.txt",
+		},
+		{
+			name: "AbsolutePath",
+			path: filepath.Join(baseDir, "secret.txt"),
+		},
+		{
+			name: "FileSymlinkEscape",
+			path: "file-link",
+		},
+		{
+			name: "DirectorySymlinkEscape",
+			path: "dir-link/secret.txt",
+		},
+		{
+			name: "RelativeSymlinkEscape",
+			path: "dir/rel-link",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := storage.Get(context.Background(), runArtifactRoot, tt.path)
+			assert.ErrorIs(t, err, ErrPathOutsideRoot)
+			_, err = storage.Stat(context.Background(), runArtifactRoot, tt.path)
+			assert.ErrorIs(t, err, ErrPathOutsideRoot)
+			_, err = storage.List(context.Background(), runArtifactRoot, tt.path, false)
+			assert.ErrorIs(t, err, ErrPathOutsideRoot)
+		})
+	}
+
+	// 2. symbolic links inside of the artifact root are still allowed.
+	require.Nil(t, os.Symlink(filepath.Join(runArtifactRoot, "dir"), filepath.Join(runArtifactRoot, "inner-link")))
+	reader, err := storage.Get(context.Background(), "file://"+runArtifactRoot, "inner-link/file.txt")
+	require.Nil(t, err)
+	require.Nil(t, reader.Close())
+}
+
+func TestLocal_PathTraversal_NotExistingPath_Error(t *testing.T) {
+	// create artifact root with symbolic links to the directory outside of the root.
+	baseDir := t.TempDir()
+	runArtifactRoot := filepath.Join(baseDir, "artifacts")
+	require.Nil(t, os.MkdirAll(runArtifactRoot, fs.ModePerm))
+	require.Nil(t, os.Symlink(baseDir, filepath.Join(runArtifactRoot, "dir-link")))
+	require.Nil(t, os.Symlink(filepath.Join(baseDir, "missing"), filepath.Join(runArtifactRoot, "dangling-link")))
+
+	// paths, which don't exist yet, are checked by the symbolic links of their existing ancestors.
+	for _, path := range []string{"dir-link/file.txt", "dir-link/new/file.txt", "dangling-link/file.txt"} {
+		_, err := resolveLocalPath(runArtifactRoot, path)
+		assert.ErrorIs(t, err, ErrPathOutsideRoot)
+	}
+	absPath, err := resolveLocalPath(runArtifactRoot, "new/file.txt")
+	require.Nil(t, err)
+	assert.Equal(t, filepath.Join(runArtifactRoot, "new", "file.txt"), absPath)
+}
+
+func TestLocal_ChunkedUpload_PathTraversal_Error(t *testing.T) {
+	// 1. create artifact root with symbolic links to the directory outside of the root.
+	baseDir := t.TempDir()
+	outsideDir := filepath.Join(baseDir, "outside")
+	runArtifactRoot := filepath.Join(baseDir, "artifacts")
+	require.Nil(t, os.MkdirAll(outsideDir, fs.ModePerm))
+	require.Nil(t, os.MkdirAll(runArtifactRoot, fs.ModePerm))
+	require.Nil(t, os.Symlink(outsideDir, filepath.Join(runArtifactRoot, "dir-link")))
+	require.Nil(t, os.Symlink(filepath.Join(outsideDir, "missing"), filepath.Join(runArtifactRoot, "dangling-link")))
+
+	storage, err := NewLocal(&config.Config{})
+	require.Nil(t, err)
+
+	// 2. uploads can't be started under the existing symbolic links, even if the rest of the path doesn't exist.
+	for _, path := range []string{"dir-link/file.txt", "dir-link/new/file.txt", "dangling-link/file.txt"} {
+		_, err := storage.CreateUpload(context.Background(), runArtifactRoot, path)
+		assert.ErrorIs(t, err, ErrPathOutsideRoot)
+	}
+
+	// 3. upload can't be completed, if the symbolic link has been created after the upload was started.
+	uploadID, err := storage.CreateUpload(context.Background(), runArtifactRoot, "later-link/new/file.txt")
+	require.Nil(t, err)
+	require.Nil(t, storage.UploadPart(
+		context.Background(), runArtifactRoot, "later-link/new/file.txt", uploadID, 1, strings.NewReader("content"),
+	))
+	require.Nil(t, os.Symlink(outsideDir, filepath.Join(runArtifactRoot, "later-link")))
+	err = storage.CompleteUpload(context.Background(), runArtifactRoot, "later-link/new/file.txt", uploadID, 1)
+	assert.ErrorIs(t, err, ErrPathOutsideRoot)
+	require.Nil(t, storage.AbortUpload(context.Background(), runArtifactRoot, "later-link/new/file.txt", uploadID))
+
+	// 4. nothing has been written outside of the artifact root.
+	entries, err := os.ReadDir(outsideDir)
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocal_RemoveStaleUploads_Ok(t *testing.T) {
+	// setup
+	staleUploadDir, err := getLocalUploadDir("stale")
+	require.Nil(t, err)
+	activeUploadDir, err := getLocalUploadDir("active")
+	require.Nil(t, err)
+	for _, dir := range []string{staleUploadDir, activeUploadDir} {
+		require.Nil(t, os.MkdirAll(dir, 0o750))
+		require.Nil(t, os.WriteFile(filepath.Join(dir, "1"), []byte("content"), 0o600))
+	}
+	//nolint:errcheck
+	defer os.RemoveAll(activeUploadDir)
+	staleTime := time.Now().Add(-2 * time.Hour)
+	require.Nil(t, os.Chtimes(staleUploadDir, staleTime, staleTime))
+
+	// invoke
+	_, err = NewLocal(&config.Config{ArtifactUploadSessionTTL: time.Hour})
+	require.Nil(t, err)
+
+	// verify
+	_, err = os.Stat(staleUploadDir)
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+	_, err = os.Stat(activeUploadDir)
+	assert.Nil(t, err)
+}
+
+func TestLocal_ChunkedUpload_Ok(t *testing.T) {
+	// setup
+	runArtifactRoot := t.TempDir()
+	storage, err := NewLocal(&config.Config{})
+	require.Nil(t, err)
+
+	uploadID, err := storage.CreateUpload(context.Background(), runArtifactRoot, "subdir/file.txt")
+	require.Nil(t, err)
+
+	// invoke, parts are uploaded out of order and the last part is uploaded twice.
+	for _, part := range []struct {
+		number  int
+		content string
+	}{
+		{number: 3, content: "wrong"},
+		{number: 1, content: "first "},
+		{number: 3, content: "third"},
+		{number: 2, content: "second "},
+	} {
+		require.Nil(t, storage.UploadPart(
+			context.Background(), runArtifactRoot, "subdir/file.txt", uploadID, part.number, strings.NewReader(part.content),
+		))
+	}
+	err = storage.CompleteUpload(context.Background(), runArtifactRoot, "subdir/file.txt", uploadID, 4)
+	assert.True(t, errors.Is(err, ErrUploadPartMissing))
+	require.Nil(t, storage.CompleteUpload(context.Background(), runArtifactRoot, "subdir/file.txt", uploadID, 3))
+
+	// verify
+	content, err := os.ReadFile(filepath.Join(runArtifactRoot, "subdir", "file.txt"))
+	require.Nil(t, err)
+	assert.Equal(t, "first second third", string(content))
+	uploadDir, err := getLocalUploadDir(uploadID)
+	require.Nil(t, err)
+	_, err = os.Stat(uploadDir)
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestLocal_AbortUpload_Ok(t *testing.T) {
+	// setup
+	runArtifactRoot := t.TempDir()
+	storage, err := NewLocal(&config.Config{})
+	require.Nil(t, err)
+
+	uploadID, err := storage.CreateUpload(context.Background(), runArtifactRoot, "file.txt")
+	require.Nil(t, err)
+	require.Nil(t, storage.UploadPart(
+		context.Background(), runArtifactRoot, "file.txt", uploadID, 1, strings.NewReader("content"),
+	))
+
+	// invoke
+	require.Nil(t, storage.AbortUpload(context.Background(), runArtifactRoot, "file.txt", uploadID))
+
+	// verify
+	uploadDir, err := getLocalUploadDir(uploadID)
+	require.Nil(t, err)
+	_, err = os.Stat(uploadDir)
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+	_, err = os.Stat(filepath.Join(runArtifactRoot, "file.txt"))
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	err = storage.UploadPart(
+		context.Background(), runArtifactRoot, "file.txt", uploadID, 2, strings.NewReader("content"),
+	)
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
@@ -14,6 +14,58 @@ type MockArtifactStorageProvider struct {
 	mock.Mock
 }
 
+// AbortUpload provides a mock function with given fields: ctx, artifactURI, path, uploadID
+func (_m *MockArtifactStorageProvider) AbortUpload(ctx context.Context, artifactURI string, path string, uploadID string) error {
+	ret := _m.Called(ctx, artifactURI, path, uploadID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, artifactURI, path, uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompleteUpload provides a mock function with given fields: ctx, artifactURI, path, uploadID, partCount
+func (_m *MockArtifactStorageProvider) CompleteUpload(ctx context.Context, artifactURI string, path string, uploadID string, partCount int) error {
+	ret := _m.Called(ctx, artifactURI, path, uploadID, partCount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) error); ok {
+		r0 = rf(ctx, artifactURI, path, uploadID, partCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUpload provides a mock function with given fields: ctx, artifactURI, path
+func (_m *MockArtifactStorageProvider) CreateUpload(ctx context.Context, artifactURI string, path string) (string, error) {
+	ret := _m.Called(ctx, artifactURI, path)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, artifactURI, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, artifactURI, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, artifactURI, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, artifactURI, path
 func (_m *MockArtifactStorageProvider) Get(ctx context.Context, artifactURI string, path string) (io.ReadCloser, error) {
 	ret := _m.Called(ctx, artifactURI, path)
@@ -40,20 +92,46 @@ func (_m *MockArtifactStorageProvider) Get(ctx context.Context, artifactURI stri
 	return r0, r1
 }
 
-// List provides a mock function with given fields: ctx, artifactURI, path
-func (_m *MockArtifactStorageProvider) List(ctx context.Context, artifactURI string, path string) ([]ArtifactObject, error) {
-	ret := _m.Called(ctx, artifactURI, path)
+// List provides a mock function with given fields: ctx, artifactURI, path, recursive
+func (_m *MockArtifactStorageProvider) List(ctx context.Context, artifactURI string, path string, recursive bool) ([]ArtifactObject, error) {
+	ret := _m.Called(ctx, artifactURI, path, recursive)
 
 	var r0 []ArtifactObject
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]ArtifactObject, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) ([]ArtifactObject, error)); ok {
+		return rf(ctx, artifactURI, path, recursive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) []ArtifactObject); ok {
+		r0 = rf(ctx, artifactURI, path, recursive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ArtifactObject)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, artifactURI, path, recursive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Stat provides a mock function with given fields: ctx, artifactURI, path
+func (_m *MockArtifactStorageProvider) Stat(ctx context.Context, artifactURI string, path string) (*ArtifactObject, error) {
+	ret := _m.Called(ctx, artifactURI, path)
+
+	var r0 *ArtifactObject
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*ArtifactObject, error)); ok {
 		return rf(ctx, artifactURI, path)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []ArtifactObject); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *ArtifactObject); ok {
 		r0 = rf(ctx, artifactURI, path)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]ArtifactObject)
+			r0 = ret.Get(0).(*ArtifactObject)
 		}
 	}
 
@@ -66,6 +144,20 @@ func (_m *MockArtifactStorageProvider) List(ctx context.Context, artifactURI str
 	return r0, r1
 }
 
+// UploadPart provides a mock function with given fields: ctx, artifactURI, path, uploadID, partNumber, body
+func (_m *MockArtifactStorageProvider) UploadPart(ctx context.Context, artifactURI string, path string, uploadID string, partNumber int, body io.Reader) error {
+	ret := _m.Called(ctx, artifactURI, path, uploadID, partNumber, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int, io.Reader) error); ok {
+		r0 = rf(ctx, artifactURI, path, uploadID, partNumber, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockArtifactStorageProvider creates a new instance of MockArtifactStorageProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockArtifactStorageProvider(t interface {
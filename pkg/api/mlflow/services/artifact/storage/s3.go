@@ -5,7 +5,9 @@ import (
 	"errors"
 	"io"
 	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
@@ -31,10 +33,22 @@ type S3 struct {
 func NewS3(ctx context.Context, config *config.Config) (*S3, error) {
 	var clientOptions []func(o *s3.Options)
 	var configOptions []func(*awsConfig.LoadOptions) error
-	if config.S3EndpointURI != "" {
+	if config.S3Region != "" {
+		configOptions = append(configOptions, awsConfig.WithRegion(config.S3Region))
+	}
+	// S3 compatible storages, like MinIO or Ceph, usually don't support virtual-hosted-style addressing,
+	// so path-style addressing is always used together with the custom endpoint.
+	if config.S3ForcePathStyle || config.S3EndpointURI != "" {
 		clientOptions = append(clientOptions, func(o *s3.Options) {
 			o.UsePathStyle = true
 		})
+	}
+	if config.S3DisableSSL {
+		clientOptions = append(clientOptions, func(o *s3.Options) {
+			o.EndpointOptions.DisableHTTPS = true
+		})
+	}
+	if config.S3EndpointURI != "" {
 		configOptions = append(configOptions, awsConfig.WithEndpointResolverWithOptions(
 			aws.EndpointResolverWithOptionsFunc(
 				func(service, region string, options ...interface{}) (aws.Endpoint, error) {
@@ -61,15 +75,18 @@ func NewS3(ctx context.Context, config *config.Config) (*S3, error) {
 }
 
 // List implements ArtifactStorageProvider interface.
-func (s S3) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+func (s S3) List(ctx context.Context, artifactURI, path string, recursive bool) ([]ArtifactObject, error) {
 	// 1. create s3 request input.
+	// without delimiter s3 returns all the objects under the prefix, which is used for recursive listing.
 	bucket, rootPrefix, err := ExtractBucketAndPrefix(artifactURI)
 	if err != nil {
 		return nil, eris.Wrap(err, "error extracting bucket and prefix from provided uri")
 	}
 	input := s3.ListObjectsV2Input{
-		Bucket:    aws.String(bucket),
-		Delimiter: aws.String("/"),
+		Bucket: aws.String(bucket),
+	}
+	if !recursive {
+		input.Delimiter = aws.String("/")
 	}
 
 	// 2. process search `path` parameter.
@@ -107,6 +124,16 @@ func (s S3) List(ctx context.Context, artifactURI, path string) ([]ArtifactObjec
 			if err != nil {
 				return nil, eris.Wrapf(err, "error getting relative path for object: %s", *object.Key)
 			}
+			// directory placeholder objects, created by some s3 clients, represent directories.
+			if strings.HasSuffix(*object.Key, "/") {
+				if relPath != filepath.Clean(path) {
+					artifactList = append(artifactList, ArtifactObject{
+						Path:  relPath,
+						IsDir: true,
+					})
+				}
+				continue
+			}
 			artifactList = append(artifactList, ArtifactObject{
 				Path:  relPath,
 				Size:  *object.Size,
@@ -115,6 +142,9 @@ func (s S3) List(ctx context.Context, artifactURI, path string) ([]ArtifactObjec
 		}
 	}
 
+	if recursive {
+		artifactList = addParentDirectories(path, artifactList)
+	}
 	return artifactList, nil
 }
 
@@ -144,3 +174,181 @@ func (s S3) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, e
 
 	return resp.Body, nil
 }
+
+// Stat implements ArtifactStorageProvider interface.
+func (s S3) Stat(ctx context.Context, artifactURI, path string) (*ArtifactObject, error) {
+	// 1. create s3 request input.
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return nil, eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	input := &s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(filepath.Join(prefix, path)),
+	}
+
+	// 2. get object metadata from s3 storage.
+	resp, err := s.client.HeadObject(ctx, input)
+	if err != nil {
+		// HEAD response has no body, so s3 returns generic NotFound error instead of NoSuchKey.
+		var s3NotFound *types.NotFound
+		if errors.As(err, &s3NotFound) {
+			return nil, eris.Wrap(fs.ErrNotExist, "object does not exist")
+		}
+		return nil, eris.Wrap(err, "error getting object metadata")
+	}
+
+	// 3. s3 ETag is a content hash, which is already quoted.
+	object := ArtifactObject{
+		Path:        path,
+		ETag:        strings.Trim(aws.ToString(resp.ETag), `"`),
+		ContentType: aws.ToString(resp.ContentType),
+	}
+	if resp.ContentLength != nil {
+		object.Size = *resp.ContentLength
+	}
+	if resp.LastModified != nil {
+		object.LastModified = *resp.LastModified
+	}
+	return &object, nil
+}
+
+// CreateUpload implements ArtifactStorageProvider interface.
+// Chunked upload is backed by s3 multipart upload, so all the parts, except the last one,
+// have to be at least 5MiB in size.
+func (s S3) CreateUpload(ctx context.Context, artifactURI, path string) (string, error) {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return "", eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	resp, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(filepath.Join(prefix, path)),
+	})
+	if err != nil {
+		return "", eris.Wrap(err, "error creating multipart upload")
+	}
+	return aws.ToString(resp.UploadId), nil
+}
+
+// UploadPart implements ArtifactStorageProvider interface.
+func (s S3) UploadPart(
+	ctx context.Context, artifactURI, path, uploadID string, partNumber int, body io.Reader,
+) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	// S3 needs length of the part beforehand to sign the request,
+	// so streamed body is stored into the temporary file firstly.
+	if _, ok := body.(io.ReadSeeker); !ok {
+		file, err := os.CreateTemp("", "fasttrackml-part-*")
+		if err != nil {
+			return eris.Wrapf(err, "error creating file for part %d", partNumber)
+		}
+		//nolint:errcheck
+		defer os.Remove(file.Name())
+		//nolint:errcheck
+		defer file.Close()
+		if _, err := io.Copy(file, body); err != nil {
+			return eris.Wrapf(err, "error reading part %d", partNumber)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return eris.Wrapf(err, "error reading part %d", partNumber)
+		}
+		body = file
+	}
+
+	if _, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(bucketName),
+		Key:        aws.String(filepath.Join(prefix, path)),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(int32(partNumber)),
+		Body:       body,
+	}); err != nil {
+		var s3NoSuchUpload *types.NoSuchUpload
+		if errors.As(err, &s3NoSuchUpload) {
+			return eris.Wrap(fs.ErrNotExist, "upload does not exist")
+		}
+		return eris.Wrapf(err, "error uploading part %d", partNumber)
+	}
+	return nil
+}
+
+// CompleteUpload implements ArtifactStorageProvider interface.
+func (s S3) CompleteUpload(ctx context.Context, artifactURI, path, uploadID string, partCount int) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	key := filepath.Join(prefix, path)
+
+	// 1. s3 requires ETags of the parts to complete the upload, so get them from s3 itself.
+	etags := make(map[int32]*string, partCount)
+	paginator := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			var s3NoSuchUpload *types.NoSuchUpload
+			if errors.As(err, &s3NoSuchUpload) {
+				return eris.Wrap(fs.ErrNotExist, "upload does not exist")
+			}
+			return eris.Wrap(err, "error getting s3 page of upload parts")
+		}
+		for _, part := range page.Parts {
+			etags[aws.ToInt32(part.PartNumber)] = part.ETag
+		}
+	}
+
+	// 2. complete the upload with the requested parts.
+	parts := make([]types.CompletedPart, 0, partCount)
+	for partNumber := int32(1); partNumber <= int32(partCount); partNumber++ {
+		etag, ok := etags[partNumber]
+		if !ok {
+			return eris.Wrapf(ErrUploadPartMissing, "part %d of upload %s", partNumber, uploadID)
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag:       etag,
+			PartNumber: aws.Int32(partNumber),
+		})
+	}
+	if _, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: parts,
+		},
+	}); err != nil {
+		return eris.Wrap(err, "error completing multipart upload")
+	}
+	return nil
+}
+
+// AbortUpload implements ArtifactStorageProvider interface.
+func (s S3) AbortUpload(ctx context.Context, artifactURI, path, uploadID string) error {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	if _, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucketName),
+		Key:      aws.String(filepath.Join(prefix, path)),
+		UploadId: aws.String(uploadID),
+	}); err != nil {
+		var s3NoSuchUpload *types.NoSuchUpload
+		if errors.As(err, &s3NoSuchUpload) {
+			return nil
+		}
+		return eris.Wrap(err, "error aborting multipart upload")
+	}
+	return nil
+}
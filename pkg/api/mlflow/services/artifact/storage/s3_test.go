@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestNewS3_Ok(t *testing.T) {
+	testData := []struct {
+		name         string
+		config       *config.Config
+		region       string
+		usePathStyle bool
+		disableHTTPS bool
+	}{
+		{
+			name:   "DefaultConfiguration",
+			config: &config.Config{},
+			region: "eu-west-1",
+		},
+		{
+			name: "CustomEndpoint",
+			config: &config.Config{
+				S3EndpointURI: "http://localhost:9000",
+			},
+			region:       "eu-west-1",
+			usePathStyle: true,
+		},
+		{
+			name: "AllOptions",
+			config: &config.Config{
+				S3EndpointURI:    "https://minio.example.com",
+				S3Region:         "us-east-2",
+				S3ForcePathStyle: true,
+				S3DisableSSL:     true,
+			},
+			region:       "us-east-2",
+			usePathStyle: true,
+			disableHTTPS: true,
+		},
+		{
+			name: "ForcePathStyleWithoutEndpoint",
+			config: &config.Config{
+				S3ForcePathStyle: true,
+			},
+			region:       "eu-west-1",
+			usePathStyle: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_REGION", "eu-west-1")
+			storage, err := NewS3(context.Background(), tt.config)
+			require.Nil(t, err)
+			options := storage.client.Options()
+			assert.Equal(t, tt.region, options.Region)
+			assert.Equal(t, tt.usePathStyle, options.UsePathStyle)
+			assert.Equal(t, tt.disableHTTPS, options.EndpointOptions.DisableHTTPS)
+		})
+	}
+}
@@ -2,20 +2,36 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/rotisserie/eris"
 
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
+// ErrPathOutsideRoot is returned, when the artifact path points outside of the artifact root.
+var ErrPathOutsideRoot = errors.New("artifact path is outside of the artifact root")
+
+// ErrUploadPartMissing is returned, when the upload is completed before all of its parts have been uploaded.
+var ErrUploadPartMissing = errors.New("upload part is missing")
+
 // ArtifactObject represents Artifact object agnostic to selected storage.
 type ArtifactObject struct {
 	Path  string
 	Size  int64 // artifact object size in bytes.
 	IsDir bool
+	// ETag is a strong entity tag of the object content, which is the same for identical uploads.
+	// it is filled only by ArtifactStorageProvider.Stat method.
+	ETag         string
+	LastModified time.Time
+	// ContentType is a content type stored by object storage backend, if any.
+	// it is filled only by ArtifactStorageProvider.Stat method.
+	ContentType string
 }
 
 // GetPath returns Artifact Path.
@@ -33,12 +49,35 @@ func (o ArtifactObject) IsDirectory() bool {
 	return o.IsDir
 }
 
+// GetETag returns quoted ETag value, as it is used in HTTP headers, or empty string if ETag is unknown.
+func (o ArtifactObject) GetETag() string {
+	if o.ETag == "" {
+		return ""
+	}
+	return fmt.Sprintf(`"%s"`, o.ETag)
+}
+
 // ArtifactStorageProvider provides an interface to work with artifact storage.
 type ArtifactStorageProvider interface {
 	// Get returns an io.ReadCloser for specific artifact.
 	Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error)
-	// List lists all artifact object under provided path.
-	List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error)
+	// List lists artifact objects under provided path. Paths of the objects are relative to artifactURI,
+	// directories are returned with zero size and provided path itself is never returned.
+	// When recursive is false, only direct children of the path are returned, otherwise the whole subtree.
+	// Not existing path results in an empty list.
+	List(ctx context.Context, artifactURI, path string, recursive bool) ([]ArtifactObject, error)
+	// Stat returns ArtifactObject, including ETag, LastModified and ContentType, for specific artifact.
+	Stat(ctx context.Context, artifactURI, path string) (*ArtifactObject, error)
+	// CreateUpload starts a new chunked upload of the artifact and returns ID of the upload.
+	CreateUpload(ctx context.Context, artifactURI, path string) (string, error)
+	// UploadPart stores the part of the upload. Parts are numbered from 1 and could be uploaded in any order,
+	// uploading the part with the same number again replaces it. Unknown upload results in fs.ErrNotExist.
+	UploadPart(ctx context.Context, artifactURI, path, uploadID string, partNumber int, body io.Reader) error
+	// CompleteUpload assembles parts from 1 to partCount, in order, into the artifact and removes the upload.
+	// ErrUploadPartMissing is returned, if any of the parts hasn't been uploaded.
+	CompleteUpload(ctx context.Context, artifactURI, path, uploadID string, partCount int) error
+	// AbortUpload removes the upload together with all the uploaded parts.
+	AbortUpload(ctx context.Context, artifactURI, path, uploadID string) error
 }
 
 // ArtifactStorageFactoryProvider provides an interface provider to work with Artifact Storage.
@@ -61,7 +100,8 @@ func NewArtifactStorageFactory(config *config.Config) (*ArtifactStorageFactory,
 	}, nil
 }
 
-// GetStorage returns Artifact storage based on provided runArtifactPath.
+// GetStorage returns Artifact storage based on provided runArtifactPath. It is called for each of the
+// run artifact roots, so storages are created once per root and cached.
 func (s *ArtifactStorageFactory) GetStorage(
 	ctx context.Context,
 	runArtifactPath string,
@@ -71,13 +111,13 @@ func (s *ArtifactStorageFactory) GetStorage(
 		return nil, eris.Wrap(err, "error parsing artifact root")
 	}
 
-	storageName := u.Scheme
-	if storage, ok := s.storageList.Load(storageName); ok {
+	root := getStorageRoot(u)
+	if storage, ok := s.storageList.Load(root); ok {
 		return storage.(ArtifactStorageProvider), nil
 	}
 
 	var storage ArtifactStorageProvider
-	switch storageName {
+	switch u.Scheme {
 	case GSStorageName:
 		var err error
 		storage, err = NewGS(ctx, s.config)
@@ -100,6 +140,19 @@ func (s *ArtifactStorageFactory) GetStorage(
 		return nil, eris.Errorf("unsupported schema has been provided: %s", u.Scheme)
 	}
 
-	s.storageList.Store(storageName, storage)
+	if storage, loaded := s.storageList.LoadOrStore(root, storage); loaded {
+		return storage.(ArtifactStorageProvider), nil
+	}
 	return storage, nil
 }
+
+// getStorageRoot returns root of the artifact storage, which is used as a cache key. Object storages
+// are rooted in the bucket, while all the local paths share the same root.
+func getStorageRoot(u *url.URL) string {
+	switch u.Scheme {
+	case "", LocalStorageName:
+		return LocalStorageName
+	default:
+		return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+	}
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestArtifactStorageFactory_GetStorage_Ok(t *testing.T) {
+	factory, err := NewArtifactStorageFactory(&config.Config{})
+	require.Nil(t, err)
+
+	bucket1, err := factory.GetStorage(context.Background(), "s3://bucket1/1/run1/artifacts")
+	require.Nil(t, err)
+	bucket1Run2, err := factory.GetStorage(context.Background(), "s3://bucket1/2/run2/artifacts")
+	require.Nil(t, err)
+	bucket2, err := factory.GetStorage(context.Background(), "s3://bucket2/1/run1/artifacts")
+	require.Nil(t, err)
+	assert.Same(t, bucket1, bucket1Run2)
+	assert.NotSame(t, bucket1, bucket2)
+
+	local, err := factory.GetStorage(context.Background(), "/tmp/artifacts/1/run1/artifacts")
+	require.Nil(t, err)
+	localWithScheme, err := factory.GetStorage(context.Background(), "file:///data/artifacts/1/run1/artifacts")
+	require.Nil(t, err)
+	assert.Same(t, local, localWithScheme)
+}
+
+func TestArtifactStorageFactory_GetStorage_Error(t *testing.T) {
+	factory, err := NewArtifactStorageFactory(&config.Config{})
+	require.Nil(t, err)
+
+	_, err = factory.GetStorage(context.Background(), "unknown://bucket/1/run1/artifacts")
+	assert.ErrorContains(t, err, "unsupported schema has been provided: unknown")
+}
@@ -0,0 +1,326 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// expiredUploadsBatchSize is a maximum number of expired uploads, which are aborted at once.
+const expiredUploadsBatchSize = 100
+
+// Start starts to abort expired chunked uploads every interval. Upload expires,
+// if no parts have been uploaded during the upload session ttl.
+func (s Service) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Debugf("artifact upload expiration scheduler finished. exiting.")
+				return
+			case <-ticker.C:
+				if err := s.AbortExpiredUploads(ctx); err != nil {
+					log.Errorf("error aborting expired artifact uploads: %+v", err)
+				}
+			}
+		}
+	}()
+}
+
+// AbortExpiredUploads removes expired chunked uploads together with all the uploaded parts.
+func (s Service) AbortExpiredUploads(ctx context.Context) error {
+	for {
+		uploads, err := s.artifactUploadRepository.GetUpdatedBefore(
+			ctx, s.getUploadExpirationTime(), expiredUploadsBatchSize,
+		)
+		if err != nil {
+			return eris.Wrap(err, "error getting expired artifact uploads")
+		}
+		for _, upload := range uploads {
+			deleted, err := s.artifactUploadRepository.Delete(ctx, &upload)
+			if err != nil {
+				return eris.Wrapf(err, "error deleting artifact upload %s", upload.ID)
+			}
+			// the upload is removed anyway, so storage errors don't stop the others from being aborted.
+			if deleted {
+				if err := s.abortStorageUpload(ctx, &upload); err != nil {
+					log.Errorf("error aborting artifact upload %s: %s", upload.ID, err)
+				}
+			}
+		}
+		if len(uploads) < expiredUploadsBatchSize {
+			return nil
+		}
+	}
+}
+
+// CreateArtifactUpload handles business logic of `POST /artifacts/uploads/create` endpoint.
+func (s Service) CreateArtifactUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.CreateArtifactUploadRequest,
+) (string, error) {
+	if err := ValidateCreateArtifactUploadRequest(req); err != nil {
+		return "", err
+	}
+
+	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.GetRunID())
+	if err != nil {
+		return "", api.NewInternalError("unable to find run '%s': %s", req.GetRunID(), err)
+	}
+	if run == nil {
+		return "", api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
+	}
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
+	if err != nil {
+		return "", api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
+	}
+
+	storageUploadID, err := artifactStorage.CreateUpload(ctx, run.ArtifactURI, req.Path)
+	if err != nil {
+		if errors.Is(err, storage.ErrPathOutsideRoot) {
+			return "", newPathOutsideRootError(req.Path)
+		}
+		return "", api.NewInternalError("error creating artifact upload for run '%s': %s", run.ID, err)
+	}
+
+	upload := models.ArtifactUpload{
+		ID:              strings.ReplaceAll(uuid.New().String(), "-", ""),
+		NamespaceID:     namespace.ID,
+		ArtifactURI:     run.ArtifactURI,
+		Path:            req.Path,
+		StorageUploadID: storageUploadID,
+	}
+	if err := s.artifactUploadRepository.Create(ctx, &upload); err != nil {
+		// upload can't be continued without the session, so it's removed straight away.
+		if err := artifactStorage.AbortUpload(ctx, run.ArtifactURI, req.Path, storageUploadID); err != nil {
+			log.Errorf("error aborting artifact upload of run %s: %s", run.ID, err)
+		}
+		return "", api.NewInternalError("error creating artifact upload for run '%s': %s", run.ID, err)
+	}
+	return upload.ID, nil
+}
+
+// UploadArtifactPart handles business logic of `PUT /artifacts/uploads/part` endpoint.
+func (s Service) UploadArtifactPart(
+	ctx context.Context, namespace *models.Namespace, req *request.UploadArtifactPartRequest, body io.Reader,
+) error {
+	if err := ValidateUploadArtifactPartRequest(req); err != nil {
+		return err
+	}
+
+	upload, err := s.getUpload(ctx, namespace, req.UploadID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.uploadArtifactPart(ctx, upload, req, body); err != nil {
+		if !errors.Is(err, errArtifactTooLarge) {
+			return err
+		}
+		// artifact won't fit anyway, so the whole upload is removed, not to keep already uploaded parts.
+		if err := s.abortUpload(ctx, upload); err != nil {
+			log.Errorf("error aborting artifact upload %s: %s", req.UploadID, err)
+		}
+		return s.newArtifactTooLargeError(upload)
+	}
+	return nil
+}
+
+// uploadArtifactPart stores the part of the upload. Bytes of the part are counted while they are streamed
+// to the storage, and errArtifactTooLarge is returned once maximum artifact size, if configured, is exceeded.
+func (s Service) uploadArtifactPart(
+	ctx context.Context, upload *models.ArtifactUpload, req *request.UploadArtifactPartRequest, body io.Reader,
+) error {
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, upload.ArtifactURI)
+	if err != nil {
+		return api.NewInternalError("artifact upload '%s' has unsupported artifact storage", upload.ID)
+	}
+
+	// part could be uploaded again, so its previous size isn't taken into account.
+	limit := int64(math.MaxInt64)
+	if s.config.ArtifactMaxFileSize > 0 {
+		sizes, err := s.artifactUploadRepository.GetPartSizes(ctx, upload)
+		if err != nil {
+			return api.NewInternalError("error getting parts of artifact upload '%s': %s", upload.ID, err)
+		}
+		delete(sizes, req.PartNumber)
+		limit = s.config.ArtifactMaxFileSize - getArtifactSize(sizes)
+	}
+	limitedBody, body := newLimitedPartReader(body, limit)
+
+	err = artifactStorage.UploadPart(ctx, upload.ArtifactURI, upload.Path, upload.StorageUploadID, req.PartNumber, body)
+	// storages wrap errors of the body differently, so the reader is asked directly.
+	if limitedBody.exceeded() {
+		return errArtifactTooLarge
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return newUploadDoesNotExistError(upload.ID)
+		}
+		return api.NewInternalError(
+			"error uploading part %d of artifact upload '%s': %s", req.PartNumber, upload.ID, err,
+		)
+	}
+
+	// parts could be uploaded concurrently and each of them fits into the remaining size on its own,
+	// so the size of the whole artifact is checked again together with storing the size of the part.
+	sizes, err := s.artifactUploadRepository.SetPartSize(ctx, upload, req.PartNumber, limitedBody.size, time.Now())
+	if err != nil {
+		return api.NewInternalError(
+			"error storing part %d of artifact upload '%s': %s", req.PartNumber, upload.ID, err,
+		)
+	}
+	if sizes == nil {
+		return newUploadDoesNotExistError(upload.ID)
+	}
+	if s.config.ArtifactMaxFileSize > 0 && getArtifactSize(sizes) > s.config.ArtifactMaxFileSize {
+		return errArtifactTooLarge
+	}
+	return nil
+}
+
+// CompleteArtifactUpload handles business logic of `POST /artifacts/uploads/complete` endpoint.
+func (s Service) CompleteArtifactUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.CompleteArtifactUploadRequest,
+) error {
+	if err := ValidateCompleteArtifactUploadRequest(req); err != nil {
+		return err
+	}
+
+	upload, err := s.getUpload(ctx, namespace, req.UploadID)
+	if err != nil {
+		return err
+	}
+
+	// size of the whole artifact is checked once again, when all the parts have been uploaded.
+	if s.config.ArtifactMaxFileSize > 0 {
+		sizes, err := s.artifactUploadRepository.GetPartSizes(ctx, upload)
+		if err != nil {
+			return api.NewInternalError("error getting parts of artifact upload '%s': %s", upload.ID, err)
+		}
+		if getArtifactSize(sizes) > s.config.ArtifactMaxFileSize {
+			if err := s.abortUpload(ctx, upload); err != nil {
+				log.Errorf("error aborting artifact upload %s: %s", req.UploadID, err)
+			}
+			return s.newArtifactTooLargeError(upload)
+		}
+	}
+
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, upload.ArtifactURI)
+	if err != nil {
+		return api.NewInternalError("artifact upload '%s' has unsupported artifact storage", upload.ID)
+	}
+	if err := artifactStorage.CompleteUpload(
+		ctx, upload.ArtifactURI, upload.Path, upload.StorageUploadID, req.PartCount,
+	); err != nil {
+		if errors.Is(err, storage.ErrUploadPartMissing) {
+			return api.NewInvalidParameterValueError("unable to complete artifact upload '%s': %s", req.UploadID, err)
+		}
+		if errors.Is(err, fs.ErrNotExist) {
+			return newUploadDoesNotExistError(req.UploadID)
+		}
+		return api.NewInternalError("error completing artifact upload '%s': %s", req.UploadID, err)
+	}
+
+	if _, err := s.artifactUploadRepository.Delete(ctx, upload); err != nil {
+		return api.NewInternalError("error completing artifact upload '%s': %s", req.UploadID, err)
+	}
+	s.artifactStats.Remove(upload.ArtifactURI)
+	return nil
+}
+
+// AbortArtifactUpload handles business logic of `POST /artifacts/uploads/abort` endpoint.
+func (s Service) AbortArtifactUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.AbortArtifactUploadRequest,
+) error {
+	if err := ValidateAbortArtifactUploadRequest(req); err != nil {
+		return err
+	}
+
+	upload, err := s.getUpload(ctx, namespace, req.UploadID)
+	if err != nil {
+		return err
+	}
+	if err := s.abortUpload(ctx, upload); err != nil {
+		return api.NewInternalError("error aborting artifact upload '%s': %s", req.UploadID, err)
+	}
+	return nil
+}
+
+// abortUpload removes the upload together with all the uploaded parts. The upload could be aborted
+// concurrently, e.g. when it expires, so the parts are removed only by the caller, which removed the upload.
+func (s Service) abortUpload(ctx context.Context, upload *models.ArtifactUpload) error {
+	deleted, err := s.artifactUploadRepository.Delete(ctx, upload)
+	if err != nil {
+		return eris.Wrap(err, "error deleting artifact upload")
+	}
+	if !deleted {
+		return nil
+	}
+	return s.abortStorageUpload(ctx, upload)
+}
+
+// abortStorageUpload removes all the uploaded parts of the upload from the storage.
+func (s Service) abortStorageUpload(ctx context.Context, upload *models.ArtifactUpload) error {
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, upload.ArtifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error getting artifact storage")
+	}
+	return artifactStorage.AbortUpload(ctx, upload.ArtifactURI, upload.Path, upload.StorageUploadID)
+}
+
+// getUpload returns the upload, which belongs to the namespace and hasn't expired yet.
+func (s Service) getUpload(
+	ctx context.Context, namespace *models.Namespace, id string,
+) (*models.ArtifactUpload, error) {
+	upload, err := s.artifactUploadRepository.GetByNamespaceIDAndID(ctx, namespace.ID, id)
+	if err != nil {
+		return nil, api.NewInternalError("unable to find artifact upload '%s': %s", id, err)
+	}
+	// expired upload could have not been aborted yet, but it can't be continued anymore.
+	if upload == nil || upload.UpdatedAt.Before(s.getUploadExpirationTime()) {
+		return nil, newUploadDoesNotExistError(id)
+	}
+	return upload, nil
+}
+
+// getUploadExpirationTime returns time, before which not updated uploads are expired.
+func (s Service) getUploadExpirationTime() time.Time {
+	return time.Now().Add(-s.config.GetArtifactUploadSessionTTL())
+}
+
+// getArtifactSize returns size of the whole artifact by sizes of its parts.
+func getArtifactSize(sizes map[int]int64) int64 {
+	var size int64
+	for _, partSize := range sizes {
+		size += partSize
+	}
+	return size
+}
+
+// newUploadDoesNotExistError creates an error for the unknown, expired or already finished upload.
+func newUploadDoesNotExistError(id string) *api.ErrorResponse {
+	return api.NewResourceDoesNotExistError("unable to find artifact upload '%s'", id)
+}
+
+// newArtifactTooLargeError creates an error for the upload, which exceeds maximum size of the artifact.
+func (s Service) newArtifactTooLargeError(upload *models.ArtifactUpload) *api.ErrorResponse {
+	return api.NewInvalidParameterValueError(
+		"artifact '%s' of upload '%s' exceeds maximum size of %d bytes",
+		upload.Path, upload.ID, s.config.ArtifactMaxFileSize,
+	)
+}
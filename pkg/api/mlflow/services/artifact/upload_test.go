@@ -0,0 +1,277 @@
+package artifact
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func newUploadTestService(
+	config *config.Config,
+	artifactUploadRepository *repositories.MockArtifactUploadRepositoryProvider,
+	artifactStorage *storage.MockArtifactStorageProvider,
+) *Service {
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "/artifact/uri",
+	).Return(artifactStorage, nil)
+
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetByNamespaceIDAndRunID", context.TODO(), uint(1), "id",
+	).Return(&models.Run{
+		ID:          "id",
+		ArtifactURI: "/artifact/uri",
+	}, nil)
+
+	return NewService(config, &runRepository, artifactUploadRepository, &artifactStorageFactory)
+}
+
+func newTestUpload(path string, updatedAt time.Time) *models.ArtifactUpload {
+	return &models.ArtifactUpload{
+		ID:              "upload-id",
+		NamespaceID:     1,
+		ArtifactURI:     "/artifact/uri",
+		Path:            path,
+		StorageUploadID: "storage-upload-id",
+		UpdatedAt:       updatedAt,
+	}
+}
+
+// readPartBody reads body of the uploaded part, as storages do.
+func readPartBody(args mock.Arguments) {
+	//nolint:errcheck
+	io.ReadAll(args.Get(5).(io.Reader))
+}
+
+func TestService_ArtifactUpload_Ok(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"CreateUpload", context.TODO(), "/artifact/uri", "path/file.txt",
+	).Return("storage-upload-id", nil)
+	artifactStorage.On(
+		"UploadPart", context.TODO(), "/artifact/uri", "path/file.txt", "storage-upload-id", 1, mock.Anything,
+	).Run(readPartBody).Return(nil)
+	artifactStorage.On(
+		"CompleteUpload", context.TODO(), "/artifact/uri", "path/file.txt", "storage-upload-id", 1,
+	).Return(nil)
+
+	upload := newTestUpload("path/file.txt", time.Now())
+	artifactUploadRepository := repositories.MockArtifactUploadRepositoryProvider{}
+	artifactUploadRepository.On(
+		"Create", context.TODO(), mock.MatchedBy(func(created *models.ArtifactUpload) bool {
+			return created.NamespaceID == 1 && created.ArtifactURI == "/artifact/uri" &&
+				created.Path == "path/file.txt" && created.StorageUploadID == "storage-upload-id"
+		}),
+	).Return(nil)
+	artifactUploadRepository.On(
+		"GetByNamespaceIDAndID", context.TODO(), uint(1), "upload-id",
+	).Return(upload, nil)
+	artifactUploadRepository.On(
+		"GetByNamespaceIDAndID", context.TODO(), uint(2), "upload-id",
+	).Return(nil, nil)
+	artifactUploadRepository.On(
+		"SetPartSize", context.TODO(), upload, 1, int64(7), mock.Anything,
+	).Return(map[int]int64{1: 7}, nil)
+	artifactUploadRepository.On(
+		"Delete", context.TODO(), upload,
+	).Return(true, nil)
+	service := newUploadTestService(&config.Config{}, &artifactUploadRepository, &artifactStorage)
+	namespace := &models.Namespace{ID: 1}
+
+	// create upload session.
+	uploadID, err := service.CreateArtifactUpload(context.TODO(), namespace, &request.CreateArtifactUploadRequest{
+		RunID: "id",
+		Path:  "path/file.txt",
+	})
+	require.Nil(t, err)
+	assert.Len(t, uploadID, 32)
+
+	// upload part, session of the other namespace is not visible.
+	require.Nil(t, service.UploadArtifactPart(context.TODO(), namespace, &request.UploadArtifactPartRequest{
+		UploadID:   "upload-id",
+		PartNumber: 1,
+	}, strings.NewReader("content")))
+	err = service.UploadArtifactPart(context.TODO(), &models.Namespace{ID: 2}, &request.UploadArtifactPartRequest{
+		UploadID:   "upload-id",
+		PartNumber: 1,
+	}, strings.NewReader("content"))
+	assert.Equal(t, api.NewResourceDoesNotExistError("unable to find artifact upload 'upload-id'"), err)
+
+	// complete upload, completed upload is never aborted.
+	require.Nil(t, service.CompleteArtifactUpload(context.TODO(), namespace, &request.CompleteArtifactUploadRequest{
+		UploadID:  "upload-id",
+		PartCount: 1,
+	}))
+	artifactStorage.AssertExpectations(t)
+	artifactUploadRepository.AssertExpectations(t)
+	artifactStorage.AssertNotCalled(t, "AbortUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_UploadArtifactPart_Expired(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactUploadRepository := repositories.MockArtifactUploadRepositoryProvider{}
+	artifactUploadRepository.On(
+		"GetByNamespaceIDAndID", context.TODO(), uint(1), "upload-id",
+	).Return(newTestUpload("file.txt", time.Now().Add(-2*time.Hour)), nil)
+	service := newUploadTestService(
+		&config.Config{ArtifactUploadSessionTTL: time.Hour}, &artifactUploadRepository, &artifactStorage,
+	)
+
+	// expired upload can't be continued, even if it hasn't been aborted yet.
+	err := service.UploadArtifactPart(context.TODO(), &models.Namespace{ID: 1}, &request.UploadArtifactPartRequest{
+		UploadID:   "upload-id",
+		PartNumber: 1,
+	}, strings.NewReader("content"))
+	assert.Equal(t, api.NewResourceDoesNotExistError("unable to find artifact upload 'upload-id'"), err)
+	artifactStorage.AssertNotCalled(
+		t, "UploadPart", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	)
+}
+
+func TestService_UploadArtifactPart_TooLarge(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"UploadPart", context.TODO(), "/artifact/uri", "file.txt", "storage-upload-id", 2, mock.Anything,
+	).Run(readPartBody).Return(nil)
+	artifactStorage.On(
+		"AbortUpload", context.TODO(), "/artifact/uri", "file.txt", "storage-upload-id",
+	).Return(nil)
+
+	upload := newTestUpload("file.txt", time.Now())
+	artifactUploadRepository := repositories.MockArtifactUploadRepositoryProvider{}
+	artifactUploadRepository.On(
+		"GetByNamespaceIDAndID", context.TODO(), uint(1), "upload-id",
+	).Return(upload, nil)
+	artifactUploadRepository.On(
+		"GetPartSizes", context.TODO(), upload,
+	).Return(map[int]int64{}, nil)
+	// the first part has been uploaded concurrently, and both parts fitted into the remaining size on their own.
+	artifactUploadRepository.On(
+		"SetPartSize", context.TODO(), upload, 2, int64(6), mock.Anything,
+	).Return(map[int]int64{1: 6, 2: 6}, nil)
+	artifactUploadRepository.On(
+		"Delete", context.TODO(), upload,
+	).Return(true, nil)
+	service := newUploadTestService(
+		&config.Config{ArtifactMaxFileSize: 10}, &artifactUploadRepository, &artifactStorage,
+	)
+
+	err := service.UploadArtifactPart(context.TODO(), &models.Namespace{ID: 1}, &request.UploadArtifactPartRequest{
+		UploadID:   "upload-id",
+		PartNumber: 2,
+	}, strings.NewReader("678901"))
+	assert.Equal(t, api.NewInvalidParameterValueError(
+		"artifact 'file.txt' of upload 'upload-id' exceeds maximum size of 10 bytes",
+	), err)
+	artifactStorage.AssertExpectations(t)
+	artifactUploadRepository.AssertExpectations(t)
+}
+
+func TestService_CompleteArtifactUpload_Error(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"CompleteUpload", context.TODO(), "/artifact/uri", "file.txt", "storage-upload-id", 2,
+	).Return(storage.ErrUploadPartMissing)
+	artifactUploadRepository := repositories.MockArtifactUploadRepositoryProvider{}
+	artifactUploadRepository.On(
+		"GetByNamespaceIDAndID", context.TODO(), uint(1), "upload-id",
+	).Return(newTestUpload("file.txt", time.Now()), nil)
+	service := newUploadTestService(&config.Config{}, &artifactUploadRepository, &artifactStorage)
+	namespace := &models.Namespace{ID: 1}
+
+	err := service.CompleteArtifactUpload(context.TODO(), namespace, &request.CompleteArtifactUploadRequest{
+		UploadID:  "upload-id",
+		PartCount: 2,
+	})
+	assert.Equal(t, api.NewInvalidParameterValueError(
+		"unable to complete artifact upload 'upload-id': %s", storage.ErrUploadPartMissing,
+	), err)
+
+	err = service.CompleteArtifactUpload(context.TODO(), namespace, &request.CompleteArtifactUploadRequest{
+		UploadID: "upload-id",
+	})
+	assert.Equal(t, api.NewInvalidParameterValueError("Invalid value for parameter 'part_count' supplied."), err)
+
+	// upload is kept, so missing parts could be uploaded.
+	artifactUploadRepository.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestService_CompleteArtifactUpload_TooLarge(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"AbortUpload", context.TODO(), "/artifact/uri", "file.txt", "storage-upload-id",
+	).Return(nil)
+
+	upload := newTestUpload("file.txt", time.Now())
+	artifactUploadRepository := repositories.MockArtifactUploadRepositoryProvider{}
+	artifactUploadRepository.On(
+		"GetByNamespaceIDAndID", context.TODO(), uint(1), "upload-id",
+	).Return(upload, nil)
+	artifactUploadRepository.On(
+		"GetPartSizes", context.TODO(), upload,
+	).Return(map[int]int64{1: 6, 2: 6}, nil)
+	artifactUploadRepository.On(
+		"Delete", context.TODO(), upload,
+	).Return(true, nil)
+	service := newUploadTestService(
+		&config.Config{ArtifactMaxFileSize: 10}, &artifactUploadRepository, &artifactStorage,
+	)
+
+	err := service.CompleteArtifactUpload(context.TODO(), &models.Namespace{ID: 1}, &request.CompleteArtifactUploadRequest{
+		UploadID:  "upload-id",
+		PartCount: 2,
+	})
+	assert.Equal(t, api.NewInvalidParameterValueError(
+		"artifact 'file.txt' of upload 'upload-id' exceeds maximum size of 10 bytes",
+	), err)
+	artifactStorage.AssertExpectations(t)
+	artifactStorage.AssertNotCalled(
+		t, "CompleteUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	)
+}
+
+func TestService_AbortExpiredUploads_Ok(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"AbortUpload", context.TODO(), "/artifact/uri", "expired.txt", "storage-upload-id",
+	).Return(nil)
+
+	expired := newTestUpload("expired.txt", time.Now().Add(-2*time.Hour))
+	abortedConcurrently := newTestUpload("aborted.txt", time.Now().Add(-2*time.Hour))
+	abortedConcurrently.ID = "aborted-upload-id"
+	artifactUploadRepository := repositories.MockArtifactUploadRepositoryProvider{}
+	artifactUploadRepository.On(
+		"GetUpdatedBefore", context.TODO(), mock.MatchedBy(func(updatedAt time.Time) bool {
+			return time.Since(updatedAt) >= time.Hour
+		}), expiredUploadsBatchSize,
+	).Return([]models.ArtifactUpload{*expired, *abortedConcurrently}, nil)
+	artifactUploadRepository.On(
+		"Delete", context.TODO(), expired,
+	).Return(true, nil)
+	// upload, which has been aborted by the other instance, is not aborted in the storage again.
+	artifactUploadRepository.On(
+		"Delete", context.TODO(), abortedConcurrently,
+	).Return(false, nil)
+	service := newUploadTestService(
+		&config.Config{ArtifactUploadSessionTTL: time.Hour}, &artifactUploadRepository, &artifactStorage,
+	)
+
+	require.Nil(t, service.AbortExpiredUploads(context.TODO()))
+	artifactStorage.AssertExpectations(t)
+	artifactUploadRepository.AssertExpectations(t)
+	artifactStorage.AssertNumberOfCalls(t, "AbortUpload", 1)
+}
@@ -10,12 +10,22 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
+// MaxResultsPerPage is a maximum number of artifacts, which could be requested per page.
+const MaxResultsPerPage = 10000
+
+// MaxUploadParts is a maximum number of parts of the chunked artifact upload.
+const MaxUploadParts = 10000
+
 // ValidateListArtifactsRequest validates `GET /mlflow/artifacts/list` request.
 func ValidateListArtifactsRequest(req *request.ListArtifactsRequest) error {
 	if req.RunID == "" && req.RunUUID == "" {
 		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
 	}
 
+	if req.MaxResults < 0 || req.MaxResults > MaxResultsPerPage {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied.")
+	}
+
 	return validatePath(req.Path)
 }
 
@@ -43,3 +53,51 @@ func validatePath(path string) error {
 	}
 	return nil
 }
+
+// ValidateCreateArtifactUploadRequest validates `POST /artifacts/uploads/create` request.
+func ValidateCreateArtifactUploadRequest(req *request.CreateArtifactUploadRequest) error {
+	if req.RunID == "" && req.RunUUID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+
+	if req.Path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+
+	return validatePath(req.Path)
+}
+
+// ValidateUploadArtifactPartRequest validates `PUT /artifacts/uploads/part` request.
+func ValidateUploadArtifactPartRequest(req *request.UploadArtifactPartRequest) error {
+	if req.UploadID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'upload_id'")
+	}
+
+	if req.PartNumber < 1 || req.PartNumber > MaxUploadParts {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'part_number' supplied.")
+	}
+
+	return nil
+}
+
+// ValidateCompleteArtifactUploadRequest validates `POST /artifacts/uploads/complete` request.
+func ValidateCompleteArtifactUploadRequest(req *request.CompleteArtifactUploadRequest) error {
+	if req.UploadID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'upload_id'")
+	}
+
+	if req.PartCount < 1 || req.PartCount > MaxUploadParts {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'part_count' supplied.")
+	}
+
+	return nil
+}
+
+// ValidateAbortArtifactUploadRequest validates `POST /artifacts/uploads/abort` request.
+func ValidateAbortArtifactUploadRequest(req *request.AbortArtifactUploadRequest) error {
+	if req.UploadID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'upload_id'")
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package compaction
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+// DefaultBatchSize is the number of runs compacted at once, when it isn't requested.
+const DefaultBatchSize = 100
+
+// Result represents result of the latest metrics compaction.
+type Result struct {
+	Runs    int64
+	Created int64
+	Updated int64
+	Deleted int64
+}
+
+// Service provides service layer to work with latest metrics `compaction` business logic.
+type Service struct {
+	latestMetricRepository repositories.LatestMetricRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(latestMetricRepository repositories.LatestMetricRepositoryProvider) *Service {
+	return &Service{
+		latestMetricRepository: latestMetricRepository,
+	}
+}
+
+// CompactLatestMetrics recomputes the latest metrics of all the runs from the metrics history
+// and fixes the ones which drifted from it. Runs are compacted in batches of batchSize runs.
+func (s Service) CompactLatestMetrics(ctx context.Context, batchSize int) (*Result, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var result Result
+	afterRunID := ""
+	for {
+		compaction, err := s.latestMetricRepository.Compact(ctx, afterRunID, batchSize)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error compacting latest metrics of runs after: '%s'", afterRunID)
+		}
+		result.Runs += compaction.Runs
+		result.Created += compaction.Created
+		result.Updated += compaction.Updated
+		result.Deleted += compaction.Deleted
+		if compaction.Runs < int64(batchSize) {
+			break
+		}
+		afterRunID = compaction.LastRunID
+	}
+	log.Infof(
+		"compacted latest metrics of %d runs: %d created, %d updated, %d deleted",
+		result.Runs, result.Created, result.Updated, result.Deleted,
+	)
+	return &result, nil
+}
@@ -0,0 +1,52 @@
+package compaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+func TestService_CompactLatestMetrics_Ok(t *testing.T) {
+	// init repository mocks.
+	latestMetricRepository := repositories.MockLatestMetricRepositoryProvider{}
+	latestMetricRepository.On(
+		"Compact", context.TODO(), "", 2,
+	).Return(&repositories.LatestMetricCompaction{LastRunID: "id2", Runs: 2, Updated: 1}, nil)
+	latestMetricRepository.On(
+		"Compact", context.TODO(), "id2", 2,
+	).Return(&repositories.LatestMetricCompaction{LastRunID: "id4", Runs: 2, Created: 1, Deleted: 2}, nil)
+	latestMetricRepository.On(
+		"Compact", context.TODO(), "id4", 2,
+	).Return(&repositories.LatestMetricCompaction{}, nil)
+
+	// call service under testing.
+	service := NewService(&latestMetricRepository)
+	result, err := service.CompactLatestMetrics(context.TODO(), 2)
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, &Result{Runs: 4, Created: 1, Updated: 1, Deleted: 2}, result)
+	latestMetricRepository.AssertExpectations(t)
+}
+
+func TestService_CompactLatestMetrics_Error(t *testing.T) {
+	// init repository mocks.
+	latestMetricRepository := repositories.MockLatestMetricRepositoryProvider{}
+	latestMetricRepository.On(
+		"Compact", context.TODO(), "", DefaultBatchSize,
+	).Return(nil, eris.New("database error"))
+
+	// call service under testing.
+	service := NewService(&latestMetricRepository)
+	result, err := service.CompactLatestMetrics(context.TODO(), 0)
+
+	// compare results.
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "error compacting latest metrics of runs after: '': database error")
+	latestMetricRepository.AssertExpectations(t)
+}
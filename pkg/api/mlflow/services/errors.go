@@ -17,10 +17,14 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 		var f *fiber.Error
 		if errors.As(err, &f) {
 			switch f.Code {
-			case fiber.StatusBadRequest:
+			case fiber.StatusBadRequest, fiber.StatusUnprocessableEntity:
 				code = api.ErrorCodeBadRequest
+			case fiber.StatusConflict:
+				code = api.ErrorCodeResourceAlreadyExists
 			case fiber.StatusServiceUnavailable:
 				code = api.ErrorCodeTemporarilyUnavailable
+			case fiber.StatusTooManyRequests:
+				code = api.ErrorCodeRequestLimitExceeded
 			case fiber.StatusNotFound:
 				code = api.ErrorCodeEndpointNotFound
 			}
@@ -42,6 +46,9 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 	case api.ErrorCodeTemporarilyUnavailable:
 		code = fiber.StatusServiceUnavailable
 		fn = log.Warnf
+	case api.ErrorCodeRequestLimitExceeded:
+		code = fiber.StatusTooManyRequests
+		fn = log.Warnf
 	case api.ErrorCodeEndpointNotFound, api.ErrorCodeResourceDoesNotExist:
 		code = fiber.StatusNotFound
 		fn = log.Debugf
@@ -18,6 +18,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/convertors"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/database"
@@ -71,6 +72,9 @@ func (s Service) CreateExperiment(
 	if err := ValidateCreateExperimentRequest(req); err != nil {
 		return nil, err
 	}
+	if err := api.ValidateName("experiment", req.Name, s.config.GetMaxExperimentNameLength()); err != nil {
+		return nil, err
+	}
 
 	experiment, err := s.experimentRepository.GetByNamespaceIDAndName(ctx, ns.ID, req.Name)
 	if err != nil {
@@ -84,6 +88,18 @@ func (s Service) CreateExperiment(
 	if err != nil {
 		return nil, api.NewInvalidParameterValueError("Invalid value for parameter 'artifact_location': %s", err)
 	}
+	if experiment.ArtifactLocation != "" {
+		u, err := url.Parse(experiment.ArtifactLocation)
+		if err != nil {
+			return nil, api.NewInvalidParameterValueError("Invalid value for parameter 'artifact_location': %s", err)
+		}
+		if !s.config.IsArtifactSchemeAllowed(ns.Code, u.Scheme) {
+			return nil, api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'artifact_location': scheme of '%s' is not allowed in namespace '%s'",
+				experiment.ArtifactLocation, ns.Code,
+			)
+		}
+	}
 	experiment.NamespaceID = ns.ID
 
 	if err := s.experimentRepository.Create(ctx, experiment); err != nil {
@@ -91,7 +107,7 @@ func (s Service) CreateExperiment(
 	}
 
 	if experiment.ArtifactLocation == "" {
-		path, err := url.JoinPath(s.config.DefaultArtifactRoot, fmt.Sprintf("%d", *experiment.ID))
+		path, err := common.BuildArtifactLocation(s.config.DefaultArtifactRoot, ns.Code, *experiment.ID)
 		if err != nil {
 			return nil, api.NewInternalError(
 				"error creating artifact_location for experiment'%s': %s", experiment.Name, err,
@@ -115,6 +131,9 @@ func (s Service) UpdateExperiment(
 	if err := ValidateUpdateExperimentRequest(req); err != nil {
 		return err
 	}
+	if err := api.ValidateName("experiment", req.Name, s.config.GetMaxExperimentNameLength()); err != nil {
+		return err
+	}
 
 	parsedID, err := strconv.ParseInt(req.ID, 10, 32)
 	if err != nil {
@@ -126,6 +145,15 @@ func (s Service) UpdateExperiment(
 		return api.NewResourceDoesNotExistError("unable to find experiment '%d': %s", parsedID, err)
 	}
 
+	// experiment names are unique per namespace, so check that the new name is not taken yet.
+	existingExperiment, err := s.experimentRepository.GetByNamespaceIDAndName(ctx, ns.ID, req.Name)
+	if err != nil {
+		return api.NewInternalError("error getting experiment with name: '%s', error: %s", req.Name, err)
+	}
+	if existingExperiment != nil && *existingExperiment.ID != *experiment.ID {
+		return api.NewResourceAlreadyExistsError("experiment(name=%s) already exists", req.Name)
+	}
+
 	experiment = convertors.ConvertUpdateExperimentToDBModel(experiment, req)
 	if err := s.experimentRepository.Update(ctx, experiment); err != nil {
 		return api.NewInternalError("unable to update experiment '%d': %s", *experiment.ID, err)
@@ -228,13 +256,16 @@ func (s Service) RestoreExperiment(
 		return api.NewResourceDoesNotExistError(`unable to find experiment '%d': %s`, parsedID, err)
 	}
 
-	experiment.LifecycleStage = models.LifecycleStageActive
+	if experiment.LifecycleStage != models.LifecycleStageDeleted {
+		return api.NewInvalidParameterValueError("experiment '%d' is not deleted", parsedID)
+	}
+
 	experiment.LastUpdateTime = sql.NullInt64{
 		Int64: time.Now().UTC().UnixMilli(),
 		Valid: true,
 	}
 
-	if err := s.experimentRepository.Update(ctx, experiment); err != nil {
+	if err := s.experimentRepository.Restore(ctx, experiment); err != nil {
 		return api.NewInternalError("Unable to restore experiment '%d': %s", *experiment.ID, err)
 	}
 
@@ -275,7 +306,7 @@ func (s Service) SearchExperiments(
 		return nil, 0, 0, err
 	}
 
-	query := database.DB.Where(
+	query := database.DB.WithContext(ctx).Where(
 		"experiments.namespace_id = ?", ns.ID,
 	)
 
@@ -299,10 +330,7 @@ func (s Service) SearchExperiments(
 	query.Where("lifecycle_stage IN ?", lifecyleStages)
 
 	// MaxResults
-	limit := int(req.MaxResults)
-	if limit == 0 {
-		limit = 1000
-	}
+	limit := s.config.GetPageSize(int(req.MaxResults))
 	query.Limit(limit + 1)
 
 	// PageToken
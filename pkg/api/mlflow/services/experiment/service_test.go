@@ -644,13 +644,13 @@ func TestService_RestoreExperiment_Ok(t *testing.T) {
 	experimentRepository.On(
 		"GetByNamespaceIDAndExperimentID", context.TODO(), ns.ID, int32(1),
 	).Return(&models.Experiment{
-		ID: common.GetPointer(int32(1)),
+		ID:             common.GetPointer(int32(1)),
+		LifecycleStage: models.LifecycleStageDeleted,
 	}, nil)
 	experimentRepository.On(
-		"Update",
+		"Restore",
 		context.TODO(),
 		mock.MatchedBy(func(experiment *models.Experiment) bool {
-			assert.Equal(t, models.LifecycleStageActive, experiment.LifecycleStage)
 			assert.NotNil(t, experiment.LastUpdateTime)
 			return true
 		}),
@@ -730,7 +730,28 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 			},
 		},
 		{
-			name:  "UpdateExperimentDatabaseError",
+			name:  "ExperimentNotDeleted",
+			error: api.NewInvalidParameterValueError(`experiment '1' is not deleted`),
+			request: &request.RestoreExperimentRequest{
+				ID: "1",
+			},
+			service: func() *Service {
+				experimentRepository := repositories.MockExperimentRepositoryProvider{}
+				experimentRepository.On(
+					"GetByNamespaceIDAndExperimentID", context.TODO(), ns.ID, int32(1),
+				).Return(&models.Experiment{
+					ID:             common.GetPointer(int32(1)),
+					LifecycleStage: models.LifecycleStageActive,
+				}, nil)
+				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&experimentRepository,
+				)
+			},
+		},
+		{
+			name:  "RestoreExperimentDatabaseError",
 			error: api.NewInternalError(`Unable to restore experiment '1': database error`),
 			request: &request.RestoreExperimentRequest{
 				ID: "1",
@@ -740,10 +761,11 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 				experimentRepository.On(
 					"GetByNamespaceIDAndExperimentID", context.TODO(), ns.ID, int32(1),
 				).Return(&models.Experiment{
-					ID: common.GetPointer(int32(1)),
+					ID:             common.GetPointer(int32(1)),
+					LifecycleStage: models.LifecycleStageDeleted,
 				}, nil)
 				experimentRepository.On(
-					"Update", context.TODO(), mock.AnythingOfType("*models.Experiment"),
+					"Restore", context.TODO(), mock.AnythingOfType("*models.Experiment"),
 				).Return(errors.New("database error"))
 				return NewService(
 					&config.Config{},
@@ -931,6 +953,9 @@ func TestService_UpdateExperiment_Ok(t *testing.T) {
 	).Return(&models.Experiment{
 		ID: common.GetPointer(int32(1)),
 	}, nil)
+	experimentRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(nil, nil)
 	experimentRepository.On(
 		"Update",
 		context.TODO(),
@@ -1031,6 +1056,33 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:  "ExperimentNameAlreadyExists",
+			error: api.NewResourceAlreadyExistsError(`experiment(name=name) already exists`),
+			request: &request.UpdateExperimentRequest{
+				ID:   "1",
+				Name: "name",
+			},
+			service: func() *Service {
+				experimentRepository := repositories.MockExperimentRepositoryProvider{}
+				experimentRepository.On(
+					"GetByNamespaceIDAndExperimentID", context.TODO(), ns.ID, int32(1),
+				).Return(&models.Experiment{
+					ID: common.GetPointer(int32(1)),
+				}, nil)
+				experimentRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(&models.Experiment{
+					ID:   common.GetPointer(int32(2)),
+					Name: "name",
+				}, nil)
+				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&experimentRepository,
+				)
+			},
+		},
 		{
 			name:  "UpdateExperimentDatabaseError",
 			error: api.NewInternalError(`unable to update experiment '1': database error`),
@@ -1045,6 +1097,9 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 				).Return(&models.Experiment{
 					ID: common.GetPointer(int32(1)),
 				}, nil)
+				experimentRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(nil, nil)
 				experimentRepository.On(
 					"Update", context.TODO(), mock.AnythingOfType("*models.Experiment"),
 				).Return(errors.New("database error"))
@@ -3,6 +3,11 @@ package metric
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
@@ -42,7 +47,28 @@ func (s Service) GetMetricHistory(
 		return nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
 	}
 
-	metrics, err := s.metricRepository.GetMetricHistoryByRunIDAndKey(ctx, run.ID, req.MetricKey)
+	var pageToken *request.MetricHistoryPageToken
+	if req.PageToken != "" {
+		pageToken = &request.MetricHistoryPageToken{}
+		if err := json.NewDecoder(
+			base64.NewDecoder(
+				base64.StdEncoding,
+				strings.NewReader(req.PageToken),
+			),
+		).Decode(pageToken); err != nil {
+			return nil, api.NewInvalidParameterValueError("invalid page_token '%s': %s", req.PageToken, err)
+		}
+	}
+
+	// request one more metric to find out whether there is the next page.
+	limit := req.MaxResults
+	if limit > 0 {
+		limit++
+	}
+
+	metrics, err := s.metricRepository.GetMetricHistoryByRunIDAndKey(
+		ctx, run.ID, req.MetricKey, req.MetricHistoryWindow, pageToken, limit,
+	)
 	if err != nil {
 		return nil, api.NewInternalError(
 			"unable to get metric history for metric '%s' of run '%s'", req.MetricKey, req.GetRunID(),
@@ -63,6 +89,7 @@ func (s Service) GetMetricHistoryBulk(
 		namespace.ID,
 		req.RunIDs,
 		req.MetricKey,
+		req.MetricHistoryWindow,
 		req.MaxResults,
 	)
 	if err != nil {
@@ -90,6 +117,7 @@ func (s Service) GetMetricHistories(
 		req.ViewType,
 		req.MaxResults,
 		req.Context,
+		req.MetricHistoryWindow,
 	)
 	if err != nil {
 		return nil, nil, api.NewInternalError("Unable to search runs: %s", err)
@@ -97,3 +125,95 @@ func (s Service) GetMetricHistories(
 
 	return rows, iterator, nil
 }
+
+// DeleteMetric handles business logic of `POST /metrics/delete` endpoint. Metrics of the runs,
+// which aren't active, are deleted only when it is forced by the request.
+func (s Service) DeleteMetric(
+	ctx context.Context, namespace *models.Namespace, req *request.DeleteMetricRequest,
+) error {
+	if err := ValidateDeleteMetricRequest(req); err != nil {
+		return err
+	}
+
+	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.GetRunID())
+	if err != nil {
+		return api.NewInternalError("unable to find run '%s': %s", req.GetRunID(), err)
+	}
+	if run == nil {
+		return api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
+	}
+	if run.LifecycleStage != models.LifecycleStageActive && !req.Force {
+		return api.NewInvalidParameterValueError(
+			"run '%s' is not active, use 'force' to delete its metrics", run.ID,
+		)
+	}
+
+	var metricContext *models.Context
+	if req.Context != nil {
+		contextJSON, err := json.Marshal(req.Context)
+		if err != nil {
+			return api.NewInvalidParameterValueError("invalid context: %s", err)
+		}
+		metricContext = &models.Context{Json: contextJSON}
+	}
+
+	deleted, err := s.metricRepository.DeleteByRunIDAndKey(ctx, run.ID, req.Key, metricContext)
+	if err != nil {
+		return api.NewInternalError("unable to delete metric '%s' of run '%s': %s", req.Key, run.ID, err)
+	}
+	if deleted == 0 {
+		return api.NewResourceDoesNotExistError("unable to find metric '%s' of run '%s'", req.Key, run.ID)
+	}
+	return nil
+}
+
+// GetBestMetrics handles business logic of `POST /metrics/get-best` endpoint. It returns the best latest
+// value of the metric among the active runs of each of the requested experiments, in order of request.
+// Experiments, which runs don't have the metric, are returned without RunID.
+func (s Service) GetBestMetrics(
+	ctx context.Context, namespace *models.Namespace, req *request.GetBestMetricsRequest,
+) ([]repositories.ExperimentBestMetric, error) {
+	if err := ValidateGetBestMetricsRequest(req); err != nil {
+		return nil, err
+	}
+
+	experimentIDs := make([]int32, 0, len(req.ExperimentIDs))
+	for _, id := range req.ExperimentIDs {
+		experimentID, err := strconv.ParseInt(id, 10, 32)
+		if err != nil {
+			return nil, api.NewBadRequestError("Unable to parse experiment id '%s': %s", id, err)
+		}
+		if !slices.Contains(experimentIDs, int32(experimentID)) {
+			experimentIDs = append(experimentIDs, int32(experimentID))
+		}
+	}
+
+	metricContext := models.Context{Json: []byte("{}")}
+	if req.Context != nil {
+		contextJSON, err := json.Marshal(req.Context)
+		if err != nil {
+			return nil, api.NewInvalidParameterValueError("invalid context: %s", err)
+		}
+		metricContext.Json = contextJSON
+	}
+
+	bestMetrics, err := s.metricRepository.GetBestByExperimentIDs(
+		ctx, namespace.ID, experimentIDs, req.MetricKey, &metricContext, models.MetricDirection(req.Direction),
+	)
+	if err != nil {
+		return nil, api.NewInternalError("unable to get best values of metric '%s': %s", req.MetricKey, err)
+	}
+
+	bestMetricsMap := make(map[int32]repositories.ExperimentBestMetric, len(bestMetrics))
+	for _, bestMetric := range bestMetrics {
+		bestMetricsMap[bestMetric.ExperimentID] = bestMetric
+	}
+	result := make([]repositories.ExperimentBestMetric, len(experimentIDs))
+	for i, experimentID := range experimentIDs {
+		result[i] = repositories.ExperimentBestMetric{ExperimentID: experimentID}
+		if bestMetric, ok := bestMetricsMap[experimentID]; ok {
+			result[i] = bestMetric
+		}
+	}
+	return result, nil
+}
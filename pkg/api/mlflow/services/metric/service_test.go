@@ -35,6 +35,9 @@ func TestService_GetMetricHistory_Ok(t *testing.T) {
 		context.TODO(),
 		"1",
 		"key",
+		request.MetricHistoryWindow{},
+		(*request.MetricHistoryPageToken)(nil),
+		0,
 	).Return([]models.Metric{
 		{
 			Key:       "key",
@@ -108,6 +111,100 @@ func TestService_GetMetricHistory_Error(t *testing.T) {
 				return NewService(&runRepository, &metricRepository)
 			},
 		},
+		{
+			name:  "NegativeMaxResults",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied."),
+			request: &request.GetMetricHistoryRequest{
+				RunID:      "1",
+				MetricKey:  "key",
+				MaxResults: -1,
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
+		{
+			name: "IncorrectStepWindow",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'max_step' supplied. It should be greater than or equal to 'min_step'.",
+			),
+			request: &request.GetMetricHistoryRequest{
+				RunID:     "1",
+				MetricKey: "key",
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					MinStep: common.GetPointer[int64](10),
+					MaxStep: common.GetPointer[int64](5),
+				},
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
+		{
+			name: "IncorrectTimeWindow",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'end_time' supplied. It should be greater than or equal to 'start_time'.",
+			),
+			request: &request.GetMetricHistoryRequest{
+				RunID:     "1",
+				MetricKey: "key",
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					StartTime: common.GetPointer[int64](2000),
+					EndTime:   common.GetPointer[int64](1000),
+				},
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
+		{
+			name: "IncorrectStride",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'stride' supplied. It should be a positive number.",
+			),
+			request: &request.GetMetricHistoryRequest{
+				RunID:     "1",
+				MetricKey: "key",
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					Stride: -1,
+				},
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
+		{
+			name: "IncorrectPageToken",
+			error: api.NewInvalidParameterValueError(
+				"invalid page_token 'incorrect': invalid character '\\x8a' looking for beginning of value",
+			),
+			request: &request.GetMetricHistoryRequest{
+				RunID:     "1",
+				MetricKey: "key",
+				PageToken: "incorrect",
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDAndRunID",
+					context.TODO(),
+					uint(1),
+					"1",
+				).Return(&models.Run{
+					ID: "1",
+				}, nil)
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
 		{
 			name:  "GetMetricHistoryDatabaseError",
 			error: api.NewInternalError("unable to find run '1': database error"),
@@ -129,6 +226,9 @@ func TestService_GetMetricHistory_Error(t *testing.T) {
 					context.TODO(),
 					"1",
 					"key",
+					request.MetricHistoryWindow{},
+					(*request.MetricHistoryPageToken)(nil),
+					0,
 				).Return(nil, errors.New("database error"))
 				return NewService(&runRepository, &metricRepository)
 			},
@@ -155,6 +255,7 @@ func TestService_GetMetricHistoryBulk_Ok(t *testing.T) {
 		uint(1),
 		[]string{"1", "2"},
 		"key",
+		request.MetricHistoryWindow{},
 		10,
 	).Return([]models.Metric{
 		{
@@ -251,6 +352,7 @@ func TestService_GetMetricHistoryBulk_Error(t *testing.T) {
 					uint(1),
 					[]string{"1"},
 					"key",
+					request.MetricHistoryWindow{},
 					10,
 				).Return(nil, errors.New("database error"))
 				return NewService(&runRepository, &metricRepository)
@@ -336,6 +438,7 @@ func TestNewService_GetMetricHistories_Ok(t *testing.T) {
 				request.ViewTypeActiveOnly,
 				int32(1),
 				map[string]string(nil),
+				request.MetricHistoryWindow{},
 			).Return(
 				tt.expectedRows,
 				tt.expectedIter,
@@ -425,6 +528,7 @@ func TestNewService_GetMetricHistories_Error(t *testing.T) {
 					request.ViewTypeAll,
 					int32(1),
 					map[string]string(nil),
+					request.MetricHistoryWindow{},
 				).Return(
 					nil,
 					nil,
@@ -444,3 +548,206 @@ func TestNewService_GetMetricHistories_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestService_DeleteMetric_Ok(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         *request.DeleteMetricRequest
+		lifecycleStage  models.LifecycleStage
+		expectedContext *models.Context
+	}{
+		{
+			name:           "AllContexts",
+			request:        &request.DeleteMetricRequest{RunID: "1", Key: "key"},
+			lifecycleStage: models.LifecycleStageActive,
+		},
+		{
+			name: "SpecificContext",
+			request: &request.DeleteMetricRequest{
+				RunID: "1", Key: "key", Context: map[string]any{"subset": "train"},
+			},
+			lifecycleStage:  models.LifecycleStageActive,
+			expectedContext: &models.Context{Json: []byte(`{"subset":"train"}`)},
+		},
+		{
+			name:           "ForcedOnDeletedRun",
+			request:        &request.DeleteMetricRequest{RunID: "1", Key: "key", Force: true},
+			lifecycleStage: models.LifecycleStageDeleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// init repository mocks.
+			runRepository := repositories.MockRunRepositoryProvider{}
+			runRepository.On(
+				"GetByNamespaceIDAndRunID", context.TODO(), uint(1), "1",
+			).Return(&models.Run{ID: "1", LifecycleStage: tt.lifecycleStage}, nil)
+			metricRepository := repositories.MockMetricRepositoryProvider{}
+			metricRepository.On(
+				"DeleteByRunIDAndKey", context.TODO(), "1", "key", tt.expectedContext,
+			).Return(int64(1), nil)
+
+			// call service under testing.
+			service := NewService(&runRepository, &metricRepository)
+			err := service.DeleteMetric(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+
+			// compare results.
+			require.Nil(t, err)
+			metricRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_DeleteMetric_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.DeleteMetricRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.DeleteMetricRequest{Key: "key"},
+			service: func() *Service {
+				return NewService(&repositories.MockRunRepositoryProvider{}, &repositories.MockMetricRepositoryProvider{})
+			},
+		},
+		{
+			name:    "NotActiveRun",
+			error:   api.NewInvalidParameterValueError("run '1' is not active, use 'force' to delete its metrics"),
+			request: &request.DeleteMetricRequest{RunID: "1", Key: "key"},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDAndRunID", context.TODO(), uint(1), "1",
+				).Return(&models.Run{ID: "1", LifecycleStage: models.LifecycleStageDeleted}, nil)
+				return NewService(&runRepository, &repositories.MockMetricRepositoryProvider{})
+			},
+		},
+		{
+			name:    "NotFoundMetric",
+			error:   api.NewResourceDoesNotExistError("unable to find metric 'key' of run '1'"),
+			request: &request.DeleteMetricRequest{RunID: "1", Key: "key"},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDAndRunID", context.TODO(), uint(1), "1",
+				).Return(&models.Run{ID: "1", LifecycleStage: models.LifecycleStageActive}, nil)
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				metricRepository.On(
+					"DeleteByRunIDAndKey", context.TODO(), "1", "key", (*models.Context)(nil),
+				).Return(int64(0), nil)
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
+		{
+			name:    "DatabaseError",
+			error:   api.NewInternalError("unable to delete metric 'key' of run '1': database error"),
+			request: &request.DeleteMetricRequest{RunID: "1", Key: "key"},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDAndRunID", context.TODO(), uint(1), "1",
+				).Return(&models.Run{ID: "1", LifecycleStage: models.LifecycleStageActive}, nil)
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				metricRepository.On(
+					"DeleteByRunIDAndKey", context.TODO(), "1", "key", (*models.Context)(nil),
+				).Return(int64(0), errors.New("database error"))
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.service().DeleteMetric(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_GetBestMetrics_Ok(t *testing.T) {
+	// init repository mocks.
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On(
+		"GetBestByExperimentIDs",
+		context.TODO(),
+		uint(1),
+		[]int32{1, 2},
+		"loss",
+		&models.Context{Json: []byte(`{"subset":"train"}`)},
+		models.MetricDirectionMin,
+	).Return([]repositories.ExperimentBestMetric{
+		{ExperimentID: 2, RunID: "run2", Value: 0.3},
+	}, nil)
+
+	// call service under testing.
+	service := NewService(&repositories.MockRunRepositoryProvider{}, &metricRepository)
+	bestMetrics, err := service.GetBestMetrics(
+		context.TODO(),
+		&models.Namespace{ID: 1},
+		&request.GetBestMetricsRequest{
+			ExperimentIDs: []string{"1", "2", "1"},
+			MetricKey:     "loss",
+			Context:       map[string]any{"subset": "train"},
+			Direction:     "min",
+		},
+	)
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, []repositories.ExperimentBestMetric{
+		{ExperimentID: 1},
+		{ExperimentID: 2, RunID: "run2", Value: 0.3},
+	}, bestMetrics)
+}
+
+func TestService_GetBestMetrics_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.GetBestMetricsRequest
+		service func() *Service
+	}{
+		{
+			name: "IncorrectExperimentID",
+			error: api.NewBadRequestError(
+				`Unable to parse experiment id 'id': strconv.ParseInt: parsing "id": invalid syntax`,
+			),
+			request: &request.GetBestMetricsRequest{
+				ExperimentIDs: []string{"id"}, MetricKey: "loss", Direction: "max",
+			},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockRunRepositoryProvider{}, &repositories.MockMetricRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "DatabaseError",
+			error: api.NewInternalError("unable to get best values of metric 'loss': database error"),
+			request: &request.GetBestMetricsRequest{
+				ExperimentIDs: []string{"1"}, MetricKey: "loss", Direction: "max",
+			},
+			service: func() *Service {
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				metricRepository.On(
+					"GetBestByExperimentIDs",
+					context.TODO(),
+					uint(1),
+					[]int32{1},
+					"loss",
+					&models.Context{Json: []byte(`{}`)},
+					models.MetricDirectionMax,
+				).Return(nil, errors.New("database error"))
+				return NewService(&repositories.MockRunRepositoryProvider{}, &metricRepository)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().GetBestMetrics(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package metric
 
 import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
@@ -28,7 +29,10 @@ func ValidateGetMetricHistoryRequest(req *request.GetMetricHistoryRequest) error
 	if req.MetricKey == "" {
 		return api.NewInvalidParameterValueError("Missing value for required parameter 'metric_key'")
 	}
-	return nil
+	if req.MaxResults < 0 {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied.")
+	}
+	return validateMetricHistoryWindow(&req.MetricHistoryWindow)
 }
 
 // ValidateGetMetricHistoryBulkRequest validates `GET /mlflow/metrics/get-history-bulk` request.
@@ -46,7 +50,7 @@ func ValidateGetMetricHistoryBulkRequest(req *request.GetMetricHistoryBulkReques
 	if req.MetricKey == "" {
 		return api.NewInvalidParameterValueError("GetMetricHistoryBulk request must specify a metric_key.")
 	}
-	return nil
+	return validateMetricHistoryWindow(&req.MetricHistoryWindow)
 }
 
 // ValidateGetMetricHistoriesRequest validates `GET /mlflow/metrics/get-histories` request.
@@ -66,5 +70,53 @@ func ValidateGetMetricHistoriesRequest(req *request.GetMetricHistoriesRequest) e
 	if req.MaxResults > MaxResultsForMetricHistoriesRequest {
 		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied.")
 	}
+	return validateMetricHistoryWindow(&req.MetricHistoryWindow)
+}
+
+// ValidateGetBestMetricsRequest validates `POST /mlflow/metrics/get-best` request.
+func ValidateGetBestMetricsRequest(req *request.GetBestMetricsRequest) error {
+	if len(req.ExperimentIDs) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'")
+	}
+	if req.MetricKey == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'metric_key'")
+	}
+	if req.Direction != string(models.MetricDirectionMin) && req.Direction != string(models.MetricDirectionMax) {
+		return api.NewInvalidParameterValueError(
+			"Invalid value '%s' for parameter 'direction' supplied. It should be 'min' or 'max'.", req.Direction,
+		)
+	}
+	return nil
+}
+
+// validateMetricHistoryWindow validates that bounds of the requested metric history window don't contradict
+// and that stride, if any, is positive.
+func validateMetricHistoryWindow(window *request.MetricHistoryWindow) error {
+	if window.MinStep != nil && window.MaxStep != nil && *window.MinStep > *window.MaxStep {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'max_step' supplied. It should be greater than or equal to 'min_step'.",
+		)
+	}
+	if window.StartTime != nil && window.EndTime != nil && *window.StartTime > *window.EndTime {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'end_time' supplied. It should be greater than or equal to 'start_time'.",
+		)
+	}
+	if window.Stride < 0 {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'stride' supplied. It should be a positive number.",
+		)
+	}
+	return nil
+}
+
+// ValidateDeleteMetricRequest validates `POST /mlflow/metrics/delete` request.
+func ValidateDeleteMetricRequest(req *request.DeleteMetricRequest) error {
+	if req.RunID == "" && req.RunUUID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+	}
 	return nil
 }
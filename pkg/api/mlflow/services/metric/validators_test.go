@@ -143,3 +143,45 @@ func TestValidateGetMetricHistoriesRequest_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGetBestMetricsRequest_Ok(t *testing.T) {
+	err := ValidateGetBestMetricsRequest(&request.GetBestMetricsRequest{
+		ExperimentIDs: []string{"1"},
+		MetricKey:     "loss",
+		Direction:     "min",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateGetBestMetricsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.GetBestMetricsRequest
+	}{
+		{
+			name:    "EmptyExperimentIDs",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'"),
+			request: &request.GetBestMetricsRequest{MetricKey: "loss", Direction: "min"},
+		},
+		{
+			name:    "EmptyMetricKey",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'metric_key'"),
+			request: &request.GetBestMetricsRequest{ExperimentIDs: []string{"1"}, Direction: "min"},
+		},
+		{
+			name: "IncorrectDirection",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value '' for parameter 'direction' supplied. It should be 'min' or 'max'.",
+			),
+			request: &request.GetBestMetricsRequest{ExperimentIDs: []string{"1"}, MetricKey: "loss"},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGetBestMetricsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+// Service provides service layer to work with run `retention` business logic.
+type Service struct {
+	runRepository       repositories.RunRepositoryProvider
+	namespaceRepository repositories.NamespaceRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	runRepository repositories.RunRepositoryProvider,
+	namespaceRepository repositories.NamespaceRepositoryProvider,
+) *Service {
+	return &Service{
+		runRepository:       runRepository,
+		namespaceRepository: namespaceRepository,
+	}
+}
+
+// Start applies retention policies periodically, with the provided interval, until context is done.
+func (s Service) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Debugf("retention scheduler finished. exiting.")
+				return
+			case <-ticker.C:
+				if err := s.ApplyPolicies(ctx); err != nil {
+					log.Errorf("error applying retention policies: %+v", err)
+				}
+			}
+		}
+	}()
+}
+
+// ApplyPolicies applies retention policy of each Namespace which has it configured.
+func (s Service) ApplyPolicies(ctx context.Context) error {
+	namespaces, err := s.namespaceRepository.List(ctx)
+	if err != nil {
+		return eris.Wrap(err, "error getting namespaces")
+	}
+
+	for _, namespace := range namespaces {
+		if !namespace.RetentionPolicy.IsEnabled() {
+			continue
+		}
+		// policies could have been stored by the previous versions without validation,
+		// so invalid one is skipped and doesn't block the policies of other namespaces.
+		if err := namespace.RetentionPolicy.Validate(); err != nil {
+			log.Errorf("skipping invalid retention policy of namespace %s: %s", namespace.Code, err)
+			continue
+		}
+		archived, err := s.ApplyPolicy(ctx, &namespace, time.Now().UTC())
+		if err != nil {
+			return eris.Wrapf(err, "error applying retention policy of namespace: %s", namespace.Code)
+		}
+		if archived > 0 {
+			log.Infof("retention policy archived %d runs in namespace: %s", archived, namespace.Code)
+		}
+	}
+	return nil
+}
+
+// ApplyPolicy archives runs which don't satisfy Namespace retention policy and returns number of archived runs.
+func (s Service) ApplyPolicy(ctx context.Context, namespace *models.Namespace, now time.Time) (int, error) {
+	policy := namespace.RetentionPolicy
+	if err := policy.Validate(); err != nil {
+		return 0, eris.Wrap(err, "error validating retention policy")
+	}
+
+	var ids []string
+	if policy.HasMaxAge() {
+		startTime := now.AddDate(0, 0, -int(*policy.MaxAgeDays)).UnixMilli()
+		expiredIDs, err := s.runRepository.GetActiveIDsByNamespaceIDAndStartTime(ctx, namespace.ID, startTime)
+		if err != nil {
+			return 0, eris.Wrap(err, "error getting expired runs")
+		}
+		ids = append(ids, expiredIDs...)
+	}
+
+	if policy.HasKeepBest() {
+		rankedIDs, err := s.runRepository.GetActiveIDsByNamespaceIDOrderedByMetric(
+			ctx, namespace.ID, policy.MetricKey, policy.GetMetricDirection(),
+		)
+		if err != nil {
+			return 0, eris.Wrap(err, "error getting runs ordered by metric")
+		}
+		if keepBest := int(*policy.KeepBest); len(rankedIDs) > keepBest {
+			ids = append(ids, rankedIDs[keepBest:]...)
+		}
+	}
+
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := s.runRepository.ArchiveBatch(ctx, namespace.ID, ids); err != nil {
+		return 0, eris.Wrap(err, "error archiving runs")
+	}
+	return len(ids), nil
+}
+
+// uniqueIDs removes duplicated ids, keeping the original order.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
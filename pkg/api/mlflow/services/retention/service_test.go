@@ -0,0 +1,197 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+func TestService_ApplyPolicy_MaxAge_Ok(t *testing.T) {
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+		RetentionPolicy: models.RetentionPolicy{
+			MaxAgeDays: common.GetPointer[int32](7),
+		},
+	}
+
+	// init repository mocks.
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetActiveIDsByNamespaceIDAndStartTime", context.TODO(), ns.ID, now.AddDate(0, 0, -7).UnixMilli(),
+	).Return([]string{"id1", "id2"}, nil)
+	runRepository.On(
+		"ArchiveBatch", context.TODO(), ns.ID, []string{"id1", "id2"},
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(&runRepository, &repositories.MockNamespaceRepositoryProvider{})
+	archived, err := service.ApplyPolicy(context.TODO(), &ns, now)
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, 2, archived)
+	runRepository.AssertExpectations(t)
+}
+
+func TestService_ApplyPolicy_KeepBest_Ok(t *testing.T) {
+	testData := []struct {
+		name        string
+		keepBest    int32
+		rankedIDs   []string
+		archivedIDs []string
+	}{
+		{
+			name:        "MoreRunsThanKeepBest",
+			keepBest:    2,
+			rankedIDs:   []string{"id1", "id2", "id3", "id4"},
+			archivedIDs: []string{"id3", "id4"},
+		},
+		{
+			name:        "LessRunsThanKeepBest",
+			keepBest:    5,
+			rankedIDs:   []string{"id1", "id2"},
+			archivedIDs: nil,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := models.Namespace{
+				ID:   1,
+				Code: "code",
+				RetentionPolicy: models.RetentionPolicy{
+					KeepBest:        common.GetPointer(tt.keepBest),
+					MetricKey:       "loss",
+					MetricDirection: models.MetricDirectionMin,
+				},
+			}
+
+			// init repository mocks.
+			runRepository := repositories.MockRunRepositoryProvider{}
+			runRepository.On(
+				"GetActiveIDsByNamespaceIDOrderedByMetric", context.TODO(), ns.ID, "loss", models.MetricDirectionMin,
+			).Return(tt.rankedIDs, nil)
+			if tt.archivedIDs != nil {
+				runRepository.On(
+					"ArchiveBatch", context.TODO(), ns.ID, tt.archivedIDs,
+				).Return(nil)
+			}
+
+			// call service under testing.
+			service := NewService(&runRepository, &repositories.MockNamespaceRepositoryProvider{})
+			archived, err := service.ApplyPolicy(context.TODO(), &ns, time.Now())
+
+			// compare results.
+			require.Nil(t, err)
+			assert.Equal(t, len(tt.archivedIDs), archived)
+			runRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_ApplyPolicy_MaxAgeAndKeepBest_Ok(t *testing.T) {
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+		RetentionPolicy: models.RetentionPolicy{
+			MaxAgeDays:      common.GetPointer[int32](30),
+			KeepBest:        common.GetPointer[int32](1),
+			MetricKey:       "accuracy",
+			MetricDirection: models.MetricDirectionMax,
+		},
+	}
+
+	// init repository mocks.
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetActiveIDsByNamespaceIDAndStartTime", context.TODO(), ns.ID, now.AddDate(0, 0, -30).UnixMilli(),
+	).Return([]string{"id3"}, nil)
+	runRepository.On(
+		"GetActiveIDsByNamespaceIDOrderedByMetric", context.TODO(), ns.ID, "accuracy", models.MetricDirectionMax,
+	).Return([]string{"id1", "id2", "id3"}, nil)
+	runRepository.On(
+		"ArchiveBatch", context.TODO(), ns.ID, []string{"id3", "id2"},
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(&runRepository, &repositories.MockNamespaceRepositoryProvider{})
+	archived, err := service.ApplyPolicy(context.TODO(), &ns, now)
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, 2, archived)
+	runRepository.AssertExpectations(t)
+}
+
+func TestService_ApplyPolicies_Ok(t *testing.T) {
+	// init repository mocks.
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On("List", context.TODO()).Return([]models.Namespace{
+		{
+			ID:   1,
+			Code: "without-policy",
+		},
+		{
+			ID:   2,
+			Code: "with-policy",
+			RetentionPolicy: models.RetentionPolicy{
+				KeepBest:        common.GetPointer[int32](1),
+				MetricKey:       "loss",
+				MetricDirection: models.MetricDirectionMin,
+			},
+		},
+		{
+			ID:   3,
+			Code: "with-invalid-policy",
+			RetentionPolicy: models.RetentionPolicy{
+				KeepBest:        common.GetPointer[int32](1),
+				MetricKey:       "loss",
+				MetricDirection: "up",
+			},
+		},
+		{
+			ID:   4,
+			Code: "with-default-direction",
+			RetentionPolicy: models.RetentionPolicy{
+				KeepBest:  common.GetPointer[int32](2),
+				MetricKey: "loss",
+			},
+		},
+	}, nil)
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetActiveIDsByNamespaceIDOrderedByMetric", context.TODO(), uint(4), "loss", models.MetricDirectionMin,
+	).Return([]string{"id3", "id4"}, nil)
+	runRepository.On(
+		"GetActiveIDsByNamespaceIDOrderedByMetric", context.TODO(), uint(2), "loss", models.MetricDirectionMin,
+	).Return([]string{"id1", "id2"}, nil)
+	runRepository.On(
+		"ArchiveBatch", context.TODO(), uint(2), []string{"id2"},
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(&runRepository, &namespaceRepository)
+	require.Nil(t, service.ApplyPolicies(context.TODO()))
+	runRepository.AssertExpectations(t)
+}
+
+func TestService_ApplyPolicies_Error(t *testing.T) {
+	// init repository mocks.
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On("List", context.TODO()).Return(nil, eris.New("database error"))
+
+	// call service under testing.
+	service := NewService(&repositories.MockRunRepositoryProvider{}, &namespaceRepository)
+	assert.NotNil(t, service.ApplyPolicies(context.TODO()))
+}
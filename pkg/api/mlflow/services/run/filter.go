@@ -0,0 +1,480 @@
+package run
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// RunDurationAttribute is the attribute of the run duration in milliseconds.
+const RunDurationAttribute = "duration"
+
+// supported filter entity list.
+const (
+	FilterEntityAttribute = "attribute"
+	FilterEntityMetric    = "metric"
+	FilterEntityParam     = "param"
+	FilterEntityTag       = "tag"
+)
+
+// FilterCondition represents single condition of the search filter.
+// Key and Value are never a part of SQL query text and have to be passed as query parameters.
+// Entity and Operator are always one of the supported values, so they are safe to be used in SQL query text.
+// For FilterEntityAttribute, Key is a column of `runs` table, or RunDurationAttribute, which isn't stored
+// and is calculated from start and end times, so it's also safe to be used in SQL query text.
+type FilterCondition struct {
+	Entity   string
+	Key      string
+	Operator string
+	Value    any
+}
+
+// OrderByClause represents single clause of the search order.
+// Key follows the same rules as FilterCondition.Key.
+type OrderByClause struct {
+	Entity string
+	Key    string
+	Desc   bool
+}
+
+// ParseFilter parses MLflow search filter, like `metrics.accuracy > 0.9 and params.model = "resnet"`,
+// into the list of conditions, which have to be satisfied all together.
+func ParseFilter(filter string) ([]FilterCondition, error) {
+	p, err := newFilterParser(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []FilterCondition
+	for {
+		condition, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, *condition)
+		if p.peek().kind == filterTokenEOF {
+			return conditions, nil
+		}
+		if !p.next().isKeyword("AND") {
+			return nil, p.malformed()
+		}
+	}
+}
+
+// ParseOrderBy parses MLflow search order clause, like `metrics.accuracy DESC`.
+func ParseOrderBy(orderBy string) (*OrderByClause, error) {
+	p, err := newFilterParser(orderBy)
+	if err != nil {
+		return nil, api.NewInvalidParameterValueError("invalid order_by clause '%s'", orderBy)
+	}
+
+	entity, key, ok := p.parseEntityKey()
+	if !ok {
+		return nil, api.NewInvalidParameterValueError("invalid order_by clause '%s'", orderBy)
+	}
+	clause := OrderByClause{
+		Key: key,
+	}
+	switch token := p.next(); {
+	case token.kind == filterTokenEOF:
+	case token.isKeyword("ASC") && p.peek().kind == filterTokenEOF:
+	case token.isKeyword("DESC") && p.peek().kind == filterTokenEOF:
+		clause.Desc = true
+	default:
+		return nil, api.NewInvalidParameterValueError("invalid order_by clause '%s'", orderBy)
+	}
+
+	switch clause.Entity = normalizeFilterEntity(entity); clause.Entity {
+	case FilterEntityAttribute:
+		switch key {
+		case "start_time", "end_time", "status", "user_id", "artifact_uri", "experiment_id", RunDurationAttribute:
+		case "run_id":
+			clause.Key = "run_uuid"
+		case "run_name":
+			clause.Key = "name"
+		default:
+			return nil, api.NewInvalidParameterValueError(
+				`invalid order_by attribute '%s'. Valid values are `+
+					`['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id', 'experiment_id', `+
+					`'duration']`,
+				key,
+			)
+		}
+	case FilterEntityMetric, FilterEntityParam, FilterEntityTag:
+	default:
+		return nil, api.NewInvalidParameterValueError(
+			"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute']", entity,
+		)
+	}
+	return &clause, nil
+}
+
+// normalizeFilterEntity converts all the supported entity aliases into the entity name.
+func normalizeFilterEntity(entity string) string {
+	switch entity {
+	case "", "attribute", "attributes", "attr", "run":
+		return FilterEntityAttribute
+	case "metric", "metrics":
+		return FilterEntityMetric
+	case "parameter", "parameters", "param", "params":
+		return FilterEntityParam
+	case "tag", "tags":
+		return FilterEntityTag
+	}
+	return entity
+}
+
+// filterTokenKind represents kind of the filter token.
+type filterTokenKind int
+
+// supported filter token kinds.
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenIdentifier
+	filterTokenNumber
+	filterTokenString
+	filterTokenQuotedIdentifier
+	filterTokenOperator
+	filterTokenLeftParen
+	filterTokenRightParen
+	filterTokenComma
+)
+
+// filterToken represents single token of the filter.
+type filterToken struct {
+	kind filterTokenKind
+	// value is a token value. For strings and quoted identifiers quotes are removed and escapes are resolved.
+	value string
+	// text is an original text of the token.
+	text string
+}
+
+// isKeyword checks that token is the provided keyword, case-insensitively.
+func (t filterToken) isKeyword(keyword string) bool {
+	return t.kind == filterTokenIdentifier && strings.EqualFold(t.value, keyword)
+}
+
+// filterParser represents parser of the filter tokens.
+type filterParser struct {
+	filter   string
+	tokens   []filterToken
+	position int
+}
+
+// newFilterParser creates new filterParser instance for the provided filter.
+func newFilterParser(filter string) (*filterParser, error) {
+	p := filterParser{
+		filter: filter,
+	}
+	tokens, ok := tokenizeFilter(filter)
+	if !ok {
+		return nil, p.malformed()
+	}
+	p.tokens = tokens
+	return &p, nil
+}
+
+// peek returns current token.
+func (p *filterParser) peek() filterToken {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position]
+	}
+	return filterToken{kind: filterTokenEOF}
+}
+
+// next returns current token and moves to the next one.
+func (p *filterParser) next() filterToken {
+	token := p.peek()
+	if p.position < len(p.tokens) {
+		p.position++
+	}
+	return token
+}
+
+// malformed returns error about malformed filter.
+func (p *filterParser) malformed() error {
+	return api.NewInvalidParameterValueError("malformed filter '%s'", p.filter)
+}
+
+// parseEntityKey parses `entity.key` expression. Key could be quoted, like metrics."my metric",
+// and could contain dots, like tags.mlflow.runName. Entity is empty when it is omitted.
+func (p *filterParser) parseEntityKey() (string, string, bool) {
+	switch token := p.next(); token.kind {
+	case filterTokenQuotedIdentifier, filterTokenString:
+		return "", token.value, token.value != ""
+	case filterTokenIdentifier:
+		if entity, ok := strings.CutSuffix(token.value, "."); ok {
+			key := p.next()
+			if key.kind != filterTokenQuotedIdentifier && key.kind != filterTokenString || key.value == "" {
+				return "", "", false
+			}
+			return entity, key.value, true
+		}
+		entity, key, found := strings.Cut(token.value, ".")
+		if !found {
+			return "", entity, true
+		}
+		return entity, key, key != ""
+	}
+	return "", "", false
+}
+
+// parseOperator parses comparison operator.
+func (p *filterParser) parseOperator() (string, bool) {
+	token := p.next()
+	switch {
+	case token.kind == filterTokenOperator:
+		return token.value, true
+	case token.isKeyword(LikeExpression), token.isKeyword(ILikeExpression), token.isKeyword(InExpression):
+		return strings.ToUpper(token.value), true
+	case token.isKeyword("NOT") && p.peek().isKeyword(InExpression):
+		p.next()
+		return NotInExpression, true
+	}
+	return "", false
+}
+
+// parseValue parses either single value or, for IN operators, the list of values.
+func (p *filterParser) parseValue() ([]filterToken, bool, bool) {
+	token := p.next()
+	switch token.kind {
+	case filterTokenString, filterTokenNumber, filterTokenIdentifier:
+		return []filterToken{token}, false, true
+	case filterTokenLeftParen:
+		var values []filterToken
+		for {
+			value := p.next()
+			switch value.kind {
+			case filterTokenString, filterTokenNumber, filterTokenIdentifier:
+				values = append(values, value)
+			default:
+				return nil, false, false
+			}
+			switch p.next().kind {
+			case filterTokenComma:
+			case filterTokenRightParen:
+				return values, true, true
+			default:
+				return nil, false, false
+			}
+		}
+	}
+	return nil, false, false
+}
+
+// parseCondition parses single condition, like `metrics.accuracy > 0.9`.
+//
+//nolint:gocyclo
+func (p *filterParser) parseCondition() (*FilterCondition, error) {
+	entity, key, ok := p.parseEntityKey()
+	if !ok {
+		return nil, p.malformed()
+	}
+	operator, ok := p.parseOperator()
+	if !ok {
+		return nil, p.malformed()
+	}
+	values, isList, ok := p.parseValue()
+	if !ok {
+		return nil, p.malformed()
+	}
+	text := values[0].text
+	if isList {
+		texts := make([]string, len(values))
+		for i, value := range values {
+			texts[i] = value.text
+		}
+		text = fmt.Sprintf("(%s)", strings.Join(texts, ", "))
+	}
+
+	condition := FilterCondition{
+		Entity:   normalizeFilterEntity(entity),
+		Key:      key,
+		Operator: operator,
+	}
+	switch condition.Entity {
+	case FilterEntityAttribute:
+		switch key {
+		case "start_time", "end_time", RunDurationAttribute:
+			if !isNumericFilterOperator(operator) {
+				return nil, api.NewInvalidParameterValueError(
+					"invalid numeric attribute comparison operator '%s'", operator,
+				)
+			}
+			if values[0].kind != filterTokenNumber {
+				return nil, api.NewInvalidParameterValueError("invalid numeric value '%s'", text)
+			}
+			value, err := strconv.ParseInt(values[0].value, 10, 64)
+			if err != nil {
+				return nil, api.NewInvalidParameterValueError("invalid numeric value '%s'", text)
+			}
+			condition.Value = value
+			return &condition, nil
+		case "run_name":
+			condition.Entity, condition.Key = FilterEntityTag, "mlflow.runName"
+		case "run_id":
+			condition.Key = "run_uuid"
+		case "status", "user_id", "artifact_uri":
+		default:
+			return nil, api.NewInvalidParameterValueError(
+				`invalid attribute '%s'. `+
+					`Valid values are ['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id', `+
+					`'duration']`,
+				key,
+			)
+		}
+		if !isStringFilterOperator(operator) {
+			return nil, api.NewInvalidParameterValueError(
+				"invalid string attribute comparison operator '%s'", operator,
+			)
+		}
+	case FilterEntityMetric:
+		if !isNumericFilterOperator(operator) {
+			return nil, api.NewInvalidParameterValueError("invalid metric comparison operator '%s'", operator)
+		}
+		if values[0].kind != filterTokenNumber && values[0].kind != filterTokenIdentifier {
+			return nil, api.NewInvalidParameterValueError("invalid numeric value '%s'", text)
+		}
+		// identifiers are allowed to support values like `nan` or `inf`.
+		value, err := strconv.ParseFloat(values[0].value, 64)
+		if err != nil {
+			return nil, api.NewInvalidParameterValueError("invalid numeric value '%s'", text)
+		}
+		condition.Value = value
+		return &condition, nil
+	case FilterEntityParam:
+		if !isStringFilterOperator(operator) {
+			return nil, api.NewInvalidParameterValueError("invalid param comparison operator '%s'", operator)
+		}
+	case FilterEntityTag:
+		if !isStringFilterOperator(operator) {
+			return nil, api.NewInvalidParameterValueError("invalid tag comparison operator '%s'", operator)
+		}
+	default:
+		return nil, api.NewInvalidParameterValueError(
+			"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute']", entity,
+		)
+	}
+
+	// string values.
+	switch operator {
+	case InExpression, NotInExpression:
+		if !isList {
+			return nil, api.NewInvalidParameterValueError("invalid list definition '%s'", text)
+		}
+		list := make([]string, len(values))
+		for i, value := range values {
+			list[i] = value.value
+		}
+		condition.Value = list
+	default:
+		if isList {
+			return nil, api.NewInvalidParameterValueError("invalid string value '%s'", text)
+		}
+		condition.Value = values[0].value
+	}
+	return &condition, nil
+}
+
+// isNumericFilterOperator checks that operator could be applied to numeric values.
+func isNumericFilterOperator(operator string) bool {
+	switch operator {
+	case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
+		EqualExpression, LessExpression, LessOrEqualExpression:
+		return true
+	}
+	return false
+}
+
+// isStringFilterOperator checks that operator could be applied to string values.
+func isStringFilterOperator(operator string) bool {
+	switch operator {
+	case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression, InExpression, NotInExpression:
+		return true
+	}
+	return false
+}
+
+// tokenizeFilter splits filter into the tokens. Returns false, if filter contains unsupported characters
+// or unterminated quotes.
+//
+//nolint:gocyclo
+func tokenizeFilter(filter string) ([]filterToken, bool) {
+	var tokens []filterToken
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: filterTokenLeftParen, value: "(", text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: filterTokenRightParen, value: ")", text: ")"})
+			i++
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: filterTokenComma, value: ",", text: ","})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			text := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				text += "="
+			}
+			operator := text
+			switch text {
+			case "==":
+				operator = EqualExpression
+			case "!":
+				return nil, false
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenOperator, value: operator, text: text})
+			i += len(text)
+		case r == '\'' || r == '"' || r == '`':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				// backslash escapes the next character, including quote.
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				value.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, false
+			}
+			kind := filterTokenString
+			if r == '`' {
+				kind = filterTokenQuotedIdentifier
+			}
+			tokens = append(tokens, filterToken{kind: kind, value: value.String(), text: string(runes[i : j+1])})
+			i = j + 1
+		case unicode.IsDigit(r) || r == '-' || r == '+' || r == '.':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				c := runes[j]
+				if !unicode.IsDigit(c) && c != '.' && c != 'e' && c != 'E' &&
+					!((c == '-' || c == '+') && (runes[j-1] == 'e' || runes[j-1] == 'E')) {
+					break
+				}
+			}
+			text := string(runes[i:j])
+			tokens = append(tokens, filterToken{kind: filterTokenNumber, value: text, text: text})
+			i = j
+		case r == '_' || unicode.IsLetter(r):
+			j := i + 1
+			for ; j < len(runes) && (runes[j] == '_' || runes[j] == '.' || unicode.IsLetter(runes[j]) ||
+				unicode.IsDigit(runes[j])); j++ {
+			}
+			text := string(runes[i:j])
+			tokens = append(tokens, filterToken{kind: filterTokenIdentifier, value: text, text: text})
+			i = j
+		default:
+			return nil, false
+		}
+	}
+	return tokens, true
+}
@@ -0,0 +1,254 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestParseFilter_Ok(t *testing.T) {
+	testData := []struct {
+		name       string
+		filter     string
+		conditions []FilterCondition
+	}{
+		{
+			name:   "MetricAndParam",
+			filter: `metrics.accuracy > 0.9 and params.model = "resnet"`,
+			conditions: []FilterCondition{
+				{Entity: FilterEntityMetric, Key: "accuracy", Operator: GraterExpression, Value: 0.9},
+				{Entity: FilterEntityParam, Key: "model", Operator: EqualExpression, Value: "resnet"},
+			},
+		},
+		{
+			name:   "AttributesWithAliases",
+			filter: `start_time >= 1000 AND attr.status != 'FAILED' AND run.run_id = 'id' AND run_name = 'name'`,
+			conditions: []FilterCondition{
+				{Entity: FilterEntityAttribute, Key: "start_time", Operator: GraterOrEqualExpression, Value: int64(1000)},
+				{Entity: FilterEntityAttribute, Key: "status", Operator: NotEqualExpression, Value: "FAILED"},
+				{Entity: FilterEntityAttribute, Key: "run_uuid", Operator: EqualExpression, Value: "id"},
+				{Entity: FilterEntityTag, Key: "mlflow.runName", Operator: EqualExpression, Value: "name"},
+			},
+		},
+		{
+			name:   "Duration",
+			filter: `attributes.duration >= 60000`,
+			conditions: []FilterCondition{
+				{Entity: FilterEntityAttribute, Key: RunDurationAttribute, Operator: GraterOrEqualExpression, Value: int64(60000)},
+			},
+		},
+		{
+			name:   "QuotedKeysAndDottedKey",
+			filter: "metrics.\"my metric\" <= -1.5e-3 and tags.`my tag` LIKE '%value%' and tags.mlflow.user ilike 'User'",
+			conditions: []FilterCondition{
+				{Entity: FilterEntityMetric, Key: "my metric", Operator: LessOrEqualExpression, Value: -1.5e-3},
+				{Entity: FilterEntityTag, Key: "my tag", Operator: LikeExpression, Value: "%value%"},
+				{Entity: FilterEntityTag, Key: "mlflow.user", Operator: ILikeExpression, Value: "User"},
+			},
+		},
+		{
+			name:   "InAndNotIn",
+			filter: `params.optimizer IN ('adam', "sgd") AND tags.env not in ('dev') AND attributes.run_id IN ('id1','id2')`,
+			conditions: []FilterCondition{
+				{Entity: FilterEntityParam, Key: "optimizer", Operator: InExpression, Value: []string{"adam", "sgd"}},
+				{Entity: FilterEntityTag, Key: "env", Operator: NotInExpression, Value: []string{"dev"}},
+				{Entity: FilterEntityAttribute, Key: "run_uuid", Operator: InExpression, Value: []string{"id1", "id2"}},
+			},
+		},
+		{
+			name:   "KeywordsAndEscapesInsideValues",
+			filter: `params.query = 'a AND b = \'c\'' and params.other = "1) OR (1 = 1"`,
+			conditions: []FilterCondition{
+				{Entity: FilterEntityParam, Key: "query", Operator: EqualExpression, Value: "a AND b = 'c'"},
+				{Entity: FilterEntityParam, Key: "other", Operator: EqualExpression, Value: "1) OR (1 = 1"},
+			},
+		},
+		{
+			name:   "InjectionAttemptStaysValue",
+			filter: `params.model = 'x\'; DROP TABLE runs; --' AND params."k' OR '1'='1" = 'v'`,
+			conditions: []FilterCondition{
+				{Entity: FilterEntityParam, Key: "model", Operator: EqualExpression, Value: "x'; DROP TABLE runs; --"},
+				{Entity: FilterEntityParam, Key: "k' OR '1'='1", Operator: EqualExpression, Value: "v"},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := ParseFilter(tt.filter)
+			require.Nil(t, err)
+			assert.Equal(t, tt.conditions, conditions)
+		})
+	}
+}
+
+func TestParseFilter_Error(t *testing.T) {
+	testData := []struct {
+		name   string
+		filter string
+		error  *api.ErrorResponse
+	}{
+		{
+			name:   "UnquotedInjection",
+			filter: `metrics.accuracy > 0.9; DROP TABLE runs`,
+			error:  api.NewInvalidParameterValueError("malformed filter 'metrics.accuracy > 0.9; DROP TABLE runs'"),
+		},
+		{
+			name:   "InjectionWithOr",
+			filter: `params.model = 'a' OR 1 = 1`,
+			error:  api.NewInvalidParameterValueError("malformed filter 'params.model = 'a' OR 1 = 1'"),
+		},
+		{
+			name:   "UnterminatedString",
+			filter: `params.model = 'a`,
+			error:  api.NewInvalidParameterValueError("malformed filter 'params.model = 'a'"),
+		},
+		{
+			name:   "MissingValue",
+			filter: `params.model =`,
+			error:  api.NewInvalidParameterValueError("malformed filter 'params.model ='"),
+		},
+		{
+			name:   "InvalidEntity",
+			filter: `unknown.key = 'a'`,
+			error: api.NewInvalidParameterValueError(
+				"invalid entity type 'unknown'. Valid values are ['metric', 'parameter', 'tag', 'attribute']",
+			),
+		},
+		{
+			name:   "InvalidAttribute",
+			filter: `attributes.name = 'a'`,
+			error: api.NewInvalidParameterValueError(
+				"invalid attribute 'name'. " +
+					"Valid values are ['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id', " +
+					"'duration']",
+			),
+		},
+		{
+			name:   "InvalidMetricOperator",
+			filter: `metrics.accuracy LIKE 0.9`,
+			error:  api.NewInvalidParameterValueError("invalid metric comparison operator 'LIKE'"),
+		},
+		{
+			name:   "InvalidMetricValue",
+			filter: `metrics.accuracy > '0.9'`,
+			error:  api.NewInvalidParameterValueError("invalid numeric value ''0.9''"),
+		},
+		{
+			name:   "InvalidNumericAttributeOperator",
+			filter: `attributes.start_time IN (1)`,
+			error:  api.NewInvalidParameterValueError("invalid numeric attribute comparison operator 'IN'"),
+		},
+		{
+			name:   "InvalidParamOperator",
+			filter: `params.model > 'a'`,
+			error:  api.NewInvalidParameterValueError("invalid param comparison operator '>'"),
+		},
+		{
+			name:   "InvalidListDefinition",
+			filter: `tags.env IN 'dev'`,
+			error:  api.NewInvalidParameterValueError("invalid list definition ''dev''"),
+		},
+		{
+			name:   "InvalidStringValue",
+			filter: `tags.env = ('dev', 'prod')`,
+			error:  api.NewInvalidParameterValueError("invalid string value '('dev', 'prod')'"),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := ParseFilter(tt.filter)
+			assert.Nil(t, conditions)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestParseOrderBy_Ok(t *testing.T) {
+	testData := []struct {
+		name    string
+		orderBy string
+		clause  *OrderByClause
+	}{
+		{
+			name:    "Attribute",
+			orderBy: "attributes.start_time",
+			clause:  &OrderByClause{Entity: FilterEntityAttribute, Key: "start_time"},
+		},
+		{
+			name:    "AttributeWithoutEntity",
+			orderBy: "run_name desc",
+			clause:  &OrderByClause{Entity: FilterEntityAttribute, Key: "name", Desc: true},
+		},
+		{
+			name:    "Duration",
+			orderBy: "attributes.duration DESC",
+			clause:  &OrderByClause{Entity: FilterEntityAttribute, Key: RunDurationAttribute, Desc: true},
+		},
+		{
+			name:    "QuotedMetric",
+			orderBy: `metrics."my metric" DESC`,
+			clause:  &OrderByClause{Entity: FilterEntityMetric, Key: "my metric", Desc: true},
+		},
+		{
+			name:    "Param",
+			orderBy: "params.model ASC",
+			clause:  &OrderByClause{Entity: FilterEntityParam, Key: "model"},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, err := ParseOrderBy(tt.orderBy)
+			require.Nil(t, err)
+			assert.Equal(t, tt.clause, clause)
+		})
+	}
+}
+
+func TestParseOrderBy_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		orderBy string
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "Injection",
+			orderBy: "attributes.start_time; DROP TABLE runs",
+			error:   api.NewInvalidParameterValueError("invalid order_by clause 'attributes.start_time; DROP TABLE runs'"),
+		},
+		{
+			name:    "UnknownDirection",
+			orderBy: "metrics.accuracy DOWN",
+			error:   api.NewInvalidParameterValueError("invalid order_by clause 'metrics.accuracy DOWN'"),
+		},
+		{
+			name:    "UnknownAttribute",
+			orderBy: "attributes.lifecycle_stage",
+			error: api.NewInvalidParameterValueError(
+				"invalid order_by attribute 'lifecycle_stage'. Valid values are " +
+					"['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id', 'experiment_id', " +
+					"'duration']",
+			),
+		},
+		{
+			name:    "InvalidEntity",
+			orderBy: "unknown.key",
+			error: api.NewInvalidParameterValueError(
+				"invalid entity type 'unknown'. Valid values are ['metric', 'parameter', 'tag', 'attribute']",
+			),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, err := ParseOrderBy(tt.orderBy)
+			assert.Nil(t, clause)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
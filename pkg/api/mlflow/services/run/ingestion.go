@@ -0,0 +1,154 @@
+package run
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// ingestedBatch represents number of metric points ingested by a namespace at some moment.
+type ingestedBatch struct {
+	time   time.Time
+	points int
+}
+
+// ingestionTracker tracks metrics ingestion rate of each namespace over a sliding window. The tracking
+// is in-memory, so each server instance tracks and throttles only the metrics ingested by itself.
+type ingestionTracker struct {
+	sync.Mutex
+	window  time.Duration
+	batches map[uint][]ingestedBatch
+}
+
+// newIngestionTracker creates new ingestionTracker instance with the sliding window of given length.
+func newIngestionTracker(window time.Duration) *ingestionTracker {
+	return &ingestionTracker{
+		window:  window,
+		batches: map[uint][]ingestedBatch{},
+	}
+}
+
+// Rate returns metrics ingestion rate of the namespace, in points per second, over the sliding window.
+func (t *ingestionTracker) Rate(namespaceID uint, now time.Time) float64 {
+	t.Lock()
+	defer t.Unlock()
+	return float64(t.ingestedPoints(namespaceID, now)) / t.window.Seconds()
+}
+
+// Reserve records ingestion of the points by the namespace, if they fit into the capacity of the sliding
+// window. Otherwise, nothing is recorded and the time after which the points would fit is returned.
+// Negative time means that the points never fit, as there are more of them than the capacity.
+func (t *ingestionTracker) Reserve(namespaceID uint, points, capacity int, now time.Time) (time.Duration, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	ingested := t.ingestedPoints(namespaceID, now)
+	if ingested+points <= capacity {
+		t.batches[namespaceID] = append(t.batches[namespaceID], ingestedBatch{time: now, points: points})
+		return 0, true
+	}
+	if points > capacity {
+		return -1, false
+	}
+
+	// batches are ordered by time, so the oldest ones leave the window first.
+	var wait time.Duration
+	for _, batch := range t.batches[namespaceID] {
+		ingested -= batch.points
+		wait = batch.time.Add(t.window).Sub(now)
+		if ingested+points <= capacity {
+			break
+		}
+	}
+	return wait, false
+}
+
+// Release removes the points reserved by the namespace at provided time, e.g. when their ingestion has failed.
+func (t *ingestionTracker) Release(namespaceID uint, points int, reservedAt time.Time) {
+	t.Lock()
+	defer t.Unlock()
+
+	batches := t.batches[namespaceID]
+	for i, batch := range batches {
+		if batch.time.Equal(reservedAt) && batch.points == points {
+			t.batches[namespaceID] = append(batches[:i:i], batches[i+1:]...)
+			return
+		}
+	}
+}
+
+// ingestedPoints removes batches, which left the sliding window, and returns number of points ingested
+// by the namespace within the window. It should be called under the lock.
+func (t *ingestionTracker) ingestedPoints(namespaceID uint, now time.Time) int {
+	batches := t.batches[namespaceID]
+	expired := 0
+	for expired < len(batches) && !batches[expired].time.After(now.Add(-t.window)) {
+		expired++
+	}
+	if expired == len(batches) {
+		delete(t.batches, namespaceID)
+		return 0
+	}
+	batches = batches[expired:]
+	t.batches[namespaceID] = batches
+
+	points := 0
+	for _, batch := range batches {
+		points += batch.points
+	}
+	return points
+}
+
+// throttleIngestion makes sure that ingestion of the metric points won't exceed configured rate limit
+// of the namespace. Depending on configured policy, namespace exceeding the limit is either rejected
+// or delayed until the points fit into the limit. Zero limit means that throttling is disabled.
+// It has to be called before the first repository call, so the delay doesn't hold request-scoped transaction.
+// Returned function releases the reserved points and has to be called, when their ingestion fails.
+// It is also called, when request-scoped transaction is rolled back.
+func (s Service) throttleIngestion(ctx context.Context, namespace *models.Namespace, points int) (func(), error) {
+	if s.config.NamespaceIngestionRateLimit == 0 || points == 0 {
+		return func() {}, nil
+	}
+
+	window := s.config.GetNamespaceIngestionRateWindow()
+	capacity := int(math.Ceil(float64(s.config.NamespaceIngestionRateLimit) * window.Seconds()))
+	for {
+		now := time.Now()
+		wait, ok := s.ingestionTracker.Reserve(namespace.ID, points, capacity, now)
+		if ok {
+			release := sync.OnceFunc(func() {
+				s.ingestionTracker.Release(namespace.ID, points, now)
+			})
+			repositories.OnTransactionRollback(ctx, release)
+			return release, nil
+		}
+		if wait < 0 || s.config.GetIngestionThrottlePolicy() == config.IngestionThrottlePolicyReject {
+			return nil, api.NewRequestLimitExceededError(
+				"namespace '%s' exceeded metrics ingestion rate limit of %d points per second: "+
+					"current rate is %.2f points per second, %d more points requested",
+				namespace.Code, s.config.NamespaceIngestionRateLimit,
+				s.ingestionTracker.Rate(namespace.ID, time.Now()), points,
+			)
+		}
+
+		log.Debugf("delaying ingestion of %d metric points to namespace '%s' by %s", points, namespace.Code, wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, api.NewRequestLimitExceededError(
+				"namespace '%s' exceeded metrics ingestion rate limit of %d points per second",
+				namespace.Code, s.config.NamespaceIngestionRateLimit,
+			)
+		case <-timer.C:
+		}
+	}
+}
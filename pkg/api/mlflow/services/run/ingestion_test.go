@@ -0,0 +1,176 @@
+package run
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestIngestionTracker_Reserve(t *testing.T) {
+	now := time.Now()
+	tracker := newIngestionTracker(10 * time.Second)
+
+	// points fit into the capacity of the window.
+	wait, ok := tracker.Reserve(1, 40, 100, now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+	wait, ok = tracker.Reserve(1, 50, 100, now.Add(2*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+	assert.Equal(t, 9.0, tracker.Rate(1, now.Add(2*time.Second)))
+
+	// other namespaces are tracked independently.
+	_, ok = tracker.Reserve(2, 100, 100, now.Add(2*time.Second))
+	assert.True(t, ok)
+
+	// points don't fit until the first batch leaves the window.
+	wait, ok = tracker.Reserve(1, 20, 100, now.Add(3*time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+	assert.Equal(t, 9.0, tracker.Rate(1, now.Add(3*time.Second)))
+
+	// points never fit, as there are more of them than the capacity.
+	wait, ok = tracker.Reserve(1, 101, 100, now.Add(3*time.Second))
+	assert.False(t, ok)
+	assert.Negative(t, wait)
+
+	// after the first batch left the window, points fit again.
+	_, ok = tracker.Reserve(1, 20, 100, now.Add(10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 7.0, tracker.Rate(1, now.Add(10*time.Second)))
+	assert.Equal(t, 0.0, tracker.Rate(1, now.Add(time.Minute)))
+}
+
+func TestIngestionTracker_Release(t *testing.T) {
+	now := time.Now()
+	tracker := newIngestionTracker(10 * time.Second)
+
+	_, ok := tracker.Reserve(1, 40, 100, now)
+	assert.True(t, ok)
+	_, ok = tracker.Reserve(1, 50, 100, now.Add(time.Second))
+	assert.True(t, ok)
+
+	// released points don't count towards the capacity anymore.
+	tracker.Release(1, 40, now)
+	assert.Equal(t, 5.0, tracker.Rate(1, now.Add(time.Second)))
+	_, ok = tracker.Reserve(1, 50, 100, now.Add(time.Second))
+	assert.True(t, ok)
+
+	// unknown reservations are ignored.
+	tracker.Release(1, 40, now)
+	tracker.Release(2, 50, now.Add(time.Second))
+	assert.Equal(t, 10.0, tracker.Rate(1, now.Add(time.Second)))
+}
+
+func TestService_throttleIngestion_Release(t *testing.T) {
+	service := Service{
+		config:           &config.Config{NamespaceIngestionRateLimit: 10, NamespaceIngestionRateWindow: time.Second},
+		ingestionTracker: newIngestionTracker(time.Second),
+	}
+	namespace := models.Namespace{ID: 1, Code: "default"}
+
+	// points of the failed ingestion are released, so they can be ingested again.
+	release, err := service.throttleIngestion(context.Background(), &namespace, 10)
+	require.Nil(t, err)
+	release()
+	release()
+	_, err = service.throttleIngestion(context.Background(), &namespace, 10)
+	require.Nil(t, err)
+	_, err = service.throttleIngestion(context.Background(), &namespace, 1)
+	assert.NotNil(t, err)
+}
+
+func TestService_throttleIngestion_Ok(t *testing.T) {
+	testData := []struct {
+		name   string
+		config *config.Config
+		points []int
+	}{
+		{
+			name:   "DisabledByDefault",
+			config: &config.Config{},
+			points: []int{1000, 1000},
+		},
+		{
+			name:   "WithinLimit",
+			config: &config.Config{NamespaceIngestionRateLimit: 10, NamespaceIngestionRateWindow: time.Second},
+			points: []int{5, 5},
+		},
+		{
+			name: "DelayedOverLimit",
+			config: &config.Config{
+				NamespaceIngestionRateLimit:  100,
+				NamespaceIngestionRateWindow: 100 * time.Millisecond,
+				IngestionThrottlePolicy:      config.IngestionThrottlePolicyDelay,
+			},
+			points: []int{10, 10},
+		},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := Service{
+				config:           tt.config,
+				ingestionTracker: newIngestionTracker(tt.config.GetNamespaceIngestionRateWindow()),
+			}
+			for _, points := range tt.points {
+				_, err := service.throttleIngestion(context.Background(), &models.Namespace{ID: 1}, points)
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestService_throttleIngestion_Error(t *testing.T) {
+	testData := []struct {
+		name   string
+		config *config.Config
+		points []int
+		error  *api.ErrorResponse
+	}{
+		{
+			name:   "RejectedOverLimit",
+			config: &config.Config{NamespaceIngestionRateLimit: 10, NamespaceIngestionRateWindow: time.Second},
+			points: []int{5, 5, 1},
+			error: api.NewRequestLimitExceededError(
+				"namespace 'default' exceeded metrics ingestion rate limit of 10 points per second: " +
+					"current rate is 10.00 points per second, 1 more points requested",
+			),
+		},
+		{
+			name: "DelayedMoreThanCapacity",
+			config: &config.Config{
+				NamespaceIngestionRateLimit:  10,
+				NamespaceIngestionRateWindow: time.Second,
+				IngestionThrottlePolicy:      config.IngestionThrottlePolicyDelay,
+			},
+			points: []int{11},
+			error: api.NewRequestLimitExceededError(
+				"namespace 'default' exceeded metrics ingestion rate limit of 10 points per second: " +
+					"current rate is 0.00 points per second, 11 more points requested",
+			),
+		},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := Service{
+				config:           tt.config,
+				ingestionTracker: newIngestionTracker(tt.config.GetNamespaceIngestionRateWindow()),
+			}
+			namespace := models.Namespace{ID: 1, Code: "default"}
+			var err error
+			for _, points := range tt.points {
+				if _, err = service.throttleIngestion(context.Background(), &namespace, points); err != nil {
+					break
+				}
+			}
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// validateLogBatchSize makes sure that log-batch request doesn't exceed configured maximum number
+// of metrics, params and tags, so a single giant batch can't monopolize the database.
+func (s Service) validateLogBatchSize(req *request.LogBatchRequest) error {
+	size := len(req.Metrics) + len(req.Params) + len(req.Tags)
+	if s.config.LogBatchMaxItems > 0 && size > s.config.LogBatchMaxItems {
+		return api.NewInvalidParameterValueError(
+			"log-batch request contains %d metrics, params and tags, which exceeds the maximum of %d, "+
+				"please split it into smaller batches",
+			size, s.config.LogBatchMaxItems,
+		)
+	}
+	return nil
+}
+
+// keyCardinalityGetter returns cardinality of already stored keys for some scope (run or namespace).
+type keyCardinalityGetter func(ctx context.Context, keys []string) (*repositories.KeyCardinality, error)
+
+// validateKeyCardinality makes sure that logging of provided metrics, params and tags won't exceed
+// configured limits of distinct keys per run and per namespace. Keys which already exist are always
+// accepted, so runs which are already over the limit stay readable and can still be updated.
+func (s Service) validateKeyCardinality(
+	ctx context.Context,
+	namespace *models.Namespace,
+	run *models.Run,
+	metrics []models.Metric,
+	params []models.Param,
+	tags []models.Tag,
+) error {
+	metricKeys := make([]string, len(metrics))
+	for i, metric := range metrics {
+		metricKeys[i] = metric.Key
+	}
+	paramKeys := make([]string, len(params))
+	for i, param := range params {
+		paramKeys[i] = param.Key
+	}
+	tagKeys := make([]string, len(tags))
+	for i, tag := range tags {
+		tagKeys[i] = tag.Key
+	}
+
+	runScope := fmt.Sprintf("run '%s'", run.ID)
+	namespaceScope := fmt.Sprintf("namespace '%s'", namespace.Code)
+	for _, limit := range []struct {
+		entity string
+		scope  string
+		limit  int
+		keys   []string
+		getter keyCardinalityGetter
+	}{
+		{
+			entity: "metric",
+			scope:  runScope,
+			limit:  s.config.RunMaxMetricKeys,
+			keys:   metricKeys,
+			getter: func(ctx context.Context, keys []string) (*repositories.KeyCardinality, error) {
+				return s.metricRepository.GetKeyCardinalityByRunID(ctx, run.ID, keys)
+			},
+		},
+		{
+			entity: "param",
+			scope:  runScope,
+			limit:  s.config.RunMaxParamKeys,
+			keys:   paramKeys,
+			getter: func(ctx context.Context, keys []string) (*repositories.KeyCardinality, error) {
+				return s.paramRepository.GetKeyCardinalityByRunID(ctx, run.ID, keys)
+			},
+		},
+		{
+			entity: "tag",
+			scope:  runScope,
+			limit:  s.config.RunMaxTagKeys,
+			keys:   tagKeys,
+			getter: func(ctx context.Context, keys []string) (*repositories.KeyCardinality, error) {
+				return s.tagRepository.GetKeyCardinalityByRunID(ctx, run.ID, keys)
+			},
+		},
+		{
+			entity: "metric",
+			scope:  namespaceScope,
+			limit:  s.config.NamespaceMaxMetricKeys,
+			keys:   metricKeys,
+			getter: func(ctx context.Context, keys []string) (*repositories.KeyCardinality, error) {
+				return s.metricRepository.GetKeyCardinalityByNamespaceID(ctx, namespace.ID, keys)
+			},
+		},
+		{
+			entity: "param",
+			scope:  namespaceScope,
+			limit:  s.config.NamespaceMaxParamKeys,
+			keys:   paramKeys,
+			getter: func(ctx context.Context, keys []string) (*repositories.KeyCardinality, error) {
+				return s.paramRepository.GetKeyCardinalityByNamespaceID(ctx, namespace.ID, keys)
+			},
+		},
+		{
+			entity: "tag",
+			scope:  namespaceScope,
+			limit:  s.config.NamespaceMaxTagKeys,
+			keys:   tagKeys,
+			getter: func(ctx context.Context, keys []string) (*repositories.KeyCardinality, error) {
+				return s.tagRepository.GetKeyCardinalityByNamespaceID(ctx, namespace.ID, keys)
+			},
+		},
+	} {
+		if err := checkKeyCardinality(ctx, limit.entity, limit.scope, limit.limit, limit.keys, limit.getter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkKeyCardinality checks that provided keys fit into the limit of distinct keys for the scope.
+// Zero limit means that the check is disabled.
+func checkKeyCardinality(
+	ctx context.Context, entity, scope string, limit int, keys []string, getter keyCardinalityGetter,
+) error {
+	if limit == 0 || len(keys) == 0 {
+		return nil
+	}
+
+	uniqueKeys := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			uniqueKeys = append(uniqueKeys, key)
+		}
+	}
+
+	cardinality, err := getter(ctx, uniqueKeys)
+	if err != nil {
+		return api.NewInternalError("unable to count distinct %s keys for %s: %s", entity, scope, err)
+	}
+
+	newKeys := int64(len(uniqueKeys)) - cardinality.Existing
+	if newKeys > 0 && cardinality.Total+newKeys > int64(limit) {
+		return api.NewInvalidParameterValueError(
+			"too many distinct %s keys for %s: limit is %d, %d already logged, %d new requested",
+			entity, scope, limit, cardinality.Total, newKeys,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package run
+
+import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/convertors"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// validateRunName checks run name against configured maximum length and allowed characters.
+// Only new names are checked, so runs which already have over-long names stay readable.
+func (s Service) validateRunName(name string) error {
+	return api.ValidateName("run", name, s.config.GetMaxRunNameLength())
+}
+
+// validateRunNameTags checks run names set through `mlflow.runName` tag, as the tag renames the run.
+func (s Service) validateRunNameTags(tags []models.Tag) error {
+	for _, tag := range tags {
+		if tag.Key == convertors.TagKeyRunName {
+			if err := s.validateRunName(tag.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
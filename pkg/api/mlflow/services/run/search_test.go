@@ -0,0 +1,83 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+// newSearchBenchmarkDB creates sqlite database with runs, each of which has a few tags.
+func newSearchBenchmarkDB(b *testing.B, numRuns int) *gorm.DB {
+	db, err := database.NewDBProvider(
+		"sqlite://"+filepath.Join(b.TempDir(), "fasttrackml.db"),
+		time.Second*2,
+		database.NewPoolConfig(2),
+		database.StatementTimeouts{},
+	)
+	require.Nil(b, err)
+	b.Cleanup(func() {
+		require.Nil(b, db.Close())
+	})
+	require.Nil(b, database.CheckAndMigrateDB(true, db.GormDB()))
+	require.Nil(b, database.CreateDefaultNamespace(db.GormDB()))
+	require.Nil(b, database.CreateDefaultExperiment(db.GormDB(), b.TempDir()))
+
+	runs := make([]database.Run, 0, numRuns)
+	tags := make([]database.Tag, 0, numRuns*3)
+	for i := 0; i < numRuns; i++ {
+		id := fmt.Sprintf("%032d", i)
+		runs = append(runs, database.Run{
+			ID:             id,
+			Status:         database.StatusFinished,
+			SourceType:     "JOB",
+			ExperimentID:   models.DefaultExperimentID,
+			LifecycleStage: database.LifecycleStageActive,
+		})
+		tags = append(tags,
+			database.Tag{Key: "mlflow.user", Value: fmt.Sprintf("user%d", i%100), RunID: id},
+			database.Tag{Key: "dataset", Value: fmt.Sprintf("dataset%d", i%1000), RunID: id},
+			database.Tag{Key: "commit", Value: fmt.Sprintf("%040d", i), RunID: id},
+		)
+	}
+	require.Nil(b, db.GormDB().CreateInBatches(&runs, 500).Error)
+	require.Nil(b, db.GormDB().CreateInBatches(&tags, 500).Error)
+	return db.GormDB()
+}
+
+func BenchmarkSearchRunsByTag(b *testing.B) {
+	db := newSearchBenchmarkDB(b, 20000)
+	defer func(db *gorm.DB) {
+		database.DB = db
+	}(database.DB)
+	database.DB = db
+
+	service := NewService(&config.Config{}, nil, nil, nil, nil, nil)
+	namespace := &models.Namespace{ID: 1, DefaultExperimentID: common.GetPointer(models.DefaultExperimentID)}
+	benchmark := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			runs, _, _, err := service.SearchRuns(context.Background(), namespace, &request.SearchRunsRequest{
+				ExperimentIDs: []string{"0"},
+				Filter:        fmt.Sprintf(`tags.dataset = 'dataset%d' AND tags."mlflow.user" = 'user%d'`, i%10, i%10),
+			})
+			require.Nil(b, err)
+			require.Len(b, runs, 20)
+		}
+	}
+
+	b.Run("WithTagIndexes", benchmark)
+	for _, index := range []string{"idx_tags_run_uuid_key", "idx_tags_key_value"} {
+		require.Nil(b, db.Migrator().DropIndex(&database.Tag{}, index))
+	}
+	b.Run("WithoutTagIndexes", benchmark)
+}
@@ -2,16 +2,15 @@ package run
 
 import (
 	"context"
-	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
-	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -20,17 +19,11 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
-//nolint:lll
-var (
-	runOrder      = regexp.MustCompile(`^(attribute|metric|param|tag)s?\.("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)(?i:\s+(ASC|DESC))?$`)
-	filterAnd     = regexp.MustCompile(`(?i)\s+AND\s+`)
-	filterCond    = regexp.MustCompile(`^(?:(\w+)\.)?("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)\s+(<|<=|>|>=|=|!=|(?i:I?LIKE)|(?i:(?:NOT )?IN))\s+(\((?:'[^']+'(?:,\s*)?)+\)|"[^"]+"|'[^']+'|[\w\.]+)$`)
-	filterInGroup = regexp.MustCompile(`,\s*`)
-)
-
 // supported expression list.
 const (
 	InExpression            = "IN"
@@ -47,15 +40,18 @@ const (
 
 // Service provides service layer to work with `run` business logic.
 type Service struct {
+	config               *config.Config
 	tagRepository        repositories.TagRepositoryProvider
 	runRepository        repositories.RunRepositoryProvider
 	paramRepository      repositories.ParamRepositoryProvider
 	metricRepository     repositories.MetricRepositoryProvider
 	experimentRepository repositories.ExperimentRepositoryProvider
+	ingestionTracker     *ingestionTracker
 }
 
 // NewService creates new Service instance.
 func NewService(
+	config *config.Config,
 	tagRepository repositories.TagRepositoryProvider,
 	runRepository repositories.RunRepositoryProvider,
 	paramRepository repositories.ParamRepositoryProvider,
@@ -63,11 +59,13 @@ func NewService(
 	experimentRepository repositories.ExperimentRepositoryProvider,
 ) *Service {
 	return &Service{
+		config:               config,
 		tagRepository:        tagRepository,
 		runRepository:        runRepository,
 		paramRepository:      paramRepository,
 		metricRepository:     metricRepository,
 		experimentRepository: experimentRepository,
+		ingestionTracker:     newIngestionTracker(config.GetNamespaceIngestionRateWindow()),
 	}
 }
 
@@ -89,6 +87,56 @@ func (s Service) CreateRun(
 	if err != nil {
 		return nil, api.NewInternalError("error converting request to actual run model: %s", err)
 	}
+	if err := s.validateRunName(run.Name); err != nil {
+		return nil, err
+	}
+	if err := s.runRepository.Create(ctx, run); err != nil {
+		return nil, api.NewInternalError("error inserting run: %s", err)
+	}
+
+	return run, nil
+}
+
+// CloneRun creates new run with the params and tags, but not the metrics, of the existing run.
+// Both the source run and the target experiment are looked up only in the namespace of the request,
+// which write access has been already checked, so runs are never cloned into other namespaces.
+func (s Service) CloneRun(
+	ctx context.Context, namespace *models.Namespace, req *request.CloneRunRequest,
+) (*models.Run, error) {
+	if err := ValidateCloneRunRequest(req); err != nil {
+		return nil, err
+	}
+
+	source, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.RunID)
+	if err != nil {
+		return nil, api.NewResourceDoesNotExistError("unable to find run '%s': %s", req.RunID, err)
+	}
+	if source == nil {
+		return nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.RunID)
+	}
+
+	experimentID := source.ExperimentID
+	if req.ExperimentID != "" {
+		id, err := strconv.ParseInt(req.ExperimentID, 10, 32)
+		if err != nil {
+			return nil, api.NewBadRequestError("unable to parse experiment id '%s': %s", req.ExperimentID, err)
+		}
+		experimentID = int32(id)
+	}
+	experiment, err := s.experimentRepository.GetByNamespaceIDAndExperimentID(ctx, namespace.ID, experimentID)
+	if err != nil {
+		return nil, api.NewResourceDoesNotExistError("unable to find experiment with id '%d': %s", experimentID, err)
+	}
+
+	run, err := convertors.ConvertCloneRunRequestToDBModel(experiment, source, req, time.Now().UTC().UnixMilli())
+	if err != nil {
+		return nil, api.NewInternalError("error converting request to actual run model: %s", err)
+	}
+	if req.Name != "" {
+		if err := s.validateRunName(req.Name); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.runRepository.Create(ctx, run); err != nil {
 		return nil, api.NewInternalError("error inserting run: %s", err)
 	}
@@ -102,6 +150,11 @@ func (s Service) UpdateRun(
 	if err := ValidateUpdateRunRequest(req); err != nil {
 		return nil, err
 	}
+	if req.Name != "" {
+		if err := s.validateRunName(req.Name); err != nil {
+			return nil, err
+		}
+	}
 
 	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.GetRunID())
 	if err != nil {
@@ -110,9 +163,12 @@ func (s Service) UpdateRun(
 	if run == nil {
 		return nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
 	}
+	if err := s.checkRunStatusTransition(run, models.Status(req.Status)); err != nil {
+		return nil, err
+	}
 
 	run = convertors.ConvertUpdateRunRequestToDBModel(run, req)
-	if err := s.runRepository.GetDB().Transaction(func(tx *gorm.DB) error {
+	if err := s.runRepository.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := s.runRepository.UpdateWithTransaction(ctx, tx, run); err != nil {
 			return err
 		}
@@ -127,6 +183,9 @@ func (s Service) UpdateRun(
 		}
 		return nil
 	}); err != nil {
+		if errors.As(err, &repositories.RunVersionConflictError{}) {
+			return nil, api.NewResourceConflictError("unable to update run '%s': %s", run.ID, err)
+		}
 		return nil, api.NewInternalError("unable to update run '%s': %s", run.ID, err)
 	}
 
@@ -142,7 +201,9 @@ func (s Service) GetRun(
 		return nil, err
 	}
 
-	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.GetRunID())
+	run, err := s.runRepository.GetByNamespaceIDAndRunIDWithFields(
+		ctx, namespace.ID, req.GetRunID(), commonModels.NewResponseFields(req.Fields),
+	)
 	if err != nil {
 		return nil, api.NewResourceDoesNotExistError("unable to find run '%s': %s", req.GetRunID(), err)
 	}
@@ -162,6 +223,9 @@ func (s Service) SearchRuns(
 		return nil, 0, 0, err
 	}
 	adjustSearchRunsRequestForNamespace(namespace, req)
+	if err := s.checkSearchRunsExperiments(ctx, namespace, req); err != nil {
+		return nil, 0, 0, err
+	}
 
 	// ViewType
 	var lifecyleStages []database.LifecycleStage
@@ -180,7 +244,7 @@ func (s Service) SearchRuns(
 			database.LifecycleStageDeleted,
 		}
 	}
-	tx := database.DB.Joins(
+	tx := database.DB.WithContext(ctx).Joins(
 		"LEFT JOIN experiments ON experiments.experiment_id = runs.experiment_id",
 	).Where(
 		"experiments.namespace_id = ?", namespace.ID,
@@ -192,10 +256,7 @@ func (s Service) SearchRuns(
 
 	// MaxResults
 	// TODO if compatible with mlflow client, consider using same logic as in ExperimentSearch
-	limit := int(req.MaxResults)
-	if limit == 0 {
-		limit = 1000
-	}
+	limit := s.config.GetPageSize(int(req.MaxResults))
 	tx.Limit(limit)
 
 	// PageToken
@@ -214,149 +275,48 @@ func (s Service) SearchRuns(
 	}
 	tx.Offset(offset)
 
+	// duration of running runs is calculated up to the same moment for the filter and the order.
+	now := time.Now().UnixMilli()
+
 	// Filter
 	if req.Filter != "" {
-		for n, f := range filterAnd.Split(req.Filter, -1) {
-			components := filterCond.FindStringSubmatch(f)
-			if len(components) != 5 {
-				return nil, 0, 0, api.NewInvalidParameterValueError("malformed filter '%s'", f)
-			}
-
-			entity := components[1]
-			key := strings.Trim(components[2], "\"`")
-			comparison := components[3]
-			var value any = components[4]
-
-			var kind any
-			switch entity {
-			case "", "attribute", "attributes", "attr", "run":
-				switch key {
-				case "start_time", "end_time":
-					switch comparison {
-					case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
-						EqualExpression, LessExpression, LessOrEqualExpression:
-						v, err := strconv.Atoi(value.(string))
-						if err != nil {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
-						}
-						value = v
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid numeric attribute comparison operator '%s'", comparison,
-						)
-					}
-				case "run_name":
-					key = "mlflow.runName"
-					kind = &database.Tag{}
-					fallthrough
-				case "status", "user_id", "artifact_uri":
-					switch strings.ToUpper(comparison) {
-					case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-						if strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(value.(string), `"'`)
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid string attribute comparison operator '%s'", comparison,
-						)
-					}
-				case "run_id":
-					key = "run_uuid"
-					switch strings.ToUpper(comparison) {
-					case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-						if strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(value.(string), `"'`)
-					case InExpression, NotInExpression:
-						if !strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid list definition '%s'", value)
-						}
-						var values []string
-						for _, v := range filterInGroup.Split(value.(string)[1:len(value.(string))-1], -1) {
-							values = append(values, strings.Trim(v, "'"))
-						}
-						value = values
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid string attribute comparison operator '%s'", comparison,
-						)
-					}
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						`invalid attribute '%s'. `+
-							`Valid values are ['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id']`,
-						key,
-					)
-				}
-			case "metric", "metrics":
-				switch comparison {
-				case GraterExpression, GraterOrEqualExpression,
-					NotEqualExpression, EqualExpression, LessExpression, LessOrEqualExpression:
-					v, err := strconv.ParseFloat(value.(string), 64)
-					if err != nil {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
-					}
-					value = v
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid metric comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.LatestMetric{}
-			case "parameter", "parameters", "param", "params":
-				switch strings.ToUpper(comparison) {
-				case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-					if strings.HasPrefix(value.(string), "(") {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-					}
-					value = strings.Trim(value.(string), `"'`)
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid param comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.Param{}
-			case "tag", "tags":
-				switch strings.ToUpper(comparison) {
-				case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-					if strings.HasPrefix(value.(string), "(") {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-					}
-					value = strings.Trim(value.(string), `"'`)
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid tag comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.Tag{}
-			default:
-				return nil, 0, 0, api.NewInvalidParameterValueError(
-					"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute']", entity,
-				)
+		conditions, err := ParseFilter(req.Filter)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		for n, condition := range conditions {
+			comparison, value := condition.Operator, condition.Value
+			if database.DB.Dialector.Name() == database.SQLiteDialectorName && comparison == ILikeExpression {
+				comparison, value = LikeExpression, strings.ToLower(value.(string))
 			}
 
+			kind := getEntityModel(condition.Entity)
 			if kind == nil {
-				if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
-					key = fmt.Sprintf("LOWER(runs.%s)", key)
-					comparison = LikeExpression
-					value = strings.ToLower(value.(string))
-					tx.Where(fmt.Sprintf("%s %s ?", key, comparison), value)
-				} else {
-					tx.Where(fmt.Sprintf("runs.%s %s ?", key, comparison), value)
+				column := getAttributeColumn(condition.Key, now)
+				if condition.Operator != comparison {
+					column = fmt.Sprintf("LOWER(%s)", column)
 				}
+				tx.Where(fmt.Sprintf("%s %s ?", column, comparison), value)
 			} else {
-				table := fmt.Sprintf("filter_%d", n)
 				where := fmt.Sprintf("value %s ?", comparison)
-				if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
-					where = "LOWER(value) LIKE ?"
-					value = strings.ToLower(value.(string))
+				if condition.Operator != comparison {
+					where = fmt.Sprintf("LOWER(value) %s ?", comparison)
+				}
+				query := database.DB.Where("key = ?", condition.Key).Where(where, value).Model(kind)
+				if condition.Entity == FilterEntityTag {
+					// tags are filtered by semi-join, so the lookup could be served by tags (key, value) index.
+					// on Postgres that index is built on the hash of the value, see v_0016 migration.
+					if comparison == EqualExpression && database.DB.Dialector.Name() == database.PostgresDialectorName {
+						query.Where("md5(value) = md5(?)", value)
+					}
+					tx.Where("runs.run_uuid IN (?)", query.Select("run_uuid"))
+				} else {
+					table := fmt.Sprintf("filter_%d", n)
+					tx.Joins(
+						fmt.Sprintf("JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
+						query.Select("run_uuid", "value"),
+					)
 				}
-				tx.Joins(
-					fmt.Sprintf("JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
-					database.DB.Select("run_uuid", "value").Where("key = ?", key).Where(where, value).Model(kind),
-				)
 			}
 		}
 	}
@@ -364,47 +324,40 @@ func (s Service) SearchRuns(
 	// OrderBy
 	// TODO order numeric, nan, null?
 	// TODO collation for strings on postgres?
+	// configured default order is used, when the client doesn't request any.
+	orderBys := req.OrderBy
+	if len(orderBys) == 0 {
+		if orderBy := s.config.GetRunSearchDefaultOrderBy(namespace.Code); orderBy != "" {
+			orderBys = []string{orderBy}
+		}
+	}
 	startTimeOrder := false
-	for n, o := range req.OrderBy {
-		components := runOrder.FindStringSubmatch(o)
-		log.Debugf("Components: %#v", components)
-		if len(components) < 3 {
-			return nil, 0, 0, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
+	for n, o := range orderBys {
+		orderBy, err := ParseOrderBy(o)
+		if err != nil {
+			return nil, 0, 0, err
 		}
 
-		column := strings.Trim(components[2], "`\"")
-
-		var kind any
-		switch components[1] {
-		case "attribute":
-			if column == "start_time" {
-				startTimeOrder = true
-			}
-		case "metric":
-			kind = &database.LatestMetric{}
-		case "param":
-			kind = &database.Param{}
-		case "tag":
-			kind = &database.Tag{}
-		default:
-			return nil, 0, 0, api.NewInvalidParameterValueError(
-				"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute']",
-				components[1],
-			)
+		column := clause.Column{
+			Name: getAttributeColumn(orderBy.Key, now),
+			Raw:  true,
 		}
-		if kind != nil {
+		if kind := getEntityModel(orderBy.Entity); kind != nil {
 			table := fmt.Sprintf("order_%d", n)
 			tx.Joins(
 				fmt.Sprintf("LEFT OUTER JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
-				database.DB.Select("run_uuid", "value").Where("key = ?", column).Model(kind),
+				database.DB.Select("run_uuid", "value").Where("key = ?", orderBy.Key).Model(kind),
 			)
-			column = fmt.Sprintf("%s.value", table)
+			column = clause.Column{
+				Table: table,
+				Name:  "value",
+			}
+		} else if orderBy.Key == "start_time" {
+			startTimeOrder = true
 		}
 		tx.Order(clause.OrderByColumn{
-			Column: clause.Column{
-				Name: column,
-			},
-			Desc: len(components) == 4 && strings.ToUpper(components[3]) == "DESC",
+			Column: column,
+			Desc:   orderBy.Desc,
 		})
 	}
 	if !startTimeOrder {
@@ -425,6 +378,53 @@ func (s Service) SearchRuns(
 	return runs, limit, offset, nil
 }
 
+// checkSearchRunsExperiments checks that every requested experiment belongs to the namespace,
+// so runs of inaccessible experiments are rejected instead of being silently skipped.
+func (s Service) checkSearchRunsExperiments(
+	ctx context.Context, namespace *models.Namespace, req *request.SearchRunsRequest,
+) error {
+	checked := make(map[string]struct{}, len(req.ExperimentIDs))
+	for _, id := range req.ExperimentIDs {
+		if _, ok := checked[id]; ok {
+			continue
+		}
+		experimentID, err := strconv.ParseInt(id, 10, 32)
+		if err != nil {
+			return api.NewInvalidParameterValueError("unable to parse experiment id '%s'", id)
+		}
+		if _, err := s.experimentRepository.GetByNamespaceIDAndExperimentID(
+			ctx, namespace.ID, int32(experimentID),
+		); err != nil {
+			return api.NewResourceDoesNotExistError("unable to find experiment with id '%s'", id)
+		}
+		checked[id] = struct{}{}
+	}
+	return nil
+}
+
+// getAttributeColumn returns SQL expression of the run attribute. Duration isn't stored, so it's calculated
+// from start and end times. Runs, which don't have end time yet, are treated as lasting up to now.
+func getAttributeColumn(key string, now int64) string {
+	if key == RunDurationAttribute {
+		return fmt.Sprintf("(COALESCE(runs.end_time, %d) - runs.start_time)", now)
+	}
+	return fmt.Sprintf("runs.%s", key)
+}
+
+// getEntityModel returns database model which holds values of the filter entity,
+// or nil for FilterEntityAttribute, which values are the columns of `runs` table.
+func getEntityModel(entity string) any {
+	switch entity {
+	case FilterEntityMetric:
+		return &database.LatestMetric{}
+	case FilterEntityParam:
+		return &database.Param{}
+	case FilterEntityTag:
+		return &database.Tag{}
+	}
+	return nil
+}
+
 // DeleteRun handles delete models.Run entity business logic.
 func (s Service) DeleteRun(
 	ctx context.Context, namespace *models.Namespace, req *request.DeleteRunRequest,
@@ -442,6 +442,9 @@ func (s Service) DeleteRun(
 	}
 
 	if err := s.runRepository.Archive(ctx, run); err != nil {
+		if errors.As(err, &repositories.RunVersionConflictError{}) {
+			return api.NewResourceConflictError("unable to delete run '%s': %s", run.ID, err)
+		}
 		return api.NewInternalError("unable to delete run '%s': %s", run.ID, err)
 	}
 
@@ -465,9 +468,10 @@ func (s Service) RestoreRun(
 		return api.NewResourceDoesNotExistError("unable to find run '%s'", req.RunID)
 	}
 
-	run.DeletedTime = sql.NullInt64{Valid: false}
-	run.LifecycleStage = models.LifecycleStageActive
-	if err := s.runRepository.Update(ctx, run); err != nil {
+	if err := s.runRepository.Restore(ctx, run); err != nil {
+		if errors.As(err, &repositories.RunVersionConflictError{}) {
+			return api.NewResourceConflictError("unable to restore run '%s': %s", run.ID, err)
+		}
 		return api.NewInternalError("unable to restore run '%s': %s", run.ID, err)
 	}
 
@@ -478,10 +482,19 @@ func (s Service) LogMetric(
 	ctx context.Context,
 	namespace *models.Namespace,
 	req *request.LogMetricRequest,
-) error {
+) (err error) {
 	if err := ValidateLogMetricRequest(req); err != nil {
 		return err
 	}
+	release, err := s.throttleIngestion(ctx, namespace, 1)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
 
 	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.RunID)
 	if err != nil {
@@ -490,12 +503,22 @@ func (s Service) LogMetric(
 	if run == nil {
 		return api.NewResourceDoesNotExistError("unable to find run '%s'", req.RunID)
 	}
+	if err := s.checkRunAcceptsLogging(run); err != nil {
+		return err
+	}
 
 	metric, err := convertors.ConvertLogMetricRequestToDBModel(run.ID, req)
 	if err != nil {
 		return api.NewInvalidParameterValueError(err.Error())
 	}
-	if err := s.metricRepository.CreateBatch(ctx, run, 1, []models.Metric{*metric}); err != nil {
+	metrics := []models.Metric{*metric}
+	if err := s.validateMetricTimestamps(run, metrics); err != nil {
+		return err
+	}
+	if err := s.validateKeyCardinality(ctx, namespace, run, metrics, nil, nil); err != nil {
+		return err
+	}
+	if err := s.metricRepository.CreateBatch(ctx, run, 1, metrics); err != nil {
 		return api.NewInternalError("unable to log metric '%s' for run '%s': %s", req.Key, req.GetRunID(), err)
 	}
 
@@ -520,8 +543,14 @@ func (s Service) LogParam(
 	if run == nil {
 		return api.NewResourceDoesNotExistError("Run '%s' not found", req.RunID)
 	}
+	if err := s.checkRunAcceptsLogging(run); err != nil {
+		return err
+	}
 
 	param := convertors.ConvertLogParamRequestToDBModel(run.ID, req)
+	if err := s.validateKeyCardinality(ctx, namespace, run, nil, []models.Param{*param}, nil); err != nil {
+		return err
+	}
 	if err := s.paramRepository.CreateBatch(ctx, 1, []models.Param{*param}); err != nil {
 		if errors.As(err, &repositories.ParamConflictError{}) {
 			return api.NewInvalidParameterValueError("unable to insert params for run '%s': %s", run.ID, err)
@@ -551,13 +580,96 @@ func (s Service) SetRunTag(
 		return api.NewResourceDoesNotExistError("Run '%s' not found", req.RunID)
 	}
 
-	tag := convertors.ConvertSetRunTagRequestToDBModel(run.ID, req)
-	if err := s.runRepository.SetRunTagsBatch(ctx, run, 1, []models.Tag{*tag}); err != nil {
+	tags, err := s.applySystemTagPolicy(run, []models.Tag{*convertors.ConvertSetRunTagRequestToDBModel(run.ID, req)})
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	if err := s.validateRunNameTags(tags); err != nil {
+		return err
+	}
+	if err := s.validateKeyCardinality(ctx, namespace, run, nil, nil, tags); err != nil {
+		return err
+	}
+	if err := s.runRepository.SetRunTagsBatch(ctx, run, 1, tags); err != nil {
+		if errors.As(err, &repositories.RunVersionConflictError{}) {
+			return api.NewResourceConflictError("unable to insert tags for run '%s': %s", run.ID, err)
+		}
 		return api.NewInternalError("unable to insert tags for run '%s': %s", run.ID, err)
 	}
 	return nil
 }
 
+// LogModel records MLmodel metadata of the model logged by the run, appending it to the history
+// of logged models kept in the run tag, like MLflow does.
+func (s Service) LogModel(
+	ctx context.Context,
+	namespace *models.Namespace,
+	req *request.LogModelRequest,
+) error {
+	if err := ValidateLogModelRequest(req); err != nil {
+		return err
+	}
+
+	var loggedModel models.LoggedModel
+	if err := json.Unmarshal([]byte(req.ModelJSON), &loggedModel); err != nil {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'model_json' supplied: %s", err)
+	}
+	if loggedModel.ArtifactPath == "" {
+		return api.NewInvalidParameterValueError("Model json is missing mandatory field 'artifact_path'")
+	}
+	if len(loggedModel.Flavors) == 0 {
+		return api.NewInvalidParameterValueError("Model json is missing mandatory field 'flavors'")
+	}
+	if loggedModel.RunID != "" && loggedModel.RunID != req.RunID {
+		return api.NewInvalidParameterValueError(
+			"Model json run id '%s' doesn't match run id '%s'", loggedModel.RunID, req.RunID,
+		)
+	}
+
+	run, err := s.runRepository.GetByNamespaceIDRunIDAndLifecycleStage(
+		ctx, namespace.ID, req.RunID, models.LifecycleStageActive,
+	)
+	if err != nil {
+		return api.NewInternalError("Unable to find run '%s': %s", req.RunID, err)
+	}
+	if run == nil {
+		return api.NewResourceDoesNotExistError("Run '%s' not found", req.RunID)
+	}
+	loggedModel.RunID = run.ID
+
+	var loggedModels []models.LoggedModel
+	for _, tag := range run.Tags {
+		if tag.Key == models.TagKeyLogModelHistory {
+			if loggedModels, err = models.DecodeLoggedModels(tag.Value); err != nil {
+				return api.NewInternalError("unable to read logged models of run '%s': %s", run.ID, err)
+			}
+		}
+	}
+	value, err := models.EncodeLoggedModels(append(loggedModels, loggedModel))
+	if err != nil {
+		return api.NewInternalError("unable to log model for run '%s': %s", run.ID, err)
+	}
+	if len(value) > MaxLogModelHistoryLength {
+		return api.NewInvalidParameterValueError(
+			"unable to log model for run '%s': history of logged models exceeds the limit of %d characters",
+			run.ID, MaxLogModelHistoryLength,
+		)
+	}
+
+	// the history tag is a system tag maintained by FastTrackML itself, so system tag policy isn't applied.
+	tags := []models.Tag{{Key: models.TagKeyLogModelHistory, Value: value, RunID: run.ID}}
+	if err := s.runRepository.SetRunTagsBatch(ctx, run, 1, tags); err != nil {
+		if errors.As(err, &repositories.RunVersionConflictError{}) {
+			return api.NewResourceConflictError("unable to log model for run '%s': %s", run.ID, err)
+		}
+		return api.NewInternalError("unable to log model for run '%s': %s", run.ID, err)
+	}
+	return nil
+}
+
 func (s Service) DeleteRunTag(
 	ctx context.Context,
 	namespace *models.Namespace,
@@ -585,6 +697,14 @@ func (s Service) DeleteRunTag(
 		return api.NewResourceDoesNotExistError("No tag with name: %s", req.Key)
 	}
 
+	allowed, err := s.applySystemTagPolicy(run, []models.Tag{*tag})
+	if err != nil {
+		return err
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+
 	if err := s.tagRepository.Delete(ctx, tag); err != nil {
 		return api.NewInternalError("unable to delete tag '%s' for run '%s': %s", req.Key, req.RunID, err)
 	}
@@ -592,14 +712,157 @@ func (s Service) DeleteRunTag(
 	return nil
 }
 
+// SetRunsTag sets the tag of all the selected runs in one transaction and returns IDs of the runs.
+// Runs are looked up only in the namespace of the request, which write access has been already checked,
+// so the whole request fails if any of the runs belongs to another namespace.
+func (s Service) SetRunsTag(
+	ctx context.Context,
+	namespace *models.Namespace,
+	req *request.SetRunsTagRequest,
+) ([]string, error) {
+	if err := ValidateSetRunsTagRequest(req); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.getSelectedRuns(ctx, namespace, &req.RunSelectionPartialRequest)
+	if err != nil {
+		return nil, err
+	}
+	runIDs := make([]string, len(runs))
+	for i := range runs {
+		runIDs[i] = runs[i].ID
+		tags, err := s.applySystemTagPolicy(&runs[i], []models.Tag{{RunID: runs[i].ID, Key: req.Key, Value: req.Value}})
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) == 0 {
+			return runIDs[:0], nil
+		}
+		if err := s.validateKeyCardinality(ctx, namespace, &runs[i], nil, nil, tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.runRepository.SetRunsTag(ctx, runs, req.Key, req.Value); err != nil {
+		if errors.As(err, &repositories.RunVersionConflictError{}) {
+			return nil, api.NewResourceConflictError("unable to set tag '%s' for runs: %s", req.Key, err)
+		}
+		return nil, api.NewInternalError("unable to set tag '%s' for runs: %s", req.Key, err)
+	}
+	return runIDs, nil
+}
+
+// DeleteRunsTag deletes the tag of all the selected runs and returns IDs of the runs. Runs without the tag
+// are skipped. Runs are looked up only in the namespace of the request, the same way as by SetRunsTag.
+func (s Service) DeleteRunsTag(
+	ctx context.Context,
+	namespace *models.Namespace,
+	req *request.DeleteRunsTagRequest,
+) ([]string, error) {
+	if err := ValidateDeleteRunsTagRequest(req); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.getSelectedRuns(ctx, namespace, &req.RunSelectionPartialRequest)
+	if err != nil {
+		return nil, err
+	}
+	runIDs := make([]string, len(runs))
+	for i := range runs {
+		runIDs[i] = runs[i].ID
+		allowed, err := s.applySystemTagPolicy(&runs[i], []models.Tag{{RunID: runs[i].ID, Key: req.Key}})
+		if err != nil {
+			return nil, err
+		}
+		if len(allowed) == 0 {
+			return runIDs[:0], nil
+		}
+	}
+
+	if len(runIDs) > 0 {
+		if err := s.tagRepository.DeleteByRunIDsAndKey(ctx, runIDs, req.Key); err != nil {
+			return nil, api.NewInternalError("unable to delete tag '%s' for runs: %s", req.Key, err)
+		}
+	}
+	return runIDs, nil
+}
+
+// getSelectedRuns returns active runs of the namespace selected either by their IDs or by the search filter.
+func (s Service) getSelectedRuns(
+	ctx context.Context, namespace *models.Namespace, req *request.RunSelectionPartialRequest,
+) ([]models.Run, error) {
+	if len(req.RunIDs) > 0 {
+		runIDs := slices.Clone(req.RunIDs)
+		slices.Sort(runIDs)
+		runIDs = slices.Compact(runIDs)
+		runs, err := s.runRepository.GetByNamespaceIDRunIDsAndLifecycleStage(
+			ctx, namespace.ID, runIDs, models.LifecycleStageActive,
+		)
+		if err != nil {
+			return nil, api.NewInternalError("Unable to find runs: %s", err)
+		}
+		// both runs and IDs are sorted, so the first mismatch is the first missing run.
+		for i, runID := range runIDs {
+			if i >= len(runs) || runs[i].ID != runID {
+				return nil, api.NewResourceDoesNotExistError("Run '%s' not found", runID)
+			}
+		}
+		return runs, nil
+	}
+
+	// walk through all the pages of the search results.
+	var runs []models.Run
+	searchReq := request.SearchRunsRequest{
+		ExperimentIDs: req.ExperimentIDs,
+		Filter:        req.Filter,
+		OrderBy:       []string{"attributes.run_id"},
+	}
+	for {
+		page, limit, offset, err := s.SearchRuns(ctx, namespace, &searchReq)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, page...)
+		if len(runs) > MaxRunsPerTagBulk {
+			return nil, api.NewInvalidParameterValueError(
+				"filter '%s' selects more than %d runs", req.Filter, MaxRunsPerTagBulk,
+			)
+		}
+		if len(page) < limit {
+			return runs, nil
+		}
+		token, err := json.Marshal(request.PageToken{Offset: int32(offset + limit)})
+		if err != nil {
+			return nil, api.NewInternalError("unable to encode page token: %s", err)
+		}
+		searchReq.PageToken = base64.StdEncoding.EncodeToString(token)
+	}
+}
+
 func (s Service) LogBatch(
 	ctx context.Context,
 	namespace *models.Namespace,
 	req *request.LogBatchRequest,
-) error {
+) (err error) {
 	if err := ValidateLogBatchRequest(req); err != nil {
 		return err
 	}
+	if err := s.validateLogBatchSize(req); err != nil {
+		return err
+	}
+	points := len(req.Metrics)
+	if req.ValidateOnly {
+		points = 0
+	}
+	release, err := s.throttleIngestion(ctx, namespace, points)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
 
 	run, err := s.runRepository.GetByNamespaceIDRunIDAndLifecycleStage(
 		ctx, namespace.ID, req.RunID, models.LifecycleStageActive,
@@ -615,6 +878,33 @@ func (s Service) LogBatch(
 	if err != nil {
 		return api.NewInvalidParameterValueError(err.Error())
 	}
+	// tags could be still set on finished runs, so only batches with metrics or params are rejected.
+	if len(metrics)+len(params) > 0 {
+		if err := s.checkRunAcceptsLogging(run); err != nil {
+			return err
+		}
+	}
+	if err := s.validateMetricTimestamps(run, metrics); err != nil {
+		return err
+	}
+	if tags, err = s.applySystemTagPolicy(run, tags); err != nil {
+		return err
+	}
+	if err := s.validateRunNameTags(tags); err != nil {
+		return err
+	}
+	if err := s.validateKeyCardinality(ctx, namespace, run, metrics, params, tags); err != nil {
+		return err
+	}
+	if req.ValidateOnly {
+		if err := s.paramRepository.ValidateBatch(ctx, params); err != nil {
+			if errors.As(err, &repositories.ParamConflictError{}) {
+				return api.NewInvalidParameterValueError("unable to insert params for run '%s': %s", run.ID, err)
+			}
+			return api.NewInternalError("unable to validate params for run '%s': %s", run.ID, err)
+		}
+		return nil
+	}
 	if err := s.paramRepository.CreateBatch(ctx, 100, params); err != nil {
 		if errors.As(err, &repositories.ParamConflictError{}) {
 			return api.NewInvalidParameterValueError("unable to insert params for run '%s': %s", run.ID, err)
@@ -625,6 +915,9 @@ func (s Service) LogBatch(
 		return api.NewInternalError("unable to insert metrics for run '%s': %s", run.ID, err)
 	}
 	if err := s.runRepository.SetRunTagsBatch(ctx, run, 100, tags); err != nil {
+		if errors.As(err, &repositories.RunVersionConflictError{}) {
+			return api.NewResourceConflictError("unable to insert tags for run '%s': %s", run.ID, err)
+		}
 		return api.NewInternalError("unable to insert tags for run '%s': %s", run.ID, err)
 	}
 
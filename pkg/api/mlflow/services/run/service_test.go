@@ -15,6 +15,8 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 func TestService_CreateRun_Ok(t *testing.T) {
@@ -62,6 +64,7 @@ func TestService_CreateRun_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&repositories.MockParamRepositoryProvider{},
@@ -117,6 +120,7 @@ func TestService_CreateRun_Error(t *testing.T) {
 			request: &request.CreateRunRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -140,6 +144,7 @@ func TestService_CreateRun_Error(t *testing.T) {
 					int32(1),
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -192,6 +197,7 @@ func TestService_CreateRun_Error(t *testing.T) {
 					}),
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -228,6 +234,7 @@ func TestService_UpdateRun_Error(t *testing.T) {
 			request: &request.UpdateRunRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -251,6 +258,7 @@ func TestService_UpdateRun_Error(t *testing.T) {
 					"1",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -280,17 +288,14 @@ func TestService_RestoreRun_Ok(t *testing.T) {
 		"1",
 	).Return(&models.Run{ID: "1"}, nil)
 	runRepository.On(
-		"Update",
+		"Restore",
 		context.TODO(),
-		&models.Run{
-			ID:             "1",
-			DeletedTime:    sql.NullInt64{Valid: false},
-			LifecycleStage: models.LifecycleStageActive,
-		},
+		&models.Run{ID: "1"},
 	).Return(nil)
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&repositories.MockParamRepositoryProvider{},
@@ -316,6 +321,7 @@ func TestService_RestoreRun_Error(t *testing.T) {
 			request: &request.RestoreRunRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -339,6 +345,40 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					"1",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&runRepository,
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "RestoreRunVersionConflict",
+			error: api.NewResourceConflictError("unable to restore run '1': run '1' has been modified by another request"),
+			request: &request.RestoreRunRequest{
+				RunID: "1",
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDAndRunID",
+					context.TODO(),
+					uint(1),
+					"1",
+				).Return(&models.Run{
+					ID: "1",
+				}, nil)
+				runRepository.On(
+					"Restore",
+					context.TODO(),
+					mock.AnythingOfType("*models.Run"),
+				).Return(repositories.RunVersionConflictError{
+					Message: "run '1' has been modified by another request",
+				})
+				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -364,16 +404,15 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					ID: "1",
 				}, nil)
 				runRepository.On(
-					"Update",
+					"Restore",
 					context.TODO(),
 					mock.MatchedBy(func(run *models.Run) bool {
 						assert.Equal(t, "1", run.ID)
-						assert.Equal(t, sql.NullInt64{}, run.DeletedTime)
-						assert.Equal(t, models.LifecycleStageActive, run.LifecycleStage)
 						return true
 					}),
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -415,6 +454,7 @@ func TestService_SetRunTag_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&repositories.MockParamRepositoryProvider{},
@@ -434,6 +474,148 @@ func TestService_SetRunTag_Ok(t *testing.T) {
 }
 func TestService_SetRunTag_Error(t *testing.T) {}
 
+func TestService_LogModel_Ok(t *testing.T) {
+	// init repository mocks.
+	run := models.Run{
+		ID:             "1",
+		LifecycleStage: models.LifecycleStageActive,
+		Tags: []models.Tag{{
+			RunID: "1",
+			Key:   models.TagKeyLogModelHistory,
+			Value: `[{"run_id":"1","artifact_path":"first","utc_time_created":"","flavors":{"sklearn":{}}}]`,
+		}},
+	}
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetByNamespaceIDRunIDAndLifecycleStage",
+		context.TODO(),
+		uint(1),
+		"1",
+		models.LifecycleStageActive,
+	).Return(&run, nil)
+	runRepository.On(
+		"SetRunTagsBatch",
+		context.TODO(),
+		&run,
+		1,
+		[]models.Tag{{
+			RunID: "1",
+			Key:   models.TagKeyLogModelHistory,
+			Value: `[{"run_id":"1","artifact_path":"first","utc_time_created":"","flavors":{"sklearn":{}}},` +
+				`{"run_id":"1","artifact_path":"second","utc_time_created":"2024-01-01 00:00:00.000000",` +
+				`"flavors":{"python_function":{"loader_module":"mlflow.sklearn"}}}]`,
+		}},
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{},
+		&repositories.MockTagRepositoryProvider{},
+		&runRepository,
+		&repositories.MockParamRepositoryProvider{},
+		&repositories.MockMetricRepositoryProvider{},
+		&repositories.MockExperimentRepositoryProvider{},
+	)
+	err := service.LogModel(context.TODO(), &models.Namespace{
+		ID: 1,
+	}, &request.LogModelRequest{
+		RunID: "1",
+		ModelJSON: `{"artifact_path":"second","utc_time_created":"2024-01-01 00:00:00.000000",` +
+			`"flavors":{"python_function":{"loader_module":"mlflow.sklearn"}}}`,
+	})
+
+	// compare results.
+	require.Nil(t, err)
+}
+
+func TestService_LogModel_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogModelRequest
+		service func() *Service
+	}{
+		{
+			name: "MalformedModelJSON",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'model_json' supplied: unexpected end of JSON input",
+			),
+			request: &request.LogModelRequest{
+				RunID:     "1",
+				ModelJSON: `{"artifact_path":`,
+			},
+		},
+		{
+			name:  "MissingArtifactPath",
+			error: api.NewInvalidParameterValueError("Model json is missing mandatory field 'artifact_path'"),
+			request: &request.LogModelRequest{
+				RunID:     "1",
+				ModelJSON: `{"flavors":{"sklearn":{}}}`,
+			},
+		},
+		{
+			name:  "MissingFlavors",
+			error: api.NewInvalidParameterValueError("Model json is missing mandatory field 'flavors'"),
+			request: &request.LogModelRequest{
+				RunID:     "1",
+				ModelJSON: `{"artifact_path":"model"}`,
+			},
+		},
+		{
+			name:  "MismatchedRunID",
+			error: api.NewInvalidParameterValueError("Model json run id '2' doesn't match run id '1'"),
+			request: &request.LogModelRequest{
+				RunID:     "1",
+				ModelJSON: `{"run_id":"2","artifact_path":"model","flavors":{"sklearn":{}}}`,
+			},
+		},
+		{
+			name:  "RunNotFound",
+			error: api.NewResourceDoesNotExistError("Run '1' not found"),
+			request: &request.LogModelRequest{
+				RunID:     "1",
+				ModelJSON: `{"artifact_path":"model","flavors":{"sklearn":{}}}`,
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDRunIDAndLifecycleStage",
+					context.TODO(),
+					uint(1),
+					"1",
+					models.LifecycleStageActive,
+				).Return(nil, nil)
+				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&runRepository,
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+				)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(
+				&config.Config{},
+				&repositories.MockTagRepositoryProvider{},
+				&repositories.MockRunRepositoryProvider{},
+				&repositories.MockParamRepositoryProvider{},
+				&repositories.MockMetricRepositoryProvider{},
+				&repositories.MockExperimentRepositoryProvider{},
+			)
+			if tt.service != nil {
+				service = tt.service()
+			}
+			err := service.LogModel(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
 func TestService_DeleteRun_Ok(t *testing.T) {
 	// init repository mocks.
 	runRepository := repositories.MockRunRepositoryProvider{}
@@ -451,6 +633,7 @@ func TestService_DeleteRun_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&repositories.MockParamRepositoryProvider{},
@@ -476,6 +659,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 			request: &request.DeleteRunRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -499,6 +683,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					"1",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -522,6 +707,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					"1",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -555,6 +741,7 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					}),
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -590,6 +777,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 			request: &request.DeleteRunTagRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -614,6 +802,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					models.LifecycleStageActive,
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -638,6 +827,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					models.LifecycleStageActive,
 				).Return(nil, nil)
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -673,6 +863,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					"key",
 				).Return(nil, nil)
 				return NewService(
+					&config.Config{},
 					&tagRepository,
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -708,6 +899,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					"key",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&tagRepository,
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -757,6 +949,7 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					}),
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&tagRepository,
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -780,10 +973,11 @@ func TestService_GetRun_Ok(t *testing.T) {
 	// init repository mocks.
 	runRepository := repositories.MockRunRepositoryProvider{}
 	runRepository.On(
-		"GetByNamespaceIDAndRunID",
+		"GetByNamespaceIDAndRunIDWithFields",
 		context.TODO(),
 		uint(1),
 		"1",
+		commonModels.ResponseFields(nil),
 	).Return(&models.Run{
 		ID:             "1",
 		Name:           "name",
@@ -823,6 +1017,7 @@ func TestService_GetRun_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&repositories.MockParamRepositoryProvider{},
@@ -884,6 +1079,27 @@ func TestService_GetRun_Error(t *testing.T) {
 			request: &request.GetRunRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name: "InvalidField",
+			error: api.NewInvalidParameterValueError(
+				`invalid field 'data'. Valid values are ['params', 'metrics', 'tags', 'summary']`,
+			),
+			request: &request.GetRunRequest{
+				RunID:  "1",
+				Fields: "params,data",
+			},
+			service: func() *Service {
+				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -901,12 +1117,14 @@ func TestService_GetRun_Error(t *testing.T) {
 			service: func() *Service {
 				runRepository := repositories.MockRunRepositoryProvider{}
 				runRepository.On(
-					"GetByNamespaceIDAndRunID",
+					"GetByNamespaceIDAndRunIDWithFields",
 					context.TODO(),
 					uint(1),
 					"1",
+					commonModels.ResponseFields(nil),
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -983,6 +1201,7 @@ func TestService_LogBatch_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&paramRepository,
@@ -1019,6 +1238,100 @@ func TestService_LogBatch_Ok(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestService_LogBatch_ValidateOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate error
+		error    *api.ErrorResponse
+	}{
+		{
+			name: "Ok",
+		},
+		{
+			name:     "ConflictingParams",
+			validate: repositories.ParamConflictError{Message: "conflicting params found"},
+			error: api.NewInvalidParameterValueError(
+				"unable to insert params for run '1': conflicting params found",
+			),
+		},
+		{
+			name:     "DatabaseError",
+			validate: errors.New("database error"),
+			error:    api.NewInternalError("unable to validate params for run '1': database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// init repository mocks.
+			runRepository := repositories.MockRunRepositoryProvider{}
+			runRepository.On(
+				"GetByNamespaceIDRunIDAndLifecycleStage",
+				context.TODO(),
+				uint(1),
+				"1",
+				models.LifecycleStageActive,
+			).Return(&models.Run{
+				ID:             "1",
+				LifecycleStage: models.LifecycleStageActive,
+			}, nil)
+			paramRepository := repositories.MockParamRepositoryProvider{}
+			paramRepository.On(
+				"ValidateBatch",
+				context.TODO(),
+				[]models.Param{{Key: "key2", Value: "value2", RunID: "1"}},
+			).Return(tt.validate)
+			metricRepository := repositories.MockMetricRepositoryProvider{}
+
+			// call service under testing.
+			service := NewService(
+				&config.Config{},
+				&repositories.MockTagRepositoryProvider{},
+				&runRepository,
+				&paramRepository,
+				&metricRepository,
+				&repositories.MockExperimentRepositoryProvider{},
+			)
+			err := service.LogBatch(context.TODO(), &models.Namespace{
+				ID: 1,
+			}, &request.LogBatchRequest{
+				RunID: "1",
+				Tags: []request.TagPartialRequest{
+					{
+						Key:   "key1",
+						Value: "value1",
+					},
+				},
+				Params: []request.ParamPartialRequest{
+					{
+						Key:   "key2",
+						Value: "value2",
+					},
+				},
+				Metrics: []request.MetricPartialRequest{
+					{
+						Key:       "key3",
+						Value:     1.1,
+						Timestamp: 1234567890,
+						Step:      1,
+					},
+				},
+				ValidateOnly: true,
+			})
+
+			// compare results.
+			if tt.error == nil {
+				require.Nil(t, err)
+			} else {
+				assert.Equal(t, tt.error, err)
+			}
+			paramRepository.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything)
+			metricRepository.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			runRepository.AssertNotCalled(t, "SetRunTagsBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestService_LogBatch_Error(t *testing.T) {
 	testData := []struct {
 		name    string
@@ -1032,6 +1345,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 			request: &request.LogBatchRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -1056,6 +1370,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					models.LifecycleStageActive,
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1080,6 +1395,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					models.LifecycleStageActive,
 				).Return(nil, nil)
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1104,6 +1420,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					models.LifecycleStageActive,
 				).Return(nil, nil)
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1137,6 +1454,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					ID: "1",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1182,6 +1500,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					},
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&paramRepository,
@@ -1227,6 +1546,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					},
 				).Return(repositories.ParamConflictError{Message: "param conflict!"})
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&paramRepository,
@@ -1302,6 +1622,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					},
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&paramRepository,
@@ -1399,6 +1720,7 @@ func TestService_LogBatch_Error(t *testing.T) {
 					},
 				).Return(nil)
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&paramRepository,
@@ -1407,6 +1729,196 @@ func TestService_LogBatch_Error(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "CreateBatchTagsConflictError",
+			error: api.NewResourceConflictError(
+				`unable to insert tags for run '1': run '1' has been modified by another request`,
+			),
+			request: &request.LogBatchRequest{
+				RunID: "1",
+				Params: []request.ParamPartialRequest{
+					{
+						Key:   "key",
+						Value: "value",
+					},
+				},
+				Tags: []request.TagPartialRequest{
+					{
+						Key:   "key",
+						Value: "value",
+					},
+				},
+				Metrics: []request.MetricPartialRequest{
+					{
+						Step:      1,
+						Key:       "key",
+						Value:     1.1,
+						Timestamp: 123456789,
+					},
+				},
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDRunIDAndLifecycleStage",
+					context.TODO(),
+					uint(1),
+					"1",
+					models.LifecycleStageActive,
+				).Return(&models.Run{
+					ID:             "1",
+					LifecycleStage: models.LifecycleStageActive,
+				}, nil)
+				runRepository.On(
+					"SetRunTagsBatch",
+					context.TODO(),
+					&models.Run{
+						ID:             "1",
+						LifecycleStage: models.LifecycleStageActive,
+					},
+					100,
+					[]models.Tag{
+						{
+							Key:   "key",
+							Value: "value",
+							RunID: "1",
+						},
+					},
+				).Return(repositories.RunVersionConflictError{
+					Message: "run '1' has been modified by another request",
+				})
+				paramRepository := repositories.MockParamRepositoryProvider{}
+				paramRepository.On(
+					"CreateBatch",
+					context.TODO(),
+					100,
+					[]models.Param{
+						{
+							Key:   "key",
+							Value: "value",
+							RunID: "1",
+						},
+					},
+				).Return(nil)
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				metricRepository.On(
+					"CreateBatch",
+					context.TODO(),
+					&models.Run{
+						ID:             "1",
+						LifecycleStage: models.LifecycleStageActive,
+					},
+					100,
+					[]models.Metric{
+						{
+							Step:      1,
+							Key:       "key",
+							Value:     1.1,
+							RunID:     "1",
+							Timestamp: 123456789,
+							ContextID: models.DefaultContext.ID,
+							Context:   models.DefaultContext,
+						},
+					},
+				).Return(nil)
+				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&runRepository,
+					&paramRepository,
+					&metricRepository,
+					&repositories.MockExperimentRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name: "RunParamKeysLimitExceeded",
+			error: api.NewInvalidParameterValueError(
+				"too many distinct param keys for run '1': limit is 2, 2 already logged, 1 new requested",
+			),
+			request: &request.LogBatchRequest{
+				RunID: "1",
+				Params: []request.ParamPartialRequest{
+					{
+						Key:   "key1",
+						Value: "value",
+					},
+					{
+						Key:   "key2",
+						Value: "value",
+					},
+				},
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDRunIDAndLifecycleStage",
+					context.TODO(),
+					uint(1),
+					"1",
+					models.LifecycleStageActive,
+				).Return(&models.Run{
+					ID:             "1",
+					LifecycleStage: models.LifecycleStageActive,
+				}, nil)
+				paramRepository := repositories.MockParamRepositoryProvider{}
+				paramRepository.On(
+					"GetKeyCardinalityByRunID",
+					context.TODO(),
+					"1",
+					[]string{"key1", "key2"},
+				).Return(&repositories.KeyCardinality{Total: 2, Existing: 1}, nil)
+				return NewService(
+					&config.Config{RunMaxParamKeys: 2},
+					&repositories.MockTagRepositoryProvider{},
+					&runRepository,
+					&paramRepository,
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "RunTagKeysLimitDatabaseError",
+			error: api.NewInternalError("unable to count distinct tag keys for run '1': database error"),
+			request: &request.LogBatchRequest{
+				RunID: "1",
+				Tags: []request.TagPartialRequest{
+					{
+						Key:   "key",
+						Value: "value",
+					},
+				},
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDRunIDAndLifecycleStage",
+					context.TODO(),
+					uint(1),
+					"1",
+					models.LifecycleStageActive,
+				).Return(&models.Run{
+					ID:             "1",
+					LifecycleStage: models.LifecycleStageActive,
+				}, nil)
+				tagRepository := repositories.MockTagRepositoryProvider{}
+				tagRepository.On(
+					"GetKeyCardinalityByRunID",
+					context.TODO(),
+					"1",
+					[]string{"key"},
+				).Return(nil, errors.New("database error"))
+				return NewService(
+					&config.Config{RunMaxTagKeys: 1},
+					&tagRepository,
+					&runRepository,
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+				)
+			},
+		},
 	}
 
 	for _, tt := range testData {
@@ -1448,6 +1960,7 @@ func TestService_LogMetric_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&repositories.MockParamRepositoryProvider{},
@@ -1481,6 +1994,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 			request: &request.LogMetricRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -1497,6 +2011,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -1514,6 +2029,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -1540,6 +2056,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					"1",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1568,6 +2085,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					ID: "1",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1616,6 +2134,7 @@ func TestService_LogMetric_Error(t *testing.T) {
 					}),
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1624,6 +2143,38 @@ func TestService_LogMetric_Error(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "LoggingToFinishedRunRejected",
+			error: api.NewInvalidParameterValueError(
+				`run '1' is already in terminal status 'FINISHED' and doesn't accept logging`,
+			),
+			request: &request.LogMetricRequest{
+				RunID:     "1",
+				Key:       "key",
+				Value:     1.1,
+				Timestamp: 1234567890,
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				runRepository.On(
+					"GetByNamespaceIDAndRunID",
+					context.TODO(),
+					uint(1),
+					"1",
+				).Return(&models.Run{
+					ID:     "1",
+					Status: models.StatusFinished,
+				}, nil)
+				return NewService(
+					&config.Config{TerminatedRunLogPolicy: config.TerminatedRunLogPolicyReject},
+					&repositories.MockTagRepositoryProvider{},
+					&runRepository,
+					&repositories.MockParamRepositoryProvider{},
+					&repositories.MockMetricRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+				)
+			},
+		},
 	}
 
 	for _, tt := range testData {
@@ -1663,6 +2214,7 @@ func TestService_LogParam_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(
+		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&runRepository,
 		&paramRepository,
@@ -1694,6 +2246,7 @@ func TestService_LogParam_Error(t *testing.T) {
 			request: &request.LogParamRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -1710,6 +2263,7 @@ func TestService_LogParam_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockRunRepositoryProvider{},
 					&repositories.MockParamRepositoryProvider{},
@@ -1736,6 +2290,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					models.LifecycleStageActive,
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1762,6 +2317,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					models.LifecycleStageActive,
 				).Return(nil, nil)
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&repositories.MockParamRepositoryProvider{},
@@ -1804,6 +2360,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					}),
 				).Return(errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&paramRepository,
@@ -1846,6 +2403,7 @@ func TestService_LogParam_Error(t *testing.T) {
 					}),
 				).Return(repositories.ParamConflictError{Message: "conflict!"})
 				return NewService(
+					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&runRepository,
 					&paramRepository,
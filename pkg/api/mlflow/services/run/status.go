@@ -0,0 +1,45 @@
+package run
+
+import (
+	"slices"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// allowedRunStatusTransitions represents run statuses, which run could be moved to from its current status,
+// when strict run status transitions are enabled. Runs in a terminal status can't be moved anywhere.
+var allowedRunStatusTransitions = map[models.Status][]models.Status{
+	models.StatusScheduled: {models.StatusRunning, models.StatusFailed, models.StatusKilled},
+	models.StatusRunning:   {models.StatusFinished, models.StatusFailed, models.StatusKilled},
+}
+
+// checkRunStatusTransition checks that run could be moved to the requested status, when strict run status
+// transitions are enabled. Requests, which don't change status, are always accepted.
+func (s Service) checkRunStatusTransition(run *models.Run, status models.Status) error {
+	if !s.config.StrictRunStatusTransitions || status == "" || status == run.Status {
+		return nil
+	}
+	if !slices.Contains(allowedRunStatusTransitions[run.Status], status) {
+		return api.NewInvalidParameterValueError(
+			"run '%s' can't be moved from status '%s' to status '%s'", run.ID, run.Status, status,
+		)
+	}
+	return nil
+}
+
+// checkRunAcceptsLogging checks logging of metrics and params to the run against configured terminated
+// run log policy. Runs, which are finished, failed or killed, reject logging, when policy is `reject`.
+func (s Service) checkRunAcceptsLogging(run *models.Run) error {
+	if s.config.GetTerminatedRunLogPolicy() == config.TerminatedRunLogPolicyAllow {
+		return nil
+	}
+	switch run.Status {
+	case models.StatusFinished, models.StatusFailed, models.StatusKilled:
+		return api.NewInvalidParameterValueError(
+			"run '%s' is already in terminal status '%s' and doesn't accept logging", run.ID, run.Status,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestService_checkRunAcceptsLogging_Ok(t *testing.T) {
+	testData := []struct {
+		name   string
+		config *config.Config
+		run    *models.Run
+	}{
+		{
+			name:   "FinishedRunAllowedByDefault",
+			config: &config.Config{},
+			run:    &models.Run{ID: "1", Status: models.StatusFinished},
+		},
+		{
+			name:   "FinishedRunAllowed",
+			config: &config.Config{TerminatedRunLogPolicy: config.TerminatedRunLogPolicyAllow},
+			run:    &models.Run{ID: "1", Status: models.StatusFinished},
+		},
+		{
+			name:   "RunningRunAccepted",
+			config: &config.Config{TerminatedRunLogPolicy: config.TerminatedRunLogPolicyReject},
+			run:    &models.Run{ID: "1", Status: models.StatusRunning},
+		},
+		{
+			name:   "ScheduledRunAccepted",
+			config: &config.Config{TerminatedRunLogPolicy: config.TerminatedRunLogPolicyReject},
+			run:    &models.Run{ID: "1", Status: models.StatusScheduled},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := Service{config: tt.config}
+			assert.Nil(t, service.checkRunAcceptsLogging(tt.run))
+		})
+	}
+}
+
+func TestService_checkRunAcceptsLogging_Error(t *testing.T) {
+	service := Service{config: &config.Config{TerminatedRunLogPolicy: config.TerminatedRunLogPolicyReject}}
+	for _, status := range []models.Status{models.StatusFinished, models.StatusFailed, models.StatusKilled} {
+		t.Run(string(status), func(t *testing.T) {
+			assert.Equal(t, api.NewInvalidParameterValueError(
+				"run '1' is already in terminal status '%s' and doesn't accept logging", status,
+			), service.checkRunAcceptsLogging(&models.Run{ID: "1", Status: status}))
+		})
+	}
+}
+
+func TestService_checkRunStatusTransition_Ok(t *testing.T) {
+	testData := []struct {
+		name   string
+		config *config.Config
+		run    *models.Run
+		status models.Status
+	}{
+		{
+			name:   "AnyTransitionAllowedByDefault",
+			config: &config.Config{},
+			run:    &models.Run{ID: "1", Status: models.StatusFinished},
+			status: models.StatusRunning,
+		},
+		{
+			name:   "ScheduledToRunning",
+			config: &config.Config{StrictRunStatusTransitions: true},
+			run:    &models.Run{ID: "1", Status: models.StatusScheduled},
+			status: models.StatusRunning,
+		},
+		{
+			name:   "RunningToFinished",
+			config: &config.Config{StrictRunStatusTransitions: true},
+			run:    &models.Run{ID: "1", Status: models.StatusRunning},
+			status: models.StatusFinished,
+		},
+		{
+			name:   "StatusIsNotChanged",
+			config: &config.Config{StrictRunStatusTransitions: true},
+			run:    &models.Run{ID: "1", Status: models.StatusFinished},
+			status: models.StatusFinished,
+		},
+		{
+			name:   "StatusIsNotProvided",
+			config: &config.Config{StrictRunStatusTransitions: true},
+			run:    &models.Run{ID: "1", Status: models.StatusFinished},
+			status: "",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := Service{config: tt.config}
+			assert.Nil(t, service.checkRunStatusTransition(tt.run, tt.status))
+		})
+	}
+}
+
+func TestService_checkRunStatusTransition_Error(t *testing.T) {
+	testData := []struct {
+		name   string
+		run    *models.Run
+		status models.Status
+	}{
+		{
+			name:   "FinishedToRunning",
+			run:    &models.Run{ID: "1", Status: models.StatusFinished},
+			status: models.StatusRunning,
+		},
+		{
+			name:   "KilledToFinished",
+			run:    &models.Run{ID: "1", Status: models.StatusKilled},
+			status: models.StatusFinished,
+		},
+		{
+			name:   "RunningToScheduled",
+			run:    &models.Run{ID: "1", Status: models.StatusRunning},
+			status: models.StatusScheduled,
+		},
+	}
+
+	service := Service{config: &config.Config{StrictRunStatusTransitions: true}}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, api.NewInvalidParameterValueError(
+				"run '1' can't be moved from status '%s' to status '%s'", tt.run.Status, tt.status,
+			), service.checkRunStatusTransition(tt.run, tt.status))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package run
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// applySystemTagPolicy checks user modifications of run tags against configured system tag policy.
+// Depending on the policy, modifications of system tags are either rejected or silently dropped.
+// FastTrackML itself sets system tags directly through the repositories, so it is not affected.
+func (s Service) applySystemTagPolicy(run *models.Run, tags []models.Tag) ([]models.Tag, error) {
+	policy := s.config.GetSystemTagPolicy()
+	if policy == config.SystemTagPolicyAllow {
+		return tags, nil
+	}
+
+	allowed := make([]models.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if !s.config.IsSystemTag(tag.Key) {
+			allowed = append(allowed, tag)
+			continue
+		}
+		if policy == config.SystemTagPolicyReject {
+			return nil, api.NewInvalidParameterValueError(
+				"tag '%s' of run '%s' is a protected system tag and can't be modified", tag.Key, run.ID,
+			)
+		}
+		log.Debugf("ignoring modification of protected system tag '%s' of run '%s'", tag.Key, run.ID)
+	}
+	return allowed, nil
+}
@@ -0,0 +1,63 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestService_applySystemTagPolicy_Ok(t *testing.T) {
+	tags := []models.Tag{
+		{Key: "mlflow.source.name", Value: "train.py"},
+		{Key: "team", Value: "research"},
+	}
+	testData := []struct {
+		name     string
+		config   *config.Config
+		expected []models.Tag
+	}{
+		{
+			name:     "AllowedByDefault",
+			config:   &config.Config{},
+			expected: tags,
+		},
+		{
+			name:     "ProtectedTagIgnored",
+			config:   &config.Config{SystemTagPolicy: config.SystemTagPolicyIgnore},
+			expected: []models.Tag{{Key: "team", Value: "research"}},
+		},
+		{
+			name: "UnprotectedTagsAccepted",
+			config: &config.Config{
+				SystemTagPolicy:   config.SystemTagPolicyReject,
+				SystemTagPrefixes: []string{"fasttrackml."},
+			},
+			expected: tags,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := Service{config: tt.config}
+			result, err := service.applySystemTagPolicy(&models.Run{ID: "1"}, tags)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestService_applySystemTagPolicy_Error(t *testing.T) {
+	service := Service{config: &config.Config{SystemTagPolicy: config.SystemTagPolicyReject}}
+	_, err := service.applySystemTagPolicy(&models.Run{ID: "1"}, []models.Tag{
+		{Key: "team", Value: "research"},
+		{Key: "mlflow.runName", Value: "name"},
+	})
+	assert.Equal(t, api.NewInvalidParameterValueError(
+		"tag 'mlflow.runName' of run '1' is a protected system tag and can't be modified",
+	), err)
+}
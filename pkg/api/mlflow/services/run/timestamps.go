@@ -0,0 +1,42 @@
+package run
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// validateMetricTimestamps checks that metric timestamps are within allowed clock skew from server time.
+// Depending on configured policy, metrics out of allowed range are either rejected or clamped to it.
+// Zero skew means that the check is disabled.
+func (s Service) validateMetricTimestamps(run *models.Run, metrics []models.Metric) error {
+	if s.config.MetricTimestampMaxSkew == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	minTimestamp := now.Add(-s.config.MetricTimestampMaxSkew).UnixMilli()
+	maxTimestamp := now.Add(s.config.MetricTimestampMaxSkew).UnixMilli()
+	for i, metric := range metrics {
+		if metric.Timestamp >= minTimestamp && metric.Timestamp <= maxTimestamp {
+			continue
+		}
+		if s.config.GetMetricTimestampSkewPolicy() == config.MetricTimestampSkewPolicyReject {
+			return api.NewInvalidParameterValueError(
+				"timestamp %d of metric '%s' for run '%s' is out of allowed clock skew %s from server time",
+				metric.Timestamp, metric.Key, run.ID, s.config.MetricTimestampMaxSkew,
+			)
+		}
+		timestamp := min(max(metric.Timestamp, minTimestamp), maxTimestamp)
+		log.Warnf(
+			"clamping timestamp %d of metric '%s' for run '%s' to %d: out of allowed clock skew %s from server time",
+			metric.Timestamp, metric.Key, run.ID, timestamp, s.config.MetricTimestampMaxSkew,
+		)
+		metrics[i].Timestamp = timestamp
+	}
+	return nil
+}
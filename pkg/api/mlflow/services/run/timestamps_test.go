@@ -0,0 +1,100 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestService_validateMetricTimestamps_Ok(t *testing.T) {
+	now := time.Now()
+	testData := []struct {
+		name      string
+		config    *config.Config
+		timestamp int64
+		expected  func(timestamp int64) bool
+	}{
+		{
+			name:      "DisabledByDefault",
+			config:    &config.Config{},
+			timestamp: now.Add(24 * time.Hour).UnixMilli(),
+			expected: func(timestamp int64) bool {
+				return timestamp == now.Add(24*time.Hour).UnixMilli()
+			},
+		},
+		{
+			name:      "WithinSkew",
+			config:    &config.Config{MetricTimestampMaxSkew: time.Hour},
+			timestamp: now.Add(-30 * time.Minute).UnixMilli(),
+			expected: func(timestamp int64) bool {
+				return timestamp == now.Add(-30*time.Minute).UnixMilli()
+			},
+		},
+		{
+			name: "FutureSkewClamped",
+			config: &config.Config{
+				MetricTimestampMaxSkew:    time.Hour,
+				MetricTimestampSkewPolicy: config.MetricTimestampSkewPolicyClamp,
+			},
+			timestamp: now.Add(24 * time.Hour).UnixMilli(),
+			expected: func(timestamp int64) bool {
+				return timestamp >= now.Add(time.Hour).UnixMilli() && timestamp <= time.Now().Add(time.Hour).UnixMilli()
+			},
+		},
+		{
+			name: "PastSkewClamped",
+			config: &config.Config{
+				MetricTimestampMaxSkew:    time.Hour,
+				MetricTimestampSkewPolicy: config.MetricTimestampSkewPolicyClamp,
+			},
+			timestamp: 1,
+			expected: func(timestamp int64) bool {
+				return timestamp >= now.Add(-time.Hour).UnixMilli() && timestamp <= time.Now().Add(-time.Hour).UnixMilli()
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := []models.Metric{{Key: "key", Timestamp: tt.timestamp}}
+			service := Service{config: tt.config}
+			require.Nil(t, service.validateMetricTimestamps(&models.Run{ID: "1"}, metrics))
+			assert.True(t, tt.expected(metrics[0].Timestamp))
+		})
+	}
+}
+
+func TestService_validateMetricTimestamps_Error(t *testing.T) {
+	testData := []struct {
+		name      string
+		timestamp int64
+	}{
+		{
+			name:      "FutureSkewRejected",
+			timestamp: time.Now().Add(24 * time.Hour).UnixMilli(),
+		},
+		{
+			name:      "PastSkewRejected",
+			timestamp: 1,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			service := Service{config: &config.Config{MetricTimestampMaxSkew: time.Hour}}
+			err := service.validateMetricTimestamps(
+				&models.Run{ID: "1"}, []models.Metric{{Key: "key", Timestamp: tt.timestamp}},
+			)
+			assert.Equal(t, api.NewInvalidParameterValueError(
+				"timestamp %d of metric 'key' for run '1' is out of allowed clock skew 1h0m0s from server time",
+				tt.timestamp,
+			), err)
+		})
+	}
+}
@@ -1,12 +1,20 @@
 package run
 
 import (
+	"github.com/rotisserie/eris"
+
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 const (
 	MaxResultsPerPage = 1000000
+	// MaxRunsPerTagBulk is the maximum number of runs updated by one bulk tag request.
+	MaxRunsPerTagBulk = 1000
+	// MaxLogModelHistoryLength is the maximum length of the history of logged models, limited by tag value length.
+	MaxLogModelHistoryLength = 5000
 )
 
 // AllowedViewTypeList supported list of ViewType.
@@ -32,6 +40,16 @@ func ValidateGetRunRequest(req *request.GetRunRequest) error {
 	if req.RunID == "" && req.RunUUID == "" {
 		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
 	}
+	for _, field := range models.NewResponseFields(req.Fields) {
+		switch field {
+		case models.ResponseFieldParams, models.ResponseFieldMetrics,
+			models.ResponseFieldTags, models.ResponseFieldSummary:
+		default:
+			return api.NewInvalidParameterValueError(
+				"invalid field '%s'. Valid values are ['params', 'metrics', 'tags', 'summary']", field,
+			)
+		}
+	}
 	return nil
 }
 
@@ -43,6 +61,14 @@ func ValidateDeleteRunRequest(req *request.DeleteRunRequest) error {
 	return nil
 }
 
+// ValidateCloneRunRequest validates `POST /mlflow/runs/clone` request.
+func ValidateCloneRunRequest(req *request.CloneRunRequest) error {
+	if req.RunID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+	return nil
+}
+
 // ValidateRestoreRunRequest validates `POST /mlflow/runs/restore` request.
 func ValidateRestoreRunRequest(req *request.RestoreRunRequest) error {
 	if req.RunID == "" {
@@ -91,6 +117,59 @@ func ValidateSetRunTagRequest(req *request.SetRunTagRequest) error {
 	return nil
 }
 
+// ValidateLogModelRequest validates `POST /mlflow/runs/log-model` request.
+func ValidateLogModelRequest(req *request.LogModelRequest) error {
+	if req.RunID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+
+	if req.ModelJSON == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'model_json'")
+	}
+	return nil
+}
+
+// ValidateSetRunsTagRequest validates `POST /mlflow/runs/set-tag-bulk` request.
+func ValidateSetRunsTagRequest(req *request.SetRunsTagRequest) error {
+	if err := validateRunSelection(&req.RunSelectionPartialRequest); err != nil {
+		return err
+	}
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+	}
+	return nil
+}
+
+// ValidateDeleteRunsTagRequest validates `POST /mlflow/runs/delete-tag-bulk` request.
+func ValidateDeleteRunsTagRequest(req *request.DeleteRunsTagRequest) error {
+	if err := validateRunSelection(&req.RunSelectionPartialRequest); err != nil {
+		return err
+	}
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+	}
+	return nil
+}
+
+// validateRunSelection validates that runs are selected either by their IDs or by the search filter.
+func validateRunSelection(req *request.RunSelectionPartialRequest) error {
+	switch {
+	case len(req.RunIDs) == 0 && len(req.ExperimentIDs) == 0:
+		return api.NewInvalidParameterValueError(
+			"Missing value for required parameter 'run_ids' or 'experiment_ids'",
+		)
+	case len(req.RunIDs) > 0 && (len(req.ExperimentIDs) > 0 || req.Filter != ""):
+		return api.NewInvalidParameterValueError(
+			"Parameter 'run_ids' can't be combined with 'experiment_ids' and 'filter'",
+		)
+	case len(req.RunIDs) > MaxRunsPerTagBulk:
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'run_ids' supplied. It should contain at most %d runs.", MaxRunsPerTagBulk,
+		)
+	}
+	return nil
+}
+
 // ValidateDeleteRunTagRequest validates `POST /mlflow/runs/delete-tag` request.
 func ValidateDeleteRunTagRequest(req *request.DeleteRunTagRequest) error {
 	if req.RunID == "" {
@@ -133,3 +212,14 @@ func ValidateSearchRunsRequest(req *request.SearchRunsRequest) error {
 	}
 	return nil
 }
+
+// ValidateSearchRunsDefaultOrderBy validates configured default orders of run search,
+// so they refer to supported sort fields the same way as order requested by the client.
+func ValidateSearchRunsDefaultOrderBy(config *config.Config) error {
+	for _, orderBy := range config.GetRunSearchDefaultOrderByClauses() {
+		if _, err := ParseOrderBy(orderBy); err != nil {
+			return eris.Wrap(err, "error parsing run search default order")
+		}
+	}
+	return nil
+}
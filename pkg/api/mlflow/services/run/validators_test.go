@@ -8,6 +8,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 func TestValidateUpdateRunRequest_Ok(t *testing.T) {
@@ -95,6 +96,34 @@ func TestValidateDeleteRunRequest_Error(t *testing.T) {
 	}
 }
 
+func TestValidateCloneRunRequest_Ok(t *testing.T) {
+	err := ValidateCloneRunRequest(&request.CloneRunRequest{
+		RunID: "id",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateCloneRunRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CloneRunRequest
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.CloneRunRequest{},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCloneRunRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
 func TestValidateRestoreRunRequest_Ok(t *testing.T) {
 	err := ValidateRestoreRunRequest(&request.RestoreRunRequest{
 		RunID: "id",
@@ -243,6 +272,42 @@ func TestValidateSetRunTagRequest_Error(t *testing.T) {
 	}
 }
 
+func TestValidateLogModelRequest_Ok(t *testing.T) {
+	err := ValidateLogModelRequest(&request.LogModelRequest{
+		RunID:     "id",
+		ModelJSON: `{"artifact_path":"model","flavors":{"sklearn":{}}}`,
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateLogModelRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogModelRequest
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.LogModelRequest{},
+		},
+		{
+			name:  "EmptyModelJSON",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'model_json'"),
+			request: &request.LogModelRequest{
+				RunID: "id",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLogModelRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
 func TestValidateDeleteRunTagRequest_Ok(t *testing.T) {
 	err := ValidateDeleteRunTagRequest(&request.DeleteRunTagRequest{
 		RunID: "id",
@@ -271,6 +336,120 @@ func TestValidateDeleteRunTagRequest_Error(t *testing.T) {
 	}
 }
 
+func TestValidateSetRunsTagRequest_Ok(t *testing.T) {
+	err := ValidateSetRunsTagRequest(&request.SetRunsTagRequest{
+		RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+			RunIDs: []string{"id1", "id2"},
+		},
+		Key: "key",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateSetRunsTagRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.SetRunsTagRequest
+	}{
+		{
+			name: "EmptyRunIDsAndExperimentIDs",
+			error: api.NewInvalidParameterValueError(
+				"Missing value for required parameter 'run_ids' or 'experiment_ids'",
+			),
+			request: &request.SetRunsTagRequest{
+				Key: "key",
+			},
+		},
+		{
+			name: "RunIDsWithExperimentIDs",
+			error: api.NewInvalidParameterValueError(
+				"Parameter 'run_ids' can't be combined with 'experiment_ids' and 'filter'",
+			),
+			request: &request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs:        []string{"id"},
+					ExperimentIDs: []string{"1"},
+				},
+				Key: "key",
+			},
+		},
+		{
+			name: "TooManyRunIDs",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'run_ids' supplied. It should contain at most %d runs.", MaxRunsPerTagBulk,
+			),
+			request: &request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: make([]string, MaxRunsPerTagBulk+1),
+				},
+				Key: "key",
+			},
+		},
+		{
+			name:  "EmptyKey",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'key'"),
+			request: &request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					ExperimentIDs: []string{"1"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetRunsTagRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateDeleteRunsTagRequest_Ok(t *testing.T) {
+	err := ValidateDeleteRunsTagRequest(&request.DeleteRunsTagRequest{
+		RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+			ExperimentIDs: []string{"1"},
+			Filter:        "tags.group = 'sweep'",
+		},
+		Key: "key",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateDeleteRunsTagRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.DeleteRunsTagRequest
+	}{
+		{
+			name: "EmptyRunIDsAndExperimentIDs",
+			error: api.NewInvalidParameterValueError(
+				"Missing value for required parameter 'run_ids' or 'experiment_ids'",
+			),
+			request: &request.DeleteRunsTagRequest{
+				Key: "key",
+			},
+		},
+		{
+			name:  "EmptyKey",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'key'"),
+			request: &request.DeleteRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: []string{"id"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDeleteRunsTagRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
 func TestValidateLogBatchRequest_Ok(t *testing.T) {
 	err := ValidateLogBatchRequest(&request.LogBatchRequest{
 		RunID: "id",
@@ -405,3 +584,51 @@ func TestValidateSearchRunsRequest_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSearchRunsDefaultOrderBy_Ok(t *testing.T) {
+	err := ValidateSearchRunsDefaultOrderBy(&config.Config{
+		RunSearchDefaultOrderBy:   "metrics.accuracy DESC",
+		NamespaceRunSearchOrderBy: []string{"team-a:attributes.start_time ASC", "team-b:params.`model:name`"},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateSearchRunsDefaultOrderBy_Error(t *testing.T) {
+	testData := []struct {
+		name   string
+		error  string
+		config *config.Config
+	}{
+		{
+			name: "UnsupportedAttribute",
+			error: "error parsing run search default order: INVALID_PARAMETER_VALUE: " +
+				"invalid order_by attribute 'created'. Valid values are ['run_name', 'start_time', 'end_time', " +
+				"'status', 'user_id', 'artifact_uri', 'run_id', 'experiment_id', 'duration']",
+			config: &config.Config{
+				RunSearchDefaultOrderBy: "attributes.created DESC",
+			},
+		},
+		{
+			name: "UnsupportedEntity",
+			error: "error parsing run search default order: INVALID_PARAMETER_VALUE: " +
+				"invalid entity type 'dataset'. Valid values are ['metric', 'parameter', 'tag', 'attribute']",
+			config: &config.Config{
+				NamespaceRunSearchOrderBy: []string{"team-a:dataset.name"},
+			},
+		},
+		{
+			name: "IncorrectDirection",
+			error: "error parsing run search default order: INVALID_PARAMETER_VALUE: " +
+				"invalid order_by clause 'metrics.accuracy DOWN'",
+			config: &config.Config{
+				RunSearchDefaultOrderBy: "metrics.accuracy DOWN",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, ValidateSearchRunsDefaultOrderBy(tt.config), tt.error)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+)
+
+// AimClient represents client for the aim api.
+type AimClient struct {
+	client *Client
+}
+
+// GetProject calls `GET /projects` endpoint.
+func (c *AimClient) GetProject(ctx context.Context) (*response.GetProjectResponse, error) {
+	var resp response.GetProjectResponse
+	if err := c.client.do(ctx, http.MethodGet, AimPrefix+"/projects/", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetExperiments calls `GET /experiments` endpoint.
+func (c *AimClient) GetExperiments(ctx context.Context) ([]response.Experiment, error) {
+	var resp []response.Experiment
+	if err := c.client.do(ctx, http.MethodGet, AimPrefix+"/experiments/", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetExperiment calls `GET /experiments/:id` endpoint.
+func (c *AimClient) GetExperiment(
+	ctx context.Context, req *request.GetExperimentRequest,
+) (*response.Experiment, error) {
+	var resp response.Experiment
+	path := fmt.Sprintf("%s/experiments/%d/", AimPrefix, req.ID)
+	if err := c.client.do(ctx, http.MethodGet, path, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRunInfo calls `GET /runs/:id/info` endpoint.
+func (c *AimClient) GetRunInfo(
+	ctx context.Context, req *request.GetRunInfoRequest,
+) (*response.GetRunInfoResponse, error) {
+	var resp response.GetRunInfoResponse
+	path := fmt.Sprintf("%s/runs/%s/info/", AimPrefix, url.PathEscape(req.ID))
+	if err := c.client.do(ctx, http.MethodGet, path, req, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateRun calls `PUT /runs/:id` endpoint.
+func (c *AimClient) UpdateRun(
+	ctx context.Context, req *request.UpdateRunRequest,
+) (*response.UpdateRunResponse, error) {
+	var resp response.UpdateRunResponse
+	path := fmt.Sprintf("%s/runs/%s/", AimPrefix, url.PathEscape(req.ID))
+	if err := c.client.do(ctx, http.MethodPut, path, nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteRun calls `DELETE /runs/:id` endpoint.
+func (c *AimClient) DeleteRun(
+	ctx context.Context, req *request.DeleteRunRequest,
+) (*response.DeleteRunResponse, error) {
+	var resp response.DeleteRunResponse
+	path := fmt.Sprintf("%s/runs/%s/", AimPrefix, url.PathEscape(req.ID))
+	if err := c.client.do(ctx, http.MethodDelete, path, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AggregateMetrics calls `POST /runs/search/metric/aggregate` endpoint.
+func (c *AimClient) AggregateMetrics(
+	ctx context.Context, req *request.AggregateMetricsRequest,
+) ([]response.AggregateMetricsResponse, error) {
+	var resp []response.AggregateMetricsResponse
+	// search doesn't change the state, so the request is safe to retry.
+	if err := c.client.do(
+		WithRetry(ctx), http.MethodPost, AimPrefix+"/runs/search/metric/aggregate/", nil, req, &resp,
+	); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// JoinMetrics calls `POST /runs/search/metric/join` endpoint.
+func (c *AimClient) JoinMetrics(
+	ctx context.Context, req *request.JoinMetricsRequest,
+) ([]response.JoinMetricsResponse, error) {
+	var resp []response.JoinMetricsResponse
+	// search doesn't change the state, so the request is safe to retry.
+	if err := c.client.do(
+		WithRetry(ctx), http.MethodPost, AimPrefix+"/runs/search/metric/join/", nil, req, &resp,
+	); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRunsMetrics calls `POST /runs/search/metric/batch` endpoint.
+func (c *AimClient) GetRunsMetrics(
+	ctx context.Context, req *request.GetRunsMetricsRequest,
+) (response.GetRunsMetricsResponse, error) {
+	var resp response.GetRunsMetricsResponse
+	// search doesn't change the state, so the request is safe to retry.
+	if err := c.client.do(
+		WithRetry(ctx), http.MethodPost, AimPrefix+"/runs/search/metric/batch/", nil, req, &resp,
+	); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
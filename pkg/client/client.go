@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hetiansu5/urlquery"
+	"github.com/rotisserie/eris"
+)
+
+// List of API prefixes.
+const (
+	MlflowPrefix = "/api/2.0/mlflow"
+	AimPrefix    = "/aim/api"
+)
+
+// default retry settings.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 500 * time.Millisecond
+)
+
+// Doer represents object able to send HTTP requests, like *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Error represents error returned by FastTrackML server.
+type Error struct {
+	StatusCode int    `json:"-"`
+	ErrorCode  string `json:"error_code"`
+	Message    string `json:"message"`
+	Detail     any    `json:"detail"`
+}
+
+// Error provides error interface to be compatible with std errors.
+func (e *Error) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("%d %s: %s", e.StatusCode, e.ErrorCode, e.Message)
+	}
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// Client represents FastTrackML client.
+type Client struct {
+	baseURL       string
+	namespace     string
+	authorization string
+	doer          Doer
+	maxRetries    int
+	retryBackoff  time.Duration
+}
+
+// NewClient creates new FastTrackML client for the server with provided base url.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		doer:         http.DefaultClient,
+		maxRetries:   DefaultMaxRetries,
+		retryBackoff: DefaultRetryBackoff,
+	}
+}
+
+// WithNamespace sets the namespace all the requests will be sent to.
+func (c *Client) WithNamespace(namespace string) *Client {
+	c.namespace = namespace
+	return c
+}
+
+// WithBasicAuth sets Basic Auth credentials.
+func (c *Client) WithBasicAuth(username, password string) *Client {
+	req := http.Request{Header: http.Header{}}
+	req.SetBasicAuth(username, password)
+	c.authorization = req.Header.Get("Authorization")
+	return c
+}
+
+// WithBearerToken sets bearer token.
+func (c *Client) WithBearerToken(token string) *Client {
+	c.authorization = fmt.Sprintf("Bearer %s", token)
+	return c
+}
+
+// WithDoer sets object which actually sends HTTP requests. By default http.DefaultClient is used.
+func (c *Client) WithDoer(doer Doer) *Client {
+	c.doer = doer
+	return c
+}
+
+// WithRetries sets maximum number of retries and the initial backoff between them.
+// Backoff is doubled after each retry.
+func (c *Client) WithRetries(maxRetries int, backoff time.Duration) *Client {
+	c.maxRetries = maxRetries
+	c.retryBackoff = backoff
+	return c
+}
+
+// retryContextKey marks context of the calls, which are allowed to be retried.
+type retryContextKey struct{}
+
+// WithRetry returns context, which allows to retry non-idempotent requests, like `POST`, sent with it.
+// By default only `GET`, `HEAD`, `PUT`, `DELETE` and `OPTIONS` requests are retried, because
+// the failed request could have been already processed by the server.
+func WithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, true)
+}
+
+// isRetryAllowed checks that request with provided method could be retried.
+func isRetryAllowed(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	allowed, _ := ctx.Value(retryContextKey{}).(bool)
+	return allowed
+}
+
+// Mlflow returns client for the mlflow api.
+func (c *Client) Mlflow() *MlflowClient {
+	return &MlflowClient{client: c}
+}
+
+// Aim returns client for the aim api.
+func (c *Client) Aim() *AimClient {
+	return &AimClient{client: c}
+}
+
+// do sends request and decodes response into provided object. Idempotent requests, and requests sent
+// with the context returned by WithRetry, are retried on network errors, `429 Too Many Requests` and
+// `5xx` responses, until context is done.
+func (c *Client) do(ctx context.Context, method, path string, query, request, response any) error {
+	u, err := c.buildURL(path, query)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	if request != nil {
+		if body, err = json.Marshal(request); err != nil {
+			return eris.Wrap(err, "error marshaling request object")
+		}
+	}
+
+	retryAllowed := isRetryAllowed(ctx, method)
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := c.send(ctx, method, u, body, response)
+		if err == nil || !retryable || !retryAllowed || attempt >= c.maxRetries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return eris.Wrap(ctx.Err(), "error waiting for retry")
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send sends a single request. Returns whether the request could be retried in case of error.
+func (c *Client) send(ctx context.Context, method, u string, body []byte, response any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return false, eris.Wrap(err, "error creating request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return true, eris.Wrap(err, "error doing request")
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, eris.Wrap(err, "error reading response data")
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		respErr := Error{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(data, &respErr); err != nil || respErr.Message == "" {
+			respErr.Message = strings.TrimSpace(string(data))
+		}
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retryable, &respErr
+	}
+	if response != nil {
+		if err := json.Unmarshal(data, response); err != nil {
+			return false, eris.Wrap(err, "error unmarshaling response data")
+		}
+	}
+	return false, nil
+}
+
+// buildURL builds request url with namespace and query parameters.
+func (c *Client) buildURL(path string, query any) (string, error) {
+	if c.namespace != "" {
+		path = fmt.Sprintf("/ns/%s%s", url.PathEscape(c.namespace), path)
+	}
+	u, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return "", eris.Wrap(err, "error building url")
+	}
+	if query != nil {
+		values, err := urlquery.Marshal(query)
+		if err != nil {
+			return "", eris.Wrap(err, "error marshaling query parameters")
+		}
+		u.RawQuery = string(values)
+	}
+	return u.String(), nil
+}
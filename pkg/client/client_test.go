@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_do_Ok(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ns/custom/api/2.0/mlflow/experiments/get", r.URL.Path)
+		assert.Equal(t, "experiment_id=1", r.URL.RawQuery)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte(`{"experiment": {"experiment_id": "1", "name": "test"}}`))
+	}))
+	defer server.Close()
+
+	type getExperimentRequest struct {
+		ID string `query:"experiment_id"`
+	}
+	var resp struct {
+		Experiment struct {
+			Name string `json:"name"`
+		} `json:"experiment"`
+	}
+	err := NewClient(server.URL).WithNamespace("custom").WithBearerToken("token").WithRetries(
+		3, time.Millisecond,
+	).do(
+		context.Background(), http.MethodGet, MlflowPrefix+"/experiments/get", getExperimentRequest{ID: "1"}, nil, &resp,
+	)
+	require.Nil(t, err)
+	assert.Equal(t, "test", resp.Experiment.Name)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestClient_do_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		retries    int
+		attempts   int32
+		error      *Error
+	}{
+		{
+			name:       "NotRetryableError",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error_code": "INVALID_PARAMETER_VALUE", "message": "invalid name"}`,
+			retries:    3,
+			attempts:   1,
+			error: &Error{
+				StatusCode: http.StatusBadRequest,
+				ErrorCode:  "INVALID_PARAMETER_VALUE",
+				Message:    "invalid name",
+			},
+		},
+		{
+			name:       "RetriesExhausted",
+			statusCode: http.StatusInternalServerError,
+			body:       "internal error",
+			retries:    2,
+			attempts:   3,
+			error: &Error{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "internal error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.statusCode)
+				//nolint:errcheck
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := NewClient(server.URL).WithRetries(tt.retries, time.Millisecond).do(
+				WithRetry(context.Background()), http.MethodPost, MlflowPrefix+"/runs/create", nil, struct{}{}, nil,
+			)
+			assert.Equal(t, tt.error, err)
+			assert.Equal(t, tt.attempts, attempts.Load())
+		})
+	}
+}
+
+func TestClient_do_NotIdempotentRequest(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// POST request is not retried, unless retry is explicitly allowed.
+	err := NewClient(server.URL).WithRetries(3, time.Millisecond).do(
+		context.Background(), http.MethodPost, MlflowPrefix+"/runs/create", nil, struct{}{}, nil,
+	)
+	assert.Equal(t, &Error{StatusCode: http.StatusServiceUnavailable}, err)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestClient_do_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := NewClient(server.URL).WithRetries(100, time.Second).do(
+		ctx, http.MethodGet, MlflowPrefix+"/experiments/get", nil, nil, nil,
+	)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,32 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/client"
+)
+
+func Example() {
+	ctx := context.Background()
+	mlflow := client.NewClient("http://localhost:5000").WithNamespace("my-namespace").WithBasicAuth(
+		"user", "password",
+	).Mlflow()
+
+	experiment, err := mlflow.CreateExperiment(ctx, &request.CreateExperimentRequest{Name: "my-experiment"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	run, err := mlflow.CreateRun(ctx, &request.CreateRunRequest{ExperimentID: experiment.ID, Name: "my-run"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := mlflow.LogMetric(ctx, &request.LogMetricRequest{
+		RunID: run.Run.Info.ID, Key: "loss", Value: 0.5, Timestamp: 1, Step: 0,
+	}); err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+)
+
+// MlflowClient represents client for the mlflow api.
+type MlflowClient struct {
+	client *Client
+}
+
+// CreateExperiment calls `POST /experiments/create` endpoint.
+func (c *MlflowClient) CreateExperiment(
+	ctx context.Context, req *request.CreateExperimentRequest,
+) (*response.CreateExperimentResponse, error) {
+	var resp response.CreateExperimentResponse
+	if err := c.client.do(ctx, http.MethodPost, MlflowPrefix+"/experiments/create", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetExperiment calls `GET /experiments/get` endpoint.
+func (c *MlflowClient) GetExperiment(
+	ctx context.Context, req *request.GetExperimentRequest,
+) (*response.GetExperimentResponse, error) {
+	var resp response.GetExperimentResponse
+	if err := c.client.do(ctx, http.MethodGet, MlflowPrefix+"/experiments/get", req, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetExperimentByName calls `GET /experiments/get-by-name` endpoint.
+func (c *MlflowClient) GetExperimentByName(
+	ctx context.Context, req *request.GetExperimentRequest,
+) (*response.GetExperimentResponse, error) {
+	var resp response.GetExperimentResponse
+	if err := c.client.do(ctx, http.MethodGet, MlflowPrefix+"/experiments/get-by-name", req, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchExperiments calls `POST /experiments/search` endpoint.
+func (c *MlflowClient) SearchExperiments(
+	ctx context.Context, req *request.SearchExperimentsRequest,
+) (*response.SearchExperimentsResponse, error) {
+	var resp response.SearchExperimentsResponse
+	// search doesn't change the state, so the request is safe to retry.
+	if err := c.client.do(
+		WithRetry(ctx), http.MethodPost, MlflowPrefix+"/experiments/search", nil, req, &resp,
+	); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteExperiment calls `POST /experiments/delete` endpoint.
+func (c *MlflowClient) DeleteExperiment(ctx context.Context, req *request.DeleteExperimentRequest) error {
+	return c.client.do(ctx, http.MethodPost, MlflowPrefix+"/experiments/delete", nil, req, nil)
+}
+
+// CreateRun calls `POST /runs/create` endpoint.
+func (c *MlflowClient) CreateRun(
+	ctx context.Context, req *request.CreateRunRequest,
+) (*response.CreateRunResponse, error) {
+	var resp response.CreateRunResponse
+	if err := c.client.do(ctx, http.MethodPost, MlflowPrefix+"/runs/create", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRun calls `GET /runs/get` endpoint.
+func (c *MlflowClient) GetRun(ctx context.Context, req *request.GetRunRequest) (*response.GetRunResponse, error) {
+	var resp response.GetRunResponse
+	if err := c.client.do(ctx, http.MethodGet, MlflowPrefix+"/runs/get", req, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateRun calls `POST /runs/update` endpoint.
+func (c *MlflowClient) UpdateRun(
+	ctx context.Context, req *request.UpdateRunRequest,
+) (*response.UpdateRunResponse, error) {
+	var resp response.UpdateRunResponse
+	if err := c.client.do(ctx, http.MethodPost, MlflowPrefix+"/runs/update", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchRuns calls `POST /runs/search` endpoint.
+func (c *MlflowClient) SearchRuns(
+	ctx context.Context, req *request.SearchRunsRequest,
+) (*response.SearchRunsResponse, error) {
+	var resp response.SearchRunsResponse
+	// search doesn't change the state, so the request is safe to retry.
+	if err := c.client.do(
+		WithRetry(ctx), http.MethodPost, MlflowPrefix+"/runs/search", nil, req, &resp,
+	); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteRun calls `POST /runs/delete` endpoint.
+func (c *MlflowClient) DeleteRun(ctx context.Context, req *request.DeleteRunRequest) error {
+	return c.client.do(ctx, http.MethodPost, MlflowPrefix+"/runs/delete", nil, req, nil)
+}
+
+// SetRunTag calls `POST /runs/set-tag` endpoint.
+func (c *MlflowClient) SetRunTag(ctx context.Context, req *request.SetRunTagRequest) error {
+	return c.client.do(ctx, http.MethodPost, MlflowPrefix+"/runs/set-tag", nil, req, nil)
+}
+
+// LogMetric calls `POST /runs/log-metric` endpoint.
+func (c *MlflowClient) LogMetric(ctx context.Context, req *request.LogMetricRequest) error {
+	return c.client.do(ctx, http.MethodPost, MlflowPrefix+"/runs/log-metric", nil, req, nil)
+}
+
+// LogParam calls `POST /runs/log-parameter` endpoint.
+func (c *MlflowClient) LogParam(ctx context.Context, req *request.LogParamRequest) error {
+	return c.client.do(ctx, http.MethodPost, MlflowPrefix+"/runs/log-parameter", nil, req, nil)
+}
+
+// LogBatch calls `POST /runs/log-batch` endpoint.
+func (c *MlflowClient) LogBatch(ctx context.Context, req *request.LogBatchRequest) error {
+	return c.client.do(ctx, http.MethodPost, MlflowPrefix+"/runs/log-batch", nil, req, nil)
+}
+
+// GetMetricHistory calls `GET /metrics/get-history` endpoint.
+func (c *MlflowClient) GetMetricHistory(
+	ctx context.Context, req *request.GetMetricHistoryRequest,
+) (*response.GetMetricHistoryResponse, error) {
+	var resp response.GetMetricHistoryResponse
+	if err := c.client.do(ctx, http.MethodGet, MlflowPrefix+"/metrics/get-history", req, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/compaction"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+var CompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Recomputes the latest metrics from the metrics history",
+	Long: `The compact command recomputes the latest metric of each run, key
+         and context from the metrics history and fixes the latest metrics
+         which drifted from it. Runs are processed in batches, so it is safe
+         to run it while the FasttrackML server is logging metrics.`,
+	RunE: compactCmd,
+}
+
+func compactCmd(cmd *cobra.Command, args []string) error {
+	db, err := database.NewDBProvider(
+		viper.GetString("database-uri"),
+		time.Second*1,
+		database.NewPoolConfig(1),
+		database.StatementTimeouts{},
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	if err := database.CheckAndMigrateDB(false, db.GormDB()); err != nil {
+		return fmt.Errorf("error checking database schema: %w", err)
+	}
+
+	result, err := compaction.NewService(
+		repositories.NewLatestMetricRepository(db.GormDB()),
+	).CompactLatestMetrics(cmd.Context(), viper.GetInt("batch-size"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf(
+		"Compacted latest metrics of %d runs: %d created, %d updated, %d deleted\n",
+		result.Runs, result.Created, result.Updated, result.Deleted,
+	)
+	return nil
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(CompactCmd)
+
+	CompactCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	CompactCmd.Flags().Int("batch-size", compaction.DefaultBatchSize, "Number of runs compacted at once")
+}
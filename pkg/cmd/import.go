@@ -25,7 +25,8 @@ func importCmd(cmd *cobra.Command, args []string) error {
 	input, err := database.NewDBProvider(
 		viper.GetString("input-database-uri"),
 		time.Second*1,
-		20,
+		database.NewPoolConfig(20),
+		database.StatementTimeouts{},
 	)
 	if err != nil {
 		return fmt.Errorf("error connecting to input DB: %w", err)
@@ -34,7 +35,8 @@ func importCmd(cmd *cobra.Command, args []string) error {
 	output, err := database.NewDBProvider(
 		viper.GetString("output-database-uri"),
 		time.Second*1,
-		20,
+		database.NewPoolConfig(20),
+		database.StatementTimeouts{},
 	)
 	if err != nil {
 		return fmt.Errorf("error connecting to output DB: %w", err)
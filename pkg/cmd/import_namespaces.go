@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+)
+
+var ImportNamespacesCmd = &cobra.Command{
+	Use:   "import-namespaces",
+	Short: "Creates namespaces from a YAML or JSON file",
+	Long: `The import-namespaces command creates namespaces from the YAML or
+         JSON list of namespace definitions. Already existing namespaces
+         are skipped, or updated when --update-existing flag is set, so
+         the same file can be imported several times.`,
+	RunE: importNamespacesCmd,
+}
+
+func importNamespacesCmd(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(viper.GetString("file"))
+	if err != nil {
+		return fmt.Errorf("error reading namespace definitions: %w", err)
+	}
+	definitions, err := namespace.ParseDefinitions(data)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.NewDBProvider(
+		viper.GetString("database-uri"),
+		time.Second*1,
+		database.NewPoolConfig(1),
+		database.StatementTimeouts{},
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	if err := database.CheckAndMigrateDB(false, db.GormDB()); err != nil {
+		return fmt.Errorf("error checking database schema: %w", err)
+	}
+
+	results := namespace.NewService(
+		&config.Config{DefaultArtifactRoot: viper.GetString("default-artifact-root")},
+		repositories.NewNamespaceRepository(db.GormDB()),
+		repositories.NewExperimentRepository(db.GormDB()),
+	).ImportNamespaces(cmd.Context(), definitions, viper.GetBool("update-existing"))
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == namespace.ImportStatusFailed {
+			failed++
+			fmt.Printf("%s: %s (%s)\n", result.Code, result.Status, result.Error)
+		} else {
+			fmt.Printf("%s: %s\n", result.Code, result.Status)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d namespaces failed to import", failed, len(results))
+	}
+	return nil
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(ImportNamespacesCmd)
+
+	ImportNamespacesCmd.Flags().StringP("file", "f", "", "Path to YAML or JSON file with namespace definitions")
+	ImportNamespacesCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	ImportNamespacesCmd.Flags().StringP("default-artifact-root", "a", "./artifacts", "Artifact Root")
+	ImportNamespacesCmd.Flags().Bool("update-existing", false, "Update already existing namespaces instead of skipping")
+	ImportNamespacesCmd.MarkFlagRequired("file")
+}
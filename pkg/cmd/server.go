@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
 	"github.com/G-Research/fasttrackml/pkg/server"
 )
 
@@ -66,10 +67,22 @@ func init() {
 	RootCmd.AddCommand(ServerCmd)
 
 	ServerCmd.Flags().StringP("listen-address", "a", "localhost:5000", "Address (host:post) to listen to")
-	ServerCmd.Flags().String("default-artifact-root", "./artifacts", "Default artifact root")
+	ServerCmd.Flags().String(
+		"default-artifact-root", "./artifacts",
+		"Default artifact root, which may contain {namespace} and {experiment_id} placeholders "+
+			"(experiment ID is appended, when not used)",
+	)
 	ServerCmd.Flags().String("s3-endpoint-uri", "", "S3 compatible storage base endpoint url")
+	ServerCmd.Flags().String("s3-region", "", "S3 compatible storage region (AWS default region if empty)")
+	ServerCmd.Flags().Bool(
+		"s3-force-path-style", false, "Use path-style addressing for S3 (always used with s3-endpoint-uri)",
+	)
+	ServerCmd.Flags().Bool("s3-disable-ssl", false, "Use plain HTTP to connect to S3 compatible storage")
 	ServerCmd.Flags().String("gs-endpoint-uri", "", "Google Storage base endpoint url")
 	ServerCmd.Flags().MarkHidden("gs-endpoint-uri")
+	ServerCmd.Flags().String(
+		"gs-credentials-file", "", "Google Storage service account key file (Application Default Credentials if empty)",
+	)
 	ServerCmd.Flags().String("auth-username", "", "BasicAuth username")
 	ServerCmd.Flags().String("auth-password", "", "BasicAuth password")
 	ServerCmd.Flags().String("auth-users-config", "", "Users configuration file")
@@ -79,13 +92,195 @@ func init() {
 	ServerCmd.Flags().String("auth-oidc-scopes", "", "OIDC requested scopes")
 	ServerCmd.Flags().String("auth-oidc-admin-role", "", "OIDC admin role identifier")
 	ServerCmd.Flags().String("auth-oidc-claim-roles", "", "OIDC claim to inspect for roles")
+	ServerCmd.Flags().String("auth-oidc-providers-config", "", "Configuration file of additional OIDC providers")
+	ServerCmd.Flags().Duration(
+		"auth-oidc-refresh-interval", auth.DefaultOIDCRefreshInterval, "Interval of OIDC provider discovery and keys refresh",
+	)
+	ServerCmd.Flags().String("auth-cookie-name", auth.DefaultCookieName, "Name of the auth cookie")
+	ServerCmd.Flags().String("auth-cookie-domain", "", "Domain of the auth cookie (request host if empty)")
+	ServerCmd.Flags().String("auth-cookie-path", auth.DefaultCookiePath, "Path of the auth cookie")
+	ServerCmd.Flags().String(
+		"auth-cookie-same-site", auth.CookieSameSiteLax, "SameSite attribute of the auth cookie (Lax, Strict or None)",
+	)
+	ServerCmd.Flags().Bool(
+		"auth-cookie-secure", false, "Always set Secure attribute of the auth cookie (set for HTTPS requests otherwise)",
+	)
+	ServerCmd.Flags().Duration(
+		"auth-cookie-max-age", 0, "Max age of the auth cookie (cookie lasts until the browser is closed if 0)",
+	)
 	ServerCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
 	ServerCmd.Flags().Int("database-pool-max", 20, "Maximum number of database connections in the pool")
+	ServerCmd.Flags().Int(
+		"database-pool-max-idle", 0, "Maximum number of idle database connections in the pool (0 for database-pool-max)",
+	)
+	ServerCmd.Flags().Duration(
+		"database-conn-max-lifetime", 0, "Time after which database connections are closed (0 for default of 30m)",
+	)
+	ServerCmd.Flags().Duration(
+		"database-conn-max-idle-time", 0, "Time after which idle database connections are closed (0 for default of 1m)",
+	)
 	ServerCmd.Flags().Duration("database-slow-threshold", 1*time.Second, "Slow SQL warning threshold")
+	ServerCmd.Flags().Duration(
+		"database-read-statement-timeout", 0, "Timeout after which database queries are aborted (0 to use database default)",
+	)
+	ServerCmd.Flags().Duration(
+		"database-write-statement-timeout", 0, "Timeout after which database writes are aborted (0 to use database default)",
+	)
 	ServerCmd.Flags().Bool("database-migrate", true, "Run database migrations")
 	ServerCmd.Flags().Bool("database-reset", false, "Reinitialize database - WARNING all data will be lost!")
 	ServerCmd.Flags().Bool("live-updates-enabled", false, "Enable 'live updates' in the Aim UI")
 	ServerCmd.Flags().MarkHidden("database-reset")
+	ServerCmd.Flags().Duration(
+		"retention-interval", 1*time.Hour, "Interval between namespace retention policy runs (0 to disable)",
+	)
+	ServerCmd.Flags().Duration(
+		"activity-interval", 24*time.Hour, "Interval between rebuilds of the project activity summary (0 to disable)",
+	)
+	ServerCmd.Flags().String(
+		"namespace-base-domain", "", "Base domain which subdomains are resolved as namespaces, e.g. 'mlflow.example.com'",
+	)
+	ServerCmd.Flags().String(
+		"namespace-header", "X-Namespace", "Header to resolve namespace from (requires 'header' resolver)",
+	)
+	ServerCmd.Flags().StringSlice(
+		"namespace-resolution-order", []string{"path", "subdomain"}, "Order of namespace resolvers (path, header, subdomain)",
+	)
+	ServerCmd.Flags().Int("run-max-param-keys", 0, "Maximum number of distinct param keys per run (0 to disable)")
+	ServerCmd.Flags().Int("run-max-metric-keys", 0, "Maximum number of distinct metric keys per run (0 to disable)")
+	ServerCmd.Flags().Int("run-max-tag-keys", 0, "Maximum number of distinct tag keys per run (0 to disable)")
+	ServerCmd.Flags().Int(
+		"namespace-max-param-keys", 0, "Maximum number of distinct param keys per namespace (0 to disable)",
+	)
+	ServerCmd.Flags().Int(
+		"namespace-max-metric-keys", 0, "Maximum number of distinct metric keys per namespace (0 to disable)",
+	)
+	ServerCmd.Flags().Int("namespace-max-tag-keys", 0, "Maximum number of distinct tag keys per namespace (0 to disable)")
+	ServerCmd.Flags().Duration(
+		"metric-timestamp-max-skew", 0, "Maximum allowed skew of metric timestamps from server time (0 to disable)",
+	)
+	ServerCmd.Flags().String(
+		"metric-timestamp-skew-policy", "reject", "Policy for skewed metric timestamps (reject, clamp)",
+	)
+	ServerCmd.Flags().Int(
+		"max-page-size", config.DefaultMaxPageSize, "Maximum number of items returned by list and search endpoints per page",
+	)
+	ServerCmd.Flags().StringSlice(
+		"namespace-feature-flags", []string{},
+		"Per-namespace overrides of features in format 'namespace:feature=true|false' (features: live-updates)",
+	)
+	ServerCmd.Flags().Duration(
+		"project-cache-ttl", 0, "Time to cache Aim project information of the namespace (0 to disable)",
+	)
+	ServerCmd.Flags().Bool(
+		"maintenance-mode", false, "Start in maintenance mode - reject write requests to Aim and MLflow APIs",
+	)
+	ServerCmd.Flags().Duration(
+		"request-timeout", 0, "Maximum time to process requests to Aim and MLflow APIs (0 to disable)",
+	)
+	ServerCmd.Flags().Duration(
+		"artifact-request-timeout", 0, "Maximum time to process requests to MLflow artifact API (0 to disable)",
+	)
+	ServerCmd.Flags().Duration(
+		"artifact-upload-session-ttl", 1*time.Hour, "Time after which abandoned chunked artifact uploads are removed",
+	)
+	ServerCmd.Flags().Int64(
+		"artifact-max-file-size", 0, "Maximum size, in bytes, of each uploaded artifact file (0 to disable)",
+	)
+	ServerCmd.Flags().StringSlice(
+		"artifact-root-fallbacks", []string{}, "Artifact roots tried in order, when an artifact isn't found "+
+			"under the run artifact root, in format 'root=fallback'",
+	)
+	ServerCmd.Flags().StringSlice(
+		"artifact-allowed-schemes", []string{},
+		"Schemes allowed in custom artifact locations of experiments, e.g. 's3,gs' (default all)",
+	)
+	ServerCmd.Flags().StringSlice(
+		"namespace-artifact-allowed-schemes", []string{},
+		"Per-namespace overrides of allowed artifact schemes in format 'namespace:scheme'",
+	)
+	ServerCmd.Flags().String(
+		"system-tag-policy", "allow", "Policy for user modifications of system run tags (allow, reject, ignore)",
+	)
+	ServerCmd.Flags().StringSlice(
+		"system-tag-prefixes", config.DefaultSystemTagPrefixes, "Prefixes of run tags treated as system tags",
+	)
+	ServerCmd.Flags().String(
+		"run-search-default-order-by", "",
+		"Order of run search results, when it isn't requested, e.g. 'metrics.accuracy DESC' (default start_time DESC)",
+	)
+	ServerCmd.Flags().StringSlice(
+		"namespace-run-search-default-order-by", []string{},
+		"Per-namespace overrides of run search default order in format 'namespace:clause'",
+	)
+	ServerCmd.Flags().StringSlice(
+		"middleware-plugins", []string{},
+		"Ordered list of enabled middleware plugins in format 'position:name', "+
+			"where position is either 'before-namespace' or 'after-auth'",
+	)
+	ServerCmd.Flags().StringSlice(
+		"ip-allowlist", []string{}, "IP addresses and CIDRs allowed to access the server (default all)",
+	)
+	ServerCmd.Flags().StringSlice(
+		"ip-denylist", []string{}, "IP addresses and CIDRs denied to access the server, even if they are allowed",
+	)
+	ServerCmd.Flags().StringSlice(
+		"trusted-proxies", []string{},
+		"IP addresses and CIDRs of proxies trusted to report client address in X-Forwarded-For and X-Real-IP headers",
+	)
+	ServerCmd.Flags().StringSlice(
+		"response-headers", []string{}, "Headers added to responses by 'response-headers' middleware plugin "+
+			"in format 'Name:value'",
+	)
+	ServerCmd.Flags().Int(
+		"metric-value-precision", 0,
+		"Number of significant digits of metric values in responses (0 for the shortest exact representation)",
+	)
+	ServerCmd.Flags().StringSlice(
+		"debug-body-log-prefixes", []string{},
+		"Route prefixes, which request and response bodies are logged for, for debugging (disabled when empty)",
+	)
+	ServerCmd.Flags().Int(
+		"debug-body-log-max-size", config.DefaultDebugBodyLogMaxSize,
+		"Maximum size, in bytes, of each logged request and response body",
+	)
+	ServerCmd.Flags().Int(
+		"max-decompressed-request-size", config.DefaultMaxDecompressedRequestSize,
+		"Maximum size, in bytes, of gzip or deflate compressed request body after decompression",
+	)
+	ServerCmd.Flags().Int(
+		"namespace-ingestion-rate-limit", 0,
+		"Maximum rate, in metric points per second, of metrics ingestion per namespace (0 to disable)",
+	)
+	ServerCmd.Flags().Duration(
+		"namespace-ingestion-rate-window", config.DefaultNamespaceIngestionRateWindow,
+		"Length of the sliding window, which namespace metrics ingestion rate is measured over",
+	)
+	ServerCmd.Flags().String(
+		"namespace-ingestion-throttle-policy", config.IngestionThrottlePolicyReject,
+		"Policy for namespaces exceeding metrics ingestion rate limit (reject, delay)",
+	)
+	ServerCmd.Flags().String(
+		"terminated-run-log-policy", config.TerminatedRunLogPolicyAllow,
+		"Policy for logging of metrics and params to finished, failed or killed runs (allow, reject)",
+	)
+	ServerCmd.Flags().Int(
+		"max-experiment-name-length", config.DefaultMaxExperimentNameLength, "Maximum length of experiment names",
+	)
+	ServerCmd.Flags().Int("max-run-name-length", config.DefaultMaxRunNameLength, "Maximum length of run names")
+	ServerCmd.Flags().Int(
+		"log-batch-max-items", 0, "Maximum number of metrics, params and tags per log-batch request (0 to disable)",
+	)
+	ServerCmd.Flags().String(
+		"chooser-default-namespace", "", "Namespace which users land on after authentication, if they have access to it",
+	)
+	ServerCmd.Flags().StringSlice(
+		"chooser-user-default-namespaces", []string{},
+		"Per-user overrides of the namespace which users land on in format 'user:namespace'",
+	)
+	ServerCmd.Flags().Bool(
+		"strict-run-status-transitions", false,
+		"Reject run status updates, which don't follow run lifecycle, e.g. from FINISHED back to RUNNING",
+	)
 	ServerCmd.Flags().Bool("dev-mode", false, "Development mode - enable CORS")
 	ServerCmd.Flags().MarkHidden("dev-mode")
 	ServerCmd.Flags().Bool("run-original-aim-service", false, "Run original aim service at /aim/api")
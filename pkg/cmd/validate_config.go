@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+var ValidateConfigCmd = &cobra.Command{
+	Use:   "validate-config",
+	Short: "Validates the tracking server configuration",
+	Long: `The validate-config command loads the tracking server configuration
+         from the same flags and environment variables as the server command
+         and validates it, including the users configuration file, storage
+         URIs and database URI, without starting the server. All the found
+         problems are reported at once.`,
+	RunE: validateConfigCmd,
+}
+
+func validateConfigCmd(cmd *cobra.Command, args []string) error {
+	err := config.NewConfig().Validate()
+	if err == nil {
+		fmt.Println("Configuration is valid")
+		return nil
+	}
+
+	// validation errors are joined, so each of them is reported separately.
+	problems := []error{err}
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		problems = joined.Unwrap()
+	}
+	fmt.Println("Configuration is invalid:")
+	for _, problem := range problems {
+		fmt.Printf("  - %v\n", problem)
+	}
+	return fmt.Errorf("found %d configuration problem(s)", len(problems))
+}
+
+func init() {
+	RootCmd.AddCommand(ValidateConfigCmd)
+
+	// the configuration is the server one, so the server flags are shared. They are already defined,
+	// as init functions are run in order of file names.
+	ValidateConfigCmd.Flags().AddFlagSet(ServerCmd.Flags())
+}
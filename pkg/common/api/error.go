@@ -23,8 +23,10 @@ func (e *ErrorResponse) WithError(err error) {
 	e.OriginalError = err
 }
 
+// ErrorCode is a machine-readable code of the error, which is stable across releases.
 type ErrorCode string
 
+// supported list of error codes.
 const (
 	ErrorCodeInternalError          = "INTERNAL_ERROR"
 	ErrorCodeTemporarilyUnavailable = "TEMPORARILY_UNAVAILABLE"
@@ -33,6 +35,9 @@ const (
 	ErrorCodeEndpointNotFound       = "ENDPOINT_NOT_FOUND"
 	ErrorCodeResourceAlreadyExists  = "RESOURCE_ALREADY_EXISTS"
 	ErrorCodeResourceDoesNotExist   = "RESOURCE_DOES_NOT_EXIST"
+	ErrorCodeResourceConflict       = "RESOURCE_CONFLICT"
+	ErrorCodePermissionDenied       = "PERMISSION_DENIED"
+	ErrorCodeRequestLimitExceeded   = "REQUEST_LIMIT_EXCEEDED"
 )
 
 // NewBadRequestError creates new Response object with ErrorCodeBadRequest.
@@ -80,6 +85,24 @@ func NewResourceAlreadyExistsError(msg string, args ...any) *ErrorResponse {
 	}
 }
 
+// NewResourceConflictError creates new Response object with ErrorCodeResourceConflict.
+func NewResourceConflictError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodeResourceConflict,
+		StatusCode: http.StatusConflict,
+	}
+}
+
+// NewTemporarilyUnavailableError creates new Response object with ErrorCodeTemporarilyUnavailable.
+func NewTemporarilyUnavailableError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodeTemporarilyUnavailable,
+		StatusCode: http.StatusServiceUnavailable,
+	}
+}
+
 // NewEndpointNotFound creates new Response object with ErrorCodeEndpointNotFound.
 func NewEndpointNotFound(msg string, args ...any) *ErrorResponse {
 	return &ErrorResponse{
@@ -88,3 +111,41 @@ func NewEndpointNotFound(msg string, args ...any) *ErrorResponse {
 		StatusCode: http.StatusNotFound,
 	}
 }
+
+// NewPermissionDeniedError creates new Response object with ErrorCodePermissionDenied.
+func NewPermissionDeniedError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodePermissionDenied,
+		StatusCode: http.StatusForbidden,
+	}
+}
+
+// NewRequestLimitExceededError creates new Response object with ErrorCodeRequestLimitExceeded.
+func NewRequestLimitExceededError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodeRequestLimitExceeded,
+		StatusCode: http.StatusTooManyRequests,
+	}
+}
+
+// ErrorCodeFromStatus maps HTTP status code of the error to the ErrorCode.
+func ErrorCodeFromStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrorCodeBadRequest
+	case http.StatusForbidden:
+		return ErrorCodePermissionDenied
+	case http.StatusNotFound:
+		return ErrorCodeResourceDoesNotExist
+	case http.StatusConflict:
+		return ErrorCodeResourceAlreadyExists
+	case http.StatusServiceUnavailable:
+		return ErrorCodeTemporarilyUnavailable
+	case http.StatusTooManyRequests:
+		return ErrorCodeRequestLimitExceeded
+	default:
+		return ErrorCodeInternalError
+	}
+}
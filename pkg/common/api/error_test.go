@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodeFromStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected ErrorCode
+	}{
+		{
+			name:     "BadRequest",
+			status:   http.StatusBadRequest,
+			expected: ErrorCodeBadRequest,
+		},
+		{
+			name:     "UnprocessableEntity",
+			status:   http.StatusUnprocessableEntity,
+			expected: ErrorCodeBadRequest,
+		},
+		{
+			name:     "Forbidden",
+			status:   http.StatusForbidden,
+			expected: ErrorCodePermissionDenied,
+		},
+		{
+			name:     "NotFound",
+			status:   http.StatusNotFound,
+			expected: ErrorCodeResourceDoesNotExist,
+		},
+		{
+			name:     "Conflict",
+			status:   http.StatusConflict,
+			expected: ErrorCodeResourceAlreadyExists,
+		},
+		{
+			name:     "ServiceUnavailable",
+			status:   http.StatusServiceUnavailable,
+			expected: ErrorCodeTemporarilyUnavailable,
+		},
+		{
+			name:     "TooManyRequests",
+			status:   http.StatusTooManyRequests,
+			expected: ErrorCodeRequestLimitExceeded,
+		},
+		{
+			name:     "InternalServerError",
+			status:   http.StatusInternalServerError,
+			expected: ErrorCodeInternalError,
+		},
+		{
+			name:     "UnknownStatus",
+			status:   http.StatusTeapot,
+			expected: ErrorCodeInternalError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ErrorCodeFromStatus(tt.status))
+		})
+	}
+}
+
+func TestErrorResponse_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		error    *ErrorResponse
+		expected string
+	}{
+		{
+			name:     "InternalError",
+			error:    NewInternalError("error %d", 1),
+			expected: "INTERNAL_ERROR: error 1",
+		},
+		{
+			name:     "ResourceDoesNotExist",
+			error:    NewResourceDoesNotExistError("run '%s' not found", "id"),
+			expected: "RESOURCE_DOES_NOT_EXIST: run 'id' not found",
+		},
+		{
+			name:     "ResourceAlreadyExists",
+			error:    NewResourceAlreadyExistsError("experiment '%s' already exists", "name"),
+			expected: "RESOURCE_ALREADY_EXISTS: experiment 'name' already exists",
+		},
+		{
+			name:     "BadRequest",
+			error:    NewBadRequestError("bad request"),
+			expected: "BAD_REQUEST: bad request",
+		},
+		{
+			name:     "PermissionDenied",
+			error:    NewPermissionDeniedError("api key has read only access"),
+			expected: "PERMISSION_DENIED: api key has read only access",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.error.Error())
+		})
+	}
+}
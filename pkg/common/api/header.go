@@ -0,0 +1,4 @@
+package api
+
+// PageSizeHeader is a name of response header, which contains effective page size of list and search endpoints.
+const PageSizeHeader = "X-Page-Size"
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MaxMetricValuePrecision is the maximum number of significant digits of metric values,
+// enough to represent any float64 value exactly.
+const MaxMetricValuePrecision = 17
+
+// MetricValue represents metric value, which is serialized into JSON consistently across all the endpoints.
+type MetricValue struct {
+	value     float64
+	precision int
+}
+
+// NewMetricValue creates new MetricValue, which is serialized with precision significant digits.
+// Zero precision means the shortest representation, which is parsed back to exactly the same value.
+func NewMetricValue(value float64, precision int) MetricValue {
+	return MetricValue{
+		value:     value,
+		precision: precision,
+	}
+}
+
+// Float64 returns metric value as float64.
+func (v MetricValue) Float64() float64 {
+	return v.value
+}
+
+// MarshalJSON implements json.Marshaler interface. Values are formatted with requested number of
+// significant digits, while NaN and infinite values, which JSON numbers can't hold, are formatted
+// as strings in the same way as MLflow does.
+func (v MetricValue) MarshalJSON() ([]byte, error) {
+	value := v.value
+	switch {
+	case math.IsNaN(value):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(value, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(value, -1):
+		return []byte(`"-Infinity"`), nil
+	}
+
+	precision := v.precision
+	if precision == 0 {
+		precision = -1
+	}
+	return strconv.AppendFloat(nil, value, 'g', precision, 64), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. It accepts both numbers and strings,
+// which are produced by MarshalJSON for NaN and infinite values.
+func (v *MetricValue) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null":
+	case `"NaN"`:
+		*v = MetricValue{value: math.NaN()}
+	case `"Infinity"`:
+		*v = MetricValue{value: math.Inf(1)}
+	case `"-Infinity"`:
+		*v = MetricValue{value: math.Inf(-1)}
+	default:
+		value, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return fmt.Errorf("unsupported metric value %s", data)
+		}
+		*v = MetricValue{value: value}
+	}
+	return nil
+}
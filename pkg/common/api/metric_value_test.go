@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricValue_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision int
+		value     float64
+		expected  string
+	}{
+		{
+			name:     "ShortestDecimal",
+			value:    0.1,
+			expected: "0.1",
+		},
+		{
+			name:     "ShortestSum",
+			value:    0.30000000000000004,
+			expected: "0.30000000000000004",
+		},
+		{
+			name:     "ShortestInteger",
+			value:    42,
+			expected: "42",
+		},
+		{
+			name:     "ShortestSmall",
+			value:    1e-7,
+			expected: "1e-07",
+		},
+		{
+			name:      "Precision",
+			precision: 3,
+			value:     0.30000000000000004,
+			expected:  "0.3",
+		},
+		{
+			name:      "MaxPrecision",
+			precision: MaxMetricValuePrecision,
+			value:     0.1,
+			expected:  "0.10000000000000001",
+		},
+		{
+			name:     "NaN",
+			value:    math.NaN(),
+			expected: `"NaN"`,
+		},
+		{
+			name:     "PositiveInfinity",
+			value:    math.Inf(1),
+			expected: `"Infinity"`,
+		},
+		{
+			name:     "NegativeInfinity",
+			value:    math.Inf(-1),
+			expected: `"-Infinity"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(NewMetricValue(tt.value, tt.precision))
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestMetricValue_RoundTrip(t *testing.T) {
+	for _, value := range []float64{0.1, 0.30000000000000004, 1.0 / 3, 1e-300, math.MaxFloat64, -math.SmallestNonzeroFloat64} {
+		data, err := json.Marshal(NewMetricValue(value, 0))
+		require.Nil(t, err)
+
+		var decoded MetricValue
+		require.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, value, decoded.Float64())
+	}
+}
+
+func TestMetricValue_UnmarshalJSON_Error(t *testing.T) {
+	var value MetricValue
+	assert.EqualError(t, json.Unmarshal([]byte(`"abc"`), &value), `unsupported metric value "abc"`)
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ValidateName checks that name of the entity, like experiment or run, is not longer than maxLength
+// characters and has no control characters, which break UI layout.
+func ValidateName(entity, name string, maxLength int) error {
+	if length := utf8.RuneCountInString(name); length > maxLength {
+		return NewInvalidParameterValueError(
+			"%s name is %d characters long, which exceeds the maximum length of %d characters", entity, length, maxLength,
+		)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) != -1 {
+		return NewInvalidParameterValueError("%s name should not contain control characters", entity)
+	}
+	return nil
+}
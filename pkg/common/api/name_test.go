@@ -0,0 +1,53 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateName_Ok(t *testing.T) {
+	assert.Nil(t, ValidateName("run", "chill-run", 10))
+	// length is measured in characters, not bytes.
+	assert.Nil(t, ValidateName("run", strings.Repeat("ü", 10), 10))
+}
+
+func TestValidateName_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		entity    string
+		value     string
+		maxLength int
+		error     *ErrorResponse
+	}{
+		{
+			name:      "OverLongName",
+			entity:    "run",
+			value:     strings.Repeat("a", 11),
+			maxLength: 10,
+			error: NewInvalidParameterValueError(
+				"run name is 11 characters long, which exceeds the maximum length of 10 characters",
+			),
+		},
+		{
+			name:      "NameWithNewLine",
+			entity:    "experiment",
+			value:     "first\nsecond",
+			maxLength: 256,
+			error:     NewInvalidParameterValueError("experiment name should not contain control characters"),
+		},
+		{
+			name:      "NameWithEscapeCharacter",
+			entity:    "experiment",
+			value:     "name\x1b[31m",
+			maxLength: 256,
+			error:     NewInvalidParameterValueError("experiment name should not contain control characters"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.error, ValidateName(tt.entity, tt.value, tt.maxLength))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEApplicationPaginatedJSON is a media type, which clients put into `Accept` header to receive
+// PaginatedResponse envelope from list and search endpoints, which keep their own response shape otherwise.
+const MIMEApplicationPaginatedJSON = "application/vnd.fasttrackml.paginated+json"
+
+// PaginatedResponse is a pagination envelope, shared by list and search endpoints of all the APIs.
+// NextToken is empty on the last page, Total is null when the total number of items isn't known.
+type PaginatedResponse[T any] struct {
+	Items     []T    `json:"items"`
+	NextToken string `json:"next_token"`
+	Total     *int64 `json:"total"`
+}
+
+// NewPaginatedResponse creates new PaginatedResponse object.
+func NewPaginatedResponse[T any](items []T, nextToken string, total *int64) *PaginatedResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &PaginatedResponse[T]{
+		Items:     items,
+		NextToken: nextToken,
+		Total:     total,
+	}
+}
+
+// IsPaginatedResponseAccepted checks whether client opted in for PaginatedResponse envelope.
+func IsPaginatedResponseAccepted(ctx *fiber.Ctx) bool {
+	return ctx.Accepts(
+		fiber.MIMEApplicationJSON, MIMEApplicationPaginatedJSON,
+	) == MIMEApplicationPaginatedJSON
+}
+
+// SendPaginatedResponse sends PaginatedResponse envelope, negotiated by IsPaginatedResponseAccepted.
+func SendPaginatedResponse[T any](ctx *fiber.Ctx, resp *PaginatedResponse[T]) error {
+	return ctx.JSON(resp, MIMEApplicationPaginatedJSON)
+}
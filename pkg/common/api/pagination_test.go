@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaginatedResponse_Ok(t *testing.T) {
+	total := int64(3)
+	tests := []struct {
+		name     string
+		response any
+		expected string
+	}{
+		{
+			name:     "WithNextTokenAndTotal",
+			response: NewPaginatedResponse([]string{"a", "b"}, "token", &total),
+			expected: `{"items":["a","b"],"next_token":"token","total":3}`,
+		},
+		{
+			name:     "LastPageWithUnknownTotal",
+			response: NewPaginatedResponse([]int{1}, "", nil),
+			expected: `{"items":[1],"next_token":"","total":null}`,
+		},
+		{
+			name:     "NoItems",
+			response: NewPaginatedResponse[string](nil, "", nil),
+			expected: `{"items":[],"next_token":"","total":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.response)
+			require.Nil(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// supported list of placeholders of the artifact root, which are expanded at experiment creation time.
+const (
+	ArtifactRootNamespacePlaceholder    = "{namespace}"
+	ArtifactRootExperimentIDPlaceholder = "{experiment_id}"
+)
+
+// artifactRootPlaceholderRegexp matches any placeholder of the artifact root.
+var artifactRootPlaceholderRegexp = regexp.MustCompile(`{[^{}]*}`)
+
+// ValidateArtifactRootPlaceholders checks that artifact root contains only supported placeholders.
+func ValidateArtifactRootPlaceholders(artifactRoot string) error {
+	for _, placeholder := range artifactRootPlaceholderRegexp.FindAllString(artifactRoot, -1) {
+		if placeholder != ArtifactRootNamespacePlaceholder && placeholder != ArtifactRootExperimentIDPlaceholder {
+			return fmt.Errorf("unsupported placeholder '%s'", placeholder)
+		}
+	}
+	if strings.ContainsAny(artifactRootPlaceholderRegexp.ReplaceAllString(artifactRoot, ""), "{}") {
+		return fmt.Errorf("unbalanced braces of placeholders")
+	}
+	return nil
+}
+
+// ExpandArtifactRoot replaces placeholders of the artifact root with namespace code and experiment ID.
+func ExpandArtifactRoot(artifactRoot, namespaceCode string, experimentID int32) string {
+	return strings.NewReplacer(
+		ArtifactRootNamespacePlaceholder, namespaceCode,
+		ArtifactRootExperimentIDPlaceholder, fmt.Sprintf("%d", experimentID),
+	).Replace(artifactRoot)
+}
+
+// BuildArtifactLocation builds artifact location of the experiment from the artifact root. Placeholders of
+// the artifact root are expanded, and experiment ID is appended, unless artifact root already contains it.
+func BuildArtifactLocation(artifactRoot, namespaceCode string, experimentID int32) (string, error) {
+	if err := ValidateArtifactRootPlaceholders(artifactRoot); err != nil {
+		return "", err
+	}
+	location := ExpandArtifactRoot(artifactRoot, namespaceCode, experimentID)
+	if _, err := url.Parse(location); err != nil {
+		return "", err
+	}
+	if strings.Contains(artifactRoot, ArtifactRootExperimentIDPlaceholder) {
+		return strings.TrimRight(location, "/"), nil
+	}
+	return url.JoinPath(location, fmt.Sprintf("%d", experimentID))
+}
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildArtifactLocation_Ok(t *testing.T) {
+	tests := []struct {
+		name          string
+		artifactRoot  string
+		namespaceCode string
+		experimentID  int32
+		expected      string
+	}{
+		{
+			name:          "WithoutPlaceholders",
+			artifactRoot:  "s3://bucket/prefix",
+			namespaceCode: "team-a",
+			experimentID:  1,
+			expected:      "s3://bucket/prefix/1",
+		},
+		{
+			name:          "WithNamespaceAndExperimentIDPlaceholders",
+			artifactRoot:  "s3://bucket/{namespace}/{experiment_id}/",
+			namespaceCode: "team-a",
+			experimentID:  1,
+			expected:      "s3://bucket/team-a/1",
+		},
+		{
+			name:          "WithNamespaceAndExperimentIDPlaceholdersOfAnotherNamespace",
+			artifactRoot:  "s3://bucket/{namespace}/{experiment_id}/",
+			namespaceCode: "team-b",
+			experimentID:  25,
+			expected:      "s3://bucket/team-b/25",
+		},
+		{
+			name:          "WithNamespacePlaceholderOnly",
+			artifactRoot:  "gs://bucket/{namespace}/experiments",
+			namespaceCode: "team-a",
+			experimentID:  7,
+			expected:      "gs://bucket/team-a/experiments/7",
+		},
+		{
+			name:          "WithExperimentIDPlaceholderInTheMiddle",
+			artifactRoot:  "file:///data/{namespace}/{experiment_id}/artifacts",
+			namespaceCode: "default",
+			experimentID:  0,
+			expected:      "file:///data/default/0/artifacts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := BuildArtifactLocation(tt.artifactRoot, tt.namespaceCode, tt.experimentID)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expected, location)
+		})
+	}
+}
+
+func TestValidateArtifactRootPlaceholders_Error(t *testing.T) {
+	tests := []struct {
+		name         string
+		artifactRoot string
+		error        string
+	}{
+		{
+			name:         "UnsupportedPlaceholder",
+			artifactRoot: "s3://bucket/{team}/{experiment_id}",
+			error:        "unsupported placeholder '{team}'",
+		},
+		{
+			name:         "UnclosedNamespacePlaceholder",
+			artifactRoot: "s3://bucket/{namespace/{experiment_id}",
+			error:        "unbalanced braces of placeholders",
+		},
+		{
+			name:         "UnclosedExperimentIDPlaceholder",
+			artifactRoot: "s3://bucket/{namespace}/{experiment_id",
+			error:        "unbalanced braces of placeholders",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, ValidateArtifactRootPlaceholders(tt.artifactRoot), tt.error)
+		})
+	}
+}
@@ -2,10 +2,16 @@ package auth
 
 // User represents object to store current user information.
 type User struct {
+	name    string
 	roles   []string
 	isAdmin bool
 }
 
+// GetName returns current user name.
+func (u User) GetName() string {
+	return u.name
+}
+
 // IsAdmin makes check that current user is Admin user.
 func (u User) IsAdmin() bool {
 	return u.isAdmin
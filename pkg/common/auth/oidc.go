@@ -3,9 +3,12 @@ package auth
 import (
 	"context"
 	"slices"
+	"sync"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
 )
@@ -16,28 +19,110 @@ type OIDCClientProvider interface {
 	Verify(ctx context.Context, accessToken string) (*User, error)
 }
 
-// OIDCClient represents OIDC client.
+const (
+	// oidcDiscoveryTimeout is a timeout of one OIDC provider discovery attempt.
+	oidcDiscoveryTimeout = 10 * time.Second
+	// oidcMinRetryDelay is a delay before the first retry of failed OIDC provider discovery.
+	// Next retries are delayed twice longer each time, until the refresh interval is reached.
+	oidcMinRetryDelay = time.Second
+)
+
+// OIDCClient represents OIDC client of one OIDC provider.
 type OIDCClient struct {
-	config   *auth.Config
-	verifier *oidc.IDTokenVerifier
+	provider        auth.OIDCProviderConfig
+	refreshInterval time.Duration
+	mutex           sync.RWMutex
+	issuer          string
+	verifier        *oidc.IDTokenVerifier
+}
+
+// NewOIDCClient creates new OIDC client. Discovery document of OIDC provider is fetched in the background
+// and re-fetched periodically until ctx is canceled, so temporarily unavailable provider doesn't prevent
+// the server from start and rotated signing keys are picked up without restart.
+func NewOIDCClient(
+	ctx context.Context, provider auth.OIDCProviderConfig, refreshInterval time.Duration,
+) *OIDCClient {
+	client := &OIDCClient{
+		provider:        provider,
+		refreshInterval: refreshInterval,
+	}
+	if err := client.refresh(ctx); err != nil {
+		log.Warnf("OIDC provider '%s' is unavailable, discovery will be retried: %+v", provider.Name, err)
+	}
+	go client.refreshPeriodically(ctx)
+	return client
 }
 
-// NewOIDCClient creates new OIDC client
-func NewOIDCClient(ctx context.Context, config *auth.Config) (*OIDCClient, error) {
-	provider, err := oidc.NewProvider(ctx, config.AuthOIDCProviderEndpoint)
+// refresh fetches OIDC provider discovery document and replaces the token verifier.
+// Signing keys of the new verifier are fetched lazily and re-fetched on unknown key id.
+func (c *OIDCClient) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, oidcDiscoveryTimeout)
+	defer cancel()
+	provider, err := oidc.NewProvider(ctx, c.provider.ProviderEndpoint)
 	if err != nil {
-		return nil, eris.Wrap(err, "error creating OIDC provider")
+		return eris.Wrap(err, "error creating OIDC provider")
 	}
+	var discovery struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := provider.Claims(&discovery); err != nil {
+		return eris.Wrap(err, "error reading OIDC provider issuer")
+	}
+	verifier := provider.Verifier(&oidc.Config{ClientID: c.provider.ClientID, SkipIssuerCheck: true})
 
-	return &OIDCClient{
-		config:   config,
-		verifier: provider.Verifier(&oidc.Config{ClientID: config.AuthOIDCClientID, SkipIssuerCheck: true}),
-	}, nil
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.issuer, c.verifier = discovery.Issuer, verifier
+	return nil
+}
+
+// refreshPeriodically refreshes OIDC provider with configured interval. Failed refreshes are retried
+// with growing delay, the previous verifier stays in use meanwhile.
+func (c *OIDCClient) refreshPeriodically(ctx context.Context) {
+	interval, retryDelay := c.refreshInterval, oidcMinRetryDelay
+	for {
+		delay := interval
+		if c.getVerifier() == nil {
+			delay, retryDelay = min(retryDelay, interval), min(retryDelay*2, interval)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := c.refresh(ctx); err != nil {
+			log.Warnf("error refreshing OIDC provider '%s': %+v", c.provider.Name, err)
+			continue
+		}
+		retryDelay = oidcMinRetryDelay
+	}
+}
+
+// getVerifier returns current token verifier or nil, if OIDC provider discovery hasn't succeeded yet.
+func (c *OIDCClient) getVerifier() *oidc.IDTokenVerifier {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.verifier
+}
+
+// getIssuer returns issuer of OIDC provider or empty string, if OIDC provider discovery hasn't succeeded yet.
+func (c *OIDCClient) getIssuer() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.issuer
 }
 
 // Verify makes Access Token verification.
-func (c OIDCClient) Verify(ctx context.Context, accessToken string) (*User, error) {
-	idToken, err := c.verifier.Verify(ctx, accessToken)
+func (c *OIDCClient) Verify(ctx context.Context, accessToken string) (*User, error) {
+	verifier := c.getVerifier()
+	if verifier == nil {
+		return nil, eris.Errorf("OIDC provider '%s' is not available", c.provider.Name)
+	}
+	idToken, err := verifier.Verify(ctx, accessToken)
 	if err != nil {
 		return nil, eris.Wrap(err, "error verifying access token")
 	}
@@ -47,17 +132,29 @@ func (c OIDCClient) Verify(ctx context.Context, accessToken string) (*User, erro
 		return nil, eris.Wrap(err, "error extracting token claims")
 	}
 
-	data, ok := claims[c.config.AuthOIDCClaimRoles]
+	data, ok := claims[c.provider.ClaimRoles]
 	if !ok {
-		return nil, eris.Errorf("claim property: %s not found", c.config.AuthOIDCClaimRoles)
+		return nil, eris.Errorf("claim property: %s not found", c.provider.ClaimRoles)
 	}
 
 	roles, err := ConvertAndNormaliseRoles(data)
 	if err != nil {
-		return nil, eris.Wrapf(err, "error converting claim %s property", c.config.AuthOIDCClaimRoles)
+		return nil, eris.Wrapf(err, "error converting claim %s property", c.provider.ClaimRoles)
 	}
 	return &User{
+		name:    getUserName(claims),
 		roles:   roles,
-		isAdmin: slices.Contains(roles, c.config.AuthOIDCAdminRole),
+		isAdmin: slices.Contains(roles, c.provider.AdminRole),
 	}, nil
 }
+
+// getUserName returns user name from token claims. standard claims are checked
+// in order of preference, because their presence depends on requested scopes.
+func getUserName(claims map[string]interface{}) string {
+	for _, claim := range []string{"preferred_username", "email", "sub"} {
+		if name, ok := claims[claim].(string); ok && name != "" {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+)
+
+// OIDCProvidersClient verifies Access Tokens issued by any of several configured OIDC providers.
+type OIDCProvidersClient struct {
+	clients []*OIDCClient
+}
+
+// NewOIDCProvidersClient creates new client of all the OIDC providers from auth configuration.
+func NewOIDCProvidersClient(ctx context.Context, config *auth.Config) *OIDCProvidersClient {
+	providers := config.GetOIDCProviders()
+	clients := make([]*OIDCClient, 0, len(providers))
+	for _, provider := range providers {
+		clients = append(clients, NewOIDCClient(ctx, provider, config.GetOIDCRefreshInterval()))
+	}
+	return &OIDCProvidersClient{
+		clients: clients,
+	}
+}
+
+// Verify makes Access Token verification by OIDC provider, which issued the token.
+// When only one provider is configured, the token is verified by it regardless of the `iss` claim.
+func (c *OIDCProvidersClient) Verify(ctx context.Context, accessToken string) (*User, error) {
+	if len(c.clients) == 1 {
+		return c.clients[0].Verify(ctx, accessToken)
+	}
+
+	issuer, err := getTokenIssuer(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	var matchedClients []*OIDCClient
+	for _, client := range c.clients {
+		if client.getIssuer() == issuer {
+			matchedClients = append(matchedClients, client)
+		}
+	}
+	switch len(matchedClients) {
+	case 0:
+		return nil, eris.Errorf("token issuer '%s' doesn't match any available OIDC provider", issuer)
+	case 1:
+		return matchedClients[0].Verify(ctx, accessToken)
+	default:
+		return nil, eris.Errorf("token issuer '%s' matches several OIDC providers", issuer)
+	}
+}
+
+// getTokenIssuer returns `iss` claim of the token without its verification, which is done later
+// by the matched OIDC provider.
+func getTokenIssuer(accessToken string) (string, error) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return "", eris.New("malformed access token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", eris.Wrap(err, "error decoding access token payload")
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", eris.Wrap(err, "error parsing access token payload")
+	}
+	if claims.Issuer == "" {
+		return "", eris.New("access token has no issuer")
+	}
+	return claims.Issuer, nil
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+)
+
+// newTestToken creates unsigned token with provided payload.
+func newTestToken(payload string) string {
+	return fmt.Sprintf("header.%s.signature", base64.RawURLEncoding.EncodeToString([]byte(payload)))
+}
+
+func TestGetTokenIssuer_Ok(t *testing.T) {
+	issuer, err := getTokenIssuer(newTestToken(`{"iss":"https://employees.example.com","sub":"user"}`))
+	require.Nil(t, err)
+	assert.Equal(t, "https://employees.example.com", issuer)
+}
+
+func TestGetTokenIssuer_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		error string
+	}{
+		{
+			name:  "MalformedToken",
+			token: "token",
+			error: "malformed access token",
+		},
+		{
+			name:  "NotEncodedPayload",
+			token: "header.#.signature",
+			error: "error decoding access token payload",
+		},
+		{
+			name:  "NotJSONPayload",
+			token: newTestToken("payload"),
+			error: "error parsing access token payload",
+		},
+		{
+			name:  "NoIssuer",
+			token: newTestToken(`{"sub":"user"}`),
+			error: "access token has no issuer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := getTokenIssuer(tt.token)
+			assert.ErrorContains(t, err, tt.error)
+		})
+	}
+}
+
+func TestOIDCProvidersClient_Verify_Error(t *testing.T) {
+	client := OIDCProvidersClient{
+		clients: []*OIDCClient{
+			{provider: auth.OIDCProviderConfig{Name: "employees"}, issuer: "https://employees.example.com"},
+			{provider: auth.OIDCProviderConfig{Name: "contractors"}, issuer: "https://contractors.example.com"},
+			{provider: auth.OIDCProviderConfig{Name: "partners"}, issuer: "https://contractors.example.com"},
+			{provider: auth.OIDCProviderConfig{Name: "unavailable"}},
+		},
+	}
+	tests := []struct {
+		name  string
+		token string
+		error string
+	}{
+		{
+			name:  "UnmatchedIssuer",
+			token: newTestToken(`{"iss":"https://unknown.example.com"}`),
+			error: "token issuer 'https://unknown.example.com' doesn't match any available OIDC provider",
+		},
+		{
+			name:  "AmbiguousIssuer",
+			token: newTestToken(`{"iss":"https://contractors.example.com"}`),
+			error: "token issuer 'https://contractors.example.com' matches several OIDC providers",
+		},
+		{
+			name:  "MatchedProviderIsNotAvailable",
+			token: newTestToken(`{"iss":"https://employees.example.com"}`),
+			error: "OIDC provider 'employees' is not available",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Verify(context.Background(), tt.token)
+			assert.ErrorContains(t, err, tt.error)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package auth
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/rotisserie/eris"
 
 	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
@@ -12,6 +16,22 @@ const (
 	TypeUser string = "user"
 )
 
+// supported list of SameSite attribute values of the auth cookie.
+const (
+	CookieSameSiteLax    = "Lax"
+	CookieSameSiteStrict = "Strict"
+	CookieSameSiteNone   = "None"
+)
+
+// DefaultOIDCRefreshInterval is a default interval of re-fetching of OIDC provider discovery document and keys.
+const DefaultOIDCRefreshInterval = time.Hour
+
+// default attributes of the auth cookie.
+const (
+	DefaultCookieName = "access_token"
+	DefaultCookiePath = "/"
+)
+
 type Config struct {
 	AuthType                  string
 	AuthUsername              string
@@ -23,7 +43,16 @@ type Config struct {
 	AuthOIDCAdminRole         string
 	AuthOIDCClaimRoles        string
 	AuthOIDCProviderEndpoint  string
+	AuthOIDCRefreshInterval   time.Duration
+	AuthOIDCProvidersConfig   string
+	AuthOIDCProviders         []OIDCProviderConfig
 	AuthParsedUserPermissions *models.UserPermissions
+	AuthCookieName            string
+	AuthCookieDomain          string
+	AuthCookiePath            string
+	AuthCookieSameSite        string
+	AuthCookieSecure          bool
+	AuthCookieMaxAge          time.Duration
 }
 
 // IsAuthTypeOIDC makes check that current auth is TypeOIDC.
@@ -36,9 +65,98 @@ func (c *Config) IsAuthTypeUser() bool {
 	return c.AuthType == TypeUser
 }
 
+// GetOIDCProviders returns all configured OIDC providers: the one configured with `auth-oidc-*` flags
+// followed by the ones loaded from `auth-oidc-providers-config` file.
+func (c *Config) GetOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	if c.AuthOIDCProviderEndpoint != "" {
+		providers = append(providers, OIDCProviderConfig{
+			Name:             DefaultOIDCProviderName,
+			ClientID:         c.AuthOIDCClientID,
+			ProviderEndpoint: c.AuthOIDCProviderEndpoint,
+			ClaimRoles:       c.AuthOIDCClaimRoles,
+			AdminRole:        c.AuthOIDCAdminRole,
+		})
+	}
+	return append(providers, c.AuthOIDCProviders...)
+}
+
+// GetOIDCRefreshInterval returns configured interval of OIDC provider refresh or the default one.
+func (c *Config) GetOIDCRefreshInterval() time.Duration {
+	if c.AuthOIDCRefreshInterval == 0 {
+		return DefaultOIDCRefreshInterval
+	}
+	return c.AuthOIDCRefreshInterval
+}
+
+// GetCookieName returns configured name of the auth cookie or the default one.
+func (c *Config) GetCookieName() string {
+	if c.AuthCookieName == "" {
+		return DefaultCookieName
+	}
+	return c.AuthCookieName
+}
+
+// GetCookiePath returns configured path of the auth cookie or the default one.
+func (c *Config) GetCookiePath() string {
+	if c.AuthCookiePath == "" {
+		return DefaultCookiePath
+	}
+	return c.AuthCookiePath
+}
+
+// GetCookieSameSite returns configured SameSite attribute of the auth cookie or the default one.
+func (c *Config) GetCookieSameSite() string {
+	if c.AuthCookieSameSite == "" {
+		return CookieSameSiteLax
+	}
+	return c.AuthCookieSameSite
+}
+
 // ValidateConfiguration validates service configuration for correctness.
 func (c *Config) ValidateConfiguration() error {
-	return nil
+	var errs []error
+	if c.AuthUsersConfig != "" {
+		if _, err := Load(c.AuthUsersConfig); err != nil {
+			errs = append(errs, eris.Wrapf(
+				err, "error loading auth user configuration from file: %s", c.AuthUsersConfig,
+			))
+		}
+	}
+
+	// browsers reject cookies with `SameSite=None`, which are not `Secure`.
+	switch sameSite := c.GetCookieSameSite(); {
+	case strings.EqualFold(sameSite, CookieSameSiteNone):
+		if !c.AuthCookieSecure {
+			errs = append(errs, eris.New(
+				"'auth-cookie-secure' flag should be enabled, when 'auth-cookie-same-site' flag is 'None'",
+			))
+		}
+	case !strings.EqualFold(sameSite, CookieSameSiteLax) && !strings.EqualFold(sameSite, CookieSameSiteStrict):
+		errs = append(errs, eris.Errorf("unsupported value '%s' in 'auth-cookie-same-site' flag", sameSite))
+	}
+	if c.AuthCookieMaxAge < 0 {
+		errs = append(errs, eris.New("'auth-cookie-max-age' flag should not be negative"))
+	}
+	if c.AuthOIDCRefreshInterval < 0 {
+		errs = append(errs, eris.New("'auth-oidc-refresh-interval' flag should not be negative"))
+	}
+	if c.AuthOIDCProvidersConfig != "" {
+		providers, err := LoadOIDCProviders(c.AuthOIDCProvidersConfig)
+		if err != nil {
+			errs = append(errs, eris.Wrapf(
+				err, "error loading OIDC providers configuration from file: %s", c.AuthOIDCProvidersConfig,
+			))
+		}
+		for i, provider := range providers {
+			if provider.Name == "" || provider.ClientID == "" || provider.ProviderEndpoint == "" {
+				errs = append(errs, eris.Errorf(
+					"OIDC provider #%d should have 'name', 'client_id' and 'provider_endpoint' properties", i+1,
+				))
+			}
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // NormalizeConfiguration normalizes auth configuration parameters.
@@ -51,8 +169,30 @@ func (c *Config) NormalizeConfiguration() error {
 			return eris.Wrapf(err, "error loading auth user configuration from file: %s", c.AuthUsersConfig)
 		}
 		c.AuthParsedUserPermissions = parsedUserPermissions
+	case c.AuthOIDCProvidersConfig != "":
+		c.AuthType = TypeOIDC
+		providers, err := LoadOIDCProviders(c.AuthOIDCProvidersConfig)
+		if err != nil {
+			return eris.Wrapf(
+				err, "error loading OIDC providers configuration from file: %s", c.AuthOIDCProvidersConfig,
+			)
+		}
+		c.AuthOIDCProviders = providers
 	case c.AuthOIDCClientID != "" && c.AuthOIDCClientSecret != "" && c.AuthOIDCProviderEndpoint != "":
 		c.AuthType = TypeOIDC
 	}
 	return nil
 }
+
+// ReloadUserPermissions reloads user permissions from the users configuration file.
+func (c *Config) ReloadUserPermissions() error {
+	if !c.IsAuthTypeUser() {
+		return nil
+	}
+	parsedUserPermissions, err := Load(c.AuthUsersConfig)
+	if err != nil {
+		return eris.Wrapf(err, "error loading auth user configuration from file: %s", c.AuthUsersConfig)
+	}
+	c.AuthParsedUserPermissions.Replace(parsedUserPermissions.GetData())
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,6 +41,19 @@ func TestConfig_NormalizeConfiguration(t *testing.T) {
 			},
 			configType: TypeOIDC,
 		},
+		{
+			name: "TestAuthTypeOIDCWithProvidersConfig",
+			init: func() *Config {
+				configPath := fmt.Sprintf("%s/providers.yml", t.TempDir())
+				assert.Nil(t, os.WriteFile(
+					configPath, []byte("providers:\n- name: contractors\n  client_id: id\n  provider_endpoint: url\n"), 0o600,
+				))
+				return &Config{
+					AuthOIDCProvidersConfig: configPath,
+				}
+			},
+			configType: TypeOIDC,
+		},
 	}
 
 	for _, tt := range tests {
@@ -50,3 +64,102 @@ func TestConfig_NormalizeConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ReloadUserPermissions(t *testing.T) {
+	configPath := fmt.Sprintf("%s/configuration.yml", t.TempDir())
+	assert.Nil(t, os.WriteFile(configPath, []byte("users:\n- name: user1\n  password: pass\n  roles: [ns:ns1]\n"), 0o600))
+
+	config := Config{AuthUsersConfig: configPath}
+	assert.Nil(t, config.NormalizeConfiguration())
+	permissions := config.AuthParsedUserPermissions
+
+	assert.Nil(t, os.WriteFile(configPath, []byte("users:\n- name: user1\n  password: pass\n  roles: [ns:ns2]\n"), 0o600))
+	assert.Nil(t, config.ReloadUserPermissions())
+
+	// permissions object is kept, only its data is replaced.
+	assert.Same(t, permissions, config.AuthParsedUserPermissions)
+	assert.Equal(t, map[string]map[string]struct{}{
+		"dXNlcjE6cGFzcw==": {"ns:ns2": {}},
+	}, config.AuthParsedUserPermissions.GetData())
+}
+
+func TestConfig_ValidateConfiguration(t *testing.T) {
+	validConfigPath := fmt.Sprintf("%s/valid.yml", t.TempDir())
+	assert.Nil(t, os.WriteFile(validConfigPath, []byte("users:\n- name: user1\n  password: pass\n"), 0o600))
+	invalidConfigPath := fmt.Sprintf("%s/invalid.yml", t.TempDir())
+	assert.Nil(t, os.WriteFile(invalidConfigPath, []byte("users: [[\n"), 0o600))
+
+	assert.Nil(t, (&Config{}).ValidateConfiguration())
+	assert.Nil(t, (&Config{AuthUsersConfig: validConfigPath}).ValidateConfiguration())
+	assert.ErrorContains(
+		t,
+		(&Config{AuthUsersConfig: invalidConfigPath}).ValidateConfiguration(),
+		"error loading auth user configuration from file",
+	)
+	assert.ErrorContains(
+		t,
+		(&Config{AuthUsersConfig: fmt.Sprintf("%s/not_existing.yml", t.TempDir())}).ValidateConfiguration(),
+		"error reading user configuration file",
+	)
+
+	assert.Nil(t, (&Config{
+		AuthCookieSameSite: CookieSameSiteNone,
+		AuthCookieSecure:   true,
+		AuthCookieMaxAge:   time.Hour,
+	}).ValidateConfiguration())
+	assert.ErrorContains(
+		t,
+		(&Config{AuthCookieSameSite: CookieSameSiteNone}).ValidateConfiguration(),
+		"'auth-cookie-secure' flag should be enabled, when 'auth-cookie-same-site' flag is 'None'",
+	)
+	assert.ErrorContains(
+		t,
+		(&Config{AuthCookieSameSite: "Any"}).ValidateConfiguration(),
+		"unsupported value 'Any' in 'auth-cookie-same-site' flag",
+	)
+	assert.ErrorContains(
+		t,
+		(&Config{AuthCookieMaxAge: -time.Second}).ValidateConfiguration(),
+		"'auth-cookie-max-age' flag should not be negative",
+	)
+	assert.ErrorContains(
+		t,
+		(&Config{AuthOIDCRefreshInterval: -time.Second}).ValidateConfiguration(),
+		"'auth-oidc-refresh-interval' flag should not be negative",
+	)
+
+	providersConfigPath := fmt.Sprintf("%s/providers.yml", t.TempDir())
+	assert.Nil(t, os.WriteFile(providersConfigPath, []byte("providers:\n- name: contractors\n"), 0o600))
+	assert.ErrorContains(
+		t,
+		(&Config{AuthOIDCProvidersConfig: providersConfigPath}).ValidateConfiguration(),
+		"OIDC provider #1 should have 'name', 'client_id' and 'provider_endpoint' properties",
+	)
+	assert.ErrorContains(
+		t,
+		(&Config{AuthOIDCProvidersConfig: fmt.Sprintf("%s/providers.json", t.TempDir())}).ValidateConfiguration(),
+		"error loading OIDC providers configuration from file",
+	)
+}
+
+func TestConfig_GetOIDCProviders(t *testing.T) {
+	config := Config{
+		AuthOIDCClientID:         "employees_id",
+		AuthOIDCProviderEndpoint: "https://employees.example.com",
+		AuthOIDCClaimRoles:       "groups",
+		AuthOIDCAdminRole:        "admin",
+		AuthOIDCProviders: []OIDCProviderConfig{
+			{Name: "contractors", ClientID: "contractors_id", ProviderEndpoint: "https://contractors.example.com"},
+		},
+	}
+	assert.Equal(t, []OIDCProviderConfig{
+		{
+			Name:             DefaultOIDCProviderName,
+			ClientID:         "employees_id",
+			ProviderEndpoint: "https://employees.example.com",
+			ClaimRoles:       "groups",
+			AdminRole:        "admin",
+		},
+		{Name: "contractors", ClientID: "contractors_id", ProviderEndpoint: "https://contractors.example.com"},
+	}, config.GetOIDCProviders())
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/rotisserie/eris"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultOIDCProviderName is a name of OIDC provider, configured with `auth-oidc-*` flags.
+const DefaultOIDCProviderName = "default"
+
+// OIDCProviderConfig represents configuration of one OIDC provider.
+type OIDCProviderConfig struct {
+	Name             string `yaml:"name"`
+	ClientID         string `yaml:"client_id"`
+	ProviderEndpoint string `yaml:"provider_endpoint"`
+	ClaimRoles       string `yaml:"claim_roles"`
+	AdminRole        string `yaml:"admin_role"`
+}
+
+// YamlOIDCProvidersConfig represents OIDC providers configuration in YAML format.
+type YamlOIDCProvidersConfig struct {
+	Providers []OIDCProviderConfig `yaml:"providers"`
+}
+
+// LoadOIDCProviders loads OIDC providers configuration from given configuration file.
+func LoadOIDCProviders(configFilePath string) ([]OIDCProviderConfig, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return nil, eris.Wrap(err, "error reading OIDC providers configuration file")
+	}
+
+	switch filepath.Ext(configFilePath) {
+	case ".yaml", ".yml":
+		config := YamlOIDCProvidersConfig{}
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, eris.Wrap(err, "error parsing OIDC providers configuration from yaml")
+		}
+		return config.Providers, nil
+	}
+	return nil, eris.Errorf("unsupported OIDC providers configuration file type")
+}
@@ -1,33 +1,178 @@
 package config
 
 import (
+	"errors"
+	"net"
 	"net/url"
+	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rotisserie/eris"
 	"github.com/spf13/viper"
 
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
+// supported list of namespace resolvers.
+const (
+	NamespaceResolverPath      = "path"
+	NamespaceResolverHeader    = "header"
+	NamespaceResolverSubdomain = "subdomain"
+)
+
+// supported list of policies applied to metric timestamps out of allowed clock skew.
+const (
+	MetricTimestampSkewPolicyReject = "reject"
+	MetricTimestampSkewPolicyClamp  = "clamp"
+)
+
+// supported list of policies applied to metrics ingestion of namespaces exceeding the rate limit.
+const (
+	IngestionThrottlePolicyReject = "reject"
+	IngestionThrottlePolicyDelay  = "delay"
+)
+
+// supported list of policies applied to user modifications of system tags.
+const (
+	SystemTagPolicyAllow  = "allow"
+	SystemTagPolicyReject = "reject"
+	SystemTagPolicyIgnore = "ignore"
+)
+
+// supported list of policies applied to logging of metrics and params to runs in a terminal status.
+const (
+	TerminatedRunLogPolicyAllow  = "allow"
+	TerminatedRunLogPolicyReject = "reject"
+)
+
+// supported list of features, which could be enabled or disabled per namespace.
+const (
+	FeatureLiveUpdates = "live-updates"
+)
+
+// supported list of positions of middleware plugins in the middleware chain.
+const (
+	// MiddlewarePluginPositionBeforeNamespace attaches plugin before namespace and auth middlewares,
+	// so the plugin handles every request, including requests of anonymous users.
+	MiddlewarePluginPositionBeforeNamespace = "before-namespace"
+	// MiddlewarePluginPositionAfterAuth attaches plugin right after auth middlewares,
+	// so the plugin handles only the requests, which have been let through by them.
+	MiddlewarePluginPositionAfterAuth = "after-auth"
+)
+
+// DefaultNamespaceHeader is a default name of header to resolve namespace from.
+const DefaultNamespaceHeader = "X-Namespace"
+
+// DefaultMaxPageSize is a default maximum number of items returned by list and search endpoints per page.
+const DefaultMaxPageSize = 1000
+
+// DefaultDebugBodyLogMaxSize is a default maximum size, in bytes, of each logged request and response body.
+const DefaultDebugBodyLogMaxSize = 1024
+
+// DefaultMaxDecompressedRequestSize is a default maximum size, in bytes, of decompressed request body.
+const DefaultMaxDecompressedRequestSize = 64 * 1024 * 1024
+
+// DefaultNamespaceIngestionRateWindow is a default length of the sliding window, which namespace
+// metrics ingestion rate is measured over.
+const DefaultNamespaceIngestionRateWindow = 10 * time.Second
+
+// DefaultArtifactUploadSessionTTL is a default time after which abandoned chunked artifact upload is removed.
+const DefaultArtifactUploadSessionTTL = time.Hour
+
+// DefaultMaxExperimentNameLength is a default, and the largest supported, maximum length of experiment name.
+// It matches the size of the `experiments.name` column.
+const DefaultMaxExperimentNameLength = 256
+
+// DefaultMaxRunNameLength is a default, and the largest supported, maximum length of run name.
+// It matches the size of the `runs.name` column.
+const DefaultMaxRunNameLength = 250
+
+// DefaultSystemTagPrefixes is a default list of prefixes of the tags, which are treated as system tags.
+var DefaultSystemTagPrefixes = []string{"mlflow."}
+
+// validation rule for HTTP header name.
+var validHeaderName = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
 // Config represents main service configuration.
 type Config struct {
-	Auth                  auth.Config
-	DevMode               bool
-	AimRevert             bool
-	ListenAddress         string
-	DefaultArtifactRoot   string
-	S3EndpointURI         string
-	GSEndpointURI         string
-	DatabaseURI           string
-	DatabaseReset         bool
-	DatabasePoolMax       int
-	DatabaseMigrate       bool
-	DatabaseSlowThreshold time.Duration
-	LiveUpdatesEnabled    bool
+	Auth                          auth.Config
+	DevMode                       bool
+	AimRevert                     bool
+	ListenAddress                 string
+	DefaultArtifactRoot           string
+	S3EndpointURI                 string
+	S3Region                      string
+	S3ForcePathStyle              bool
+	S3DisableSSL                  bool
+	GSEndpointURI                 string
+	GSCredentialsFile             string
+	DatabaseURI                   string
+	DatabaseReset                 bool
+	DatabasePoolMax               int
+	DatabasePoolMaxIdle           int
+	DatabaseConnMaxLifetime       time.Duration
+	DatabaseConnMaxIdleTime       time.Duration
+	DatabaseMigrate               bool
+	DatabaseSlowThreshold         time.Duration
+	DatabaseReadStatementTimeout  time.Duration
+	DatabaseWriteStatementTimeout time.Duration
+	LiveUpdatesEnabled            bool
+	RetentionInterval             time.Duration
+	ActivityInterval              time.Duration
+	NamespaceHeader               string
+	NamespaceBaseDomain           string
+	NamespaceResolutionOrder      []string
+	RunMaxParamKeys               int
+	RunMaxMetricKeys              int
+	RunMaxTagKeys                 int
+	NamespaceMaxParamKeys         int
+	NamespaceMaxMetricKeys        int
+	NamespaceMaxTagKeys           int
+	MetricTimestampMaxSkew        time.Duration
+	MetricTimestampSkewPolicy     string
+	MaxPageSize                   int
+	NamespaceFeatureFlags         []string
+	ProjectCacheTTL               time.Duration
+	MaintenanceMode               bool
+	RequestTimeout                time.Duration
+	ArtifactRequestTimeout        time.Duration
+	ArtifactUploadSessionTTL      time.Duration
+	SystemTagPolicy               string
+	SystemTagPrefixes             []string
+	RunSearchDefaultOrderBy       string
+	NamespaceRunSearchOrderBy     []string
+	MiddlewarePlugins             []string
+	IPAllowlist                   []string
+	IPDenylist                    []string
+	TrustedProxies                []string
+	ResponseHeaders               []string
+	MetricValuePrecision          int
+	ArtifactRootFallbacks         []string
+	ArtifactAllowedSchemes        []string
+	NamespaceArtifactSchemes      []string
+	DebugBodyLogPrefixes          []string
+	DebugBodyLogMaxSize           int
+	MaxDecompressedRequestSize    int
+	NamespaceIngestionRateLimit   int
+	NamespaceIngestionRateWindow  time.Duration
+	IngestionThrottlePolicy       string
+	TerminatedRunLogPolicy        string
+	MaxExperimentNameLength       int
+	MaxRunNameLength              int
+	LogBatchMaxItems              int
+	ChooserDefaultNamespace       string
+	ChooserUserDefaultNamespaces  []string
+	StrictRunStatusTransitions    bool
+	ArtifactMaxFileSize           int64
 }
 
 // NewConfig creates new instance of Config.
@@ -43,23 +188,460 @@ func NewConfig() *Config {
 			AuthOIDCClaimRoles:       viper.GetString("auth-oidc-claim-roles"),
 			AuthOIDCClientSecret:     viper.GetString("auth-oidc-client-secret"),
 			AuthOIDCProviderEndpoint: viper.GetString("auth-oidc-provider-endpoint"),
+			AuthOIDCRefreshInterval:  viper.GetDuration("auth-oidc-refresh-interval"),
+			AuthOIDCProvidersConfig:  viper.GetString("auth-oidc-providers-config"),
+			AuthCookieName:           viper.GetString("auth-cookie-name"),
+			AuthCookieDomain:         viper.GetString("auth-cookie-domain"),
+			AuthCookiePath:           viper.GetString("auth-cookie-path"),
+			AuthCookieSameSite:       viper.GetString("auth-cookie-same-site"),
+			AuthCookieSecure:         viper.GetBool("auth-cookie-secure"),
+			AuthCookieMaxAge:         viper.GetDuration("auth-cookie-max-age"),
 		},
-		DevMode:               viper.GetBool("dev-mode"),
-		AimRevert:             viper.GetBool("run-original-aim-service"),
-		ListenAddress:         viper.GetString("listen-address"),
-		DefaultArtifactRoot:   viper.GetString("default-artifact-root"),
-		S3EndpointURI:         viper.GetString("s3-endpoint-uri"),
-		GSEndpointURI:         viper.GetString("gs-endpoint-uri"),
-		DatabaseURI:           viper.GetString("database-uri"),
-		DatabaseReset:         viper.GetBool("database-reset"),
-		DatabasePoolMax:       viper.GetInt("database-pool-max"),
-		DatabaseMigrate:       viper.GetBool("database-migrate"),
-		DatabaseSlowThreshold: viper.GetDuration("database-slow-threshold"),
-		LiveUpdatesEnabled:    viper.GetBool("live-updates-enabled"),
-	}
-}
-
-// Validate validates service configuration.
+		DevMode:                       viper.GetBool("dev-mode"),
+		AimRevert:                     viper.GetBool("run-original-aim-service"),
+		ListenAddress:                 viper.GetString("listen-address"),
+		DefaultArtifactRoot:           viper.GetString("default-artifact-root"),
+		S3EndpointURI:                 viper.GetString("s3-endpoint-uri"),
+		S3Region:                      viper.GetString("s3-region"),
+		S3ForcePathStyle:              viper.GetBool("s3-force-path-style"),
+		S3DisableSSL:                  viper.GetBool("s3-disable-ssl"),
+		GSEndpointURI:                 viper.GetString("gs-endpoint-uri"),
+		GSCredentialsFile:             viper.GetString("gs-credentials-file"),
+		DatabaseURI:                   viper.GetString("database-uri"),
+		DatabaseReset:                 viper.GetBool("database-reset"),
+		DatabasePoolMax:               viper.GetInt("database-pool-max"),
+		DatabasePoolMaxIdle:           viper.GetInt("database-pool-max-idle"),
+		DatabaseConnMaxLifetime:       viper.GetDuration("database-conn-max-lifetime"),
+		DatabaseConnMaxIdleTime:       viper.GetDuration("database-conn-max-idle-time"),
+		DatabaseMigrate:               viper.GetBool("database-migrate"),
+		DatabaseSlowThreshold:         viper.GetDuration("database-slow-threshold"),
+		DatabaseReadStatementTimeout:  viper.GetDuration("database-read-statement-timeout"),
+		DatabaseWriteStatementTimeout: viper.GetDuration("database-write-statement-timeout"),
+		LiveUpdatesEnabled:            viper.GetBool("live-updates-enabled"),
+		RetentionInterval:             viper.GetDuration("retention-interval"),
+		ActivityInterval:              viper.GetDuration("activity-interval"),
+		NamespaceHeader:               viper.GetString("namespace-header"),
+		NamespaceBaseDomain:           viper.GetString("namespace-base-domain"),
+		NamespaceResolutionOrder:      viper.GetStringSlice("namespace-resolution-order"),
+		RunMaxParamKeys:               viper.GetInt("run-max-param-keys"),
+		RunMaxMetricKeys:              viper.GetInt("run-max-metric-keys"),
+		RunMaxTagKeys:                 viper.GetInt("run-max-tag-keys"),
+		NamespaceMaxParamKeys:         viper.GetInt("namespace-max-param-keys"),
+		NamespaceMaxMetricKeys:        viper.GetInt("namespace-max-metric-keys"),
+		NamespaceMaxTagKeys:           viper.GetInt("namespace-max-tag-keys"),
+		MetricTimestampMaxSkew:        viper.GetDuration("metric-timestamp-max-skew"),
+		MetricTimestampSkewPolicy:     viper.GetString("metric-timestamp-skew-policy"),
+		MaxPageSize:                   viper.GetInt("max-page-size"),
+		NamespaceFeatureFlags:         viper.GetStringSlice("namespace-feature-flags"),
+		ProjectCacheTTL:               viper.GetDuration("project-cache-ttl"),
+		MaintenanceMode:               viper.GetBool("maintenance-mode"),
+		RequestTimeout:                viper.GetDuration("request-timeout"),
+		ArtifactRequestTimeout:        viper.GetDuration("artifact-request-timeout"),
+		ArtifactUploadSessionTTL:      viper.GetDuration("artifact-upload-session-ttl"),
+		SystemTagPolicy:               viper.GetString("system-tag-policy"),
+		SystemTagPrefixes:             viper.GetStringSlice("system-tag-prefixes"),
+		RunSearchDefaultOrderBy:       viper.GetString("run-search-default-order-by"),
+		NamespaceRunSearchOrderBy:     viper.GetStringSlice("namespace-run-search-default-order-by"),
+		MiddlewarePlugins:             viper.GetStringSlice("middleware-plugins"),
+		IPAllowlist:                   viper.GetStringSlice("ip-allowlist"),
+		IPDenylist:                    viper.GetStringSlice("ip-denylist"),
+		TrustedProxies:                viper.GetStringSlice("trusted-proxies"),
+		ResponseHeaders:               viper.GetStringSlice("response-headers"),
+		MetricValuePrecision:          viper.GetInt("metric-value-precision"),
+		ArtifactRootFallbacks:         viper.GetStringSlice("artifact-root-fallbacks"),
+		ArtifactAllowedSchemes:        viper.GetStringSlice("artifact-allowed-schemes"),
+		NamespaceArtifactSchemes:      viper.GetStringSlice("namespace-artifact-allowed-schemes"),
+		DebugBodyLogPrefixes:          viper.GetStringSlice("debug-body-log-prefixes"),
+		DebugBodyLogMaxSize:           viper.GetInt("debug-body-log-max-size"),
+		MaxDecompressedRequestSize:    viper.GetInt("max-decompressed-request-size"),
+		NamespaceIngestionRateLimit:   viper.GetInt("namespace-ingestion-rate-limit"),
+		NamespaceIngestionRateWindow:  viper.GetDuration("namespace-ingestion-rate-window"),
+		IngestionThrottlePolicy:       viper.GetString("namespace-ingestion-throttle-policy"),
+		TerminatedRunLogPolicy:        viper.GetString("terminated-run-log-policy"),
+		MaxExperimentNameLength:       viper.GetInt("max-experiment-name-length"),
+		MaxRunNameLength:              viper.GetInt("max-run-name-length"),
+		LogBatchMaxItems:              viper.GetInt("log-batch-max-items"),
+		ChooserDefaultNamespace:       viper.GetString("chooser-default-namespace"),
+		ChooserUserDefaultNamespaces:  viper.GetStringSlice("chooser-user-default-namespaces"),
+		StrictRunStatusTransitions:    viper.GetBool("strict-run-status-transitions"),
+		ArtifactMaxFileSize:           viper.GetInt64("artifact-max-file-size"),
+	}
+}
+
+// GetDatabasePoolConfig returns configured settings of the database connection pool.
+// Settings, which are not configured, fall back to the defaults of database.NewPoolConfig.
+func (c *Config) GetDatabasePoolConfig() database.PoolConfig {
+	pool := database.NewPoolConfig(c.DatabasePoolMax)
+	if c.DatabasePoolMaxIdle > 0 {
+		pool.MaxIdleConns = c.DatabasePoolMaxIdle
+	}
+	if c.DatabaseConnMaxLifetime > 0 {
+		pool.ConnMaxLifetime = c.DatabaseConnMaxLifetime
+	}
+	if c.DatabaseConnMaxIdleTime > 0 {
+		pool.ConnMaxIdleTime = c.DatabaseConnMaxIdleTime
+	}
+	return pool
+}
+
+// GetDatabaseStatementTimeouts returns configured timeouts of the database statements.
+func (c *Config) GetDatabaseStatementTimeouts() database.StatementTimeouts {
+	return database.StatementTimeouts{
+		Read:  c.DatabaseReadStatementTimeout,
+		Write: c.DatabaseWriteStatementTimeout,
+	}
+}
+
+// GetNamespaceHeader returns configured name of header to resolve namespace from or the default one.
+func (c *Config) GetNamespaceHeader() string {
+	if c.NamespaceHeader == "" {
+		return DefaultNamespaceHeader
+	}
+	return c.NamespaceHeader
+}
+
+// GetNamespaceResolutionOrder returns configured order of namespace resolvers or the default one.
+func (c *Config) GetNamespaceResolutionOrder() []string {
+	if len(c.NamespaceResolutionOrder) == 0 {
+		return []string{NamespaceResolverPath, NamespaceResolverSubdomain}
+	}
+	return c.NamespaceResolutionOrder
+}
+
+// GetMetricTimestampSkewPolicy returns configured policy for skewed metric timestamps or the default one.
+func (c *Config) GetMetricTimestampSkewPolicy() string {
+	if c.MetricTimestampSkewPolicy == "" {
+		return MetricTimestampSkewPolicyReject
+	}
+	return c.MetricTimestampSkewPolicy
+}
+
+// GetSystemTagPolicy returns configured policy for user modifications of system tags or the default one.
+func (c *Config) GetSystemTagPolicy() string {
+	if c.SystemTagPolicy == "" {
+		return SystemTagPolicyAllow
+	}
+	return c.SystemTagPolicy
+}
+
+// IsSystemTag returns whether the tag key starts with one of configured system tag prefixes or the default ones.
+func (c *Config) IsSystemTag(key string) bool {
+	prefixes := c.SystemTagPrefixes
+	if len(prefixes) == 0 {
+		prefixes = DefaultSystemTagPrefixes
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMaxPageSize returns configured maximum page size or the default one.
+func (c *Config) GetMaxPageSize() int {
+	if c.MaxPageSize == 0 {
+		return DefaultMaxPageSize
+	}
+	return c.MaxPageSize
+}
+
+// GetMaxDecompressedRequestSize returns configured maximum size of decompressed request body or the default one.
+func (c *Config) GetMaxDecompressedRequestSize() int {
+	if c.MaxDecompressedRequestSize == 0 {
+		return DefaultMaxDecompressedRequestSize
+	}
+	return c.MaxDecompressedRequestSize
+}
+
+// GetNamespaceIngestionRateWindow returns configured length of the namespace ingestion rate window
+// or the default one.
+func (c *Config) GetNamespaceIngestionRateWindow() time.Duration {
+	if c.NamespaceIngestionRateWindow == 0 {
+		return DefaultNamespaceIngestionRateWindow
+	}
+	return c.NamespaceIngestionRateWindow
+}
+
+// GetArtifactUploadSessionTTL returns configured time after which abandoned chunked artifact upload
+// is removed or the default one.
+func (c *Config) GetArtifactUploadSessionTTL() time.Duration {
+	if c.ArtifactUploadSessionTTL == 0 {
+		return DefaultArtifactUploadSessionTTL
+	}
+	return c.ArtifactUploadSessionTTL
+}
+
+// GetIngestionThrottlePolicy returns configured policy for namespaces exceeding the ingestion
+// rate limit or the default one.
+func (c *Config) GetIngestionThrottlePolicy() string {
+	if c.IngestionThrottlePolicy == "" {
+		return IngestionThrottlePolicyReject
+	}
+	return c.IngestionThrottlePolicy
+}
+
+// GetMaxExperimentNameLength returns configured maximum length of experiment name or the default one.
+func (c *Config) GetMaxExperimentNameLength() int {
+	if c.MaxExperimentNameLength == 0 {
+		return DefaultMaxExperimentNameLength
+	}
+	return c.MaxExperimentNameLength
+}
+
+// GetMaxRunNameLength returns configured maximum length of run name or the default one.
+func (c *Config) GetMaxRunNameLength() int {
+	if c.MaxRunNameLength == 0 {
+		return DefaultMaxRunNameLength
+	}
+	return c.MaxRunNameLength
+}
+
+// GetTerminatedRunLogPolicy returns configured policy for logging of metrics and params to runs
+// in a terminal status or the default one.
+func (c *Config) GetTerminatedRunLogPolicy() string {
+	if c.TerminatedRunLogPolicy == "" {
+		return TerminatedRunLogPolicyAllow
+	}
+	return c.TerminatedRunLogPolicy
+}
+
+// GetPageSize returns effective page size for requested one. Page size which is not set
+// or exceeds the maximum page size is clamped to the maximum page size.
+func (c *Config) GetPageSize(requested int) int {
+	if requested <= 0 || requested > c.GetMaxPageSize() {
+		return c.GetMaxPageSize()
+	}
+	return requested
+}
+
+// IsFeatureEnabled returns whether the feature is enabled for the namespace. Feature which is not
+// configured for the namespace falls back to the global setting.
+func (c *Config) IsFeatureEnabled(namespaceCode, feature string) bool {
+	enabled := c.isFeatureEnabledGlobally(feature)
+	for _, flag := range c.NamespaceFeatureFlags {
+		code, name, value, err := parseNamespaceFeatureFlag(flag)
+		if err == nil && code == namespaceCode && name == feature {
+			enabled = value
+		}
+	}
+	return enabled
+}
+
+// isFeatureEnabledGlobally returns global setting of the feature.
+func (c *Config) isFeatureEnabledGlobally(feature string) bool {
+	switch feature {
+	case FeatureLiveUpdates:
+		return c.LiveUpdatesEnabled
+	default:
+		return false
+	}
+}
+
+// parseNamespaceFeatureFlag parses feature flag in format `namespace:feature=true|false`.
+func parseNamespaceFeatureFlag(flag string) (string, string, bool, error) {
+	code, rest, ok := strings.Cut(flag, ":")
+	if !ok || code == "" {
+		return "", "", false, eris.Errorf("incorrect format of feature flag '%s'", flag)
+	}
+	feature, value, ok := strings.Cut(rest, "=")
+	if !ok {
+		return "", "", false, eris.Errorf("incorrect format of feature flag '%s'", flag)
+	}
+	if !slices.Contains([]string{FeatureLiveUpdates}, feature) {
+		return "", "", false, eris.Errorf("unsupported feature '%s' in feature flag '%s'", feature, flag)
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", "", false, eris.Errorf("incorrect value of feature flag '%s'", flag)
+	}
+	return code, feature, enabled, nil
+}
+
+// GetRunSearchDefaultOrderBy returns order clause used by run search of the namespace, when the request
+// doesn't specify any order. Order which is not configured for the namespace falls back to the global one.
+// Empty clause means that runs are sorted by start time in descending order.
+func (c *Config) GetRunSearchDefaultOrderBy(namespaceCode string) string {
+	orderBy := c.RunSearchDefaultOrderBy
+	for _, item := range c.NamespaceRunSearchOrderBy {
+		code, clause, err := parseNamespaceRunSearchOrderBy(item)
+		if err == nil && code == namespaceCode {
+			orderBy = clause
+		}
+	}
+	return orderBy
+}
+
+// GetRunSearchDefaultOrderByClauses returns all the configured default order clauses of run search.
+func (c *Config) GetRunSearchDefaultOrderByClauses() []string {
+	var clauses []string
+	if c.RunSearchDefaultOrderBy != "" {
+		clauses = append(clauses, c.RunSearchDefaultOrderBy)
+	}
+	for _, item := range c.NamespaceRunSearchOrderBy {
+		if _, clause, err := parseNamespaceRunSearchOrderBy(item); err == nil {
+			clauses = append(clauses, clause)
+		}
+	}
+	return clauses
+}
+
+// parseNamespaceRunSearchOrderBy parses default order of run search in format `namespace:clause`.
+func parseNamespaceRunSearchOrderBy(item string) (string, string, error) {
+	code, clause, ok := strings.Cut(item, ":")
+	if !ok || code == "" || strings.TrimSpace(clause) == "" {
+		return "", "", eris.Errorf("incorrect format of default order '%s'", item)
+	}
+	return code, strings.TrimSpace(clause), nil
+}
+
+// GetChooserDefaultNamespace returns code of the namespace, which the user lands on in the chooser UI.
+// Per-user default namespace takes precedence over the global one.
+func (c *Config) GetChooserDefaultNamespace(userName string) string {
+	namespaceCode := c.ChooserDefaultNamespace
+	for _, item := range c.ChooserUserDefaultNamespaces {
+		name, code, err := parseChooserUserDefaultNamespace(item)
+		if err == nil && name == userName {
+			namespaceCode = code
+		}
+	}
+	return namespaceCode
+}
+
+// parseChooserUserDefaultNamespace parses per-user default namespace of the chooser UI in format `user:namespace`.
+func parseChooserUserDefaultNamespace(item string) (string, string, error) {
+	name, code, ok := strings.Cut(item, ":")
+	if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(code) == "" {
+		return "", "", eris.Errorf("incorrect format of user default namespace '%s'", item)
+	}
+	return strings.TrimSpace(name), strings.TrimSpace(code), nil
+}
+
+// GetMiddlewarePlugins returns names of the middleware plugins enabled at the position of the middleware chain,
+// in the order they have to be attached.
+func (c *Config) GetMiddlewarePlugins(position string) []string {
+	var names []string
+	for _, item := range c.MiddlewarePlugins {
+		if pluginPosition, name, err := parseMiddlewarePlugin(item); err == nil && pluginPosition == position {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseMiddlewarePlugin parses middleware plugin in format `position:name`.
+func parseMiddlewarePlugin(item string) (string, string, error) {
+	position, name, ok := strings.Cut(item, ":")
+	if !ok || name == "" {
+		return "", "", eris.Errorf("incorrect format of middleware plugin '%s'", item)
+	}
+	if !slices.Contains(
+		[]string{MiddlewarePluginPositionBeforeNamespace, MiddlewarePluginPositionAfterAuth}, position,
+	) {
+		return "", "", eris.Errorf("unsupported position '%s' of middleware plugin '%s'", position, item)
+	}
+	return position, name, nil
+}
+
+// IsIPFilterEnabled returns whether requests are filtered by the client address.
+func (c *Config) IsIPFilterEnabled() bool {
+	return len(c.IPAllowlist) > 0 || len(c.IPDenylist) > 0
+}
+
+// IsDebugBodyLogEnabled returns whether request and response bodies are logged for debugging.
+func (c *Config) IsDebugBodyLogEnabled() bool {
+	return len(c.DebugBodyLogPrefixes) > 0
+}
+
+// GetResponseHeaders returns configured static headers added to every response.
+func (c *Config) GetResponseHeaders() map[string]string {
+	headers := make(map[string]string, len(c.ResponseHeaders))
+	for _, item := range c.ResponseHeaders {
+		if name, value, err := parseResponseHeader(item); err == nil {
+			headers[name] = value
+		}
+	}
+	return headers
+}
+
+// GetArtifactRoots returns the run artifact root followed by its fallback roots, in order in which
+// artifacts should be looked up. Fallback root replaces the configured root at the beginning of artifactURI.
+func (c *Config) GetArtifactRoots(artifactURI string) []string {
+	roots := []string{artifactURI}
+	for _, item := range c.ArtifactRootFallbacks {
+		root, fallback, err := parseArtifactRootFallback(item)
+		if err != nil {
+			continue
+		}
+		if artifactURI == root {
+			roots = append(roots, fallback)
+		} else if rest, ok := strings.CutPrefix(artifactURI, strings.TrimSuffix(root, "/")+"/"); ok {
+			roots = append(roots, strings.TrimSuffix(fallback, "/")+"/"+rest)
+		}
+	}
+	return roots
+}
+
+// IsArtifactSchemeAllowed checks that custom artifact location with the scheme could be used by the namespace.
+// Schemes, which are not configured for the namespace, fall back to the global ones. No schemes allow any.
+// Empty scheme of the local path is the same as `file` one.
+func (c *Config) IsArtifactSchemeAllowed(namespaceCode, scheme string) bool {
+	schemes := c.ArtifactAllowedSchemes
+	var namespaceSchemes []string
+	for _, item := range c.NamespaceArtifactSchemes {
+		code, namespaceScheme, err := parseNamespaceArtifactScheme(item)
+		if err == nil && code == namespaceCode {
+			namespaceSchemes = append(namespaceSchemes, namespaceScheme)
+		}
+	}
+	if len(namespaceSchemes) > 0 {
+		schemes = namespaceSchemes
+	}
+	if len(schemes) == 0 {
+		return true
+	}
+	if scheme == "" {
+		scheme = "file"
+	}
+	return slices.ContainsFunc(schemes, func(allowed string) bool {
+		return strings.EqualFold(allowed, scheme)
+	})
+}
+
+// parseNamespaceArtifactScheme parses allowed artifact scheme of the namespace in format `namespace:scheme`.
+func parseNamespaceArtifactScheme(item string) (string, string, error) {
+	code, scheme, ok := strings.Cut(item, ":")
+	if !ok || strings.TrimSpace(code) == "" || strings.TrimSpace(scheme) == "" {
+		return "", "", eris.Errorf("incorrect format of namespace artifact scheme '%s'", item)
+	}
+	return strings.TrimSpace(code), strings.TrimSpace(scheme), nil
+}
+
+// parseArtifactRootFallback parses artifact root fallback in format `root=fallback`.
+func parseArtifactRootFallback(item string) (string, string, error) {
+	root, fallback, ok := strings.Cut(item, "=")
+	if !ok || root == "" || fallback == "" {
+		return "", "", eris.Errorf("incorrect format of artifact root fallback '%s'", item)
+	}
+	for _, uri := range []string{root, fallback} {
+		if _, err := url.Parse(uri); err != nil {
+			return "", "", eris.Wrapf(err, "incorrect artifact root '%s'", uri)
+		}
+	}
+	return root, fallback, nil
+}
+
+// parseResponseHeader parses static response header in format `Name:value`.
+func parseResponseHeader(item string) (string, string, error) {
+	name, value, ok := strings.Cut(item, ":")
+	if !ok || !validHeaderName.MatchString(name) {
+		return "", "", eris.Errorf("incorrect format of response header '%s'", item)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// Validate validates service configuration. All the validation problems are reported at once,
+// joined into the returned error.
 func (c *Config) Validate() error {
 	if err := c.validateConfiguration(); err != nil {
 		return eris.Wrap(err, "error validating service configuration")
@@ -71,26 +653,345 @@ func (c *Config) Validate() error {
 }
 
 // validateConfiguration validates service configuration for correctness.
+// It doesn't stop on the first problem, but returns all of them joined into one error.
 func (c *Config) validateConfiguration() error {
+	var errs []error
+
 	// 1. validate DefaultArtifactRoot configuration parameter for correctness and valid values.
-	parsed, err := url.Parse(c.DefaultArtifactRoot)
-	if err != nil {
-		return eris.Wrap(err, "error parsing 'default-artifact-root' flag")
+	if parsed, err := url.Parse(c.DefaultArtifactRoot); err != nil {
+		errs = append(errs, eris.Wrap(err, "error parsing 'default-artifact-root' flag"))
+	} else {
+		if parsed.User != nil || parsed.RawQuery != "" || parsed.RawFragment != "" {
+			errs = append(errs, eris.New("incorrect format of 'default-artifact-root' flag"))
+		}
+
+		if !slices.Contains([]string{"", "file", "s3", "gs"}, parsed.Scheme) {
+			errs = append(errs, eris.New("unsupported schema of 'default-artifact-root' flag"))
+		}
+
+		// object storage uri should be in format `scheme://bucket/prefix`.
+		if slices.Contains([]string{"s3", "gs"}, parsed.Scheme) && (parsed.Host == "" || parsed.Port() != "") {
+			errs = append(errs, eris.New("incorrect bucket name in 'default-artifact-root' flag"))
+		}
+	}
+
+	// placeholders are expanded at experiment creation time, so only supported ones are allowed.
+	if err := common.ValidateArtifactRootPlaceholders(c.DefaultArtifactRoot); err != nil {
+		errs = append(errs, eris.Wrap(err, "incorrect format of 'default-artifact-root' flag"))
+	}
+
+	// 2. validate GSCredentialsFile configuration parameter.
+	if c.GSCredentialsFile != "" {
+		if _, err := os.Stat(c.GSCredentialsFile); err != nil {
+			errs = append(errs, eris.Wrap(err, "error reading 'gs-credentials-file' flag"))
+		}
+	}
+
+	// 3. validate S3EndpointURI configuration parameter.
+	if c.S3EndpointURI != "" {
+		parsed, err := url.Parse(c.S3EndpointURI)
+		switch {
+		case err != nil:
+			errs = append(errs, eris.Wrap(err, "error parsing 's3-endpoint-uri' flag"))
+		case !slices.Contains([]string{"http", "https"}, parsed.Scheme) ||
+			parsed.Host == "" || parsed.User != nil || parsed.RawQuery != "" || parsed.RawFragment != "":
+			errs = append(errs, eris.New("incorrect format of 's3-endpoint-uri' flag"))
+		}
+	}
+
+	// 4. validate RetentionInterval configuration parameter.
+	if c.RetentionInterval < 0 {
+		errs = append(errs, eris.New("'retention-interval' flag should not be negative"))
+	}
+
+	// 5. validate ActivityInterval configuration parameter.
+	if c.ActivityInterval < 0 {
+		errs = append(errs, eris.New("'activity-interval' flag should not be negative"))
+	}
+
+	// 6. validate NamespaceBaseDomain configuration parameter.
+	if c.NamespaceBaseDomain != "" {
+		if strings.ContainsAny(c.NamespaceBaseDomain, ":/") || net.ParseIP(c.NamespaceBaseDomain) != nil {
+			errs = append(errs, eris.New("incorrect format of 'namespace-base-domain' flag"))
+		}
+	}
+
+	// 7. validate NamespaceHeader configuration parameter.
+	if c.NamespaceHeader != "" && !validHeaderName.MatchString(c.NamespaceHeader) {
+		errs = append(errs, eris.New("incorrect format of 'namespace-header' flag"))
+	}
+
+	// 8. validate NamespaceResolutionOrder configuration parameter.
+	for i, resolver := range c.NamespaceResolutionOrder {
+		switch {
+		case !slices.Contains(
+			[]string{NamespaceResolverPath, NamespaceResolverHeader, NamespaceResolverSubdomain}, resolver,
+		):
+			errs = append(errs, eris.Errorf(
+				"unsupported namespace resolver '%s' in 'namespace-resolution-order' flag", resolver,
+			))
+		case slices.Contains(c.NamespaceResolutionOrder[:i], resolver):
+			errs = append(errs, eris.Errorf(
+				"duplicated namespace resolver '%s' in 'namespace-resolution-order' flag", resolver,
+			))
+		}
+	}
+
+	// 9. validate key cardinality limits configuration parameters.
+	for _, limit := range []struct {
+		flag  string
+		value int
+	}{
+		{"run-max-param-keys", c.RunMaxParamKeys},
+		{"run-max-metric-keys", c.RunMaxMetricKeys},
+		{"run-max-tag-keys", c.RunMaxTagKeys},
+		{"namespace-max-param-keys", c.NamespaceMaxParamKeys},
+		{"namespace-max-metric-keys", c.NamespaceMaxMetricKeys},
+		{"namespace-max-tag-keys", c.NamespaceMaxTagKeys},
+	} {
+		if limit.value < 0 {
+			errs = append(errs, eris.Errorf("'%s' flag should not be negative", limit.flag))
+		}
+	}
+
+	// 10. validate MetricTimestampMaxSkew and MetricTimestampSkewPolicy configuration parameters.
+	if c.MetricTimestampMaxSkew < 0 {
+		errs = append(errs, eris.New("'metric-timestamp-max-skew' flag should not be negative"))
+	}
+	if !slices.Contains(
+		[]string{"", MetricTimestampSkewPolicyReject, MetricTimestampSkewPolicyClamp}, c.MetricTimestampSkewPolicy,
+	) {
+		errs = append(errs, eris.Errorf(
+			"unsupported policy '%s' in 'metric-timestamp-skew-policy' flag", c.MetricTimestampSkewPolicy,
+		))
+	}
+
+	// 11. validate MaxPageSize configuration parameter.
+	if c.MaxPageSize < 0 {
+		errs = append(errs, eris.New("'max-page-size' flag should not be negative"))
+	}
+
+	// 12. validate NamespaceFeatureFlags configuration parameter.
+	for _, flag := range c.NamespaceFeatureFlags {
+		if _, _, _, err := parseNamespaceFeatureFlag(flag); err != nil {
+			errs = append(errs, eris.Wrap(err, "error parsing 'namespace-feature-flags' flag"))
+		}
 	}
 
-	if parsed.User != nil || parsed.RawQuery != "" || parsed.RawFragment != "" {
-		return eris.New("incorrect format of 'default-artifact-root' flag")
+	// 13. validate ProjectCacheTTL configuration parameter.
+	if c.ProjectCacheTTL < 0 {
+		errs = append(errs, eris.New("'project-cache-ttl' flag should not be negative"))
 	}
 
-	if !slices.Contains([]string{"", "file", "s3", "gs"}, parsed.Scheme) {
-		return eris.New("unsupported schema of 'default-artifact-root' flag")
+	// 14. validate RequestTimeout and ArtifactRequestTimeout configuration parameters.
+	if c.RequestTimeout < 0 {
+		errs = append(errs, eris.New("'request-timeout' flag should not be negative"))
+	}
+	if c.ArtifactRequestTimeout < 0 {
+		errs = append(errs, eris.New("'artifact-request-timeout' flag should not be negative"))
+	}
+
+	// 15. validate ArtifactUploadSessionTTL configuration parameter.
+	if c.ArtifactUploadSessionTTL < 0 {
+		errs = append(errs, eris.New("'artifact-upload-session-ttl' flag should not be negative"))
+	}
+
+	// 16. validate SystemTagPolicy and SystemTagPrefixes configuration parameters.
+	if !slices.Contains(
+		[]string{"", SystemTagPolicyAllow, SystemTagPolicyReject, SystemTagPolicyIgnore}, c.SystemTagPolicy,
+	) {
+		errs = append(errs, eris.Errorf("unsupported policy '%s' in 'system-tag-policy' flag", c.SystemTagPolicy))
+	}
+	if slices.Contains(c.SystemTagPrefixes, "") {
+		errs = append(errs, eris.New("'system-tag-prefixes' flag should not contain empty prefix"))
+	}
+
+	// 17. validate DatabaseReadStatementTimeout and DatabaseWriteStatementTimeout configuration parameters.
+	if c.DatabaseReadStatementTimeout < 0 {
+		errs = append(errs, eris.New("'database-read-statement-timeout' flag should not be negative"))
+	}
+	if c.DatabaseWriteStatementTimeout < 0 {
+		errs = append(errs, eris.New("'database-write-statement-timeout' flag should not be negative"))
+	}
+
+	// 18. validate NamespaceRunSearchOrderBy configuration parameter.
+	// order clauses themselves are validated by run search, which knows supported sort fields.
+	for _, item := range c.NamespaceRunSearchOrderBy {
+		if _, _, err := parseNamespaceRunSearchOrderBy(item); err != nil {
+			errs = append(errs, eris.Wrap(err, "error parsing 'namespace-run-search-default-order-by' flag"))
+		}
+	}
+
+	// 19. validate MiddlewarePlugins configuration parameter.
+	// names of plugins are validated on server start, when all the compiled in plugins are registered.
+	for i, item := range c.MiddlewarePlugins {
+		_, name, err := parseMiddlewarePlugin(item)
+		if err != nil {
+			errs = append(errs, eris.Wrap(err, "error parsing 'middleware-plugins' flag"))
+			continue
+		}
+		for _, previous := range c.MiddlewarePlugins[:i] {
+			if _, previousName, _ := parseMiddlewarePlugin(previous); previousName == name {
+				errs = append(errs, eris.Errorf("duplicated middleware plugin '%s' in 'middleware-plugins' flag", name))
+				break
+			}
+		}
+	}
+
+	// 20. validate IPAllowlist, IPDenylist and TrustedProxies configuration parameters.
+	for _, list := range []struct {
+		flag  string
+		items []string
+	}{
+		{"ip-allowlist", c.IPAllowlist},
+		{"ip-denylist", c.IPDenylist},
+		{"trusted-proxies", c.TrustedProxies},
+	} {
+		for _, item := range list.items {
+			if _, _, err := net.ParseCIDR(item); err != nil && net.ParseIP(item) == nil {
+				errs = append(errs, eris.Errorf("incorrect IP address or CIDR '%s' in '%s' flag", item, list.flag))
+			}
+		}
+	}
+
+	// 21. validate ResponseHeaders configuration parameter.
+	for _, item := range c.ResponseHeaders {
+		if _, _, err := parseResponseHeader(item); err != nil {
+			errs = append(errs, eris.Wrap(err, "error parsing 'response-headers' flag"))
+		}
+	}
+
+	// 22. validate MetricValuePrecision configuration parameter.
+	if c.MetricValuePrecision < 0 || c.MetricValuePrecision > api.MaxMetricValuePrecision {
+		errs = append(errs, eris.Errorf(
+			"'metric-value-precision' flag should be between 0 and %d", api.MaxMetricValuePrecision,
+		))
+	}
+
+	// 23. validate ArtifactRootFallbacks configuration parameter.
+	for _, item := range c.ArtifactRootFallbacks {
+		if _, _, err := parseArtifactRootFallback(item); err != nil {
+			errs = append(errs, eris.Wrap(err, "error parsing 'artifact-root-fallbacks' flag"))
+		}
+	}
+
+	// 24. validate DebugBodyLogPrefixes and DebugBodyLogMaxSize configuration parameters.
+	for _, prefix := range c.DebugBodyLogPrefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			errs = append(errs, eris.Errorf(
+				"route prefix '%s' in 'debug-body-log-prefixes' flag should start with '/'", prefix,
+			))
+		}
+	}
+	if c.IsDebugBodyLogEnabled() && c.DebugBodyLogMaxSize <= 0 {
+		errs = append(errs, eris.New("'debug-body-log-max-size' flag should be positive"))
+	}
+
+	// 25. validate MaxDecompressedRequestSize configuration parameter.
+	if c.MaxDecompressedRequestSize < 0 {
+		errs = append(errs, eris.New("'max-decompressed-request-size' flag should not be negative"))
+	}
+
+	// 26. validate DatabaseURI configuration parameter.
+	// connection itself isn't checked, so configuration can be validated without the database.
+	if c.DatabaseURI != "" {
+		parsed, err := url.Parse(c.DatabaseURI)
+		switch {
+		case err != nil:
+			errs = append(errs, eris.Wrap(err, "error parsing 'database-uri' flag"))
+		case !slices.Contains(
+			[]string{database.SQLiteSchemaName, database.PostgresSchemaName, database.PostgresQLSchemaName},
+			parsed.Scheme,
+		):
+			errs = append(errs, eris.Errorf("unsupported database type '%s' in 'database-uri' flag", parsed.Scheme))
+		}
+	}
+
+	// 27. validate NamespaceIngestionRateLimit, NamespaceIngestionRateWindow and IngestionThrottlePolicy
+	// configuration parameters.
+	if c.NamespaceIngestionRateLimit < 0 {
+		errs = append(errs, eris.New("'namespace-ingestion-rate-limit' flag should not be negative"))
+	}
+	if c.NamespaceIngestionRateWindow < 0 {
+		errs = append(errs, eris.New("'namespace-ingestion-rate-window' flag should not be negative"))
+	}
+	if !slices.Contains(
+		[]string{"", IngestionThrottlePolicyReject, IngestionThrottlePolicyDelay}, c.IngestionThrottlePolicy,
+	) {
+		errs = append(errs, eris.Errorf(
+			"unsupported policy '%s' in 'namespace-ingestion-throttle-policy' flag", c.IngestionThrottlePolicy,
+		))
+	}
+
+	// 28. validate TerminatedRunLogPolicy configuration parameter.
+	if !slices.Contains(
+		[]string{"", TerminatedRunLogPolicyAllow, TerminatedRunLogPolicyReject}, c.TerminatedRunLogPolicy,
+	) {
+		errs = append(errs, eris.Errorf(
+			"unsupported policy '%s' in 'terminated-run-log-policy' flag", c.TerminatedRunLogPolicy,
+		))
+	}
+
+	// 29. validate MaxExperimentNameLength and MaxRunNameLength configuration parameters.
+	for _, limit := range []struct {
+		flag     string
+		value    int
+		maxValue int
+	}{
+		{"max-experiment-name-length", c.MaxExperimentNameLength, DefaultMaxExperimentNameLength},
+		{"max-run-name-length", c.MaxRunNameLength, DefaultMaxRunNameLength},
+	} {
+		if limit.value < 0 {
+			errs = append(errs, eris.Errorf("'%s' flag should not be negative", limit.flag))
+		}
+		if limit.value > limit.maxValue {
+			errs = append(errs, eris.Errorf("'%s' flag should not be greater than %d", limit.flag, limit.maxValue))
+		}
+	}
+
+	// 30. validate LogBatchMaxItems configuration parameter.
+	if c.LogBatchMaxItems < 0 {
+		errs = append(errs, eris.New("'log-batch-max-items' flag should not be negative"))
+	}
+
+	// 31. validate ChooserUserDefaultNamespaces configuration parameter.
+	for _, item := range c.ChooserUserDefaultNamespaces {
+		if _, _, err := parseChooserUserDefaultNamespace(item); err != nil {
+			errs = append(errs, eris.Wrap(err, "error parsing 'chooser-user-default-namespaces' flag"))
+		}
+	}
+
+	// 32. validate ArtifactMaxFileSize configuration parameter.
+	if c.ArtifactMaxFileSize < 0 {
+		errs = append(errs, eris.New("'artifact-max-file-size' flag should not be negative"))
+	}
+
+	// 33. validate DatabasePoolMaxIdle, DatabaseConnMaxLifetime and DatabaseConnMaxIdleTime configuration parameters.
+	if c.DatabasePoolMaxIdle < 0 {
+		errs = append(errs, eris.New("'database-pool-max-idle' flag should not be negative"))
+	}
+	if c.DatabaseConnMaxLifetime < 0 {
+		errs = append(errs, eris.New("'database-conn-max-lifetime' flag should not be negative"))
+	}
+	if c.DatabaseConnMaxIdleTime < 0 {
+		errs = append(errs, eris.New("'database-conn-max-idle-time' flag should not be negative"))
+	}
+
+	// 34. validate ArtifactAllowedSchemes and NamespaceArtifactSchemes configuration parameters.
+	if slices.Contains(c.ArtifactAllowedSchemes, "") {
+		errs = append(errs, eris.New("'artifact-allowed-schemes' flag should not contain empty scheme"))
+	}
+	for _, item := range c.NamespaceArtifactSchemes {
+		if _, _, err := parseNamespaceArtifactScheme(item); err != nil {
+			errs = append(errs, eris.Wrap(err, "error parsing 'namespace-artifact-allowed-schemes' flag"))
+		}
 	}
 
 	if err := c.Auth.ValidateConfiguration(); err != nil {
-		return eris.Wrap(err, "error validating auth configuration")
+		errs = append(errs, eris.Wrap(err, "error validating auth configuration"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // normalizeConfiguration normalizes service configuration parameters.
@@ -108,6 +1009,8 @@ func (c *Config) normalizeConfiguration() error {
 		c.DefaultArtifactRoot = "file://" + absoluteArtifactRoot
 	}
 
+	c.NamespaceBaseDomain = strings.Trim(strings.ToLower(c.NamespaceBaseDomain), ".")
+
 	if err := c.Auth.NormalizeConfiguration(); err != nil {
 		return eris.Wrap(err, "error normalizing auth configuration")
 	}
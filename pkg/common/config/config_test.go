@@ -3,10 +3,13 @@ package config
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rotisserie/eris"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
 func TestConfig_Validate_Ok(t *testing.T) {
@@ -24,6 +27,34 @@ func TestConfig_Validate_Ok(t *testing.T) {
 				DefaultArtifactRoot: "s3://bucket_name",
 			},
 		},
+		{
+			name: "DefaultArtifactRootHasGSPrefixWithTrailingSlash",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "gs://bucket-name/prefix/",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "gs://bucket-name/prefix/",
+			},
+		},
+		{
+			name: "DefaultArtifactRootHasPlaceholders",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket-name/{namespace}/{experiment_id}/",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket-name/{namespace}/{experiment_id}/",
+			},
+		},
+		{
+			name: "S3EndpointURIIsProvided",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+				S3EndpointURI:       "http://minio:9000",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+			},
+		},
 		{
 			name: "DefaultArtifactRootHasFilePrefixAndIsRelative",
 			providedConfig: &Config{
@@ -78,6 +109,338 @@ func TestConfig_Validate_Ok(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_Namespace_Ok(t *testing.T) {
+	config := Config{
+		NamespaceBaseDomain:      ".MLflow.Example.com",
+		NamespaceResolutionOrder: []string{NamespaceResolverSubdomain, NamespaceResolverPath},
+	}
+	require.Nil(t, config.Validate())
+	assert.Equal(t, "mlflow.example.com", config.NamespaceBaseDomain)
+	assert.Equal(
+		t, []string{NamespaceResolverSubdomain, NamespaceResolverPath}, config.GetNamespaceResolutionOrder(),
+	)
+
+	// default resolution order and header are used, when nothing is configured.
+	assert.Equal(
+		t, []string{NamespaceResolverPath, NamespaceResolverSubdomain}, (&Config{}).GetNamespaceResolutionOrder(),
+	)
+	assert.Equal(t, DefaultNamespaceHeader, (&Config{}).GetNamespaceHeader())
+	assert.Equal(t, "X-Proxy-Namespace", (&Config{NamespaceHeader: "X-Proxy-Namespace"}).GetNamespaceHeader())
+}
+
+func TestConfig_IsSystemTag(t *testing.T) {
+	// default prefixes are used, when nothing is configured.
+	assert.Equal(t, SystemTagPolicyAllow, (&Config{}).GetSystemTagPolicy())
+	assert.True(t, (&Config{}).IsSystemTag("mlflow.runName"))
+	assert.False(t, (&Config{}).IsSystemTag("mlflow"))
+	assert.False(t, (&Config{}).IsSystemTag("team.owner"))
+
+	config := Config{
+		SystemTagPolicy:   SystemTagPolicyReject,
+		SystemTagPrefixes: []string{"fasttrackml.", "team."},
+	}
+	assert.Equal(t, SystemTagPolicyReject, config.GetSystemTagPolicy())
+	assert.True(t, config.IsSystemTag("team.owner"))
+	assert.False(t, config.IsSystemTag("mlflow.runName"))
+}
+
+func TestConfig_GetPageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *Config
+		requested int
+		expected  int
+	}{
+		{
+			name:      "NotRequestedWithDefaultMaxPageSize",
+			config:    &Config{},
+			requested: 0,
+			expected:  DefaultMaxPageSize,
+		},
+		{
+			name:      "RequestedWithinDefaultMaxPageSize",
+			config:    &Config{},
+			requested: 10,
+			expected:  10,
+		},
+		{
+			name:      "RequestedOverDefaultMaxPageSize",
+			config:    &Config{},
+			requested: DefaultMaxPageSize + 1,
+			expected:  DefaultMaxPageSize,
+		},
+		{
+			name:      "NotRequestedWithConfiguredMaxPageSize",
+			config:    &Config{MaxPageSize: 50},
+			requested: 0,
+			expected:  50,
+		},
+		{
+			name:      "RequestedOverConfiguredMaxPageSize",
+			config:    &Config{MaxPageSize: 50},
+			requested: 51,
+			expected:  50,
+		},
+		{
+			name:      "RequestedNegative",
+			config:    &Config{MaxPageSize: 50},
+			requested: -1,
+			expected:  50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.GetPageSize(tt.requested))
+		})
+	}
+}
+
+func TestConfig_IsFeatureEnabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *Config
+		namespace string
+		expected  bool
+	}{
+		{
+			name:      "NotConfiguredWithGlobalDisabled",
+			config:    &Config{},
+			namespace: "default",
+			expected:  false,
+		},
+		{
+			name:      "NotConfiguredWithGlobalEnabled",
+			config:    &Config{LiveUpdatesEnabled: true},
+			namespace: "default",
+			expected:  true,
+		},
+		{
+			name: "DisabledForNamespaceWithGlobalEnabled",
+			config: &Config{
+				LiveUpdatesEnabled:    true,
+				NamespaceFeatureFlags: []string{"team-a:live-updates=false"},
+			},
+			namespace: "team-a",
+			expected:  false,
+		},
+		{
+			name: "EnabledForNamespaceWithGlobalDisabled",
+			config: &Config{
+				NamespaceFeatureFlags: []string{"team-a:live-updates=true"},
+			},
+			namespace: "team-a",
+			expected:  true,
+		},
+		{
+			name: "ConfiguredForAnotherNamespace",
+			config: &Config{
+				LiveUpdatesEnabled:    true,
+				NamespaceFeatureFlags: []string{"team-a:live-updates=false"},
+			},
+			namespace: "team-b",
+			expected:  true,
+		},
+		{
+			name: "LastFlagWins",
+			config: &Config{
+				NamespaceFeatureFlags: []string{"team-a:live-updates=true", "team-a:live-updates=false"},
+			},
+			namespace: "team-a",
+			expected:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.IsFeatureEnabled(tt.namespace, FeatureLiveUpdates))
+		})
+	}
+}
+
+func TestConfig_IsArtifactSchemeAllowed(t *testing.T) {
+	config := &Config{
+		ArtifactAllowedSchemes:   []string{"s3", "gs", "file"},
+		NamespaceArtifactSchemes: []string{"restricted:s3", "restricted:GS"},
+	}
+	assert.True(t, config.IsArtifactSchemeAllowed("restricted", "s3"))
+	assert.True(t, config.IsArtifactSchemeAllowed("restricted", "gs"))
+	assert.False(t, config.IsArtifactSchemeAllowed("restricted", "file"))
+	assert.False(t, config.IsArtifactSchemeAllowed("restricted", ""))
+	assert.True(t, config.IsArtifactSchemeAllowed("default", "file"))
+	assert.True(t, config.IsArtifactSchemeAllowed("default", ""))
+	assert.False(t, config.IsArtifactSchemeAllowed("default", "http"))
+
+	// no schemes allow any.
+	assert.True(t, (&Config{}).IsArtifactSchemeAllowed("default", "http"))
+}
+
+func TestConfig_GetRunSearchDefaultOrderBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *Config
+		namespace string
+		expected  string
+	}{
+		{
+			name:      "NotConfigured",
+			config:    &Config{},
+			namespace: "default",
+			expected:  "",
+		},
+		{
+			name: "ConfiguredGlobally",
+			config: &Config{
+				RunSearchDefaultOrderBy: "metrics.accuracy DESC",
+			},
+			namespace: "default",
+			expected:  "metrics.accuracy DESC",
+		},
+		{
+			name: "ConfiguredForNamespace",
+			config: &Config{
+				RunSearchDefaultOrderBy:   "metrics.accuracy DESC",
+				NamespaceRunSearchOrderBy: []string{"team-a: attributes.start_time ASC"},
+			},
+			namespace: "team-a",
+			expected:  "attributes.start_time ASC",
+		},
+		{
+			name: "ConfiguredForAnotherNamespace",
+			config: &Config{
+				RunSearchDefaultOrderBy:   "metrics.accuracy DESC",
+				NamespaceRunSearchOrderBy: []string{"team-a:attributes.start_time ASC"},
+			},
+			namespace: "team-b",
+			expected:  "metrics.accuracy DESC",
+		},
+		{
+			name: "ClauseContainsColon",
+			config: &Config{
+				NamespaceRunSearchOrderBy: []string{"team-a:params.`model:name` DESC"},
+			},
+			namespace: "team-a",
+			expected:  "params.`model:name` DESC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.GetRunSearchDefaultOrderBy(tt.namespace))
+		})
+	}
+}
+
+func TestConfig_GetChooserDefaultNamespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		user     string
+		expected string
+	}{
+		{
+			name:     "NotConfigured",
+			config:   &Config{},
+			user:     "user1",
+			expected: "",
+		},
+		{
+			name: "ConfiguredGlobally",
+			config: &Config{
+				ChooserDefaultNamespace: "team-a",
+			},
+			user:     "user1",
+			expected: "team-a",
+		},
+		{
+			name: "ConfiguredForUser",
+			config: &Config{
+				ChooserDefaultNamespace:      "team-a",
+				ChooserUserDefaultNamespaces: []string{"user1: team-b"},
+			},
+			user:     "user1",
+			expected: "team-b",
+		},
+		{
+			name: "ConfiguredForAnotherUser",
+			config: &Config{
+				ChooserDefaultNamespace:      "team-a",
+				ChooserUserDefaultNamespaces: []string{"user2:team-b"},
+			},
+			user:     "user1",
+			expected: "team-a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.GetChooserDefaultNamespace(tt.user))
+		})
+	}
+}
+
+func TestConfig_GetMiddlewarePlugins(t *testing.T) {
+	config := &Config{
+		MiddlewarePlugins: []string{
+			"after-auth:response-headers",
+			"before-namespace:rate-limit",
+			"after-auth:audit",
+		},
+	}
+	assert.Equal(t, []string{"rate-limit"}, config.GetMiddlewarePlugins(MiddlewarePluginPositionBeforeNamespace))
+	assert.Equal(t, []string{"response-headers", "audit"}, config.GetMiddlewarePlugins(MiddlewarePluginPositionAfterAuth))
+}
+
+func TestConfig_GetResponseHeaders(t *testing.T) {
+	config := &Config{
+		ResponseHeaders: []string{"X-Team: ml", "Content-Security-Policy:default-src 'self'; img-src *"},
+	}
+	assert.Equal(t, map[string]string{
+		"X-Team":                  "ml",
+		"Content-Security-Policy": "default-src 'self'; img-src *",
+	}, config.GetResponseHeaders())
+}
+
+func TestConfig_GetDatabasePoolConfig(t *testing.T) {
+	config := &Config{
+		DatabasePoolMax: 10,
+	}
+	assert.Equal(t, database.PoolConfig{
+		MaxOpenConns:    10,
+		MaxIdleConns:    10,
+		ConnMaxLifetime: database.DefaultConnMaxLifetime,
+		ConnMaxIdleTime: database.DefaultConnMaxIdleTime,
+	}, config.GetDatabasePoolConfig())
+
+	config.DatabasePoolMaxIdle = 2
+	config.DatabaseConnMaxLifetime = 5 * time.Minute
+	config.DatabaseConnMaxIdleTime = 30 * time.Second
+	assert.Equal(t, database.PoolConfig{
+		MaxOpenConns:    10,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: 30 * time.Second,
+	}, config.GetDatabasePoolConfig())
+}
+
+func TestConfig_GetArtifactRoots(t *testing.T) {
+	config := &Config{
+		ArtifactRootFallbacks: []string{
+			"s3://old-bucket/experiments=s3://new-bucket/experiments/",
+			"s3://old-bucket/experiments/=gs://backup-bucket",
+			"s3://old=s3://other-bucket",
+		},
+	}
+	assert.Equal(t, []string{
+		"s3://old-bucket/experiments/1/run/artifacts",
+		"s3://new-bucket/experiments/1/run/artifacts",
+		"gs://backup-bucket/1/run/artifacts",
+	}, config.GetArtifactRoots("s3://old-bucket/experiments/1/run/artifacts"))
+	assert.Equal(t, []string{
+		"s3://old-bucket/experiments",
+		"s3://new-bucket/experiments/",
+	}, config.GetArtifactRoots("s3://old-bucket/experiments"))
+	assert.Equal(t, []string{
+		"/artifacts/1/run/artifacts",
+	}, config.GetArtifactRoots("/artifacts/1/run/artifacts"))
+}
+
 func TestConfig_Validate_Error(t *testing.T) {
 	testData := []struct {
 		name   string
@@ -103,6 +466,498 @@ func TestConfig_Validate_Error(t *testing.T) {
 				DefaultArtifactRoot: "unsupported://something",
 			},
 		},
+		{
+			name: "DefaultArtifactRootHasGSPrefixWithoutBucket",
+			error: eris.New(
+				"error validating service configuration: incorrect bucket name in 'default-artifact-root' flag",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "gs:///prefix",
+			},
+		},
+		{
+			name: "DefaultArtifactRootHasGSPrefixWithPort",
+			error: eris.New(
+				"error validating service configuration: incorrect bucket name in 'default-artifact-root' flag",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "gs://bucket:8080/prefix",
+			},
+		},
+		{
+			name: "DefaultArtifactRootHasUnsupportedPlaceholder",
+			error: eris.New(
+				"error validating service configuration: incorrect format of 'default-artifact-root' flag: " +
+					"unsupported placeholder '{team}'",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "s3://bucket/{team}/{experiment_id}",
+			},
+		},
+		{
+			name: "S3EndpointURIHasUnsupportedSchema",
+			error: eris.New(
+				"error validating service configuration: incorrect format of 's3-endpoint-uri' flag",
+			),
+			config: &Config{
+				S3EndpointURI: "ftp://minio:9000",
+			},
+		},
+		{
+			name: "S3EndpointURIHasNoHost",
+			error: eris.New(
+				"error validating service configuration: incorrect format of 's3-endpoint-uri' flag",
+			),
+			config: &Config{
+				S3EndpointURI: "minio:9000",
+			},
+		},
+		{
+			name: "GSCredentialsFileDoesNotExist",
+			error: eris.New(
+				"error validating service configuration: error reading 'gs-credentials-file' flag: " +
+					"stat /not-existing/credentials.json: no such file or directory",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "gs://bucket/prefix",
+				GSCredentialsFile:   "/not-existing/credentials.json",
+			},
+		},
+		{
+			name: "NamespaceBaseDomainHasPort",
+			error: eris.New(
+				"error validating service configuration: incorrect format of 'namespace-base-domain' flag",
+			),
+			config: &Config{
+				NamespaceBaseDomain: "mlflow.example.com:5000",
+			},
+		},
+		{
+			name: "NamespaceHeaderHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: incorrect format of 'namespace-header' flag",
+			),
+			config: &Config{
+				NamespaceHeader: "X Namespace:",
+			},
+		},
+		{
+			name: "NamespaceResolutionOrderHasUnsupportedResolver",
+			error: eris.New(
+				"error validating service configuration: " +
+					"unsupported namespace resolver 'cookie' in 'namespace-resolution-order' flag",
+			),
+			config: &Config{
+				NamespaceResolutionOrder: []string{"path", "cookie"},
+			},
+		},
+		{
+			name: "NamespaceResolutionOrderHasDuplicatedResolver",
+			error: eris.New(
+				"error validating service configuration: " +
+					"duplicated namespace resolver 'path' in 'namespace-resolution-order' flag",
+			),
+			config: &Config{
+				NamespaceResolutionOrder: []string{"path", "subdomain", "path"},
+			},
+		},
+		{
+			name: "RunMaxParamKeysIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'run-max-param-keys' flag should not be negative",
+			),
+			config: &Config{
+				RunMaxParamKeys: -1,
+			},
+		},
+		{
+			name: "NamespaceMaxTagKeysIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'namespace-max-tag-keys' flag should not be negative",
+			),
+			config: &Config{
+				NamespaceMaxTagKeys: -1,
+			},
+		},
+		{
+			name: "MetricTimestampMaxSkewIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'metric-timestamp-max-skew' flag should not be negative",
+			),
+			config: &Config{
+				MetricTimestampMaxSkew: -time.Minute,
+			},
+		},
+		{
+			name: "MetricTimestampSkewPolicyIsUnsupported",
+			error: eris.New(
+				"error validating service configuration: " +
+					"unsupported policy 'ignore' in 'metric-timestamp-skew-policy' flag",
+			),
+			config: &Config{
+				MetricTimestampSkewPolicy: "ignore",
+			},
+		},
+		{
+			name: "MaxPageSizeIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'max-page-size' flag should not be negative",
+			),
+			config: &Config{
+				MaxPageSize: -1,
+			},
+		},
+		{
+			name: "NamespaceFeatureFlagHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: error parsing 'namespace-feature-flags' flag: " +
+					"incorrect format of feature flag 'live-updates=true'",
+			),
+			config: &Config{
+				NamespaceFeatureFlags: []string{"live-updates=true"},
+			},
+		},
+		{
+			name: "NamespaceFeatureFlagHasUnsupportedFeature",
+			error: eris.New(
+				"error validating service configuration: error parsing 'namespace-feature-flags' flag: " +
+					"unsupported feature 'unknown' in feature flag 'team-a:unknown=true'",
+			),
+			config: &Config{
+				NamespaceFeatureFlags: []string{"team-a:unknown=true"},
+			},
+		},
+		{
+			name: "NamespaceFeatureFlagHasIncorrectValue",
+			error: eris.New(
+				"error validating service configuration: error parsing 'namespace-feature-flags' flag: " +
+					"incorrect value of feature flag 'team-a:live-updates=maybe'",
+			),
+			config: &Config{
+				NamespaceFeatureFlags: []string{"team-a:live-updates=maybe"},
+			},
+		},
+		{
+			name: "ProjectCacheTTLIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'project-cache-ttl' flag should not be negative",
+			),
+			config: &Config{
+				ProjectCacheTTL: -time.Minute,
+			},
+		},
+		{
+			name: "RequestTimeoutIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'request-timeout' flag should not be negative",
+			),
+			config: &Config{
+				RequestTimeout: -time.Second,
+			},
+		},
+		{
+			name: "ArtifactRequestTimeoutIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'artifact-request-timeout' flag should not be negative",
+			),
+			config: &Config{
+				ArtifactRequestTimeout: -time.Second,
+			},
+		},
+		{
+			name: "ArtifactUploadSessionTTLIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'artifact-upload-session-ttl' flag should not be negative",
+			),
+			config: &Config{
+				ArtifactUploadSessionTTL: -time.Minute,
+			},
+		},
+		{
+			name: "ArtifactMaxFileSizeIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'artifact-max-file-size' flag should not be negative",
+			),
+			config: &Config{
+				ArtifactMaxFileSize: -1,
+			},
+		},
+		{
+			name: "SystemTagPolicyIsUnsupported",
+			error: eris.New(
+				"error validating service configuration: unsupported policy 'clamp' in 'system-tag-policy' flag",
+			),
+			config: &Config{
+				SystemTagPolicy: "clamp",
+			},
+		},
+		{
+			name: "SystemTagPrefixesContainEmptyPrefix",
+			error: eris.New(
+				"error validating service configuration: 'system-tag-prefixes' flag should not contain empty prefix",
+			),
+			config: &Config{
+				SystemTagPrefixes: []string{"mlflow.", ""},
+			},
+		},
+		{
+			name: "DatabaseReadStatementTimeoutIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'database-read-statement-timeout' flag should not be negative",
+			),
+			config: &Config{
+				DatabaseReadStatementTimeout: -time.Second,
+			},
+		},
+		{
+			name: "DatabaseWriteStatementTimeoutIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'database-write-statement-timeout' flag should not be negative",
+			),
+			config: &Config{
+				DatabaseWriteStatementTimeout: -time.Second,
+			},
+		},
+		{
+			name: "NamespaceArtifactSchemesHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: error parsing 'namespace-artifact-allowed-schemes' flag: " +
+					"incorrect format of namespace artifact scheme 'restricted'",
+			),
+			config: &Config{
+				NamespaceArtifactSchemes: []string{"restricted"},
+			},
+		},
+		{
+			name: "DatabaseConnMaxLifetimeIsNegative",
+			error: eris.New(
+				"error validating service configuration: 'database-conn-max-lifetime' flag should not be negative",
+			),
+			config: &Config{
+				DatabaseConnMaxLifetime: -time.Second,
+			},
+		},
+		{
+			name: "NamespaceRunSearchOrderByHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: error parsing 'namespace-run-search-default-order-by' flag: " +
+					"incorrect format of default order 'metrics.accuracy DESC'",
+			),
+			config: &Config{
+				NamespaceRunSearchOrderBy: []string{"metrics.accuracy DESC"},
+			},
+		},
+		{
+			name: "NamespaceRunSearchOrderByHasEmptyClause",
+			error: eris.New(
+				"error validating service configuration: error parsing 'namespace-run-search-default-order-by' flag: " +
+					"incorrect format of default order 'team-a: '",
+			),
+			config: &Config{
+				NamespaceRunSearchOrderBy: []string{"team-a: "},
+			},
+		},
+		{
+			name: "MiddlewarePluginHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: error parsing 'middleware-plugins' flag: " +
+					"incorrect format of middleware plugin 'response-headers'",
+			),
+			config: &Config{
+				MiddlewarePlugins: []string{"response-headers"},
+			},
+		},
+		{
+			name: "MiddlewarePluginHasUnsupportedPosition",
+			error: eris.New(
+				"error validating service configuration: error parsing 'middleware-plugins' flag: " +
+					"unsupported position 'before-auth' of middleware plugin 'before-auth:response-headers'",
+			),
+			config: &Config{
+				MiddlewarePlugins: []string{"before-auth:response-headers"},
+			},
+		},
+		{
+			name: "MiddlewarePluginIsDuplicated",
+			error: eris.New(
+				"error validating service configuration: " +
+					"duplicated middleware plugin 'response-headers' in 'middleware-plugins' flag",
+			),
+			config: &Config{
+				MiddlewarePlugins: []string{"before-namespace:response-headers", "after-auth:response-headers"},
+			},
+		},
+		{
+			name: "IPAllowlistHasIncorrectAddress",
+			error: eris.New(
+				"error validating service configuration: " +
+					"incorrect IP address or CIDR '10.0.0.0/33' in 'ip-allowlist' flag",
+			),
+			config: &Config{
+				IPAllowlist: []string{"127.0.0.1", "10.0.0.0/33"},
+			},
+		},
+		{
+			name: "TrustedProxiesHasIncorrectAddress",
+			error: eris.New(
+				"error validating service configuration: " +
+					"incorrect IP address or CIDR 'proxy.local' in 'trusted-proxies' flag",
+			),
+			config: &Config{
+				TrustedProxies: []string{"proxy.local"},
+			},
+		},
+		{
+			name: "ResponseHeaderHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: error parsing 'response-headers' flag: " +
+					"incorrect format of response header 'X Team: ml'",
+			),
+			config: &Config{
+				ResponseHeaders: []string{"X Team: ml"},
+			},
+		},
+		{
+			name: "ArtifactRootFallbackHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: error parsing 'artifact-root-fallbacks' flag: " +
+					"incorrect format of artifact root fallback 's3://old-bucket'",
+			),
+			config: &Config{
+				ArtifactRootFallbacks: []string{"s3://old-bucket"},
+			},
+		},
+		{
+			name: "ArtifactRootFallbackHasIncorrectRoot",
+			error: eris.New(
+				`error validating service configuration: error parsing 'artifact-root-fallbacks' flag: ` +
+					`incorrect artifact root 'incorrect_format_of_schema://something': parse ` +
+					`"incorrect_format_of_schema://something": first path segment in URL cannot contain colon`,
+			),
+			config: &Config{
+				ArtifactRootFallbacks: []string{"s3://old-bucket=incorrect_format_of_schema://something"},
+			},
+		},
+		{
+			name: "DebugBodyLogPrefixHasIncorrectFormat",
+			error: eris.New(
+				"error validating service configuration: route prefix 'api/2.0/mlflow' in " +
+					"'debug-body-log-prefixes' flag should start with '/'",
+			),
+			config: &Config{
+				DebugBodyLogPrefixes: []string{"api/2.0/mlflow"},
+				DebugBodyLogMaxSize:  1024,
+			},
+		},
+		{
+			name: "DebugBodyLogMaxSizeIsNotPositive",
+			error: eris.New(
+				"error validating service configuration: 'debug-body-log-max-size' flag should be positive",
+			),
+			config: &Config{
+				DebugBodyLogPrefixes: []string{"/api/2.0/mlflow"},
+			},
+		},
+		{
+			name: "NegativeMetricValuePrecision",
+			error: eris.New(
+				"error validating service configuration: 'metric-value-precision' flag should be between 0 and 17",
+			),
+			config: &Config{
+				MetricValuePrecision: -1,
+			},
+		},
+		{
+			name: "TooBigMetricValuePrecision",
+			error: eris.New(
+				"error validating service configuration: 'metric-value-precision' flag should be between 0 and 17",
+			),
+			config: &Config{
+				MetricValuePrecision: 18,
+			},
+		},
+		{
+			name: "SeveralErrors",
+			error: eris.New(
+				"error validating service configuration: incorrect bucket name in 'default-artifact-root' flag\n" +
+					"'max-page-size' flag should not be negative\n" +
+					"incorrect IP address or CIDR 'incorrect' in 'ip-allowlist' flag\n" +
+					"incorrect IP address or CIDR '10.0.0.0/33' in 'trusted-proxies' flag\n" +
+					"unsupported database type 'mysql' in 'database-uri' flag",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "s3://",
+				MaxPageSize:         -1,
+				IPAllowlist:         []string{"incorrect"},
+				TrustedProxies:      []string{"10.0.0.0/33"},
+				DatabaseURI:         "mysql://localhost/fasttrackml",
+			},
+		},
+		{
+			name: "NegativeNamespaceIngestionRateLimit",
+			error: eris.New(
+				"error validating service configuration: 'namespace-ingestion-rate-limit' flag should not be negative",
+			),
+			config: &Config{
+				NamespaceIngestionRateLimit: -1,
+			},
+		},
+		{
+			name: "UnsupportedIngestionThrottlePolicy",
+			error: eris.New(
+				"error validating service configuration: unsupported policy 'wait' in " +
+					"'namespace-ingestion-throttle-policy' flag",
+			),
+			config: &Config{
+				IngestionThrottlePolicy: "wait",
+			},
+		},
+		{
+			name: "UnsupportedTerminatedRunLogPolicy",
+			error: eris.New(
+				"error validating service configuration: unsupported policy 'ignore' in 'terminated-run-log-policy' flag",
+			),
+			config: &Config{
+				TerminatedRunLogPolicy: "ignore",
+			},
+		},
+		{
+			name: "TooLargeMaxRunNameLength",
+			error: eris.New(
+				"error validating service configuration: 'max-run-name-length' flag should not be greater than 250",
+			),
+			config: &Config{
+				MaxRunNameLength: 1000,
+			},
+		},
+		{
+			name: "NegativeLogBatchMaxItems",
+			error: eris.New(
+				"error validating service configuration: 'log-batch-max-items' flag should not be negative",
+			),
+			config: &Config{
+				LogBatchMaxItems: -1,
+			},
+		},
+		{
+			name: "IncorrectChooserUserDefaultNamespaces",
+			error: eris.New(
+				"error validating service configuration: error parsing 'chooser-user-default-namespaces' flag: " +
+					"incorrect format of user default namespace 'user1'",
+			),
+			config: &Config{
+				ChooserUserDefaultNamespaces: []string{"user1"},
+			},
+		},
+		{
+			name: "UnsupportedDatabaseType",
+			error: eris.New(
+				"error validating service configuration: unsupported database type 'mysql' in 'database-uri' flag",
+			),
+			config: &Config{
+				DatabaseURI: "mysql://localhost/fasttrackml",
+			},
+		},
 	}
 
 	for _, tt := range testData {
@@ -17,6 +17,8 @@ type EventListenerProvider interface {
 	Listen()
 	// Subscribe subscribe to particular channel.
 	Subscribe(subscriber chan<- string)
+	// Publish publishes event to the channel.
+	Publish(ctx context.Context, db *gorm.DB, payload string) error
 	// GetChannelName returns channel name.
 	GetChannelName() string
 }
@@ -76,6 +78,11 @@ func NewNamespaceListener(ctx context.Context, db *gorm.DB) (*EventListener, err
 	return NewEventListener(ctx, db, "namespace_update_events")
 }
 
+// NewRunListener creates new database event listener for Run entity.
+func NewRunListener(ctx context.Context, db *gorm.DB) (*EventListener, error) {
+	return NewEventListener(ctx, db, "run_update_events")
+}
+
 // Listen listens for incoming database events.
 func (el *EventListener) Listen() {
 	// if listener not nil, then listen for incoming events from database.
@@ -113,6 +120,31 @@ func (el *EventListener) Subscribe(subscriber chan<- string) {
 	}
 }
 
+// Publish publishes event to the channel. For `postgres` event is sent via `pg_notify`, so all the
+// instances get it, once the transaction of provided db, if any, is committed. Otherwise, event is
+// delivered straight to the subscribers of the current instance.
+func (el *EventListener) Publish(ctx context.Context, db *gorm.DB, payload string) error {
+	if el.connection != nil {
+		if err := db.WithContext(ctx).Exec(`SELECT pg_notify(?, ?)`, el.channel, payload).Error; err != nil {
+			return eris.Wrap(err, "error triggering 'pg_notify'")
+		}
+		return nil
+	}
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	for _, ch := range el.subscriptions[el.channel] {
+		// deliver asynchronously, so publisher is never blocked by busy subscriber.
+		go func(ch chan<- string) {
+			select {
+			case <-el.ctx.Done():
+			case ch <- payload:
+			}
+		}(ch)
+	}
+	return nil
+}
+
 // GetChannelName returns current channel name.
 func (el *EventListener) GetChannelName() string {
 	return el.channel
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// ResponseField represents optional section of the entity, which could be selected by `fields` query parameter.
+type ResponseField string
+
+// Supported list of response fields.
+const (
+	ResponseFieldParams  ResponseField = "params"
+	ResponseFieldMetrics ResponseField = "metrics"
+	ResponseFieldTags    ResponseField = "tags"
+	ResponseFieldSummary ResponseField = "summary"
+)
+
+// ResponseFields represents the list of requested sections. Empty list means that all the sections are requested.
+type ResponseFields []ResponseField
+
+// NewResponseFields creates ResponseFields from comma-separated value of `fields` query parameter.
+func NewResponseFields(value string) ResponseFields {
+	var fields ResponseFields
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, ResponseField(field))
+		}
+	}
+	return fields
+}
+
+// Has checks that section has been requested.
+func (f ResponseFields) Has(field ResponseField) bool {
+	return len(f) == 0 || slices.Contains(f, field)
+}
@@ -1,12 +1,23 @@
 package models
 
-import "fmt"
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // BasicAuthToken represents object to store auth information related to Basic Auth.
 type BasicAuthToken struct {
+	name  string
 	roles map[string]struct{}
 }
 
+// GetName returns User name assigned to current Auth token.
+func (p BasicAuthToken) GetName() string {
+	return p.name
+}
+
 // HasAdminAccess makes check that user has admin permissions to access to the requested resource.
 func (p BasicAuthToken) HasAdminAccess() bool {
 	if _, ok := p.roles["admin"]; ok {
@@ -30,6 +41,7 @@ func (p BasicAuthToken) GetRoles() map[string]struct{} {
 
 // UserPermissions represents model to store user permissions data.
 type UserPermissions struct {
+	mu   sync.RWMutex
 	data map[string]map[string]struct{}
 }
 
@@ -41,22 +53,40 @@ func NewUserPermissions(data map[string]map[string]struct{}) *UserPermissions {
 }
 
 // GetData returns current permissions data.
-func (p UserPermissions) GetData() map[string]map[string]struct{} {
+func (p *UserPermissions) GetData() map[string]map[string]struct{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.data
 }
 
+// Replace atomically replaces current permissions data, e.g. after configuration reload.
+func (p *UserPermissions) Replace(data map[string]map[string]struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.data = data
+}
+
 // ValidateAuthToken makes basic validation of auth token.
-func (p UserPermissions) ValidateAuthToken(authToken string) *BasicAuthToken {
+func (p *UserPermissions) ValidateAuthToken(authToken string) *BasicAuthToken {
 	if authToken == "" {
 		return nil
 	}
 
+	p.mu.RLock()
 	roles, ok := p.data[authToken]
+	p.mu.RUnlock()
 	if !ok {
 		return nil
 	}
 
+	// auth token has been found, so it is a valid base64 encoded `name:password` pair.
+	name := ""
+	if login, err := base64.StdEncoding.DecodeString(authToken); err == nil {
+		name, _, _ = strings.Cut(string(login), ":")
+	}
+
 	return &BasicAuthToken{
+		name:  name,
 		roles: roles,
 	}
 }
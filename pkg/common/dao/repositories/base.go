@@ -1,11 +1,17 @@
 package repositories
 
-import "gorm.io/gorm"
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
 
 // BaseRepositoryProvider provides base repository interface.
 type BaseRepositoryProvider interface {
 	// GetDB returns current DB instance.
 	GetDB() *gorm.DB
+	// GetDBWithContext returns DB instance bound to the context, which joins request-scoped transaction, if any.
+	GetDBWithContext(ctx context.Context) *gorm.DB
 }
 
 // BaseRepository represents base repository object.
@@ -22,3 +28,12 @@ func NewBaseRepository(db *gorm.DB) *BaseRepository {
 func (r BaseRepository) GetDB() *gorm.DB {
 	return r.db
 }
+
+// GetDBWithContext returns DB instance bound to the context. When the context carries request-scoped
+// transaction, the transaction is returned instead, so changes are committed or rolled back together.
+func (r BaseRepository) GetDBWithContext(ctx context.Context) *gorm.DB {
+	if tx := GetTransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
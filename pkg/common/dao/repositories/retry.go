@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// TransientErrorMaxAttempts is the maximum number of attempts to run an operation failing with transient errors.
+	TransientErrorMaxAttempts = 5
+	// TransientErrorBaseDelay is the delay before the first retry, which is doubled for every next retry.
+	TransientErrorBaseDelay = 10 * time.Millisecond
+	// TransientErrorMaxDelay is the maximum delay between retries.
+	TransientErrorMaxDelay = 500 * time.Millisecond
+)
+
+// transientErrorCodes are Postgres error codes of failures, after which the whole transaction could be retried:
+// serialization_failure and deadlock_detected.
+var transientErrorCodes = []string{"40001", "40P01"}
+
+// IsTransientError checks that error is caused by a transient failure of concurrent transactions.
+func IsTransientError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && slices.Contains(transientErrorCodes, pgErr.Code)
+}
+
+// RetryOnTransientError runs the operation and reruns it with bounded exponential backoff
+// while it fails with transient errors. The operation has to be safe to rerun from scratch.
+// When the context carries request-scoped transaction, the operation runs once, because the failed
+// transaction can't be continued, and transient error is remembered, so the whole request could be rerun.
+func RetryOnTransientError(ctx context.Context, operation func() error) error {
+	if hasTransaction(ctx) {
+		err := operation()
+		markTransientError(ctx, err)
+		return err
+	}
+	return RetryOnError(ctx, operation, IsTransientError)
+}
+
+// RetryOnError runs the operation and reruns it with bounded exponential backoff
+// while isRetryable reports its errors as retryable.
+func RetryOnError(ctx context.Context, operation func() error, isRetryable func(err error) bool) error {
+	delay := TransientErrorBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil || !isRetryable(err) || attempt == TransientErrorMaxAttempts {
+			return err
+		}
+		log.Debugf("retrying operation after transient error (attempt %d): %s", attempt, err)
+
+		// add jitter, so concurrent transactions don't collide again on the next attempt.
+		//nolint:gosec
+		timer := time.NewTimer(delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, TransientErrorMaxDelay)
+	}
+}
+
+// TransactionWithRetry runs fc within a transaction and reruns the whole transaction while it fails
+// with transient errors, e.g. serialization failures of concurrent transactions. When the context carries
+// request-scoped transaction, fc runs within a nested transaction of it, and transient failures are left
+// for the transaction middleware, as only the whole request-scoped transaction could be rerun.
+func TransactionWithRetry(ctx context.Context, db *gorm.DB, fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	if tx := GetTransactionFromContext(ctx); tx != nil {
+		err := tx.WithContext(ctx).Transaction(fc, opts...)
+		markTransientError(ctx, err)
+		return err
+	}
+	return RetryOnTransientError(ctx, func() error {
+		return db.WithContext(ctx).Transaction(fc, opts...)
+	})
+}
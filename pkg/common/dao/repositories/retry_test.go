@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryOnTransientError(t *testing.T) {
+	tests := []struct {
+		name             string
+		errors           []error
+		expectedAttempts int
+		expectedError    bool
+	}{
+		{
+			name:             "NoError",
+			errors:           []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "SerializationFailure",
+			errors:           []error{&pgconn.PgError{Code: "40001"}, nil},
+			expectedAttempts: 2,
+		},
+		{
+			name: "WrappedDeadlock",
+			errors: []error{
+				eris.Wrap(&pgconn.PgError{Code: "40P01"}, "error creating params"),
+				eris.Wrap(&pgconn.PgError{Code: "40P01"}, "error creating params"),
+				nil,
+			},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "NotTransientError",
+			errors:           []error{&pgconn.PgError{Code: "23505"}},
+			expectedAttempts: 1,
+			expectedError:    true,
+		},
+		{
+			name:             "OtherError",
+			errors:           []error{errors.New("database error")},
+			expectedAttempts: 1,
+			expectedError:    true,
+		},
+		{
+			name: "TooManyAttempts",
+			errors: []error{
+				&pgconn.PgError{Code: "40001"},
+				&pgconn.PgError{Code: "40001"},
+				&pgconn.PgError{Code: "40001"},
+				&pgconn.PgError{Code: "40001"},
+				&pgconn.PgError{Code: "40001"},
+				nil,
+			},
+			expectedAttempts: TransientErrorMaxAttempts,
+			expectedError:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := RetryOnTransientError(context.Background(), func() error {
+				attempts++
+				return tt.errors[attempts-1]
+			})
+			assert.Equal(t, tt.expectedAttempts, attempts)
+			assert.Equal(t, tt.expectedError, err != nil)
+		})
+	}
+}
+
+func TestRetryOnTransientError_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := RetryOnTransientError(ctx, func() error {
+		attempts++
+		return &pgconn.PgError{Code: "40001"}
+	})
+	assert.Equal(t, 1, attempts)
+	assert.True(t, IsTransientError(err))
+}
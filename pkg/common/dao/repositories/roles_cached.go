@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"slices"
+	"sync/atomic"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/rotisserie/eris"
@@ -19,12 +20,15 @@ import (
 type RoleRepositoryProvider interface {
 	// ValidateRolesAccessToNamespace makes validation that requested roles has access to requested namespace.
 	ValidateRolesAccessToNamespace(ctx context.Context, roles []string, namespaceCode string) (bool, error)
+	// Invalidate drops all the cached roles, so they will be loaded again on next request.
+	Invalidate()
 }
 
 // RoleCachedRepository cached repository to work with `role` entity.
 type RoleCachedRepository struct {
 	db                     *gorm.DB
 	cache                  *lru.Cache[string, []string]
+	generation             *atomic.Uint64
 	namespaceEventListener dao.EventListenerProvider
 }
 
@@ -40,6 +44,7 @@ func NewRoleCachedRepository(
 	repository := RoleCachedRepository{
 		db:                     db,
 		cache:                  cache,
+		generation:             &atomic.Uint64{},
 		namespaceEventListener: namespaceEventListener,
 	}
 
@@ -79,7 +84,9 @@ func (r RoleCachedRepository) ValidateRolesAccessToNamespace(
 		return false, nil
 	}
 
-	// otherwise check database and store result in cache.
+	// otherwise check database and store result in cache. remember current cache generation,
+	// so roles loaded before concurrent invalidation won't get back into the cache.
+	generation := r.generation.Load()
 	var data []models.RoleNamespace
 	if err := r.db.WithContext(ctx).Model(
 		&models.RoleNamespace{},
@@ -102,8 +109,11 @@ func (r RoleCachedRepository) ValidateRolesAccessToNamespace(
 		namespaceRoles[i] = namespaceRole.Role.Name
 	}
 
-	// save into cache.
+	// save into cache, unless cache has been invalidated in the meantime.
 	r.cache.Add(requestedNamespaceCode, namespaceRoles)
+	if generation != r.generation.Load() {
+		r.cache.Remove(requestedNamespaceCode)
+	}
 
 	// check permissions from database.
 	for _, requestedRole := range requestedRoles {
@@ -115,6 +125,13 @@ func (r RoleCachedRepository) ValidateRolesAccessToNamespace(
 	return false, nil
 }
 
+// Invalidate drops all the cached roles, so they will be loaded again on next request.
+func (r RoleCachedRepository) Invalidate() {
+	r.generation.Add(1)
+	r.cache.Purge()
+	log.Debugf("roles cache has been invalidated")
+}
+
 // processEvent process incoming event from database.
 func (r RoleCachedRepository) processEvent(data string) error {
 	log.Debugf("got incoming namespace event: %s", data)
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// transactionContextKey is a key of request-scoped transaction in the context.
+type transactionContextKey struct{}
+
+// requestTransaction represents request-scoped transaction and the transient error, which failed it, if any.
+// The transaction is begun lazily, when the first repository joins it, so the database connection is not
+// held while the request is validated or throttled.
+type requestTransaction struct {
+	sync.Mutex
+	db           *gorm.DB
+	tx           *gorm.DB
+	transientErr error
+	rollbackFns  []func()
+}
+
+// ContextWithTransaction returns a copy of the context, which carries request-scoped transaction.
+// Repositories, which get DB instance by GetDBWithContext, join this transaction.
+func ContextWithTransaction(ctx context.Context, db *gorm.DB) context.Context {
+	return context.WithValue(ctx, transactionContextKey{}, &requestTransaction{db: db})
+}
+
+// GetTransactionFromContext returns request-scoped transaction from the context, if any.
+// The transaction is begun by the first call.
+func GetTransactionFromContext(ctx context.Context) *gorm.DB {
+	transaction, ok := ctx.Value(transactionContextKey{}).(*requestTransaction)
+	if !ok {
+		return nil
+	}
+	transaction.Lock()
+	defer transaction.Unlock()
+	if transaction.tx == nil {
+		// failed Begin is kept as well, so its error is returned by every statement of the request.
+		transaction.tx = transaction.db.Begin()
+	}
+	return transaction.tx
+}
+
+// CommitTransactionFromContext commits request-scoped transaction of the context, if it has been begun.
+// When commit fails, the functions registered by OnTransactionRollback are run.
+func CommitTransactionFromContext(ctx context.Context) error {
+	transaction, ok := ctx.Value(transactionContextKey{}).(*requestTransaction)
+	if !ok || transaction.tx == nil {
+		return nil
+	}
+	err := transaction.tx.Error
+	if err == nil {
+		err = transaction.tx.Commit().Error
+	}
+	if err != nil {
+		transaction.runRollbackFns()
+	}
+	return err
+}
+
+// RollbackTransactionFromContext rolls back request-scoped transaction of the context, if it has been begun,
+// and runs the functions registered by OnTransactionRollback.
+func RollbackTransactionFromContext(ctx context.Context) error {
+	transaction, ok := ctx.Value(transactionContextKey{}).(*requestTransaction)
+	if !ok {
+		return nil
+	}
+	transaction.runRollbackFns()
+	if transaction.tx == nil || transaction.tx.Error != nil {
+		return nil
+	}
+	return transaction.tx.Rollback().Error
+}
+
+// OnTransactionRollback registers function, which reverts side effects of the request outside of the database,
+// when request-scoped transaction of the context is rolled back or fails to commit.
+// Nothing is registered, when the context carries no request-scoped transaction.
+func OnTransactionRollback(ctx context.Context, fn func()) {
+	if transaction, ok := ctx.Value(transactionContextKey{}).(*requestTransaction); ok {
+		transaction.Lock()
+		defer transaction.Unlock()
+		transaction.rollbackFns = append(transaction.rollbackFns, fn)
+	}
+}
+
+// runRollbackFns runs the functions registered by OnTransactionRollback.
+func (t *requestTransaction) runRollbackFns() {
+	t.Lock()
+	fns := t.rollbackFns
+	t.rollbackFns = nil
+	t.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// GetTransientErrorFromContext returns transient error, which failed request-scoped transaction, if any.
+// Such a transaction can't be retried by repositories, so the whole request has to be rerun instead.
+func GetTransientErrorFromContext(ctx context.Context) error {
+	if transaction, ok := ctx.Value(transactionContextKey{}).(*requestTransaction); ok {
+		return transaction.transientErr
+	}
+	return nil
+}
+
+// hasTransaction checks that the context carries request-scoped transaction, without beginning it.
+func hasTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(transactionContextKey{}).(*requestTransaction)
+	return ok
+}
+
+// markTransientError remembers transient error in request-scoped transaction of the context, if any.
+func markTransientError(ctx context.Context, err error) {
+	if transaction, ok := ctx.Value(transactionContextKey{}).(*requestTransaction); ok && IsTransientError(err) {
+		transaction.transientErr = err
+	}
+}
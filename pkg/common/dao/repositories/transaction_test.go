@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newTransactionTestDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	mockDb, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		//nolint:errcheck
+		mockDb.Close()
+	})
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDb,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.Nil(t, err)
+	return db, mock
+}
+
+func TestOnTransactionRollback(t *testing.T) {
+	tests := []struct {
+		name            string
+		finish          func(ctx context.Context) error
+		expectations    func(mock sqlmock.Sqlmock)
+		expectedRelease int
+	}{
+		{
+			name:   "Committed",
+			finish: CommitTransactionFromContext,
+			expectations: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "CommitFailed",
+			finish: CommitTransactionFromContext,
+			expectations: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(errors.New("commit error"))
+			},
+			expectedRelease: 1,
+		},
+		{
+			name:   "RolledBack",
+			finish: RollbackTransactionFromContext,
+			expectations: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			expectedRelease: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newTransactionTestDB(t)
+			tt.expectations(mock)
+
+			released := 0
+			ctx := ContextWithTransaction(context.Background(), db)
+			OnTransactionRollback(ctx, func() {
+				released++
+			})
+			require.NotNil(t, GetTransactionFromContext(ctx))
+			//nolint:errcheck
+			tt.finish(ctx)
+			assert.Equal(t, tt.expectedRelease, released)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
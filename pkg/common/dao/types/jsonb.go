@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"encoding/json"
@@ -60,6 +61,18 @@ func (j *JSONB) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// Canonical returns canonical form of JSON with sorted object keys and without insignificant whitespaces,
+// so semantically identical values have the same form.
+func (j JSONB) Canonical() (JSONB, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(j))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
 func (j JSONB) String() string {
 	return string(j)
 }
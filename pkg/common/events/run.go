@@ -0,0 +1,11 @@
+package events
+
+// RunEvent represents database event, triggered when runs of a namespace are created, updated or removed.
+type RunEvent struct {
+	// NamespaceID is an ID of the namespace of the runs. Could be empty, if ExperimentID is provided.
+	NamespaceID uint `json:"namespace_id"`
+	// ExperimentID is an ID of the experiment of the runs.
+	ExperimentID *int32 `json:"experiment_id"`
+	// StartTimes are start times of the affected runs. Empty list means that all the runs could be affected.
+	StartTimes []int64 `json:"start_times"`
+}
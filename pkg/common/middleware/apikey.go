@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// nolint:gosec
+const (
+	apiKeyContextKey = "api_key"
+)
+
+// apiKeyLastUsedInterval is a minimal interval between updates of the API key last usage time,
+// so frequently used keys don't cause a database write on each request.
+const apiKeyLastUsedInterval = time.Minute
+
+// APIKeyMiddleware represents namespace API key middleware.
+type APIKeyMiddleware struct {
+	apiKeyRepository repositories.APIKeyRepositoryProvider
+}
+
+// NewAPIKeyMiddleware creates new API key middleware logic. API keys are accepted only by Aim or Mlflow
+// resources of the namespace, which the key belongs to. Other auth middlewares let through requests
+// authenticated by API key.
+func NewAPIKeyMiddleware(apiKeyRepository repositories.APIKeyRepositoryProvider) fiber.Handler {
+	return APIKeyMiddleware{
+		apiKeyRepository: apiKeyRepository,
+	}.Handle()
+}
+
+// Handle handles API key middleware logic.
+func (m APIKeyMiddleware) Handle() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		key, ok := getAPIKeyFromHeader(ctx)
+		if !ok || !MlflowAimPrefixRegexp.MatchString(ctx.Path()) {
+			return ctx.Next()
+		}
+
+		namespace, err := GetNamespaceFromContext(ctx.Context())
+		if err != nil {
+			return api.NewInternalError("error getting namespace from context")
+		}
+
+		apiKey, err := m.apiKeyRepository.GetByHash(ctx.Context(), models.HashAPIKey(key))
+		if err != nil {
+			log.Errorf("error getting api key: %+v", err)
+			return ctx.Status(
+				http.StatusInternalServerError,
+			).JSON(
+				api.NewInternalError("error validating api key"),
+			)
+		}
+		// keys of other namespaces are handled as unknown ones, so existence of the namespace isn't exposed.
+		if apiKey == nil || apiKey.NamespaceID != namespace.ID {
+			return sendNamespaceNotFoundError(ctx, namespace.Code)
+		}
+		if APIKeysPathRegexp.MatchString(ctx.Path()) {
+			return ctx.Status(
+				http.StatusForbidden,
+			).JSON(
+				api.NewPermissionDeniedError("api keys can't be managed using api key"),
+			)
+		}
+		if !apiKey.HasWriteAccess() && !isReadRequest(ctx) {
+			return ctx.Status(
+				http.StatusForbidden,
+			).JSON(
+				api.NewPermissionDeniedError("api key '%s' has read only access", apiKey.Prefix),
+			)
+		}
+
+		now := time.Now().UTC()
+		if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= apiKeyLastUsedInterval {
+			// failure to track the usage shouldn't fail the request itself.
+			if err := m.apiKeyRepository.UpdateLastUsedAt(ctx.Context(), apiKey, now); err != nil {
+				log.Errorf("error updating last usage time of api key '%s': %+v", apiKey.Prefix, err)
+			}
+		}
+
+		ctx.Locals(apiKeyContextKey, apiKey)
+		return ctx.Next()
+	}
+}
+
+// getAPIKeyFromHeader returns API key from the `Authorization` header, if the header contains it.
+func getAPIKeyFromHeader(ctx *fiber.Ctx) (string, bool) {
+	token, ok := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok || !models.IsAPIKey(token) {
+		return "", false
+	}
+	return token, true
+}
+
+// IsAPIKeyRequest checks that request was already authenticated by namespace API key.
+func IsAPIKeyRequest(ctx *fiber.Ctx) bool {
+	_, ok := ctx.Locals(apiKeyContextKey).(*models.APIKey)
+	return ok
+}
+
+// GetAPIKeyFromContext returns API key, which authenticated the request, from the context.
+func GetAPIKeyFromContext(ctx context.Context) (*models.APIKey, error) {
+	apiKey, ok := ctx.Value(apiKeyContextKey).(*models.APIKey)
+	if !ok {
+		return nil, eris.New("error getting api key from context")
+	}
+	return apiKey, nil
+}
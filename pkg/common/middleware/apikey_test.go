@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+func newAPIKeyTestApp(apiKeyRepository repositories.APIKeyRepositoryProvider) *fiber.App {
+	app := fiber.New()
+	app.Use(func(ctx *fiber.Ctx) error {
+		ctx.Locals(namespaceContextKey, &models.Namespace{ID: 1, Code: "default"})
+		return ctx.Next()
+	})
+	app.Use(NewAPIKeyMiddleware(apiKeyRepository))
+
+	handler := func(ctx *fiber.Ctx) error {
+		if IsAPIKeyRequest(ctx) {
+			return ctx.SendStatus(http.StatusOK)
+		}
+		return ctx.SendStatus(http.StatusUnauthorized)
+	}
+	app.Get("/api/2.0/mlflow/runs/get", handler)
+	app.Post("/api/2.0/mlflow/runs/create", handler)
+	app.Post("/api/2.0/mlflow/runs/search", handler)
+	app.Post("/api/2.0/mlflow/api-keys/create", handler)
+	app.Get("/admin/namespaces", handler)
+	return app
+}
+
+func TestAPIKeyMiddleware_Ok(t *testing.T) {
+	recentlyUsedAt := sql.NullTime{Time: time.Now().UTC().Add(-time.Second), Valid: true}
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		apiKey         models.APIKey
+		expectedStatus int
+		expectedUpdate bool
+	}{
+		{
+			name:           "WriteKeyWithWriteRequest",
+			method:         http.MethodPost,
+			path:           "/api/2.0/mlflow/runs/create",
+			apiKey:         models.APIKey{NamespaceID: 1, AccessLevel: models.APIKeyAccessLevelWrite},
+			expectedStatus: http.StatusOK,
+			expectedUpdate: true,
+		},
+		{
+			name:           "ReadKeyWithReadRequest",
+			method:         http.MethodGet,
+			path:           "/api/2.0/mlflow/runs/get",
+			apiKey:         models.APIKey{NamespaceID: 1, AccessLevel: models.APIKeyAccessLevelRead},
+			expectedStatus: http.StatusOK,
+			expectedUpdate: true,
+		},
+		{
+			name:           "ReadKeyWithReadOnlyPostRequest",
+			method:         http.MethodPost,
+			path:           "/api/2.0/mlflow/runs/search",
+			apiKey:         models.APIKey{NamespaceID: 1, AccessLevel: models.APIKeyAccessLevelRead},
+			expectedStatus: http.StatusOK,
+			expectedUpdate: true,
+		},
+		{
+			name:   "RecentlyUsedKey",
+			method: http.MethodGet,
+			path:   "/api/2.0/mlflow/runs/get",
+			apiKey: models.APIKey{
+				NamespaceID: 1, AccessLevel: models.APIKeyAccessLevelRead, LastUsedAt: recentlyUsedAt,
+			},
+			expectedStatus: http.StatusOK,
+			expectedUpdate: false,
+		},
+		{
+			name:           "ReadKeyWithWriteRequest",
+			method:         http.MethodPost,
+			path:           "/api/2.0/mlflow/runs/create",
+			apiKey:         models.APIKey{NamespaceID: 1, AccessLevel: models.APIKeyAccessLevelRead},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "KeyManagementRequest",
+			method:         http.MethodPost,
+			path:           "/api/2.0/mlflow/api-keys/create",
+			apiKey:         models.APIKey{NamespaceID: 1, AccessLevel: models.APIKeyAccessLevelWrite},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "KeyOfOtherNamespace",
+			method:         http.MethodGet,
+			path:           "/api/2.0/mlflow/runs/get",
+			apiKey:         models.APIKey{NamespaceID: 2, AccessLevel: models.APIKeyAccessLevelWrite},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKey := tt.apiKey
+			apiKeyRepository := repositories.NewMockAPIKeyRepositoryProvider(t)
+			apiKeyRepository.On(
+				"GetByHash", mock.Anything, models.HashAPIKey("ftml_key"),
+			).Return(&apiKey, nil)
+			if tt.expectedUpdate {
+				apiKeyRepository.On(
+					"UpdateLastUsedAt", mock.Anything, &apiKey, mock.AnythingOfType("time.Time"),
+				).Return(nil)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer ftml_key")
+			resp, err := newAPIKeyTestApp(apiKeyRepository).Test(req)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestAPIKeyMiddleware_NotAPIKeyRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+	}{
+		{
+			name: "NoAuthorizationHeader",
+			path: "/api/2.0/mlflow/runs/get",
+		},
+		{
+			name:          "OtherBearerToken",
+			path:          "/api/2.0/mlflow/runs/get",
+			authorization: "Bearer token",
+		},
+		{
+			name:          "NotApiRequest",
+			path:          "/admin/namespaces",
+			authorization: "Bearer ftml_key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			resp, err := newAPIKeyTestApp(repositories.NewMockAPIKeyRepositoryProvider(t)).Test(req)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rotisserie/eris"
@@ -32,7 +34,7 @@ func NewBasicAuthMiddleware(userPermissions *models.UserPermissions) fiber.Handl
 // Handle handles OIDC middleware logic.
 func (m BasicAuthMiddleware) Handle() fiber.Handler {
 	return func(ctx *fiber.Ctx) (err error) {
-		authToken := m.userPermissions.ValidateAuthToken(ctx.Get(fiber.HeaderAuthorization)[6:])
+		authToken := m.userPermissions.ValidateAuthToken(getBasicAuthToken(ctx))
 		switch {
 		case AdminPrefixRegexp.MatchString(ctx.Path()):
 			return m.handleAdminResourceRequest(ctx, authToken)
@@ -63,7 +65,9 @@ func (m BasicAuthMiddleware) handleChooserResourceRequest(ctx *fiber.Ctx, authTo
 	if authToken == nil {
 		return ctx.Redirect("/errors/not-found", http.StatusMovedPermanently)
 	}
-	if authToken.HasAdminAccess() {
+	// information about current user and landing page, which redirects user to the accessible namespace,
+	// are available regardless of access to requested namespace.
+	if authToken.HasAdminAccess() || WhoAmIPathRegexp.MatchString(ctx.Path()) || IsLandingRequest(ctx, namespace) {
 		ctx.Locals(basicAuthTokenContextKey, authToken)
 		return ctx.Next()
 	}
@@ -81,23 +85,28 @@ func (m BasicAuthMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx, auth
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("checking access permission to %s namespace", namespace.Code)
+	if IsPublicReadRequest(ctx) || IsAPIKeyRequest(ctx) {
+		return ctx.Next()
+	}
 	if authToken == nil {
-		return ctx.Status(
-			http.StatusNotFound,
-		).JSON(
-			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
-		)
+		return sendNamespaceNotFoundError(ctx, namespace.Code)
 	}
 	if !authToken.HasUserAccess(namespace.Code) && !authToken.HasAdminAccess() {
-		return ctx.Status(
-			http.StatusNotFound,
-		).JSON(
-			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
-		)
+		return sendNamespaceNotFoundError(ctx, namespace.Code)
 	}
 	return ctx.Next()
 }
 
+// getBasicAuthToken returns Basic Auth token from the `Authorization` header of the request.
+func getBasicAuthToken(ctx *fiber.Ctx) string {
+	return strings.TrimPrefix(ctx.Get(fiber.HeaderAuthorization), "Basic ")
+}
+
+// hasBasicAuthToken checks that request is authenticated by the provided Basic Auth token.
+func hasBasicAuthToken(ctx *fiber.Ctx, token string) bool {
+	return subtle.ConstantTimeCompare([]byte(getBasicAuthToken(ctx)), []byte(token)) == 1
+}
+
 // GetBasicAuthTokenFromContext returns Basic Auth Token from the context.
 func GetBasicAuthTokenFromContext(ctx context.Context) (*models.BasicAuthToken, error) {
 	authToken, ok := ctx.Value(basicAuthTokenContextKey).(*models.BasicAuthToken)
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// NewBodyLimitMiddleware creates new middleware for the server, which streams request bodies.
+// Bodies are read into memory, so handlers get whole bodies as usual, and bodies larger than maxSize bytes
+// are rejected. Requests, for which stream returns true, keep streamed bodies unless they are compressed,
+// so handlers could pass large bodies further without holding them in memory.
+func NewBodyLimitMiddleware(maxSize int, stream func(ctx *fiber.Ctx) bool) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		body := ctx.Context().RequestBodyStream()
+		if body == nil {
+			return ctx.Next()
+		}
+
+		if stream(ctx) && ctx.Get(fiber.HeaderContentEncoding) == "" {
+			// handler could fail without reading the whole body, and the rest of it would be taken
+			// as the next request, so the connection isn't reused in that case.
+			err := ctx.Next()
+			if err != nil || ctx.Response().StatusCode() >= fiber.StatusBadRequest {
+				ctx.Response().SetConnectionClose()
+			}
+			return err
+		}
+
+		// one extra byte is read to detect, that the limit has been exceeded.
+		data, err := io.ReadAll(io.LimitReader(body, int64(maxSize)+1))
+		if err != nil {
+			ctx.Response().SetConnectionClose()
+			return api.NewBadRequestError("unable to read request body: %s", err)
+		}
+		if len(data) > maxSize {
+			ctx.Response().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+		ctx.Request().SetBody(data)
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBodyLimitTestApp(maxSize int) *fiber.App {
+	app := fiber.New(fiber.Config{
+		BodyLimit:         maxSize,
+		StreamRequestBody: true,
+	})
+	app.Use(NewBodyLimitMiddleware(maxSize, func(ctx *fiber.Ctx) bool {
+		return ctx.Path() == "/stream"
+	}))
+	app.Post("/echo", func(ctx *fiber.Ctx) error {
+		return ctx.Send(ctx.Body())
+	})
+	app.Post("/stream", func(ctx *fiber.Ctx) error {
+		body := ctx.Context().RequestBodyStream()
+		if body == nil {
+			return ctx.SendString("<not streamed>")
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		return ctx.Send(data)
+	})
+	return app
+}
+
+func TestBodyLimitMiddleware_Ok(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{
+			name: "BufferedBody",
+			path: "/echo",
+			body: "0123456789",
+		},
+		{
+			name: "StreamedBodyOverTheLimit",
+			path: "/stream",
+			body: strings.Repeat("0123456789", 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newBodyLimitTestApp(10)
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			resp, err := app.Test(req)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			data, err := io.ReadAll(resp.Body)
+			require.Nil(t, err)
+			assert.Equal(t, tt.body, string(data))
+		})
+	}
+}
+
+func TestBodyLimitMiddleware_Error(t *testing.T) {
+	app := newBodyLimitTestApp(10)
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("01234567890"))
+	resp, err := app.Test(req)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.True(t, resp.Close)
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// RedactedValue replaces values of sensitive fields in logged bodies.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveFieldNames are parts of field names, which values are redacted in logged bodies.
+var sensitiveFieldNames = []string{"password", "token", "secret", "authorization", "api_key", "apikey", "credential"}
+
+// NewBodyLoggerMiddleware creates new middleware, which logs request and response bodies of the requests
+// to paths starting with any of the prefixes. Values of sensitive fields of JSON and form bodies are redacted
+// and each body is truncated to maxSize bytes. It is meant only for debugging, so it's disabled by default.
+func NewBodyLoggerMiddleware(prefixes []string, maxSize int) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !hasAnyPrefix(ctx.Path(), prefixes) {
+			return ctx.Next()
+		}
+
+		requestBody := formatBody(ctx.Get(fiber.HeaderContentType), ctx.Body(), maxSize)
+		if err := ctx.Next(); err != nil {
+			// errors are converted into responses by the error handler, so it's called here, as logger does.
+			if err := ctx.App().ErrorHandler(ctx, err); err != nil {
+				_ = ctx.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		responseBody := "<stream>"
+		if !ctx.Context().IsBodyStream() {
+			responseBody = formatBody(
+				string(ctx.Response().Header.ContentType()), ctx.Response().Body(), maxSize,
+			)
+		}
+
+		log.WithFields(log.Fields{
+			"method":        ctx.Method(),
+			"path":          ctx.Path(),
+			"status":        ctx.Response().StatusCode(),
+			"request_body":  requestBody,
+			"response_body": responseBody,
+		}).Info("request and response bodies")
+		return nil
+	}
+}
+
+// hasAnyPrefix checks if path starts with any of the prefixes.
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatBody redacts sensitive fields of the body and truncates it to maxSize bytes. Only JSON, form and
+// text bodies are logged, for the other ones and for malformed JSON just their size is logged.
+func formatBody(contentType string, body []byte, maxSize int) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var formatted string
+	switch {
+	case mediaType == fiber.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json"):
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Sprintf("<%d bytes of malformed JSON>", len(body))
+		}
+		data, err := json.Marshal(redactJSON(value))
+		if err != nil {
+			return fmt.Sprintf("<%d bytes of %s>", len(body), mediaType)
+		}
+		formatted = string(data)
+	case mediaType == fiber.MIMEApplicationForm:
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("<%d bytes of malformed form>", len(body))
+		}
+		for key := range values {
+			if isSensitiveField(key) {
+				values[key] = []string{RedactedValue}
+			}
+		}
+		formatted = values.Encode()
+	case strings.HasPrefix(mediaType, "text/"):
+		formatted = string(body)
+	default:
+		return fmt.Sprintf("<%d bytes of %s>", len(body), mediaType)
+	}
+	return truncateBody(formatted, maxSize)
+}
+
+// redactJSON replaces values of sensitive fields of decoded JSON value. Besides the sensitive fields
+// themselves, values of key-value objects with sensitive key, like run params and tags, are redacted too.
+func redactJSON(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, item := range typed {
+			if isSensitiveField(key) {
+				typed[key] = RedactedValue
+			} else {
+				typed[key] = redactJSON(item)
+			}
+		}
+		if key, ok := typed["key"].(string); ok && isSensitiveField(key) {
+			if _, ok := typed["value"]; ok {
+				typed["value"] = RedactedValue
+			}
+		}
+	case []any:
+		for i, item := range typed {
+			typed[i] = redactJSON(item)
+		}
+	}
+	return value
+}
+
+// isSensitiveField checks if the field name contains any of the sensitive names.
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitiveName := range sensitiveFieldNames {
+		if strings.Contains(name, sensitiveName) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateBody truncates body to maxSize bytes, not splitting multibyte characters.
+func truncateBody(body string, maxSize int) string {
+	if len(body) <= maxSize {
+		return body
+	}
+	size := maxSize
+	for size > 0 && !utf8.RuneStart(body[size]) {
+		size--
+	}
+	return fmt.Sprintf("%s...(%d more bytes)", body[:size], len(body)-size)
+}
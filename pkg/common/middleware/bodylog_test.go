@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBodyLoggerTestApp(maxSize int) *fiber.App {
+	app := fiber.New()
+	app.Use(NewBodyLoggerMiddleware([]string{"/api/2.0/mlflow/runs"}, maxSize))
+	echoHandler := func(ctx *fiber.Ctx) error {
+		ctx.Set(fiber.HeaderContentType, ctx.Get(fiber.HeaderContentType))
+		return ctx.Send(ctx.Body())
+	}
+	app.Post("/api/2.0/mlflow/runs/log-batch", echoHandler)
+	app.Post("/api/2.0/mlflow/runs/create", func(ctx *fiber.Ctx) error {
+		return fiber.NewError(http.StatusBadRequest, "invalid run")
+	})
+	app.Post("/api/2.0/mlflow/experiments/create", echoHandler)
+	return app
+}
+
+func TestBodyLoggerMiddleware_Ok(t *testing.T) {
+	redactedJSON := `{"auth":{"Password":"[REDACTED]","api_key":"[REDACTED]"},` +
+		`"params":[{"key":"db_token","value":"[REDACTED]"}],"run_id":"id"}`
+	tests := []struct {
+		name         string
+		maxSize      int
+		path         string
+		contentType  string
+		body         string
+		requestBody  string
+		responseBody string
+		status       int
+	}{
+		{
+			name:         "JSONWithSensitiveFields",
+			maxSize:      1024,
+			path:         "/api/2.0/mlflow/runs/log-batch",
+			contentType:  fiber.MIMEApplicationJSON,
+			body:         `{"run_id":"id","auth":{"Password":"p","api_key":"k"},"params":[{"key":"db_token","value":"t"}]}`,
+			requestBody:  redactedJSON,
+			responseBody: redactedJSON,
+			status:       http.StatusOK,
+		},
+		{
+			name:         "FormWithSensitiveFields",
+			maxSize:      1024,
+			path:         "/api/2.0/mlflow/runs/log-batch",
+			contentType:  fiber.MIMEApplicationForm,
+			body:         "name=run&secret=s",
+			requestBody:  "name=run&secret=%5BREDACTED%5D",
+			responseBody: "name=run&secret=%5BREDACTED%5D",
+			status:       http.StatusOK,
+		},
+		{
+			name:         "TruncatedBody",
+			maxSize:      10,
+			path:         "/api/2.0/mlflow/runs/log-batch",
+			contentType:  fiber.MIMETextPlain,
+			body:         strings.Repeat("a", 15),
+			requestBody:  "aaaaaaaaaa...(5 more bytes)",
+			responseBody: "aaaaaaaaaa...(5 more bytes)",
+			status:       http.StatusOK,
+		},
+		{
+			name:         "MalformedJSON",
+			maxSize:      1024,
+			path:         "/api/2.0/mlflow/runs/log-batch",
+			contentType:  fiber.MIMEApplicationJSON,
+			body:         `{"password":`,
+			requestBody:  "<12 bytes of malformed JSON>",
+			responseBody: "<12 bytes of malformed JSON>",
+			status:       http.StatusOK,
+		},
+		{
+			name:         "BinaryBody",
+			maxSize:      1024,
+			path:         "/api/2.0/mlflow/runs/log-batch",
+			contentType:  fiber.MIMEOctetStream,
+			body:         "binary",
+			requestBody:  "<6 bytes of application/octet-stream>",
+			responseBody: "<6 bytes of application/octet-stream>",
+			status:       http.StatusOK,
+		},
+		{
+			name:         "ErrorResponse",
+			maxSize:      1024,
+			path:         "/api/2.0/mlflow/runs/create",
+			contentType:  fiber.MIMEApplicationJSON,
+			body:         `{"name":"run"}`,
+			requestBody:  `{"name":"run"}`,
+			responseBody: "invalid run",
+			status:       http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := test.NewGlobal()
+			defer hook.Reset()
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, tt.contentType)
+			resp, err := newBodyLoggerTestApp(tt.maxSize).Test(req)
+			require.Nil(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			require.Len(t, hook.AllEntries(), 1)
+			entry := hook.LastEntry()
+			assert.Equal(t, log.InfoLevel, entry.Level)
+			assert.Equal(t, tt.path, entry.Data["path"])
+			assert.Equal(t, tt.status, entry.Data["status"])
+			assert.Equal(t, tt.requestBody, entry.Data["request_body"])
+			assert.Equal(t, tt.responseBody, entry.Data["response_body"])
+		})
+	}
+}
+
+func TestBodyLoggerMiddleware_NotEnabledRoute(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	req := httptest.NewRequest(
+		http.MethodPost, "/api/2.0/mlflow/experiments/create", strings.NewReader(`{"name":"experiment"}`),
+	)
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := newBodyLoggerTestApp(1024).Test(req)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, hook.AllEntries())
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+)
+
+const (
+	clientIPContextKey = "client_ip"
+	headerXRealIP      = "X-Real-IP"
+)
+
+// clientIPMiddleware represents middleware, which resolves address of the client.
+type clientIPMiddleware struct {
+	trustedProxies []*net.IPNet
+}
+
+// NewClientIPMiddleware creates new middleware, which resolves address of the client and stores it
+// in the request context, so it could be used by other middlewares, see GetClientIP.
+// Address is taken from `X-Forwarded-For` or `X-Real-IP` headers only for requests coming from trusted proxies,
+// otherwise the headers could be spoofed by the client itself and are ignored.
+func NewClientIPMiddleware(trustedProxies []string) (fiber.Handler, error) {
+	m := clientIPMiddleware{}
+	for _, item := range trustedProxies {
+		network, err := parseIPNetwork(item)
+		if err != nil {
+			return nil, err
+		}
+		m.trustedProxies = append(m.trustedProxies, network)
+	}
+	return m.handle, nil
+}
+
+// handle resolves address of the client.
+func (m clientIPMiddleware) handle(ctx *fiber.Ctx) error {
+	ctx.Locals(clientIPContextKey, m.getClientIP(ctx).String())
+	return ctx.Next()
+}
+
+// getClientIP returns address of the client. Addresses of `X-Forwarded-For` header are walked from the closest
+// one, and the first address, which doesn't belong to trusted proxies, is the client one, because only
+// the addresses added by trusted proxies could be relied on. `X-Real-IP` header is used,
+// when proxy doesn't provide `X-Forwarded-For` one. Remote address of the connection is returned,
+// when one of the walked addresses couldn't be parsed, because the rest of the chain couldn't be relied on.
+func (m clientIPMiddleware) getClientIP(ctx *fiber.Ctx) net.IP {
+	remoteIP := ctx.Context().RemoteIP()
+	if !containsIP(m.trustedProxies, remoteIP) {
+		return remoteIP
+	}
+
+	var forwardedFor []string
+	for _, header := range ctx.Request().Header.PeekAll(fiber.HeaderXForwardedFor) {
+		for _, item := range strings.Split(string(header), ",") {
+			forwardedFor = append(forwardedFor, strings.TrimSpace(item))
+		}
+	}
+	if len(forwardedFor) == 0 {
+		if realIP := ctx.Get(headerXRealIP); realIP != "" {
+			forwardedFor = append(forwardedFor, strings.TrimSpace(realIP))
+		}
+	}
+	ip := remoteIP
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		ip = parseForwardedIP(forwardedFor[i])
+		if ip == nil {
+			return remoteIP
+		}
+		if !containsIP(m.trustedProxies, ip) {
+			return ip
+		}
+	}
+	// all the addresses belong to trusted proxies, so the farthest one is the client.
+	return ip
+}
+
+// parseForwardedIP parses address from forwarded headers. Some proxies add port of the client as well,
+// so `ip:port` and `[ipv6]:port` forms are supported too.
+func parseForwardedIP(item string) net.IP {
+	if ip := net.ParseIP(item); ip != nil {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(item); err == nil {
+		return net.ParseIP(host)
+	}
+	return nil
+}
+
+// GetClientIP returns address of the client resolved by client IP middleware. Remote address of the connection
+// is returned, when the middleware isn't attached.
+func GetClientIP(ctx *fiber.Ctx) net.IP {
+	if ip, ok := ctx.Locals(clientIPContextKey).(string); ok {
+		return net.ParseIP(ip)
+	}
+	return ctx.Context().RemoteIP()
+}
+
+// parseIPNetwork parses either IP address or CIDR. Single address is handled as a network of this address only.
+func parseIPNetwork(item string) (*net.IPNet, error) {
+	if _, network, err := net.ParseCIDR(item); err == nil {
+		return network, nil
+	}
+	ip := net.ParseIP(item)
+	if ip == nil {
+		return nil, eris.Errorf("incorrect IP address or CIDR '%s'", item)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+}
+
+// containsIP checks that one of the networks contains the address.
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
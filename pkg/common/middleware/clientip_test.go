@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test requests always come from 0.0.0.0 address.
+func newClientIPTestApp(t *testing.T, trustedProxies []string) *fiber.App {
+	app := fiber.New()
+	clientIP, err := NewClientIPMiddleware(trustedProxies)
+	require.Nil(t, err)
+	app.Use(clientIP)
+	app.Get("/ip", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(GetClientIP(ctx).String())
+	})
+	return app
+}
+
+func TestClientIPMiddleware_Ok(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		headers        map[string]string
+		expectedIP     string
+	}{
+		{
+			name:       "NoProxy",
+			expectedIP: "0.0.0.0",
+		},
+		{
+			name:           "ForwardedForFromUntrustedProxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			headers: map[string]string{
+				"X-Forwarded-For": "192.168.1.10",
+				"X-Real-IP":       "192.168.1.11",
+			},
+			expectedIP: "0.0.0.0",
+		},
+		{
+			name:           "ForwardedForFromTrustedProxy",
+			trustedProxies: []string{"0.0.0.0"},
+			headers: map[string]string{
+				"X-Forwarded-For": "192.168.1.10",
+			},
+			expectedIP: "192.168.1.10",
+		},
+		{
+			name:           "ForwardedForFromChainOfTrustedProxies",
+			trustedProxies: []string{"0.0.0.0", "10.0.0.0/8"},
+			headers: map[string]string{
+				"X-Forwarded-For": "192.168.1.10, 10.1.1.1",
+			},
+			expectedIP: "192.168.1.10",
+		},
+		{
+			name:           "SpoofedForwardedForBehindTrustedProxy",
+			trustedProxies: []string{"0.0.0.0"},
+			headers: map[string]string{
+				"X-Forwarded-For": "192.168.1.10, 11.0.0.1",
+			},
+			expectedIP: "11.0.0.1",
+		},
+		{
+			name:           "ForwardedForHasPrecedenceOverRealIP",
+			trustedProxies: []string{"0.0.0.0"},
+			headers: map[string]string{
+				"X-Forwarded-For": "192.168.1.10",
+				"X-Real-IP":       "192.168.1.11",
+			},
+			expectedIP: "192.168.1.10",
+		},
+		{
+			name:           "RealIPFromTrustedProxy",
+			trustedProxies: []string{"0.0.0.0"},
+			headers: map[string]string{
+				"X-Real-IP": "2001:db8::1",
+			},
+			expectedIP: "2001:db8::1",
+		},
+		{
+			name:           "ForwardedForWithPorts",
+			trustedProxies: []string{"0.0.0.0", "10.0.0.0/8"},
+			headers: map[string]string{
+				"X-Forwarded-For": "[2001:db8::1]:51234, 192.168.1.10:51234, 10.1.1.1:8080",
+			},
+			expectedIP: "192.168.1.10",
+		},
+		{
+			name:           "IncorrectForwardedForFallsBackToRemoteAddress",
+			trustedProxies: []string{"0.0.0.0", "192.168.1.0/24"},
+			headers: map[string]string{
+				"X-Forwarded-For": "unknown, 192.168.1.10",
+			},
+			expectedIP: "0.0.0.0",
+		},
+		{
+			name:           "TrustedProxyWithoutHeaders",
+			trustedProxies: []string{"0.0.0.0"},
+			expectedIP:     "0.0.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			resp, err := newClientIPTestApp(t, tt.trustedProxies).Test(req, -1)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expectedIP, string(body))
+		})
+	}
+}
+
+func TestClientIPMiddleware_Error(t *testing.T) {
+	_, err := NewClientIPMiddleware([]string{"proxy.local"})
+	assert.EqualError(t, err, "incorrect IP address or CIDR 'proxy.local'")
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	skipCompressionContextKey = "skip_compression"
+)
+
+// NewCompressMiddleware creates new middleware which compresses responses, if client accepts compressed content.
+// Handlers could opt out of compression for the current response by calling SkipCompression.
+func NewCompressMiddleware() fiber.Handler {
+	compressor := fasthttp.CompressHandlerBrotliLevel(
+		func(*fasthttp.RequestCtx) {},
+		fasthttp.CompressBrotliDefaultCompression,
+		fasthttp.CompressDefaultCompression,
+	)
+	return func(ctx *fiber.Ctx) error {
+		if err := ctx.Next(); err != nil {
+			return err
+		}
+		if skip, ok := ctx.Locals(skipCompressionContextKey).(bool); ok && skip {
+			return nil
+		}
+		compressor(ctx.Context())
+		return nil
+	}
+}
+
+// SkipCompression disables compression of the current response.
+func SkipCompression(ctx *fiber.Ctx) {
+	ctx.Locals(skipCompressionContextKey, true)
+}
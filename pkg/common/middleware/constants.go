@@ -7,4 +7,12 @@ var (
 	AdminPrefixRegexp     = regexp.MustCompile(`^/admin`)
 	ChooserPrefixRegexp   = regexp.MustCompile(`^/chooser|^/$`)
 	MlflowAimPrefixRegexp = regexp.MustCompile(`^/aim/api|^/ajax-api/2.0/mlflow|^/api/2.0/mlflow`)
+	WhoAmIPathRegexp      = regexp.MustCompile(`^/chooser/whoami/?$`)
+	ArtifactsPrefixRegexp = regexp.MustCompile(`^(/ajax-api|/api)/2\.0/mlflow/artifacts`)
+	APIKeysPathRegexp     = regexp.MustCompile(`^(/ajax-api|/api)/2\.0/mlflow/api-keys`)
+	// ReadOnlyPathRegexp matches Aim or Mlflow endpoints, which only read data despite using POST method.
+	ReadOnlyPathRegexp = regexp.MustCompile(
+		`^(/ajax-api|/api)/2\.0/mlflow/(runs/search|experiments/search|metrics/(get-histories|get-best))/?$|` +
+			`^/aim/api/runs/(search/metric(/align|/aggregate|/join|/batch)?|[^/]+/metric/get-batch)/?$`,
+	)
 )
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// NewDecompressMiddleware creates new middleware, which decompresses request bodies encoded by gzip or deflate,
+// so handlers transparently get plain bodies. Body is decompressed once and `Content-Encoding` header is removed,
+// so it isn't decompressed again by fiber. Decompressed body larger than maxSize bytes is rejected,
+// to protect from decompression bombs.
+func NewDecompressMiddleware(maxSize int) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		contentEncoding := ctx.Get(fiber.HeaderContentEncoding)
+		if contentEncoding == "" {
+			return ctx.Next()
+		}
+
+		// encodings are listed in order in which they have been applied, so they are removed in reverse order.
+		body := ctx.Request().Body()
+		encodings := strings.Split(contentEncoding, ",")
+		for i := len(encodings) - 1; i >= 0; i-- {
+			encoding := strings.ToLower(strings.TrimSpace(encodings[i]))
+			var err error
+			switch encoding {
+			case "", "identity":
+				continue
+			case "gzip", "x-gzip":
+				body, err = decompressBody(body, maxSize, newGzipReader)
+			case "deflate":
+				body, err = decompressBody(body, maxSize, newDeflateReader)
+			default:
+				return api.NewBadRequestError("unsupported request content encoding '%s'", encoding)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		ctx.Request().SetBody(body)
+		ctx.Request().Header.Del(fiber.HeaderContentEncoding)
+		return ctx.Next()
+	}
+}
+
+// decompressBody decompresses body by the reader created by newReader, limiting its size to maxSize bytes.
+func decompressBody(
+	body []byte, maxSize int, newReader func(body []byte) (io.ReadCloser, error),
+) ([]byte, error) {
+	reader, err := newReader(body)
+	if err != nil {
+		return nil, api.NewBadRequestError("unable to decompress request body: %s", err)
+	}
+	//nolint:errcheck
+	defer reader.Close()
+
+	// one extra byte is read to detect, that the limit has been exceeded.
+	decompressed, err := io.ReadAll(io.LimitReader(reader, int64(maxSize)+1))
+	if err != nil {
+		return nil, api.NewBadRequestError("unable to decompress request body: %s", err)
+	}
+	if len(decompressed) > maxSize {
+		return nil, api.NewBadRequestError("decompressed request body exceeds the limit of %d bytes", maxSize)
+	}
+	return decompressed, nil
+}
+
+// newGzipReader creates reader of gzip encoded body.
+func newGzipReader(body []byte) (io.ReadCloser, error) {
+	return gzip.NewReader(bytes.NewReader(body))
+}
+
+// newDeflateReader creates reader of deflate encoded body. HTTP deflate encoding is zlib format,
+// but some clients send raw deflate stream, so it's accepted as well.
+func newDeflateReader(body []byte) (io.ReadCloser, error) {
+	if reader, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+		return reader, nil
+	}
+	return flate.NewReader(bytes.NewReader(body)), nil
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func newDecompressTestApp(maxSize int) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
+			var e *api.ErrorResponse
+			if errors.As(err, &e) {
+				return ctx.Status(e.StatusCode).JSON(e)
+			}
+			return fiber.DefaultErrorHandler(ctx, err)
+		},
+	})
+	app.Use(NewDecompressMiddleware(maxSize))
+	app.Post("/echo", func(ctx *fiber.Ctx) error {
+		return ctx.Send(ctx.Body())
+	})
+	return app
+}
+
+func compress(t *testing.T, data string, newWriter func(writer io.Writer) io.WriteCloser) []byte {
+	buffer := bytes.Buffer{}
+	writer := newWriter(&buffer)
+	_, err := writer.Write([]byte(data))
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestDecompressMiddleware_Ok(t *testing.T) {
+	body := `{"run_id":"id","metrics":[{"key":"loss","value":0.1}]}`
+	gzipped := compress(t, body, func(writer io.Writer) io.WriteCloser { return gzip.NewWriter(writer) })
+	tests := []struct {
+		name            string
+		contentEncoding string
+		body            []byte
+	}{
+		{
+			name: "NotCompressed",
+			body: []byte(body),
+		},
+		{
+			name:            "Identity",
+			contentEncoding: "identity",
+			body:            []byte(body),
+		},
+		{
+			name:            "Gzip",
+			contentEncoding: "gzip",
+			body:            gzipped,
+		},
+		{
+			name:            "Zlib",
+			contentEncoding: "Deflate",
+			body:            compress(t, body, func(writer io.Writer) io.WriteCloser { return zlib.NewWriter(writer) }),
+		},
+		{
+			name:            "RawDeflate",
+			contentEncoding: "deflate",
+			body: compress(t, body, func(writer io.Writer) io.WriteCloser {
+				flateWriter, err := flate.NewWriter(writer, flate.DefaultCompression)
+				require.Nil(t, err)
+				return flateWriter
+			}),
+		},
+		{
+			name:            "SeveralEncodings",
+			contentEncoding: "gzip, deflate",
+			body: compress(t, string(gzipped), func(writer io.Writer) io.WriteCloser {
+				return zlib.NewWriter(writer)
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(tt.body))
+			if tt.contentEncoding != "" {
+				req.Header.Set(fiber.HeaderContentEncoding, tt.contentEncoding)
+			}
+			resp, err := newDecompressTestApp(1024).Test(req)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			data, err := io.ReadAll(resp.Body)
+			require.Nil(t, err)
+			assert.Equal(t, body, string(data))
+		})
+	}
+}
+
+func TestDecompressMiddleware_Error(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentEncoding string
+		body            []byte
+		error           string
+	}{
+		{
+			name:            "ExceededLimit",
+			contentEncoding: "gzip",
+			body: compress(t, strings.Repeat("a", 1025), func(writer io.Writer) io.WriteCloser {
+				return gzip.NewWriter(writer)
+			}),
+			error: "decompressed request body exceeds the limit of 1024 bytes",
+		},
+		{
+			name:            "MalformedGzip",
+			contentEncoding: "gzip",
+			body:            []byte("not a gzip stream"),
+			error:           "unable to decompress request body: gzip: invalid header",
+		},
+		{
+			name:            "UnsupportedEncoding",
+			contentEncoding: "br",
+			body:            []byte("body"),
+			error:           "unsupported request content encoding 'br'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/echo", bytes.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentEncoding, tt.contentEncoding)
+			resp, err := newDecompressTestApp(1024).Test(req)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			var errResp api.ErrorResponse
+			require.Nil(t, json.NewDecoder(resp.Body).Decode(&errResp))
+			assert.Equal(t, tt.error, errResp.Message)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// ResponseHeadersPluginName is a name of the middleware plugin, which adds static headers to every response.
+const ResponseHeadersPluginName = "response-headers"
+
+func init() {
+	RegisterPlugin(ResponseHeadersPluginName, func(config *config.Config) (fiber.Handler, error) {
+		return NewResponseHeadersMiddleware(config.GetResponseHeaders()), nil
+	})
+}
+
+// NewResponseHeadersMiddleware creates new middleware, which adds static headers to every response.
+// Headers are set before the request is handled, so handlers are still able to override them.
+func NewResponseHeadersMiddleware(headers map[string]string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		for name, value := range headers {
+			ctx.Set(name, value)
+		}
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// IPAllowlistPluginName is a name of the middleware plugin, which allows only requests from the listed addresses.
+const IPAllowlistPluginName = "ip-allowlist"
+
+func init() {
+	RegisterPlugin(IPAllowlistPluginName, func(config *config.Config) (fiber.Handler, error) {
+		return NewIPAllowlistMiddleware(config.IPAllowlist)
+	})
+}
+
+// NewIPAllowlistMiddleware creates new middleware, which rejects requests with `403 Forbidden`,
+// unless the client address matches one of the allowed IP addresses or CIDRs. It's kept for the deployments,
+// which enabled `ip-allowlist` plugin, so unlike IP filter middleware, empty allowlist rejects all the requests.
+func NewIPAllowlistMiddleware(allowlist []string) (fiber.Handler, error) {
+	if len(allowlist) == 0 {
+		return NewIPFilterMiddleware(nil, []string{"0.0.0.0/0", "::/0"})
+	}
+	return NewIPFilterMiddleware(allowlist, nil)
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func newIPAllowlistTestApp(t *testing.T, allowlist []string) *fiber.App {
+	// test requests always come from 0.0.0.0 address, which is trusted, so client address is taken from the header
+	// and tests could simulate requests from different addresses.
+	app := fiber.New()
+	clientIP, err := NewClientIPMiddleware([]string{"0.0.0.0"})
+	require.Nil(t, err)
+	app.Use(clientIP)
+	middlewares, err := NewPluginMiddlewares(&config.Config{
+		MiddlewarePlugins: []string{"before-namespace:ip-allowlist"},
+		IPAllowlist:       allowlist,
+	}, PluginPositionBeforeNamespace)
+	require.Nil(t, err)
+	require.Len(t, middlewares, 1)
+	app.Use(middlewares[0])
+	app.Get("/health", func(ctx *fiber.Ctx) error {
+		return ctx.SendString("OK")
+	})
+	return app
+}
+
+func TestIPAllowlistMiddleware_Ok(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		address   string
+	}{
+		{
+			name:      "SingleAddress",
+			allowlist: []string{"192.168.1.10"},
+			address:   "192.168.1.10",
+		},
+		{
+			name:      "AddressInsideCIDR",
+			allowlist: []string{"192.168.1.10", "10.0.0.0/8"},
+			address:   "10.20.30.40",
+		},
+		{
+			name:      "IPv6AddressInsideCIDR",
+			allowlist: []string{"2001:db8::/32"},
+			address:   "2001:db8::1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, tt.address)
+			resp, err := newIPAllowlistTestApp(t, tt.allowlist).Test(req, -1)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestIPAllowlistMiddleware_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		address   string
+		error     *api.ErrorResponse
+	}{
+		{
+			name:      "AddressOutsideCIDR",
+			allowlist: []string{"192.168.1.10", "10.0.0.0/8"},
+			address:   "11.0.0.1",
+			error:     api.NewPermissionDeniedError("access from address '11.0.0.1' is not allowed"),
+		},
+		{
+			name:      "EmptyAllowlist",
+			allowlist: nil,
+			address:   "192.168.1.10",
+			error:     api.NewPermissionDeniedError("access from address '192.168.1.10' is not allowed"),
+		},
+		{
+			name:      "IPv4AddressWithIPv6CIDR",
+			allowlist: []string{"2001:db8::/32"},
+			address:   "192.168.1.10",
+			error:     api.NewPermissionDeniedError("access from address '192.168.1.10' is not allowed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, tt.address)
+			resp, err := newIPAllowlistTestApp(t, tt.allowlist).Test(req, -1)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+			var errorResponse api.ErrorResponse
+			require.Nil(t, json.NewDecoder(resp.Body).Decode(&errorResponse))
+			assert.Equal(t, tt.error.Error(), errorResponse.Error())
+		})
+	}
+}
+
+func TestNewIPAllowlistMiddleware_Error(t *testing.T) {
+	_, err := NewIPAllowlistMiddleware([]string{"10.0.0.0/8", "localhost"})
+	assert.EqualError(t, err, "incorrect IP address or CIDR 'localhost'")
+
+	_, err = NewPluginMiddlewares(&config.Config{
+		MiddlewarePlugins: []string{"before-namespace:unknown"},
+	}, PluginPositionBeforeNamespace)
+	assert.EqualError(t, err, "middleware plugin 'unknown' is not registered")
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// ipFilterMiddleware represents middleware, which filters requests by the client address.
+type ipFilterMiddleware struct {
+	allowlist []*net.IPNet
+	denylist  []*net.IPNet
+}
+
+// NewIPFilterMiddleware creates new middleware, which rejects requests with `403 Forbidden`, when the client
+// address matches one of denied IP addresses or CIDRs, or, if allowed ones are provided, matches none of them.
+// Client address is resolved by client IP middleware, which has to be attached before this one.
+func NewIPFilterMiddleware(allowlist, denylist []string) (fiber.Handler, error) {
+	m := ipFilterMiddleware{}
+	for _, list := range []struct {
+		items    []string
+		networks *[]*net.IPNet
+	}{
+		{items: allowlist, networks: &m.allowlist},
+		{items: denylist, networks: &m.denylist},
+	} {
+		for _, item := range list.items {
+			network, err := parseIPNetwork(item)
+			if err != nil {
+				return nil, err
+			}
+			*list.networks = append(*list.networks, network)
+		}
+	}
+	return m.handle, nil
+}
+
+// handle filters the request by the client address.
+func (m ipFilterMiddleware) handle(ctx *fiber.Ctx) error {
+	ip := GetClientIP(ctx)
+	if containsIP(m.denylist, ip) || (len(m.allowlist) > 0 && !containsIP(m.allowlist, ip)) {
+		return ctx.Status(
+			http.StatusForbidden,
+		).JSON(
+			api.NewPermissionDeniedError("access from address '%s' is not allowed", ip),
+		)
+	}
+	return ctx.Next()
+}
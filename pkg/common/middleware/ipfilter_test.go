@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// test requests always come from 0.0.0.0 address.
+func newIPFilterTestApp(t *testing.T, allowlist, denylist, trustedProxies []string) *fiber.App {
+	app := fiber.New()
+	clientIP, err := NewClientIPMiddleware(trustedProxies)
+	require.Nil(t, err)
+	app.Use(clientIP)
+	ipFilter, err := NewIPFilterMiddleware(allowlist, denylist)
+	require.Nil(t, err)
+	app.Use(ipFilter)
+	app.Get("/health", func(ctx *fiber.Ctx) error {
+		return ctx.SendString("OK")
+	})
+	return app
+}
+
+func TestIPFilterMiddleware_Ok(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowlist      []string
+		denylist       []string
+		trustedProxies []string
+		forwardedFor   string
+	}{
+		{
+			name:      "AllowedIP",
+			allowlist: []string{"192.168.1.10", "0.0.0.0"},
+		},
+		{
+			name:     "NotDeniedIP",
+			denylist: []string{"10.0.0.0/8"},
+		},
+		{
+			name:           "ForwardedByTrustedProxy",
+			allowlist:      []string{"192.168.1.0/24"},
+			trustedProxies: []string{"0.0.0.0"},
+			forwardedFor:   "192.168.1.10",
+		},
+		{
+			name:           "ForwardedByChainOfTrustedProxies",
+			allowlist:      []string{"192.168.1.0/24"},
+			trustedProxies: []string{"0.0.0.0", "10.0.0.0/8"},
+			forwardedFor:   "192.168.1.10, 10.1.1.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			if tt.forwardedFor != "" {
+				req.Header.Set(fiber.HeaderXForwardedFor, tt.forwardedFor)
+			}
+			resp, err := newIPFilterTestApp(t, tt.allowlist, tt.denylist, tt.trustedProxies).Test(req, -1)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestIPFilterMiddleware_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowlist      []string
+		denylist       []string
+		trustedProxies []string
+		forwardedFor   string
+		error          *api.ErrorResponse
+	}{
+		{
+			name:      "NotAllowedIP",
+			allowlist: []string{"192.168.1.0/24"},
+			error:     api.NewPermissionDeniedError("access from address '0.0.0.0' is not allowed"),
+		},
+		{
+			name:      "DeniedIP",
+			allowlist: []string{"0.0.0.0/0"},
+			denylist:  []string{"0.0.0.0"},
+			error:     api.NewPermissionDeniedError("access from address '0.0.0.0' is not allowed"),
+		},
+		{
+			name:           "SpoofedForwardedForFromUntrustedSource",
+			allowlist:      []string{"192.168.1.0/24"},
+			trustedProxies: []string{"10.0.0.0/8"},
+			forwardedFor:   "192.168.1.10",
+			error:          api.NewPermissionDeniedError("access from address '0.0.0.0' is not allowed"),
+		},
+		{
+			name:           "SpoofedForwardedForBehindTrustedProxy",
+			allowlist:      []string{"192.168.1.0/24"},
+			trustedProxies: []string{"0.0.0.0"},
+			forwardedFor:   "192.168.1.10, 11.0.0.1",
+			error:          api.NewPermissionDeniedError("access from address '11.0.0.1' is not allowed"),
+		},
+		{
+			name:           "ForwardedDeniedIP",
+			denylist:       []string{"2001:db8::/32"},
+			trustedProxies: []string{"0.0.0.0"},
+			forwardedFor:   "2001:db8::1",
+			error:          api.NewPermissionDeniedError("access from address '2001:db8::1' is not allowed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			if tt.forwardedFor != "" {
+				req.Header.Set(fiber.HeaderXForwardedFor, tt.forwardedFor)
+			}
+			resp, err := newIPFilterTestApp(t, tt.allowlist, tt.denylist, tt.trustedProxies).Test(req, -1)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+			var errorResponse api.ErrorResponse
+			require.Nil(t, json.NewDecoder(resp.Body).Decode(&errorResponse))
+			assert.Equal(t, tt.error.Error(), errorResponse.Error())
+		})
+	}
+}
+
+func TestNewIPFilterMiddleware_Error(t *testing.T) {
+	_, err := NewIPFilterMiddleware([]string{"10.0.0.0/8"}, []string{"localhost"})
+	assert.EqualError(t, err, "incorrect IP address or CIDR 'localhost'")
+}
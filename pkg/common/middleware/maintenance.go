@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// MaintenanceModeProvider provides an interface to check state of maintenance mode.
+type MaintenanceModeProvider interface {
+	// IsEnabled checks that maintenance mode is enabled.
+	IsEnabled() bool
+}
+
+// NewMaintenanceMiddleware creates new middleware, which rejects requests writing Aim or Mlflow resources
+// with `503 Service Unavailable`, while maintenance mode is enabled. Read requests are always let through.
+func NewMaintenanceMiddleware(maintenanceMode MaintenanceModeProvider) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !maintenanceMode.IsEnabled() || !MlflowAimPrefixRegexp.MatchString(ctx.Path()) || isReadRequest(ctx) {
+			return ctx.Next()
+		}
+		return ctx.Status(
+			http.StatusServiceUnavailable,
+		).JSON(
+			api.NewTemporarilyUnavailableError("server is in maintenance mode, write requests are not accepted"),
+		)
+	}
+}
@@ -2,18 +2,22 @@ package middleware
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"net"
 	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 const (
@@ -23,26 +27,51 @@ const (
 var namespaceRegexp = regexp.MustCompile(`^/ns/([^/]+)/`)
 
 // NewNamespaceMiddleware creates new Middleware instance.
-func NewNamespaceMiddleware(namespaceRepository repositories.NamespaceRepositoryProvider) fiber.Handler {
+func NewNamespaceMiddleware(
+	namespaceRepository repositories.NamespaceRepositoryProvider, config *config.Config,
+) fiber.Handler {
+	resolutionOrder, header := config.GetNamespaceResolutionOrder(), config.GetNamespaceHeader()
+	globalBasicAuthToken := ""
+	if config.Auth.AuthUsername != "" && config.Auth.AuthPassword != "" {
+		globalBasicAuthToken = base64.StdEncoding.EncodeToString(
+			[]byte(fmt.Sprintf("%s:%s", config.Auth.AuthUsername, config.Auth.AuthPassword)),
+		)
+	}
 	return func(ctx *fiber.Ctx) (err error) {
 		log.Debugf("checking namespace for path: %s", ctx.Path())
-		// if namespace exists in the request then try to process it, otherwise fallback to default namespace.
-		namespaceCode := models.DefaultNamespaceCode
+		// namespace prefix is always removed from the path, so routes could be matched
+		// regardless of which resolver finally provides namespace code.
+		pathNamespaceCode := ""
 		if matches := namespaceRegexp.FindStringSubmatch(ctx.Path()); matches != nil {
-			namespaceCode = strings.Clone(matches[1])
-			ctx.Path(strings.TrimPrefix(ctx.Path(), fmt.Sprintf("/ns/%s", namespaceCode)))
+			pathNamespaceCode = strings.Clone(matches[1])
+			ctx.Path(strings.TrimPrefix(ctx.Path(), fmt.Sprintf("/ns/%s", pathNamespaceCode)))
 		}
+
+		// if namespace exists in the request then try to process it, otherwise fallback to default namespace.
+		namespaceCode := resolveNamespaceCode(
+			ctx, resolutionOrder, pathNamespaceCode, header, config.NamespaceBaseDomain,
+		)
+
 		namespace, err := namespaceRepository.GetByCode(ctx.Context(), namespaceCode)
 		if err != nil {
-			return ctx.JSON(api.NewInternalError("error getting namespace with code: %s", namespaceCode))
-		}
-		if namespace == nil {
+			log.Errorf("error getting namespace with code: %s, %+v", namespaceCode, err)
 			return ctx.Status(
-				http.StatusNotFound,
+				http.StatusInternalServerError,
 			).JSON(
-				api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespaceCode),
+				api.NewInternalError("error getting namespace with code: %s", namespaceCode),
 			)
 		}
+		if namespace == nil {
+			// with global Basic Auth, anonymous requests get the same challenge for unknown namespaces
+			// as for private ones, so they can't find out which namespaces exist. Clients using API keys
+			// don't use Basic Auth, so they get the same error as for keys of other namespaces instead.
+			_, hasAPIKey := getAPIKeyFromHeader(ctx)
+			if globalBasicAuthToken != "" && !hasAPIKey && !hasBasicAuthToken(ctx, globalBasicAuthToken) {
+				ctx.Set(fiber.HeaderWWWAuthenticate, "basic realm="+basicauth.ConfigDefault.Realm)
+				return ctx.SendStatus(fiber.StatusUnauthorized)
+			}
+			return sendNamespaceNotFoundError(ctx, namespaceCode)
+		}
 
 		ctx.Locals(namespaceContextKey, namespace)
 
@@ -50,6 +79,60 @@ func NewNamespaceMiddleware(namespaceRepository repositories.NamespaceRepository
 	}
 }
 
+// resolveNamespaceCode applies namespace resolvers in the given order and returns the first found namespace code.
+func resolveNamespaceCode(
+	ctx *fiber.Ctx, resolutionOrder []string, pathNamespaceCode, header, baseDomain string,
+) string {
+	for _, resolver := range resolutionOrder {
+		code := ""
+		switch resolver {
+		case config.NamespaceResolverPath:
+			code = pathNamespaceCode
+		case config.NamespaceResolverHeader:
+			code = strings.TrimSpace(ctx.Get(header))
+		case config.NamespaceResolverSubdomain:
+			code = getNamespaceCodeFromHost(ctx.Hostname(), baseDomain)
+		}
+		if code != "" {
+			return code
+		}
+	}
+	return models.DefaultNamespaceCode
+}
+
+// getNamespaceCodeFromHost returns namespace code from the subdomain of the base domain, e.g. `team1`
+// for `team1.mlflow.example.com` host. the base domain itself and any other host have no namespace code.
+func getNamespaceCodeFromHost(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	subdomain, ok := strings.CutSuffix(strings.ToLower(host), "."+baseDomain)
+	if !ok || subdomain == "" || strings.Contains(subdomain, ".") {
+		return ""
+	}
+	return subdomain
+}
+
+// sendNamespaceNotFoundError sends `RESOURCE_DOES_NOT_EXIST` error for namespace which either doesn't exist
+// or isn't accessible, so clients can't distinguish these two cases, but can distinguish them from server errors.
+func sendNamespaceNotFoundError(ctx *fiber.Ctx, namespaceCode string) error {
+	return ctx.Status(
+		http.StatusNotFound,
+	).JSON(
+		api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespaceCode),
+	)
+}
+
+// IsLandingRequest checks that request is a landing request of the chooser UI, which is sent to the root path
+// without namespace selected explicitly, e.g. right after authentication.
+func IsLandingRequest(ctx *fiber.Ctx, namespace *models.Namespace) bool {
+	path, _, _ := strings.Cut(ctx.OriginalURL(), "?")
+	return path == "/" && namespace.IsDefault()
+}
+
 // GetNamespaceFromContext returns models.Namespace object from the context.
 func GetNamespaceFromContext(ctx context.Context) (*models.Namespace, error) {
 	namespace, ok := ctx.Value(namespaceContextKey).(*models.Namespace)
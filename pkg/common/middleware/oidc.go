@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -23,16 +24,19 @@ const (
 type OIDCMiddleware struct {
 	client          auth.OIDCClientProvider
 	rolesRepository repositories.RoleRepositoryProvider
+	cookieName      string
 }
 
 // NewOIDCMiddleware creates new OIDC middleware logic.
 func NewOIDCMiddleware(
 	client auth.OIDCClientProvider,
 	rolesRepository repositories.RoleRepositoryProvider,
+	cookieName string,
 ) fiber.Handler {
 	return OIDCMiddleware{
 		client:          client,
 		rolesRepository: rolesRepository,
+		cookieName:      cookieName,
 	}.Handle()
 }
 
@@ -53,7 +57,7 @@ func (m OIDCMiddleware) Handle() fiber.Handler {
 
 // handleAdminResourceRequest applies OIDC check for Admin resources.
 func (m OIDCMiddleware) handleAdminResourceRequest(ctx *fiber.Ctx) error {
-	authToken := strings.Replace(ctx.Get("Authorization"), "Bearer ", "", 1)
+	authToken := m.getAuthToken(ctx)
 	if authToken == "" {
 		log.Error("auth token has incorrect format")
 		return ctx.Redirect("/login", http.StatusMovedPermanently)
@@ -80,7 +84,7 @@ func (m OIDCMiddleware) handleChooserResourceRequest(ctx *fiber.Ctx) error {
 	log.Debugf("checking access permission to %s namespace", namespace.Code)
 
 	if path := ctx.Path(); path != "/login" && !strings.Contains(path, "/chooser/static") {
-		authToken := strings.Replace(ctx.Get("Authorization"), "Bearer ", "", 1)
+		authToken := m.getAuthToken(ctx)
 		if authToken == "" {
 			log.Error("auth token has incorrect format")
 			return ctx.Redirect("/login", http.StatusMovedPermanently)
@@ -104,24 +108,19 @@ func (m OIDCMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx) error {
 		return api.NewInternalError("error getting namespace from context")
 	}
 	log.Debugf("checking access permission to %s namespace", namespace.Code)
+	if IsPublicReadRequest(ctx) || IsAPIKeyRequest(ctx) {
+		return ctx.Next()
+	}
 
-	authToken := strings.Replace(ctx.Get("Authorization"), "Bearer ", "", 1)
+	authToken := m.getAuthToken(ctx)
 	if authToken == "" {
 		log.Error("auth token has incorrect format")
-		return ctx.Status(
-			http.StatusNotFound,
-		).JSON(
-			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
-		)
+		return sendNamespaceNotFoundError(ctx, namespace.Code)
 	}
 
 	user, err := m.client.Verify(ctx.Context(), authToken)
 	if err != nil {
-		return ctx.Status(
-			http.StatusNotFound,
-		).JSON(
-			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
-		)
+		return sendNamespaceNotFoundError(ctx, namespace.Code)
 	}
 	log.Debugf("user has roles: %v accociated", user.GetRoles())
 
@@ -137,15 +136,47 @@ func (m OIDCMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx) error {
 		)
 	}
 	if !isValid {
-		return ctx.Status(
-			http.StatusNotFound,
-		).JSON(
-			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
-		)
+		return sendNamespaceNotFoundError(ctx, namespace.Code)
 	}
 	return ctx.Next()
 }
 
+// getAuthToken returns access token either from `Authorization` header or, if header is not provided,
+// from the auth cookie set by `/set-cookie` endpoint. The cookie is sent by the browser automatically,
+// so for state-changing requests it is accepted only when request comes from the same origin.
+func (m OIDCMiddleware) getAuthToken(ctx *fiber.Ctx) string {
+	if authToken := strings.Replace(ctx.Get("Authorization"), "Bearer ", "", 1); authToken != "" {
+		return authToken
+	}
+	if !isSafeMethod(ctx.Method()) && !isSameOriginRequest(ctx) {
+		log.Debugf("auth cookie is ignored for cross-origin %s %s request", ctx.Method(), ctx.Path())
+		return ""
+	}
+	return ctx.Cookies(m.cookieName)
+}
+
+// isSafeMethod checks that request method doesn't change the state.
+func isSafeMethod(method string) bool {
+	return method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions
+}
+
+// isSameOriginRequest checks that `Origin` or, if it is not provided, `Referer` header
+// points to the same host the request was sent to.
+func isSameOriginRequest(ctx *fiber.Ctx) bool {
+	origin := ctx.Get(fiber.HeaderOrigin)
+	if origin == "" {
+		origin = ctx.Get(fiber.HeaderReferer)
+	}
+	if origin == "" {
+		return false
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return originURL.Host != "" && strings.EqualFold(originURL.Host, ctx.Hostname())
+}
+
 // GetOIDCUserFromContext returns OIDC User object from the context.
 func GetOIDCUserFromContext(ctx context.Context) (*auth.User, error) {
 	user, ok := ctx.Value(oidcUserContextKey).(*auth.User)
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOIDCTestApp() *fiber.App {
+	app := fiber.New()
+	m := OIDCMiddleware{cookieName: "access_token"}
+	app.All("/token", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(m.getAuthToken(ctx))
+	})
+	return app
+}
+
+func TestOIDCMiddleware_GetAuthToken_Ok(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		headers       map[string]string
+		expectedToken string
+	}{
+		{
+			name:   "TokenFromHeader",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"Authorization": "Bearer header-token",
+				"Cookie":        "access_token=cookie-token",
+			},
+			expectedToken: "header-token",
+		},
+		{
+			name:   "TokenFromCookieForGetRequest",
+			method: http.MethodGet,
+			headers: map[string]string{
+				"Cookie": "access_token=cookie-token",
+			},
+			expectedToken: "cookie-token",
+		},
+		{
+			name:   "TokenFromCookieForSameOriginPostRequest",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"Cookie": "access_token=cookie-token",
+				"Origin": "http://example.com",
+			},
+			expectedToken: "cookie-token",
+		},
+		{
+			name:   "TokenFromCookieForSameRefererPostRequest",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"Cookie":  "access_token=cookie-token",
+				"Referer": "http://example.com/admin/namespaces",
+			},
+			expectedToken: "cookie-token",
+		},
+		{
+			name:   "CookieIgnoredForPostRequestWithoutOrigin",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"Cookie": "access_token=cookie-token",
+			},
+		},
+		{
+			name:   "CookieIgnoredForCrossOriginDeleteRequest",
+			method: http.MethodDelete,
+			headers: map[string]string{
+				"Cookie": "access_token=cookie-token",
+				"Origin": "http://attacker.com",
+			},
+		},
+	}
+
+	app := newOIDCTestApp()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://example.com/token", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			resp, err := app.Test(req)
+			require.Nil(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expectedToken, string(body))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// supported list of positions of middleware plugins in the middleware chain.
+const (
+	PluginPositionBeforeNamespace = config.MiddlewarePluginPositionBeforeNamespace
+	PluginPositionAfterAuth       = config.MiddlewarePluginPositionAfterAuth
+)
+
+// PluginFactory creates middleware of the plugin from the service configuration.
+// Factory is called once on server start, so invalid plugin settings should be reported by the returned error.
+type PluginFactory func(config *config.Config) (fiber.Handler, error)
+
+// registry of compiled in middleware plugins.
+var (
+	pluginsMutex sync.RWMutex
+	plugins      = map[string]PluginFactory{}
+)
+
+// RegisterPlugin registers middleware plugin under the name, which is used to enable it by
+// `middleware-plugins` flag. Plugins are meant to be registered from `init` function of the package,
+// which is compiled into the server, so registration of an already taken name panics.
+func RegisterPlugin(name string, factory PluginFactory) {
+	pluginsMutex.Lock()
+	defer pluginsMutex.Unlock()
+	if _, ok := plugins[name]; ok {
+		panic(eris.Errorf("middleware plugin '%s' is already registered", name))
+	}
+	plugins[name] = factory
+}
+
+// NewPluginMiddlewares creates middlewares of the plugins enabled at the position of the middleware chain,
+// in the configured order.
+func NewPluginMiddlewares(config *config.Config, position string) ([]fiber.Handler, error) {
+	pluginsMutex.RLock()
+	defer pluginsMutex.RUnlock()
+
+	names := config.GetMiddlewarePlugins(position)
+	middlewares := make([]fiber.Handler, len(names))
+	for i, name := range names {
+		factory, ok := plugins[name]
+		if !ok {
+			return nil, eris.Errorf("middleware plugin '%s' is not registered", name)
+		}
+		middleware, err := factory(config)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error creating middleware plugin '%s'", name)
+		}
+		middlewares[i] = middleware
+	}
+	return middlewares, nil
+}
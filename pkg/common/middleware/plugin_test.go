@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestNewPluginMiddlewares_Ok(t *testing.T) {
+	middlewares, err := NewPluginMiddlewares(&config.Config{
+		MiddlewarePlugins: []string{"before-namespace:response-headers"},
+		ResponseHeaders:   []string{"X-Team: ml"},
+	}, PluginPositionBeforeNamespace)
+	require.Nil(t, err)
+	require.Len(t, middlewares, 1)
+
+	app := fiber.New()
+	app.Use(middlewares[0])
+	app.Get("/health", func(ctx *fiber.Ctx) error {
+		return ctx.SendString("OK")
+	})
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/health", nil), -1)
+	require.Nil(t, err)
+	assert.Equal(t, "ml", resp.Header.Get("X-Team"))
+
+	middlewares, err = NewPluginMiddlewares(&config.Config{
+		MiddlewarePlugins: []string{"before-namespace:response-headers"},
+	}, PluginPositionAfterAuth)
+	require.Nil(t, err)
+	assert.Empty(t, middlewares)
+}
+
+func TestNewPluginMiddlewares_Error(t *testing.T) {
+	_, err := NewPluginMiddlewares(&config.Config{
+		MiddlewarePlugins: []string{"after-auth:unknown"},
+	}, PluginPositionAfterAuth)
+	assert.EqualError(t, err, "middleware plugin 'unknown' is not registered")
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// IsPublicReadRequest checks that request only reads Aim or Mlflow resources of the namespace with public
+// read access, so it could be let through without authentication. Writes, Admin and Chooser resources
+// and resources of private namespaces always require authentication.
+func IsPublicReadRequest(ctx *fiber.Ctx) bool {
+	if !MlflowAimPrefixRegexp.MatchString(ctx.Path()) {
+		return false
+	}
+	namespace, err := GetNamespaceFromContext(ctx.Context())
+	if err != nil || !namespace.PublicRead {
+		return false
+	}
+	return isReadRequest(ctx)
+}
+
+// isReadRequest checks that request only reads data, so it has no side effects.
+func isReadRequest(ctx *fiber.Ctx) bool {
+	switch ctx.Method() {
+	case fiber.MethodGet, fiber.MethodHead:
+		return true
+	case fiber.MethodPost:
+		return ReadOnlyPathRegexp.MatchString(ctx.Path())
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// NewTimeoutMiddleware creates new middleware, which limits processing time of Aim and Mlflow requests.
+// Handlers have to use `ctx.UserContext()` to be cancelled, when deadline is exceeded.
+// Artifact requests are limited by a separate `artifactTimeout`, because they could transfer large files.
+// Zero value disables corresponding deadline.
+func NewTimeoutMiddleware(timeout, artifactTimeout time.Duration) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		// request context is used as a parent, so values stored by previous middlewares stay available.
+		ctx.SetUserContext(ctx.Context())
+
+		if !MlflowAimPrefixRegexp.MatchString(ctx.Path()) {
+			return ctx.Next()
+		}
+
+		deadline := timeout
+		if ArtifactsPrefixRegexp.MatchString(ctx.Path()) {
+			deadline = artifactTimeout
+		}
+		if deadline == 0 {
+			return ctx.Next()
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx.Context(), deadline)
+		defer func() {
+			// streamed responses are written after handler returns, so deadline is kept till the end.
+			if !ctx.Context().IsBodyStream() {
+				cancel()
+			}
+		}()
+		ctx.SetUserContext(timeoutCtx)
+
+		err := ctx.Next()
+		if err != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			return api.NewTemporarilyUnavailableError("request has been cancelled after timeout of %s", deadline)
+		}
+		return err
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func newTimeoutTestApp(timeout, artifactTimeout time.Duration) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
+			var e *api.ErrorResponse
+			if errors.As(err, &e) {
+				return ctx.Status(e.StatusCode).JSON(e)
+			}
+			return fiber.DefaultErrorHandler(ctx, err)
+		},
+	})
+	app.Use(NewTimeoutMiddleware(timeout, artifactTimeout))
+
+	// slowHandler waits longer than any deadline used in tests, unless it is cancelled.
+	slowHandler := func(ctx *fiber.Ctx) error {
+		select {
+		case <-ctx.UserContext().Done():
+			return ctx.UserContext().Err()
+		case <-time.After(500 * time.Millisecond):
+			return ctx.SendStatus(http.StatusOK)
+		}
+	}
+	app.Get("/api/2.0/mlflow/runs/get", slowHandler)
+	app.Get("/api/2.0/mlflow/artifacts/get", slowHandler)
+	app.Get("/admin/namespaces", slowHandler)
+	return app
+}
+
+func TestTimeoutMiddleware_Ok(t *testing.T) {
+	tests := []struct {
+		name            string
+		timeout         time.Duration
+		artifactTimeout time.Duration
+		path            string
+	}{
+		{
+			name:    "DisabledTimeout",
+			timeout: 0,
+			path:    "/api/2.0/mlflow/runs/get",
+		},
+		{
+			name:    "NotExceededTimeout",
+			timeout: 5 * time.Second,
+			path:    "/api/2.0/mlflow/runs/get",
+		},
+		{
+			name:            "ArtifactRequestWithDisabledTimeout",
+			timeout:         50 * time.Millisecond,
+			artifactTimeout: 0,
+			path:            "/api/2.0/mlflow/artifacts/get",
+		},
+		{
+			name:            "ArtifactRequestWithLargerTimeout",
+			timeout:         50 * time.Millisecond,
+			artifactTimeout: 5 * time.Second,
+			path:            "/api/2.0/mlflow/artifacts/get",
+		},
+		{
+			name:    "NotApiRequest",
+			timeout: 50 * time.Millisecond,
+			path:    "/admin/namespaces",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTimeoutTestApp(tt.timeout, tt.artifactTimeout)
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil), -1)
+			require.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestTimeoutMiddleware_Error(t *testing.T) {
+	tests := []struct {
+		name            string
+		timeout         time.Duration
+		artifactTimeout time.Duration
+		path            string
+		error           *api.ErrorResponse
+	}{
+		{
+			name:    "ExceededTimeout",
+			timeout: 50 * time.Millisecond,
+			path:    "/api/2.0/mlflow/runs/get",
+			error:   api.NewTemporarilyUnavailableError("request has been cancelled after timeout of 50ms"),
+		},
+		{
+			name:            "ExceededArtifactTimeout",
+			timeout:         5 * time.Second,
+			artifactTimeout: 50 * time.Millisecond,
+			path:            "/api/2.0/mlflow/artifacts/get",
+			error:           api.NewTemporarilyUnavailableError("request has been cancelled after timeout of 50ms"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTimeoutTestApp(tt.timeout, tt.artifactTimeout)
+			started := time.Now()
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil), -1)
+			require.Nil(t, err)
+			assert.Less(t, time.Since(started), 500*time.Millisecond)
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+			var errorResponse api.ErrorResponse
+			require.Nil(t, json.NewDecoder(resp.Body).Decode(&errorResponse))
+			assert.Equal(t, tt.error.Error(), errorResponse.Error())
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// NewTransactionMiddleware creates new middleware, which wraps handler into a transaction, so that several
+// repository calls of a handler are applied atomically. Repositories join the transaction via request context,
+// see repositories.BaseRepository.GetDBWithContext. The transaction is begun by the first repository call,
+// so the database connection is not held while the handler validates or throttles the request.
+// Transaction is committed when handler succeeds and rolled back when handler fails. When transaction
+// fails with transient error, e.g. deadlock, the whole handler is rerun in a new transaction, unless it has
+// already written the response.
+func NewTransactionMiddleware(db *gorm.DB) func(handler fiber.Handler) fiber.Handler {
+	return func(handler fiber.Handler) fiber.Handler {
+		return func(ctx *fiber.Ctx) error {
+			// request context is used as a parent, so values stored by previous middlewares stay available.
+			userContext := ctx.UserContext()
+			defer ctx.SetUserContext(userContext)
+
+			var transientErr error
+			return repositories.RetryOnError(userContext, func() error {
+				transientErr = nil
+				txContext := repositories.ContextWithTransaction(userContext, db.WithContext(userContext))
+				ctx.SetUserContext(txContext)
+
+				if err := handler(ctx); err != nil {
+					if rollbackErr := repositories.RollbackTransactionFromContext(txContext); rollbackErr != nil {
+						log.Errorf("error rolling back transaction of %s %s: %s", ctx.Method(), ctx.Path(), rollbackErr)
+					}
+					transientErr = repositories.GetTransientErrorFromContext(txContext)
+					if transientErr == nil && repositories.IsTransientError(err) {
+						transientErr = err
+					}
+					return err
+				}
+				if err := repositories.CommitTransactionFromContext(txContext); err != nil {
+					if repositories.IsTransientError(err) {
+						transientErr = err
+					}
+					return api.NewInternalError("unable to commit transaction: %s", err)
+				}
+				return nil
+			}, func(err error) bool {
+				return transientErr != nil && len(ctx.Response().Body()) == 0
+			})
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+func newTransactionTestApp(t *testing.T) (*fiber.App, sqlmock.Sqlmock) {
+	mockDb, mock, err := sqlmock.New()
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		//nolint:errcheck
+		mockDb.Close()
+	})
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:       mockDb,
+		DriverName: "postgres",
+	}), &gorm.Config{})
+	require.Nil(t, err)
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
+			var e *api.ErrorResponse
+			if errors.As(err, &e) {
+				return ctx.Status(e.StatusCode).JSON(e)
+			}
+			return fiber.DefaultErrorHandler(ctx, err)
+		},
+	})
+
+	// multiStepHandler makes several changes via different repository calls and fails after the last one,
+	// when it is requested.
+	repository := repositories.NewBaseRepository(db)
+	multiStepHandler := func(ctx *fiber.Ctx) error {
+		if ctx.QueryBool("invalid") {
+			return api.NewInvalidParameterValueError("invalid request")
+		}
+		if err := repository.GetDBWithContext(ctx.UserContext()).Exec(
+			`INSERT INTO params (run_uuid, key, value) VALUES ('run', 'key', 'value')`,
+		).Error; err != nil {
+			return err
+		}
+		if err := repositories.TransactionWithRetry(
+			ctx.UserContext(), repository.GetDB(), func(tx *gorm.DB) error {
+				return tx.Exec(`INSERT INTO tags (run_uuid, key, value) VALUES ('run', 'key', 'value')`).Error
+			},
+		); err != nil {
+			return err
+		}
+		if ctx.QueryBool("fail") {
+			return api.NewInternalError("late failure")
+		}
+		return ctx.SendStatus(http.StatusOK)
+	}
+	app.Post("/multi-step", NewTransactionMiddleware(db)(multiStepHandler))
+	return app, mock
+}
+
+func TestTransactionMiddleware_Ok(t *testing.T) {
+	app, mock := newTransactionTestApp(t)
+
+	// all the changes are committed together.
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO params`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO tags`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/multi-step", nil))
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionMiddleware_Error(t *testing.T) {
+	app, mock := newTransactionTestApp(t)
+
+	// late failure rolls back all the changes, made by the handler.
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO params`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO tags`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/multi-step?fail=true", nil))
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionMiddleware_ValidationError(t *testing.T) {
+	app, mock := newTransactionTestApp(t)
+
+	// transaction is not begun, when handler fails before any repository call.
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/multi-step?invalid=true", nil))
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionMiddleware_TransientError(t *testing.T) {
+	app, mock := newTransactionTestApp(t)
+
+	// deadlock in the nested transaction rolls back the whole request transaction and reruns the handler.
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO params`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO tags`).WillReturnError(&pgconn.PgError{Code: "40P01"})
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO params`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO tags`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/multi-step", nil))
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -9,7 +9,7 @@ import (
 
 // NewDBProvider creates a DBProvider of the correct type from the parameters.
 func NewDBProvider(
-	dsn string, slowThreshold time.Duration, poolMax int,
+	dsn string, slowThreshold time.Duration, pool PoolConfig, timeouts StatementTimeouts,
 ) (db DBProvider, err error) {
 	dsnURL, err := url.Parse(dsn)
 	if err != nil {
@@ -20,7 +20,8 @@ func NewDBProvider(
 		db, err = NewSqliteDBInstance(
 			*dsnURL,
 			slowThreshold,
-			poolMax,
+			pool,
+			timeouts,
 		)
 		if err != nil {
 			return nil, eris.Wrap(err, "error creating sqlite provider")
@@ -29,7 +30,8 @@ func NewDBProvider(
 		db, err = NewPostgresDBInstance(
 			*dsnURL,
 			slowThreshold,
-			poolMax,
+			pool,
+			timeouts,
 		)
 		if err != nil {
 			return nil, eris.Wrap(err, "error creating postgres provider")
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"path/filepath"
 	"testing"
 	"time"
@@ -27,7 +29,8 @@ func TestMakeDBProvider(t *testing.T) {
 			db, err := NewDBProvider(
 				tt.dsn,
 				time.Second*2,
-				2,
+				NewPoolConfig(2),
+				StatementTimeouts{},
 			)
 			require.Nil(t, err)
 			assert.NotNil(t, db)
@@ -39,3 +42,73 @@ func TestMakeDBProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeDBProvider_PoolConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		pool   PoolConfig
+		closed func(stats sql.DBStats) int64
+	}{
+		{
+			name: "WithConnMaxIdleTime",
+			pool: PoolConfig{
+				MaxOpenConns:    4,
+				MaxIdleConns:    1,
+				ConnMaxLifetime: time.Hour,
+				ConnMaxIdleTime: 10 * time.Millisecond,
+			},
+			closed: func(stats sql.DBStats) int64 {
+				return stats.MaxIdleTimeClosed
+			},
+		},
+		{
+			name: "WithConnMaxLifetime",
+			pool: PoolConfig{
+				MaxOpenConns:    4,
+				MaxIdleConns:    1,
+				ConnMaxLifetime: 10 * time.Millisecond,
+				ConnMaxIdleTime: time.Hour,
+			},
+			closed: func(stats sql.DBStats) int64 {
+				return stats.MaxLifetimeClosed
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := NewDBProvider(
+				"sqlite://"+filepath.Join(t.TempDir(), "fasttrackml.db"),
+				time.Second*2,
+				tt.pool,
+				StatementTimeouts{},
+			)
+			require.Nil(t, err)
+			//nolint:errcheck
+			defer db.Close()
+
+			// settings are applied to the pool of read replica connections.
+			sqliteDB, ok := db.(*SqliteDBInstance)
+			require.True(t, ok)
+			replicaDB, ok := sqliteDB.closers[1].(*sql.DB)
+			require.True(t, ok)
+			assert.Equal(t, tt.pool.MaxOpenConns, replicaDB.Stats().MaxOpenConnections)
+
+			// only one of the released connections is kept idle.
+			conns := make([]*sql.Conn, 3)
+			for i := range conns {
+				conns[i], err = replicaDB.Conn(context.Background())
+				require.Nil(t, err)
+			}
+			for _, conn := range conns {
+				require.Nil(t, conn.Close())
+			}
+			assert.Equal(t, 1, replicaDB.Stats().Idle)
+			assert.Equal(t, int64(2), replicaDB.Stats().MaxIdleClosed)
+
+			// idle connection is eventually closed by the pool.
+			assert.Eventually(t, func() bool {
+				return tt.closed(replicaDB.Stats()) == 1 && replicaDB.Stats().Idle == 0
+			}, 5*time.Second, 50*time.Millisecond)
+		})
+	}
+}
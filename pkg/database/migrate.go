@@ -5,16 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"slices"
-	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
-	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
 )
 
 var supportedAlembicVersions = []string{
@@ -144,9 +144,16 @@ func CheckAndMigrateDB(migrate bool, db *gorm.DB) error {
 				&Dashboard{},
 				&App{},
 				&SchemaVersion{},
+				&RunActivity{},
+				&APIKey{},
+				&ArtifactUpload{},
+				&ArtifactUploadPart{},
 			); err != nil {
 				return fmt.Errorf("error initializing database: %w", err)
 			}
+			if err := migrations.CreateTagKeyValueIndex(tx); err != nil {
+				return fmt.Errorf("error initializing database: %w", err)
+			}
 			tx.Create(&AlembicVersion{
 				Version: "97727af70f4d",
 			})
@@ -220,7 +227,11 @@ func CreateDefaultExperiment(db *gorm.DB, defaultArtifactRoot string) error {
 					return err
 				}
 
-				exp.ArtifactLocation = fmt.Sprintf("%s/%d", strings.TrimRight(defaultArtifactRoot, "/"), *exp.ID)
+				artifactLocation, err := common.BuildArtifactLocation(defaultArtifactRoot, ns.Code, *exp.ID)
+				if err != nil {
+					return fmt.Errorf("error creating artifact_location for experiment '%s': %s", exp.Name, err)
+				}
+				exp.ArtifactLocation = artifactLocation
 				if err := tx.Model(&exp).Update("ArtifactLocation", exp.ArtifactLocation).Error; err != nil {
 					return fmt.Errorf("error updating artifact_location for experiment '%s': %s", exp.Name, err)
 				}
@@ -237,9 +248,14 @@ func CreateDefaultExperiment(db *gorm.DB, defaultArtifactRoot string) error {
 }
 
 // CreateDefaultMetricContext creates the default metric context if it doesn't exist.
+// Context is looked up by its canonical form, which is used to store all the metric contexts.
 func CreateDefaultMetricContext(db *gorm.DB) error {
-	defaultContext := Context{Json: types.JSONB("{}")}
-	if err := db.First(&defaultContext).Error; err != nil {
+	json, err := types.JSONB("{}").Canonical()
+	if err != nil {
+		return fmt.Errorf("error normalizing default context: %s", err)
+	}
+	defaultContext := Context{Json: json}
+	if err := db.Where("json = ?", defaultContext.Json).First(&defaultContext).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Info("Creating default context")
 			if err := db.Create(&defaultContext).Error; err != nil {
@@ -20,10 +20,19 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0011"
 	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0012"
 	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0013"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0014"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0015"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0016"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0017"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0018"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0019"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0020"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0021"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations/v_0022"
 )
 
 func currentVersion() string {
-	return v_0013.Version
+	return v_0022.Version
 }
 
 func generatedMigrations(db *gorm.DB, schemaVersion string) error {
@@ -117,6 +126,69 @@ func generatedMigrations(db *gorm.DB, schemaVersion string) error {
 		if err := v_0013.Migrate(db); err != nil {
 			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0013.Version, err)
 		}
+		fallthrough
+
+	case v_0013.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0014.Version)
+		if err := v_0014.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0014.Version, err)
+		}
+		fallthrough
+
+	case v_0014.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0015.Version)
+		if err := v_0015.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0015.Version, err)
+		}
+		fallthrough
+
+	case v_0015.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0016.Version)
+		if err := v_0016.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0016.Version, err)
+		}
+		fallthrough
+
+	case v_0016.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0017.Version)
+		if err := v_0017.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0017.Version, err)
+		}
+		fallthrough
+
+	case v_0017.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0018.Version)
+		if err := v_0018.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0018.Version, err)
+		}
+		fallthrough
+
+	case v_0018.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0019.Version)
+		if err := v_0019.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0019.Version, err)
+		}
+		fallthrough
+
+	case v_0019.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0020.Version)
+		if err := v_0020.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0020.Version, err)
+		}
+		fallthrough
+
+	case v_0020.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0021.Version)
+		if err := v_0021.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0021.Version, err)
+		}
+		fallthrough
+
+	case v_0021.Version:
+		log.Infof("Migrating database to FastTrackML schema %s", v_0022.Version)
+		if err := v_0022.Migrate(db); err != nil {
+			return fmt.Errorf("error migrating database to FastTrackML schema %s: %w", v_0022.Version, err)
+		}
 
 	default:
 		return fmt.Errorf("unsupported database FastTrackML schema version %s", schemaVersion)
@@ -15,3 +15,14 @@ func RunWithoutForeignKeyIfNeeded(db *gorm.DB, fn func() error) error {
 	}
 	return fn()
 }
+
+// CreateTagKeyValueIndex creates index on tags (key, value) used to filter runs by tag values.
+// Postgres limits the size of a btree index row, while tag values could be up to 5000 characters,
+// so there the index is built on the hash of the value instead of the value itself.
+func CreateTagKeyValueIndex(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case sqlite.Dialector{}.Name():
+		return db.Exec("CREATE INDEX IF NOT EXISTS idx_tags_key_value ON tags (key, value)").Error
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_tags_key_value ON tags (key, md5(value))").Error
+}
@@ -0,0 +1,31 @@
+package v_0014
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
+)
+
+const Version = "20261016060419"
+
+func Migrate(db *gorm.DB) error {
+	return migrations.RunWithoutForeignKeyIfNeeded(db, func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, column := range []string{
+				"retention_max_age_days",
+				"retention_keep_best",
+				"retention_metric_key",
+				"retention_metric_direction",
+			} {
+				if err := tx.Migrator().AddColumn(&Namespace{}, column); err != nil {
+					return err
+				}
+			}
+			// Update the schema version
+			return tx.Model(&SchemaVersion{}).
+				Where("1 = 1").
+				Update("Version", Version).
+				Error
+		})
+	})
+}
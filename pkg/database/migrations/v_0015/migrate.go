@@ -0,0 +1,41 @@
+package v_0015
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
+)
+
+const Version = "20261016063221"
+
+func Migrate(db *gorm.DB) error {
+	return migrations.RunWithoutForeignKeyIfNeeded(db, func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&RunActivity{}); err != nil {
+				return err
+			}
+			// populate the summary from the existing runs
+			if err := tx.Exec(
+				"INSERT INTO run_activities" +
+					"  (namespace_id, bucket, num_runs, num_active_runs, num_archived_runs)" +
+					"  SELECT" +
+					"    experiments.namespace_id," +
+					"    COALESCE(runs.start_time, 0) / 3600000," +
+					"    COUNT(*)," +
+					"    SUM(CASE WHEN runs.lifecycle_stage <> 'deleted' AND runs.status = 'RUNNING'" +
+					"      THEN 1 ELSE 0 END)," +
+					"    SUM(CASE WHEN runs.lifecycle_stage = 'deleted' THEN 1 ELSE 0 END)" +
+					"  FROM runs" +
+					"  INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id" +
+					"  GROUP BY experiments.namespace_id, COALESCE(runs.start_time, 0) / 3600000").
+				Error; err != nil {
+				return err
+			}
+			// Update the schema version
+			return tx.Model(&SchemaVersion{}).
+				Where("1 = 1").
+				Update("Version", Version).
+				Error
+		})
+	})
+}
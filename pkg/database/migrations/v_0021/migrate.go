@@ -0,0 +1,151 @@
+package v_0021
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
+)
+
+const Version = "20261019090000"
+
+func Migrate(db *gorm.DB) error {
+	return migrations.RunWithoutForeignKeyIfNeeded(db, func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := deduplicateContexts(tx); err != nil {
+				return err
+			}
+			// Update the schema version
+			return tx.Model(&SchemaVersion{}).
+				Where("1 = 1").
+				Update("Version", Version).
+				Error
+		})
+	})
+}
+
+// deduplicateContexts converts JSON of the contexts into canonical form and merges contexts,
+// which are identical in canonical form, into the one with the lowest ID.
+func deduplicateContexts(tx *gorm.DB) error {
+	var contexts []Context
+	if err := tx.Order("id").Find(&contexts).Error; err != nil {
+		return err
+	}
+
+	keptIDs := map[string]uint{}
+	var renamed []Context
+	for _, context := range contexts {
+		canonical, err := canonicalJSON(context.Json)
+		if err != nil {
+			return err
+		}
+		if keptID, ok := keptIDs[string(canonical)]; ok {
+			if err := mergeContext(tx, context.ID, keptID); err != nil {
+				return err
+			}
+			continue
+		}
+		keptIDs[string(canonical)] = context.ID
+		if !bytes.Equal(canonical, context.Json) {
+			renamed = append(renamed, Context{ID: context.ID, Json: canonical})
+		}
+	}
+
+	// contexts are renamed only after all the duplicates are removed, so canonical JSON is unique.
+	for _, context := range renamed {
+		if err := tx.Model(&Context{}).Where("id = ?", context.ID).Update("json", context.Json).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeContext moves metrics of the duplicated context into the kept one and removes the duplicated context.
+// Metric series of the duplicated context are appended to the series of the kept context.
+func mergeContext(tx *gorm.DB, duplicatedID, keptID uint) error {
+	// the same values logged in both contexts are kept only once.
+	if err := tx.Exec(
+		`DELETE FROM metrics WHERE context_id = ? AND EXISTS (
+			SELECT 1 FROM metrics AS kept WHERE kept.context_id = ? AND kept.run_uuid = metrics.run_uuid AND
+			kept.key = metrics.key AND kept.value = metrics.value AND kept.timestamp = metrics.timestamp AND
+			kept.step = metrics.step AND kept.is_nan = metrics.is_nan
+		)`,
+		duplicatedID, keptID,
+	).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec(
+		`UPDATE metrics SET iter = iter + COALESCE((
+			SELECT last_iter FROM latest_metrics WHERE latest_metrics.context_id = ? AND
+			latest_metrics.run_uuid = metrics.run_uuid AND latest_metrics.key = metrics.key
+		), 0), context_id = ? WHERE context_id = ?`,
+		keptID, keptID, duplicatedID,
+	).Error; err != nil {
+		return err
+	}
+
+	var latestMetrics []LatestMetric
+	if err := tx.Where("context_id = ?", duplicatedID).Find(&latestMetrics).Error; err != nil {
+		return err
+	}
+	for _, duplicated := range latestMetrics {
+		var kept LatestMetric
+		result := tx.Where(
+			"run_uuid = ? AND key = ? AND context_id = ?", duplicated.RunID, duplicated.Key, keptID,
+		).Limit(1).Find(&kept)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			if err := tx.Model(&LatestMetric{}).Where(
+				"run_uuid = ? AND key = ? AND context_id = ?", duplicated.RunID, duplicated.Key, duplicatedID,
+			).Update("context_id", keptID).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		// duplicated values have been removed from the appended series, so the last iteration
+		// is recomputed from the merged metrics rather than summed up.
+		updates := map[string]any{
+			"last_iter": gorm.Expr(
+				"(SELECT COALESCE(MAX(iter), 0) FROM metrics WHERE run_uuid = ? AND key = ? AND context_id = ?)",
+				kept.RunID, kept.Key, keptID,
+			),
+		}
+		if duplicated.Step > kept.Step ||
+			(duplicated.Step == kept.Step && duplicated.Timestamp > kept.Timestamp) ||
+			(duplicated.Step == kept.Step && duplicated.Timestamp == kept.Timestamp && duplicated.Value > kept.Value) {
+			updates["value"] = duplicated.Value
+			updates["timestamp"] = duplicated.Timestamp
+			updates["step"] = duplicated.Step
+			updates["is_nan"] = duplicated.IsNan
+		}
+		if err := tx.Model(&LatestMetric{}).Where(
+			"run_uuid = ? AND key = ? AND context_id = ?", kept.RunID, kept.Key, keptID,
+		).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(
+			"run_uuid = ? AND key = ? AND context_id = ?", duplicated.RunID, duplicated.Key, duplicatedID,
+		).Delete(&LatestMetric{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Where("id = ?", duplicatedID).Delete(&Context{}).Error
+}
+
+// canonicalJSON returns JSON with sorted object keys and without insignificant whitespaces.
+func canonicalJSON(data types.JSONB) (types.JSONB, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
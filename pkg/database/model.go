@@ -41,15 +41,24 @@ const (
 )
 
 type Namespace struct {
-	ID                  uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	Apps                []App          `gorm:"constraint:OnDelete:CASCADE" json:"apps"`
-	Code                string         `gorm:"unique;index;not null" json:"code"`
-	Description         string         `json:"description"`
-	CreatedAt           time.Time      `json:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at"`
-	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at"`
-	DefaultExperimentID *int32         `gorm:"not null" json:"default_experiment_id"`
-	Experiments         []Experiment   `gorm:"constraint:OnDelete:CASCADE" json:"experiments"`
+	ID                  uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Apps                []App           `gorm:"constraint:OnDelete:CASCADE" json:"apps"`
+	Code                string          `gorm:"unique;index;not null" json:"code"`
+	Description         string          `json:"description"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt  `gorm:"index" json:"deleted_at"`
+	DefaultExperimentID *int32          `gorm:"not null" json:"default_experiment_id"`
+	Experiments         []Experiment    `gorm:"constraint:OnDelete:CASCADE" json:"experiments"`
+	RetentionPolicy     RetentionPolicy `gorm:"embedded;embeddedPrefix:retention_" json:"retention_policy"`
+	PublicRead          bool            `gorm:"not null;default:false" json:"public_read"`
+}
+
+type RetentionPolicy struct {
+	MaxAgeDays      *int32 `json:"max_age_days"`
+	KeepBest        *int32 `json:"keep_best"`
+	MetricKey       string `gorm:"type:varchar(250)" json:"metric_key"`
+	MetricDirection string `gorm:"type:varchar(3)" json:"metric_direction"`
 }
 
 type Experiment struct {
@@ -84,16 +93,17 @@ type Run struct {
 	SourceName     string         `gorm:"<-:create;type:varchar(500)"`
 	EntryPointName string         `gorm:"<-:create;type:varchar(50)"`
 	UserID         string         `gorm:"<-:create;type:varchar(256)"`
-	Status         Status         `gorm:"type:varchar(9);check:status IN ('SCHEDULED', 'FAILED', 'FINISHED', 'RUNNING', 'KILLED')"`
+	Status         Status         `gorm:"type:varchar(9);index:idx_runs_status_experiment_id,priority:1;check:status IN ('SCHEDULED', 'FAILED', 'FINISHED', 'RUNNING', 'KILLED')"`
 	StartTime      sql.NullInt64  `gorm:"<-:create;type:bigint"`
 	EndTime        sql.NullInt64  `gorm:"type:bigint"`
 	SourceVersion  string         `gorm:"<-:create;type:varchar(50)"`
 	LifecycleStage LifecycleStage `gorm:"type:varchar(20);check:lifecycle_stage IN ('active', 'deleted')"`
 	ArtifactURI    string         `gorm:"<-:create;type:varchar(200)"`
-	ExperimentID   int32
+	ExperimentID   int32          `gorm:"index:idx_runs_status_experiment_id,priority:2"`
 	Experiment     Experiment
 	DeletedTime    sql.NullInt64  `gorm:"type:bigint"`
 	RowNum         RowNum         `gorm:"<-:create;index"`
+	Version        int64          `gorm:"not null;default:0"`
 	Params         []Param        `gorm:"constraint:OnDelete:CASCADE"`
 	Tags           []Tag          `gorm:"constraint:OnDelete:CASCADE"`
 	Metrics        []Metric       `gorm:"constraint:OnDelete:CASCADE"`
@@ -134,9 +144,9 @@ type Param struct {
 }
 
 type Tag struct {
-	Key   string `gorm:"type:varchar(250);not null;primaryKey"`
+	Key   string `gorm:"type:varchar(250);not null;primaryKey;index:idx_tags_run_uuid_key,priority:2"`
 	Value string `gorm:"type:varchar(5000)"`
-	RunID string `gorm:"column:run_uuid;not null;primaryKey;index"`
+	RunID string `gorm:"column:run_uuid;not null;primaryKey;index;index:idx_tags_run_uuid_key,priority:1"`
 }
 
 type Metric struct {
@@ -273,6 +283,15 @@ type Role struct {
 	Name string `gorm:"unique;index;not null"`
 }
 
+type RunActivity struct {
+	Namespace       Namespace `gorm:"constraint:OnDelete:CASCADE"`
+	NamespaceID     uint      `gorm:"not null;primaryKey"`
+	Bucket          int64     `gorm:"not null;primaryKey"`
+	NumRuns         int64     `gorm:"not null"`
+	NumActiveRuns   int64     `gorm:"not null"`
+	NumArchivedRuns int64     `gorm:"not null"`
+}
+
 type RoleNamespace struct {
 	Base
 	Role        Role      `gorm:"constraint:OnDelete:CASCADE"`
@@ -280,3 +299,33 @@ type RoleNamespace struct {
 	Namespace   Namespace `gorm:"constraint:OnDelete:CASCADE"`
 	NamespaceID uint      `gorm:"not null;index:,unique,composite:relation"`
 }
+
+type APIKey struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Namespace   Namespace `gorm:"constraint:OnDelete:CASCADE"`
+	NamespaceID uint      `gorm:"not null;index"`
+	Name        string    `gorm:"type:varchar(256);not null"`
+	Prefix      string    `gorm:"type:varchar(16);not null"`
+	Hash        string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	AccessLevel string    `gorm:"type:varchar(16);not null"`
+	CreatedAt   time.Time
+	LastUsedAt  sql.NullTime
+}
+
+type ArtifactUpload struct {
+	ID              string    `gorm:"type:varchar(32);primaryKey"`
+	Namespace       Namespace `gorm:"constraint:OnDelete:CASCADE"`
+	NamespaceID     uint      `gorm:"not null;index"`
+	ArtifactURI     string    `gorm:"type:varchar(200);not null"`
+	Path            string    `gorm:"type:text;not null"`
+	StorageUploadID string    `gorm:"type:text;not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time            `gorm:"index"`
+	Parts           []ArtifactUploadPart `gorm:"foreignKey:UploadID;constraint:OnDelete:CASCADE"`
+}
+
+type ArtifactUploadPart struct {
+	UploadID   string `gorm:"type:varchar(32);primaryKey"`
+	PartNumber int    `gorm:"primaryKey;autoIncrement:false"`
+	Size       int64  `gorm:"not null"`
+}
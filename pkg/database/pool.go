@@ -0,0 +1,40 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	// DefaultConnMaxLifetime is a default time after which database connection is closed, even if it is in use.
+	// It keeps connections from going stale behind proxies and load balancers, which drop long-lived connections.
+	DefaultConnMaxLifetime = 30 * time.Minute
+	// DefaultConnMaxIdleTime is a default time after which idle database connection is closed.
+	DefaultConnMaxIdleTime = time.Minute
+)
+
+// PoolConfig represents settings of the database connection pool.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// NewPoolConfig creates PoolConfig with default settings, where all open connections could be kept idle.
+func NewPoolConfig(maxOpenConns int) PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    maxOpenConns,
+		MaxIdleConns:    maxOpenConns,
+		ConnMaxLifetime: DefaultConnMaxLifetime,
+		ConnMaxIdleTime: DefaultConnMaxIdleTime,
+	}
+}
+
+// apply applies settings to the database connection pool.
+func (c PoolConfig) apply(db *sql.DB) {
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(c.ConnMaxIdleTime)
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"net/url"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 // PostgresDBInstance is the Postgres-specific DbInstance variant.
@@ -18,13 +20,17 @@ type PostgresDBInstance struct {
 
 // NewPostgresDBInstance constructs a Postgres DbInstance.
 func NewPostgresDBInstance(
-	dsnURL url.URL, slowThreshold time.Duration, poolMax int,
+	dsnURL url.URL, slowThreshold time.Duration, pool PoolConfig, timeouts StatementTimeouts,
 ) (*PostgresDBInstance, error) {
 	db := PostgresDBInstance{
 		DBInstance: DBInstance{dsn: dsnURL.String()},
 	}
 
-	conn := postgres.Open(dsnURL.String())
+	// `statement_timeout` is passed as a runtime parameter, so it is set by each of the connections on connect.
+	query := dsnURL.Query()
+	sourceURL := dsnURL
+	sourceURL.RawQuery = withTimeoutParam(query, postgresStatementTimeoutParam, timeouts.Write).Encode()
+	conn := postgres.Open(sourceURL.String())
 
 	log.Infof("Using database %s", dsnURL.Redacted())
 
@@ -43,9 +49,35 @@ func NewPostgresDBInstance(
 	if err != nil {
 		return nil, eris.Wrap(err, "failed to get underlying database connection pool")
 	}
-	sqlDB.SetConnMaxIdleTime(time.Minute)
-	sqlDB.SetMaxIdleConns(poolMax)
-	sqlDB.SetMaxOpenConns(poolMax)
+	db.closers = append(db.closers, sqlDB)
+	pool.apply(sqlDB)
+
+	// queries are limited by another timeout, so they are sent to the separate connection pool,
+	// while transactions are kept on the source one.
+	if timeouts.Read != timeouts.Write {
+		replicaURL := dsnURL
+		replicaURL.RawQuery = withTimeoutParam(query, postgresStatementTimeoutParam, timeouts.Read).Encode()
+		replicaDB, err := sql.Open("pgx", replicaURL.String())
+		if err != nil {
+			//nolint:errcheck,gosec
+			db.Close()
+			return nil, eris.Wrap(err, "failed to connect to database")
+		}
+		db.closers = append(db.closers, replicaDB)
+		pool.apply(replicaDB)
+
+		if err := db.Use(
+			dbresolver.Register(dbresolver.Config{
+				Replicas: []gorm.Dialector{
+					postgres.New(postgres.Config{Conn: replicaDB}),
+				},
+			}),
+		); err != nil {
+			//nolint:errcheck,gosec
+			db.Close()
+			return nil, eris.Wrap(err, "error attaching plugin")
+		}
+	}
 
 	return &db, nil
 }
@@ -29,7 +29,7 @@ type SqliteDBInstance struct {
 
 // NewSqliteDBInstance creates a SqliteDBInstance.
 func NewSqliteDBInstance(
-	dsnURL url.URL, slowThreshold time.Duration, poolMax int,
+	dsnURL url.URL, slowThreshold time.Duration, pool PoolConfig, timeouts StatementTimeouts,
 ) (*SqliteDBInstance, error) {
 	db := SqliteDBInstance{
 		DBInstance: DBInstance{dsn: dsnURL.String()},
@@ -44,7 +44,7 @@ func NewSqliteDBInstance(
 		query.Set("_journal", "WAL")
 	}
 	sourceURL := dsnURL
-	sourceURL.RawQuery = query.Encode()
+	sourceURL.RawQuery = withTimeoutParam(query, sqliteBusyTimeoutParam, timeouts.Write).Encode()
 
 	if !slices.Contains(sql.Drivers(), SQLiteCustomDriverName) {
 		sql.Register(SQLiteCustomDriverName, &sqlite3.SQLiteDriver{
@@ -84,7 +84,7 @@ func NewSqliteDBInstance(
 
 	query.Set("_query_only", "true")
 	replicaURL := dsnURL
-	replicaURL.RawQuery = query.Encode()
+	replicaURL.RawQuery = withTimeoutParam(query, sqliteBusyTimeoutParam, timeouts.Read).Encode()
 	replicaDB, err := sql.Open(SQLiteCustomDriverName, strings.Replace(replicaURL.String(), "sqlite://", "file:", 1))
 	if err != nil {
 		//nolint:errcheck,gosec
@@ -92,7 +92,8 @@ func NewSqliteDBInstance(
 		return nil, eris.Wrap(err, "failed to connect to database")
 	}
 	db.closers = append(db.closers, replicaDB)
-	replicaDB.SetMaxOpenConns(poolMax)
+	// source connection is the only writer and is kept open forever, so pool settings are applied to replicas only.
+	pool.apply(replicaDB)
 	replicaConn = sqlite.Dialector{
 		Conn: replicaDB,
 	}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"maps"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	postgresStatementTimeoutParam = "statement_timeout"
+	sqliteBusyTimeoutParam        = "_busy_timeout"
+)
+
+// StatementTimeouts represents timeouts, which are applied to database connections, so statements are aborted
+// by the database itself: Postgres aborts statements running longer than `statement_timeout`, while SQLite
+// aborts statements waiting for a lock longer than `busy_timeout`. Queries are limited by read timeout,
+// while writes and transactions are limited by write timeout. Zero timeout means that the timeout
+// from the DSN or the database default is used.
+type StatementTimeouts struct {
+	Read  time.Duration
+	Write time.Duration
+}
+
+// withTimeoutParam returns copy of the DSN query, where parameter is set to the timeout in milliseconds.
+// Zero timeout leaves the query as is.
+func withTimeoutParam(query url.Values, name string, timeout time.Duration) url.Values {
+	if timeout <= 0 {
+		return query
+	}
+	query = maps.Clone(query)
+	query.Set(name, strconv.FormatInt(timeout.Milliseconds(), 10))
+	return query
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"maps"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeoutParam_Ok(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         url.Values
+		timeout       time.Duration
+		expectedQuery url.Values
+	}{
+		{
+			name:          "TimeoutIsSet",
+			query:         url.Values{"sslmode": {"disable"}},
+			timeout:       1500 * time.Millisecond,
+			expectedQuery: url.Values{"sslmode": {"disable"}, "statement_timeout": {"1500"}},
+		},
+		{
+			name:          "TimeoutOverridesDSN",
+			query:         url.Values{"statement_timeout": {"100"}},
+			timeout:       time.Minute,
+			expectedQuery: url.Values{"statement_timeout": {"60000"}},
+		},
+		{
+			name:          "ZeroTimeoutKeepsDSN",
+			query:         url.Values{"statement_timeout": {"100"}},
+			timeout:       0,
+			expectedQuery: url.Values{"statement_timeout": {"100"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := maps.Clone(tt.query)
+			assert.Equal(t, tt.expectedQuery, withTimeoutParam(tt.query, postgresStatementTimeoutParam, tt.timeout))
+			// original query is shared between connections, so it must not be changed.
+			assert.Equal(t, original, tt.query)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+)
+
+// maxAccessTokenLength is a maximum length of access token which fits into the cookie.
+const maxAccessTokenLength = 4096
+
+// validation rule for access token. It covers both JWT and opaque tokens in `b64token` format of RFC 6750.
+var validAccessToken = regexp.MustCompile(`^[A-Za-z0-9\-._~+/]+=*$`)
+
+// setCookie handles `GET /set-cookie/:access_token` endpoint. It stores access token in the cookie
+// and redirects either to the same-origin path provided in `redirect` query param or to `/`.
+// Cookie attributes are taken from the auth configuration.
+func setCookie(config *auth.Config) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		token := ctx.Params("access_token")
+		if len(token) > maxAccessTokenLength || !validAccessToken.MatchString(token) {
+			return fiber.NewError(fiber.StatusBadRequest, "access token has incorrect format")
+		}
+
+		redirect := ctx.Query("redirect", "/")
+		if !isSameOriginPath(redirect) {
+			return fiber.NewError(fiber.StatusBadRequest, "redirect target should be a same-origin path")
+		}
+
+		ctx.Cookie(&fiber.Cookie{
+			Name:     config.GetCookieName(),
+			Value:    token,
+			Domain:   config.AuthCookieDomain,
+			Path:     config.GetCookiePath(),
+			MaxAge:   int(config.AuthCookieMaxAge / time.Second),
+			Secure:   config.AuthCookieSecure || ctx.Protocol() == "https",
+			HTTPOnly: true,
+			SameSite: config.GetCookieSameSite(),
+		})
+		return ctx.Redirect(redirect, http.StatusFound)
+	}
+}
+
+// isSameOriginPath checks that redirect target is an absolute path without scheme and host.
+// Protocol-relative targets like `//host` and `/\host` are rejected, as browsers treat them as hosts.
+func isSameOriginPath(target string) bool {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return false
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == "" && parsed.Host == "" && parsed.User == nil
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/basicauth"
-	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -20,6 +19,7 @@ import (
 	aim2API "github.com/G-Research/fasttrackml/pkg/api/aim2"
 	aim2Controller "github.com/G-Research/fasttrackml/pkg/api/aim2/controller"
 	aimRepositories "github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
+	aimActivityService "github.com/G-Research/fasttrackml/pkg/api/aim2/services/activity"
 	aimAppService "github.com/G-Research/fasttrackml/pkg/api/aim2/services/app"
 	aimDashboardService "github.com/G-Research/fasttrackml/pkg/api/aim2/services/dashboard"
 	aimExperimentService "github.com/G-Research/fasttrackml/pkg/api/aim2/services/experiment"
@@ -30,11 +30,14 @@ import (
 	mlflowController "github.com/G-Research/fasttrackml/pkg/api/mlflow/controller"
 	mlflowRepositories "github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	mlflowService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services"
+	mlflowAPIKeyService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/apikey"
 	mlflowArtifactService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	mlflowCompactionService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/compaction"
 	mlflowExperimentService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/experiment"
 	mlflowMetricService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/metric"
 	mlflowModelService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/model"
+	mlflowRetentionService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/retention"
 	mlflowRunService "github.com/G-Research/fasttrackml/pkg/api/mlflow/services/run"
 	"github.com/G-Research/fasttrackml/pkg/common/auth"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
@@ -44,11 +47,17 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/database"
 	adminUI "github.com/G-Research/fasttrackml/pkg/ui/admin"
 	adminUIController "github.com/G-Research/fasttrackml/pkg/ui/admin/controller"
+	adminUICloneService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/clone"
+	adminUIExperimentService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/experiment"
+	adminUIMaintenanceService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/maintenance"
 	adminUINamespaceService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+	adminUIPermissionService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/permission"
+	adminUIUsageService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/usage"
 	aimUI "github.com/G-Research/fasttrackml/pkg/ui/aim"
 	"github.com/G-Research/fasttrackml/pkg/ui/chooser"
 	chooserController "github.com/G-Research/fasttrackml/pkg/ui/chooser/controller"
 	chooserNamespaceService "github.com/G-Research/fasttrackml/pkg/ui/chooser/service/namespace"
+	chooserUserService "github.com/G-Research/fasttrackml/pkg/ui/chooser/service/user"
 	mlflowUI "github.com/G-Research/fasttrackml/pkg/ui/mlflow"
 	"github.com/G-Research/fasttrackml/pkg/version"
 )
@@ -89,22 +98,27 @@ func NewServer(ctx context.Context, config *config.Config) (Server, error) {
 
 // createDBProvider creates a new DB provider.
 func createDBProvider(ctx context.Context, config *config.Config) (database.DBProvider, error) {
-	db, err := database.NewDBProvider(
+	// migrations could take a while on big databases, so they are run by the separate DB provider,
+	// which connections aren't limited by statement timeouts.
+	migrationDB, err := database.NewDBProvider(
 		config.DatabaseURI,
 		config.DatabaseSlowThreshold,
-		config.DatabasePoolMax,
+		config.GetDatabasePoolConfig(),
+		database.StatementTimeouts{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to DB: %w", err)
 	}
+	//nolint:errcheck
+	defer migrationDB.Close()
 
 	if config.DatabaseReset {
-		if err := db.Reset(); err != nil {
+		if err := migrationDB.Reset(); err != nil {
 			return nil, eris.Wrap(err, "error resetting database")
 		}
 	}
 
-	gormDBWithContext := db.GormDB().WithContext(ctx)
+	gormDBWithContext := migrationDB.GormDB().WithContext(ctx)
 	if err := database.CheckAndMigrateDB(config.DatabaseMigrate, gormDBWithContext); err != nil {
 		return nil, eris.Wrap(err, "error running database migration")
 	}
@@ -121,11 +135,32 @@ func createDBProvider(ctx context.Context, config *config.Config) (database.DBPr
 		return nil, eris.Wrap(err, "error creating default context")
 	}
 
+	// migration DB provider is closed only after this one is created, so in-memory database is kept.
+	db, err := database.NewDBProvider(
+		config.DatabaseURI,
+		config.DatabaseSlowThreshold,
+		config.GetDatabasePoolConfig(),
+		config.GetDatabaseStatementTimeouts(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to DB: %w", err)
+	}
+
 	// cache a global reference to the gorm.DB
 	database.DB = db.GormDB()
 	return db, nil
 }
 
+// bodyLimit is a maximum size of the request body, which isn't streamed.
+const bodyLimit = 16 * 1024 * 1024
+
+// isStreamedRequest checks if the request body is streamed to the handler. Only artifact parts are streamed,
+// so they aren't limited by bodyLimit and aren't held in memory.
+func isStreamedRequest(ctx *fiber.Ctx) bool {
+	return ctx.Method() == fiber.MethodPut &&
+		strings.HasSuffix(ctx.Path(), mlflowAPI.ArtifactsRoutePrefix+mlflowAPI.ArtifactsUploadsPartRoute)
+}
+
 // createApp creates a new fiber app with base configuration.
 //
 //nolint:contextcheck
@@ -136,7 +171,8 @@ func createApp(
 	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
 ) (*fiber.App, error) {
 	app := fiber.New(fiber.Config{
-		BodyLimit:             16 * 1024 * 1024,
+		BodyLimit:             bodyLimit,
+		StreamRequestBody:     true,
 		ReadBufferSize:        16384,
 		ReadTimeout:           5 * time.Second,
 		WriteTimeout:          600 * time.Second,
@@ -190,56 +226,124 @@ func createApp(
 
 	namespaceEventListener.Listen()
 
-	// attach global middlewares.
+	// reload user permissions and invalidate cached roles on SIGHUP.
+	permissionService := adminUIPermissionService.NewService(config, rolesCachedRepository)
+	if config.Auth.AuthType != "" {
+		permissionService.Start(ctx)
+	}
+
+	// create run notification listener and keep project activity summary up-to-date.
+	runEventListener, err := dao.NewRunListener(ctx, db.GormDB())
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating run notification listener")
+	}
+	activityService := aimActivityService.NewService(
+		aimRepositories.NewRunActivityRepository(db.GormDB()),
+		aimRepositories.NewExperimentRepository(db.GormDB()),
+	)
+	activityService.Subscribe(ctx, runEventListener)
+	runEventListener.Listen()
+	if config.ActivityInterval > 0 {
+		activityService.Start(ctx, config.ActivityInterval)
+	}
+
+	// start namespace retention policy scheduler.
+	if config.RetentionInterval > 0 {
+		mlflowRetentionService.NewService(
+			mlflowRepositories.NewRunNotifyingRepository(db.GormDB(), runEventListener),
+			mlflowRepositories.NewNamespaceRepository(db.GormDB()),
+		).Start(ctx, config.RetentionInterval)
+	}
+
+	// request bodies are streamed by the server only to be passed to the artifact storage,
+	// all the other bodies are read into memory up to the limit before any other middleware.
+	app.Use(middleware.NewBodyLimitMiddleware(bodyLimit, isStreamedRequest))
+
+	// client address is resolved firstly, so it's used consistently by all the other middlewares and the logger.
+	clientIP, err := middleware.NewClientIPMiddleware(config.TrustedProxies)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating client ip middleware")
+	}
+	app.Use(clientIP)
+
+	// requests are filtered by the client address before any other middleware, regardless of auth.
+	if config.IsIPFilterEnabled() {
+		ipFilter, err := middleware.NewIPFilterMiddleware(config.IPAllowlist, config.IPDenylist)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating ip filter middleware")
+		}
+		app.Use(ipFilter)
+	}
+
+	// middleware plugins are attached at the explicit positions of the chain, either before namespace
+	// and auth middlewares or right after auth middlewares, in the configured order.
+	beforeNamespacePlugins, err := middleware.NewPluginMiddlewares(config, middleware.PluginPositionBeforeNamespace)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating middleware plugins")
+	}
+	afterAuthPlugins, err := middleware.NewPluginMiddlewares(config, middleware.PluginPositionAfterAuth)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating middleware plugins")
+	}
+	for _, plugin := range beforeNamespacePlugins {
+		app.Use(plugin)
+	}
+
+	// attach global middlewares. namespace is resolved firstly, so public read access
+	// of the namespace could be checked by auth middlewares.
+	// namespace API keys are checked before other auth methods, which let through requests authenticated by them.
+	app.Use(middleware.NewNamespaceMiddleware(namespaceCachedRepository, config))
+	app.Use(middleware.NewAPIKeyMiddleware(mlflowRepositories.NewAPIKeyRepository(db.GormDB())))
 	if config.Auth.AuthUsername != "" && config.Auth.AuthPassword != "" {
 		log.Info("Auth - enabling Basic Auth")
 		app.Use(basicauth.New(basicauth.Config{
+			Next: func(ctx *fiber.Ctx) bool {
+				return middleware.IsPublicReadRequest(ctx) || middleware.IsAPIKeyRequest(ctx)
+			},
 			Users: map[string]string{
 				config.Auth.AuthUsername: config.Auth.AuthPassword,
 			},
 		}))
 	}
-	app.Get("/set-cookie/:access_token", func(ctx *fiber.Ctx) error {
-		ctx.Cookie(&fiber.Cookie{
-			Name:  "access_token",
-			Value: ctx.Params("access_token"),
-		})
-		return ctx.Redirect("/", http.StatusMovedPermanently)
-	})
-	app.Use(middleware.NewNamespaceMiddleware(namespaceCachedRepository))
+	app.Get("/set-cookie/:access_token", setCookie(&config.Auth))
 
 	// based on Auth configuration attach global OIDC or Basic Auth middleware.
 	switch {
 	case config.Auth.IsAuthTypeOIDC():
-		oidcClient, err := auth.NewOIDCClient(ctx, &config.Auth)
-		if err != nil {
-			return nil, eris.Wrap(err, "error creating OIDC client")
-		}
-		app.Use(middleware.NewOIDCMiddleware(oidcClient, rolesCachedRepository))
+		oidcClient := auth.NewOIDCProvidersClient(ctx, &config.Auth)
+		app.Use(middleware.NewOIDCMiddleware(oidcClient, rolesCachedRepository, config.Auth.GetCookieName()))
 	case config.Auth.IsAuthTypeUser():
 		app.Use(middleware.NewBasicAuthMiddleware(config.Auth.AuthParsedUserPermissions))
 	}
+	for _, plugin := range afterAuthPlugins {
+		app.Use(plugin)
+	}
 
-	app.Use(compress.New(compress.Config{
-		Next: func(c *fiber.Ctx) bool {
-			// This is a little brittle, maybe there is a better way?
-			// Do not compress metric histories as urllib3 did not support file-like compressed reads until 2.0.0a1
-			return strings.HasSuffix(c.Path(), "/metrics/get-histories")
-		},
-	}))
+	// writes are rejected in maintenance mode only after authentication,
+	// so state of maintenance mode is not exposed to anonymous users.
+	maintenanceService := adminUIMaintenanceService.NewService(config)
+	app.Use(middleware.NewMaintenanceMiddleware(maintenanceService))
+	app.Use(middleware.NewTimeoutMiddleware(config.RequestTimeout, config.ArtifactRequestTimeout))
+
+	app.Use(middleware.NewDecompressMiddleware(config.GetMaxDecompressedRequestSize()))
+	app.Use(middleware.NewCompressMiddleware())
 
 	app.Use(recover.New(recover.Config{EnableStackTrace: true}))
 	app.Use(logger.New(logger.Config{
-		Format: "${status} - ${latency} ${method} ${path}\n",
+		Format: "${status} - ${latency} ${locals:client_ip} ${method} ${path}\n",
 		Output: log.StandardLogger().Writer(),
 	}))
 
+	// bodies are logged after compression middleware, so they are logged uncompressed.
+	if config.IsDebugBodyLogEnabled() {
+		app.Use(middleware.NewBodyLoggerMiddleware(config.DebugBodyLogPrefixes, config.DebugBodyLogMaxSize))
+	}
+
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.SendString("OK")
 	})
-	app.Get("/version", func(c *fiber.Ctx) error {
-		return c.SendString(version.Version)
-	})
+	app.Get("/version", newVersionHandler(config, false))
+	app.Get("/version.json", newVersionHandler(config, true))
 
 	if config.AimRevert {
 		// init original `aim` api routes.
@@ -249,8 +353,19 @@ func createApp(
 	} else {
 		// init `aim` api refactored routes.
 		log.Info("using refactored aim service")
+		projectService := aimProjectService.NewService(
+			config,
+			aimRepositories.NewTagRepository(db.GormDB()),
+			aimRepositories.NewRunRepository(db.GormDB()),
+			aimRepositories.NewParamRepository(db.GormDB()),
+			aimRepositories.NewMetricRepository(db.GormDB()),
+			aimRepositories.NewExperimentRepository(db.GormDB()),
+			aimRepositories.NewRunActivityRepository(db.GormDB()),
+		)
+		projectService.Subscribe(ctx, namespaceEventListener)
 		aim2API.NewRouter(
 			aim2Controller.NewController(
+				config,
 				aimTagService.NewService(
 					aimRepositories.NewTagRepository(db.GormDB()),
 				),
@@ -258,36 +373,47 @@ func createApp(
 					aimRepositories.NewAppRepository(db.GormDB()),
 				),
 				aimRunService.NewService(
-					aimRepositories.NewRunRepository(db.GormDB()),
+					config,
+					aimRepositories.NewRunNotifyingRepository(db.GormDB(), runEventListener),
 					aimRepositories.NewMetricRepository(db.GormDB()),
 				),
-				aimProjectService.NewService(
-					aimRepositories.NewTagRepository(db.GormDB()),
-					aimRepositories.NewRunRepository(db.GormDB()),
-					aimRepositories.NewParamRepository(db.GormDB()),
-					aimRepositories.NewMetricRepository(db.GormDB()),
-					aimRepositories.NewExperimentRepository(db.GormDB()),
-					config.LiveUpdatesEnabled,
-				),
+				projectService,
 				aimDashboardService.NewService(
 					aimRepositories.NewDashboardRepository(db.GormDB()),
 					aimRepositories.NewAppRepository(db.GormDB()),
 				),
 				aimExperimentService.NewService(
+					config,
 					aimRepositories.NewTagRepository(db.GormDB()),
-					aimRepositories.NewExperimentRepository(db.GormDB()),
+					aimRepositories.NewExperimentNotifyingRepository(db.GormDB(), runEventListener),
 				),
 			),
 		).Init(app)
 	}
 
+	// default order of run search is validated here, as supported sort fields are known only by run search.
+	if err := mlflowRunService.ValidateSearchRunsDefaultOrderBy(config); err != nil {
+		return nil, eris.Wrap(err, "error validating run search default order configuration")
+	}
+
 	// init `mlflow` api and ui routes.
 	// TODO:DSuhinin right now it might look scary. we prettify it a bit later.
+	// abandoned chunked artifact uploads are aborted, once they expire.
+	artifactService := mlflowArtifactService.NewService(
+		config,
+		mlflowRepositories.NewRunRepository(db.GormDB()),
+		mlflowRepositories.NewArtifactUploadRepository(db.GormDB()),
+		artifactStorageFactory,
+	)
+	artifactService.Start(ctx, config.GetArtifactUploadSessionTTL())
+
 	mlflowAPI.NewRouter(
 		mlflowController.NewController(
+			config,
 			mlflowRunService.NewService(
+				config,
 				mlflowRepositories.NewTagRepository(db.GormDB()),
-				mlflowRepositories.NewRunRepository(db.GormDB()),
+				mlflowRepositories.NewRunNotifyingRepository(db.GormDB(), runEventListener),
 				mlflowRepositories.NewParamRepository(db.GormDB()),
 				mlflowRepositories.NewMetricRepository(db.GormDB()),
 				mlflowRepositories.NewExperimentRepository(db.GormDB()),
@@ -297,16 +423,18 @@ func createApp(
 				mlflowRepositories.NewRunRepository(db.GormDB()),
 				mlflowRepositories.NewMetricRepository(db.GormDB()),
 			),
-			mlflowArtifactService.NewService(
-				mlflowRepositories.NewRunRepository(db.GormDB()),
-				artifactStorageFactory,
-			),
+			artifactService,
 			mlflowExperimentService.NewService(
 				config,
 				mlflowRepositories.NewTagRepository(db.GormDB()),
-				mlflowRepositories.NewExperimentRepository(db.GormDB()),
+				mlflowRepositories.NewExperimentNotifyingRepository(db.GormDB(), runEventListener),
+			),
+			mlflowAPIKeyService.NewService(
+				mlflowRepositories.NewAPIKeyRepository(db.GormDB()),
 			),
 		),
+	).WithTransactionMiddleware(
+		middleware.NewTransactionMiddleware(db.GormDB()),
 	).Init(app)
 
 	mlflowUI.AddRoutes(app)
@@ -320,7 +448,32 @@ func createApp(
 				namespaceCachedRepository,
 				mlflowRepositories.NewExperimentRepository(db.GormDB()),
 			),
+			permissionService,
+			maintenanceService,
+			adminUIUsageService.NewService(
+				mlflowRepositories.NewNamespaceUsageRepository(db.GormDB()),
+				artifactStorageFactory,
+			),
+			mlflowCompactionService.NewService(
+				mlflowRepositories.NewLatestMetricRepository(db.GormDB()),
+			),
+			adminUIExperimentService.NewService(
+				config,
+				namespaceCachedRepository,
+				mlflowRepositories.NewExperimentRepository(db.GormDB()),
+				aimRepositories.NewRunActivityRepository(db.GormDB()),
+				artifactStorageFactory,
+			),
+			adminUICloneService.NewService(
+				config,
+				namespaceCachedRepository,
+				mlflowRepositories.NewNamespaceCloneRepository(db.GormDB()),
+				aimRepositories.NewRunActivityRepository(db.GormDB()),
+				artifactStorageFactory,
+			),
 		),
+	).WithTransactionMiddleware(
+		middleware.NewTransactionMiddleware(db.GormDB()),
 	).Init(app); err != nil {
 		return nil, eris.Wrap(err, "error initializing admin routes")
 	}
@@ -332,6 +485,7 @@ func createApp(
 				config,
 				namespaceCachedRepository,
 			),
+			chooserUserService.NewService(config),
 		),
 	).Init(app); err != nil {
 		return nil, eris.Wrap(err, "error initializing chooser routes")
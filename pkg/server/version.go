@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/version"
+)
+
+// VersionResponse represents build and runtime information returned by `GET /version` endpoint in JSON.
+type VersionResponse struct {
+	version.Info
+	Features VersionFeaturesResponse `json:"features"`
+}
+
+// VersionFeaturesResponse represents enabled features of the running instance.
+type VersionFeaturesResponse struct {
+	AuthType       string `json:"auth_type"`
+	StorageBackend string `json:"storage_backend"`
+}
+
+// newVersionHandler creates handler of `GET /version` endpoint. Build and runtime information is returned
+// in JSON, when it is forced or requested via `Accept` header, otherwise plain version string is returned.
+func newVersionHandler(config *config.Config, forceJSON bool) fiber.Handler {
+	resp := VersionResponse{
+		Info: version.GetInfo(),
+		Features: VersionFeaturesResponse{
+			AuthType:       getAuthType(config),
+			StorageBackend: getStorageBackend(config),
+		},
+	}
+	return func(ctx *fiber.Ctx) error {
+		if forceJSON || ctx.Accepts(fiber.MIMETextPlain, fiber.MIMEApplicationJSON) == fiber.MIMEApplicationJSON {
+			return ctx.JSON(resp)
+		}
+		return ctx.SendString(version.Version)
+	}
+}
+
+// getAuthType returns type of enabled authentication or `none`.
+func getAuthType(config *config.Config) string {
+	switch {
+	case config.Auth.AuthType != "":
+		return config.Auth.AuthType
+	case config.Auth.AuthUsername != "" && config.Auth.AuthPassword != "":
+		return "basic"
+	}
+	return "none"
+}
+
+// getStorageBackend returns storage backend of the default artifact root.
+func getStorageBackend(config *config.Config) string {
+	parsed, err := url.Parse(config.DefaultArtifactRoot)
+	if err != nil || parsed.Scheme == "" {
+		return "file"
+	}
+	return parsed.Scheme
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/clone"
+)
+
+// CloneNamespace clones experiments, apps and dashboards, and optionally runs, of a namespace into another one.
+func (c Controller) CloneNamespace(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	var req request.NamespaceClone
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse request body")
+	}
+	if req.Code == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "code is required")
+	}
+
+	result, err := c.cloneService.CloneNamespace(
+		ctx.Context(), uint(id), req.Code, req.Description, clone.Options{
+			IncludeRuns:      req.IncludeRuns,
+			IncludeArtifacts: req.IncludeArtifacts,
+		},
+	)
+	if err != nil {
+		var apiError *api.ErrorResponse
+		switch {
+		case errors.Is(err, clone.ErrNamespaceNotFound):
+			return fiber.NewError(fiber.StatusNotFound, clone.ErrNamespaceNotFound.Error())
+		case errors.Is(err, clone.ErrSameNamespace):
+			return fiber.NewError(fiber.StatusBadRequest, clone.ErrSameNamespace.Error())
+		case errors.As(err, &apiError):
+			return fiber.NewError(fiber.StatusBadRequest, apiError.Message)
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, "unable to clone namespace")
+		}
+	}
+	return ctx.JSON(response.NamespaceClone{
+		NamespaceID: result.Namespace.ID,
+		Code:        result.Namespace.Code,
+		Experiments: result.Experiments,
+		Runs:        result.Runs,
+		Apps:        result.Apps,
+		Dashboards:  result.Dashboards,
+		Artifacts:   result.Artifacts,
+	})
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+)
+
+// CompactLatestMetrics recomputes the latest metrics from the metrics history and fixes the drifted ones.
+func (c Controller) CompactLatestMetrics(ctx *fiber.Ctx) error {
+	var req request.LatestMetricsCompaction
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse request query")
+	}
+	if req.BatchSize < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "batch_size should be greater than or equal to 0")
+	}
+	result, err := c.compactionService.CompactLatestMetrics(ctx.Context(), req.BatchSize)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "unable to compact latest metrics")
+	}
+	return ctx.JSON(response.LatestMetricsCompaction{
+		Runs:    result.Runs,
+		Created: result.Created,
+		Updated: result.Updated,
+		Deleted: result.Deleted,
+	})
+}
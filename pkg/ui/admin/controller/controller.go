@@ -1,15 +1,43 @@
 package controller
 
-import "github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/compaction"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/clone"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/experiment"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/maintenance"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/permission"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/usage"
+)
 
 // Controller contains all the request handler functions for the admin ui.
 type Controller struct {
-	namespaceService *namespace.Service
+	namespaceService   *namespace.Service
+	permissionService  *permission.Service
+	maintenanceService *maintenance.Service
+	usageService       *usage.Service
+	compactionService  *compaction.Service
+	experimentService  *experiment.Service
+	cloneService       *clone.Service
 }
 
 // NewController creates new Controller instance.
-func NewController(namespaceService *namespace.Service) *Controller {
+func NewController(
+	namespaceService *namespace.Service,
+	permissionService *permission.Service,
+	maintenanceService *maintenance.Service,
+	usageService *usage.Service,
+	compactionService *compaction.Service,
+	experimentService *experiment.Service,
+	cloneService *clone.Service,
+) *Controller {
 	return &Controller{
-		namespaceService: namespaceService,
+		namespaceService:   namespaceService,
+		permissionService:  permissionService,
+		maintenanceService: maintenanceService,
+		usageService:       usageService,
+		compactionService:  compactionService,
+		experimentService:  experimentService,
+		cloneService:       cloneService,
 	}
 }
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/experiment"
+)
+
+// MoveExperiment reassigns an experiment, together with its runs, to another namespace.
+func (c Controller) MoveExperiment(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	var req request.ExperimentNamespace
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse request body")
+	}
+	if req.NamespaceID == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "namespace_id is required")
+	}
+
+	movedExperiment, err := c.experimentService.MoveExperiment(ctx.UserContext(), int32(id), req.NamespaceID)
+	if err != nil {
+		switch {
+		case errors.Is(err, experiment.ErrExperimentNotFound):
+			return fiber.NewError(fiber.StatusNotFound, experiment.ErrExperimentNotFound.Error())
+		case errors.Is(err, experiment.ErrNamespaceNotFound):
+			return fiber.NewError(fiber.StatusNotFound, experiment.ErrNamespaceNotFound.Error())
+		case errors.Is(err, experiment.ErrExperimentAlreadyExists):
+			return fiber.NewError(fiber.StatusConflict, experiment.ErrExperimentAlreadyExists.Error())
+		case errors.Is(err, experiment.ErrDefaultExperiment):
+			return fiber.NewError(fiber.StatusBadRequest, experiment.ErrDefaultExperiment.Error())
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, "unable to move experiment")
+		}
+	}
+	return ctx.JSON(response.Experiment{
+		ID:          *movedExperiment.ID,
+		Name:        movedExperiment.Name,
+		NamespaceID: movedExperiment.NamespaceID,
+	})
+}
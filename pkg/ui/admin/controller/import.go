@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+)
+
+// ImportNamespaces creates namespaces from the YAML or JSON list of namespace definitions.
+func (c Controller) ImportNamespaces(ctx *fiber.Ctx) error {
+	var req request.NamespaceImport
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+	definitions, err := namespace.ParseDefinitions(ctx.Body())
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse namespace definitions")
+	}
+
+	results := c.namespaceService.ImportNamespaces(ctx.Context(), definitions, req.UpdateExisting)
+	resp := response.NamespaceImport{Results: make([]response.NamespaceImportResult, 0, len(results))}
+	for _, result := range results {
+		resp.Results = append(resp.Results, response.NamespaceImportResult{
+			Code:   result.Code,
+			Status: result.Status,
+			Error:  result.Error,
+		})
+	}
+	return ctx.JSON(resp)
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+// GetMaintenanceMode returns current state of maintenance mode.
+func (c Controller) GetMaintenanceMode(ctx *fiber.Ctx) error {
+	return ctx.JSON(fiber.Map{
+		"enabled": c.maintenanceService.IsEnabled(),
+	})
+}
+
+// SetMaintenanceMode enables or disables maintenance mode.
+func (c Controller) SetMaintenanceMode(ctx *fiber.Ctx) error {
+	var req request.MaintenanceMode
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse request body")
+	}
+	c.maintenanceService.SetEnabled(req.Enabled)
+	state := "disabled"
+	if req.Enabled {
+		state = "enabled"
+	}
+	return ctx.JSON(fiber.Map{
+		"status":  StatusSuccess,
+		"message": fmt.Sprintf("Successfully %s maintenance mode.", state),
+	})
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
 	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
 	"github.com/G-Research/fasttrackml/pkg/ui/common"
@@ -31,8 +32,13 @@ func (c Controller) GetNamespace(ctx *fiber.Ctx) error {
 	if namespace == nil {
 		return fiber.NewError(fiber.StatusNotFound, "namespace not found")
 	}
+	counts, err := c.usageService.GetNamespaceLifecycleStageCounts(ctx.Context(), namespace.ID)
+	if err != nil {
+		return fiber.NewError(fiber.ErrInternalServerError.Code, "unable to count namespace experiments and runs")
+	}
 	return ctx.Render("namespaces/update", fiber.Map{
-		"Namespace": namespace,
+		"Namespace":            namespace,
+		"LifecycleStageCounts": counts,
 	})
 }
 
@@ -50,7 +56,9 @@ func (c Controller) CreateNamespace(ctx *fiber.Ctx) error {
 	if err := ctx.BodyParser(&namespace); err != nil {
 		return fiber.NewError(400, "unable to parse request body")
 	}
-	_, err := c.namespaceService.CreateNamespace(ctx.Context(), namespace.Code, namespace.Description)
+	_, err := c.namespaceService.CreateNamespace(
+		ctx.Context(), namespace.Code, namespace.Description, namespace.PublicRead,
+	)
 	if err != nil {
 		return ctx.Render("namespaces/create", fiber.Map{
 			"Namespace": namespace,
@@ -72,7 +80,7 @@ func (c Controller) UpdateNamespace(ctx *fiber.Ctx) error {
 		return fiber.NewError(400, "unable to parse request body")
 	}
 
-	_, err = c.namespaceService.UpdateNamespace(ctx.Context(), uint(id), req.Code, req.Description)
+	_, err = c.namespaceService.UpdateNamespace(ctx.Context(), uint(id), req.Code, req.Description, req.PublicRead)
 	if err != nil {
 		return ctx.JSON(fiber.Map{
 			"status":  StatusError,
@@ -85,6 +93,62 @@ func (c Controller) UpdateNamespace(ctx *fiber.Ctx) error {
 	})
 }
 
+// GetNamespaceRetentionPolicy returns run retention policy of a namespace.
+func (c Controller) GetNamespaceRetentionPolicy(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	namespace, err := c.namespaceService.GetNamespace(ctx.Context(), uint(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "unable to find namespace")
+	}
+	if namespace == nil {
+		return fiber.NewError(fiber.StatusNotFound, "namespace not found")
+	}
+	return ctx.JSON(newNamespaceRetentionPolicyResponse(namespace.RetentionPolicy))
+}
+
+// UpdateNamespaceRetentionPolicy updates run retention policy of a namespace.
+func (c Controller) UpdateNamespaceRetentionPolicy(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "unable to parse id")
+	}
+	var req request.NamespaceRetentionPolicy
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse request body")
+	}
+	policy := models.RetentionPolicy{
+		MaxAgeDays:      req.MaxAgeDays,
+		KeepBest:        req.KeepBest,
+		MetricKey:       req.MetricKey,
+		MetricDirection: models.MetricDirection(req.MetricDirection),
+	}
+	if err := policy.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	namespace, err := c.namespaceService.UpdateRetentionPolicy(ctx.Context(), uint(id), policy)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "unable to update namespace retention policy")
+	}
+	if namespace == nil {
+		return fiber.NewError(fiber.StatusNotFound, "namespace not found")
+	}
+	return ctx.JSON(newNamespaceRetentionPolicyResponse(namespace.RetentionPolicy))
+}
+
+// newNamespaceRetentionPolicyResponse converts models.RetentionPolicy into response object.
+func newNamespaceRetentionPolicyResponse(policy models.RetentionPolicy) response.NamespaceRetentionPolicy {
+	return response.NamespaceRetentionPolicy{
+		MaxAgeDays:      policy.MaxAgeDays,
+		KeepBest:        policy.KeepBest,
+		MetricKey:       policy.MetricKey,
+		MetricDirection: string(policy.MetricDirection),
+	}
+}
+
 // DeleteNamespace deletes a namespace record.
 func (c Controller) DeleteNamespace(ctx *fiber.Ctx) error {
 	id, err := ctx.ParamsInt("id")
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/common"
+)
+
+// ReloadPermissions reloads user permissions and invalidates cached roles.
+func (c Controller) ReloadPermissions(ctx *fiber.Ctx) error {
+	if err := c.permissionService.ReloadPermissions(ctx.Context()); err != nil {
+		return ctx.JSON(fiber.Map{
+			"status":  StatusError,
+			"message": common.ErrorMessageForUI("permissions", err.Error()),
+		})
+	}
+	return ctx.JSON(fiber.Map{
+		"status":  StatusSuccess,
+		"message": "Successfully reloaded permissions.",
+	})
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/usage"
+)
+
+// GetNamespaceUsage returns page of the namespace usage report.
+func (c Controller) GetNamespaceUsage(ctx *fiber.Ctx) error {
+	var req request.NamespaceUsage
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "unable to parse request query")
+	}
+	if req.Offset < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "offset should be greater than or equal to 0")
+	}
+	if req.Limit < 0 || req.Limit > usage.MaxLimit {
+		return fiber.NewError(
+			fiber.StatusBadRequest, fmt.Sprintf("limit should be between 0 and %d", usage.MaxLimit),
+		)
+	}
+	report, err := c.usageService.GetNamespaceUsageReport(ctx.Context(), &req)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "unable to get namespace usage report")
+	}
+	return ctx.JSON(report)
+}
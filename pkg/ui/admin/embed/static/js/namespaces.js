@@ -10,6 +10,8 @@ function handleUpdateNamespace() {
     formData.forEach(function(entry) {
       formDataObject[entry.name] = entry.value;
     });
+    // unchecked checkboxes are not serialized, so send the flag explicitly.
+    formDataObject["public_read"] = $("#public_read").is(":checked");
 
     // Perform a PUT request using jQuery's $.ajax
     $.ajax({
@@ -49,6 +51,15 @@ function deleteNamespace(id) {
   }).done(handleResponse);
 }
 
+function reloadPermissions() {
+  // Perform a POST request using jQuery's $.ajax
+  $.ajax({
+    url: '/admin/permissions/reload',
+    type: "POST",
+    contentType: "application/json",
+  }).done(handleResponse);
+}
+
 function handleResponse(data, jqxhr, status) {
   if (data['status'] == 'success'){
     redirectTo('/admin/namespaces/'
@@ -0,0 +1,6 @@
+package request
+
+// LatestMetricsCompaction represents the data to start compaction of the latest metrics.
+type LatestMetricsCompaction struct {
+	BatchSize int `query:"batch_size"`
+}
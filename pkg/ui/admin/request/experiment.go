@@ -0,0 +1,6 @@
+package request
+
+// ExperimentNamespace represents the data to move an experiment to another namespace.
+type ExperimentNamespace struct {
+	NamespaceID uint `json:"namespace_id"`
+}
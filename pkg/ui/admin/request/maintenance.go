@@ -0,0 +1,6 @@
+package request
+
+// MaintenanceMode represents the data to enable or disable maintenance mode.
+type MaintenanceMode struct {
+	Enabled bool `json:"enabled"`
+}
@@ -4,4 +4,27 @@ package request
 type Namespace struct {
 	Code        string `json:"code"`
 	Description string `json:"description"`
+	PublicRead  bool   `json:"public_read" form:"public_read"`
+}
+
+// NamespaceRetentionPolicy represents the data to update run retention policy of a Namespace.
+type NamespaceRetentionPolicy struct {
+	MaxAgeDays      *int32 `json:"max_age_days"`
+	KeepBest        *int32 `json:"keep_best"`
+	MetricKey       string `json:"metric_key"`
+	MetricDirection string `json:"metric_direction"`
+}
+
+// NamespaceClone represents the data to clone a Namespace into another one.
+type NamespaceClone struct {
+	Code             string `json:"code"`
+	Description      string `json:"description"`
+	IncludeRuns      bool   `json:"include_runs"`
+	IncludeArtifacts bool   `json:"include_artifacts"`
+}
+
+// NamespaceImport represents the options of the namespaces import.
+// The namespace definitions themselves are provided as YAML or JSON request body.
+type NamespaceImport struct {
+	UpdateExisting bool `query:"update_existing"`
 }
@@ -0,0 +1,7 @@
+package request
+
+// NamespaceUsage represents the data to request page of the namespace usage report.
+type NamespaceUsage struct {
+	Offset int `query:"offset"`
+	Limit  int `query:"limit"`
+}
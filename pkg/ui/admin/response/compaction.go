@@ -0,0 +1,9 @@
+package response
+
+// LatestMetricsCompaction represents result of the latest metrics compaction.
+type LatestMetricsCompaction struct {
+	Runs    int64 `json:"runs"`
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+	Deleted int64 `json:"deleted"`
+}
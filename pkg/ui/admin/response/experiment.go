@@ -0,0 +1,8 @@
+package response
+
+// Experiment represents the data of an experiment moved to another namespace.
+type Experiment struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	NamespaceID uint   `json:"namespace_id"`
+}
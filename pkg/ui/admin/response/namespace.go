@@ -9,6 +9,38 @@ type Namespace struct {
 	ID          uint       `json:"id"`
 	Code        string     `json:"code"`
 	Description string     `json:"description"`
+	PublicRead  bool       `json:"public_read"`
 	CreatedAt   time.Time  `json:"created_at"`
 	DeletedAt   *time.Time `json:"deleted_at"`
 }
+
+// NamespaceRetentionPolicy represents run retention policy of a Namespace.
+type NamespaceRetentionPolicy struct {
+	MaxAgeDays      *int32 `json:"max_age_days"`
+	KeepBest        *int32 `json:"keep_best"`
+	MetricKey       string `json:"metric_key"`
+	MetricDirection string `json:"metric_direction"`
+}
+
+// NamespaceClone represents the result of cloning a Namespace into another one.
+type NamespaceClone struct {
+	NamespaceID uint   `json:"namespace_id"`
+	Code        string `json:"code"`
+	Experiments int64  `json:"experiments"`
+	Runs        int64  `json:"runs"`
+	Apps        int64  `json:"apps"`
+	Dashboards  int64  `json:"dashboards"`
+	Artifacts   int64  `json:"artifacts"`
+}
+
+// NamespaceImportResult represents the result of import of one Namespace.
+type NamespaceImportResult struct {
+	Code   string `json:"code"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NamespaceImport represents the result of import of several Namespaces.
+type NamespaceImport struct {
+	Results []NamespaceImportResult `json:"results"`
+}
@@ -0,0 +1,26 @@
+package response
+
+// NamespaceUsage represents usage of one namespace.
+type NamespaceUsage struct {
+	ID               uint   `json:"id"`
+	Code             string `json:"code"`
+	RunCount         int64  `json:"run_count"`
+	ExperimentCount  int64  `json:"experiment_count"`
+	MetricCount      int64  `json:"metric_count"`
+	ArtifactBytes    int64  `json:"artifact_bytes"`
+	LastActivityTime int64  `json:"last_activity_time"`
+}
+
+// NamespaceUsageReport represents page of the namespace usage report.
+type NamespaceUsageReport struct {
+	Namespaces []NamespaceUsage `json:"namespaces"`
+	NextOffset *int             `json:"next_offset,omitempty"`
+}
+
+// NamespaceLifecycleStageCounts represents numbers of active and deleted experiments and runs of one namespace.
+type NamespaceLifecycleStageCounts struct {
+	ActiveExperimentCount  int64 `json:"active_experiment_count"`
+	DeletedExperimentCount int64 `json:"deleted_experiment_count"`
+	ActiveRunCount         int64 `json:"active_run_count"`
+	DeletedRunCount        int64 `json:"deleted_run_count"`
+}
@@ -19,8 +19,9 @@ var content embed.FS
 
 // Router represents `admin` router.
 type Router struct {
-	controller        *controller.Controller
-	globalMiddlewares []fiber.Handler
+	controller            *controller.Controller
+	globalMiddlewares     []fiber.Handler
+	transactionMiddleware func(handler fiber.Handler) fiber.Handler
 }
 
 // NewRouter creates new instance of `admin` router.
@@ -54,10 +55,44 @@ func (r *Router) Init(router fiber.Router) error {
 	}
 	namespaces.Get("/", r.controller.GetNamespaces)
 	namespaces.Post("/", r.controller.CreateNamespace)
+	namespaces.Post("/import", r.controller.ImportNamespaces)
 	namespaces.Get("/new", r.controller.NewNamespace)
+	namespaces.Get("/usage", r.controller.GetNamespaceUsage)
 	namespaces.Get("/:id<int>/", r.controller.GetNamespace)
 	namespaces.Put("/:id<int>/", r.controller.UpdateNamespace)
 	namespaces.Delete("/:id<int>/", r.controller.DeleteNamespace)
+	namespaces.Post("/:id<int>/clone", r.controller.CloneNamespace)
+	namespaces.Get("/:id<int>/retention", r.controller.GetNamespaceRetentionPolicy)
+	namespaces.Put("/:id<int>/retention", r.controller.UpdateNamespaceRetentionPolicy)
+
+	permissions := app.Group("permissions")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		permissions.Use(globalMiddleware)
+	}
+	permissions.Post("/reload", r.controller.ReloadPermissions)
+
+	maintenance := app.Group("maintenance")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		maintenance.Use(globalMiddleware)
+	}
+	maintenance.Get("/", r.controller.GetMaintenanceMode)
+	maintenance.Post("/", r.controller.SetMaintenanceMode)
+
+	compaction := app.Group("compaction")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		compaction.Use(globalMiddleware)
+	}
+	compaction.Post("/latest-metrics", r.controller.CompactLatestMetrics)
+
+	experiments := app.Group("experiments")
+	// apply global middlewares.
+	for _, globalMiddleware := range r.globalMiddlewares {
+		experiments.Use(globalMiddleware)
+	}
+	experiments.Put("/:id<int>/namespace", r.transactional(r.controller.MoveExperiment))
 
 	// default route
 	app.Use("/", etag.New(), filesystem.New(filesystem.Config{
@@ -72,3 +107,17 @@ func (r *Router) AddGlobalMiddleware(middleware fiber.Handler) *Router {
 	r.globalMiddlewares = append(r.globalMiddlewares, middleware)
 	return r
 }
+
+// WithTransactionMiddleware sets a middleware, which wraps handlers of multi-step routes into a transaction.
+func (r *Router) WithTransactionMiddleware(middleware func(handler fiber.Handler) fiber.Handler) *Router {
+	r.transactionMiddleware = middleware
+	return r
+}
+
+// transactional wraps the handler with transaction middleware, if any.
+func (r *Router) transactional(handler fiber.Handler) fiber.Handler {
+	if r.transactionMiddleware == nil {
+		return handler
+	}
+	return r.transactionMiddleware(handler)
+}
@@ -0,0 +1,214 @@
+package clone
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	aimRepositories "github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+)
+
+var (
+	// ErrNamespaceNotFound is returned, when the cloned namespace doesn't exist.
+	ErrNamespaceNotFound = errors.New("namespace not found")
+	// ErrSameNamespace is returned, when the namespace is cloned into itself.
+	ErrSameNamespace = errors.New("namespace can't be cloned into itself")
+)
+
+// Options represents options of the namespace cloning.
+type Options struct {
+	// IncludeRuns enables cloning of the runs, together with their params, tags and metrics.
+	IncludeRuns bool
+	// IncludeArtifacts enables copying of the run artifacts. It has effect only together with IncludeRuns.
+	IncludeArtifacts bool
+}
+
+// Result represents result of the namespace cloning. Numbers count only the entities created by this
+// cloning, so repeated cloning into the same namespace reports only what has been left to clone.
+type Result struct {
+	Namespace   *models.Namespace
+	Experiments int64
+	Runs        int64
+	Apps        int64
+	Dashboards  int64
+	Artifacts   int64
+}
+
+// Service provides service layer to work with namespace `clone` business logic.
+type Service struct {
+	config                   *config.Config
+	namespaceRepository      repositories.NamespaceRepositoryProvider
+	namespaceCloneRepository repositories.NamespaceCloneRepositoryProvider
+	runActivityRepository    aimRepositories.RunActivityRepositoryProvider
+	artifactStorageFactory   storage.ArtifactStorageFactoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	config *config.Config,
+	namespaceRepository repositories.NamespaceRepositoryProvider,
+	namespaceCloneRepository repositories.NamespaceCloneRepositoryProvider,
+	runActivityRepository aimRepositories.RunActivityRepositoryProvider,
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) *Service {
+	return &Service{
+		config:                   config,
+		namespaceRepository:      namespaceRepository,
+		namespaceCloneRepository: namespaceCloneRepository,
+		runActivityRepository:    runActivityRepository,
+		artifactStorageFactory:   artifactStorageFactory,
+	}
+}
+
+// CloneNamespace clones experiments, apps and dashboards, and optionally runs and their artifacts,
+// of the namespace with id into the namespace with code, which is created if it doesn't exist yet.
+// Cloned entities get new IDs. Experiments are matched by name and all the other entities by IDs
+// derived from the source ones, so cloning is idempotent and interrupted cloning is resumed just by
+// cloning into the same namespace again.
+func (s Service) CloneNamespace(
+	ctx context.Context, id uint, code, description string, options Options,
+) (*Result, error) {
+	source, err := s.namespaceRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting namespace by id: %d", id)
+	}
+	if source == nil {
+		return nil, eris.Wrapf(ErrNamespaceNotFound, "error getting namespace by id: %d", id)
+	}
+
+	target, err := s.getOrCreateTargetNamespace(ctx, source, code, description)
+	if err != nil {
+		return nil, err
+	}
+
+	result := Result{Namespace: target}
+	experiments, err := s.namespaceCloneRepository.ListExperiments(ctx, source.ID)
+	if err != nil {
+		return nil, eris.Wrap(err, "error listing experiments to clone")
+	}
+	for i := range experiments {
+		experiment := &experiments[i]
+		clonedExperiment, created, err := s.namespaceCloneRepository.CloneExperiment(
+			ctx, experiment, target, s.config.DefaultArtifactRoot,
+		)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error cloning experiment with id: %d", *experiment.ID)
+		}
+		if created {
+			result.Experiments++
+		}
+		if options.IncludeRuns {
+			if err := s.cloneRuns(ctx, experiment, clonedExperiment, options, &result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if result.Apps, result.Dashboards, err = s.namespaceCloneRepository.CloneApps(
+		ctx, source.ID, target.ID,
+	); err != nil {
+		return nil, eris.Wrap(err, "error cloning apps")
+	}
+
+	// run activity is summarized by namespace, so it has to include the cloned runs. It is rebuilt
+	// even if no runs have been created, as the previous, interrupted, cloning could have created them.
+	if options.IncludeRuns {
+		if err := s.runActivityRepository.RebuildByNamespaceID(ctx, target.ID); err != nil {
+			return nil, eris.Wrapf(err, "error rebuilding run activity of namespace with id: %d", target.ID)
+		}
+	}
+
+	log.Infof(
+		"cloned namespace '%s' into '%s': %d experiments, %d runs, %d apps, %d dashboards, %d artifacts",
+		source.Code, target.Code, result.Experiments, result.Runs, result.Apps, result.Dashboards, result.Artifacts,
+	)
+	return &result, nil
+}
+
+// getOrCreateTargetNamespace returns the namespace with code, creating it, together with its default
+// experiment, if it doesn't exist yet. Already existing namespace means that cloning is resumed.
+func (s Service) getOrCreateTargetNamespace(
+	ctx context.Context, source *models.Namespace, code, description string,
+) (*models.Namespace, error) {
+	code = namespace.NormalizeNamespace(code)
+	target, err := s.namespaceRepository.GetByCode(ctx, code)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting namespace by code: %s", code)
+	}
+	if target != nil {
+		if target.ID == source.ID {
+			return nil, eris.Wrapf(ErrSameNamespace, "error cloning namespace with code: %s", code)
+		}
+		return target, nil
+	}
+
+	if err := namespace.ValidateNamespace(code); err != nil {
+		return nil, eris.Wrap(err, "error validating namespace")
+	}
+	if description == "" {
+		description = source.Description
+	}
+	target = &models.Namespace{
+		Code:                code,
+		Description:         description,
+		PublicRead:          source.PublicRead,
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	}
+	timestamp := time.Now().UTC().UnixMilli()
+	experiment := models.Experiment{
+		Name:           models.DefaultExperimentName,
+		CreationTime:   sql.NullInt64{Int64: timestamp, Valid: true},
+		LifecycleStage: models.LifecycleStageActive,
+		LastUpdateTime: sql.NullInt64{Int64: timestamp, Valid: true},
+	}
+	if err := s.namespaceRepository.CreateWithDefaultExperiment(
+		ctx, target, &experiment, s.config.DefaultArtifactRoot,
+	); err != nil {
+		return nil, eris.Wrap(err, "error creating namespace")
+	}
+	return target, nil
+}
+
+// cloneRuns clones all the runs of the experiment into the cloned experiment and copies their artifacts,
+// when it's requested. Artifacts are copied for already cloned runs too, as their copying could have
+// been interrupted.
+func (s Service) cloneRuns(
+	ctx context.Context,
+	experiment, clonedExperiment *models.Experiment,
+	options Options,
+	result *Result,
+) error {
+	runIDs, err := s.namespaceCloneRepository.ListRunIDs(ctx, *experiment.ID)
+	if err != nil {
+		return eris.Wrapf(err, "error listing runs of experiment with id: %d", *experiment.ID)
+	}
+	for _, runID := range runIDs {
+		clonedRun, err := s.namespaceCloneRepository.CloneRun(ctx, runID, clonedExperiment)
+		if err != nil {
+			return eris.Wrapf(err, "error cloning run with id: %s", runID)
+		}
+		if clonedRun.Created {
+			result.Runs++
+		}
+		if options.IncludeArtifacts && clonedRun.SourceArtifactURI != "" {
+			artifacts, err := storage.CopyArtifacts(
+				ctx, s.artifactStorageFactory, clonedRun.SourceArtifactURI, clonedRun.ArtifactURI,
+			)
+			if err != nil {
+				return eris.Wrapf(err, "error copying artifacts of run with id: %s", runID)
+			}
+			result.Artifacts += artifacts
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rotisserie/eris"
+
+	log "github.com/sirupsen/logrus"
+
+	aimRepositories "github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+var (
+	// ErrExperimentNotFound is returned, when the moved experiment doesn't exist.
+	ErrExperimentNotFound = errors.New("experiment not found")
+	// ErrNamespaceNotFound is returned, when the target namespace doesn't exist.
+	ErrNamespaceNotFound = errors.New("namespace not found")
+	// ErrExperimentAlreadyExists is returned, when the target namespace has an experiment with the same name.
+	ErrExperimentAlreadyExists = errors.New("experiment with the same name already exists in the namespace")
+	// ErrDefaultExperiment is returned, when the moved experiment is the default experiment of its namespace.
+	ErrDefaultExperiment = errors.New("default experiment of the namespace can't be moved")
+)
+
+// Service provides service layer to work with `experiment` business logic.
+type Service struct {
+	config                 *config.Config
+	namespaceRepository    repositories.NamespaceRepositoryProvider
+	experimentRepository   repositories.ExperimentRepositoryProvider
+	runActivityRepository  aimRepositories.RunActivityRepositoryProvider
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	config *config.Config,
+	namespaceRepository repositories.NamespaceRepositoryProvider,
+	experimentRepository repositories.ExperimentRepositoryProvider,
+	runActivityRepository aimRepositories.RunActivityRepositoryProvider,
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) *Service {
+	return &Service{
+		config:                 config,
+		namespaceRepository:    namespaceRepository,
+		experimentRepository:   experimentRepository,
+		runActivityRepository:  runActivityRepository,
+		artifactStorageFactory: artifactStorageFactory,
+	}
+}
+
+// MoveExperiment reassigns the experiment, together with its runs, to the namespace with namespaceID
+// and rebuilds run activity of both namespaces. When artifact location of the experiment has been built
+// from the default artifact root, which contains `{namespace}` placeholder, the location is rebuilt for
+// the target namespace and artifacts are copied there. Artifacts at the old location are kept.
+// Repositories join request-scoped transaction of the context, so the name check and all the updates
+// are applied atomically, when the route is wrapped into the transaction middleware.
+func (s Service) MoveExperiment(
+	ctx context.Context, experimentID int32, namespaceID uint,
+) (*models.Experiment, error) {
+	experiment, err := s.experimentRepository.GetByID(ctx, experimentID)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting experiment by id: %d", experimentID)
+	}
+	if experiment == nil {
+		return nil, eris.Wrapf(ErrExperimentNotFound, "error getting experiment by id: %d", experimentID)
+	}
+
+	namespace, err := s.namespaceRepository.GetByID(ctx, namespaceID)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting namespace by id: %d", namespaceID)
+	}
+	if namespace == nil {
+		return nil, eris.Wrapf(ErrNamespaceNotFound, "error getting namespace by id: %d", namespaceID)
+	}
+	if experiment.NamespaceID == namespace.ID {
+		return experiment, nil
+	}
+
+	sourceNamespaceID := experiment.NamespaceID
+	sourceNamespace, err := s.namespaceRepository.GetByID(ctx, sourceNamespaceID)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting namespace by id: %d", sourceNamespaceID)
+	}
+	if sourceNamespace != nil && sourceNamespace.DefaultExperimentID != nil &&
+		*sourceNamespace.DefaultExperimentID == *experiment.ID {
+		return nil, eris.Wrapf(ErrDefaultExperiment, "error moving experiment with id: %d", experimentID)
+	}
+
+	// experiment names are unique per namespace, so check that the name is not taken in the target one yet.
+	existingExperiment, err := s.experimentRepository.GetByNamespaceIDAndName(ctx, namespace.ID, experiment.Name)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error getting experiment by name: %s", experiment.Name)
+	}
+	if existingExperiment != nil {
+		return nil, eris.Wrapf(ErrExperimentAlreadyExists, "error moving experiment with name: %s", experiment.Name)
+	}
+
+	sourceArtifactLocation := experiment.ArtifactLocation
+	artifactLocation, err := s.getArtifactLocation(experiment, sourceNamespace, namespace)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error building artifact location of experiment with id: %d", experimentID)
+	}
+	if err := s.experimentRepository.UpdateNamespaceID(ctx, experiment, namespace.ID, artifactLocation); err != nil {
+		return nil, eris.Wrapf(err, "error moving experiment with id: %d", experimentID)
+	}
+
+	// run activity is summarized by namespace, so runs of the experiment have to be moved between summaries.
+	for _, id := range []uint{sourceNamespaceID, namespace.ID} {
+		if err := s.runActivityRepository.RebuildByNamespaceID(ctx, id); err != nil {
+			return nil, eris.Wrapf(err, "error rebuilding run activity of namespace with id: %d", id)
+		}
+	}
+
+	if artifactLocation != sourceArtifactLocation {
+		artifacts, err := storage.CopyArtifacts(ctx, s.artifactStorageFactory, sourceArtifactLocation, artifactLocation)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error copying artifacts of experiment with id: %d", experimentID)
+		}
+		log.Infof(
+			"copied %d artifacts of experiment with id %d from '%s' to '%s'",
+			artifacts, experimentID, sourceArtifactLocation, artifactLocation,
+		)
+	}
+	return experiment, nil
+}
+
+// getArtifactLocation returns artifact location of the experiment in the target namespace. Location is rebuilt
+// only if it has been built from the default artifact root for the source namespace, as locations provided
+// on experiment creation don't depend on the namespace.
+func (s Service) getArtifactLocation(
+	experiment *models.Experiment, source, target *models.Namespace,
+) (string, error) {
+	if source == nil || s.config.DefaultArtifactRoot == "" {
+		return experiment.ArtifactLocation, nil
+	}
+	sourceLocation, err := common.BuildArtifactLocation(s.config.DefaultArtifactRoot, source.Code, *experiment.ID)
+	if err != nil {
+		return "", err
+	}
+	if experiment.ArtifactLocation != sourceLocation {
+		return experiment.ArtifactLocation, nil
+	}
+	return common.BuildArtifactLocation(s.config.DefaultArtifactRoot, target.Code, *experiment.ID)
+}
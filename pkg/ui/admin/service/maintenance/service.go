@@ -0,0 +1,34 @@
+package maintenance
+
+import (
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// Service provides service layer to work with `maintenance` mode business logic.
+type Service struct {
+	enabled *atomic.Bool
+}
+
+// NewService creates new Service instance. Initial state of maintenance mode is taken from configuration.
+func NewService(config *config.Config) *Service {
+	enabled := atomic.Bool{}
+	enabled.Store(config.MaintenanceMode)
+	return &Service{
+		enabled: &enabled,
+	}
+}
+
+// IsEnabled checks that maintenance mode is enabled.
+func (s Service) IsEnabled() bool {
+	return s.enabled.Load()
+}
+
+// SetEnabled enables or disables maintenance mode.
+func (s Service) SetEnabled(enabled bool) {
+	s.enabled.Store(enabled)
+	log.Infof("maintenance mode has been set to %t", enabled)
+}
@@ -0,0 +1,135 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+// Supported statuses of the imported namespaces.
+const (
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusSkipped = "skipped"
+	ImportStatusFailed  = "failed"
+)
+
+// Definition represents a definition of the namespace to import.
+type Definition struct {
+	Code            string                    `json:"code" yaml:"code"`
+	Description     string                    `json:"description" yaml:"description"`
+	PublicRead      bool                      `json:"public_read" yaml:"public_read"`
+	ArtifactRoot    string                    `json:"artifact_root" yaml:"artifact_root"`
+	RetentionPolicy RetentionPolicyDefinition `json:"retention_policy" yaml:"retention_policy"`
+}
+
+// RetentionPolicyDefinition represents a definition of the run retention policy of the namespace to import.
+type RetentionPolicyDefinition struct {
+	MaxAgeDays      *int32 `json:"max_age_days" yaml:"max_age_days"`
+	KeepBest        *int32 `json:"keep_best" yaml:"keep_best"`
+	MetricKey       string `json:"metric_key" yaml:"metric_key"`
+	MetricDirection string `json:"metric_direction" yaml:"metric_direction"`
+}
+
+// ImportResult represents the result of import of one namespace.
+type ImportResult struct {
+	Code   string `json:"code"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ParseDefinitions parses the list of namespace definitions from YAML or JSON document.
+func ParseDefinitions(data []byte) ([]Definition, error) {
+	var definitions []Definition
+	// JSON is a subset of YAML, so both formats are handled by the YAML parser.
+	if err := yaml.Unmarshal(data, &definitions); err != nil {
+		return nil, eris.Wrap(err, "error parsing namespace definitions")
+	}
+	return definitions, nil
+}
+
+// ImportNamespaces creates the namespaces by provided definitions. Already existing namespaces are
+// updated when updateExisting is set and skipped otherwise, so the same definitions can be imported
+// several times. Import doesn't stop on the first failure, the result of each namespace is reported instead.
+func (s Service) ImportNamespaces(
+	ctx context.Context, definitions []Definition, updateExisting bool,
+) []ImportResult {
+	results := make([]ImportResult, 0, len(definitions))
+	for _, definition := range definitions {
+		code := NormalizeNamespace(definition.Code)
+		status, err := s.importNamespace(ctx, code, definition, updateExisting)
+		result := ImportResult{Code: code, Status: status}
+		if err != nil {
+			result.Status, result.Error = ImportStatusFailed, err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// importNamespace creates or updates one namespace and returns the import status.
+func (s Service) importNamespace(
+	ctx context.Context, code string, definition Definition, updateExisting bool,
+) (string, error) {
+	retentionPolicy, err := definition.RetentionPolicy.toModel()
+	if err != nil {
+		return "", err
+	}
+
+	namespace, err := s.namespaceRepository.GetByCode(ctx, code)
+	if err != nil {
+		return "", eris.Wrapf(err, "error finding namespace by code: %s", code)
+	}
+
+	// unchanged code of existing namespace is not validated again, like it is done on update.
+	if namespace != nil {
+		if !updateExisting {
+			return ImportStatusSkipped, nil
+		}
+		namespace.Description = definition.Description
+		namespace.PublicRead = definition.PublicRead
+		namespace.RetentionPolicy = retentionPolicy
+		if err := s.namespaceRepository.Update(ctx, namespace); err != nil {
+			return "", eris.Wrap(err, "error updating namespace")
+		}
+		if err := s.namespaceRepository.UpdateRetentionPolicy(ctx, namespace); err != nil {
+			return "", eris.Wrap(err, "error updating retention policy of namespace")
+		}
+		return ImportStatusUpdated, nil
+	}
+
+	if err := ValidateNamespace(code); err != nil {
+		return "", eris.Wrap(err, "error validating namespace")
+	}
+	artifactRoot := definition.ArtifactRoot
+	if artifactRoot == "" {
+		artifactRoot = s.config.DefaultArtifactRoot
+	}
+	if err := s.createNamespace(ctx, &models.Namespace{
+		Code:            code,
+		Description:     definition.Description,
+		PublicRead:      definition.PublicRead,
+		RetentionPolicy: retentionPolicy,
+	}, artifactRoot); err != nil {
+		return "", err
+	}
+	return ImportStatusCreated, nil
+}
+
+// toModel validates the retention policy definition and converts it into models.RetentionPolicy.
+func (d RetentionPolicyDefinition) toModel() (models.RetentionPolicy, error) {
+	policy := models.RetentionPolicy{
+		MaxAgeDays:      d.MaxAgeDays,
+		KeepBest:        d.KeepBest,
+		MetricKey:       d.MetricKey,
+		MetricDirection: models.MetricDirection(d.MetricDirection),
+	}
+	if err := policy.Validate(); err != nil {
+		return models.RetentionPolicy{}, api.NewInvalidParameterValueError(err.Error())
+	}
+	return policy, nil
+}
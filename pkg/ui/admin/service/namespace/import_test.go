@@ -0,0 +1,71 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+)
+
+func TestParseDefinitions_Ok(t *testing.T) {
+	expected := []Definition{
+		{
+			Code:         "team-a",
+			Description:  "team A",
+			PublicRead:   true,
+			ArtifactRoot: "s3://bucket/team-a",
+			RetentionPolicy: RetentionPolicyDefinition{
+				KeepBest:        common.GetPointer[int32](10),
+				MetricKey:       "loss",
+				MetricDirection: "min",
+			},
+		},
+		{Code: "team-b"},
+	}
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "ParseYAML",
+			data: `
+- code: team-a
+  description: team A
+  public_read: true
+  artifact_root: s3://bucket/team-a
+  retention_policy:
+    keep_best: 10
+    metric_key: loss
+    metric_direction: min
+- code: team-b
+`,
+		},
+		{
+			name: "ParseJSON",
+			data: `[
+				{
+					"code": "team-a",
+					"description": "team A",
+					"public_read": true,
+					"artifact_root": "s3://bucket/team-a",
+					"retention_policy": {"keep_best": 10, "metric_key": "loss", "metric_direction": "min"}
+				},
+				{"code": "team-b"}
+			]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definitions, err := ParseDefinitions([]byte(tt.data))
+			require.Nil(t, err)
+			assert.Equal(t, expected, definitions)
+		})
+	}
+}
+
+func TestParseDefinitions_Error(t *testing.T) {
+	_, err := ParseDefinitions([]byte(`code: team-a`))
+	assert.ErrorContains(t, err, "error parsing namespace definitions")
+}
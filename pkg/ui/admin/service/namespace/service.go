@@ -3,8 +3,6 @@ package namespace
 import (
 	"context"
 	"database/sql"
-	"fmt"
-	"net/url"
 	"time"
 
 	"github.com/rotisserie/eris"
@@ -12,7 +10,6 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
-	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
@@ -55,58 +52,49 @@ func (s Service) GetNamespace(ctx context.Context, id uint) (*models.Namespace,
 }
 
 // CreateNamespace creates a new namespace and default experiment.
-func (s Service) CreateNamespace(ctx context.Context, code, description string) (*models.Namespace, error) {
+func (s Service) CreateNamespace(
+	ctx context.Context, code, description string, publicRead bool,
+) (*models.Namespace, error) {
+	code = NormalizeNamespace(code)
 	if err := ValidateNamespace(code); err != nil {
 		return nil, eris.Wrap(err, "error validating namespace")
 	}
 
 	namespace := &models.Namespace{
-		Code:                code,
-		Description:         description,
-		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+		Code:        code,
+		Description: description,
+		PublicRead:  publicRead,
 	}
-	if err := s.namespaceRepository.Create(ctx, namespace); err != nil {
-		return nil, eris.Wrap(err, "error creating namespace")
+	if err := s.createNamespace(ctx, namespace, s.config.DefaultArtifactRoot); err != nil {
+		return nil, err
 	}
 
+	return namespace, nil
+}
+
+// createNamespace creates already validated namespace together with its default experiment,
+// which artifacts are stored under artifactRoot.
+func (s Service) createNamespace(ctx context.Context, namespace *models.Namespace, artifactRoot string) error {
+	namespace.DefaultExperimentID = common.GetPointer(models.DefaultExperimentID)
 	timestamp := time.Now().UTC().UnixMilli()
 	experiment := models.Experiment{
 		Name:           models.DefaultExperimentName,
-		NamespaceID:    namespace.ID,
 		CreationTime:   sql.NullInt64{Int64: timestamp, Valid: true},
 		LifecycleStage: models.LifecycleStageActive,
 		LastUpdateTime: sql.NullInt64{Int64: timestamp, Valid: true},
 	}
-
-	if err := s.experimentRepository.Create(ctx, &experiment); err != nil {
-		return nil, eris.Wrap(err, "error creating experiment")
-	}
-
-	// update Namespace with correct DefaultExperimentID now that it is known
-	namespace.DefaultExperimentID = experiment.ID
-	if err := s.namespaceRepository.Update(ctx, namespace); err != nil {
-		return nil, eris.Wrap(err, "error setting namespace default experiment id during create")
+	if err := s.namespaceRepository.CreateWithDefaultExperiment(
+		ctx, namespace, &experiment, artifactRoot,
+	); err != nil {
+		return eris.Wrap(err, "error creating namespace")
 	}
-
-	// setup ArtifactLocation for default experiment.
-	path, err := url.JoinPath(s.config.DefaultArtifactRoot, fmt.Sprintf("%d", *experiment.ID))
-	if err != nil {
-		return nil, api.NewInternalError(
-			"error creating artifact_location for experiment'%s': %s", experiment.Name, err,
-		)
-	}
-	experiment.ArtifactLocation = path
-	if err := s.experimentRepository.Update(ctx, &experiment); err != nil {
-		return nil, api.NewInternalError(
-			"error updating artifact_location for experiment '%s': %s", experiment.Name, err,
-		)
-	}
-
-	return namespace, nil
+	return nil
 }
 
-// UpdateNamespace updates the code and description fields.
-func (s Service) UpdateNamespace(ctx context.Context, id uint, code, description string) (*models.Namespace, error) {
+// UpdateNamespace updates the code, description and public_read fields.
+func (s Service) UpdateNamespace(
+	ctx context.Context, id uint, code, description string, publicRead bool,
+) (*models.Namespace, error) {
 	namespace, err := s.namespaceRepository.GetByID(ctx, id)
 	if err != nil {
 		return nil, eris.Wrapf(err, "error finding namespace by id: %d", id)
@@ -114,11 +102,15 @@ func (s Service) UpdateNamespace(ctx context.Context, id uint, code, description
 	if namespace == nil {
 		return nil, eris.Errorf("namespace not found by id: %d", id)
 	}
-	if err := ValidateNamespace(code); err != nil {
-		return nil, eris.Wrap(err, "error validating namespace code")
+	// unchanged code is not validated again, so the default namespace can keep its reserved code.
+	if code = NormalizeNamespace(code); code != namespace.Code {
+		if err := ValidateNamespace(code); err != nil {
+			return nil, eris.Wrap(err, "error validating namespace code")
+		}
 	}
 	namespace.Code = code
 	namespace.Description = description
+	namespace.PublicRead = publicRead
 
 	if err := s.namespaceRepository.Update(ctx, namespace); err != nil {
 		return nil, eris.Wrap(err, "error updating namespace")
@@ -126,6 +118,28 @@ func (s Service) UpdateNamespace(ctx context.Context, id uint, code, description
 	return namespace, nil
 }
 
+// UpdateRetentionPolicy validates and updates run retention policy of the namespace.
+// Nil namespace is returned, when namespace doesn't exist.
+func (s Service) UpdateRetentionPolicy(
+	ctx context.Context, id uint, policy models.RetentionPolicy,
+) (*models.Namespace, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, eris.Wrap(err, "error validating retention policy")
+	}
+	namespace, err := s.namespaceRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error finding namespace by id: %d", id)
+	}
+	if namespace == nil {
+		return nil, nil
+	}
+	namespace.RetentionPolicy = policy
+	if err := s.namespaceRepository.UpdateRetentionPolicy(ctx, namespace); err != nil {
+		return nil, eris.Wrap(err, "error updating retention policy")
+	}
+	return namespace, nil
+}
+
 // DeleteNamespace deletes the namespace.
 func (s Service) DeleteNamespace(ctx context.Context, id uint) error {
 	namespace, err := s.namespaceRepository.GetByID(ctx, id)
@@ -3,14 +3,12 @@ package namespace
 import (
 	"context"
 	"errors"
-	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
@@ -20,58 +18,35 @@ func TestService_CreateNamespace_Ok(t *testing.T) {
 	// init repository mocks.
 	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
 	namespaceRepository.On(
-		"Create",
+		"CreateWithDefaultExperiment",
 		context.TODO(),
 		mock.MatchedBy(func(ns *models.Namespace) bool {
 			assert.Equal(t, "code", ns.Code)
 			assert.Equal(t, "description", ns.Description)
+			assert.True(t, ns.PublicRead)
 			return true
 		}),
-	).Return(nil)
-	namespaceRepository.On(
-		"Update",
-		context.TODO(),
-		mock.MatchedBy(func(ns *models.Namespace) bool {
-			assert.Equal(t, "code", ns.Code)
-			assert.Equal(t, "description", ns.Description)
-			return true
-		}),
-	).Return(nil)
-
-	experimentRepository := repositories.MockExperimentRepositoryProvider{}
-	experimentRepository.On(
-		"Create",
-		context.TODO(),
 		mock.MatchedBy(func(experiment *models.Experiment) bool {
 			assert.Equal(t, models.DefaultExperimentName, experiment.Name)
 			assert.Equal(t, models.LifecycleStageActive, experiment.LifecycleStage)
-			assert.NotNil(t, experiment.CreationTime)
-			assert.NotNil(t, experiment.LastUpdateTime)
-			experiment.ID = common.GetPointer(int32(1))
-			return true
-		}),
-	).Return(nil)
-	experimentRepository.On(
-		"Update",
-		context.TODO(),
-		mock.MatchedBy(func(experiment *models.Experiment) bool {
-			assert.Equal(
-				t,
-				fmt.Sprintf("default_artifact_root/%d", *experiment.ID),
-				experiment.ArtifactLocation,
-			)
+			assert.True(t, experiment.CreationTime.Valid)
+			assert.True(t, experiment.LastUpdateTime.Valid)
 			return true
 		}),
+		"default_artifact_root",
 	).Return(nil)
 
 	// call service under testing.
 	service := NewService(&config.Config{
 		DefaultArtifactRoot: "default_artifact_root",
-	}, &namespaceRepository, &experimentRepository)
-	_, err := service.CreateNamespace(context.TODO(), "code", "description")
+	}, &namespaceRepository, &repositories.MockExperimentRepositoryProvider{})
+	namespace, err := service.CreateNamespace(context.TODO(), " Code ", "description", true)
 
 	// compare results.
 	require.Nil(t, err)
+	assert.Equal(t, "code", namespace.Code)
+	assert.Equal(t, "description", namespace.Description)
+	assert.True(t, namespace.PublicRead)
 }
 
 func TestService_CreateNamespace_Error(t *testing.T) {
@@ -80,28 +55,32 @@ func TestService_CreateNamespace_Error(t *testing.T) {
 	// init repository mocks.
 	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
 	namespaceRepository.On(
-		"Create", context.TODO(), mock.Anything, mock.Anything,
+		"CreateWithDefaultExperiment", context.TODO(), mock.Anything, mock.Anything, mock.Anything,
 	).Return(err)
-	namespaceRepository.On(
-		"Update", context.TODO(), mock.Anything, mock.Anything,
-	).Return(nil)
-
-	experimentRepository := repositories.MockExperimentRepositoryProvider{}
-	experimentRepository.On(
-		"Update",
-		context.TODO(),
-		mock.Anything,
-	).Return(nil)
 
 	// call service under testing.
-	service := NewService(&config.Config{}, &namespaceRepository, &experimentRepository)
-	_, err = service.CreateNamespace(context.TODO(), "code", "description")
+	service := NewService(&config.Config{}, &namespaceRepository, &repositories.MockExperimentRepositoryProvider{})
+	_, err = service.CreateNamespace(context.TODO(), "code", "description", false)
 
 	// compare results.
 	assert.NotNil(t, err)
 	assert.Equal(t, "error creating namespace: repository error", err.Error())
 }
 
+func TestService_CreateNamespace_ReservedCode_Error(t *testing.T) {
+	// call service under testing.
+	service := NewService(
+		&config.Config{},
+		&repositories.MockNamespaceRepositoryProvider{},
+		&repositories.MockExperimentRepositoryProvider{},
+	)
+	_, err := service.CreateNamespace(context.TODO(), " Default ", "description", false)
+
+	// compare results.
+	assert.NotNil(t, err)
+	assert.Equal(t, "error validating namespace: INVALID_PARAMETER_VALUE: namespace code 'default' is reserved", err.Error())
+}
+
 func TestService_GetNamespace_Ok(t *testing.T) {
 	// initialise namespace.
 	ns := models.Namespace{
@@ -264,6 +243,7 @@ func TestService_UpdateNamespace_Ok(t *testing.T) {
 			assert.Equal(t, uint(1), ns.ID)
 			assert.Equal(t, "code", ns.Code)
 			assert.Equal(t, "description", ns.Description)
+			assert.True(t, ns.PublicRead)
 			return true
 		}),
 	).Return(nil).On(
@@ -274,7 +254,34 @@ func TestService_UpdateNamespace_Ok(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(&config.Config{}, &namespaceRepository, &experimentRepository)
-	_, err := service.UpdateNamespace(context.TODO(), uint(1), "code", "description")
+	_, err := service.UpdateNamespace(context.TODO(), uint(1), " CODE", "description", true)
+
+	// compare results.
+	require.Nil(t, err)
+}
+
+func TestService_UpdateDefaultNamespace_Ok(t *testing.T) {
+	// init repository mocks.
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	ns := models.Namespace{
+		ID:   1,
+		Code: models.DefaultNamespaceCode,
+	}
+	namespaceRepository.On(
+		"Update",
+		context.TODO(),
+		mock.MatchedBy(func(ns *models.Namespace) bool {
+			assert.Equal(t, models.DefaultNamespaceCode, ns.Code)
+			assert.Equal(t, "description", ns.Description)
+			return true
+		}),
+	).Return(nil).On(
+		"GetByID", context.TODO(), uint(1),
+	).Return(&ns, nil)
+
+	// call service under testing.
+	service := NewService(&config.Config{}, &namespaceRepository, &repositories.MockExperimentRepositoryProvider{})
+	_, err := service.UpdateNamespace(context.TODO(), uint(1), models.DefaultNamespaceCode, "description", false)
 
 	// compare results.
 	require.Nil(t, err)
@@ -291,7 +298,7 @@ func TestService_UpdateNamespace_Error(t *testing.T) {
 
 	// call service under testing.
 	service := NewService(&config.Config{}, &namespaceRepository, &experimentRepository)
-	_, err := service.UpdateNamespace(context.TODO(), uint(1), "code", "description")
+	_, err := service.UpdateNamespace(context.TODO(), uint(1), "code", "description", false)
 
 	// compare results.
 	assert.NotNil(t, err)
@@ -2,19 +2,41 @@ package namespace
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
-const namespaceValidationMessage = "namespace code is invalid -- must be 2-12 letters, numbers, dash, or underscore"
+const (
+	namespaceValidationMessage = "namespace code is invalid -- must be 2-12 lowercase letters, numbers, or dash"
+	namespaceReservedMessage   = "namespace code '%s' is reserved"
+)
 
 // validation rule for namespace code
-var validNamespaceCode = regexp.MustCompile(`^[\w\d-_]{2,12}$`)
+var validNamespaceCode = regexp.MustCompile(`^[a-z0-9-]{2,12}$`)
+
+// reservedNamespaceCodes contains codes which can't be used by namespaces
+// because they clash with the default namespace or with routing.
+var reservedNamespaceCodes = map[string]struct{}{
+	"default": {},
+	"health":  {},
+	"version": {},
+	"aim":     {},
+	"mlflow":  {},
+}
+
+// NormalizeNamespace normalizes namespace code by trimming spaces and converting it to lower case.
+func NormalizeNamespace(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
 
 // ValidateNamespace validates namespace code
 func ValidateNamespace(code string) error {
 	if !validNamespaceCode.MatchString(code) {
 		return api.NewInvalidParameterValueError(namespaceValidationMessage)
 	}
+	if _, ok := reservedNamespaceCodes[code]; ok {
+		return api.NewInvalidParameterValueError(namespaceReservedMessage, code)
+	}
 	return nil
 }
@@ -10,10 +10,40 @@ import (
 )
 
 func TestValidateUpdateRunRequest_Ok(t *testing.T) {
-	err := ValidateNamespace("legit-123_ns")
+	err := ValidateNamespace("legit-123-ns")
 	require.Nil(t, err)
 }
 
+func TestNormalizeNamespace_Ok(t *testing.T) {
+	testData := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{
+			name:     "AlreadyNormalized",
+			code:     "test-ns",
+			expected: "test-ns",
+		},
+		{
+			name:     "WithSpaces",
+			code:     "  test-ns \t",
+			expected: "test-ns",
+		},
+		{
+			name:     "WithUpperCase",
+			code:     " Test-NS ",
+			expected: "test-ns",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeNamespace(tt.code))
+		})
+	}
+}
+
 func TestValidateUpdateRunRequest_Error(t *testing.T) {
 	testData := []struct {
 		name    string
@@ -40,6 +70,46 @@ func TestValidateUpdateRunRequest_Error(t *testing.T) {
 			error:   api.NewInvalidParameterValueError(namespaceValidationMessage),
 			request: "1234+(*&^56",
 		},
+		{
+			name:    "NoUnderscore",
+			error:   api.NewInvalidParameterValueError(namespaceValidationMessage),
+			request: "test_ns",
+		},
+		{
+			name:    "NoSlash",
+			error:   api.NewInvalidParameterValueError(namespaceValidationMessage),
+			request: "test/ns",
+		},
+		{
+			name:    "NoUpperCase",
+			error:   api.NewInvalidParameterValueError(namespaceValidationMessage),
+			request: "TestNS",
+		},
+		{
+			name:    "ReservedDefault",
+			error:   api.NewInvalidParameterValueError(namespaceReservedMessage, "default"),
+			request: "default",
+		},
+		{
+			name:    "ReservedHealth",
+			error:   api.NewInvalidParameterValueError(namespaceReservedMessage, "health"),
+			request: "health",
+		},
+		{
+			name:    "ReservedVersion",
+			error:   api.NewInvalidParameterValueError(namespaceReservedMessage, "version"),
+			request: "version",
+		},
+		{
+			name:    "ReservedAim",
+			error:   api.NewInvalidParameterValueError(namespaceReservedMessage, "aim"),
+			request: "aim",
+		},
+		{
+			name:    "ReservedMlflow",
+			error:   api.NewInvalidParameterValueError(namespaceReservedMessage, "mlflow"),
+			request: "mlflow",
+		},
 	}
 
 	for _, tt := range testData {
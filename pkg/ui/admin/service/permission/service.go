@@ -0,0 +1,59 @@
+package permission
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// Service provides service layer to work with user `permission` business logic.
+type Service struct {
+	config         *config.Config
+	roleRepository repositories.RoleRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(config *config.Config, roleRepository repositories.RoleRepositoryProvider) *Service {
+	return &Service{
+		config:         config,
+		roleRepository: roleRepository,
+	}
+}
+
+// Start reloads permissions each time the process receives SIGHUP, until context is done.
+func (s Service) Start(ctx context.Context) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				log.Debugf("permissions reloader finished. exiting.")
+				return
+			case <-ch:
+				if err := s.ReloadPermissions(ctx); err != nil {
+					log.Errorf("error reloading permissions: %+v", err)
+				}
+			}
+		}
+	}()
+}
+
+// ReloadPermissions reloads user permissions from configuration and invalidates cached roles,
+// so revoked access takes effect on the next request.
+func (s Service) ReloadPermissions(ctx context.Context) error {
+	if err := s.config.Auth.ReloadUserPermissions(); err != nil {
+		return eris.Wrap(err, "error reloading user permissions")
+	}
+	s.roleRepository.Invalidate()
+	log.Info("user permissions have been reloaded")
+	return nil
+}
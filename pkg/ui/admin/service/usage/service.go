@@ -0,0 +1,114 @@
+package usage
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+)
+
+const (
+	// DefaultLimit is the number of namespaces in one page of the report, when it isn't requested.
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of namespaces in one page of the report.
+	MaxLimit = 1000
+)
+
+// Service provides service layer to work with namespace `usage` business logic.
+type Service struct {
+	namespaceUsageRepository repositories.NamespaceUsageRepositoryProvider
+	artifactStorageFactory   storage.ArtifactStorageFactoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	namespaceUsageRepository repositories.NamespaceUsageRepositoryProvider,
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) *Service {
+	return &Service{
+		namespaceUsageRepository: namespaceUsageRepository,
+		artifactStorageFactory:   artifactStorageFactory,
+	}
+}
+
+// GetNamespaceUsageReport returns page of the namespace usage report. Artifact bytes are
+// calculated by listing artifact locations of the namespace experiments in artifact storage.
+func (s Service) GetNamespaceUsageReport(
+	ctx context.Context, req *request.NamespaceUsage,
+) (*response.NamespaceUsageReport, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	// one more namespace is requested to find out if there is the next page.
+	usage, err := s.namespaceUsageRepository.List(ctx, req.Offset, limit+1)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting namespace usage")
+	}
+
+	report := response.NamespaceUsageReport{
+		Namespaces: make([]response.NamespaceUsage, 0, len(usage)),
+	}
+	if len(usage) > limit {
+		usage = usage[:limit]
+		nextOffset := req.Offset + limit
+		report.NextOffset = &nextOffset
+	}
+	for _, namespaceUsage := range usage {
+		artifactBytes, err := s.getArtifactBytes(ctx, namespaceUsage.ArtifactLocations)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error getting artifact bytes of namespace: %s", namespaceUsage.Code)
+		}
+		report.Namespaces = append(report.Namespaces, response.NamespaceUsage{
+			ID:               namespaceUsage.NamespaceID,
+			Code:             namespaceUsage.Code,
+			RunCount:         namespaceUsage.RunCount,
+			ExperimentCount:  namespaceUsage.ExperimentCount,
+			MetricCount:      namespaceUsage.MetricCount,
+			ArtifactBytes:    artifactBytes,
+			LastActivityTime: namespaceUsage.LastActivityTime,
+		})
+	}
+	return &report, nil
+}
+
+// GetNamespaceLifecycleStageCounts returns numbers of active and deleted experiments and runs of the namespace,
+// so it is visible how many of them are waiting to be purged.
+func (s Service) GetNamespaceLifecycleStageCounts(
+	ctx context.Context, namespaceID uint,
+) (*response.NamespaceLifecycleStageCounts, error) {
+	counts, err := s.namespaceUsageRepository.GetLifecycleStageCounts(ctx, namespaceID)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting lifecycle stage counts of namespace")
+	}
+	return &response.NamespaceLifecycleStageCounts{
+		ActiveExperimentCount:  counts.ActiveExperimentCount,
+		DeletedExperimentCount: counts.DeletedExperimentCount,
+		ActiveRunCount:         counts.ActiveRunCount,
+		DeletedRunCount:        counts.DeletedRunCount,
+	}, nil
+}
+
+// getArtifactBytes sums sizes of all the artifact objects stored under the artifact locations.
+func (s Service) getArtifactBytes(ctx context.Context, artifactLocations []string) (int64, error) {
+	var artifactBytes int64
+	for _, artifactLocation := range artifactLocations {
+		artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, artifactLocation)
+		if err != nil {
+			return 0, eris.Wrapf(err, "error getting artifact storage for location: %s", artifactLocation)
+		}
+		objects, err := artifactStorage.List(ctx, artifactLocation, "", true)
+		if err != nil {
+			return 0, eris.Wrapf(err, "error listing artifacts under location: %s", artifactLocation)
+		}
+		for _, object := range objects {
+			artifactBytes += object.GetSize()
+		}
+	}
+	return artifactBytes, nil
+}
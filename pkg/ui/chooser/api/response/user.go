@@ -0,0 +1,26 @@
+package response
+
+import (
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/chooser/service/user"
+)
+
+// User is the response struct for the GetCurrentUser endpoint.
+type User struct {
+	AuthType   string         `json:"auth_type"`
+	Name       string         `json:"name"`
+	Roles      []string       `json:"roles"`
+	IsAdmin    bool           `json:"is_admin"`
+	Namespaces ListNamespaces `json:"namespaces"`
+}
+
+// NewGetCurrentUserResponse creates new instance of User.
+func NewGetCurrentUserResponse(user *user.User, namespaces []models.Namespace) *User {
+	return &User{
+		AuthType:   user.AuthType,
+		Name:       user.Name,
+		Roles:      user.Roles,
+		IsAdmin:    user.IsAdmin,
+		Namespaces: *NewListNamespacesResponse(namespaces),
+	}
+}
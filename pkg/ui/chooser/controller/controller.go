@@ -1,15 +1,20 @@
 package controller
 
-import "github.com/G-Research/fasttrackml/pkg/ui/chooser/service/namespace"
+import (
+	"github.com/G-Research/fasttrackml/pkg/ui/chooser/service/namespace"
+	"github.com/G-Research/fasttrackml/pkg/ui/chooser/service/user"
+)
 
 // Controller handles all the input HTTP requests.
 type Controller struct {
 	namespaceService *namespace.Service
+	userService      *user.Service
 }
 
 // NewController creates new Controller instance.
-func NewController(namespaceService *namespace.Service) *Controller {
+func NewController(namespaceService *namespace.Service, userService *user.Service) *Controller {
 	return &Controller{
 		namespaceService: namespaceService,
+		userService:      userService,
 	}
 }
@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/gofiber/fiber/v2"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 	"github.com/G-Research/fasttrackml/pkg/ui/chooser/api/response"
 )
@@ -16,8 +20,21 @@ func (c Controller) GetNamespaces(ctx *fiber.Ctx) error {
 	}
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
 	if err != nil {
-		return err
+		return api.NewInternalError("error getting namespace from context")
+	}
+
+	// namespace wasn't selected explicitly, so redirect user to the landing namespace, if there is one.
+	if middleware.IsLandingRequest(ctx, ns) {
+		user, err := c.userService.GetCurrentUser(ctx.Context())
+		if err != nil {
+			return err
+		}
+		if landing := c.namespaceService.GetLandingNamespace(namespaces, user.Name); landing != nil &&
+			!landing.IsDefault() {
+			return ctx.Redirect(fmt.Sprintf("/ns/%s/", landing.Code), http.StatusFound)
+		}
 	}
+
 	return ctx.Render("namespaces/index", fiber.Map{
 		"IsAdmin":          isAdmin,
 		"Namespaces":       namespaces,
@@ -41,7 +58,7 @@ func (c Controller) ListNamespaces(ctx *fiber.Ctx) error {
 func (c Controller) GetCurrentNamespace(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
 	if err != nil {
-		return err
+		return api.NewInternalError("error getting namespace from context")
 	}
 	resp := response.NewGetCurrentNamespaceResponse(ns)
 	log.Debugf("currentNamespace response: %#v", resp)
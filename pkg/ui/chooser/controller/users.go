@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/chooser/api/response"
+)
+
+// GetCurrentUser handles `GET /chooser/whoami` endpoint.
+func (c Controller) GetCurrentUser(ctx *fiber.Ctx) error {
+	user, err := c.userService.GetCurrentUser(ctx.Context())
+	if err != nil {
+		return err
+	}
+	namespaces, _, err := c.namespaceService.ListNamespaces(ctx.Context())
+	if err != nil {
+		return err
+	}
+	resp := response.NewGetCurrentUserResponse(user, namespaces)
+	log.Debugf("currentUser response: %#v", resp)
+
+	return ctx.JSON(resp)
+}
@@ -56,6 +56,7 @@ func (r *Router) Init(router fiber.Router) error {
 	app.Get("/", r.controller.GetNamespaces)
 	app.Get("/chooser/namespaces", r.controller.ListNamespaces)
 	app.Get("/chooser/namespaces/current", r.controller.GetCurrentNamespace)
+	app.Get("/chooser/whoami", r.controller.GetCurrentUser)
 
 	// setup routes to static files.
 	app.Use("/chooser/", etag.New(), filesystem.New(filesystem.Config{
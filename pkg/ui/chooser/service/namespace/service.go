@@ -64,3 +64,20 @@ func (s Service) ListNamespaces(ctx context.Context) ([]models.Namespace, bool,
 
 	return namespaces, true, nil
 }
+
+// GetLandingNamespace returns namespace, which user has to land on after authentication.
+// Configured default namespace is used, when user has access to it, otherwise,
+// when user has access to exactly one namespace, this namespace is auto-selected.
+func (s Service) GetLandingNamespace(namespaces []models.Namespace, userName string) *models.Namespace {
+	if code := s.config.GetChooserDefaultNamespace(userName); code != "" {
+		for _, namespace := range namespaces {
+			if namespace.Code == code {
+				return &namespace
+			}
+		}
+	}
+	if len(namespaces) == 1 {
+		return &namespaces[0]
+	}
+	return nil
+}
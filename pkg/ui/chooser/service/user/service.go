@@ -0,0 +1,84 @@
+package user
+
+import (
+	"context"
+	"sort"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+)
+
+// supported list of authentication types in addition to `auth` ones.
+const (
+	AuthTypeNone  = "none"
+	AuthTypeBasic = "basic"
+)
+
+// User represents currently authenticated user.
+type User struct {
+	AuthType string
+	Name     string
+	Roles    []string
+	IsAdmin  bool
+}
+
+// Service provides service layer to work with `user` business logic.
+type Service struct {
+	config *config.Config
+}
+
+// NewService creates new Service instance.
+func NewService(config *config.Config) *Service {
+	return &Service{
+		config: config,
+	}
+}
+
+// GetCurrentUser returns currently authenticated user resolved by auth middleware.
+func (s Service) GetCurrentUser(ctx context.Context) (*User, error) {
+	switch {
+	case s.config.Auth.IsAuthTypeUser():
+		authToken, err := middleware.GetBasicAuthTokenFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		roles := make([]string, 0, len(authToken.GetRoles()))
+		for role := range authToken.GetRoles() {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+		return &User{
+			AuthType: auth.TypeUser,
+			Name:     authToken.GetName(),
+			Roles:    roles,
+			IsAdmin:  authToken.HasAdminAccess(),
+		}, nil
+	case s.config.Auth.IsAuthTypeOIDC():
+		user, err := middleware.GetOIDCUserFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &User{
+			AuthType: auth.TypeOIDC,
+			Name:     user.GetName(),
+			Roles:    user.GetRoles(),
+			IsAdmin:  user.IsAdmin(),
+		}, nil
+	case s.config.Auth.AuthUsername != "" && s.config.Auth.AuthPassword != "":
+		// global Basic Auth has only one user, which has access to everything.
+		return &User{
+			AuthType: AuthTypeBasic,
+			Name:     s.config.Auth.AuthUsername,
+			Roles:    []string{},
+			IsAdmin:  true,
+		}, nil
+	}
+
+	// without authentication everybody is an anonymous user, which has access to everything.
+	return &User{
+		AuthType: AuthTypeNone,
+		Roles:    []string{},
+		IsAdmin:  true,
+	}, nil
+}
@@ -189,6 +189,8 @@ class CustomRestStore(RestStore):
                 "max_results": max_results,
                 "context": context,
             },
+            # raw stream is read below, which urllib3 < 2.0 is not able to decompress.
+            extra_headers={"Accept-Encoding": "identity"},
             stream=True,
         )
 
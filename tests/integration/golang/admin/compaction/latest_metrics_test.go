@@ -0,0 +1,159 @@
+package compaction
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CompactLatestMetricsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCompactLatestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(CompactLatestMetricsTestSuite))
+}
+
+func (s *CompactLatestMetricsTestSuite) Test_Ok() {
+	runs := make([]*models.Run, 4)
+	for i, runID := range []string{"run1", "run2", "run3", "run4"} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             runID,
+			Name:           runID,
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			ExperimentID:   *s.DefaultExperiment.ID,
+		})
+		s.Require().Nil(err)
+		runs[i] = run
+	}
+
+	// run1 has corrupted latest value, run2 has correct one, run3 lacks it and run4 has it without history.
+	for step, value := range []float64{3, 2, 1} {
+		_, err := s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "loss",
+			Value:     value,
+			Timestamp: 1000 + int64(step),
+			RunID:     runs[0].ID,
+			Step:      int64(step),
+			Iter:      int64(step + 1),
+		})
+		s.Require().Nil(err)
+	}
+	_, err := s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "loss",
+		Value:     2,
+		Timestamp: 1001,
+		RunID:     runs[0].ID,
+		Step:      1,
+		LastIter:  2,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+		Key:       "loss",
+		Value:     5,
+		Timestamp: 2000,
+		RunID:     runs[1].ID,
+		Iter:      1,
+	})
+	s.Require().Nil(err)
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "loss",
+		Value:     5,
+		Timestamp: 2000,
+		RunID:     runs[1].ID,
+		LastIter:  1,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+		Key:       "accuracy",
+		Value:     0.5,
+		Timestamp: 3000,
+		RunID:     runs[2].ID,
+		Iter:      1,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "stale",
+		Value:     1,
+		Timestamp: 4000,
+		RunID:     runs[3].ID,
+		LastIter:  1,
+	})
+	s.Require().Nil(err)
+
+	// compact in batches smaller than the number of runs.
+	resp := response.LatestMetricsCompaction{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithQuery(
+			request.LatestMetricsCompaction{BatchSize: 3},
+		).WithResponse(
+			&resp,
+		).DoRequest("/compaction/latest-metrics"),
+	)
+	s.Equal(response.LatestMetricsCompaction{Runs: 4, Created: 1, Updated: 1, Deleted: 1}, resp)
+
+	latestMetric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), runs[0].ID)
+	s.Require().Nil(err)
+	s.Equal(1.0, latestMetric.Value)
+	s.Equal(int64(1002), latestMetric.Timestamp)
+	s.Equal(int64(2), latestMetric.Step)
+	s.Equal(int64(3), latestMetric.LastIter)
+
+	latestMetric, err = s.MetricFixtures.GetLatestMetricByRunID(context.Background(), runs[1].ID)
+	s.Require().Nil(err)
+	s.Equal(5.0, latestMetric.Value)
+	s.Equal(int64(1), latestMetric.LastIter)
+
+	latestMetric, err = s.MetricFixtures.GetLatestMetricByRunID(context.Background(), runs[2].ID)
+	s.Require().Nil(err)
+	s.Equal("accuracy", latestMetric.Key)
+	s.Equal(0.5, latestMetric.Value)
+	s.Equal(int64(3000), latestMetric.Timestamp)
+	s.Equal(int64(1), latestMetric.LastIter)
+
+	latestMetrics, err := s.MetricFixtures.GetLatestMetricsByKey(context.Background(), "stale")
+	s.Require().Nil(err)
+	s.Empty(latestMetrics)
+
+	// nothing is left to fix after compaction.
+	resp = response.LatestMetricsCompaction{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithResponse(
+			&resp,
+		).DoRequest("/compaction/latest-metrics"),
+	)
+	s.Equal(response.LatestMetricsCompaction{Runs: 4}, resp)
+}
+
+func (s *CompactLatestMetricsTestSuite) Test_Error() {
+	resp := bytes.Buffer{}
+	client := s.AdminClient().WithMethod(
+		http.MethodPost,
+	).WithQuery(
+		request.LatestMetricsCompaction{BatchSize: -1},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("/compaction/latest-metrics"))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal("batch_size should be greater than or equal to 0", resp.String())
+}
@@ -0,0 +1,122 @@
+package experiment
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	mlflowRequest "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type MoveExperimentArtifactsTestSuite struct {
+	helpers.BaseTestSuite
+	artifactRoot string
+}
+
+func TestMoveExperimentArtifactsTestSuite(t *testing.T) {
+	testSuite := new(MoveExperimentArtifactsTestSuite)
+	testSuite.artifactRoot = t.TempDir()
+	testSuite.Config = config.Config{
+		DefaultArtifactRoot: filepath.Join(testSuite.artifactRoot, "{namespace}"),
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *MoveExperimentArtifactsTestSuite) Test_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "test2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	experimentResp := mlflowResponse.CreateExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			mlflowRequest.CreateExperimentRequest{Name: "Test Experiment"},
+		).WithResponse(
+			&experimentResp,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+		),
+	)
+
+	runResp := mlflowResponse.CreateRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			mlflowRequest.CreateRunRequest{ExperimentID: experimentResp.ID},
+		).WithResponse(
+			&runResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsCreateRoute,
+		),
+	)
+	s.Require().Nil(os.MkdirAll(runResp.Run.Info.ArtifactURI, 0o755))
+	s.Require().Nil(os.WriteFile(filepath.Join(runResp.Run.Info.ArtifactURI, "artifact.txt"), []byte("data"), 0o600))
+
+	resp := response.Experiment{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			request.ExperimentNamespace{NamespaceID: namespace.ID},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"/experiments/%s/namespace", experimentResp.ID,
+		),
+	)
+	s.Equal(namespace.ID, resp.NamespaceID)
+
+	// artifact locations of the experiment and its run are rebuilt for the new namespace.
+	experimentLocation := filepath.Join(s.artifactRoot, namespace.Code, experimentResp.ID)
+	getExperimentResp := mlflowResponse.GetExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			namespace.Code,
+		).WithQuery(
+			mlflowRequest.GetExperimentRequest{ID: experimentResp.ID},
+		).WithResponse(
+			&getExperimentResp,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetRoute,
+		),
+	)
+	s.Equal(experimentLocation, getExperimentResp.Experiment.ArtifactLocation)
+
+	getRunResp := mlflowResponse.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			namespace.Code,
+		).WithQuery(
+			mlflowRequest.GetRunRequest{RunID: runResp.Run.Info.ID},
+		).WithResponse(
+			&getRunResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	artifactURI := filepath.Join(experimentLocation, runResp.Run.Info.ID, "artifacts")
+	s.Equal(artifactURI, getRunResp.Run.Info.ArtifactURI)
+
+	// and artifacts are copied there.
+	data, err := os.ReadFile(filepath.Join(artifactURI, "artifact.txt"))
+	s.Require().Nil(err)
+	s.Equal("data", string(data))
+}
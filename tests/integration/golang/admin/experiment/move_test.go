@@ -0,0 +1,181 @@
+package experiment
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	mlflowRequest "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type MoveExperimentTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestMoveExperimentTestSuite(t *testing.T) {
+	suite.Run(t, new(MoveExperimentTestSuite))
+}
+
+func (s *MoveExperimentTestSuite) Test_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "test2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *experiment.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := response.Experiment{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			request.ExperimentNamespace{NamespaceID: namespace.ID},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"/experiments/%d/namespace", *experiment.ID,
+		),
+	)
+	s.Equal(response.Experiment{
+		ID:          *experiment.ID,
+		Name:        experiment.Name,
+		NamespaceID: namespace.ID,
+	}, resp)
+
+	// run is visible under the new namespace.
+	runResp := mlflowResponse.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			namespace.Code,
+		).WithQuery(
+			mlflowRequest.GetRunRequest{RunID: run.ID},
+		).WithResponse(
+			&runResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Equal(run.ID, runResp.Run.Info.ID)
+
+	// and not under the old one anymore.
+	errResp := api.ErrorResponse{}
+	client := s.MlflowClient().WithQuery(
+		mlflowRequest.GetRunRequest{RunID: run.ID},
+	).WithResponse(
+		&errResp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute))
+	s.Equal(http.StatusNotFound, client.GetStatusCode())
+	s.Equal(api.ErrorCode(api.ErrorCodeResourceDoesNotExist), errResp.ErrorCode)
+}
+
+func (s *MoveExperimentTestSuite) Test_Error() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "test2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	_, err = s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name         string
+		experimentID int32
+		request      request.ExperimentNamespace
+		statusCode   int
+		error        string
+	}{
+		{
+			name:         "MissingNamespaceID",
+			experimentID: *experiment.ID,
+			request:      request.ExperimentNamespace{},
+			statusCode:   http.StatusBadRequest,
+			error:        "namespace_id is required",
+		},
+		{
+			name:         "NotFoundExperiment",
+			experimentID: 1000,
+			request:      request.ExperimentNamespace{NamespaceID: namespace.ID},
+			statusCode:   http.StatusNotFound,
+			error:        "experiment not found",
+		},
+		{
+			name:         "NotFoundNamespace",
+			experimentID: *experiment.ID,
+			request:      request.ExperimentNamespace{NamespaceID: 1000},
+			statusCode:   http.StatusNotFound,
+			error:        "namespace not found",
+		},
+		{
+			name:         "ExperimentNameAlreadyExists",
+			experimentID: *experiment.ID,
+			request:      request.ExperimentNamespace{NamespaceID: namespace.ID},
+			statusCode:   http.StatusConflict,
+			error:        "experiment with the same name already exists in the namespace",
+		},
+		{
+			name:         "DefaultExperiment",
+			experimentID: *s.DefaultExperiment.ID,
+			request:      request.ExperimentNamespace{NamespaceID: namespace.ID},
+			statusCode:   http.StatusBadRequest,
+			error:        "default experiment of the namespace can't be moved",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := bytes.Buffer{}
+			client := s.AdminClient().WithMethod(
+				http.MethodPut,
+			).WithRequest(
+				tt.request,
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest("/experiments/%d/namespace", tt.experimentID))
+			s.Equal(tt.statusCode, client.GetStatusCode())
+			s.Equal(tt.error, resp.String())
+		})
+	}
+}
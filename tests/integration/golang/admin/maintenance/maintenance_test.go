@@ -0,0 +1,153 @@
+package maintenance
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	adminRequest "github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type MaintenanceModeTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestMaintenanceModeTestSuite(t *testing.T) {
+	testSuite := new(MaintenanceModeTestSuite)
+	testSuite.Config = config.Config{
+		MaintenanceMode: true,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *MaintenanceModeTestSuite) Test_Ok() {
+	// check that maintenance mode is enabled by configuration.
+	var state map[string]any
+	s.Require().Nil(s.AdminClient().WithResponse(&state).DoRequest("/maintenance/"))
+	s.Equal(map[string]any{"enabled": true}, state)
+
+	// check that read requests are accepted.
+	experimentsResp := mlflowResponse.SearchExperimentsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithResponse(
+			&experimentsResp,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+		),
+	)
+	s.Len(experimentsResp.Experiments, 1)
+
+	runsResp := mlflowResponse.SearchRunsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SearchRunsRequest{ExperimentIDs: []string{"0"}},
+		).WithResponse(
+			&runsResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+		),
+	)
+	s.Empty(runsResp.Runs)
+
+	projectResp := response.GetProjectResponse{}
+	s.Require().Nil(s.AIMClient().WithResponse(&projectResp).DoRequest("/projects"))
+	s.Equal("FastTrackML", projectResp.Name)
+
+	// check that write requests are rejected.
+	for _, tt := range []struct {
+		name    string
+		client  func() *helpers.HttpClient
+		method  string
+		request any
+		path    string
+	}{
+		{
+			name:    "MlflowCreateExperiment",
+			client:  s.MlflowClient,
+			method:  http.MethodPost,
+			request: request.CreateExperimentRequest{Name: "experiment"},
+			path:    mlflow.ExperimentsRoutePrefix + mlflow.ExperimentsCreateRoute,
+		},
+		{
+			name:    "MlflowLogBatch",
+			client:  s.MlflowClient,
+			method:  http.MethodPost,
+			request: request.LogBatchRequest{RunID: "id"},
+			path:    mlflow.RunsRoutePrefix + mlflow.RunsLogBatchRoute,
+		},
+		{
+			name:   "AimDeleteRun",
+			client: s.AIMClient,
+			method: http.MethodDelete,
+			path:   "/runs/id",
+		},
+	} {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := tt.client().WithMethod(tt.method).WithResponse(&resp)
+			if tt.request != nil {
+				client = client.WithRequest(tt.request)
+			}
+			s.Require().Nil(client.DoRequest("%s", tt.path))
+			s.Equal(http.StatusServiceUnavailable, client.GetStatusCode())
+			s.Equal(
+				"TEMPORARILY_UNAVAILABLE: server is in maintenance mode, write requests are not accepted",
+				resp.Error(),
+			)
+		})
+	}
+
+	// disable maintenance mode and check that write requests are accepted again.
+	var resp map[string]any
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			adminRequest.MaintenanceMode{Enabled: false},
+		).WithResponse(
+			&resp,
+		).DoRequest("/maintenance/"),
+	)
+	s.Equal(map[string]any{
+		"status":  "success",
+		"message": "Successfully disabled maintenance mode.",
+	}, resp)
+
+	createResp := mlflowResponse.CreateExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.CreateExperimentRequest{Name: "experiment"},
+		).WithResponse(
+			&createResp,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+		),
+	)
+	s.NotEmpty(createResp.ID)
+
+	// enable maintenance mode again.
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			adminRequest.MaintenanceMode{Enabled: true},
+		).WithResponse(
+			&resp,
+		).DoRequest("/maintenance/"),
+	)
+	s.Equal("Successfully enabled maintenance mode.", resp["message"])
+	s.Require().Nil(s.AdminClient().WithResponse(&state).DoRequest("/maintenance/"))
+	s.Equal(map[string]any{"enabled": true}, state)
+}
@@ -0,0 +1,204 @@
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	mlflowRequest "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CloneNamespaceTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCloneNamespaceTestSuite(t *testing.T) {
+	suite.Run(t, new(CloneNamespaceTestSuite))
+}
+
+func (s *CloneNamespaceTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+		Tags: []models.ExperimentTag{
+			{Key: "stage", Value: "staging"},
+		},
+	})
+	s.Require().Nil(err)
+
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *experiment.ID,
+	})
+	s.Require().Nil(err)
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:   "param1",
+		Value: "value1",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+
+	dashboards, err := s.DashboardFixtures.CreateDashboards(context.Background(), s.DefaultNamespace, 2)
+	s.Require().Nil(err)
+
+	// clone the namespace, the default experiment is matched with the one of the new namespace.
+	cloneNamespace := func() response.NamespaceClone {
+		resp := response.NamespaceClone{}
+		s.Require().Nil(
+			s.AdminClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.NamespaceClone{Code: "prod", IncludeRuns: true},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"/namespaces/%d/clone", s.DefaultNamespace.ID,
+			),
+		)
+		return resp
+	}
+	resp := cloneNamespace()
+	s.NotEqual(s.DefaultNamespace.ID, resp.NamespaceID)
+	s.Equal(response.NamespaceClone{
+		NamespaceID: resp.NamespaceID,
+		Code:        "prod",
+		Experiments: 1,
+		Runs:        1,
+		Apps:        2,
+		Dashboards:  2,
+	}, resp)
+
+	// experiment, together with its tags and runs, is available under the new namespace with the new ID.
+	experimentResp := mlflowResponse.GetExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			"prod",
+		).WithQuery(
+			mlflowRequest.GetExperimentRequest{Name: experiment.Name},
+		).WithResponse(
+			&experimentResp,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetByNameRoute,
+		),
+	)
+	s.NotEqual(fmt.Sprint(*experiment.ID), experimentResp.Experiment.ID)
+	s.Equal([]mlflowResponse.ExperimentTagPartialResponse{
+		{Key: "stage", Value: "staging"},
+	}, experimentResp.Experiment.Tags)
+
+	runsResp := mlflowResponse.SearchRunsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			"prod",
+		).WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			mlflowRequest.SearchRunsRequest{ExperimentIDs: []string{experimentResp.Experiment.ID}},
+		).WithResponse(
+			&runsResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+		),
+	)
+	s.Require().Len(runsResp.Runs, 1)
+	s.NotEqual(run.ID, runsResp.Runs[0].Info.ID)
+	s.Equal("chill-run", runsResp.Runs[0].Info.Name)
+	s.Equal([]mlflowResponse.RunParamPartialResponse{
+		{Key: "param1", Value: "value1"},
+	}, runsResp.Runs[0].Data.Params)
+
+	// dashboards are cloned together with their apps.
+	allDashboards, err := s.DashboardFixtures.GetDashboards(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(allDashboards, 4)
+	for _, dashboard := range dashboards {
+		clonedDashboards := 0
+		for _, clonedDashboard := range allDashboards {
+			if clonedDashboard.ID != dashboard.ID && clonedDashboard.Name == dashboard.Name {
+				s.NotEqual(dashboard.AppID, clonedDashboard.AppID)
+				clonedDashboards++
+			}
+		}
+		s.Equal(1, clonedDashboards)
+	}
+
+	// cloning again into the same namespace doesn't create anything new.
+	s.Equal(response.NamespaceClone{
+		NamespaceID: resp.NamespaceID,
+		Code:        "prod",
+	}, cloneNamespace())
+	allDashboards, err = s.DashboardFixtures.GetDashboards(context.Background())
+	s.Require().Nil(err)
+	s.Len(allDashboards, 4)
+}
+
+func (s *CloneNamespaceTestSuite) Test_Error() {
+	tests := []struct {
+		name        string
+		namespaceID uint
+		request     request.NamespaceClone
+		statusCode  int
+		error       string
+	}{
+		{
+			name:        "MissingCode",
+			namespaceID: s.DefaultNamespace.ID,
+			request:     request.NamespaceClone{},
+			statusCode:  http.StatusBadRequest,
+			error:       "code is required",
+		},
+		{
+			name:        "NotFoundNamespace",
+			namespaceID: 1000,
+			request:     request.NamespaceClone{Code: "prod"},
+			statusCode:  http.StatusNotFound,
+			error:       "namespace not found",
+		},
+		{
+			name:        "SameNamespace",
+			namespaceID: s.DefaultNamespace.ID,
+			request:     request.NamespaceClone{Code: "default"},
+			statusCode:  http.StatusBadRequest,
+			error:       "namespace can't be cloned into itself",
+		},
+		{
+			name:        "InvalidCode",
+			namespaceID: s.DefaultNamespace.ID,
+			request:     request.NamespaceClone{Code: "invalid code"},
+			statusCode:  http.StatusBadRequest,
+			error:       "namespace code is invalid -- must be 2-12 lowercase letters, numbers, or dash",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := bytes.Buffer{}
+			client := s.AdminClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				tt.request,
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest("/namespaces/%d/clone", tt.namespaceID))
+			s.Equal(tt.statusCode, client.GetStatusCode())
+			s.Equal(tt.error, resp.String())
+		})
+	}
+}
@@ -2,12 +2,17 @@ package namespace
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
@@ -49,6 +54,80 @@ func (s *CreateNamespaceTestSuite) Test_Ok() {
 	s.Equal(len(requests)+1, len(namespaces))
 }
 
+func (s *CreateNamespaceTestSuite) Test_DefaultExperiment_Ok() {
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.Namespace{
+				Code:        "test4",
+				Description: "test namespace 4 description",
+			},
+		).DoRequest("/namespaces"),
+	)
+
+	namespace, err := s.NamespaceFixtures.GetNamespaceByCode(context.Background(), "test4")
+	s.Require().Nil(err)
+	s.Require().NotNil(namespace.DefaultExperimentID)
+
+	experiment, err := s.ExperimentFixtures.GetByNamespaceIDAndExperimentID(
+		context.Background(), namespace.ID, *namespace.DefaultExperimentID,
+	)
+	s.Require().Nil(err)
+	s.Equal(models.DefaultExperimentName, experiment.Name)
+	s.Equal(models.LifecycleStageActive, experiment.LifecycleStage)
+	s.True(strings.HasSuffix(experiment.ArtifactLocation, fmt.Sprintf("/%d", *experiment.ID)))
+
+	// default experiment is also available via namespace API.
+	resp := response.GetExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			"test4",
+		).WithQuery(
+			map[any]any{"experiment_id": *experiment.ID},
+		).WithResponse(
+			&resp,
+		).DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetRoute),
+	)
+	s.Equal(fmt.Sprintf("%d", *experiment.ID), resp.Experiment.ID)
+	s.Equal(models.DefaultExperimentName, resp.Experiment.Name)
+}
+
+func (s *CreateNamespaceTestSuite) Test_Normalized_Ok() {
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.Namespace{
+				Code:        " Test5 ",
+				Description: "test namespace 5 description",
+			},
+		).DoRequest("/namespaces"),
+	)
+
+	namespace, err := s.NamespaceFixtures.GetNamespaceByCode(context.Background(), "test5")
+	s.Require().Nil(err)
+	s.Equal("test5", namespace.Code)
+
+	// the same code in a different case is a duplicate after normalization.
+	var resp goquery.Document
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.Namespace{
+				Code:        "TEST5",
+				Description: "description",
+			},
+		).WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&resp,
+		).DoRequest("/namespaces"),
+	)
+	s.Equal("The namespace code is already in use.", resp.Find(".error-message").Text())
+}
+
 func (s *CreateNamespaceTestSuite) Test_Error() {
 	testData := []struct {
 		name    string
@@ -88,12 +167,36 @@ func (s *CreateNamespaceTestSuite) Test_Error() {
 			error: "The namespace code is invalid.",
 		},
 		{
-			name: "CodeAlreadyExists",
+			name: "CodeWithSlash",
+			request: &request.Namespace{
+				Code:        "test/ns",
+				Description: "description",
+			},
+			error: "The namespace code is invalid.",
+		},
+		{
+			name: "CodeWithUnderscore",
+			request: &request.Namespace{
+				Code:        "test_ns",
+				Description: "description",
+			},
+			error: "The namespace code is invalid.",
+		},
+		{
+			name: "ReservedCode",
 			request: &request.Namespace{
 				Code:        "default",
 				Description: "description",
 			},
-			error: "The namespace code is already in use.",
+			error: "The namespace code is invalid.",
+		},
+		{
+			name: "ReservedCodeNotNormalized",
+			request: &request.Namespace{
+				Code:        " MLflow ",
+				Description: "description",
+			},
+			error: "The namespace code is invalid.",
 		},
 	}
 	for _, tt := range testData {
@@ -0,0 +1,77 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetNamespaceTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetNamespaceTestSuite(t *testing.T) {
+	suite.Run(t, new(GetNamespaceTestSuite))
+}
+
+func (s *GetNamespaceTestSuite) Test_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "lifecycle",
+		DefaultExperimentID: common.GetPointer(int32(0)),
+	})
+	s.Require().Nil(err)
+
+	// seed two active and one deleted experiments, three active and two deleted runs.
+	// runs of the deleted experiment are counted by their own lifecycle stage.
+	experimentIDs := make([]int32, 3)
+	for i, lifecycleStage := range []models.LifecycleStage{
+		models.LifecycleStageActive, models.LifecycleStageActive, models.LifecycleStageDeleted,
+	} {
+		experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           fmt.Sprintf("experiment%d", i),
+			NamespaceID:    namespace.ID,
+			LifecycleStage: lifecycleStage,
+		})
+		s.Require().Nil(err)
+		experimentIDs[i] = *experiment.ID
+	}
+	for i, lifecycleStage := range []models.LifecycleStage{
+		models.LifecycleStageActive,
+		models.LifecycleStageDeleted,
+		models.LifecycleStageActive,
+		models.LifecycleStageDeleted,
+		models.LifecycleStageActive,
+	} {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("run%d", i),
+			Name:           fmt.Sprintf("run%d", i),
+			Status:         models.StatusFinished,
+			SourceType:     "JOB",
+			LifecycleStage: lifecycleStage,
+			ExperimentID:   experimentIDs[i%3],
+		})
+		s.Require().Nil(err)
+	}
+
+	// entities of another namespace are not counted.
+	_, err = s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+
+	var resp goquery.Document
+	client := s.AdminClient().WithResponseType(helpers.ResponseTypeHTML).WithResponse(&resp)
+	s.Require().Nil(client.DoRequest("/namespaces/%d/", namespace.ID))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	s.Equal("2", resp.Find("#active-experiment-count").Text())
+	s.Equal("1", resp.Find("#deleted-experiment-count").Text())
+	s.Equal("3", resp.Find("#active-run-count").Text())
+	s.Equal("2", resp.Find("#deleted-run-count").Text())
+}
@@ -0,0 +1,132 @@
+package namespace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ImportNamespacesTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestImportNamespacesTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportNamespacesTestSuite))
+}
+
+func (s *ImportNamespacesTestSuite) Test_Ok() {
+	_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "team-b",
+		Description:         "existing namespace",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	definitions := []byte(`
+- code: team-a
+  description: team A
+  artifact_root: s3://bucket/team-a
+  retention_policy:
+    max_age_days: 30
+- code: team-b
+  description: team B
+- code: Team-C
+  description: team C
+  public_read: true
+`)
+	importNamespaces := func(query map[any]any) response.NamespaceImport {
+		resp := response.NamespaceImport{}
+		s.Require().Nil(
+			s.AdminClient().WithMethod(
+				http.MethodPost,
+			).WithQuery(
+				query,
+			).WithRequest(
+				definitions,
+			).WithResponse(
+				&resp,
+			).DoRequest("/namespaces/import"),
+		)
+		return resp
+	}
+
+	// existing namespace is skipped by default.
+	s.Equal(response.NamespaceImport{Results: []response.NamespaceImportResult{
+		{Code: "team-a", Status: namespace.ImportStatusCreated},
+		{Code: "team-b", Status: namespace.ImportStatusSkipped},
+		{Code: "team-c", Status: namespace.ImportStatusCreated},
+	}}, importNamespaces(map[any]any{}))
+
+	teamA, err := s.NamespaceFixtures.GetNamespaceByCode(context.Background(), "team-a")
+	s.Require().Nil(err)
+	s.Equal("team A", teamA.Description)
+	s.Equal(int32(30), *teamA.RetentionPolicy.MaxAgeDays)
+	experiment, err := s.ExperimentFixtures.GetByNamespaceIDAndExperimentID(
+		context.Background(), teamA.ID, *teamA.DefaultExperimentID,
+	)
+	s.Require().Nil(err)
+	s.Contains(experiment.ArtifactLocation, "s3://bucket/team-a/")
+
+	teamB, err := s.NamespaceFixtures.GetNamespaceByCode(context.Background(), "team-b")
+	s.Require().Nil(err)
+	s.Equal("existing namespace", teamB.Description)
+
+	teamC, err := s.NamespaceFixtures.GetNamespaceByCode(context.Background(), "team-c")
+	s.Require().Nil(err)
+	s.True(teamC.PublicRead)
+
+	// import is idempotent and updates existing namespaces when requested.
+	s.Equal(response.NamespaceImport{Results: []response.NamespaceImportResult{
+		{Code: "team-a", Status: namespace.ImportStatusUpdated},
+		{Code: "team-b", Status: namespace.ImportStatusUpdated},
+		{Code: "team-c", Status: namespace.ImportStatusUpdated},
+	}}, importNamespaces(map[any]any{"update_existing": true}))
+
+	teamB, err = s.NamespaceFixtures.GetNamespaceByCode(context.Background(), "team-b")
+	s.Require().Nil(err)
+	s.Equal("team B", teamB.Description)
+	teamA, err = s.NamespaceFixtures.GetNamespaceByCode(context.Background(), "team-a")
+	s.Require().Nil(err)
+	s.Equal(int32(30), *teamA.RetentionPolicy.MaxAgeDays)
+
+	namespaces, err := s.NamespaceFixtures.GetNamespaces(context.Background())
+	s.Require().Nil(err)
+	s.Equal(4, len(namespaces))
+}
+
+func (s *ImportNamespacesTestSuite) Test_Error() {
+	// invalid definitions are reported per namespace and don't prevent import of the valid ones.
+	resp := response.NamespaceImport{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]byte(`[
+				{"code": "ok"},
+				{"code": "not valid"},
+				{"code": "metric", "retention_policy": {"metric_direction": "up"}}
+			]`),
+		).WithResponse(
+			&resp,
+		).DoRequest("/namespaces/import"),
+	)
+	s.Require().Equal(3, len(resp.Results))
+	s.Equal(response.NamespaceImportResult{Code: "ok", Status: namespace.ImportStatusCreated}, resp.Results[0])
+	s.Equal(namespace.ImportStatusFailed, resp.Results[1].Status)
+	s.Contains(resp.Results[1].Error, "namespace code is invalid")
+	s.Equal(namespace.ImportStatusFailed, resp.Results[2].Status)
+	s.Contains(resp.Results[2].Error, "unsupported retention metric direction 'up'")
+
+	// unparsable definitions are rejected as a whole.
+	client := s.AdminClient().WithMethod(http.MethodPost).WithRequest([]byte(`{"code": "ok"`))
+	s.Require().Nil(client.DoRequest("/namespaces/import"))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+}
@@ -0,0 +1,150 @@
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RetentionPolicyTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRetentionPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionPolicyTestSuite))
+}
+
+func (s *RetentionPolicyTestSuite) Test_Ok() {
+	// namespace has no retention policy by default.
+	resp := response.NamespaceRetentionPolicy{}
+	s.Require().Nil(
+		s.AdminClient().WithResponse(
+			&resp,
+		).DoRequest(
+			"/namespaces/%d/retention", s.DefaultNamespace.ID,
+		),
+	)
+	s.Equal(response.NamespaceRetentionPolicy{}, resp)
+
+	// update retention policy.
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			request.NamespaceRetentionPolicy{
+				MaxAgeDays:      common.GetPointer[int32](30),
+				KeepBest:        common.GetPointer[int32](5),
+				MetricKey:       "accuracy",
+				MetricDirection: string(models.MetricDirectionMax),
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"/namespaces/%d/retention", s.DefaultNamespace.ID,
+		),
+	)
+	expectedPolicy := models.RetentionPolicy{
+		MaxAgeDays:      common.GetPointer[int32](30),
+		KeepBest:        common.GetPointer[int32](5),
+		MetricKey:       "accuracy",
+		MetricDirection: models.MetricDirectionMax,
+	}
+	s.Equal(response.NamespaceRetentionPolicy{
+		MaxAgeDays:      common.GetPointer[int32](30),
+		KeepBest:        common.GetPointer[int32](5),
+		MetricKey:       "accuracy",
+		MetricDirection: "max",
+	}, resp)
+
+	// check that policy has been stored and other fields of the namespace are left untouched.
+	namespace, err := s.NamespaceFixtures.GetNamespaceByID(context.Background(), s.DefaultNamespace.ID)
+	s.Require().Nil(err)
+	s.Equal(expectedPolicy, namespace.RetentionPolicy)
+	s.Equal(s.DefaultNamespace.Code, namespace.Code)
+	s.Equal(s.DefaultNamespace.Description, namespace.Description)
+
+	// disable retention policy.
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			request.NamespaceRetentionPolicy{},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"/namespaces/%d/retention", s.DefaultNamespace.ID,
+		),
+	)
+	namespace, err = s.NamespaceFixtures.GetNamespaceByID(context.Background(), s.DefaultNamespace.ID)
+	s.Require().Nil(err)
+	s.False(namespace.RetentionPolicy.IsEnabled())
+}
+
+func (s *RetentionPolicyTestSuite) Test_Error() {
+	tests := []struct {
+		name        string
+		namespaceID uint
+		request     request.NamespaceRetentionPolicy
+		statusCode  int
+		error       string
+	}{
+		{
+			name:        "NotFoundNamespace",
+			namespaceID: 1000,
+			request:     request.NamespaceRetentionPolicy{MaxAgeDays: common.GetPointer[int32](30)},
+			statusCode:  http.StatusNotFound,
+			error:       "namespace not found",
+		},
+		{
+			name:        "NegativeMaxAgeDays",
+			namespaceID: s.DefaultNamespace.ID,
+			request:     request.NamespaceRetentionPolicy{MaxAgeDays: common.GetPointer[int32](-1)},
+			statusCode:  http.StatusBadRequest,
+			error:       "retention max_age_days should not be negative",
+		},
+		{
+			name:        "KeepBestWithoutMetricKey",
+			namespaceID: s.DefaultNamespace.ID,
+			request:     request.NamespaceRetentionPolicy{KeepBest: common.GetPointer[int32](5)},
+			statusCode:  http.StatusBadRequest,
+			error:       "retention metric_key is required when keep_best is set",
+		},
+		{
+			name:        "UnsupportedMetricDirection",
+			namespaceID: s.DefaultNamespace.ID,
+			request: request.NamespaceRetentionPolicy{
+				KeepBest:        common.GetPointer[int32](5),
+				MetricKey:       "loss",
+				MetricDirection: "up",
+			},
+			statusCode: http.StatusBadRequest,
+			error:      "unsupported retention metric direction 'up'",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := bytes.Buffer{}
+			client := s.AdminClient().WithMethod(
+				http.MethodPut,
+			).WithRequest(
+				tt.request,
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest("/namespaces/%d/retention", tt.namespaceID))
+			s.Equal(tt.statusCode, client.GetStatusCode())
+			s.Equal(tt.error, resp.String())
+		})
+	}
+}
@@ -45,7 +45,7 @@ func (s *UpdateNamespaceTestSuite) Test_Ok() {
 	namespace, err := s.NamespaceFixtures.GetNamespaceByID(context.Background(), ns.ID)
 	s.Require().Nil(err)
 
-	s.Equal(namespace.Code, request.Code)
+	s.Equal("test2updated", namespace.Code)
 	s.Equal(namespace.Description, request.Description)
 }
 
@@ -57,6 +57,13 @@ func (s *UpdateNamespaceTestSuite) Test_Error() {
 		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
 	})
 	s.Require().Nil(err)
+	_, err = s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "test3",
+		Description:         "test namespace 3 description",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
 	expectedNamespaces, err := s.NamespaceFixtures.GetNamespaces(context.Background())
 	s.Require().Nil(err)
 
@@ -91,12 +98,36 @@ func (s *UpdateNamespaceTestSuite) Test_Error() {
 			},
 		},
 		{
-			name: "UpdateNamespaceWithDuplicatedCode",
+			name: "UpdateNamespaceWithInvalidCode",
+			ID:   "2",
+			request: &request.Namespace{
+				Code:        "test/ns",
+				Description: "test namespace updated",
+			},
+			response: map[string]any{
+				"message": "The namespace code is invalid.",
+				"status":  "error",
+			},
+		},
+		{
+			name: "UpdateNamespaceWithReservedCode",
 			ID:   "2",
 			request: &request.Namespace{
 				Code:        "default",
 				Description: "test namespace updated",
 			},
+			response: map[string]any{
+				"message": "The namespace code is invalid.",
+				"status":  "error",
+			},
+		},
+		{
+			name: "UpdateNamespaceWithDuplicatedCode",
+			ID:   "2",
+			request: &request.Namespace{
+				Code:        " TEST3 ",
+				Description: "test namespace updated",
+			},
 			response: map[string]any{
 				"message": "The namespace code is already in use.",
 				"status":  "error",
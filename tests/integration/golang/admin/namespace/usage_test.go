@@ -0,0 +1,162 @@
+package namespace
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type NamespaceUsageTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestNamespaceUsageTestSuite(t *testing.T) {
+	suite.Run(t, new(NamespaceUsageTestSuite))
+}
+
+func (s *NamespaceUsageTestSuite) Test_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "usage",
+		DefaultExperimentID: common.GetPointer(int32(0)),
+	})
+	s.Require().Nil(err)
+
+	// seed two experiments with artifacts, three runs and five metrics.
+	experimentIDs := make([]int32, 2)
+	for i := range experimentIDs {
+		artifactLocation := filepath.Join(s.T().TempDir(), "artifacts")
+		s.Require().Nil(os.MkdirAll(filepath.Join(artifactLocation, "run", "artifacts"), 0o755))
+		s.Require().Nil(os.WriteFile(filepath.Join(artifactLocation, "run", "artifacts", "file1.txt"), []byte("12345"), 0o600))
+		s.Require().Nil(os.WriteFile(filepath.Join(artifactLocation, "file2.txt"), []byte("123"), 0o600))
+
+		experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:             "experiment" + string(rune('1'+i)),
+			NamespaceID:      namespace.ID,
+			LifecycleStage:   models.LifecycleStageActive,
+			ArtifactLocation: "file://" + artifactLocation,
+			LastUpdateTime:   sql.NullInt64{Int64: 1000, Valid: true},
+		})
+		s.Require().Nil(err)
+		experimentIDs[i] = *experiment.ID
+	}
+	for i, runID := range []string{"run1", "run2", "run3"} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             runID,
+			Name:           runID,
+			Status:         models.StatusFinished,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			ExperimentID:   experimentIDs[i%2],
+			StartTime:      sql.NullInt64{Int64: 2000, Valid: true},
+			EndTime:        sql.NullInt64{Int64: int64(3000 + i), Valid: true},
+		})
+		s.Require().Nil(err)
+		if i == 2 {
+			continue
+		}
+		for step := int64(0); step < int64(2+i); step++ {
+			_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+				Key:       "key",
+				Value:     1.1,
+				Timestamp: 4000 + step,
+				RunID:     run.ID,
+				Step:      step,
+				Iter:      step + 1,
+			})
+			s.Require().Nil(err)
+		}
+		_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+			Key:       "key",
+			Value:     1.1,
+			Timestamp: 4000 + int64(1+i),
+			RunID:     run.ID,
+			Step:      int64(1 + i),
+			LastIter:  int64(2 + i),
+		})
+		s.Require().Nil(err)
+	}
+
+	// request the report by pages of one namespace, the default namespace goes first.
+	resp := response.NamespaceUsageReport{}
+	s.Require().Nil(
+		s.AdminClient().WithQuery(
+			request.NamespaceUsage{Limit: 1},
+		).WithResponse(
+			&resp,
+		).DoRequest("/namespaces/usage"),
+	)
+	s.Require().Len(resp.Namespaces, 1)
+	s.Equal(s.DefaultNamespace.ID, resp.Namespaces[0].ID)
+	s.Equal(int64(1), resp.Namespaces[0].ExperimentCount)
+	s.Equal(int64(0), resp.Namespaces[0].RunCount)
+	s.Equal(common.GetPointer(1), resp.NextOffset)
+
+	resp = response.NamespaceUsageReport{}
+	s.Require().Nil(
+		s.AdminClient().WithQuery(
+			request.NamespaceUsage{Offset: 1, Limit: 1},
+		).WithResponse(
+			&resp,
+		).DoRequest("/namespaces/usage"),
+	)
+	s.Equal(response.NamespaceUsageReport{
+		Namespaces: []response.NamespaceUsage{
+			{
+				ID:               namespace.ID,
+				Code:             "usage",
+				RunCount:         3,
+				ExperimentCount:  2,
+				MetricCount:      5,
+				ArtifactBytes:    16,
+				LastActivityTime: 4002,
+			},
+		},
+	}, resp)
+}
+
+func (s *NamespaceUsageTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.NamespaceUsage
+		error   string
+	}{
+		{
+			name:    "NegativeOffset",
+			request: request.NamespaceUsage{Offset: -1},
+			error:   "offset should be greater than or equal to 0",
+		},
+		{
+			name:    "TooBigLimit",
+			request: request.NamespaceUsage{Limit: 1001},
+			error:   "limit should be between 0 and 1000",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := bytes.Buffer{}
+			client := s.AdminClient().WithQuery(
+				tt.request,
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest("/namespaces/usage"))
+			s.Equal(http.StatusBadRequest, client.GetStatusCode())
+			s.Equal(tt.error, resp.String())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package run
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ErrorCodesTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestErrorCodesTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorCodesTestSuite))
+}
+
+func (s *ErrorCodesTestSuite) Test_Error() {
+	tests := []struct {
+		name       string
+		method     string
+		request    any
+		path       string
+		statusCode int
+		errorCode  string
+	}{
+		{
+			name:       "NotFound",
+			method:     http.MethodGet,
+			path:       "/apps/" + uuid.NewString(),
+			statusCode: http.StatusNotFound,
+			errorCode:  api.ErrorCodeResourceDoesNotExist,
+		},
+		{
+			name:       "BadRequest",
+			method:     http.MethodPost,
+			request:    map[string]any{"State": "bad json"},
+			path:       "/apps",
+			statusCode: http.StatusUnprocessableEntity,
+			errorCode:  api.ErrorCodeBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.Error
+			client := s.AIMClient().WithMethod(tt.method).WithResponse(&resp)
+			if tt.request != nil {
+				client = client.WithRequest(tt.request)
+			}
+			s.Require().Nil(client.DoRequest(tt.path))
+			s.Equal(tt.statusCode, client.GetStatusCode())
+			s.Equal(tt.errorCode, resp.ErrorCode)
+			s.NotEmpty(resp.Message)
+		})
+	}
+}
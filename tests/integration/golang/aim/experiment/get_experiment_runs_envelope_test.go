@@ -0,0 +1,116 @@
+package experiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetExperimentRunsEnvelopeTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetExperimentRunsEnvelopeTestSuite(t *testing.T) {
+	suite.Run(t, &GetExperimentRunsEnvelopeTestSuite{
+		helpers.BaseTestSuite{
+			SkipCreateDefaultExperiment: true,
+		},
+	})
+}
+
+func (s *GetExperimentRunsEnvelopeTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	runs, err := s.RunFixtures.CreateExampleRuns(context.Background(), experiment, 5)
+	s.Require().Nil(err)
+
+	// runs are wrapped into the envelope, next token points to the last run of the page.
+	var envelope api.PaginatedResponse[map[string]any]
+	client := s.AIMClient().WithHeaders(map[string]string{
+		"Accept": api.MIMEApplicationPaginatedJSON,
+	}).WithQuery(map[any]any{
+		"limit": 3,
+	}).WithResponse(
+		&envelope,
+	)
+	s.Require().Nil(client.DoRequest("/experiments/%d/runs", *experiment.ID))
+	s.Equal(api.MIMEApplicationPaginatedJSON, client.GetResponseHeaders().Get("Content-Type"))
+	s.Require().Equal(3, len(envelope.Items))
+	for i, item := range envelope.Items {
+		s.Equal(runs[4-i].ID, item["run_id"])
+	}
+	s.Equal(runs[2].ID, envelope.NextToken)
+	s.Nil(envelope.Total)
+
+	// the last page has no next token.
+	envelope = api.PaginatedResponse[map[string]any]{}
+	s.Require().Nil(
+		s.AIMClient().WithHeaders(map[string]string{
+			"Accept": api.MIMEApplicationPaginatedJSON,
+		}).WithQuery(map[any]any{
+			"limit":  3,
+			"offset": runs[2].ID,
+		}).WithResponse(
+			&envelope,
+		).DoRequest(
+			"/experiments/%d/runs", *experiment.ID,
+		),
+	)
+	s.Equal(2, len(envelope.Items))
+	s.Equal("", envelope.NextToken)
+
+	// legacy clients still get the old shape.
+	var legacy response.GetExperimentRuns
+	client = s.AIMClient().WithQuery(map[any]any{
+		"limit": 3,
+	}).WithResponse(
+		&legacy,
+	)
+	s.Require().Nil(client.DoRequest("/experiments/%d/runs", *experiment.ID))
+	s.Equal("application/json", client.GetResponseHeaders().Get("Content-Type"))
+	s.Equal(3, len(legacy.Runs))
+}
+
+func (s *GetExperimentRunsEnvelopeTestSuite) Test_Experiments() {
+	for i := 0; i < 2; i++ {
+		_, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           uuid.New().String(),
+			NamespaceID:    s.DefaultNamespace.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	// bare array of experiments is wrapped into the envelope with total number of experiments.
+	var envelope api.PaginatedResponse[response.GetExperiment]
+	s.Require().Nil(
+		s.AIMClient().WithHeaders(map[string]string{
+			"Accept": api.MIMEApplicationPaginatedJSON,
+		}).WithResponse(
+			&envelope,
+		).DoRequest(
+			"/experiments/",
+		),
+	)
+	s.Equal(2, len(envelope.Items))
+	s.Equal("", envelope.NextToken)
+	s.Require().NotNil(envelope.Total)
+	s.Equal(int64(2), *envelope.Total)
+
+	// legacy clients still get the bare array.
+	var legacy []response.GetExperiment
+	s.Require().Nil(s.AIMClient().WithResponse(&legacy).DoRequest("/experiments/"))
+	s.Equal(2, len(legacy))
+}
@@ -0,0 +1,85 @@
+package experiment
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetExperimentRunsPageSizeTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetExperimentRunsPageSizeTestSuite(t *testing.T) {
+	suite.Run(t, &GetExperimentRunsPageSizeTestSuite{
+		helpers.BaseTestSuite{
+			Config: config.Config{
+				MaxPageSize: 4,
+			},
+			SkipCreateDefaultExperiment: true,
+		},
+	})
+}
+
+func (s *GetExperimentRunsPageSizeTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	runs, err := s.RunFixtures.CreateExampleRuns(context.Background(), experiment, 10)
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name  string
+		limit int
+	}{
+		{
+			name:  "OverLimitIsClamped",
+			limit: 100,
+		},
+		{
+			name:  "NotSetIsClamped",
+			limit: 0,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// walk through all the pages using the last returned run as offset.
+			var runIDs []string
+			offset := ""
+			for _, pageSize := range []int{4, 4, 2} {
+				var resp response.GetExperimentRuns
+				client := s.AIMClient().WithQuery(map[any]any{
+					"limit":  tt.limit,
+					"offset": offset,
+				}).WithResponse(
+					&resp,
+				)
+				s.Require().Nil(client.DoRequest("/experiments/%d/runs", *experiment.ID))
+				s.Equal(strconv.Itoa(4), client.GetResponseHeaders().Get(api.PageSizeHeader))
+				s.Require().Equal(pageSize, len(resp.Runs))
+				for _, run := range resp.Runs {
+					runIDs = append(runIDs, run.ID)
+				}
+				offset = resp.Runs[len(resp.Runs)-1].ID
+			}
+
+			s.Require().Equal(len(runs), len(runIDs))
+			for i, runID := range runIDs {
+				s.Equal(runs[len(runs)-i-1].ID, runID)
+			}
+		})
+	}
+}
@@ -56,6 +56,87 @@ func (s *GetExperimentTestSuite) Test_Ok() {
 	s.Equal(len(experiment.Runs), resp.RunCount)
 }
 
+func (s *GetExperimentTestSuite) Test_Fields_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name: "Test Experiment",
+		Tags: []models.ExperimentTag{
+			{
+				Key:   common.DescriptionTagKey,
+				Value: "value1",
+			},
+		},
+		CreationTime: sql.NullInt64{
+			Int64: time.Now().UTC().UnixMilli(),
+			Valid: true,
+		},
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name     string
+		fields   string
+		expected map[string]any
+	}{
+		{
+			name:   "AllFields",
+			fields: "",
+			expected: map[string]any{
+				"id":            fmt.Sprintf("%d", *experiment.ID),
+				"name":          experiment.Name,
+				"description":   "value1",
+				"archived":      false,
+				"run_count":     float64(0),
+				"creation_time": float64(experiment.CreationTime.Int64) / 1000,
+			},
+		},
+		{
+			name:   "TagsAndSummary",
+			fields: "tags,summary",
+			expected: map[string]any{
+				"id":            fmt.Sprintf("%d", *experiment.ID),
+				"name":          experiment.Name,
+				"description":   "value1",
+				"archived":      false,
+				"run_count":     float64(0),
+				"creation_time": float64(experiment.CreationTime.Int64) / 1000,
+			},
+		},
+		{
+			name:   "Tags",
+			fields: "tags",
+			expected: map[string]any{
+				"id":          fmt.Sprintf("%d", *experiment.ID),
+				"name":        experiment.Name,
+				"description": "value1",
+			},
+		},
+		{
+			name:   "Summary",
+			fields: "summary",
+			expected: map[string]any{
+				"id":            fmt.Sprintf("%d", *experiment.ID),
+				"name":          experiment.Name,
+				"archived":      false,
+				"run_count":     float64(0),
+				"creation_time": float64(experiment.CreationTime.Int64) / 1000,
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp map[string]any
+			s.Require().Nil(
+				s.AIMClient().WithQuery(map[any]any{
+					"fields": tt.fields,
+				}).WithResponse(&resp).DoRequest("/experiments/%d", *experiment.ID),
+			)
+			s.Equal(tt.expected, resp)
+		})
+	}
+}
+
 func (s *GetExperimentTestSuite) Test_Error() {
 	tests := []struct {
 		name  string
@@ -0,0 +1,194 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+// aggregateMetrics is an expected aggregate of a single step.
+type aggregateMetrics struct {
+	Step        int64
+	Count       int
+	Mean        float64
+	Min         float64
+	Max         float64
+	Percentiles map[string]float64
+}
+
+type AggregateMetricsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestAggregateMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(AggregateMetricsTestSuite))
+}
+
+func (s *AggregateMetricsTestSuite) createRunWithMetrics(values []float64) *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             uuid.NewString(),
+		Name:           "TestRun",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+		StartTime:      sql.NullInt64{Int64: 123456789, Valid: true},
+	})
+	s.Require().Nil(err)
+	for step, value := range values {
+		_, err := s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "loss",
+			Value:     value,
+			Timestamp: 123456789,
+			Step:      int64(step),
+			RunID:     run.ID,
+			Iter:      int64(step),
+		})
+		s.Require().Nil(err)
+	}
+	return run
+}
+
+func (s *AggregateMetricsTestSuite) Test_Ok() {
+	run1 := s.createRunWithMetrics([]float64{1, 2, 3, 4})
+	run2 := s.createRunWithMetrics([]float64{3, 4, 5})
+	run3 := s.createRunWithMetrics([]float64{5, 9})
+
+	tests := []struct {
+		name     string
+		request  request.AggregateMetricsRequest
+		response []aggregateMetrics
+	}{
+		{
+			name: "OverlappingStepsOnly",
+			request: request.AggregateMetricsRequest{
+				RunIDs:      []string{run1.ID, run2.ID, run3.ID},
+				Name:        "loss",
+				Percentiles: []float64{50},
+			},
+			response: []aggregateMetrics{
+				{Step: 0, Count: 3, Mean: 3, Min: 1, Max: 5, Percentiles: map[string]float64{"50": 3}},
+				{Step: 1, Count: 3, Mean: 5, Min: 2, Max: 9, Percentiles: map[string]float64{"50": 4}},
+			},
+		},
+		{
+			name: "FillWithLastValue",
+			request: request.AggregateMetricsRequest{
+				RunIDs: []string{run1.ID, run2.ID, run3.ID},
+				Name:   "loss",
+				Fill:   "last",
+			},
+			response: []aggregateMetrics{
+				{Step: 0, Count: 3, Mean: 3, Min: 1, Max: 5},
+				{Step: 1, Count: 3, Mean: 5, Min: 2, Max: 9},
+				{Step: 2, Count: 3, Mean: 17.0 / 3, Min: 3, Max: 9},
+				{Step: 3, Count: 3, Mean: 6, Min: 4, Max: 9},
+			},
+		},
+		{
+			name: "SingleRun",
+			request: request.AggregateMetricsRequest{
+				RunIDs:      []string{run2.ID},
+				Name:        "loss",
+				Percentiles: []float64{25, 100},
+			},
+			response: []aggregateMetrics{
+				{Step: 0, Count: 1, Mean: 3, Min: 3, Max: 3, Percentiles: map[string]float64{"25": 3, "100": 3}},
+				{Step: 1, Count: 1, Mean: 4, Min: 4, Max: 4, Percentiles: map[string]float64{"25": 4, "100": 4}},
+				{Step: 2, Count: 1, Mean: 5, Min: 5, Max: 5, Percentiles: map[string]float64{"25": 5, "100": 5}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp []response.AggregateMetricsResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric/aggregate"),
+			)
+			s.Require().Equal(len(tt.response), len(resp))
+			for i := range tt.response {
+				s.Equal(tt.response[i].Step, resp[i].Step)
+				s.Equal(tt.response[i].Count, resp[i].Count)
+				s.InDelta(tt.response[i].Mean, resp[i].Mean.Float64(), 1e-9)
+				s.Equal(tt.response[i].Min, resp[i].Min.Float64())
+				s.Equal(tt.response[i].Max, resp[i].Max.Float64())
+				s.Equal(len(tt.response[i].Percentiles), len(resp[i].Percentiles))
+				for percentile, value := range tt.response[i].Percentiles {
+					s.Equal(value, resp[i].Percentiles[percentile].Float64())
+				}
+			}
+		})
+	}
+}
+
+func (s *AggregateMetricsTestSuite) Test_Error() {
+	run := s.createRunWithMetrics([]float64{1})
+
+	tests := []struct {
+		name    string
+		request request.AggregateMetricsRequest
+		error   string
+	}{
+		{
+			name:    "EmptyRunIDs",
+			request: request.AggregateMetricsRequest{Name: "loss"},
+			error:   "at least one run id should be provided",
+		},
+		{
+			name:    "EmptyMetricName",
+			request: request.AggregateMetricsRequest{RunIDs: []string{run.ID}},
+			error:   "metric name should be provided",
+		},
+		{
+			name: "InvalidPercentile",
+			request: request.AggregateMetricsRequest{
+				RunIDs: []string{run.ID}, Name: "loss", Percentiles: []float64{101},
+			},
+			error: "percentile 101 should be in range [0, 100]",
+		},
+		{
+			name: "InvalidFill",
+			request: request.AggregateMetricsRequest{
+				RunIDs: []string{run.ID}, Name: "loss", Fill: "linear",
+			},
+			error: `"linear" is not a valid fill mode`,
+		},
+		{
+			name: "NotFoundRun",
+			request: request.AggregateMetricsRequest{
+				RunIDs: []string{run.ID, "not-existing-id"}, Name: "loss",
+			},
+			error: "run 'not-existing-id' not found",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.Error
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric/aggregate"),
+			)
+			s.Contains(resp.Message, tt.error)
+		})
+	}
+}
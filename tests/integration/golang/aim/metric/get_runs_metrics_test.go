@@ -0,0 +1,413 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetRunsMetricsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetRunsMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetRunsMetricsTestSuite))
+}
+
+func (s *GetRunsMetricsTestSuite) createRunWithMetric(
+	experimentID int32, key string, metricContext types.JSONB, values []float64,
+) *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             uuid.NewString(),
+		Name:           "TestRun",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		ExperimentID:   experimentID,
+		LifecycleStage: models.LifecycleStageActive,
+		StartTime:      sql.NullInt64{Int64: 123456789, Valid: true},
+	})
+	s.Require().Nil(err)
+	s.addMetric(run, key, metricContext, values)
+	return run
+}
+
+func (s *GetRunsMetricsTestSuite) addMetric(run *models.Run, key string, metricContext types.JSONB, values []float64) {
+	for i, value := range values {
+		_, err := s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       key,
+			Value:     value,
+			Timestamp: 123456789,
+			Step:      int64(i),
+			Iter:      int64(i),
+			RunID:     run.ID,
+			Context:   models.Context{Json: metricContext},
+		})
+		s.Require().Nil(err)
+	}
+	_, err := s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       key,
+		Value:     values[len(values)-1],
+		Timestamp: 123456789,
+		Step:      int64(len(values) - 1),
+		LastIter:  int64(len(values) - 1),
+		RunID:     run.ID,
+		Context:   models.Context{Json: metricContext},
+	})
+	s.Require().Nil(err)
+}
+
+func (s *GetRunsMetricsTestSuite) Test_Ok() {
+	values := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	runs := make([]*models.Run, 5)
+	for i := range runs {
+		runs[i] = s.createRunWithMetric(*s.DefaultExperiment.ID, "loss", nil, values)
+	}
+	s.addMetric(runs[0], "accuracy", types.JSONB(`{"subset":"train"}`), []float64{0.1, 0.2})
+
+	allIters := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	allValues := make([]*api.MetricValue, len(values))
+	for i := range values {
+		allValues[i] = common.GetPointer(api.NewMetricValue(values[i], 0))
+	}
+
+	tests := []struct {
+		name     string
+		request  request.GetRunsMetricsRequest
+		response response.GetRunsMetricsResponse
+	}{
+		{
+			name: "FiveRunsInOneCall",
+			request: request.GetRunsMetricsRequest{
+				RunIDs:  []string{runs[0].ID, runs[1].ID, runs[2].ID, runs[3].ID, runs[4].ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+			},
+			response: response.GetRunsMetricsResponse{
+				runs[0].ID: {{Name: "loss", Iters: allIters, Values: allValues, Context: []byte(`{}`)}},
+				runs[1].ID: {{Name: "loss", Iters: allIters, Values: allValues, Context: []byte(`{}`)}},
+				runs[2].ID: {{Name: "loss", Iters: allIters, Values: allValues, Context: []byte(`{}`)}},
+				runs[3].ID: {{Name: "loss", Iters: allIters, Values: allValues, Context: []byte(`{}`)}},
+				runs[4].ID: {{Name: "loss", Iters: allIters, Values: allValues, Context: []byte(`{}`)}},
+			},
+		},
+		{
+			name: "Downsampled",
+			request: request.GetRunsMetricsRequest{
+				RunIDs:  []string{runs[0].ID, runs[1].ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+				Steps:   5,
+			},
+			response: response.GetRunsMetricsResponse{
+				runs[0].ID: {{
+					Name:    "loss",
+					Iters:   []int{0, 2, 4, 6, 8},
+					Values:  []*api.MetricValue{allValues[0], allValues[2], allValues[4], allValues[6], allValues[8]},
+					Context: []byte(`{}`),
+				}},
+				runs[1].ID: {{
+					Name:    "loss",
+					Iters:   []int{0, 2, 4, 6, 8},
+					Values:  []*api.MetricValue{allValues[0], allValues[2], allValues[4], allValues[6], allValues[8]},
+					Context: []byte(`{}`),
+				}},
+			},
+		},
+		{
+			name: "SeveralMetricsWithContext",
+			request: request.GetRunsMetricsRequest{
+				RunIDs: []string{runs[0].ID, runs[1].ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{
+					{Name: "loss"},
+					{Name: "accuracy", Context: fiber.Map{"subset": "train"}},
+				},
+			},
+			response: response.GetRunsMetricsResponse{
+				runs[0].ID: {
+					{
+						Name:  "accuracy",
+						Iters: []int{0, 1},
+						Values: []*api.MetricValue{
+							common.GetPointer(api.NewMetricValue(0.1, 0)), common.GetPointer(api.NewMetricValue(0.2, 0)),
+						},
+						Context: []byte(`{"subset":"train"}`),
+					},
+					{Name: "loss", Iters: allIters, Values: allValues, Context: []byte(`{}`)},
+				},
+				runs[1].ID: {{Name: "loss", Iters: allIters, Values: allValues, Context: []byte(`{}`)}},
+			},
+		},
+		{
+			name: "RunWithoutRequestedMetric",
+			request: request.GetRunsMetricsRequest{
+				RunIDs:  []string{runs[1].ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "accuracy", Context: fiber.Map{"subset": "train"}}},
+			},
+			response: response.GetRunsMetricsResponse{
+				runs[1].ID: {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.GetRunsMetricsResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric/batch"),
+			)
+			s.Require().Equal(len(tt.response), len(resp))
+			for runID, expected := range tt.response {
+				actual, ok := resp[runID]
+				s.Require().True(ok)
+				s.Require().Equal(len(expected), len(actual))
+				for i := range expected {
+					s.Equal(expected[i].Name, actual[i].Name)
+					s.Equal(expected[i].Iters, actual[i].Iters)
+					s.Equal(expected[i].Values, actual[i].Values)
+					s.JSONEq(string(expected[i].Context), string(actual[i].Context))
+				}
+			}
+		})
+	}
+}
+
+func (s *GetRunsMetricsTestSuite) Test_Window() {
+	// log a long series in two contexts with timestamps following the steps.
+	const numMetrics = 2000
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             uuid.NewString(),
+		Name:           "TestRun",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	for _, metricContext := range []types.JSONB{nil, types.JSONB(`{"subset":"train"}`)} {
+		for i := 0; i < numMetrics; i++ {
+			_, err := s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+				Key:       "loss",
+				Value:     float64(i),
+				Timestamp: int64(1000 + i),
+				Step:      int64(i),
+				Iter:      int64(i),
+				RunID:     run.ID,
+				Context:   models.Context{Json: metricContext},
+			})
+			s.Require().Nil(err)
+		}
+		_, err := s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+			Key:       "loss",
+			Value:     numMetrics - 1,
+			Timestamp: 1000 + numMetrics - 1,
+			Step:      numMetrics - 1,
+			LastIter:  numMetrics - 1,
+			RunID:     run.ID,
+			Context:   models.Context{Json: metricContext},
+		})
+		s.Require().Nil(err)
+	}
+
+	iters := func(from, to, step int) []int {
+		var result []int
+		for i := from; i <= to; i += step {
+			result = append(result, i)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name          string
+		request       request.GetRunsMetricsRequest
+		expectedIters []int
+	}{
+		{
+			name: "MidRangeStepWindow",
+			request: request.GetRunsMetricsRequest{
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					MinStep: common.GetPointer[int64](1000),
+					MaxStep: common.GetPointer[int64](1009),
+				},
+				RunIDs:  []string{run.ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+			},
+			expectedIters: iters(1000, 1009, 1),
+		},
+		{
+			name: "TailStepWindowDownsampled",
+			request: request.GetRunsMetricsRequest{
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					MinStep: common.GetPointer[int64](numMetrics - 1000),
+				},
+				RunIDs:  []string{run.ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+				Steps:   100,
+			},
+			// the requested steps are spread over the window, not over the whole series.
+			expectedIters: iters(numMetrics-1000+4, numMetrics-1, 10),
+		},
+		{
+			name: "TimeWindowWithContext",
+			request: request.GetRunsMetricsRequest{
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					StartTime: common.GetPointer[int64](1500),
+					EndTime:   common.GetPointer[int64](1504),
+				},
+				RunIDs:  []string{run.ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss", Context: fiber.Map{"subset": "train"}}},
+			},
+			expectedIters: iters(500, 504, 1),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.GetRunsMetricsResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric/batch"),
+			)
+			s.Require().Len(resp[run.ID], 1)
+			s.Equal(tt.expectedIters, resp[run.ID][0].Iters)
+			for i, iter := range tt.expectedIters {
+				s.Equal(float64(iter), resp[run.ID][0].Values[i].Float64())
+			}
+		})
+	}
+}
+
+func (s *GetRunsMetricsTestSuite) Test_Error() {
+	run := s.createRunWithMetric(*s.DefaultExperiment.ID, "loss", nil, []float64{1})
+
+	// run of another namespace has to be invisible.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(int32(0)),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Custom Experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	otherRun := s.createRunWithMetric(*experiment.ID, "loss", nil, []float64{1})
+
+	tooManyRunIDs := make([]string, 101)
+	for i := range tooManyRunIDs {
+		tooManyRunIDs[i] = run.ID
+	}
+
+	tests := []struct {
+		name    string
+		request request.GetRunsMetricsRequest
+		error   string
+	}{
+		{
+			name:    "EmptyRunIDs",
+			request: request.GetRunsMetricsRequest{Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}}},
+			error:   "at least one run id should be provided",
+		},
+		{
+			name: "TooManyRunIDs",
+			request: request.GetRunsMetricsRequest{
+				RunIDs: tooManyRunIDs, Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+			},
+			error: "no more than 100 run ids could be provided",
+		},
+		{
+			name:    "EmptyMetrics",
+			request: request.GetRunsMetricsRequest{RunIDs: []string{run.ID}},
+			error:   "at least one metric should be provided",
+		},
+		{
+			name: "EmptyMetricName",
+			request: request.GetRunsMetricsRequest{
+				RunIDs: []string{run.ID}, Metrics: []request.GetRunsMetricsTraceRequest{{}},
+			},
+			error: "metric name should be provided",
+		},
+		{
+			name: "TooManySteps",
+			request: request.GetRunsMetricsRequest{
+				RunIDs: []string{run.ID}, Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}}, Steps: 5001,
+			},
+			error: "steps 5001 should be in range [1, 5000]",
+		},
+		{
+			name: "IncorrectStepWindow",
+			request: request.GetRunsMetricsRequest{
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					MinStep: common.GetPointer[int64](10),
+					MaxStep: common.GetPointer[int64](5),
+				},
+				RunIDs:  []string{run.ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+			},
+			error: "min_step 10 should be less than or equal to max_step 5",
+		},
+		{
+			name: "IncorrectTimeWindow",
+			request: request.GetRunsMetricsRequest{
+				MetricHistoryWindow: request.MetricHistoryWindow{
+					StartTime: common.GetPointer[int64](2000),
+					EndTime:   common.GetPointer[int64](1000),
+				},
+				RunIDs:  []string{run.ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+			},
+			error: "start_time 2000 should be less than or equal to end_time 1000",
+		},
+		{
+			name: "NotFoundContext",
+			request: request.GetRunsMetricsRequest{
+				RunIDs:  []string{run.ID},
+				Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss", Context: fiber.Map{"subset": "unknown"}}},
+			},
+			error: `metric context '{"subset":"unknown"}' not found`,
+		},
+		{
+			name: "RunOfAnotherNamespace",
+			request: request.GetRunsMetricsRequest{
+				RunIDs: []string{run.ID, otherRun.ID}, Metrics: []request.GetRunsMetricsTraceRequest{{Name: "loss"}},
+			},
+			error: fmt.Sprintf("run '%s' not found", otherRun.ID),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.Error
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric/batch"),
+			)
+			s.Contains(resp.Message, tt.error)
+		})
+	}
+}
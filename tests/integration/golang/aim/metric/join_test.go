@@ -0,0 +1,205 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+// joinedMetricPoint is an expected point of joined metrics.
+type joinedMetricPoint struct {
+	Step int64
+	X    float64
+	Y    float64
+}
+
+type JoinMetricsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestJoinMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(JoinMetricsTestSuite))
+}
+
+func (s *JoinMetricsTestSuite) createRunWithMetrics(metrics map[string]map[int64]float64) *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             uuid.NewString(),
+		Name:           "TestRun",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+		StartTime:      sql.NullInt64{Int64: 123456789, Valid: true},
+	})
+	s.Require().Nil(err)
+	for key, values := range metrics {
+		for step, value := range values {
+			_, err := s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+				Key:       key,
+				Value:     value,
+				Timestamp: 123456789,
+				Step:      step,
+				RunID:     run.ID,
+				Iter:      step,
+			})
+			s.Require().Nil(err)
+		}
+	}
+	return run
+}
+
+func (s *JoinMetricsTestSuite) Test_Ok() {
+	run1 := s.createRunWithMetrics(map[string]map[int64]float64{
+		"learning_rate": {0: 0.1, 1: 0.2, 2: 0.3},
+		"accuracy":      {0: 0.5, 1: 0.6, 2: 0.7},
+	})
+	run2 := s.createRunWithMetrics(map[string]map[int64]float64{
+		"learning_rate": {0: 1, 2: 3, 4: 5},
+		"accuracy":      {0: 10, 1: 15, 4: 50},
+	})
+	run3 := s.createRunWithMetrics(map[string]map[int64]float64{
+		"learning_rate": {0: 1},
+	})
+
+	tests := []struct {
+		name     string
+		request  request.JoinMetricsRequest
+		response map[string][]joinedMetricPoint
+	}{
+		{
+			name: "SameSteps",
+			request: request.JoinMetricsRequest{
+				RunIDs:  []string{run1.ID},
+				XMetric: "learning_rate",
+				YMetric: "accuracy",
+			},
+			response: map[string][]joinedMetricPoint{
+				run1.ID: {
+					{Step: 0, X: 0.1, Y: 0.5},
+					{Step: 1, X: 0.2, Y: 0.6},
+					{Step: 2, X: 0.3, Y: 0.7},
+				},
+			},
+		},
+		{
+			name: "DifferentStepsDropped",
+			request: request.JoinMetricsRequest{
+				RunIDs:  []string{run1.ID, run2.ID, run3.ID},
+				XMetric: "learning_rate",
+				YMetric: "accuracy",
+			},
+			response: map[string][]joinedMetricPoint{
+				run1.ID: {
+					{Step: 0, X: 0.1, Y: 0.5},
+					{Step: 1, X: 0.2, Y: 0.6},
+					{Step: 2, X: 0.3, Y: 0.7},
+				},
+				run2.ID: {
+					{Step: 0, X: 1, Y: 10},
+					{Step: 4, X: 5, Y: 50},
+				},
+			},
+		},
+		{
+			name: "DifferentStepsInterpolated",
+			request: request.JoinMetricsRequest{
+				RunIDs:      []string{run2.ID},
+				XMetric:     "learning_rate",
+				YMetric:     "accuracy",
+				Interpolate: true,
+			},
+			response: map[string][]joinedMetricPoint{
+				run2.ID: {
+					{Step: 0, X: 1, Y: 10},
+					{Step: 1, X: 2, Y: 15},
+					{Step: 2, X: 3, Y: 15 + 35.0/3},
+					{Step: 4, X: 5, Y: 50},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp []response.JoinMetricsResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric/join"),
+			)
+			s.Require().Equal(len(tt.response), len(resp))
+			for _, run := range resp {
+				expected, ok := tt.response[run.RunID]
+				s.Require().True(ok)
+				s.Require().Equal(len(expected), len(run.Points))
+				for i := range expected {
+					s.Equal(expected[i].Step, run.Points[i].Step)
+					s.InDelta(expected[i].X, run.Points[i].X.Float64(), 1e-9)
+					s.InDelta(expected[i].Y, run.Points[i].Y.Float64(), 1e-9)
+				}
+			}
+		})
+	}
+}
+
+func (s *JoinMetricsTestSuite) Test_Error() {
+	run := s.createRunWithMetrics(map[string]map[int64]float64{
+		"learning_rate": {0: 0.1},
+	})
+
+	tests := []struct {
+		name    string
+		request request.JoinMetricsRequest
+		error   string
+	}{
+		{
+			name:    "EmptyRunIDs",
+			request: request.JoinMetricsRequest{XMetric: "learning_rate", YMetric: "accuracy"},
+			error:   "at least one run id should be provided",
+		},
+		{
+			name:    "EmptyXMetric",
+			request: request.JoinMetricsRequest{RunIDs: []string{run.ID}, YMetric: "accuracy"},
+			error:   "x metric name should be provided",
+		},
+		{
+			name:    "EmptyYMetric",
+			request: request.JoinMetricsRequest{RunIDs: []string{run.ID}, XMetric: "learning_rate"},
+			error:   "y metric name should be provided",
+		},
+		{
+			name: "NotFoundRun",
+			request: request.JoinMetricsRequest{
+				RunIDs: []string{"not-existing-id"}, XMetric: "learning_rate", YMetric: "accuracy",
+			},
+			error: "run 'not-existing-id' not found",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.Error
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric/join"),
+			)
+			s.Contains(resp.Message, tt.error)
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package namespace
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
@@ -21,29 +23,64 @@ func TestNamespaceTestSuite(t *testing.T) {
 
 func (s *NamespaceTestSuite) Test_Error() {
 	tests := []struct {
-		name      string
-		error     *api.ErrorResponse
-		namespace string
+		name    string
+		method  string
+		request any
+		path    string
 	}{
 		{
-			name:      "RequestNotExistingNamespace",
-			error:     api.NewResourceDoesNotExistError("unable to find namespace with code: not-existing-namespace"),
-			namespace: "not-existing-namespace",
+			name:   "GetExperiments",
+			method: http.MethodGet,
+			path:   "/experiments",
+		},
+		{
+			name:   "GetProject",
+			method: http.MethodGet,
+			path:   "/projects",
+		},
+		{
+			name:   "GetApps",
+			method: http.MethodGet,
+			path:   "/apps",
+		},
+		{
+			name:    "CreateApp",
+			method:  http.MethodPost,
+			request: request.CreateAppRequest{Type: "tensorboard"},
+			path:    "/apps",
+		},
+		{
+			name:   "GetRunInfo",
+			method: http.MethodGet,
+			path:   "/runs/id/info",
+		},
+		{
+			name:   "SearchRuns",
+			method: http.MethodGet,
+			path:   "/runs/search/run",
 		},
 	}
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			resp := api.ErrorResponse{}
-			s.Require().Nil(
-				s.AIMClient().WithNamespace(
-					tt.namespace,
-				).WithResponse(
-					&resp,
-				).DoRequest(
-					"/experiments",
-				),
+			client := s.AIMClient().WithMethod(
+				tt.method,
+			).WithNamespace(
+				"not-existing-namespace",
+			).WithResponse(
+				&resp,
+			)
+			if tt.request != nil {
+				client = client.WithRequest(tt.request)
+			}
+			s.Require().Nil(client.DoRequest("%s", tt.path))
+			s.Equal(http.StatusNotFound, client.GetStatusCode())
+			s.Equal(
+				api.NewResourceDoesNotExistError(
+					"unable to find namespace with code: not-existing-namespace",
+				).Error(),
+				resp.Error(),
 			)
-			s.Equal(tt.error.Error(), resp.Error())
 			s.Equal(api.ErrorCodeResourceDoesNotExist, string(resp.ErrorCode))
 		})
 	}
@@ -2,11 +2,21 @@ package run
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/response"
+	aimRequest "github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	aimModels "github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
 
@@ -37,3 +47,151 @@ func (s *GetProjectActivityTestSuite) Test_Ok() {
 		s.Equal(10, v)
 	}
 }
+
+func (s *GetProjectActivityTestSuite) Test_MatchesInMemoryCalculation() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 50; i++ {
+		run := &models.Run{
+			ID:             fmt.Sprintf("run%d", i),
+			Name:           fmt.Sprintf("TestRun_%d", i),
+			Status:         models.StatusFinished,
+			SourceType:     "JOB",
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+			StartTime:      sql.NullInt64{Int64: start.Add(time.Duration(i) * 23 * time.Minute).UnixMilli(), Valid: true},
+		}
+		switch i % 3 {
+		case 0:
+			run.Status = models.StatusRunning
+		case 1:
+			run.LifecycleStage = models.LifecycleStageDeleted
+		}
+		if i%2 == 0 {
+			run.ExperimentID = *experiment.ID
+		}
+		_, err := s.RunFixtures.CreateRun(context.Background(), run)
+		s.Require().Nil(err)
+	}
+
+	// calculate expected activity with all the runs in memory.
+	var runs []models.Run
+	for _, experimentID := range []int32{*s.DefaultExperiment.ID, *experiment.ID} {
+		experimentRuns, err := s.RunFixtures.GetRuns(context.Background(), experimentID)
+		s.Require().Nil(err)
+		runs = append(runs, experimentRuns...)
+	}
+	expected := response.ProjectActivityResponse{
+		NumRuns:        len(runs),
+		NumExperiments: 2,
+		ActivityMap:    map[string]int{},
+	}
+	for _, run := range runs {
+		switch {
+		case run.LifecycleStage == models.LifecycleStageDeleted:
+			expected.NumArchivedRuns += 1
+		case run.Status == models.StatusRunning:
+			expected.NumActiveRuns += 1
+		}
+		expected.ActivityMap[time.UnixMilli(run.StartTime.Int64).Format("2006-01-02T15:00:00")] += 1
+	}
+
+	var resp response.ProjectActivityResponse
+	s.Require().Nil(s.AIMClient().WithResponse(&resp).DoRequest("/projects/activity"))
+	s.Equal(expected, resp)
+}
+
+func (s *GetProjectActivityTestSuite) Test_SummaryMatchesFullScan() {
+	ctx := context.Background()
+	mlflowClient := s.FastTrackMLClient().Mlflow()
+	aimClient := s.FastTrackMLClient().Aim()
+
+	experiment, err := mlflowClient.CreateExperiment(ctx, &request.CreateExperimentRequest{
+		Name: "Test Experiment",
+	})
+	s.Require().Nil(err)
+
+	// create runs of both experiments, spread across several hours.
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var runIDs []string
+	for i := 0; i < 12; i++ {
+		experimentID := fmt.Sprint(*s.DefaultExperiment.ID)
+		if i%2 == 0 {
+			experimentID = experiment.ID
+		}
+		run, err := mlflowClient.CreateRun(ctx, &request.CreateRunRequest{
+			ExperimentID: experimentID,
+			Name:         fmt.Sprintf("TestRun_%d", i),
+			StartTime:    start.Add(time.Duration(i) * 37 * time.Minute).UnixMilli(),
+		})
+		s.Require().Nil(err)
+		runIDs = append(runIDs, run.Run.Info.ID)
+	}
+
+	// change runs with the different operations.
+	_, err = mlflowClient.UpdateRun(ctx, &request.UpdateRunRequest{RunID: runIDs[0], Status: string(models.StatusFinished)})
+	s.Require().Nil(err)
+	_, err = mlflowClient.UpdateRun(ctx, &request.UpdateRunRequest{RunID: runIDs[1], Status: string(models.StatusKilled)})
+	s.Require().Nil(err)
+	s.Require().Nil(mlflowClient.DeleteRun(ctx, &request.DeleteRunRequest{RunID: runIDs[2]}))
+	_, err = aimClient.UpdateRun(ctx, &aimRequest.UpdateRunRequest{ID: runIDs[3], Archived: common.GetPointer(true)})
+	s.Require().Nil(err)
+	_, err = aimClient.UpdateRun(ctx, &aimRequest.UpdateRunRequest{ID: runIDs[2], Archived: common.GetPointer(false)})
+	s.Require().Nil(err)
+	_, err = aimClient.DeleteRun(ctx, &aimRequest.DeleteRunRequest{ID: runIDs[5]})
+	s.Require().Nil(err)
+	s.Require().Nil(mlflowClient.DeleteExperiment(ctx, &request.DeleteExperimentRequest{ID: experiment.ID}))
+
+	// calculate expected summary with a full scan of the runs.
+	experimentID, err := strconv.ParseInt(experiment.ID, 10, 32)
+	s.Require().Nil(err)
+	var runs []models.Run
+	for _, id := range []int32{*s.DefaultExperiment.ID, int32(experimentID)} {
+		experimentRuns, err := s.RunFixtures.GetRuns(ctx, id)
+		s.Require().Nil(err)
+		runs = append(runs, experimentRuns...)
+	}
+	s.Require().Len(runs, 11)
+	summary := map[int64]*aimModels.RunActivity{}
+	for _, run := range runs {
+		bucket := aimModels.GetRunActivityBucket(run.StartTime.Int64)
+		activity, ok := summary[bucket]
+		if !ok {
+			activity = &aimModels.RunActivity{NamespaceID: s.DefaultNamespace.ID, Bucket: bucket}
+			summary[bucket] = activity
+		}
+		activity.NumRuns += 1
+		switch {
+		case run.LifecycleStage == models.LifecycleStageDeleted:
+			activity.NumArchivedRuns += 1
+		case run.Status == models.StatusRunning:
+			activity.NumActiveRuns += 1
+		}
+	}
+	var expected []aimModels.RunActivity
+	for _, activity := range summary {
+		expected = append(expected, *activity)
+	}
+	slices.SortFunc(expected, func(a, b aimModels.RunActivity) int {
+		return int(a.Bucket - b.Bucket)
+	})
+
+	// summary is maintained asynchronously, so wait for it to catch up.
+	s.Eventually(func() bool {
+		actual, err := s.ProjectFixtures.GetRunActivity(ctx, s.DefaultNamespace.ID)
+		s.Require().Nil(err)
+		return slices.Equal(expected, actual)
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// cold rebuild has to produce the same summary.
+	s.Require().Nil(s.ProjectFixtures.RebuildRunActivity(ctx))
+	actual, err := s.ProjectFixtures.GetRunActivity(ctx, s.DefaultNamespace.ID)
+	s.Require().Nil(err)
+	s.Equal(expected, actual)
+}
@@ -0,0 +1,69 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetProjectFeatureFlagsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetProjectFeatureFlagsTestSuite(t *testing.T) {
+	testSuite := new(GetProjectFeatureFlagsTestSuite)
+	testSuite.Config = config.Config{
+		LiveUpdatesEnabled:    true,
+		NamespaceFeatureFlags: []string{"disabled:live-updates=false"},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *GetProjectFeatureFlagsTestSuite) Test_Ok() {
+	for _, code := range []string{"disabled", "other"} {
+		_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+			Code:                code,
+			DefaultExperimentID: common.GetPointer(int32(0)),
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name               string
+		namespace          string
+		liveUpdatesEnabled int
+	}{
+		{
+			name:               "DefaultNamespaceFallsBackToGlobal",
+			namespace:          "default",
+			liveUpdatesEnabled: 1,
+		},
+		{
+			name:               "NamespaceWithLiveUpdatesDisabled",
+			namespace:          "disabled",
+			liveUpdatesEnabled: 0,
+		},
+		{
+			name:               "NamespaceWithoutFlagsFallsBackToGlobal",
+			namespace:          "other",
+			liveUpdatesEnabled: 1,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.GetProjectResponse
+			s.Require().Nil(
+				s.AIMClient().WithNamespace(tt.namespace).WithResponse(&resp).DoRequest("/projects"),
+			)
+			s.Equal("FastTrackML", resp.Name)
+			s.Equal(tt.liveUpdatesEnabled, resp.LiveUpdatesEnabled)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetProjectParamValuesTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetProjectParamValuesTestSuite(t *testing.T) {
+	suite.Run(t, new(GetProjectParamValuesTestSuite))
+}
+
+func (s *GetProjectParamValuesTestSuite) Test_Ok() {
+	// create runs with overlapping and distinct param values.
+	runParams := []map[string]string{
+		{"lr": "0.1", "optimizer": "sgd"},
+		{"lr": "10", "optimizer": "adam"},
+		{"lr": "0.1", "optimizer": "sgd"},
+		{"lr": "2"},
+	}
+	for i, params := range runParams {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("run%d", i),
+			Name:           fmt.Sprintf("run%d", i),
+			Status:         models.StatusFinished,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			ExperimentID:   *s.DefaultExperiment.ID,
+		})
+		s.Require().Nil(err)
+		for key, value := range params {
+			_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+				Key:   key,
+				Value: value,
+				RunID: run.ID,
+			})
+			s.Require().Nil(err)
+		}
+	}
+
+	// params of deleted runs are ignored.
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "deleted",
+		Name:           "deleted",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageDeleted,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:   "optimizer",
+		Value: "rmsprop",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name     string
+		key      string
+		expected []string
+	}{
+		{
+			name:     "NumericValues",
+			key:      "lr",
+			expected: []string{"0.1", "2", "10"},
+		},
+		{
+			name:     "StringValues",
+			key:      "optimizer",
+			expected: []string{"adam", "sgd"},
+		},
+		{
+			name:     "NotExistingParam",
+			key:      "batch_size",
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.ProjectParamValuesResponse
+			s.Require().Nil(
+				s.AIMClient().WithQuery(
+					request.GetProjectParamValuesRequest{Key: tt.key},
+				).WithResponse(
+					&resp,
+				).DoRequest("/projects/params/values"),
+			)
+			s.Equal(response.ProjectParamValuesResponse{Key: tt.key, Values: tt.expected}, resp)
+		})
+	}
+}
+
+func (s *GetProjectParamValuesTestSuite) Test_Error() {
+	var resp api.ErrorResponse
+	client := s.AIMClient().WithResponse(&resp)
+	s.Require().Nil(client.DoRequest("/projects/params/values"))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal("param key should be provided", resp.Message)
+}
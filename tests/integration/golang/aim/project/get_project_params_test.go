@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
 
@@ -164,4 +166,76 @@ func (s *GetProjectParamsTestSuite) Test_Ok() {
 	s.Equal(map[string]interface{}{"tags": map[string]interface{}{}}, resp.Params)
 }
 
-func (s *GetProjectParamsTestSuite) Test_Error() {}
+func (s *GetProjectParamsTestSuite) Test_OkFilteredByContext() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "id",
+		Name:           "chill-run",
+		Status:         models.StatusScheduled,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	// create the same metric in two different contexts and one more metric in the second context.
+	for _, metric := range []models.LatestMetric{
+		{Key: "loss", Context: models.Context{Json: []byte(`{"subset":"train"}`)}},
+		{Key: "loss", Context: models.Context{Json: []byte(`{"subset":"validation"}`)}},
+		{Key: "accuracy", Context: models.Context{Json: []byte(`{"subset":"validation"}`)}},
+	} {
+		metric.Value, metric.Timestamp, metric.Step, metric.LastIter, metric.RunID = 0.5, 1234567890, 1, 1, run.ID
+		_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &metric)
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name     string
+		request  map[any]any
+		response map[string][]fiber.Map
+	}{
+		{
+			name:    "RequestProjectParamsWithoutContextFilter",
+			request: map[any]any{"sequence": "metric"},
+			response: map[string][]fiber.Map{
+				"loss":     {{"subset": "train"}, {"subset": "validation"}},
+				"accuracy": {{"subset": "validation"}},
+			},
+		},
+		{
+			name:    "RequestProjectParamsFilteredByContext",
+			request: map[any]any{"sequence": "metric", "context": `{"subset": "train"}`},
+			response: map[string][]fiber.Map{
+				"loss": {{"subset": "train"}},
+			},
+		},
+		{
+			name:     "RequestProjectParamsFilteredByNotExistingContext",
+			request:  map[any]any{"sequence": "metric", "context": `{"subset": "test"}`},
+			response: map[string][]fiber.Map{},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.ProjectParamsResponse{}
+			s.Require().Nil(
+				s.AIMClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/projects/params"),
+			)
+			s.Equal(len(tt.response), len(resp.Metric))
+			for key, contexts := range tt.response {
+				s.ElementsMatch(contexts, resp.Metric[key])
+			}
+		})
+	}
+}
+
+func (s *GetProjectParamsTestSuite) Test_Error() {
+	var resp api.ErrorResponse
+	client := s.AIMClient().WithQuery(map[any]any{"context": "not-a-json"}).WithResponse(&resp)
+	s.Require().Nil(client.DoRequest("/projects/params"))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Contains(resp.Message, "context should be a valid JSON object")
+}
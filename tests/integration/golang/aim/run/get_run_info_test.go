@@ -3,6 +3,8 @@ package run
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -60,6 +62,43 @@ func (s *GetRunInfoTestSuite) Test_Ok() {
 	}
 }
 
+func (s *GetRunInfoTestSuite) Test_Fields_Ok() {
+	// check each combination of the fields. empty combination means that all the fields are requested.
+	sections := []string{"params", "metrics", "tags", "summary"}
+	for mask := 0; mask < 1<<len(sections); mask++ {
+		var fields []string
+		for i, section := range sections {
+			if mask&(1<<i) != 0 {
+				fields = append(fields, section)
+			}
+		}
+		s.Run(fmt.Sprintf("Fields[%s]", strings.Join(fields, ",")), func() {
+			var resp map[string]any
+			s.Require().Nil(
+				s.AIMClient().WithQuery(map[any]any{
+					"fields": strings.Join(fields, ","),
+				}).WithResponse(&resp).DoRequest("/runs/%s/info", s.run.ID),
+			)
+
+			requested := func(section string) bool {
+				return len(fields) == 0 || slices.Contains(fields, section)
+			}
+			_, ok := resp["props"]
+			s.Equal(requested("summary"), ok)
+			_, ok = resp["traces"]
+			s.Equal(requested("metrics"), ok)
+			params, ok := resp["params"].(map[string]any)
+			s.Equal(requested("params") || requested("tags"), ok)
+			_, ok = params["tags"]
+			s.Equal(requested("tags"), ok)
+			for _, param := range s.run.Params {
+				_, ok = params[param.Key]
+				s.Equal(requested("params"), ok)
+			}
+		})
+	}
+}
+
 func (s *GetRunInfoTestSuite) Test_Error() {
 	tests := []struct {
 		name  string
@@ -79,4 +118,12 @@ func (s *GetRunInfoTestSuite) Test_Error() {
 			s.Regexp(tt.error, resp.Message)
 		})
 	}
+
+	var resp response.Error
+	s.Require().Nil(
+		s.AIMClient().WithQuery(map[any]any{
+			"fields": "params,unknown",
+		}).WithResponse(&resp).DoRequest("/runs/%s/info", s.run.ID),
+	)
+	s.Equal(`"unknown" is not a valid field`, resp.Message)
 }
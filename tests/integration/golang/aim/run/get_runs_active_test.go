@@ -115,3 +115,47 @@ func (s *GetRunsActiveTestSuite) Test_Ok() {
 		})
 	}
 }
+
+func (s *GetRunsActiveTestSuite) Test_ArchivedAndPaginated() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	runs, err := s.RunFixtures.CreateExampleRuns(context.Background(), experiment, 5)
+	s.Require().Nil(err)
+
+	// archived and finished runs are never returned.
+	s.Require().Nil(s.RunFixtures.ArchiveRun(context.Background(), s.DefaultNamespace.ID, []string{runs[0].ID}))
+	runs[1].Status = models.StatusFailed
+	s.Require().Nil(s.RunFixtures.UpdateRun(context.Background(), runs[1]))
+
+	getActiveRunIDs := func(query map[any]any) []string {
+		resp := new(bytes.Buffer)
+		s.Require().Nil(
+			s.AIMClient().WithQuery(
+				query,
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest("/runs/active"),
+		)
+		decodedData, err := encoding.NewDecoder(resp).Decode()
+		s.Require().Nil(err)
+
+		var ids []string
+		for i := len(runs) - 1; i >= 0; i-- {
+			if decodedData[fmt.Sprintf("%v.props.name", runs[i].ID)] != nil {
+				ids = append(ids, runs[i].ID)
+			}
+		}
+		return ids
+	}
+
+	// the newest runs are returned first and next page starts after the last run of the previous one.
+	s.Equal([]string{runs[4].ID, runs[3].ID, runs[2].ID}, getActiveRunIDs(map[any]any{}))
+	s.Equal([]string{runs[4].ID, runs[3].ID}, getActiveRunIDs(map[any]any{"limit": 2}))
+	s.Equal([]string{runs[2].ID}, getActiveRunIDs(map[any]any{"limit": 2, "offset": runs[3].ID}))
+}
@@ -92,6 +92,14 @@ func (s *UpdateRunTestSuite) Test_Error() {
 			requestBody: map[string]any{},
 			error:       "unable to find run 'incorrect-ID'|not found",
 		},
+		{
+			name: "UpdateRunWithControlCharactersInName",
+			ID:   s.run.ID,
+			requestBody: map[string]any{
+				"run_name": "first\nsecond",
+			},
+			error: "run name should not contain control characters",
+		},
 	}
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
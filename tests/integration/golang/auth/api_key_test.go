@@ -0,0 +1,303 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zeebo/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type APIKeyTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestAPIKeyTestSuite(t *testing.T) {
+	// create users configuration firstly.
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name: "user1",
+				Roles: []string{
+					"ns:namespace1",
+				},
+				Password: "user1password",
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	configPath := fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	// #nosec G304
+	f, err := os.Create(configPath)
+	assert.Nil(t, err)
+	_, err = f.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	// run test suite with newly created configuration.
+	testSuite := new(APIKeyTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthType:        auth.TypeUser,
+			AuthUsersConfig: configPath,
+		},
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *APIKeyTestSuite) Test_Ok() {
+	// create test namespaces.
+	_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	_, err = s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "namespace2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	basicAuthHeaders := newBasicAuthHeaders()
+	apiKeyHeaders := func(key string) map[string]string {
+		return map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": fmt.Sprintf("Bearer %s", key),
+		}
+	}
+
+	// create write and read API keys. the whole key is returned only once.
+	writeKey := s.createAPIKey("namespace1", "ci", models.APIKeyAccessLevelWrite)
+	s.True(models.IsAPIKey(writeKey.Key))
+	s.Equal("ci", writeKey.APIKey.Name)
+	s.Equal("write", writeKey.APIKey.AccessLevel)
+	s.Equal(writeKey.Key[:len(writeKey.APIKey.Prefix)], writeKey.APIKey.Prefix)
+	s.Nil(writeKey.APIKey.LastUsedAt)
+	readKey := s.createAPIKey("namespace1", "dashboard", models.APIKeyAccessLevelRead)
+
+	// write key could be used to create resources of its namespace.
+	experiment := mlflowResponse.CreateExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithNamespace(
+			"namespace1",
+		).WithHeaders(
+			apiKeyHeaders(writeKey.Key),
+		).WithRequest(
+			request.CreateExperimentRequest{Name: "experiment"},
+		).WithResponse(
+			&experiment,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+		),
+	)
+	s.NotEmpty(experiment.ID)
+
+	// read key could be used to read resources of its namespace.
+	experiments := mlflowResponse.SearchExperimentsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			"namespace1",
+		).WithHeaders(
+			apiKeyHeaders(readKey.Key),
+		).WithResponse(
+			&experiments,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+		),
+	)
+	s.Require().Len(experiments.Experiments, 1)
+	s.Equal(experiment.ID, experiments.Experiments[0].ID)
+
+	// listing shows only prefixes of the used keys together with last usage time.
+	list := mlflowResponse.ListAPIKeysResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			"namespace1",
+		).WithHeaders(
+			basicAuthHeaders,
+		).WithResponse(
+			&list,
+		).DoRequest(
+			"%s%s", mlflow.APIKeysRoutePrefix, mlflow.APIKeysListRoute,
+		),
+	)
+	s.Require().Len(list.APIKeys, 2)
+	s.Equal(writeKey.APIKey.ID, list.APIKeys[0].ID)
+	s.Equal(writeKey.APIKey.Prefix, list.APIKeys[0].Prefix)
+	s.Equal(readKey.APIKey.ID, list.APIKeys[1].ID)
+	s.Equal(readKey.APIKey.Prefix, list.APIKeys[1].Prefix)
+	for _, apiKey := range list.APIKeys {
+		s.NotNil(apiKey.LastUsedAt)
+	}
+
+	// revoked key can't be used anymore.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithNamespace(
+			"namespace1",
+		).WithHeaders(
+			basicAuthHeaders,
+		).WithRequest(
+			request.RevokeAPIKeyRequest{ID: writeKey.APIKey.ID},
+		).DoRequest(
+			"%s%s", mlflow.APIKeysRoutePrefix, mlflow.APIKeysRevokeRoute,
+		),
+	)
+	resp := api.ErrorResponse{}
+	client := s.MlflowClient().WithNamespace(
+		"namespace1",
+	).WithHeaders(
+		apiKeyHeaders(writeKey.Key),
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute))
+	s.Equal(http.StatusNotFound, client.GetStatusCode())
+	s.Equal("RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: namespace1", resp.Error())
+}
+
+func (s *APIKeyTestSuite) Test_Error() {
+	// create test namespaces.
+	_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	_, err = s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "namespace2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	// create API keys, which are used by the tests.
+	writeKey := s.createAPIKey("namespace1", "ci", models.APIKeyAccessLevelWrite)
+	readKey := s.createAPIKey("namespace1", "dashboard", models.APIKeyAccessLevelRead)
+
+	tests := []struct {
+		name    string
+		key     string
+		method  string
+		request any
+		route   string
+		ns      string
+		status  int
+		error   string
+	}{
+		{
+			name:   "KeyOfOtherNamespace",
+			key:    writeKey.Key,
+			method: http.MethodGet,
+			route:  fmt.Sprintf("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute),
+			ns:     "namespace2",
+			status: http.StatusNotFound,
+			error:  "RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: namespace2",
+		},
+		{
+			name:   "UnknownKey",
+			key:    "ftml_unknown",
+			method: http.MethodGet,
+			route:  fmt.Sprintf("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute),
+			ns:     "namespace1",
+			status: http.StatusNotFound,
+			error:  "RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: namespace1",
+		},
+		{
+			name:    "ReadKeyWithWriteRequest",
+			key:     readKey.Key,
+			method:  http.MethodPost,
+			request: request.CreateExperimentRequest{Name: "experiment"},
+			route:   fmt.Sprintf("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute),
+			ns:      "namespace1",
+			status:  http.StatusForbidden,
+			error:   fmt.Sprintf("PERMISSION_DENIED: api key '%s' has read only access", readKey.APIKey.Prefix),
+		},
+		{
+			name:    "KeyManagementWithKey",
+			key:     writeKey.Key,
+			method:  http.MethodPost,
+			request: request.CreateAPIKeyRequest{Name: "other", AccessLevel: "write"},
+			route:   fmt.Sprintf("%s%s", mlflow.APIKeysRoutePrefix, mlflow.APIKeysCreateRoute),
+			ns:      "namespace1",
+			status:  http.StatusForbidden,
+			error:   "PERMISSION_DENIED: api keys can't be managed using api key",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.MlflowClient().WithMethod(
+				tt.method,
+			).WithNamespace(
+				tt.ns,
+			).WithHeaders(map[string]string{
+				"Content-Type":  "application/json",
+				"Authorization": fmt.Sprintf("Bearer %s", tt.key),
+			}).WithResponse(
+				&resp,
+			)
+			if tt.request != nil {
+				client = client.WithRequest(tt.request)
+			}
+			s.Require().Nil(client.DoRequest("%s", tt.route))
+			s.Equal(tt.status, client.GetStatusCode())
+			s.Equal(tt.error, resp.Error())
+		})
+	}
+}
+
+// createAPIKey creates new API key of the namespace on behalf of the namespace user.
+func (s *APIKeyTestSuite) createAPIKey(
+	namespace, name string, accessLevel models.APIKeyAccessLevel,
+) *mlflowResponse.CreateAPIKeyResponse {
+	resp := mlflowResponse.CreateAPIKeyResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithNamespace(
+			namespace,
+		).WithHeaders(
+			newBasicAuthHeaders(),
+		).WithRequest(
+			request.CreateAPIKeyRequest{Name: name, AccessLevel: string(accessLevel)},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.APIKeysRoutePrefix, mlflow.APIKeysCreateRoute,
+		),
+	)
+	return &resp
+}
+
+// newBasicAuthHeaders creates headers of the request authenticated by the namespace user.
+func newBasicAuthHeaders() map[string]string {
+	return map[string]string{
+		"Content-Type": "application/json",
+		"Authorization": fmt.Sprintf(
+			"Basic %s", base64.StdEncoding.EncodeToString([]byte("user1:user1password")),
+		),
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	chooserResponse "github.com/G-Research/fasttrackml/pkg/ui/chooser/api/response"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
 
@@ -431,3 +432,74 @@ func (s *ConfigAuthTestSuite) TestMlflowAuth_Ok() {
 		})
 	}
 }
+
+func (s *ConfigAuthTestSuite) TestWhoAmI_Ok() {
+	// create test namespaces.
+	namespace1, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		Description:         "Test namespace 1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	namespace2, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "namespace2",
+		Description:         "Test namespace 2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	namespaces, err := s.NamespaceFixtures.GetNamespaces(context.Background())
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name     string
+		user     string
+		password string
+		response chooserResponse.User
+	}{
+		{
+			name:     "TestUser",
+			user:     "user1",
+			password: "user1password",
+			response: chooserResponse.User{
+				AuthType: auth.TypeUser,
+				Name:     "user1",
+				Roles:    []string{"ns:namespace1", "ns:namespace2"},
+				IsAdmin:  false,
+				Namespaces: *chooserResponse.NewListNamespacesResponse(
+					[]models.Namespace{*namespace1, *namespace2},
+				),
+			},
+		},
+		{
+			name:     "TestAdminUser",
+			user:     "user3",
+			password: "user3password",
+			response: chooserResponse.User{
+				AuthType:   auth.TypeUser,
+				Name:       "user3",
+				Roles:      []string{"admin"},
+				IsAdmin:    true,
+				Namespaces: *chooserResponse.NewListNamespacesResponse(namespaces),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			basicAuthToken := base64.StdEncoding.EncodeToString(
+				[]byte(fmt.Sprintf("%s:%s", tt.user, tt.password)),
+			)
+			var resp chooserResponse.User
+			s.Require().Nil(
+				s.ChooserClient().WithResponse(
+					&resp,
+				).WithHeaders(map[string]string{
+					"Authorization": fmt.Sprintf("Basic %s", basicAuthToken),
+				}).DoRequest("/whoami"),
+			)
+			s.Equal(tt.response, resp)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zeebo/assert"
+	"gopkg.in/yaml.v3"
+
+	aimResponse "github.com/G-Research/fasttrackml/pkg/api/aim/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ConfigAuthReloadTestSuite struct {
+	helpers.BaseTestSuite
+	configPath string
+}
+
+func TestConfigAuthReloadTestSuite(t *testing.T) {
+	testSuite := new(ConfigAuthReloadTestSuite)
+	testSuite.configPath = fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	writeUsersConfig(t, testSuite.configPath, []string{"ns:namespace1"})
+
+	// run test suite with newly created configuration.
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthType:        auth.TypeUser,
+			AuthUsersConfig: testSuite.configPath,
+		},
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *ConfigAuthReloadTestSuite) TestRevokeRole_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		Description:         "Test namespace 1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	userToken := base64.StdEncoding.EncodeToString([]byte("user1:user1password"))
+	adminToken := base64.StdEncoding.EncodeToString([]byte("admin:adminpassword"))
+
+	// check that user1 has access to namespace1 namespace.
+	successResponse := aimResponse.GetProjectResponse{}
+	s.Require().Nil(
+		s.AIMClient().WithResponse(
+			&successResponse,
+		).WithNamespace(
+			namespace.Code,
+		).WithHeaders(map[string]string{
+			"Authorization": fmt.Sprintf("Basic %s", userToken),
+		}).DoRequest("/projects"),
+	)
+	s.Equal("FastTrackML", successResponse.Name)
+
+	// revoke namespace1 role from user1 and reload configuration.
+	writeUsersConfig(s.T(), s.configPath, []string{"ns:namespace2"})
+	var resp map[string]any
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithResponse(
+			&resp,
+		).WithHeaders(map[string]string{
+			"Authorization": fmt.Sprintf("Basic %s", adminToken),
+		}).DoRequest("/permissions/reload"),
+	)
+	s.Equal(map[string]any{
+		"status":  "success",
+		"message": "Successfully reloaded permissions.",
+	}, resp)
+
+	// check that user1 has no access to namespace1 namespace anymore.
+	errorResponse := api.ErrorResponse{}
+	s.Require().Nil(
+		s.AIMClient().WithResponse(
+			&errorResponse,
+		).WithNamespace(
+			namespace.Code,
+		).WithHeaders(map[string]string{
+			"Authorization": fmt.Sprintf("Basic %s", userToken),
+		}).DoRequest("/projects"),
+	)
+	s.Equal(
+		"RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: namespace1", errorResponse.Error(),
+	)
+}
+
+// writeUsersConfig writes users configuration with `user1` having the given roles and `admin` user.
+func writeUsersConfig(t *testing.T, configPath string, roles []string) {
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name:     "user1",
+				Roles:    roles,
+				Password: "user1password",
+			},
+			{
+				Name:     "admin",
+				Roles:    []string{"admin"},
+				Password: "adminpassword",
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(configPath, data, 0o600))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	chooserResponse "github.com/G-Research/fasttrackml/pkg/ui/chooser/api/response"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers/oidc"
 )
@@ -312,6 +313,23 @@ func (s *OIDCAuthTestSuite) TestAIMAuth_Ok() {
 				s.Equal("FastTrackML", successResponse.Name)
 			},
 		},
+		{
+			name: "TestUser1CookieAccess",
+			check: func() {
+				// check that access token is read from the auth cookie, when header is not provided.
+				successResponse := aimResponse.GetProjectResponse{}
+				s.Require().Nil(
+					s.AIMClient().WithResponse(
+						&successResponse,
+					).WithNamespace(
+						s.namespace1.Code,
+					).WithHeaders(map[string]string{
+						"Cookie": fmt.Sprintf("access_token=%s", s.user1Token),
+					}).DoRequest("/projects"),
+				)
+				s.Equal("FastTrackML", successResponse.Name)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -610,3 +628,44 @@ func (s *OIDCAuthTestSuite) TestChooserAuth_Ok() {
 		})
 	}
 }
+
+func (s *OIDCAuthTestSuite) TestChooserNamespaces_Ok() {
+	s.SetupTestSuite()
+
+	tests := []struct {
+		name       string
+		token      string
+		namespaces []string
+	}{
+		{
+			name:       "TestLimitedUserSeesOnlyOwnNamespaces",
+			token:      s.user2Token,
+			namespaces: []string{s.namespace2.Code, s.namespace3.Code},
+		},
+		{
+			name:  "TestAdminUserSeesAllNamespaces",
+			token: s.user4Token,
+			namespaces: []string{
+				models.DefaultNamespaceCode, s.namespace1.Code, s.namespace2.Code, s.namespace3.Code,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp chooserResponse.ListNamespaces
+			s.Require().Nil(
+				s.ChooserClient().WithResponse(
+					&resp,
+				).WithHeaders(map[string]string{
+					"Authorization": fmt.Sprintf("Bearer %s", tt.token),
+				}).DoRequest("/namespaces"),
+			)
+			codes := make([]string, len(resp))
+			for i, namespace := range resp {
+				codes[i] = namespace.Code
+			}
+			s.ElementsMatch(tt.namespaces, codes)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers/oidc"
+)
+
+type OIDCMultipleProvidersTestSuite struct {
+	helpers.BaseTestSuite
+	employeesMockServer   *oidc.MockServer
+	contractorsMockServer *oidc.MockServer
+	unknownMockServer     *oidc.MockServer
+}
+
+func TestOIDCMultipleProvidersTestSuite(t *testing.T) {
+	employeesMockServer, err := oidc.NewMockServer()
+	assert.Nil(t, err)
+	contractorsMockServer, err := oidc.NewMockServer()
+	assert.Nil(t, err)
+	unknownMockServer, err := oidc.NewMockServer()
+	assert.Nil(t, err)
+
+	// employees provider is configured with `auth-oidc-*` flags and contractors provider in addition to it.
+	testSuite := new(OIDCMultipleProvidersTestSuite)
+	testSuite.Config = newOIDCTestConfig(employeesMockServer)
+	testSuite.Config.Auth.AuthOIDCProviders = []auth.OIDCProviderConfig{
+		{
+			Name:             "contractors",
+			ClientID:         contractorsMockServer.ClientID(),
+			ProviderEndpoint: contractorsMockServer.Address(),
+			ClaimRoles:       "groups",
+			AdminRole:        "contractors-admin",
+		},
+	}
+	testSuite.employeesMockServer = employeesMockServer
+	testSuite.contractorsMockServer = contractorsMockServer
+	testSuite.unknownMockServer = unknownMockServer
+	suite.Run(t, testSuite)
+}
+
+func (s *OIDCMultipleProvidersTestSuite) Test_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	role := models.Role{Name: "group1"}
+	s.Require().Nil(s.RolesFixtures.CreateRole(context.Background(), &role))
+	s.Require().Nil(s.RolesFixtures.AttachNamespaceToRole(context.Background(), &role, namespace))
+
+	login := func(server *oidc.MockServer, groups ...string) string {
+		token, err := server.Login(
+			context.Background(),
+			&mockoidc.MockUser{
+				Email:  "test.user@example.com",
+				Groups: groups,
+			}, []string{"openid", "groups"},
+		)
+		s.Require().Nil(err)
+		return token
+	}
+	getProjectStatusCode := func(namespace, token string) int {
+		client := s.AIMClient().WithNamespace(namespace).WithHeaders(map[string]string{
+			"Authorization": fmt.Sprintf("Bearer %s", token),
+		})
+		s.Require().Nil(client.DoRequest("/projects"))
+		return client.GetStatusCode()
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		namespace  string
+		statusCode int
+	}{
+		{
+			name:       "EmployeesAdminAccessesDefaultNamespace",
+			token:      login(s.employeesMockServer, "admin"),
+			namespace:  models.DefaultNamespaceCode,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "ContractorsUserAccessesOwnNamespace",
+			token:      login(s.contractorsMockServer, "group1"),
+			namespace:  namespace.Code,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "ContractorsAdminAccessesDefaultNamespace",
+			token:      login(s.contractorsMockServer, "contractors-admin"),
+			namespace:  models.DefaultNamespaceCode,
+			statusCode: http.StatusOK,
+		},
+		{
+			// admin role of employees provider is not mapped to admin for contractors provider.
+			name:       "ContractorsUserWithEmployeesAdminRole",
+			token:      login(s.contractorsMockServer, "admin"),
+			namespace:  models.DefaultNamespaceCode,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "UnknownIssuer",
+			token:      login(s.unknownMockServer, "admin"),
+			namespace:  models.DefaultNamespaceCode,
+			statusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.statusCode, getProjectStatusCode(tt.namespace, tt.token))
+		})
+	}
+}
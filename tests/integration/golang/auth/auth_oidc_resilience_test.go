@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers/oidc"
+)
+
+// newOIDCTestConfig creates a service configuration with OIDC enabled option.
+func newOIDCTestConfig(oidcMockServer *oidc.MockServer) config.Config {
+	return config.Config{
+		Auth: auth.Config{
+			AuthType:                 auth.TypeOIDC,
+			AuthOIDCAdminRole:        "admin",
+			AuthOIDCClientID:         oidcMockServer.ClientID(),
+			AuthOIDCClientSecret:     oidcMockServer.ClientSecret(),
+			AuthOIDCClaimRoles:       "groups",
+			AuthOIDCProviderEndpoint: oidcMockServer.Address(),
+		},
+	}
+}
+
+// loginAdmin logs in a user with admin role and returns its token.
+func loginAdmin(oidcMockServer *oidc.MockServer) (string, error) {
+	return oidcMockServer.Login(
+		context.Background(),
+		&mockoidc.MockUser{
+			Email:  "test.admin@example.com",
+			Groups: []string{"admin"},
+		}, []string{"openid", "groups"},
+	)
+}
+
+// getProjectStatusCode requests project information with provided token and returns response status code.
+func getProjectStatusCode(s *helpers.BaseTestSuite, token string) int {
+	client := s.AIMClient().WithHeaders(map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", token),
+	})
+	s.Require().Nil(client.DoRequest("/projects"))
+	return client.GetStatusCode()
+}
+
+type OIDCKeyRotationTestSuite struct {
+	helpers.BaseTestSuite
+	oidcMockServer *oidc.MockServer
+}
+
+func TestOIDCKeyRotationTestSuite(t *testing.T) {
+	oidcMockServer, err := oidc.NewMockServer()
+	assert.Nil(t, err)
+
+	testSuite := new(OIDCKeyRotationTestSuite)
+	testSuite.Config = newOIDCTestConfig(oidcMockServer)
+	testSuite.oidcMockServer = oidcMockServer
+	suite.Run(t, testSuite)
+}
+
+func (s *OIDCKeyRotationTestSuite) Test_Ok() {
+	token, err := loginAdmin(s.oidcMockServer)
+	s.Require().Nil(err)
+	s.Equal(http.StatusOK, getProjectStatusCode(&s.BaseTestSuite, token))
+
+	// rotate signing key of OIDC provider.
+	s.Require().Nil(s.oidcMockServer.RotateKeypair())
+
+	// tokens signed with the new key are accepted without restart,
+	// while tokens signed with the old key, which is not published anymore, are rejected.
+	rotatedToken, err := loginAdmin(s.oidcMockServer)
+	s.Require().Nil(err)
+	s.Equal(http.StatusOK, getProjectStatusCode(&s.BaseTestSuite, rotatedToken))
+	s.Equal(http.StatusNotFound, getProjectStatusCode(&s.BaseTestSuite, token))
+}
+
+type OIDCUnavailableProviderTestSuite struct {
+	helpers.BaseTestSuite
+	oidcMockServer *oidc.MockServer
+}
+
+func TestOIDCUnavailableProviderTestSuite(t *testing.T) {
+	// OIDC provider is not available, when the service starts.
+	oidcMockServer, err := oidc.NewUnstartedMockServer()
+	assert.Nil(t, err)
+
+	testSuite := new(OIDCUnavailableProviderTestSuite)
+	testSuite.Config = newOIDCTestConfig(oidcMockServer)
+	testSuite.oidcMockServer = oidcMockServer
+	suite.Run(t, testSuite)
+}
+
+func (s *OIDCUnavailableProviderTestSuite) Test_Ok() {
+	// the service has started, but can't verify tokens until OIDC provider becomes available.
+	s.Equal(http.StatusNotFound, getProjectStatusCode(&s.BaseTestSuite, "token"))
+
+	// OIDC provider discovery is retried in the background, so tokens are accepted once it is available.
+	s.Require().Nil(s.oidcMockServer.Start())
+	token, err := loginAdmin(s.oidcMockServer)
+	s.Require().Nil(err)
+	s.Eventually(func() bool {
+		return getProjectStatusCode(&s.BaseTestSuite, token) == http.StatusOK
+	}, 10*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zeebo/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type PublicReadTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestPublicReadTestSuite(t *testing.T) {
+	// create users configuration firstly.
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name: "user1",
+				Roles: []string{
+					"ns:public",
+				},
+				Password: "user1password",
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	configPath := fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	// #nosec G304
+	f, err := os.Create(configPath)
+	assert.Nil(t, err)
+	_, err = f.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	// run test suite with newly created configuration.
+	testSuite := new(PublicReadTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthType:        auth.TypeUser,
+			AuthUsersConfig: configPath,
+		},
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *PublicReadTestSuite) Test_Ok() {
+	// create test namespaces.
+	_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "public",
+		PublicRead:          true,
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	_, err = s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "private",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name  string
+		check func()
+	}{
+		{
+			name: "AnonymousGetRequestToPublicNamespace",
+			check: func() {
+				resp := mlflowResponse.SearchExperimentsResponse{}
+				s.Require().Nil(
+					s.MlflowClient().WithNamespace(
+						"public",
+					).WithResponse(
+						&resp,
+					).DoRequest(
+						"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+					),
+				)
+				s.Empty(resp.Experiments)
+			},
+		},
+		{
+			name: "AnonymousSearchRequestToPublicNamespace",
+			check: func() {
+				resp := mlflowResponse.SearchRunsResponse{}
+				s.Require().Nil(
+					s.MlflowClient().WithMethod(
+						http.MethodPost,
+					).WithNamespace(
+						"public",
+					).WithRequest(
+						request.SearchRunsRequest{},
+					).WithResponse(
+						&resp,
+					).DoRequest(
+						"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+					),
+				)
+				s.Empty(resp.Runs)
+			},
+		},
+		{
+			name: "AuthenticatedWriteRequestToPublicNamespace",
+			check: func() {
+				basicAuthToken := base64.StdEncoding.EncodeToString(
+					[]byte(fmt.Sprintf("%s:%s", "user1", "user1password")),
+				)
+				resp := mlflowResponse.CreateExperimentResponse{}
+				s.Require().Nil(
+					s.MlflowClient().WithMethod(
+						http.MethodPost,
+					).WithNamespace(
+						"public",
+					).WithHeaders(map[string]string{
+						"Content-Type":  "application/json",
+						"Authorization": fmt.Sprintf("Basic %s", basicAuthToken),
+					}).WithRequest(
+						request.CreateExperimentRequest{Name: "experiment"},
+					).WithResponse(
+						&resp,
+					).DoRequest(
+						"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+					),
+				)
+				s.NotEmpty(resp.ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.check()
+		})
+	}
+}
+
+func (s *PublicReadTestSuite) Test_Error() {
+	// create test namespaces.
+	_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "public",
+		PublicRead:          true,
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	_, err = s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "private",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		method  string
+		request any
+		route   string
+		ns      string
+		error   string
+	}{
+		{
+			name:    "AnonymousWriteRequestToPublicNamespace",
+			method:  http.MethodPost,
+			request: request.CreateExperimentRequest{Name: "experiment"},
+			route:   fmt.Sprintf("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute),
+			ns:      "public",
+			error:   "RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: public",
+		},
+		{
+			name:   "AnonymousGetRequestToPrivateNamespace",
+			method: http.MethodGet,
+			route:  fmt.Sprintf("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute),
+			ns:     "private",
+			error:  "RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: private",
+		},
+		{
+			name:    "AnonymousSearchRequestToPrivateNamespace",
+			method:  http.MethodPost,
+			request: request.SearchRunsRequest{},
+			route:   fmt.Sprintf("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute),
+			ns:      "private",
+			error:   "RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: private",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.MlflowClient().WithMethod(tt.method).WithNamespace(tt.ns).WithResponse(&resp)
+			if tt.request != nil {
+				client = client.WithRequest(tt.request)
+			}
+			s.Require().Nil(client.DoRequest("%s", tt.route))
+			s.Equal(http.StatusNotFound, client.GetStatusCode())
+			s.Equal(tt.error, resp.Error())
+		})
+	}
+}
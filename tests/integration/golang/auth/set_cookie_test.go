@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+// validToken is a well-formed JWT token.
+const validToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+	"eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIn0." +
+	"XbPfbIHMI6arZ3Y922BhjWgQzWXcXNrz0ogtVhfEd2o"
+
+type SetCookieTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSetCookieTestSuite(t *testing.T) {
+	suite.Run(t, new(SetCookieTestSuite))
+}
+
+func (s *SetCookieTestSuite) Test_Ok() {
+	tests := []struct {
+		name     string
+		token    string
+		query    map[any]any
+		location string
+	}{
+		{
+			name:     "JWTTokenWithDefaultRedirect",
+			token:    validToken,
+			location: "/",
+		},
+		{
+			name:     "OpaqueTokenWithSameOriginRedirect",
+			token:    "opaque-token_1.2~3+4==",
+			query:    map[any]any{"redirect": "/aim/runs?page=2"},
+			location: "/aim/runs?page=2",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			client := s.RootClient()
+			if tt.query != nil {
+				client = client.WithQuery(tt.query)
+			}
+			s.Require().Nil(client.DoRequest("/set-cookie/%s", tt.token))
+			s.Equal(http.StatusFound, client.GetStatusCode())
+			s.Equal(tt.location, client.GetResponseHeaders().Get("Location"))
+
+			cookie := client.GetResponseHeaders().Get("Set-Cookie")
+			s.True(strings.HasPrefix(cookie, "access_token="+tt.token+";"))
+			s.Contains(cookie, "path=/")
+			s.Contains(cookie, "HttpOnly")
+			s.Contains(cookie, "SameSite=Lax")
+		})
+	}
+}
+
+func (s *SetCookieTestSuite) Test_Error() {
+	tests := []struct {
+		name  string
+		token string
+		query map[any]any
+		error string
+	}{
+		{
+			name:  "TokenWithControlCharacters",
+			token: "token%0D%0ASet-Cookie:%20admin=true",
+			error: "access token has incorrect format",
+		},
+		{
+			name:  "TokenWithCookieSeparator",
+			token: "token;%20Domain=example.com",
+			error: "access token has incorrect format",
+		},
+		{
+			name:  "TooLongToken",
+			token: strings.Repeat("a", 4097),
+			error: "access token has incorrect format",
+		},
+		{
+			name:  "AbsoluteRedirect",
+			token: validToken,
+			query: map[any]any{"redirect": "https://example.com/"},
+			error: "redirect target should be a same-origin path",
+		},
+		{
+			name:  "ProtocolRelativeRedirect",
+			token: validToken,
+			query: map[any]any{"redirect": "//example.com/"},
+			error: "redirect target should be a same-origin path",
+		},
+		{
+			name:  "BackslashRedirect",
+			token: validToken,
+			query: map[any]any{"redirect": "/\\example.com/"},
+			error: "redirect target should be a same-origin path",
+		},
+		{
+			name:  "JavascriptRedirect",
+			token: validToken,
+			query: map[any]any{"redirect": "javascript:alert(1)"},
+			error: "redirect target should be a same-origin path",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp strings.Builder
+			client := s.RootClient().WithResponseType(helpers.ResponseTypeBuffer).WithResponse(&resp)
+			if tt.query != nil {
+				client = client.WithQuery(tt.query)
+			}
+			s.Require().Nil(client.DoRequest("/set-cookie/%s", tt.token))
+			s.Equal(http.StatusBadRequest, client.GetStatusCode())
+			s.Equal(tt.error, resp.String())
+			s.Empty(client.GetResponseHeaders().Get("Set-Cookie"))
+		})
+	}
+}
+
+type ConfiguredSetCookieTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestConfiguredSetCookieTestSuite(t *testing.T) {
+	testSuite := new(ConfiguredSetCookieTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthCookieName:     "fml_token",
+			AuthCookieDomain:   "example.com",
+			AuthCookiePath:     "/ui",
+			AuthCookieSameSite: auth.CookieSameSiteNone,
+			AuthCookieSecure:   true,
+			AuthCookieMaxAge:   time.Hour,
+		},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *ConfiguredSetCookieTestSuite) Test_Ok() {
+	client := s.RootClient()
+	s.Require().Nil(client.DoRequest("/set-cookie/%s", validToken))
+	s.Equal(http.StatusFound, client.GetStatusCode())
+
+	cookie := client.GetResponseHeaders().Get("Set-Cookie")
+	s.True(strings.HasPrefix(cookie, "fml_token="+validToken+";"))
+	s.Contains(cookie, "max-age=3600")
+	s.Contains(cookie, "domain=example.com")
+	s.Contains(cookie, "path=/ui")
+	s.Contains(cookie, "secure")
+	s.Contains(cookie, "HttpOnly")
+	s.Contains(cookie, "SameSite=None")
+}
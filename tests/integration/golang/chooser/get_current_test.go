@@ -2,12 +2,14 @@ package chooser
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/ui/chooser/api/response"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
@@ -44,3 +46,18 @@ func (s *GetCurrentNamespacesTestSuite) Test_Ok() {
 	s.Equal(namespace.Code, resp.Code)
 	s.Equal(namespace.Description, resp.Description)
 }
+
+func (s *GetCurrentNamespacesTestSuite) Test_Error() {
+	resp := api.ErrorResponse{}
+	client := s.ChooserClient().WithNamespace(
+		"not-existing-namespace",
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("/namespaces/current"))
+	s.Equal(http.StatusNotFound, client.GetStatusCode())
+	s.Equal(
+		api.NewResourceDoesNotExistError("unable to find namespace with code: not-existing-namespace").Error(),
+		resp.Error(),
+	)
+}
@@ -0,0 +1,134 @@
+package chooser
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LandingTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLandingTestSuite(t *testing.T) {
+	// create users configuration firstly.
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name:     "user1",
+				Roles:    []string{"ns:namespace1"},
+				Password: "user1password",
+			},
+			{
+				Name:     "user2",
+				Roles:    []string{"ns:namespace2", "ns:namespace3"},
+				Password: "user2password",
+			},
+			{
+				Name:     "user3",
+				Roles:    []string{"ns:namespace1", "ns:namespace2"},
+				Password: "user3password",
+			},
+			{
+				Name:     "user4",
+				Roles:    []string{"ns:namespace1", "ns:namespace3"},
+				Password: "user4password",
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	configPath := fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	assert.Nil(t, os.WriteFile(configPath, data, 0o600))
+
+	// run test suite with global and per-user default landing namespaces.
+	testSuite := new(LandingTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthUsersConfig: configPath,
+		},
+		ChooserDefaultNamespace:      "namespace2",
+		ChooserUserDefaultNamespaces: []string{"user2:namespace3"},
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *LandingTestSuite) Test_Ok() {
+	for i, code := range []string{"namespace1", "namespace2", "namespace3"} {
+		_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+			ID:                  uint(i + 2),
+			Code:                code,
+			DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name       string
+		user       string
+		path       string
+		statusCode int
+		location   string
+	}{
+		{
+			name:       "SingleNamespaceIsAutoSelected",
+			user:       "user1",
+			path:       "/",
+			statusCode: http.StatusFound,
+			location:   "/ns/namespace1/",
+		},
+		{
+			name:       "UserDefaultNamespaceTakesPrecedence",
+			user:       "user2",
+			path:       "/",
+			statusCode: http.StatusFound,
+			location:   "/ns/namespace3/",
+		},
+		{
+			name:       "GlobalDefaultNamespace",
+			user:       "user3",
+			path:       "/",
+			statusCode: http.StatusFound,
+			location:   "/ns/namespace2/",
+		},
+		{
+			name:       "InaccessibleDefaultNamespaceIsIgnored",
+			user:       "user4",
+			path:       "/",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "ExplicitlySelectedNamespaceIsKept",
+			user:       "user2",
+			path:       "/ns/namespace2/",
+			statusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			basicAuthToken := base64.StdEncoding.EncodeToString(
+				[]byte(fmt.Sprintf("%s:%spassword", tt.user, tt.user)),
+			)
+			client := s.RootClient().WithHeaders(map[string]string{
+				"Authorization": fmt.Sprintf("Basic %s", basicAuthToken),
+			})
+			s.Require().Nil(client.DoRequest(tt.path))
+			s.Equal(tt.statusCode, client.GetStatusCode())
+			s.Equal(tt.location, client.GetResponseHeaders().Get("Location"))
+		})
+	}
+}
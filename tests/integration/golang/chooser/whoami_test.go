@@ -0,0 +1,41 @@
+package chooser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/ui/chooser/api/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type WhoAmITestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestWhoAmITestSuite(t *testing.T) {
+	suite.Run(t, new(WhoAmITestSuite))
+}
+
+func (s *WhoAmITestSuite) Test_Ok() {
+	defaultNamespace, err := s.NamespaceFixtures.GetNamespaceByCode(context.Background(), s.DefaultNamespace.Code)
+	s.Require().Nil(err)
+
+	var resp response.User
+	s.Require().Nil(s.ChooserClient().WithResponse(&resp).DoRequest("/whoami"))
+
+	// without authentication current user is anonymous user with access to everything.
+	s.Equal(response.User{
+		AuthType: "none",
+		Roles:    []string{},
+		IsAdmin:  true,
+		Namespaces: response.ListNamespaces{
+			{
+				ID:          defaultNamespace.ID,
+				Code:        defaultNamespace.Code,
+				Description: defaultNamespace.Description,
+			},
+		},
+	}, resp)
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	aimRequest "github.com/G-Research/fasttrackml/pkg/api/aim2/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/client"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ClientTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (s *ClientTestSuite) Test_Ok() {
+	ctx := context.Background()
+	mlflowClient := s.FastTrackMLClient().Mlflow()
+
+	experiment, err := mlflowClient.CreateExperiment(ctx, &request.CreateExperimentRequest{
+		Name: "Client Experiment",
+	})
+	s.Require().Nil(err)
+
+	run, err := mlflowClient.CreateRun(ctx, &request.CreateRunRequest{
+		ExperimentID: experiment.ID,
+		Name:         "client-run",
+	})
+	s.Require().Nil(err)
+	s.Equal("client-run", run.Run.Info.Name)
+
+	s.Require().Nil(mlflowClient.LogBatch(ctx, &request.LogBatchRequest{
+		RunID: run.Run.Info.ID,
+		Metrics: []request.MetricPartialRequest{
+			{Key: "loss", Value: 1.5, Timestamp: 1, Step: 0},
+			{Key: "loss", Value: 0.5, Timestamp: 2, Step: 1},
+		},
+		Params: []request.ParamPartialRequest{
+			{Key: "lr", Value: "0.1"},
+		},
+	}))
+
+	history, err := mlflowClient.GetMetricHistory(ctx, &request.GetMetricHistoryRequest{
+		RunID:     run.Run.Info.ID,
+		MetricKey: "loss",
+	})
+	s.Require().Nil(err)
+	s.Equal(2, len(history.Metrics))
+
+	found, err := mlflowClient.GetRun(ctx, &request.GetRunRequest{RunID: run.Run.Info.ID})
+	s.Require().Nil(err)
+	s.Equal(1, len(found.Run.Data.Params))
+
+	aggregates, err := s.FastTrackMLClient().Aim().AggregateMetrics(ctx, &aimRequest.AggregateMetricsRequest{
+		RunIDs: []string{run.Run.Info.ID},
+		Name:   "loss",
+	})
+	s.Require().Nil(err)
+	s.Equal(2, len(aggregates))
+	s.Equal(0.5, aggregates[1].Mean.Float64())
+}
+
+func (s *ClientTestSuite) Test_Namespace() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Namespace Experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	resp, err := s.FastTrackMLClient().WithNamespace(namespace.Code).Mlflow().GetExperiment(
+		context.Background(), &request.GetExperimentRequest{ID: fmt.Sprintf("%d", *experiment.ID)},
+	)
+	s.Require().Nil(err)
+	s.Equal("Namespace Experiment", resp.Experiment.Name)
+
+	// the experiment doesn't exist in the default namespace.
+	_, err = s.FastTrackMLClient().Mlflow().GetExperiment(
+		context.Background(), &request.GetExperimentRequest{ID: fmt.Sprintf("%d", *experiment.ID)},
+	)
+	var clientErr *client.Error
+	s.Require().ErrorAs(err, &clientErr)
+	s.Equal(http.StatusNotFound, clientErr.StatusCode)
+	s.Equal(api.ErrorCodeResourceDoesNotExist, clientErr.ErrorCode)
+}
+
+func (s *ClientTestSuite) Test_Error() {
+	_, err := s.FastTrackMLClient().Mlflow().CreateRun(context.Background(), &request.CreateRunRequest{
+		ExperimentID: "not-an-id",
+	})
+	var clientErr *client.Error
+	s.Require().ErrorAs(err, &clientErr)
+	s.Equal(http.StatusBadRequest, clientErr.StatusCode)
+	s.Equal(api.ErrorCodeBadRequest, clientErr.ErrorCode)
+}
@@ -51,7 +51,8 @@ func (s *ImportTestSuite) SetupSubTest() {
 	db, err := database.NewDBProvider(
 		dsn,
 		1*time.Second,
-		20,
+		database.NewPoolConfig(20),
+		database.StatementTimeouts{},
 	)
 	s.Require().Nil(err)
 	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
@@ -70,7 +71,8 @@ func (s *ImportTestSuite) SetupSubTest() {
 	db, err = database.NewDBProvider(
 		dsn,
 		1*time.Second,
-		20,
+		database.NewPoolConfig(20),
+		database.StatementTimeouts{},
 	)
 	s.Require().Nil(err)
 	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
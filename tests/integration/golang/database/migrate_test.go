@@ -54,12 +54,21 @@ func (s *MigrateTestSuite) TestMigrate() {
 			db, err := database.NewDBProvider(
 				fmt.Sprintf("sqlite://%s", mlflowDBPath),
 				1*time.Second,
-				20,
+				database.NewPoolConfig(20),
+				database.StatementTimeouts{},
 			)
 			s.Require().Nil(err)
 
 			// run migrations
 			s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
+
+			// check that indexes used to filter runs by tags have been created
+			for _, index := range []string{"idx_tags_run_uuid_key", "idx_tags_key_value"} {
+				s.True(db.GormDB().Migrator().HasIndex(&database.Tag{}, index))
+			}
+
+			// check that index used to find active runs has been created
+			s.True(db.GormDB().Migrator().HasIndex(&database.Run{}, "idx_runs_status_experiment_id"))
 		})
 	}
 }
@@ -45,7 +45,8 @@ func (s *MigrationsTestSuite) TestMigrations() {
 				db, err := database.NewDBProvider(
 					dsn,
 					1*time.Second,
-					20,
+					database.NewPoolConfig(20),
+					database.StatementTimeouts{},
 				)
 				s.Require().Nil(err)
 
@@ -107,7 +108,8 @@ func (s *MigrationsTestSuite) TestMigrations() {
 				db, err := database.NewDBProvider(
 					dsn,
 					1*time.Second,
-					20,
+					database.NewPoolConfig(20),
+					database.StatementTimeouts{},
 				)
 				s.Require().Nil(err)
 
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type StatementTimeoutTestSuite struct {
+	suite.Suite
+}
+
+func TestStatementTimeoutTestSuite(t *testing.T) {
+	suite.Run(t, new(StatementTimeoutTestSuite))
+}
+
+func (s *StatementTimeoutTestSuite) TestSlowStatementIsAborted() {
+	dsn, err := helpers.GenerateDatabaseURI(s.T(), helpers.GetDatabaseBackend())
+	s.Require().Nil(err)
+
+	db, err := database.NewDBProvider(
+		dsn,
+		1*time.Second,
+		database.NewPoolConfig(2),
+		database.StatementTimeouts{
+			Read:  100 * time.Millisecond,
+			Write: 200 * time.Millisecond,
+		},
+	)
+	s.Require().Nil(err)
+	//nolint:errcheck
+	defer db.Close()
+
+	switch db.GormDB().Dialector.Name() {
+	case database.PostgresDialectorName:
+		s.Run("SlowQuery", func() {
+			startedAt := time.Now()
+			var result int
+			err := db.GormDB().Raw("SELECT 1 FROM pg_sleep(10)").Scan(&result).Error
+			s.ErrorContains(err, "canceling statement due to statement timeout")
+			s.Less(time.Since(startedAt), 5*time.Second)
+		})
+		s.Run("SlowWrite", func() {
+			startedAt := time.Now()
+			err := db.GormDB().Exec("CREATE TABLE slow AS SELECT 1 AS id FROM pg_sleep(10)").Error
+			s.ErrorContains(err, "canceling statement due to statement timeout")
+			s.Less(time.Since(startedAt), 5*time.Second)
+		})
+	case database.SQLiteDialectorName:
+		// SQLite doesn't abort running statements, so the write waiting for the lock
+		// held by another connection is aborted instead of the default 5 seconds wait.
+		s.Run("SlowWrite", func() {
+			lockDB, err := sql.Open("sqlite3", strings.Replace(dsn, "sqlite://", "file:", 1))
+			s.Require().Nil(err)
+			//nolint:errcheck
+			defer lockDB.Close()
+			lock, err := lockDB.Begin()
+			s.Require().Nil(err)
+			//nolint:errcheck
+			defer lock.Rollback()
+			_, err = lock.Exec("CREATE TABLE lock (id INTEGER)")
+			s.Require().Nil(err)
+
+			startedAt := time.Now()
+			err = db.GormDB().Exec("CREATE TABLE slow (id INTEGER)").Error
+			s.ErrorContains(err, "database is locked")
+			s.Less(time.Since(startedAt), 2*time.Second)
+		})
+	}
+}
@@ -18,6 +18,7 @@ func (f baseFixtures) TruncateTables() error {
 	for _, table := range []interface{}{
 		database.Dashboard{}, // TODO update to models when available
 		database.App{},       // TODO update to models when available
+		database.RunActivity{},
 		models.Tag{},
 		models.Param{},
 		models.LatestMetric{},
@@ -26,6 +27,9 @@ func (f baseFixtures) TruncateTables() error {
 		models.Run{},
 		models.ExperimentTag{},
 		models.Experiment{},
+		models.APIKey{},
+		models.ArtifactUploadPart{},
+		models.ArtifactUpload{},
 		models.Namespace{},
 		models.RoleNamespace{},
 		models.Role{},
@@ -40,3 +40,12 @@ func (f ContextFixtures) GetContextByJSON(ctx context.Context, json string) (*mo
 	}
 	return &context, nil
 }
+
+// GetContexts returns all the Contexts ordered by ID.
+func (f ContextFixtures) GetContexts(ctx context.Context) ([]models.Context, error) {
+	var contexts []models.Context
+	if err := f.db.WithContext(ctx).Order("id").Find(&contexts).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting contexts")
+	}
+	return contexts, nil
+}
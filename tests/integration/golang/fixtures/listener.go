@@ -0,0 +1,42 @@
+package fixtures
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	aimRepositories "github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/aim2/services/activity"
+)
+
+// runEventListener processes run events straight away, so changes done by fixtures
+// are reflected in the run activity summary without waiting for the server.
+type runEventListener struct {
+	activityService *activity.Service
+}
+
+// newRunEventListener creates new instance of runEventListener.
+func newRunEventListener(db *gorm.DB) *runEventListener {
+	return &runEventListener{
+		activityService: activity.NewService(
+			aimRepositories.NewRunActivityRepository(db),
+			aimRepositories.NewExperimentRepository(db),
+		),
+	}
+}
+
+// Listen does nothing, as events are processed when published.
+func (l runEventListener) Listen() {}
+
+// Subscribe does nothing, as events are processed when published.
+func (l runEventListener) Subscribe(chan<- string) {}
+
+// Publish processes event straight away.
+func (l runEventListener) Publish(ctx context.Context, _ *gorm.DB, payload string) error {
+	return l.activityService.ProcessEvent(ctx, payload)
+}
+
+// GetChannelName returns channel name.
+func (l runEventListener) GetChannelName() string {
+	return "run_update_events"
+}
@@ -53,6 +53,14 @@ func (f MetricFixtures) CreateMetric(ctx context.Context, metric *models.Metric)
 	return metric, nil
 }
 
+// CreateMetrics creates new test Metrics of the run the same way as they are logged, using metric repository.
+func (f MetricFixtures) CreateMetrics(ctx context.Context, run *models.Run, metrics []models.Metric) error {
+	if err := f.metricRepository.CreateBatch(ctx, run, len(metrics), metrics); err != nil {
+		return eris.Wrap(err, "error creating metrics")
+	}
+	return nil
+}
+
 // GetMetricsByRunID returns the metrics by Run ID.
 func (f MetricFixtures) GetMetricsByRunID(ctx context.Context, runID string) ([]*models.Metric, error) {
 	var metrics []*models.Metric
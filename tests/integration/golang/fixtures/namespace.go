@@ -84,6 +84,9 @@ func (f NamespaceFixtures) UpdateNamespace(
 	if err := f.namespaceRepository.Update(ctx, namespace); err != nil {
 		return nil, eris.Wrap(err, "error updating test namespace")
 	}
+	if err := f.namespaceRepository.UpdateRetentionPolicy(ctx, namespace); err != nil {
+		return nil, eris.Wrap(err, "error updating retention policy of test namespace")
+	}
 	return namespace, nil
 }
 
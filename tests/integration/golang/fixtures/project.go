@@ -1,17 +1,42 @@
 package fixtures
 
 import (
+	"context"
+
+	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
+
+	aimModels "github.com/G-Research/fasttrackml/pkg/api/aim2/dao/models"
+	aimRepositories "github.com/G-Research/fasttrackml/pkg/api/aim2/dao/repositories"
 )
 
 // ProjectFixtures represents data fixtures object.
 type ProjectFixtures struct {
 	baseFixtures
+	runActivityRepository aimRepositories.RunActivityRepositoryProvider
 }
 
 // NewProjectFixtures creates new instance of ProjectFixtures.
 func NewProjectFixtures(db *gorm.DB) (*ProjectFixtures, error) {
 	return &ProjectFixtures{
-		baseFixtures: baseFixtures{db: db},
+		baseFixtures:          baseFixtures{db: db},
+		runActivityRepository: aimRepositories.NewRunActivityRepository(db),
 	}, nil
 }
+
+// GetRunActivity returns run activity summary of the namespace.
+func (f ProjectFixtures) GetRunActivity(ctx context.Context, namespaceID uint) ([]aimModels.RunActivity, error) {
+	activities, err := f.runActivityRepository.GetByNamespaceID(ctx, namespaceID)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting run activity")
+	}
+	return activities, nil
+}
+
+// RebuildRunActivity rebuilds run activity summary from scratch.
+func (f ProjectFixtures) RebuildRunActivity(ctx context.Context) error {
+	if err := f.runActivityRepository.Rebuild(ctx); err != nil {
+		return eris.Wrap(err, "error rebuilding run activity")
+	}
+	return nil
+}
@@ -25,7 +25,7 @@ type RunFixtures struct {
 func NewRunFixtures(db *gorm.DB) (*RunFixtures, error) {
 	return &RunFixtures{
 		baseFixtures:     baseFixtures{db: db},
-		runRepository:    repositories.NewRunRepository(db),
+		runRepository:    repositories.NewRunNotifyingRepository(db, newRunEventListener(db)),
 		tagRepository:    repositories.NewTagRepository(db),
 		metricRepository: repositories.NewMetricRepository(db),
 	}, nil
@@ -56,6 +56,15 @@ func (f RunFixtures) UpdateRun(
 	return nil
 }
 
+// UpdateRunWithVersionCheck updates existing Run, failing if it has been changed since it was read.
+func (f RunFixtures) UpdateRunWithVersionCheck(
+	ctx context.Context, run *models.Run,
+) error {
+	return f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return f.runRepository.UpdateWithTransaction(ctx, tx, run)
+	})
+}
+
 // ArchiveRuns soft-deletes existing Runs.
 func (f RunFixtures) ArchiveRuns(
 	ctx context.Context, namespaceID uint, runIDs []string,
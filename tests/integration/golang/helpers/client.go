@@ -14,6 +14,7 @@ import (
 	"github.com/hetiansu5/urlquery"
 	"github.com/rotisserie/eris"
 
+	"github.com/G-Research/fasttrackml/pkg/client"
 	"github.com/G-Research/fasttrackml/pkg/server"
 )
 
@@ -30,16 +31,18 @@ const (
 
 // HttpClient represents HTTP client.
 type HttpClient struct {
-	server       server.Server
-	basePath     string
-	namespace    string
-	method       string
-	params       any
-	headers      map[string]string
-	request      any
-	response     any
-	responseType ResponseType
-	statusCode   int
+	server          server.Server
+	basePath        string
+	namespace       string
+	host            string
+	method          string
+	params          any
+	headers         map[string]string
+	request         any
+	response        any
+	responseType    ResponseType
+	statusCode      int
+	responseHeaders http.Header
 }
 
 // NewClient creates new preconfigured HTTP client.
@@ -75,6 +78,13 @@ func NewChooserApiClient(server server.Server) *HttpClient {
 	return NewClient(server, "/chooser")
 }
 
+// NewFastTrackMLClient creates new client.Client sending requests to the in-process server.
+func NewFastTrackMLClient(server server.Server) *client.Client {
+	return client.NewClient("http://localhost").WithDoer(client.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return server.Test(req, 60000)
+	}))
+}
+
 // WithMethod sets the HTTP method.
 func (c *HttpClient) WithMethod(method string) *HttpClient {
 	c.method = method
@@ -87,7 +97,7 @@ func (c *HttpClient) WithQuery(params any) *HttpClient {
 	return c
 }
 
-// WithRequest sets request object.
+// WithRequest sets request object. []byte request is sent as is, other objects are marshaled to JSON.
 func (c *HttpClient) WithRequest(request any) *HttpClient {
 	c.request = request
 	return c
@@ -99,6 +109,12 @@ func (c *HttpClient) WithNamespace(namespace string) *HttpClient {
 	return c
 }
 
+// WithHost sets the host of the HTTP request.
+func (c *HttpClient) WithHost(host string) *HttpClient {
+	c.host = host
+	return c
+}
+
 // WithHeaders adds headers to the HTTP request.
 func (c *HttpClient) WithHeaders(headers map[string]string) *HttpClient {
 	c.headers = headers
@@ -122,12 +138,19 @@ func (c *HttpClient) GetStatusCode() int {
 	return c.statusCode
 }
 
+// GetResponseHeaders returns HTTP headers of the last response, if available.
+func (c *HttpClient) GetResponseHeaders() http.Header {
+	return c.responseHeaders
+}
+
 // DoRequest do actual HTTP request based on provided parameters.
 // nolint:gocyclo
 func (c *HttpClient) DoRequest(uri string, values ...any) error {
 	// 1. check if request object were provided. if provided then marshal it.
 	var requestBody io.Reader
-	if c.request != nil {
+	if data, ok := c.request.([]byte); ok {
+		requestBody = bytes.NewReader(data)
+	} else if c.request != nil {
 		data, err := json.Marshal(c.request)
 		if err != nil {
 			return eris.Wrap(err, "error marshaling request object")
@@ -173,6 +196,10 @@ func (c *HttpClient) DoRequest(uri string, values ...any) error {
 		c.method, u.String(), requestBody,
 	)
 
+	if c.host != "" {
+		req.Host = c.host
+	}
+
 	// 6. if headers were provided, then attach them.
 	// by default attach `"Content-Type", "application/json"`
 	if c.headers != nil {
@@ -199,6 +226,7 @@ func (c *HttpClient) DoRequest(uri string, values ...any) error {
 	defer resp.Body.Close()
 
 	c.statusCode = resp.StatusCode
+	c.responseHeaders = resp.Header
 
 	// 8. read and check response data.
 	if c.response != nil {
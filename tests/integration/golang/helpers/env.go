@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"net/url"
 	"os"
 )
 
@@ -38,6 +39,15 @@ func GetGSEndpointUri() string {
 	return "http://localhost:4443/storage/v1/"
 }
 
+// GetGSEmulatorHost returns host of GS emulator in the form expected by `STORAGE_EMULATOR_HOST` variable.
+func GetGSEmulatorHost() (string, error) {
+	uri, err := url.Parse(GetGSEndpointUri())
+	if err != nil {
+		return "", err
+	}
+	return uri.Host, nil
+}
+
 func GetS3EndpointUri() string {
 	uri, ok := os.LookupEnv("FML_S3_ENDPOINT_URI")
 	if ok {
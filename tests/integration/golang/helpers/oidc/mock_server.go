@@ -3,8 +3,12 @@ package oidc
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -17,6 +21,7 @@ import (
 
 // MockServer represents OIDC mock server.
 type MockServer struct {
+	address        string
 	oidcMockServer *mockoidc.MockOIDC
 }
 
@@ -31,6 +36,54 @@ func NewMockServer() (*MockServer, error) {
 	}, nil
 }
 
+// NewUnstartedMockServer creates new OIDC mock server, which listens on a free local address
+// only after Start is called. It allows to emulate OIDC provider, which is unavailable for a while.
+func NewUnstartedMockServer() (*MockServer, error) {
+	oidcMockServer, err := mockoidc.NewServer(nil)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating oidc mock server")
+	}
+	// reserve a free local address, which the server will listen on later.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, eris.Wrap(err, "error reserving oidc mock server address")
+	}
+	address := listener.Addr().String()
+	if err := listener.Close(); err != nil {
+		return nil, eris.Wrap(err, "error releasing oidc mock server address")
+	}
+	return &MockServer{
+		address:        address,
+		oidcMockServer: oidcMockServer,
+	}, nil
+}
+
+// Start starts OIDC mock server created by NewUnstartedMockServer.
+func (m MockServer) Start() error {
+	listener, err := net.Listen("tcp", m.address)
+	if err != nil {
+		return eris.Wrap(err, "error listening oidc mock server address")
+	}
+	if err := m.oidcMockServer.Start(listener, nil); err != nil {
+		return eris.Wrap(err, "error starting oidc mock server")
+	}
+	return nil
+}
+
+// RotateKeypair replaces the key, which OIDC mock server signs tokens with, by a new one.
+func (m MockServer) RotateKeypair() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return eris.Wrap(err, "error generating rsa key")
+	}
+	keypair, err := mockoidc.NewKeypair(key)
+	if err != nil {
+		return eris.Wrap(err, "error creating keypair")
+	}
+	m.oidcMockServer.Keypair = keypair
+	return nil
+}
+
 // Login mimics User login action.
 func (m MockServer) Login(ctx context.Context, user *mockoidc.MockUser, scopes []string) (string, error) {
 	// Emulate client to IDP request.
@@ -139,6 +192,9 @@ func (m MockServer) Login(ctx context.Context, user *mockoidc.MockUser, scopes [
 
 // Address returns OIDC mock server address.
 func (m MockServer) Address() string {
+	if m.address != "" {
+		return fmt.Sprintf("http://%s%s", m.address, mockoidc.IssuerBase)
+	}
 	return m.oidcMockServer.Addr() + mockoidc.IssuerBase
 }
 
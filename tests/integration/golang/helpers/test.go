@@ -10,6 +10,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/client"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/database"
 	"github.com/G-Research/fasttrackml/pkg/server"
@@ -25,8 +26,10 @@ type BaseTestSuite struct {
 	tearDownHooks               []func()
 	AIMClient                   func() *HttpClient
 	MlflowClient                func() *HttpClient
+	RootClient                  func() *HttpClient
 	AdminClient                 func() *HttpClient
 	ChooserClient               func() *HttpClient
+	FastTrackMLClient           func() *client.Client
 	AppFixtures                 *fixtures.AppFixtures
 	RunFixtures                 *fixtures.RunFixtures
 	TagFixtures                 *fixtures.TagFixtures
@@ -43,6 +46,7 @@ type BaseTestSuite struct {
 	ResetOnSubTest              bool
 	SkipCreateDefaultNamespace  bool
 	SkipCreateDefaultExperiment bool
+	SkipGSEndpointURI           bool
 }
 
 func (s *BaseTestSuite) runSetupHooks() {
@@ -71,7 +75,8 @@ func (s *BaseTestSuite) initDB() {
 	s.db, err = database.NewDBProvider(
 		dsn,
 		1*time.Second,
-		20,
+		database.NewPoolConfig(20),
+		database.StatementTimeouts{},
 	)
 	s.Require().Nil(err)
 }
@@ -138,7 +143,11 @@ func (s *BaseTestSuite) startServer() {
 		S3EndpointURI:         GetS3EndpointUri(),
 		GSEndpointURI:         GetGSEndpointUri(),
 	}
-	s.Require().Nil(mergo.Merge(&cfg, s.Config))
+	s.Require().Nil(mergo.Merge(&cfg, s.Config, mergo.WithOverride))
+	// without explicit endpoint GS client falls back to Application Default Credentials.
+	if s.SkipGSEndpointURI {
+		cfg.GSEndpointURI = ""
+	}
 
 	srv, err := server.NewServer(context.Background(), &cfg)
 	s.Require().Nil(err)
@@ -150,12 +159,18 @@ func (s *BaseTestSuite) startServer() {
 	s.MlflowClient = func() *HttpClient {
 		return NewMlflowApiClient(s.server)
 	}
+	s.RootClient = func() *HttpClient {
+		return NewClient(s.server, "")
+	}
 	s.AdminClient = func() *HttpClient {
 		return NewAdminApiClient(s.server)
 	}
 	s.ChooserClient = func() *HttpClient {
 		return NewChooserApiClient(s.server)
 	}
+	s.FastTrackMLClient = func() *client.Client {
+		return NewFastTrackMLClient(s.server)
+	}
 }
 
 func (s *BaseTestSuite) stopServer() {
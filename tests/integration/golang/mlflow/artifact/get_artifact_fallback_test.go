@@ -0,0 +1,214 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetArtifactFallbackTestSuite struct {
+	helpers.BaseTestSuite
+	primaryRoot   string
+	secondaryRoot string
+}
+
+func TestGetArtifactFallbackTestSuite(t *testing.T) {
+	primaryRoot, secondaryRoot := t.TempDir(), t.TempDir()
+	suite.Run(t, &GetArtifactFallbackTestSuite{
+		BaseTestSuite: helpers.BaseTestSuite{
+			Config: config.Config{
+				ArtifactRootFallbacks: []string{fmt.Sprintf("%s=%s", primaryRoot, secondaryRoot)},
+			},
+		},
+		primaryRoot:   primaryRoot,
+		secondaryRoot: secondaryRoot,
+	})
+}
+
+func (s *GetArtifactFallbackTestSuite) Test_Ok() {
+	// 1. create test experiment and run in the primary artifact root.
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: s.primaryRoot,
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    filepath.Join(s.primaryRoot, runID, "artifacts"),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 2. create artifacts. `artifact.file1` exists in both roots and `artifact.file2` only in the secondary one.
+	primaryArtifactDir := filepath.Join(s.primaryRoot, runID, "artifacts")
+	secondaryArtifactDir := filepath.Join(s.secondaryRoot, runID, "artifacts")
+	s.Require().Nil(os.MkdirAll(primaryArtifactDir, fs.ModePerm))
+	s.Require().Nil(os.MkdirAll(secondaryArtifactDir, fs.ModePerm))
+	s.Require().Nil(os.WriteFile(filepath.Join(primaryArtifactDir, "artifact.file1"), []byte("primary"), fs.ModePerm))
+	s.Require().Nil(
+		os.WriteFile(filepath.Join(secondaryArtifactDir, "artifact.file1"), []byte("secondary"), fs.ModePerm),
+	)
+	s.Require().Nil(
+		os.WriteFile(filepath.Join(secondaryArtifactDir, "artifact.file2"), []byte("secondary"), fs.ModePerm),
+	)
+
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{
+			name:    "ArtifactInPrimaryRoot",
+			path:    "artifact.file1",
+			content: "primary",
+		},
+		{
+			name:    "ArtifactOnlyInSecondaryRoot",
+			path:    "artifact.file2",
+			content: "secondary",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(s.MlflowClient().WithQuery(
+				request.GetArtifactRequest{
+					RunID: run.ID,
+					Path:  tt.path,
+				},
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+			))
+			s.Equal(tt.content, resp.String())
+		})
+	}
+
+	// 3. artifacts are listed from the primary root, while it has any.
+	listResp := response.ListArtifactsResponse{}
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.ListArtifactsRequest{
+			RunID: run.ID,
+		},
+	).WithResponse(
+		&listResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+	))
+	s.Equal([]response.FilePartialResponse{
+		{
+			Path:     "artifact.file1",
+			FileSize: 7,
+		},
+	}, listResp.Files)
+}
+
+func (s *GetArtifactFallbackTestSuite) Test_ListOnlyInSecondaryRoot() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: s.primaryRoot,
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    filepath.Join(s.primaryRoot, runID, "artifacts"),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	secondaryArtifactDir := filepath.Join(s.secondaryRoot, runID, "artifacts")
+	s.Require().Nil(os.MkdirAll(secondaryArtifactDir, fs.ModePerm))
+	s.Require().Nil(
+		os.WriteFile(filepath.Join(secondaryArtifactDir, "artifact.file"), []byte("secondary"), fs.ModePerm),
+	)
+
+	listResp := response.ListArtifactsResponse{}
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.ListArtifactsRequest{
+			RunID: run.ID,
+		},
+	).WithResponse(
+		&listResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+	))
+	s.Equal(run.ArtifactURI, listResp.RootURI)
+	s.Equal([]response.FilePartialResponse{
+		{
+			Path:     "artifact.file",
+			FileSize: 9,
+		},
+	}, listResp.Files)
+}
+
+func (s *GetArtifactFallbackTestSuite) Test_Error() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: s.primaryRoot,
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    filepath.Join(s.primaryRoot, runID, "artifacts"),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.GetArtifactRequest{
+			RunID: run.ID,
+			Path:  "non-existent-file",
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal(
+		api.NewResourceDoesNotExistError(
+			"error getting artifact object for URI: %s/non-existent-file", run.ArtifactURI,
+		).Error(),
+		resp.Error(),
+	)
+}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
@@ -117,6 +119,244 @@ func (s *GetArtifactLocalTestSuite) Test_Ok() {
 	}
 }
 
+func (s *GetArtifactLocalTestSuite) Test_ContentType() {
+	// 1. create test experiment.
+	experimentArtifactDir := s.T().TempDir()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             fmt.Sprintf("Test Experiment In Path %s", experimentArtifactDir),
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: experimentArtifactDir,
+	})
+	s.Require().Nil(err)
+
+	// 2. create test run.
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(experimentArtifactDir, runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 3. create artifacts.
+	err = os.MkdirAll(runArtifactDir, fs.ModePerm)
+	s.Require().Nil(err)
+	artifacts := map[string][]byte{
+		"metrics.json":  []byte(`{"accuracy": 0.9}`),
+		"plot.png":      {0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'},
+		"model.MLmodel": []byte("flavors: {}"),
+		"image":         {0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'},
+		"data.unknown":  {0x00, 0x01, 0x02},
+	}
+	for name, content := range artifacts {
+		s.Require().Nil(os.WriteFile(filepath.Join(runArtifactDir, name), content, fs.ModePerm))
+	}
+
+	tests := []struct {
+		name               string
+		request            request.GetArtifactRequest
+		contentType        string
+		contentDisposition string
+	}{
+		{
+			name:               "JSONFile",
+			request:            request.GetArtifactRequest{RunID: run.ID, Path: "metrics.json"},
+			contentType:        "application/json",
+			contentDisposition: "attachment; filename=metrics.json",
+		},
+		{
+			name:               "PNGFile",
+			request:            request.GetArtifactRequest{RunID: run.ID, Path: "plot.png"},
+			contentType:        "image/png",
+			contentDisposition: "attachment; filename=plot.png",
+		},
+		{
+			name:               "TextFile",
+			request:            request.GetArtifactRequest{RunID: run.ID, Path: "model.MLmodel"},
+			contentType:        "text/plain",
+			contentDisposition: "attachment; filename=model.MLmodel",
+		},
+		{
+			name:               "FileWithoutExtension",
+			request:            request.GetArtifactRequest{RunID: run.ID, Path: "image"},
+			contentType:        "image/png",
+			contentDisposition: "attachment; filename=image",
+		},
+		{
+			name:               "UnknownFile",
+			request:            request.GetArtifactRequest{RunID: run.ID, Path: "data.unknown"},
+			contentType:        "application/octet-stream",
+			contentDisposition: "attachment; filename=data.unknown",
+		},
+		{
+			name:               "InlineFile",
+			request:            request.GetArtifactRequest{RunID: run.ID, Path: "plot.png", Inline: true},
+			contentType:        "image/png",
+			contentDisposition: "inline; filename=plot.png",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			client := s.MlflowClient()
+			s.Require().Nil(client.WithQuery(
+				tt.request,
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+			))
+			s.Equal(http.StatusOK, client.GetStatusCode())
+			s.Equal(artifacts[tt.request.Path], resp.Bytes())
+			s.Equal(tt.contentType, client.GetResponseHeaders().Get("Content-Type"))
+			s.Equal(tt.contentDisposition, client.GetResponseHeaders().Get("Content-Disposition"))
+			s.Equal("nosniff", client.GetResponseHeaders().Get("X-Content-Type-Options"))
+		})
+	}
+}
+
+func (s *GetArtifactLocalTestSuite) Test_NotModified() {
+	// 1. create test experiment.
+	experimentArtifactDir := s.T().TempDir()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             fmt.Sprintf("Test Experiment In Path %s", experimentArtifactDir),
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: experimentArtifactDir,
+	})
+	s.Require().Nil(err)
+
+	// 2. create test run.
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(experimentArtifactDir, runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 3. create artifact.
+	err = os.MkdirAll(runArtifactDir, fs.ModePerm)
+	s.Require().Nil(err)
+	err = os.WriteFile(filepath.Join(runArtifactDir, "artifact.file"), []byte("contentX"), fs.ModePerm)
+	s.Require().Nil(err)
+
+	query := request.GetArtifactRequest{
+		RunID: run.ID,
+		Path:  "artifact.file",
+	}
+
+	// 4. make unconditional API call and check validators.
+	resp := new(bytes.Buffer)
+	client := s.MlflowClient()
+	s.Require().Nil(client.WithQuery(
+		query,
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	s.Equal("contentX", resp.String())
+	etag := client.GetResponseHeaders().Get("ETag")
+	s.Regexp(`^"[0-9a-f]{64}"$`, etag)
+	lastModified := client.GetResponseHeaders().Get("Last-Modified")
+	s.NotEmpty(lastModified)
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "MatchingETag",
+			headers:    map[string]string{"If-None-Match": etag},
+			statusCode: http.StatusNotModified,
+		},
+		{
+			name:       "MatchingWeakETagInList",
+			headers:    map[string]string{"If-None-Match": fmt.Sprintf(`"other", W/%s`, etag)},
+			statusCode: http.StatusNotModified,
+		},
+		{
+			name:       "NotMatchingETag",
+			headers:    map[string]string{"If-None-Match": `"other"`},
+			statusCode: http.StatusOK,
+			body:       "contentX",
+		},
+		{
+			name:       "NotModifiedSince",
+			headers:    map[string]string{"If-Modified-Since": lastModified},
+			statusCode: http.StatusNotModified,
+		},
+		{
+			name: "ModifiedSince",
+			headers: map[string]string{
+				"If-Modified-Since": time.Unix(0, 0).UTC().Format(http.TimeFormat),
+			},
+			statusCode: http.StatusOK,
+			body:       "contentX",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			client := s.MlflowClient()
+			s.Require().Nil(client.WithQuery(
+				query,
+			).WithHeaders(
+				tt.headers,
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+			))
+			s.Equal(tt.statusCode, client.GetStatusCode())
+			s.Equal(tt.body, resp.String())
+			s.Equal(etag, client.GetResponseHeaders().Get("ETag"))
+		})
+	}
+
+	// 5. modify artifact and check that previous ETag doesn't match anymore.
+	err = os.WriteFile(filepath.Join(runArtifactDir, "artifact.file"), []byte("contentXY"), fs.ModePerm)
+	s.Require().Nil(err)
+
+	resp = new(bytes.Buffer)
+	client = s.MlflowClient()
+	s.Require().Nil(client.WithQuery(
+		query,
+	).WithHeaders(
+		map[string]string{"If-None-Match": etag},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	s.Equal("contentXY", resp.String())
+	s.NotEqual(etag, client.GetResponseHeaders().Get("ETag"))
+}
+
 func (s *GetArtifactLocalTestSuite) Test_Error() {
 	// create test experiment
 	experimentArtifactDir := s.T().TempDir()
@@ -143,6 +383,10 @@ func (s *GetArtifactLocalTestSuite) Test_Error() {
 
 	err = os.MkdirAll(filepath.Join(runArtifactDir, "subdir"), fs.ModePerm)
 	s.Require().Nil(err)
+	err = os.WriteFile(filepath.Join(experimentArtifactDir, "secret.file"), []byte("secret"), fs.ModePerm)
+	s.Require().Nil(err)
+	err = os.Symlink(filepath.Join(experimentArtifactDir, "secret.file"), filepath.Join(runArtifactDir, "escape.link"))
+	s.Require().Nil(err)
 
 	tests := []struct {
 		name    string
@@ -222,6 +466,16 @@ func (s *GetArtifactLocalTestSuite) Test_Error() {
 				Path:  "subdir",
 			},
 		},
+		{
+			name: "SymlinkEscapingArtifactRootProvided",
+			error: api.NewInvalidParameterValueError(
+				"Invalid path 'escape.link': path is outside of the run artifact root",
+			),
+			request: request.GetArtifactRequest{
+				RunID: runID,
+				Path:  "escape.link",
+			},
+		},
 	}
 
 	for _, tt := range tests {
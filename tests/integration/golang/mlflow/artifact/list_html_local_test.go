@@ -0,0 +1,143 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ListArtifactHTMLLocalTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestListArtifactHTMLLocalTestSuite(t *testing.T) {
+	suite.Run(t, new(ListArtifactHTMLLocalTestSuite))
+}
+
+func (s *ListArtifactHTMLLocalTestSuite) Test_Ok() {
+	// 1. create test namespace, experiment and run.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "browse",
+		DefaultExperimentID: common.GetPointer(int32(0)),
+	})
+	s.Require().Nil(err)
+
+	experimentArtifactDir := s.T().TempDir()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment",
+		NamespaceID:      namespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: experimentArtifactDir,
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(experimentArtifactDir, runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 2. create artifacts.
+	s.Require().Nil(os.MkdirAll(filepath.Join(runArtifactDir, "artifact.dir"), fs.ModePerm))
+	s.Require().Nil(os.WriteFile(filepath.Join(runArtifactDir, "artifact.file1"), []byte("contentX"), fs.ModePerm))
+	s.Require().Nil(os.WriteFile(
+		filepath.Join(runArtifactDir, "artifact.dir", "artifact.file2"), []byte("contentXX"), fs.ModePerm,
+	))
+
+	listPath := fmt.Sprintf(
+		"/ns/%s/api/2.0/mlflow%s%s", namespace.Code, mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+	)
+	listURL, err := url.Parse(listPath)
+	s.Require().Nil(err)
+
+	// getListing requests HTML listing and returns names of the artifacts together with links resolved
+	// against the listing URL, the same way a browser does.
+	getListing := func(path string) map[string]*url.URL {
+		resp := new(goquery.Document)
+		client := s.MlflowClient().WithNamespace(
+			namespace.Code,
+		).WithQuery(
+			request.ListArtifactsRequest{
+				RunID: run.ID,
+				Path:  path,
+			},
+		).WithHeaders(
+			map[string]string{"Accept": "text/html,application/xhtml+xml,*/*;q=0.8"},
+		).WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			resp,
+		)
+		s.Require().Nil(client.DoRequest("%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute))
+		s.Equal("text/html; charset=utf-8", client.GetResponseHeaders().Get("Content-Type"))
+
+		links := map[string]*url.URL{}
+		resp.Find("tbody a").Each(func(_ int, link *goquery.Selection) {
+			href, err := url.Parse(link.AttrOr("href", ""))
+			s.Require().Nil(err)
+			links[link.Text()] = listURL.ResolveReference(href)
+		})
+		return links
+	}
+
+	// 3. check the listing of the artifact root.
+	links := getListing("")
+	s.Require().Len(links, 2)
+	s.Require().Contains(links, "artifact.dir/")
+	s.Equal(listPath, links["artifact.dir/"].Path)
+	s.Equal(url.Values{"run_id": {run.ID}, "path": {"artifact.dir"}}, links["artifact.dir/"].Query())
+	s.Require().Contains(links, "artifact.file1")
+	s.Equal(
+		fmt.Sprintf("/ns/%s/api/2.0/mlflow%s%s", namespace.Code, mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute),
+		links["artifact.file1"].Path,
+	)
+	s.Equal(url.Values{"run_id": {run.ID}, "path": {"artifact.file1"}}, links["artifact.file1"].Query())
+
+	// 4. check the listing of the nested directory.
+	links = getListing("artifact.dir")
+	s.Require().Len(links, 2)
+	s.Require().Contains(links, "../")
+	s.Equal(url.Values{"run_id": {run.ID}}, links["../"].Query())
+	s.Require().Contains(links, "artifact.file2")
+	s.Equal(url.Values{"run_id": {run.ID}, "path": {"artifact.dir/artifact.file2"}}, links["artifact.file2"].Query())
+
+	// 5. JSON clients still get the structured listing.
+	resp := response.ListArtifactsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithNamespace(
+			namespace.Code,
+		).WithQuery(
+			request.ListArtifactsRequest{
+				RunID: run.ID,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+		),
+	)
+	s.Equal(2, len(resp.Files))
+}
@@ -162,6 +162,121 @@ func (s *ListArtifactLocalTestSuite) Test_Ok() {
 	}
 }
 
+func (s *ListArtifactLocalTestSuite) Test_Recursive_Ok() {
+	// 1. create test experiment.
+	experimentArtifactDir := s.T().TempDir()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             fmt.Sprintf("Test Experiment In Path %s", experimentArtifactDir),
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: experimentArtifactDir,
+	})
+	s.Require().Nil(err)
+
+	// 2. create test run.
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(experimentArtifactDir, runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 3. create nested artifacts.
+	err = os.MkdirAll(filepath.Join(runArtifactDir, "model", "data", "weights"), fs.ModePerm)
+	s.Require().Nil(err)
+	err = os.MkdirAll(filepath.Join(runArtifactDir, "plots"), fs.ModePerm)
+	s.Require().Nil(err)
+	for path, content := range map[string]string{
+		"metrics.json":                 "{}",
+		"model/MLmodel":                "flavors",
+		"model/data/config.yaml":       "config",
+		"model/data/weights/layer1.pt": "layer1",
+		"model/data/weights/layer2.pt": "layer22",
+	} {
+		s.Require().Nil(os.WriteFile(filepath.Join(runArtifactDir, path), []byte(content), fs.ModePerm))
+	}
+
+	// 4. make actual API call for the whole tree.
+	resp := response.ListArtifactsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.ListArtifactsRequest{
+				RunID:     run.ID,
+				Recursive: true,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+		),
+	)
+	allFiles := []response.FilePartialResponse{
+		{Path: "metrics.json", FileSize: 2},
+		{Path: "model", IsDir: true},
+		{Path: "model/MLmodel", FileSize: 7},
+		{Path: "model/data", IsDir: true},
+		{Path: "model/data/config.yaml", FileSize: 6},
+		{Path: "model/data/weights", IsDir: true},
+		{Path: "model/data/weights/layer1.pt", FileSize: 6},
+		{Path: "model/data/weights/layer2.pt", FileSize: 7},
+		{Path: "plots", IsDir: true},
+	}
+	s.Equal(run.ArtifactURI, resp.RootURI)
+	s.Equal(allFiles, resp.Files)
+	s.Empty(resp.NextPageToken)
+
+	// 5. make actual API call for the subtree.
+	resp = response.ListArtifactsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.ListArtifactsRequest{
+				RunID:     run.ID,
+				Path:      "model/data",
+				Recursive: true,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+		),
+	)
+	s.Equal(allFiles[4:8], resp.Files)
+
+	// 6. make actual API calls for the whole tree page by page.
+	var pagedFiles []response.FilePartialResponse
+	pageToken, pages := "", 0
+	for {
+		resp = response.ListArtifactsResponse{}
+		s.Require().Nil(
+			s.MlflowClient().WithQuery(
+				request.ListArtifactsRequest{
+					RunID:      run.ID,
+					Recursive:  true,
+					MaxResults: 4,
+					PageToken:  pageToken,
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+			),
+		)
+		pages++
+		pagedFiles = append(pagedFiles, resp.Files...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	s.Equal(3, pages)
+	s.Equal(allFiles, pagedFiles)
+}
+
 func (s *ListArtifactLocalTestSuite) Test_Error() {
 	tests := []struct {
 		name    string
@@ -213,6 +328,22 @@ func (s *ListArtifactLocalTestSuite) Test_Error() {
 				Path:  "/foo/../bar",
 			},
 		},
+		{
+			name:  "IncorrectMaxResultsProvided",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied."),
+			request: request.ListArtifactsRequest{
+				RunID:      "run_id",
+				MaxResults: -1,
+			},
+		},
+		{
+			name:  "IncorrectPageTokenProvided",
+			error: api.NewInvalidParameterValueError("invalid page_token 'invalid'"),
+			request: request.ListArtifactsRequest{
+				RunID:     "run_id",
+				PageToken: "invalid",
+			},
+		},
 	}
 
 	for _, tt := range tests {
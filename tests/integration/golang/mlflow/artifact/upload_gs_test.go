@@ -0,0 +1,125 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type UploadArtifactGSTestSuite struct {
+	helpers.GSTestSuite
+}
+
+// TestUploadArtifactGSTestSuite runs the server without explicit GS endpoint and credentials, so GS client
+// is created with Application Default Credentials, which are pointed to the emulator by `STORAGE_EMULATOR_HOST`.
+func TestUploadArtifactGSTestSuite(t *testing.T) {
+	host, err := helpers.GetGSEmulatorHost()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("STORAGE_EMULATOR_HOST", host)
+
+	testSuite := &UploadArtifactGSTestSuite{
+		helpers.NewGSTestSuite("bucket1"),
+	}
+	testSuite.SkipGSEndpointURI = true
+	suite.Run(t, testSuite)
+}
+
+func (s *UploadArtifactGSTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment In Bucket bucket1",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: "gs://bucket1/prefix/1",
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 1. upload artifact through the API.
+	createResp := response.CreateArtifactUploadResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateArtifactUploadRequest{
+			RunID: run.ID,
+			Path:  "model/weights.bin",
+		},
+	).WithResponse(
+		&createResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCreateRoute,
+	))
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPut,
+	).WithQuery(
+		request.UploadArtifactPartRequest{
+			UploadID:   createResp.UploadID,
+			PartNumber: 1,
+		},
+	).WithRequest(
+		[]byte("content"),
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute,
+	))
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteArtifactUploadRequest{
+			UploadID:  createResp.UploadID,
+			PartCount: 1,
+		},
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCompleteRoute,
+	))
+
+	// 2. check that artifact is stored under the prefix of the bucket.
+	reader, err := s.Client.Bucket("bucket1").Object(
+		fmt.Sprintf("prefix/1/%s/artifacts/model/weights.bin", runID),
+	).NewReader(context.Background())
+	s.Require().Nil(err)
+	//nolint:errcheck
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	s.Require().Nil(err)
+	s.Equal("content", string(content))
+
+	// 3. download artifact through the API.
+	resp := new(bytes.Buffer)
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.GetArtifactRequest{
+			RunID: run.ID,
+			Path:  "model/weights.bin",
+		},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal("content", resp.String())
+}
@@ -0,0 +1,259 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type UploadArtifactLocalTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestUploadArtifactLocalTestSuite(t *testing.T) {
+	suite.Run(t, new(UploadArtifactLocalTestSuite))
+}
+
+func (s *UploadArtifactLocalTestSuite) createRun() (*models.Run, string) {
+	experimentArtifactDir := s.T().TempDir()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             fmt.Sprintf("Test Experiment In Path %s", experimentArtifactDir),
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: experimentArtifactDir,
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(experimentArtifactDir, runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	return run, runArtifactDir
+}
+
+func (s *UploadArtifactLocalTestSuite) Test_Ok() {
+	run, runArtifactDir := s.createRun()
+
+	// 1. create upload session.
+	createResp := response.CreateArtifactUploadResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateArtifactUploadRequest{
+			RunID: run.ID,
+			Path:  "model/weights.bin",
+		},
+	).WithResponse(
+		&createResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCreateRoute,
+	))
+	s.NotEmpty(createResp.UploadID)
+
+	// 2. upload the parts in random order.
+	for _, part := range []struct {
+		number  int
+		content string
+	}{
+		{number: 2, content: "second-"},
+		{number: 3, content: "third"},
+		{number: 1, content: "first-"},
+	} {
+		s.Require().Nil(s.MlflowClient().WithMethod(
+			http.MethodPut,
+		).WithQuery(
+			request.UploadArtifactPartRequest{
+				UploadID:   createResp.UploadID,
+				PartNumber: part.number,
+			},
+		).WithRequest(
+			[]byte(part.content),
+		).DoRequest(
+			"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute,
+		))
+	}
+
+	// 3. finalize the upload and check that the artifact is assembled.
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteArtifactUploadRequest{
+			UploadID:  createResp.UploadID,
+			PartCount: 3,
+		},
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCompleteRoute,
+	))
+
+	content, err := os.ReadFile(filepath.Join(runArtifactDir, "model", "weights.bin"))
+	s.Require().Nil(err)
+	s.Equal("first-second-third", string(content))
+
+	// 4. finished upload can't be used anymore.
+	resp := api.ErrorResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPut,
+	).WithQuery(
+		request.UploadArtifactPartRequest{
+			UploadID:   createResp.UploadID,
+			PartNumber: 4,
+		},
+	).WithRequest(
+		[]byte("fourth"),
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute,
+	))
+	s.Equal(api.ErrorCodeResourceDoesNotExist, string(resp.ErrorCode))
+}
+
+func (s *UploadArtifactLocalTestSuite) Test_Error() {
+	run, runArtifactDir := s.createRun()
+
+	createResp := response.CreateArtifactUploadResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateArtifactUploadRequest{
+			RunID: run.ID,
+			Path:  "file.txt",
+		},
+	).WithResponse(
+		&createResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCreateRoute,
+	))
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPut,
+	).WithQuery(
+		request.UploadArtifactPartRequest{
+			UploadID:   createResp.UploadID,
+			PartNumber: 1,
+		},
+	).WithRequest(
+		[]byte("content"),
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute,
+	))
+
+	tests := []struct {
+		name     string
+		method   string
+		route    string
+		query    any
+		request  any
+		error    *api.ErrorResponse
+		httpCode int
+	}{
+		{
+			name:   "CreateWithPathOutsideRoot",
+			method: http.MethodPost,
+			route:  mlflow.ArtifactsUploadsCreateRoute,
+			request: request.CreateArtifactUploadRequest{
+				RunID: run.ID,
+				Path:  "../file.txt",
+			},
+			error:    api.NewInvalidParameterValueError("Invalid path"),
+			httpCode: http.StatusBadRequest,
+		},
+		{
+			name:   "UploadPartWithInvalidPartNumber",
+			method: http.MethodPut,
+			route:  mlflow.ArtifactsUploadsPartRoute,
+			query: request.UploadArtifactPartRequest{
+				UploadID: createResp.UploadID,
+			},
+			request:  []byte("content"),
+			error:    api.NewInvalidParameterValueError("Invalid value for parameter 'part_number' supplied."),
+			httpCode: http.StatusBadRequest,
+		},
+		{
+			name:   "UploadPartOfUnknownUpload",
+			method: http.MethodPut,
+			route:  mlflow.ArtifactsUploadsPartRoute,
+			query: request.UploadArtifactPartRequest{
+				UploadID:   "unknown",
+				PartNumber: 1,
+			},
+			request:  []byte("content"),
+			error:    api.NewResourceDoesNotExistError("unable to find artifact upload 'unknown'"),
+			httpCode: http.StatusNotFound,
+		},
+		{
+			name:   "CompleteWithMissingPart",
+			method: http.MethodPost,
+			route:  mlflow.ArtifactsUploadsCompleteRoute,
+			request: request.CompleteArtifactUploadRequest{
+				UploadID:  createResp.UploadID,
+				PartCount: 2,
+			},
+			error: api.NewInvalidParameterValueError(
+				"unable to complete artifact upload '%s': part 2 of upload", createResp.UploadID,
+			),
+			httpCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.MlflowClient().WithMethod(tt.method).WithRequest(tt.request).WithResponse(&resp)
+			if tt.query != nil {
+				client = client.WithQuery(tt.query)
+			}
+			s.Require().Nil(client.DoRequest("%s%s", mlflow.ArtifactsRoutePrefix, tt.route))
+			s.Equal(tt.httpCode, client.GetStatusCode())
+			s.Equal(tt.error.ErrorCode, resp.ErrorCode)
+			s.Contains(resp.Message, tt.error.Message)
+		})
+	}
+
+	// aborted upload removes the uploaded parts and leaves no artifact behind.
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.AbortArtifactUploadRequest{
+			UploadID: createResp.UploadID,
+		},
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsAbortRoute,
+	))
+	_, err := os.Stat(filepath.Join(runArtifactDir, "file.txt"))
+	s.True(os.IsNotExist(err))
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteArtifactUploadRequest{
+			UploadID:  createResp.UploadID,
+			PartCount: 1,
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCompleteRoute,
+	))
+	s.Equal(api.ErrorCodeResourceDoesNotExist, string(resp.ErrorCode))
+}
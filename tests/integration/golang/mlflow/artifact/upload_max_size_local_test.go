@@ -0,0 +1,157 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type UploadArtifactMaxSizeLocalTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestUploadArtifactMaxSizeLocalTestSuite(t *testing.T) {
+	testSuite := new(UploadArtifactMaxSizeLocalTestSuite)
+	testSuite.Config = config.Config{
+		ArtifactMaxFileSize: 10,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *UploadArtifactMaxSizeLocalTestSuite) createUpload(path string) (string, string) {
+	experimentArtifactDir := s.T().TempDir()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             fmt.Sprintf("Test Experiment In Path %s", experimentArtifactDir),
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: experimentArtifactDir,
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(experimentArtifactDir, runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	createResp := response.CreateArtifactUploadResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateArtifactUploadRequest{
+			RunID: run.ID,
+			Path:  path,
+		},
+	).WithResponse(
+		&createResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCreateRoute,
+	))
+	return createResp.UploadID, runArtifactDir
+}
+
+func (s *UploadArtifactMaxSizeLocalTestSuite) uploadPart(
+	uploadID string, number int, content string,
+) *helpers.HttpClient {
+	return s.MlflowClient().WithMethod(
+		http.MethodPut,
+	).WithQuery(
+		request.UploadArtifactPartRequest{
+			UploadID:   uploadID,
+			PartNumber: number,
+		},
+	).WithRequest(
+		[]byte(content),
+	)
+}
+
+func (s *UploadArtifactMaxSizeLocalTestSuite) Test_Ok() {
+	uploadID, runArtifactDir := s.createUpload("file.txt")
+
+	// artifact of 9 bytes is just under the limit, re-uploaded part is counted once.
+	for _, part := range []struct {
+		number  int
+		content string
+	}{
+		{number: 1, content: "12345"},
+		{number: 2, content: "6789"},
+		{number: 1, content: "abcde"},
+	} {
+		s.Require().Nil(
+			s.uploadPart(uploadID, part.number, part.content).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute,
+			),
+		)
+	}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteArtifactUploadRequest{
+			UploadID:  uploadID,
+			PartCount: 2,
+		},
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCompleteRoute,
+	))
+
+	content, err := os.ReadFile(filepath.Join(runArtifactDir, "file.txt"))
+	s.Require().Nil(err)
+	s.Equal("abcde6789", string(content))
+}
+
+func (s *UploadArtifactMaxSizeLocalTestSuite) Test_Error() {
+	uploadID, runArtifactDir := s.createUpload("file.txt")
+
+	// the first part fits, while the second one makes artifact 11 bytes long, just over the limit.
+	s.Require().Nil(
+		s.uploadPart(uploadID, 1, "12345").DoRequest(
+			"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute,
+		),
+	)
+	resp := api.ErrorResponse{}
+	client := s.uploadPart(uploadID, 2, "678901").WithResponse(&resp)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal(api.NewInvalidParameterValueError(
+		"artifact 'file.txt' of upload '%s' exceeds maximum size of 10 bytes", uploadID,
+	).Error(), resp.Error())
+
+	// the whole upload is aborted and leaves no artifact behind.
+	resp = api.ErrorResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteArtifactUploadRequest{
+			UploadID:  uploadID,
+			PartCount: 1,
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCompleteRoute,
+	))
+	s.Equal(api.ErrorCodeResourceDoesNotExist, string(resp.ErrorCode))
+	_, err := os.Stat(filepath.Join(runArtifactDir, "file.txt"))
+	s.True(os.IsNotExist(err))
+}
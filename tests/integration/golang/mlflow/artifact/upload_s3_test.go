@@ -0,0 +1,148 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type UploadArtifactS3TestSuite struct {
+	helpers.S3TestSuite
+}
+
+// TestUploadArtifactS3TestSuite runs the server with the options, which are needed by S3-compatible storages
+// like MinIO: custom region, path-style addressing and plain HTTP endpoint.
+func TestUploadArtifactS3TestSuite(t *testing.T) {
+	testSuite := &UploadArtifactS3TestSuite{
+		helpers.NewS3TestSuite("bucket1"),
+	}
+	testSuite.Config = config.Config{
+		S3Region:         "us-east-1",
+		S3ForcePathStyle: true,
+		S3DisableSSL:     true,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *UploadArtifactS3TestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment In Bucket bucket1",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: "s3://bucket1/prefix/1",
+	})
+	s.Require().Nil(err)
+
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 1. upload artifact through the API.
+	createResp := response.CreateArtifactUploadResponse{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateArtifactUploadRequest{
+			RunID: run.ID,
+			Path:  "model/weights.bin",
+		},
+	).WithResponse(
+		&createResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCreateRoute,
+	))
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPut,
+	).WithQuery(
+		request.UploadArtifactPartRequest{
+			UploadID:   createResp.UploadID,
+			PartNumber: 1,
+		},
+	).WithRequest(
+		[]byte("content"),
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsPartRoute,
+	))
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteArtifactUploadRequest{
+			UploadID:  createResp.UploadID,
+			PartCount: 1,
+		},
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsUploadsCompleteRoute,
+	))
+
+	// 2. check that artifact is stored under the prefix of the bucket.
+	object, err := s.Client.GetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: aws.String("bucket1"),
+		Key:    aws.String(fmt.Sprintf("prefix/1/%s/artifacts/model/weights.bin", runID)),
+	})
+	s.Require().Nil(err)
+	//nolint:errcheck
+	defer object.Body.Close()
+	content, err := io.ReadAll(object.Body)
+	s.Require().Nil(err)
+	s.Equal("content", string(content))
+
+	// 3. list artifacts through the API.
+	listResp := response.ListArtifactsResponse{}
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.ListArtifactsRequest{
+			RunID: run.ID,
+			Path:  "model",
+		},
+	).WithResponse(
+		&listResp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+	))
+	s.Equal(run.ArtifactURI, listResp.RootURI)
+	s.Equal([]response.FilePartialResponse{
+		{
+			Path:     "model/weights.bin",
+			IsDir:    false,
+			FileSize: 7,
+		},
+	}, listResp.Files)
+
+	// 4. download artifact through the API.
+	resp := new(bytes.Buffer)
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.GetArtifactRequest{
+			RunID: run.ID,
+			Path:  "model/weights.bin",
+		},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal("content", resp.String())
+}
@@ -0,0 +1,72 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateExperimentArtifactRootTemplateTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateExperimentArtifactRootTemplateTestSuite(t *testing.T) {
+	testSuite := new(CreateExperimentArtifactRootTemplateTestSuite)
+	testSuite.Config = config.Config{
+		DefaultArtifactRoot: "s3://bucket/{namespace}/{experiment_id}/",
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *CreateExperimentArtifactRootTemplateTestSuite) Test_Ok() {
+	for i, code := range []string{"team-a", "team-b"} {
+		_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+			ID:                  uint(i + 2),
+			Code:                code,
+			DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+		})
+		s.Require().Nil(err)
+	}
+
+	// each experiment gets its own prefix built from its namespace and ID.
+	for _, namespace := range []string{"default", "team-a", "team-b"} {
+		for _, name := range []string{"experiment1", "experiment2"} {
+			resp := response.CreateExperimentResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					namespace,
+				).WithRequest(
+					request.CreateExperimentRequest{Name: name},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+				),
+			)
+
+			ns, err := s.NamespaceFixtures.GetNamespaceByCode(context.Background(), namespace)
+			s.Require().Nil(err)
+			experimentID, err := strconv.ParseInt(resp.ID, 10, 32)
+			s.Require().Nil(err)
+			experiment, err := s.ExperimentFixtures.GetByNamespaceIDAndExperimentID(
+				context.Background(), ns.ID, int32(experimentID),
+			)
+			s.Require().Nil(err)
+			s.Equal(fmt.Sprintf("s3://bucket/%s/%s", namespace, resp.ID), experiment.ArtifactLocation)
+		}
+	}
+}
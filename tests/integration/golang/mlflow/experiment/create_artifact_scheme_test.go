@@ -0,0 +1,126 @@
+package experiment
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateExperimentArtifactSchemeTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateExperimentArtifactSchemeTestSuite(t *testing.T) {
+	testSuite := new(CreateExperimentArtifactSchemeTestSuite)
+	testSuite.Config = config.Config{
+		NamespaceArtifactSchemes: []string{"restricted:s3"},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *CreateExperimentArtifactSchemeTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+	_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "restricted",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+}
+
+func (s *CreateExperimentArtifactSchemeTestSuite) Test_Ok() {
+	tests := []struct {
+		name             string
+		namespace        string
+		artifactLocation string
+	}{
+		{
+			name:             "AllowedSchemeInRestrictedNamespace",
+			namespace:        "restricted",
+			artifactLocation: "s3://bucket/experiment",
+		},
+		{
+			name:             "AnySchemeInNotRestrictedNamespace",
+			namespace:        "default",
+			artifactLocation: "file:///tmp/experiment",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.CreateExperimentResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					tt.namespace,
+				).WithRequest(
+					request.CreateExperimentRequest{
+						Name:             tt.name,
+						ArtifactLocation: tt.artifactLocation,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+				),
+			)
+			s.NotEmpty(resp.ID)
+		})
+	}
+}
+
+func (s *CreateExperimentArtifactSchemeTestSuite) Test_Error() {
+	tests := []struct {
+		name             string
+		artifactLocation string
+		error            *api.ErrorResponse
+	}{
+		{
+			name:             "DisallowedScheme",
+			artifactLocation: "file:///tmp/experiment",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'artifact_location': " +
+					"scheme of 'file:///tmp/experiment' is not allowed in namespace 'restricted'",
+			),
+		},
+		{
+			name:             "LocalPathWithoutScheme",
+			artifactLocation: "/tmp/experiment",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'artifact_location': " +
+					"scheme of '/tmp/experiment' is not allowed in namespace 'restricted'",
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithNamespace(
+				"restricted",
+			).WithRequest(
+				request.CreateExperimentRequest{
+					Name:             tt.name,
+					ArtifactLocation: tt.artifactLocation,
+				},
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute))
+			s.Equal(http.StatusBadRequest, client.GetStatusCode())
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
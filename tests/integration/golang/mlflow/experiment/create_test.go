@@ -2,6 +2,7 @@ package experiment
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -77,6 +78,22 @@ func (s *CreateExperimentTestSuite) Test_Error() {
 				ArtifactLocation: "incorrect-protocol,:/incorrect-location",
 			},
 		},
+		{
+			name: "OverLongNameProperty",
+			error: api.NewInvalidParameterValueError(
+				"experiment name is 257 characters long, which exceeds the maximum length of 256 characters",
+			),
+			request: &request.CreateExperimentRequest{
+				Name: strings.Repeat("a", 257),
+			},
+		},
+		{
+			name:  "NameWithControlCharacters",
+			error: api.NewInvalidParameterValueError("experiment name should not contain control characters"),
+			request: &request.CreateExperimentRequest{
+				Name: "first\nsecond",
+			},
+		},
 	}
 
 	for _, tt := range testData {
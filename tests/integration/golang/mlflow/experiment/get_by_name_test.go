@@ -12,6 +12,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
@@ -83,7 +84,65 @@ func (s *GetExperimentByNameTestSuite) Test_Ok() {
 	}
 }
 
+func (s *GetExperimentByNameTestSuite) Test_SameNameDifferentNamespace() {
+	// 1. prepare database with test data.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	experiments := map[string]*models.Experiment{}
+	for _, ns := range []*models.Namespace{s.DefaultNamespace, namespace} {
+		experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:             "Test Experiment",
+			NamespaceID:      ns.ID,
+			LifecycleStage:   models.LifecycleStageActive,
+			ArtifactLocation: fmt.Sprintf("/artifact/location/%s", ns.Code),
+		})
+		s.Require().Nil(err)
+		experiments[ns.Code] = experiment
+	}
+
+	// 2. make actual API calls and check that experiment of requested namespace is returned.
+	for code, experiment := range experiments {
+		resp := response.GetExperimentResponse{}
+		s.Require().Nil(
+			s.MlflowClient().WithNamespace(
+				code,
+			).WithQuery(
+				request.GetExperimentRequest{Name: "Test Experiment"},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetByNameRoute,
+			),
+		)
+		s.Equal(fmt.Sprintf("%d", *experiment.ID), resp.Experiment.ID)
+		s.Equal(experiment.ArtifactLocation, resp.Experiment.ArtifactLocation)
+	}
+}
+
 func (s *GetExperimentByNameTestSuite) Test_Error() {
+	// 1. prepare database with test data.
+	_, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	_, err = s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Custom Experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
 	testData := []struct {
 		name    string
 		error   *api.ErrorResponse
@@ -96,6 +155,20 @@ func (s *GetExperimentByNameTestSuite) Test_Error() {
 				Name: "incorrect_experiment_name",
 			},
 		},
+		{
+			name:  "NameWithDifferentCase",
+			error: api.NewResourceDoesNotExistError(`unable to find experiment 'test experiment'`),
+			request: request.GetExperimentRequest{
+				Name: "test experiment",
+			},
+		},
+		{
+			name:  "ExperimentOfAnotherNamespace",
+			error: api.NewResourceDoesNotExistError(`unable to find experiment 'Custom Experiment'`),
+			request: request.GetExperimentRequest{
+				Name: "Custom Experiment",
+			},
+		},
 		{
 			name:  "EmptyExperimentName",
 			error: api.NewInvalidParameterValueError(`Missing value for required parameter 'experiment_name'`),
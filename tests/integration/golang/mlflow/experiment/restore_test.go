@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
@@ -33,12 +35,38 @@ func (s *RestoreExperimentTestSuite) Test_Ok() {
 	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
 		Name:           "Test Experiment",
 		NamespaceID:    s.DefaultNamespace.ID,
-		LifecycleStage: models.LifecycleStageDeleted,
+		LifecycleStage: models.LifecycleStageActive,
 	})
 	s.Require().Nil(err)
-	s.Equal(models.LifecycleStageDeleted, experiment.LifecycleStage)
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *experiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	// 2. delete experiment together with its runs.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.DeleteExperimentRequest{
+				ID: fmt.Sprintf("%d", *experiment.ID),
+			},
+		).WithResponse(
+			&fiber.Map{},
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsDeleteRoute,
+		),
+	)
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(models.LifecycleStageDeleted, run.LifecycleStage)
+	s.True(run.DeletedTime.Valid)
 
-	// 2. make actual API call.
+	// 3. make actual API call.
 	req := request.RestoreExperimentRequest{
 		ID: fmt.Sprintf("%d", *experiment.ID),
 	}
@@ -55,15 +83,27 @@ func (s *RestoreExperimentTestSuite) Test_Ok() {
 		),
 	)
 
-	// 3. check actual API response.
+	// 4. check that experiment and its runs have been restored.
 	exp, err := s.ExperimentFixtures.GetByNamespaceIDAndExperimentID(
 		context.Background(), s.DefaultNamespace.ID, *experiment.ID,
 	)
 	s.Require().Nil(err)
 	s.Equal(models.LifecycleStageActive, exp.LifecycleStage)
+
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(models.LifecycleStageActive, run.LifecycleStage)
+	s.False(run.DeletedTime.Valid)
 }
 
 func (s *RestoreExperimentTestSuite) Test_Error() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Active Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
 	testData := []struct {
 		name    string
 		error   *api.ErrorResponse
@@ -94,6 +134,13 @@ func (s *RestoreExperimentTestSuite) Test_Error() {
 				ID: "123",
 			},
 		},
+		{
+			name:  "ExperimentNotDeleted",
+			error: api.NewInvalidParameterValueError("experiment '%d' is not deleted", *experiment.ID),
+			request: &request.RestoreExperimentRequest{
+				ID: fmt.Sprintf("%d", *experiment.ID),
+			},
+		},
 	}
 
 	for _, tt := range testData {
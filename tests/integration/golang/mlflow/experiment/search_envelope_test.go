@@ -0,0 +1,86 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchExperimentsEnvelopeTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchExperimentsEnvelopeTestSuite(t *testing.T) {
+	suite.Run(t, &SearchExperimentsEnvelopeTestSuite{
+		helpers.BaseTestSuite{
+			SkipCreateDefaultExperiment: true,
+		},
+	})
+}
+
+func (s *SearchExperimentsEnvelopeTestSuite) Test_Ok() {
+	for i := 0; i < 3; i++ {
+		_, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           fmt.Sprintf("experiment%d", i),
+			NamespaceID:    s.DefaultNamespace.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	// clients, which opted in, get the envelope.
+	var envelope api.PaginatedResponse[response.ExperimentPartialResponse]
+	client := s.MlflowClient().WithHeaders(map[string]string{
+		"Accept": api.MIMEApplicationPaginatedJSON,
+	}).WithQuery(
+		request.SearchExperimentsRequest{MaxResults: 2},
+	).WithResponse(
+		&envelope,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute))
+	s.Equal(api.MIMEApplicationPaginatedJSON, client.GetResponseHeaders().Get("Content-Type"))
+	s.Equal(2, len(envelope.Items))
+	s.NotEmpty(envelope.NextToken)
+	s.Nil(envelope.Total)
+
+	// next token leads to the last page, which has no next token.
+	var lastPage map[string]any
+	s.Require().Nil(
+		s.MlflowClient().WithHeaders(map[string]string{
+			"Accept": api.MIMEApplicationPaginatedJSON,
+		}).WithQuery(
+			request.SearchExperimentsRequest{MaxResults: 2, PageToken: envelope.NextToken},
+		).WithResponse(
+			&lastPage,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+		),
+	)
+	s.Equal(3, len(lastPage))
+	s.Len(lastPage["items"], 1)
+	s.Equal("", lastPage["next_token"])
+	s.Contains(lastPage, "total")
+	s.Nil(lastPage["total"])
+
+	// legacy clients still get the old shape.
+	var legacy map[string]any
+	client = s.MlflowClient().WithQuery(
+		request.SearchExperimentsRequest{MaxResults: 2},
+	).WithResponse(
+		&legacy,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute))
+	s.Equal("application/json", client.GetResponseHeaders().Get("Content-Type"))
+	s.Contains(legacy, "experiments")
+	s.Contains(legacy, "next_page_token")
+	s.NotContains(legacy, "items")
+}
@@ -60,6 +60,19 @@ func (s *UpdateExperimentTestSuite) Test_Ok() {
 }
 
 func (s *UpdateExperimentTestSuite) Test_Error() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	_, err = s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Existing Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
 	testData := []struct {
 		name    string
 		error   *api.ErrorResponse
@@ -90,6 +103,14 @@ func (s *UpdateExperimentTestSuite) Test_Error() {
 				Name: "New Name",
 			},
 		},
+		{
+			name:  "ExistingNameProperty",
+			error: api.NewResourceAlreadyExistsError("experiment(name=Existing Experiment) already exists"),
+			request: &request.UpdateExperimentRequest{
+				ID:   fmt.Sprintf("%d", *experiment.ID),
+				Name: "Existing Experiment",
+			},
+		},
 	}
 
 	for _, tt := range testData {
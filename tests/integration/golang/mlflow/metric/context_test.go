@@ -0,0 +1,89 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ContextTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestContextTestSuite(t *testing.T) {
+	suite.Run(t, new(ContextTestSuite))
+}
+
+func (s *ContextTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	// log the same context with differing key order and formatting through the API.
+	for i, metricContext := range []string{
+		`{"subset":"train","model":"a"}`,
+		`{ "model": "a", "subset": "train" }`,
+	} {
+		resp := map[string]any{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				[]byte(fmt.Sprintf(
+					`{"run_id":"%s","metrics":[{"key":"loss","value":1.1,"timestamp":%d,"step":%d,"context":%s}]}`,
+					run.ID, 1234567890+i, i, metricContext,
+				)),
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			),
+		)
+	}
+
+	// log the same context with differing key order through the repository, bypassing the request decoding.
+	s.Require().Nil(s.MetricFixtures.CreateMetrics(context.Background(), run, []models.Metric{
+		{
+			Key:       "loss",
+			Value:     1.2,
+			Timestamp: 1234567892,
+			RunID:     run.ID,
+			Step:      2,
+			Context: models.Context{
+				Json: types.JSONB(`{"subset": "train", "model": "a"}`),
+			},
+		},
+	}))
+
+	contexts, err := s.ContextFixtures.GetContexts(context.Background())
+	s.Require().Nil(err)
+	var trainContexts []models.Context
+	for _, metricContext := range contexts {
+		if string(metricContext.Json) != "{}" {
+			trainContexts = append(trainContexts, metricContext)
+		}
+	}
+	s.Require().Len(trainContexts, 1)
+	s.Equal(`{"model":"a","subset":"train"}`, string(trainContexts[0].Json))
+
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Require().Len(metrics, 3)
+	for _, metric := range metrics {
+		s.Equal(trainContexts[0].ID, metric.ContextID)
+	}
+}
@@ -0,0 +1,228 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type DeleteMetricTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestDeleteMetricTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteMetricTestSuite))
+}
+
+func (s *DeleteMetricTestSuite) Test_Ok() {
+	tests := []struct {
+		name                  string
+		lifecycleStage        models.LifecycleStage
+		request               func(runID string) request.DeleteMetricRequest
+		expectedKey1Contexts  []string
+		expectedKey1Histories int
+	}{
+		{
+			name:           "DeleteKeyInAllContexts",
+			lifecycleStage: models.LifecycleStageActive,
+			request: func(runID string) request.DeleteMetricRequest {
+				return request.DeleteMetricRequest{RunID: runID, Key: "key1"}
+			},
+			expectedKey1Contexts:  []string{},
+			expectedKey1Histories: 0,
+		},
+		{
+			name:           "DeleteKeyInSpecificContext",
+			lifecycleStage: models.LifecycleStageActive,
+			request: func(runID string) request.DeleteMetricRequest {
+				return request.DeleteMetricRequest{
+					RunID: runID, Key: "key1", Context: map[string]any{"subset": "train"},
+				}
+			},
+			expectedKey1Contexts:  []string{`{"subset":"test"}`},
+			expectedKey1Histories: 2,
+		},
+		{
+			name:           "DeleteKeyOfDeletedRunWithForce",
+			lifecycleStage: models.LifecycleStageDeleted,
+			request: func(runID string) request.DeleteMetricRequest {
+				return request.DeleteMetricRequest{RunID: runID, Key: "key1", Force: true}
+			},
+			expectedKey1Contexts:  []string{},
+			expectedKey1Histories: 0,
+		},
+	}
+	for i, tt := range tests {
+		s.Run(tt.name, func() {
+			run := s.createRunWithMetrics(fmt.Sprintf("run%d", i), tt.lifecycleStage)
+
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request(run.ID),
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsDeleteRoute,
+				),
+			)
+
+			// check that only targeted metric history was removed.
+			metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			key1Histories, key2Histories := 0, 0
+			for _, metric := range metrics {
+				switch metric.Key {
+				case "key1":
+					key1Histories++
+				case "key2":
+					key2Histories++
+				}
+			}
+			s.Equal(tt.expectedKey1Histories, key1Histories)
+			s.Equal(2, key2Histories)
+
+			// check that only targeted latest metrics were removed.
+			latestMetrics, err := s.MetricFixtures.GetLatestMetricsByKey(context.Background(), "key1")
+			s.Require().Nil(err)
+			key1Contexts := []string{}
+			for _, latestMetric := range latestMetrics {
+				if latestMetric.RunID == run.ID {
+					key1Contexts = append(key1Contexts, string(latestMetric.Context.Json))
+				}
+			}
+			s.ElementsMatch(tt.expectedKey1Contexts, key1Contexts)
+			latestMetrics, err = s.MetricFixtures.GetLatestMetricsByKey(context.Background(), "key2")
+			s.Require().Nil(err)
+			s.Len(latestMetrics, i+1)
+		})
+	}
+}
+
+func (s *DeleteMetricTestSuite) Test_Error() {
+	activeRun := s.createRunWithMetrics("active", models.LifecycleStageActive)
+	deletedRun := s.createRunWithMetrics("deleted", models.LifecycleStageDeleted)
+
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.DeleteMetricRequest
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: request.DeleteMetricRequest{Key: "key1"},
+		},
+		{
+			name:    "EmptyKey",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'key'"),
+			request: request.DeleteMetricRequest{RunID: activeRun.ID},
+		},
+		{
+			name:    "NotFoundRun",
+			error:   api.NewResourceDoesNotExistError("unable to find run 'not-existing'"),
+			request: request.DeleteMetricRequest{RunID: "not-existing", Key: "key1"},
+		},
+		{
+			name: "DeletedRunWithoutForce",
+			error: api.NewInvalidParameterValueError(
+				"run '%s' is not active, use 'force' to delete its metrics", deletedRun.ID,
+			),
+			request: request.DeleteMetricRequest{RunID: deletedRun.ID, Key: "key1"},
+		},
+		{
+			name: "NotFoundKey",
+			error: api.NewResourceDoesNotExistError(
+				"unable to find metric 'not-existing' of run '%s'", activeRun.ID,
+			),
+			request: request.DeleteMetricRequest{RunID: activeRun.ID, Key: "not-existing"},
+		},
+		{
+			name: "NotFoundContext",
+			error: api.NewResourceDoesNotExistError(
+				"unable to find metric 'key1' of run '%s'", activeRun.ID,
+			),
+			request: request.DeleteMetricRequest{
+				RunID: activeRun.ID, Key: "key1", Context: map[string]any{"subset": "validation"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsDeleteRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+
+	// check that nothing was removed.
+	for _, run := range []*models.Run{activeRun, deletedRun} {
+		metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+		s.Require().Nil(err)
+		s.Len(metrics, 6)
+	}
+}
+
+// createRunWithMetrics creates run with `key1` metric logged in two contexts and `key2` metric
+// logged in the default context.
+func (s *DeleteMetricTestSuite) createRunWithMetrics(id string, lifecycleStage models.LifecycleStage) *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             id,
+		Name:           id,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	metrics := []request.MetricPartialRequest{}
+	for step := int64(1); step <= 2; step++ {
+		metrics = append(
+			metrics,
+			request.MetricPartialRequest{
+				Key: "key1", Value: 1.1, Timestamp: 1000, Step: step, Context: map[string]any{"subset": "train"},
+			},
+			request.MetricPartialRequest{
+				Key: "key1", Value: 2.2, Timestamp: 1000, Step: step, Context: map[string]any{"subset": "test"},
+			},
+			request.MetricPartialRequest{
+				Key: "key2", Value: 3.3, Timestamp: 1000, Step: step,
+			},
+		)
+	}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogBatchRequest{RunID: run.ID, Metrics: metrics},
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+
+	// metrics are logged to the active run only, so the run is deleted afterwards if it is requested.
+	if lifecycleStage != models.LifecycleStageActive {
+		run.LifecycleStage = lifecycleStage
+		s.Require().Nil(s.RunFixtures.UpdateRun(context.Background(), run))
+	}
+	return run
+}
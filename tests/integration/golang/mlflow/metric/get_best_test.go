@@ -0,0 +1,223 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetBestMetricsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetBestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetBestMetricsTestSuite))
+}
+
+func (s *GetBestMetricsTestSuite) Test_Ok() {
+	experiment1 := s.createExperiment("experiment1")
+	experiment2 := s.createExperiment("experiment2")
+	experiment3 := s.createExperiment("experiment3")
+
+	train := map[string]any{"subset": "train"}
+	s.createRunWithMetric("run1", experiment1, "loss", 0.5, train)
+	s.createRunWithMetric("run2", experiment1, "loss", 0.3, train)
+	s.createRunWithMetric("run3", experiment1, "loss", 0.4, train)
+	// metric of the deleted run and metric in the other context are better, but they aren't taken into account.
+	deletedRun := s.createRunWithMetric("run4", experiment1, "loss", 0.1, train)
+	deletedRun.LifecycleStage = models.LifecycleStageDeleted
+	s.Require().Nil(s.RunFixtures.UpdateRun(context.Background(), deletedRun))
+	s.createRunWithMetric("run5", experiment1, "loss", 0.2, map[string]any{"subset": "test"})
+	s.createRunWithMetric("run6", experiment2, "loss", 0.7, train)
+	s.createRunWithMetric("run7", experiment2, "loss", 0.9, train)
+	// the third experiment doesn't have `loss` metric at all.
+	s.createRunWithMetric("run8", experiment3, "accuracy", 0.8, nil)
+
+	experimentIDs := []string{
+		fmt.Sprintf("%d", *experiment1.ID),
+		fmt.Sprintf("%d", *experiment2.ID),
+		fmt.Sprintf("%d", *experiment3.ID),
+	}
+	tests := []struct {
+		name     string
+		request  request.GetBestMetricsRequest
+		response *response.GetBestMetricsResponse
+	}{
+		{
+			name: "MinDirection",
+			request: request.GetBestMetricsRequest{
+				ExperimentIDs: experimentIDs, MetricKey: "loss", Context: train, Direction: "min",
+			},
+			response: &response.GetBestMetricsResponse{
+				Experiments: []response.ExperimentBestMetricPartialResponse{
+					{ExperimentID: experimentIDs[0], RunID: "run2", Value: 0.3},
+					{ExperimentID: experimentIDs[1], RunID: "run6", Value: 0.7},
+					{ExperimentID: experimentIDs[2]},
+				},
+			},
+		},
+		{
+			name: "MaxDirection",
+			request: request.GetBestMetricsRequest{
+				ExperimentIDs: experimentIDs, MetricKey: "loss", Context: train, Direction: "max",
+			},
+			response: &response.GetBestMetricsResponse{
+				Experiments: []response.ExperimentBestMetricPartialResponse{
+					{ExperimentID: experimentIDs[0], RunID: "run1", Value: 0.5},
+					{ExperimentID: experimentIDs[1], RunID: "run7", Value: 0.9},
+					{ExperimentID: experimentIDs[2]},
+				},
+			},
+		},
+		{
+			name: "DefaultContext",
+			request: request.GetBestMetricsRequest{
+				ExperimentIDs: []string{experimentIDs[2], experimentIDs[0]}, MetricKey: "accuracy", Direction: "max",
+			},
+			response: &response.GetBestMetricsResponse{
+				Experiments: []response.ExperimentBestMetricPartialResponse{
+					{ExperimentID: experimentIDs[2], RunID: "run8", Value: 0.8},
+					{ExperimentID: experimentIDs[0]},
+				},
+			},
+		},
+		{
+			name: "NotExistingContext",
+			request: request.GetBestMetricsRequest{
+				ExperimentIDs: experimentIDs[:1],
+				MetricKey:     "loss",
+				Context:       map[string]any{"subset": "validation"},
+				Direction:     "min",
+			},
+			response: &response.GetBestMetricsResponse{
+				Experiments: []response.ExperimentBestMetricPartialResponse{
+					{ExperimentID: experimentIDs[0]},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.GetBestMetricsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetBestRoute,
+				),
+			)
+			s.Equal(tt.response, &resp)
+		})
+	}
+}
+
+func (s *GetBestMetricsTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.GetBestMetricsRequest
+	}{
+		{
+			name:    "EmptyExperimentIDs",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'experiment_ids'"),
+			request: request.GetBestMetricsRequest{MetricKey: "loss", Direction: "min"},
+		},
+		{
+			name:  "EmptyMetricKey",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'metric_key'"),
+			request: request.GetBestMetricsRequest{
+				ExperimentIDs: []string{"0"}, Direction: "min",
+			},
+		},
+		{
+			name: "IncorrectDirection",
+			error: api.NewInvalidParameterValueError(
+				"Invalid value 'best' for parameter 'direction' supplied. It should be 'min' or 'max'.",
+			),
+			request: request.GetBestMetricsRequest{
+				ExperimentIDs: []string{"0"}, MetricKey: "loss", Direction: "best",
+			},
+		},
+		{
+			name: "IncorrectExperimentID",
+			error: api.NewBadRequestError(
+				`Unable to parse experiment id 'incorrect': strconv.ParseInt: parsing "incorrect": invalid syntax`,
+			),
+			request: request.GetBestMetricsRequest{
+				ExperimentIDs: []string{"incorrect"}, MetricKey: "loss", Direction: "min",
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetBestRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
+
+func (s *GetBestMetricsTestSuite) createExperiment(name string) *models.Experiment {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           name,
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	return experiment
+}
+
+// createRunWithMetric creates run of the experiment and logs the metric with the value in the context.
+func (s *GetBestMetricsTestSuite) createRunWithMetric(
+	id string, experiment *models.Experiment, key string, value float64, metricContext map[string]any,
+) *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             id,
+		Name:           id,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *experiment.ID,
+	})
+	s.Require().Nil(err)
+
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogBatchRequest{
+				RunID: run.ID,
+				Metrics: []request.MetricPartialRequest{
+					{Key: key, Value: value, Timestamp: 1000, Step: 1, Context: metricContext},
+				},
+			},
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	return run
+}
@@ -1,9 +1,13 @@
 package metric
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
 
@@ -11,6 +15,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/services/metric"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
@@ -217,6 +222,151 @@ func (s *GetHistoriesTestSuite) Test_Ok() {
 	}
 }
 
+func (s *GetHistoriesTestSuite) Test_Compression() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusScheduled,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+		Key:       "key1",
+		Value:     1.1,
+		Timestamp: 1234567890,
+		RunID:     run.ID,
+		Step:      1,
+		Iter:      1,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name            string
+		acceptEncoding  string
+		contentEncoding string
+	}{
+		{
+			name:            "ClientAcceptsIdentity",
+			acceptEncoding:  "identity",
+			contentEncoding: "",
+		},
+		{
+			name:            "ClientAcceptsGzip",
+			acceptEncoding:  "gzip",
+			contentEncoding: "gzip",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			client := s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithHeaders(map[string]string{
+				"Content-Type":    "application/json",
+				"Accept-Encoding": tt.acceptEncoding,
+			}).WithRequest(
+				&request.GetMetricHistoriesRequest{RunIDs: []string{run.ID}},
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			)
+			s.Require().Nil(
+				client.DoRequest("%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoriesRoute),
+			)
+			s.Equal(tt.contentEncoding, client.GetResponseHeaders().Get("Content-Encoding"))
+
+			if tt.contentEncoding == "gzip" {
+				reader, err := gzip.NewReader(resp)
+				s.Require().Nil(err)
+				decompressed := new(bytes.Buffer)
+				_, err = io.Copy(decompressed, reader)
+				s.Require().Nil(err)
+				resp = decompressed
+			}
+			metrics, err := helpers.DecodeArrowMetrics(resp)
+			s.Require().Nil(err)
+			s.Equal(1, len(metrics))
+		})
+	}
+}
+
+func (s *GetHistoriesTestSuite) Test_NDJSON() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusScheduled,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	for step := int64(0); step < 3; step++ {
+		_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "key1",
+			Value:     1.1 * float64(step),
+			Timestamp: 1234567890 + step,
+			RunID:     run.ID,
+			Step:      step,
+			Iter:      step + 1,
+			Context:   models.Context{Json: types.JSONB(`{"subset":"train"}`)},
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+		Key:       "key2",
+		Timestamp: 1234567890,
+		RunID:     run.ID,
+		IsNan:     true,
+		Iter:      1,
+	})
+	s.Require().Nil(err)
+
+	resp := new(bytes.Buffer)
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithHeaders(map[string]string{
+		"Content-Type":    "application/json",
+		"Accept":          "application/x-ndjson",
+		"Accept-Encoding": "gzip",
+	}).WithRequest(
+		&request.GetMetricHistoriesRequest{RunIDs: []string{run.ID}},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	)
+	s.Require().Nil(
+		client.DoRequest("%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoriesRoute),
+	)
+	s.Equal("application/x-ndjson", client.GetResponseHeaders().Get("Content-Type"))
+	s.Equal("", client.GetResponseHeaders().Get("Content-Encoding"))
+
+	var lines []response.MetricHistoriesLineResponse
+	scanner := bufio.NewScanner(resp)
+	for scanner.Scan() {
+		var line response.MetricHistoriesLineResponse
+		s.Require().Nil(json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	s.Require().Nil(scanner.Err())
+	s.Require().Len(lines, 4)
+	for step := int64(0); step < 3; step++ {
+		s.Equal(run.ID, lines[step].RunID)
+		s.Equal("key1", lines[step].Key)
+		s.Equal(step, lines[step].Step)
+		s.Equal(1234567890+step, lines[step].Timestamp)
+		s.Equal(1.1*float64(step), lines[step].Value)
+		s.JSONEq(`{"subset":"train"}`, string(lines[step].Context))
+	}
+	s.Equal("key2", lines[3].Key)
+	s.Equal("NaN", lines[3].Value)
+}
+
 func (s *GetHistoriesTestSuite) Test_Error() {
 	tests := []struct {
 		name    string
@@ -0,0 +1,186 @@
+package metric
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetHistoryStrideTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetHistoryStrideTestSuite(t *testing.T) {
+	suite.Run(t, new(GetHistoryStrideTestSuite))
+}
+
+func (s *GetHistoryStrideTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "id",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	// log 10 points in `train` context and 7 points in `validation` context of the same metric.
+	for step := int64(0); step < 10; step++ {
+		_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "key1",
+			Value:     float64(step),
+			Timestamp: 1000 + step,
+			RunID:     run.ID,
+			Step:      step,
+			Iter:      step + 1,
+			Context:   models.Context{Json: types.JSONB(`{"subset":"train"}`)},
+		})
+		s.Require().Nil(err)
+	}
+	for step := int64(0); step < 7; step++ {
+		_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "key1",
+			Value:     float64(step),
+			Timestamp: 1000 + step,
+			RunID:     run.ID,
+			Step:      step,
+			Iter:      step + 1,
+			Context:   models.Context{Json: types.JSONB(`{"subset":"validation"}`)},
+		})
+		s.Require().Nil(err)
+	}
+
+	s.Run("GetHistory", func() {
+		tests := []struct {
+			name          string
+			window        request.MetricHistoryWindow
+			expectedSteps []int64
+		}{
+			{
+				name:          "StrideOfOne",
+				window:        request.MetricHistoryWindow{Stride: 1},
+				expectedSteps: []int64{0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 8, 9},
+			},
+			{
+				name:          "StrideKeepsFirstAndLastPointOfEachContext",
+				window:        request.MetricHistoryWindow{Stride: 4},
+				expectedSteps: []int64{0, 0, 4, 4, 6, 8, 9},
+			},
+			{
+				name: "StrideWithinStepWindow",
+				window: request.MetricHistoryWindow{
+					MinStep: common.GetPointer[int64](2),
+					MaxStep: common.GetPointer[int64](8),
+					Stride:  3,
+				},
+				expectedSteps: []int64{2, 2, 5, 5, 6, 8},
+			},
+		}
+		for _, tt := range tests {
+			s.Run(tt.name, func() {
+				resp := response.GetMetricHistoryResponse{}
+				s.Require().Nil(
+					s.MlflowClient().WithQuery(
+						request.GetMetricHistoryRequest{
+							RunID:               run.ID,
+							MetricKey:           "key1",
+							MetricHistoryWindow: tt.window,
+						},
+					).WithResponse(
+						&resp,
+					).DoRequest(
+						"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoryRoute,
+					),
+				)
+				steps := make([]int64, len(resp.Metrics))
+				for i, metric := range resp.Metrics {
+					steps[i] = metric.Step
+				}
+				s.Equal(tt.expectedSteps, steps)
+			})
+		}
+	})
+
+	s.Run("GetHistoriesWithContext", func() {
+		tests := []struct {
+			name          string
+			context       map[string]string
+			expectedSteps []int64
+		}{
+			{
+				name:          "TrainContext",
+				context:       map[string]string{"subset": "train"},
+				expectedSteps: []int64{0, 4, 8, 9},
+			},
+			{
+				name:          "ValidationContext",
+				context:       map[string]string{"subset": "validation"},
+				expectedSteps: []int64{0, 4, 6},
+			},
+		}
+		for _, tt := range tests {
+			s.Run(tt.name, func() {
+				resp := new(bytes.Buffer)
+				s.Require().Nil(
+					s.MlflowClient().WithMethod(
+						http.MethodPost,
+					).WithRequest(
+						request.GetMetricHistoriesRequest{
+							RunIDs:              []string{run.ID},
+							MetricKeys:          []string{"key1"},
+							Context:             tt.context,
+							MetricHistoryWindow: request.MetricHistoryWindow{Stride: 4},
+						},
+					).WithResponseType(
+						helpers.ResponseTypeBuffer,
+					).WithResponse(
+						resp,
+					).DoRequest(
+						"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoriesRoute,
+					),
+				)
+				metrics, err := helpers.DecodeArrowMetrics(resp)
+				s.Require().Nil(err)
+				steps := make([]int64, len(metrics))
+				for i, metric := range metrics {
+					steps[i] = metric.Step
+				}
+				s.Equal(tt.expectedSteps, steps)
+			})
+		}
+	})
+}
+
+func (s *GetHistoryStrideTestSuite) Test_Error() {
+	resp := api.ErrorResponse{}
+	client := s.MlflowClient().WithQuery(
+		request.GetMetricHistoryRequest{
+			RunID:               "id",
+			MetricKey:           "key1",
+			MetricHistoryWindow: request.MetricHistoryWindow{Stride: -1},
+		},
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoryRoute))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal(
+		api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'stride' supplied. It should be a positive number.",
+		).Error(),
+		resp.Error(),
+	)
+}
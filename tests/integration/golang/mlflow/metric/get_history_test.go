@@ -1,7 +1,10 @@
 package metric
 
 import (
+	"cmp"
 	"context"
+	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -9,6 +12,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
@@ -86,6 +90,192 @@ func (s *GetHistoryTestSuite) Test_Ok() {
 	}, resp)
 }
 
+func (s *GetHistoryTestSuite) Test_Pagination() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "id",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	// log metrics in descending order of steps, each step twice and under alternating contexts,
+	// so neither logging order nor iter matches the expected order of the history.
+	const numMetrics = 3000
+	metrics := make([]request.MetricPartialRequest, numMetrics)
+	for i := range metrics {
+		metrics[i] = request.MetricPartialRequest{
+			Key:       "key1",
+			Value:     float64(i),
+			Timestamp: int64(1000 + i),
+			Step:      int64((numMetrics - i) / 2),
+			Context:   map[string]any{"subset": []string{"train", "test"}[i%2]},
+		}
+	}
+	for i := 0; i < numMetrics; i += 1000 {
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.LogBatchRequest{RunID: run.ID, Metrics: metrics[i : i+1000]},
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			),
+		)
+	}
+	expected := slices.Clone(metrics)
+	slices.SortFunc(expected, func(a, b request.MetricPartialRequest) int {
+		if a.Step != b.Step {
+			return cmp.Compare(a.Step, b.Step)
+		}
+		return cmp.Compare(a.Timestamp, b.Timestamp)
+	})
+
+	var actual []response.MetricPartialResponse
+	pageToken, numPages := "", 0
+	for {
+		resp := response.GetMetricHistoryResponse{}
+		s.Require().Nil(
+			s.MlflowClient().WithQuery(
+				request.GetMetricHistoryRequest{
+					RunID:      run.ID,
+					MetricKey:  "key1",
+					PageToken:  pageToken,
+					MaxResults: 700,
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoryRoute,
+			),
+		)
+		s.Require().LessOrEqual(len(resp.Metrics), 700)
+		actual = append(actual, resp.Metrics...)
+		numPages++
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+
+		// metrics logged in the middle of pagination must not invalidate the page token.
+		if numPages == 1 {
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.LogMetricRequest{RunID: run.ID, Key: "key1", Value: -1, Timestamp: 1, Step: -1},
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogMetricRoute,
+				),
+			)
+		}
+	}
+
+	s.Equal(5, numPages)
+	s.Require().Equal(numMetrics, len(actual))
+	for i := range expected {
+		s.Equal(expected[i].Step, actual[i].Step)
+		s.Equal(expected[i].Timestamp, actual[i].Timestamp)
+		s.Equal(expected[i].Value, actual[i].Value)
+		s.Equal(expected[i].Context, actual[i].Context)
+	}
+}
+
+func (s *GetHistoryTestSuite) Test_Window() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "id",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	const numMetrics = 3000
+	metrics := make([]request.MetricPartialRequest, numMetrics)
+	for i := range metrics {
+		metrics[i] = request.MetricPartialRequest{
+			Key:       "key1",
+			Value:     float64(i),
+			Timestamp: int64(1000 + i),
+			Step:      int64(i),
+		}
+	}
+	for i := 0; i < numMetrics; i += 1000 {
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.LogBatchRequest{RunID: run.ID, Metrics: metrics[i : i+1000]},
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			),
+		)
+	}
+
+	tests := []struct {
+		name          string
+		window        request.MetricHistoryWindow
+		expectedSteps []int64
+	}{
+		{
+			name: "MidRangeStepWindow",
+			window: request.MetricHistoryWindow{
+				MinStep: common.GetPointer[int64](1500),
+				MaxStep: common.GetPointer[int64](1509),
+			},
+			expectedSteps: []int64{1500, 1501, 1502, 1503, 1504, 1505, 1506, 1507, 1508, 1509},
+		},
+		{
+			name: "MidRangeTimeWindow",
+			window: request.MetricHistoryWindow{
+				StartTime: common.GetPointer[int64](2500),
+				EndTime:   common.GetPointer[int64](2504),
+			},
+			expectedSteps: []int64{1500, 1501, 1502, 1503, 1504},
+		},
+		{
+			name: "TailStepWindow",
+			window: request.MetricHistoryWindow{
+				MinStep: common.GetPointer[int64](numMetrics - 1000),
+			},
+			expectedSteps: func() []int64 {
+				steps := make([]int64, 1000)
+				for i := range steps {
+					steps[i] = int64(numMetrics - 1000 + i)
+				}
+				return steps
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.GetMetricHistoryResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					request.GetMetricHistoryRequest{
+						RunID:               run.ID,
+						MetricKey:           "key1",
+						MetricHistoryWindow: tt.window,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoryRoute,
+				),
+			)
+			steps := make([]int64, len(resp.Metrics))
+			for i, metric := range resp.Metrics {
+				steps[i] = metric.Step
+			}
+			s.Equal(tt.expectedSteps, steps)
+		})
+	}
+}
+
 func (s *GetHistoryTestSuite) Test_Error() {
 	tests := []struct {
 		name    string
@@ -104,6 +294,15 @@ func (s *GetHistoryTestSuite) Test_Error() {
 			},
 			error: api.NewInvalidParameterValueError("Missing value for required parameter 'metric_key'"),
 		},
+		{
+			name: "NegativeMaxResults",
+			request: request.GetMetricHistoryRequest{
+				RunID:      "id",
+				MetricKey:  "key1",
+				MaxResults: -1,
+			},
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied."),
+		},
 	}
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
@@ -0,0 +1,172 @@
+package metric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ValuePrecisionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestValuePrecisionTestSuite(t *testing.T) {
+	suite.Run(t, new(ValuePrecisionTestSuite))
+}
+
+func (s *ValuePrecisionTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]byte(fmt.Sprintf(
+				`{"run_id":"%s","key":"loss","value":0.1,"timestamp":1234567890,"step":0}`, run.ID,
+			)),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogMetricRoute,
+		),
+	)
+
+	// value logged as 0.1 has to be returned as 0.1, and not as a longer approximation of it.
+	mlflowResp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetMetricHistoryRequest{
+				RunID:     run.ID,
+				MetricKey: "loss",
+			},
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			mlflowResp,
+		).DoRequest(
+			"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoryRoute,
+		),
+	)
+	s.Contains(mlflowResp.String(), `"value":0.1,`)
+
+	var history struct {
+		Metrics []struct {
+			Value float64 `json:"value"`
+		} `json:"metrics"`
+	}
+	s.Require().Nil(json.Unmarshal(mlflowResp.Bytes(), &history))
+	s.Require().Len(history.Metrics, 1)
+	s.Equal(0.1, history.Metrics[0].Value)
+
+	aimResp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]byte(fmt.Sprintf(`{"run_ids":["%s"],"metrics":[{"name":"loss"}]}`, run.ID)),
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			aimResp,
+		).DoRequest("/runs/search/metric/batch"),
+	)
+	s.Contains(aimResp.String(), `"values":[0.1]`)
+
+	var runsMetrics map[string][]struct {
+		Values []float64 `json:"values"`
+	}
+	s.Require().Nil(json.Unmarshal(aimResp.Bytes(), &runsMetrics))
+	s.Require().Len(runsMetrics[run.ID], 1)
+	s.Equal([]float64{0.1}, runsMetrics[run.ID][0].Values)
+}
+
+type ConfiguredValuePrecisionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestConfiguredValuePrecisionTestSuite(t *testing.T) {
+	testSuite := new(ConfiguredValuePrecisionTestSuite)
+	testSuite.Config = config.Config{
+		MetricValuePrecision: 3,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *ConfiguredValuePrecisionTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]byte(fmt.Sprintf(
+				`{"run_id":"%s","key":"loss","value":0.30000000000000004,"timestamp":1234567890,"step":0}`, run.ID,
+			)),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogMetricRoute,
+		),
+	)
+
+	// values are returned with configured number of significant digits by both mlflow and aim endpoints.
+	mlflowResp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetMetricHistoryRequest{
+				RunID:     run.ID,
+				MetricKey: "loss",
+			},
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			mlflowResp,
+		).DoRequest(
+			"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoryRoute,
+		),
+	)
+	s.Contains(mlflowResp.String(), `"value":0.3,`)
+
+	aimResp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]byte(fmt.Sprintf(`{"run_ids":["%s"],"metrics":[{"name":"loss"}]}`, run.ID)),
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			aimResp,
+		).DoRequest("/runs/search/metric/batch"),
+	)
+	s.Contains(aimResp.String(), `"values":[0.3]`)
+}
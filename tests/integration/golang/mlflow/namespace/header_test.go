@@ -0,0 +1,135 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type HeaderTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestHeaderTestSuite(t *testing.T) {
+	testSuite := new(HeaderTestSuite)
+	testSuite.Config = config.Config{
+		NamespaceHeader:          "X-Proxy-Namespace",
+		NamespaceResolutionOrder: []string{config.NamespaceResolverHeader, config.NamespaceResolverPath},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *HeaderTestSuite) Test_Ok() {
+	namespaces := []*models.Namespace{s.DefaultNamespace}
+	for _, code := range []string{"team1", "team2"} {
+		namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+			Code:                code,
+			DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+		})
+		s.Require().Nil(err)
+		namespaces = append(namespaces, namespace)
+	}
+	for _, namespace := range namespaces {
+		_, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           fmt.Sprintf("%s-experiment", namespace.Code),
+			NamespaceID:    namespace.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		namespace  string
+		experiment string
+	}{
+		{
+			name:       "CustomHeader",
+			headers:    map[string]string{"X-Proxy-Namespace": "team1"},
+			experiment: "team1-experiment",
+		},
+		{
+			name:       "CustomHeaderTakesPrecedenceOverPath",
+			headers:    map[string]string{"X-Proxy-Namespace": "team1"},
+			namespace:  "team2",
+			experiment: "team1-experiment",
+		},
+		{
+			name:       "DefaultHeaderIsIgnored",
+			headers:    map[string]string{config.DefaultNamespaceHeader: "team1"},
+			experiment: "default-experiment",
+		},
+		{
+			name:       "DefaultHeaderIsIgnoredAndPathIsUsed",
+			headers:    map[string]string{config.DefaultNamespaceHeader: "team1"},
+			namespace:  "team2",
+			experiment: "team2-experiment",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.GetExperimentResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithHeaders(
+					tt.headers,
+				).WithNamespace(
+					tt.namespace,
+				).WithQuery(
+					request.GetExperimentRequest{Name: tt.experiment},
+				).WithResponse(
+					&resp,
+				).DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetByNameRoute),
+			)
+			s.Equal(tt.experiment, resp.Experiment.Name)
+		})
+	}
+}
+
+type DefaultHeaderTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestDefaultHeaderTestSuite(t *testing.T) {
+	testSuite := new(DefaultHeaderTestSuite)
+	testSuite.Config = config.Config{
+		NamespaceResolutionOrder: []string{config.NamespaceResolverHeader},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *DefaultHeaderTestSuite) Test_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "team1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	_, err = s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "team1-experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	resp := response.GetExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithHeaders(
+			map[string]string{config.DefaultNamespaceHeader: "team1"},
+		).WithQuery(
+			request.GetExperimentRequest{Name: "team1-experiment"},
+		).WithResponse(
+			&resp,
+		).DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetByNameRoute),
+	)
+	s.Equal("team1-experiment", resp.Experiment.Name)
+}
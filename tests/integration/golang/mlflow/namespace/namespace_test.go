@@ -1,6 +1,7 @@
 package namespace
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -23,31 +24,77 @@ func TestNamespaceTestSuite(t *testing.T) {
 
 func (s *NamespaceTestSuite) Test_Error() {
 	tests := []struct {
-		name      string
-		error     *api.ErrorResponse
-		namespace string
+		name    string
+		method  string
+		request any
+		path    string
 	}{
 		{
-			name:      "RequestNotExistingNamespace",
-			error:     api.NewResourceDoesNotExistError("unable to find namespace with code: not-existing-namespace"),
-			namespace: "not-existing-namespace",
+			name:   "GetExperiment",
+			method: http.MethodGet,
+			path:   mlflow.ExperimentsRoutePrefix + mlflow.ExperimentsGetRoute,
+		},
+		{
+			name:    "CreateExperiment",
+			method:  http.MethodPost,
+			request: request.CreateExperimentRequest{Name: "experiment"},
+			path:    mlflow.ExperimentsRoutePrefix + mlflow.ExperimentsCreateRoute,
+		},
+		{
+			name:    "SearchExperiments",
+			method:  http.MethodPost,
+			request: request.SearchExperimentsRequest{},
+			path:    mlflow.ExperimentsRoutePrefix + mlflow.ExperimentsSearchRoute,
+		},
+		{
+			name:   "GetRun",
+			method: http.MethodGet,
+			path:   mlflow.RunsRoutePrefix + mlflow.RunsGetRoute,
+		},
+		{
+			name:    "CreateRun",
+			method:  http.MethodPost,
+			request: request.CreateRunRequest{},
+			path:    mlflow.RunsRoutePrefix + mlflow.RunsCreateRoute,
+		},
+		{
+			name:    "LogBatch",
+			method:  http.MethodPost,
+			request: request.LogBatchRequest{},
+			path:    mlflow.RunsRoutePrefix + mlflow.RunsLogBatchRoute,
+		},
+		{
+			name:   "GetMetricHistory",
+			method: http.MethodGet,
+			path:   mlflow.MetricsRoutePrefix + mlflow.MetricsGetHistoryRoute,
+		},
+		{
+			name:   "ListArtifacts",
+			method: http.MethodGet,
+			path:   mlflow.ArtifactsRoutePrefix + mlflow.ArtifactsListRoute,
 		},
 	}
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			resp := api.ErrorResponse{}
-			s.Require().Nil(
-				s.MlflowClient().WithNamespace(
-					tt.namespace,
-				).WithQuery(
-					request.GetExperimentRequest{},
-				).WithResponse(
-					&resp,
-				).DoRequest(
-					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetRoute,
-				),
+			client := s.MlflowClient().WithMethod(
+				tt.method,
+			).WithNamespace(
+				"not-existing-namespace",
+			).WithResponse(
+				&resp,
+			)
+			if tt.request != nil {
+				client = client.WithRequest(tt.request)
+			}
+			s.Require().Nil(client.DoRequest("%s", tt.path))
+			s.Equal(http.StatusNotFound, client.GetStatusCode())
+			s.Equal(
+				api.NewResourceDoesNotExistError(
+					"unable to find namespace with code: not-existing-namespace",
+				).Error(),
+				resp.Error(),
 			)
-			s.Equal(tt.error.Error(), resp.Error())
 			s.Equal(api.ErrorCodeResourceDoesNotExist, string(resp.ErrorCode))
 		})
 	}
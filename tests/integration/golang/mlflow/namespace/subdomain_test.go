@@ -0,0 +1,198 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SubdomainTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSubdomainTestSuite(t *testing.T) {
+	testSuite := new(SubdomainTestSuite)
+	testSuite.Config = config.Config{
+		NamespaceBaseDomain: "mlflow.example.com",
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *SubdomainTestSuite) Test_Ok() {
+	team1, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "team1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	team2, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "team2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	experiments := map[uint]*models.Experiment{}
+	for _, namespace := range []*models.Namespace{s.DefaultNamespace, team1, team2} {
+		experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           fmt.Sprintf("%s-experiment", namespace.Code),
+			NamespaceID:    namespace.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+		experiments[namespace.ID] = experiment
+	}
+
+	tests := []struct {
+		name      string
+		host      string
+		namespace string
+		expected  *models.Namespace
+	}{
+		{
+			name:     "Subdomain",
+			host:     "team1.mlflow.example.com",
+			expected: team1,
+		},
+		{
+			name:     "SubdomainWithPortAndUpperCase",
+			host:     "TEAM1.mlflow.example.com:5000",
+			expected: team1,
+		},
+		{
+			name:     "BaseDomainIsDefaultNamespace",
+			host:     "mlflow.example.com",
+			expected: s.DefaultNamespace,
+		},
+		{
+			name:     "NestedSubdomainIsDefaultNamespace",
+			host:     "a.team1.mlflow.example.com",
+			expected: s.DefaultNamespace,
+		},
+		{
+			name:     "OtherDomainIsDefaultNamespace",
+			host:     "team1.example.com",
+			expected: s.DefaultNamespace,
+		},
+		{
+			name:      "PathTakesPrecedenceOverSubdomain",
+			host:      "team1.mlflow.example.com",
+			namespace: "team2",
+			expected:  team2,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.GetExperimentResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithHost(
+					tt.host,
+				).WithNamespace(
+					tt.namespace,
+				).WithQuery(
+					request.GetExperimentRequest{
+						Name: fmt.Sprintf("%s-experiment", tt.expected.Code),
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetByNameRoute),
+			)
+			s.Equal(fmt.Sprintf("%d", *experiments[tt.expected.ID].ID), resp.Experiment.ID)
+		})
+	}
+}
+
+func (s *SubdomainTestSuite) Test_Error() {
+	resp := api.ErrorResponse{}
+	client := s.MlflowClient().WithHost(
+		"not-existing.mlflow.example.com",
+	).WithQuery(
+		request.GetExperimentRequest{},
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetRoute))
+	s.Equal(http.StatusNotFound, client.GetStatusCode())
+	s.Equal(
+		api.NewResourceDoesNotExistError("unable to find namespace with code: not-existing").Error(),
+		resp.Error(),
+	)
+}
+
+type SubdomainPrecedenceTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSubdomainPrecedenceTestSuite(t *testing.T) {
+	testSuite := new(SubdomainPrecedenceTestSuite)
+	testSuite.Config = config.Config{
+		NamespaceBaseDomain:      "mlflow.example.com",
+		NamespaceResolutionOrder: []string{config.NamespaceResolverSubdomain, config.NamespaceResolverPath},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *SubdomainPrecedenceTestSuite) Test_Ok() {
+	for _, code := range []string{"team1", "team2"} {
+		namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+			Code:                code,
+			DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+		})
+		s.Require().Nil(err)
+		_, err = s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           fmt.Sprintf("%s-experiment", namespace.Code),
+			NamespaceID:    namespace.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name       string
+		host       string
+		namespace  string
+		experiment string
+	}{
+		{
+			name:       "SubdomainTakesPrecedenceOverPath",
+			host:       "team1.mlflow.example.com",
+			namespace:  "team2",
+			experiment: "team1-experiment",
+		},
+		{
+			name:       "PathIsUsedForBaseDomain",
+			host:       "mlflow.example.com",
+			namespace:  "team2",
+			experiment: "team2-experiment",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.GetExperimentResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithHost(
+					tt.host,
+				).WithNamespace(
+					tt.namespace,
+				).WithQuery(
+					request.GetExperimentRequest{Name: tt.experiment},
+				).WithResponse(
+					&resp,
+				).DoRequest("%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsGetByNameRoute),
+			)
+			s.Equal(tt.experiment, resp.Experiment.Name)
+		})
+	}
+}
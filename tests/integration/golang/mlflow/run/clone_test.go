@@ -0,0 +1,238 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CloneRunTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCloneRunTestSuite(t *testing.T) {
+	suite.Run(t, new(CloneRunTestSuite))
+}
+
+func (s *CloneRunTestSuite) Test_Ok() {
+	source, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "source",
+		Name:           "source-run",
+		UserID:         "user",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		StartTime:      sql.NullInt64{Int64: 1000, Valid: true},
+		EndTime:        sql.NullInt64{Int64: 2000, Valid: true},
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	for key, value := range map[string]string{"lr": "0.01", "batch_size": "32"} {
+		_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+			Key:   key,
+			Value: value,
+			RunID: source.ID,
+		})
+		s.Require().Nil(err)
+	}
+	for key, value := range map[string]string{"mlflow.runName": "source-run", "team": "vision"} {
+		_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+			Key:   key,
+			Value: value,
+			RunID: source.ID,
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+		Key:       "loss",
+		Value:     0.5,
+		Timestamp: 1500,
+		RunID:     source.ID,
+		Iter:      1,
+	})
+	s.Require().Nil(err)
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "loss",
+		Value:     0.5,
+		Timestamp: 1500,
+		RunID:     source.ID,
+		LastIter:  1,
+	})
+	s.Require().Nil(err)
+
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "target",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: "/artifact/location",
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name         string
+		request      request.CloneRunRequest
+		experimentID int32
+		runName      string
+		status       models.Status
+	}{
+		{
+			name:         "SameExperiment",
+			request:      request.CloneRunRequest{RunID: source.ID},
+			experimentID: *s.DefaultExperiment.ID,
+			runName:      "source-run",
+			status:       models.StatusFinished,
+		},
+		{
+			name: "AnotherExperimentWithResetStatus",
+			request: request.CloneRunRequest{
+				RunID:        source.ID,
+				ExperimentID: fmt.Sprintf("%d", *experiment.ID),
+				Name:         "cloned-run",
+				ResetStatus:  true,
+			},
+			experimentID: *experiment.ID,
+			runName:      "cloned-run",
+			status:       models.StatusRunning,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.CloneRunResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsCloneRoute,
+				),
+			)
+			s.NotEqual(source.ID, resp.Run.Info.ID)
+			s.Equal(fmt.Sprintf("%d", tt.experimentID), resp.Run.Info.ExperimentID)
+			s.Equal(tt.runName, resp.Run.Info.Name)
+			s.Equal(string(tt.status), resp.Run.Info.Status)
+			s.Equal("user", resp.Run.Info.UserID)
+
+			run, err := s.RunFixtures.GetRun(context.Background(), resp.Run.Info.ID)
+			s.Require().Nil(err)
+			s.Equal(tt.experimentID, run.ExperimentID)
+			s.Equal(tt.status, run.Status)
+			if tt.request.ResetStatus {
+				s.Greater(run.StartTime.Int64, source.StartTime.Int64)
+				s.False(run.EndTime.Valid)
+			} else {
+				s.Equal(source.StartTime, run.StartTime)
+				s.Equal(source.EndTime, run.EndTime)
+			}
+
+			params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.ElementsMatch([]models.Param{
+				{Key: "lr", Value: "0.01", RunID: run.ID},
+				{Key: "batch_size", Value: "32", RunID: run.ID},
+			}, params)
+
+			tags, err := s.TagFixtures.GetByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.ElementsMatch([]models.Tag{
+				{Key: "mlflow.runName", Value: tt.runName, RunID: run.ID},
+				{Key: "team", Value: "vision", RunID: run.ID},
+			}, tags)
+
+			metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Empty(metrics)
+			_, err = s.MetricFixtures.GetLatestMetricByRunID(context.Background(), run.ID)
+			s.NotNil(err)
+		})
+	}
+}
+
+func (s *CloneRunTestSuite) Test_Error() {
+	source, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "source",
+		Name:           "source-run",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// experiment of another namespace is not accessible from the default namespace.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.CloneRunRequest
+	}{
+		{
+			name:    "MissingRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: request.CloneRunRequest{},
+		},
+		{
+			name:    "NotExistingRun",
+			error:   api.NewResourceDoesNotExistError("unable to find run 'not-existing'"),
+			request: request.CloneRunRequest{RunID: "not-existing"},
+		},
+		{
+			name: "ExperimentFromOtherNamespace",
+			error: api.NewResourceDoesNotExistError(
+				"unable to find experiment with id '%d': error getting experiment by id: %d: record not found",
+				*experiment.ID, *experiment.ID,
+			),
+			request: request.CloneRunRequest{
+				RunID:        source.ID,
+				ExperimentID: fmt.Sprintf("%d", *experiment.ID),
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsCloneRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+
+	// nothing is cloned after failures.
+	runs, err := s.RunFixtures.GetRuns(context.Background(), *s.DefaultExperiment.ID)
+	s.Require().Nil(err)
+	s.Len(runs, 1)
+}
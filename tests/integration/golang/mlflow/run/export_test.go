@@ -0,0 +1,163 @@
+package run
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ExportRunTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestExportRunTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportRunTestSuite))
+}
+
+func (s *ExportRunTestSuite) Test_Ok() {
+	// 1. create test run with param, tag and metric history.
+	runArtifactDir := s.T().TempDir()
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "TestRun",
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		ArtifactURI:    runArtifactDir,
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:   "param1",
+		Value: "value1",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+	_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+		Key:   "tag1",
+		Value: "value1",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+	for step := int64(0); step < 3; step++ {
+		_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "metric1",
+			Value:     float64(step) / 2,
+			Timestamp: 1234567890 + step,
+			Step:      step,
+			Iter:      step + 1,
+			RunID:     run.ID,
+		})
+		s.Require().Nil(err)
+	}
+
+	// 2. create artifacts.
+	s.Require().Nil(os.MkdirAll(filepath.Join(runArtifactDir, "model"), fs.ModePerm))
+	s.Require().Nil(os.WriteFile(filepath.Join(runArtifactDir, "notes.txt"), []byte("notes"), fs.ModePerm))
+	s.Require().Nil(os.WriteFile(filepath.Join(runArtifactDir, "model", "weights.bin"), []byte("weights"), fs.ModePerm))
+
+	// 3. export the run.
+	resp := new(bytes.Buffer)
+	client := s.MlflowClient().WithQuery(
+		request.ExportRunRequest{
+			RunID: run.ID,
+		},
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsExportRoute))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	s.Equal("application/zip", client.GetResponseHeaders().Get("Content-Type"))
+
+	bundle, err := zip.NewReader(bytes.NewReader(resp.Bytes()), int64(resp.Len()))
+	s.Require().Nil(err)
+	files := map[string][]byte{}
+	for _, file := range bundle.File {
+		reader, err := file.Open()
+		s.Require().Nil(err)
+		content, err := io.ReadAll(reader)
+		s.Require().Nil(err)
+		s.Require().Nil(reader.Close())
+		files[file.Name] = content
+	}
+
+	// 4. check the bundle contains run metadata, metric history and artifacts.
+	s.Require().Contains(files, "run.json")
+	metadata := response.GetRunResponse{}
+	s.Require().Nil(json.Unmarshal(files["run.json"], &metadata))
+	s.Equal(run.ID, metadata.Run.Info.ID)
+	s.Equal("TestRun", metadata.Run.Info.Name)
+	s.Equal([]response.RunParamPartialResponse{{Key: "param1", Value: "value1"}}, metadata.Run.Data.Params)
+	s.Equal([]response.RunTagPartialResponse{{Key: "tag1", Value: "value1"}}, metadata.Run.Data.Tags)
+
+	s.Require().Contains(files, "metrics.jsonl")
+	var steps []int64
+	decoder := json.NewDecoder(bytes.NewReader(files["metrics.jsonl"]))
+	for decoder.More() {
+		var line response.MetricHistoriesLineResponse
+		s.Require().Nil(decoder.Decode(&line))
+		s.Equal(run.ID, line.RunID)
+		s.Equal("metric1", line.Key)
+		s.Equal(float64(line.Step)/2, line.Value)
+		steps = append(steps, line.Step)
+	}
+	s.Equal([]int64{0, 1, 2}, steps)
+
+	s.Equal([]byte("notes"), files["artifacts/notes.txt"])
+	s.Equal([]byte("weights"), files["artifacts/model/weights.bin"])
+	s.Len(files, 4)
+}
+
+func (s *ExportRunTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.ExportRunRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "EmptyRunID",
+			request: request.ExportRunRequest{},
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+		},
+		{
+			name:    "NotFoundRun",
+			request: request.ExportRunRequest{RunID: "id"},
+			error:   api.NewResourceDoesNotExistError("unable to find run 'id'"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsExportRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
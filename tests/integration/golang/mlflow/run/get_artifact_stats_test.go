@@ -0,0 +1,126 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetArtifactStatsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetArtifactStatsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetArtifactStatsTestSuite))
+}
+
+func (s *GetArtifactStatsTestSuite) Test_Ok() {
+	// 1. create test run.
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(s.T().TempDir(), runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 2. create nested artifacts of the known size.
+	s.Require().Nil(os.MkdirAll(filepath.Join(runArtifactDir, "model", "data"), fs.ModePerm))
+	s.Require().Nil(os.MkdirAll(filepath.Join(runArtifactDir, "plots"), fs.ModePerm))
+	for path, content := range map[string]string{
+		"metrics.json":           "{}",
+		"model/MLmodel":          "flavors",
+		"model/data/config.yaml": "config",
+	} {
+		s.Require().Nil(os.WriteFile(filepath.Join(runArtifactDir, path), []byte(content), fs.ModePerm))
+	}
+
+	// 3. check that stats are returned by `GET /runs/get` endpoint only when requested.
+	getResp := response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(map[any]any{
+			"run_id":                 run.ID,
+			"include_artifact_stats": true,
+		}).WithResponse(
+			&getResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Require().NotNil(getResp.Run.Info.ArtifactSize)
+	s.Require().NotNil(getResp.Run.Info.ArtifactCount)
+	s.Equal(int64(15), *getResp.Run.Info.ArtifactSize)
+	s.Equal(int64(3), *getResp.Run.Info.ArtifactCount)
+
+	getResp = response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(map[any]any{
+			"run_id": run.ID,
+		}).WithResponse(
+			&getResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Nil(getResp.Run.Info.ArtifactSize)
+	s.Nil(getResp.Run.Info.ArtifactCount)
+
+	// 4. check that stats are returned by `POST /runs/search` endpoint only when requested.
+	searchResp := response.SearchRunsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithQuery(map[any]any{
+			"include_artifact_stats": true,
+		}).WithRequest(
+			request.SearchRunsRequest{
+				ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+			},
+		).WithResponse(
+			&searchResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+		),
+	)
+	s.Require().Len(searchResp.Runs, 1)
+	s.Require().NotNil(searchResp.Runs[0].Info.ArtifactSize)
+	s.Require().NotNil(searchResp.Runs[0].Info.ArtifactCount)
+	s.Equal(int64(15), *searchResp.Runs[0].Info.ArtifactSize)
+	s.Equal(int64(3), *searchResp.Runs[0].Info.ArtifactCount)
+
+	searchResp = response.SearchRunsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SearchRunsRequest{
+				ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+			},
+		).WithResponse(
+			&searchResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+		),
+	)
+	s.Require().Len(searchResp.Runs, 1)
+	s.Nil(searchResp.Runs[0].Info.ArtifactSize)
+	s.Nil(searchResp.Runs[0].Info.ArtifactCount)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
 
@@ -118,6 +119,113 @@ func (s *GetRunTestSuite) Test_Ok() {
 	}, resp.Run.Data.Params)
 }
 
+func (s *GetRunTestSuite) Test_Fields_Ok() {
+	// create test run with tags, metrics and params.
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:     strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:   "TestRun",
+		Status: models.StatusRunning,
+		StartTime: sql.NullInt64{
+			Int64: 1234567890,
+			Valid: true,
+		},
+		SourceType:     "JOB",
+		ArtifactURI:    "artifact_uri",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+		Key:   "tag1",
+		Value: "value1",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "metric1",
+		Value:     1.1,
+		Timestamp: 1234567890,
+		RunID:     run.ID,
+		Step:      1,
+	})
+	s.Require().Nil(err)
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:   "param1",
+		Value: "value1",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+
+	// check each combination of the fields. empty combination means that all the fields are requested.
+	sections := []commonModels.ResponseField{
+		commonModels.ResponseFieldParams,
+		commonModels.ResponseFieldMetrics,
+		commonModels.ResponseFieldTags,
+		commonModels.ResponseFieldSummary,
+	}
+	for mask := 0; mask < 1<<len(sections); mask++ {
+		var fields []string
+		for i, section := range sections {
+			if mask&(1<<i) != 0 {
+				fields = append(fields, string(section))
+			}
+		}
+		s.Run(fmt.Sprintf("Fields[%s]", strings.Join(fields, ",")), func() {
+			resp := response.GetRunResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					request.GetRunRequest{
+						RunID:  run.ID,
+						Fields: strings.Join(fields, ","),
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+				),
+			)
+
+			requested := commonModels.NewResponseFields(strings.Join(fields, ","))
+			s.Equal(run.ID, resp.Run.Info.ID)
+			s.Equal(fmt.Sprintf("%d", *s.DefaultExperiment.ID), resp.Run.Info.ExperimentID)
+			if requested.Has(commonModels.ResponseFieldSummary) {
+				s.Equal("TestRun", resp.Run.Info.Name)
+				s.Equal(string(models.StatusRunning), resp.Run.Info.Status)
+				s.Equal(int64(1234567890), resp.Run.Info.StartTime)
+				s.Equal("artifact_uri", resp.Run.Info.ArtifactURI)
+			} else {
+				s.Equal(response.RunInfoPartialResponse{
+					ID:           run.ID,
+					UUID:         run.ID,
+					ExperimentID: fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+				}, resp.Run.Info)
+			}
+			s.Equal(requested.Has(commonModels.ResponseFieldParams), resp.Run.Data.Params != nil)
+			s.Equal(requested.Has(commonModels.ResponseFieldMetrics), resp.Run.Data.Metrics != nil)
+			s.Equal(requested.Has(commonModels.ResponseFieldTags), resp.Run.Data.Tags != nil)
+
+			// summary fields are omitted from the response itself, when they haven't been requested.
+			rawResp := map[string]map[string]map[string]any{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					request.GetRunRequest{
+						RunID:  run.ID,
+						Fields: strings.Join(fields, ","),
+					},
+				).WithResponse(
+					&rawResp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+				),
+			)
+			for _, field := range []string{"run_name", "status", "start_time", "lifecycle_stage"} {
+				_, ok := rawResp["run"]["info"][field]
+				s.Equal(requested.Has(commonModels.ResponseFieldSummary), ok, field)
+			}
+		})
+	}
+}
+
 func (s *GetRunTestSuite) Test_Error() {
 	tests := []struct {
 		name    string
@@ -131,6 +239,16 @@ func (s *GetRunTestSuite) Test_Error() {
 				"Missing value for required parameter 'run_id'",
 			),
 		},
+		{
+			name: "InvalidField",
+			request: request.GetRunRequest{
+				RunID:  "id",
+				Fields: "params,unknown",
+			},
+			error: api.NewInvalidParameterValueError(
+				"invalid field 'unknown'. Valid values are ['params', 'metrics', 'tags', 'summary']",
+			),
+		},
 		{
 			name: "NotFoundRun",
 			request: request.GetRunRequest{
@@ -0,0 +1,126 @@
+package run
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchCompressedTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchCompressedTestSuite(t *testing.T) {
+	suite.Run(t, new(LogBatchCompressedTestSuite))
+}
+
+func (s *LogBatchCompressedTestSuite) Test_Ok() {
+	runs := make([]*models.Run, 2)
+	for i, runID := range []string{"plain", "gzipped"} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             runID,
+			Name:           runID,
+			ExperimentID:   *s.DefaultExperiment.ID,
+			SourceType:     "JOB",
+			LifecycleStage: models.LifecycleStageActive,
+			Status:         models.StatusRunning,
+		})
+		s.Require().Nil(err)
+		runs[i] = run
+	}
+
+	// log the same batch uncompressed to the first run and gzipped to the second one.
+	for i, run := range runs {
+		body := []byte(fmt.Sprintf(
+			`{"run_id":"%s","metrics":[{"key":"loss","value":0.1,"timestamp":1234567890,"step":1}],`+
+				`"params":[{"key":"lr","value":"0.01"}],"tags":[{"key":"team","value":"ml"}]}`,
+			run.ID,
+		))
+		headers := map[string]string{"Content-Type": "application/json"}
+		if i == 1 {
+			buffer := bytes.Buffer{}
+			writer := gzip.NewWriter(&buffer)
+			_, err := writer.Write(body)
+			s.Require().Nil(err)
+			s.Require().Nil(writer.Close())
+			body = buffer.Bytes()
+			headers["Content-Encoding"] = "gzip"
+		}
+
+		resp := map[string]any{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithHeaders(
+				headers,
+			).WithRequest(
+				body,
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			),
+		)
+		s.Equal(map[string]any{}, resp)
+	}
+
+	data := make([]response.RunDataPartialResponse, len(runs))
+	for i, run := range runs {
+		resp := response.GetRunResponse{}
+		s.Require().Nil(
+			s.MlflowClient().WithQuery(
+				request.GetRunRequest{RunID: run.ID},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+			),
+		)
+		data[i] = resp.Run.Data
+	}
+	s.Require().Len(data[1].Metrics, 1)
+	s.Equal(0.1, data[1].Metrics[0].Value)
+	s.Equal(data[0].Metrics, data[1].Metrics)
+	s.Equal(data[0].Params, data[1].Params)
+	// runs differ only by the run name tag.
+	for i := range data {
+		var tags []response.RunTagPartialResponse
+		for _, tag := range data[i].Tags {
+			if tag.Key != "mlflow.runName" {
+				tags = append(tags, tag)
+			}
+		}
+		data[i].Tags = tags
+	}
+	s.Equal([]response.RunTagPartialResponse{{Key: "team", Value: "ml"}}, data[1].Tags)
+	s.ElementsMatch(data[0].Tags, data[1].Tags)
+}
+
+func (s *LogBatchCompressedTestSuite) Test_Error() {
+	resp := api.ErrorResponse{}
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithHeaders(
+		map[string]string{"Content-Type": "application/json", "Content-Encoding": "gzip"},
+	).WithRequest(
+		[]byte(`{"run_id":"id"}`),
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal(api.ErrorCode(api.ErrorCodeBadRequest), resp.ErrorCode)
+	s.Contains(resp.Message, "unable to decompress request body")
+}
@@ -0,0 +1,252 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchLimitsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchLimitsTestSuite(t *testing.T) {
+	testSuite := new(LogBatchLimitsTestSuite)
+	testSuite.Config = config.Config{
+		RunMaxParamKeys:        2,
+		RunMaxMetricKeys:       2,
+		RunMaxTagKeys:          2,
+		NamespaceMaxParamKeys:  3,
+		NamespaceMaxMetricKeys: 3,
+		NamespaceMaxTagKeys:    3,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *LogBatchLimitsTestSuite) Test_Ok() {
+	run := s.createRun()
+
+	// run is already over the limit, e.g. it was created before the limit was configured.
+	for _, key := range []string{"key1", "key2", "key3"} {
+		_, err := s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+			RunID: run.ID,
+			Key:   key,
+			Value: "value",
+		})
+		s.Require().Nil(err)
+	}
+
+	// logging of existing keys is still possible.
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			&request.LogBatchRequest{
+				RunID: run.ID,
+				Params: []request.ParamPartialRequest{
+					{
+						Key:   "key1",
+						Value: "value",
+					},
+				},
+				Metrics: []request.MetricPartialRequest{
+					{
+						Key:       "metric1",
+						Value:     1.1,
+						Timestamp: 1234567890,
+						Step:      1,
+					},
+					{
+						Key:       "metric2",
+						Value:     1.2,
+						Timestamp: 1234567890,
+						Step:      1,
+					},
+				},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Empty(resp)
+
+	// new steps of already logged metrics don't increase cardinality.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			&request.LogBatchRequest{
+				RunID: run.ID,
+				Metrics: []request.MetricPartialRequest{
+					{
+						Key:       "metric1",
+						Value:     2.1,
+						Timestamp: 1234567891,
+						Step:      2,
+					},
+				},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Empty(resp)
+
+	// run over the limit is still readable.
+	getRunResponse := response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetRunRequest{RunID: run.ID},
+		).WithResponse(
+			&getRunResponse,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Len(getRunResponse.Run.Data.Params, 3)
+	s.Len(getRunResponse.Run.Data.Metrics, 2)
+}
+
+func (s *LogBatchLimitsTestSuite) Test_Error() {
+	otherRun := s.createRun()
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			&request.LogBatchRequest{
+				RunID: otherRun.ID,
+				Params: []request.ParamPartialRequest{
+					{Key: "other1", Value: "value"},
+					{Key: "other2", Value: "value"},
+				},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Empty(resp)
+
+	run := s.createRun()
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogBatchRequest
+	}{
+		{
+			name: "RunParamKeysLimitExceeded",
+			error: api.NewInvalidParameterValueError(
+				"too many distinct param keys for run '%s': limit is 2, 0 already logged, 3 new requested", run.ID,
+			),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Params: []request.ParamPartialRequest{
+					{Key: "key1", Value: "value"},
+					{Key: "key2", Value: "value"},
+					{Key: "key3", Value: "value"},
+				},
+			},
+		},
+		{
+			name: "RunMetricKeysLimitExceeded",
+			error: api.NewInvalidParameterValueError(
+				"too many distinct metric keys for run '%s': limit is 2, 0 already logged, 3 new requested", run.ID,
+			),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Metrics: []request.MetricPartialRequest{
+					{Key: "metric1", Value: 1.1, Timestamp: 1234567890, Step: 1},
+					{Key: "metric2", Value: 1.1, Timestamp: 1234567890, Step: 1},
+					{Key: "metric3", Value: 1.1, Timestamp: 1234567890, Step: 1},
+				},
+			},
+		},
+		{
+			name: "RunTagKeysLimitExceeded",
+			error: api.NewInvalidParameterValueError(
+				"too many distinct tag keys for run '%s': limit is 2, 0 already logged, 3 new requested", run.ID,
+			),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Tags: []request.TagPartialRequest{
+					{Key: "tag1", Value: "value"},
+					{Key: "tag2", Value: "value"},
+					{Key: "tag3", Value: "value"},
+				},
+			},
+		},
+		{
+			name: "NamespaceParamKeysLimitExceeded",
+			error: api.NewInvalidParameterValueError(
+				"too many distinct param keys for namespace 'default': limit is 3, 2 already logged, 2 new requested",
+			),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Params: []request.ParamPartialRequest{
+					{Key: "key1", Value: "value"},
+					{Key: "key2", Value: "value"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+
+			// nothing should be logged when limit is exceeded.
+			params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Empty(params)
+			tags, err := s.TagFixtures.GetByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Empty(tags)
+			metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Empty(metrics)
+		})
+	}
+}
+
+func (s *LogBatchLimitsTestSuite) createRun() *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+	return run
+}
@@ -0,0 +1,99 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchRetryTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchRetryTestSuite(t *testing.T) {
+	suite.Run(t, new(LogBatchRetryTestSuite))
+}
+
+func (s *LogBatchRetryTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	logBatch := func(metrics ...request.MetricPartialRequest) {
+		resp := fiber.Map{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.LogBatchRequest{
+					RunID:   run.ID,
+					Metrics: metrics,
+					Params:  []request.ParamPartialRequest{{Key: "lr", Value: "0.01"}},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			),
+		)
+		s.Equal(fiber.Map{}, resp)
+	}
+
+	getLastIters := func() map[float64]int64 {
+		latestMetrics, err := s.MetricFixtures.GetLatestMetricsByKey(context.Background(), "loss")
+		s.Require().Nil(err)
+		lastIters := map[float64]int64{}
+		for _, latestMetric := range latestMetrics {
+			lastIters[latestMetric.Value] = latestMetric.LastIter
+		}
+		return lastIters
+	}
+
+	// send the same batch, which repeats the point itself, several times, as retrying client does.
+	batch := []request.MetricPartialRequest{
+		{Key: "loss", Value: 0.5, Timestamp: 1234567890, Step: 1},
+		{Key: "loss", Value: 0.4, Timestamp: 1234567891, Step: 2, Context: map[string]any{"subset": "train"}},
+		{Key: "loss", Value: 0.5, Timestamp: 1234567890, Step: 1},
+	}
+	logBatch(batch...)
+	lastIters := getLastIters()
+	s.Equal(map[float64]int64{0.5: 2, 0.4: 1}, lastIters)
+
+	// retries neither store the points again nor advance iters.
+	for i := 0; i < 2; i++ {
+		logBatch(batch...)
+	}
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(metrics, 2)
+	s.Equal(lastIters, getLastIters())
+	params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(params, 1)
+
+	// only new points of the batch are stored and update the latest metrics.
+	logBatch(append(batch, request.MetricPartialRequest{Key: "loss", Value: 0.3, Timestamp: 1234567892, Step: 3})...)
+
+	metrics, err = s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(metrics, 3)
+	newLastIters := getLastIters()
+	s.Require().Len(newLastIters, 2)
+	s.Equal(lastIters[0.4], newLastIters[0.4])
+	s.Greater(newLastIters[0.3], lastIters[0.5])
+}
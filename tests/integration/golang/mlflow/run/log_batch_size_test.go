@@ -0,0 +1,102 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchSizeTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchSizeTestSuite(t *testing.T) {
+	testSuite := new(LogBatchSizeTestSuite)
+	testSuite.Config = config.Config{
+		LogBatchMaxItems: 3,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *LogBatchSizeTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	// batch of exactly maximum size is accepted.
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			&request.LogBatchRequest{
+				RunID: run.ID,
+				Params: []request.ParamPartialRequest{
+					{Key: "param1", Value: "value"},
+				},
+				Metrics: []request.MetricPartialRequest{
+					{Key: "metric1", Value: 1.1, Timestamp: 1234567890, Step: 1},
+					{Key: "metric1", Value: 1.2, Timestamp: 1234567891, Step: 2},
+				},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Empty(resp)
+
+	params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(1, len(params))
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(2, len(metrics))
+}
+
+func (s *LogBatchSizeTestSuite) Test_Error() {
+	// batch size is validated before the run is looked up, so the run doesn't need to exist.
+	resp := api.ErrorResponse{}
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		&request.LogBatchRequest{
+			RunID: "not-existing-run",
+			Params: []request.ParamPartialRequest{
+				{Key: "param1", Value: "value"},
+			},
+			Metrics: []request.MetricPartialRequest{
+				{Key: "metric1", Value: 1.1, Timestamp: 1234567890, Step: 1},
+				{Key: "metric1", Value: 1.2, Timestamp: 1234567891, Step: 2},
+			},
+			Tags: []request.TagPartialRequest{
+				{Key: "tag1", Value: "value"},
+			},
+		},
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal(api.NewInvalidParameterValueError(
+		"log-batch request contains 4 metrics, params and tags, which exceeds the maximum of 3, "+
+			"please split it into smaller batches",
+	).Error(), resp.Error())
+}
@@ -0,0 +1,120 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchThrottleTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchThrottleTestSuite(t *testing.T) {
+	// the window is long enough, so that no points leave it during the test.
+	suite.Run(t, &LogBatchThrottleTestSuite{
+		BaseTestSuite: helpers.BaseTestSuite{
+			Config: config.Config{
+				NamespaceIngestionRateLimit:  1,
+				NamespaceIngestionRateWindow: time.Minute,
+			},
+		},
+	})
+}
+
+func (s *LogBatchThrottleTestSuite) Test_Ok() {
+	run := s.createRun("run1", *s.DefaultExperiment.ID)
+
+	// metrics logged at the rate within the limit are accepted.
+	for i := 0; i < 3; i++ {
+		resp := map[string]any{}
+		client := s.logMetrics("default", run.ID, i*20, 20, &resp)
+		s.Equal(http.StatusOK, client.GetStatusCode())
+		s.Equal(map[string]any{}, resp)
+	}
+
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(metrics, 60)
+}
+
+func (s *LogBatchThrottleTestSuite) Test_Error() {
+	run := s.createRun("run1", *s.DefaultExperiment.ID)
+
+	// burst beyond the limit is rejected, once the capacity of the window is used.
+	resp := api.ErrorResponse{}
+	client := s.logMetrics("default", run.ID, 0, 50, &resp)
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	client = s.logMetrics("default", run.ID, 50, 20, &resp)
+	s.Equal(http.StatusTooManyRequests, client.GetStatusCode())
+	s.Equal(api.ErrorCode(api.ErrorCodeRequestLimitExceeded), resp.ErrorCode)
+	s.Contains(resp.Message, "namespace 'default' exceeded metrics ingestion rate limit of 1 points per second")
+
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(metrics, 50)
+
+	// other namespaces are throttled independently.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	customRun := s.createRun("run2", *experiment.ID)
+	customResp := map[string]any{}
+	client = s.logMetrics("custom", customRun.ID, 0, 20, &customResp)
+	s.Equal(http.StatusOK, client.GetStatusCode())
+}
+
+func (s *LogBatchThrottleTestSuite) createRun(id string, experimentID int32) *models.Run {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             id,
+		Name:           id,
+		ExperimentID:   experimentID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+	return run
+}
+
+// logMetrics logs batch of count metric points of the run in the namespace, starting at the step.
+func (s *LogBatchThrottleTestSuite) logMetrics(
+	namespace, runID string, step, count int, resp any,
+) *helpers.HttpClient {
+	metrics := make([]request.MetricPartialRequest, count)
+	for i := range metrics {
+		metrics[i] = request.MetricPartialRequest{
+			Key: "loss", Value: 0.1, Timestamp: 1234567890, Step: int64(step + i),
+		}
+	}
+	client := s.MlflowClient().WithNamespace(
+		namespace,
+	).WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.LogBatchRequest{RunID: runID, Metrics: metrics},
+	).WithResponse(
+		resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute))
+	return client
+}
@@ -0,0 +1,204 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchValidateOnlyTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchValidateOnlyTestSuite(t *testing.T) {
+	testSuite := new(LogBatchValidateOnlyTestSuite)
+	testSuite.Config = config.Config{
+		MetricTimestampMaxSkew: time.Hour,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *LogBatchValidateOnlyTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:   "key1",
+		Value: "value1",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			&request.LogBatchRequest{
+				RunID: run.ID,
+				Tags: []request.TagPartialRequest{
+					{Key: "mlflow.runName", Value: "new-name"},
+				},
+				Params: []request.ParamPartialRequest{
+					{Key: "key1", Value: "value1"},
+					{Key: "key2", Value: "value2"},
+				},
+				Metrics: []request.MetricPartialRequest{
+					{Key: "key3", Value: 1.1, Timestamp: time.Now().UnixMilli(), Step: 1},
+				},
+				ValidateOnly: true,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Empty(resp)
+
+	// nothing should be written in dry-run mode.
+	params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal([]models.Param{{Key: "key1", Value: "value1", RunID: run.ID}}, params)
+	tags, err := s.TagFixtures.GetByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(tags)
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(metrics)
+	latestMetric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), run.ID)
+	s.Require().NotNil(err)
+	s.Nil(latestMetric)
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(run.Name)
+}
+
+func (s *LogBatchValidateOnlyTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:   "key1",
+		Value: "value1",
+		RunID: run.ID,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogBatchRequest
+	}{
+		{
+			name: "ConflictWithStoredParam",
+			error: api.NewInvalidParameterValueError(
+				`unable to insert params for run '%s': conflicting params found: `+
+					`[{run_id: %s, key: key1, old_value: value1, new_value: value2}]`,
+				run.ID, run.ID,
+			),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Params: []request.ParamPartialRequest{
+					{Key: "key1", Value: "value2"},
+				},
+				ValidateOnly: true,
+			},
+		},
+		{
+			name: "ConflictInsideBatch",
+			error: api.NewInvalidParameterValueError(
+				`unable to insert params for run '%s': conflicting params found: `+
+					`[{run_id: %s, key: key2, old_value: value1, new_value: value2}]`,
+				run.ID, run.ID,
+			),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Params: []request.ParamPartialRequest{
+					{Key: "key1", Value: "value1"},
+					{Key: "key2", Value: "value1"},
+					{Key: "key2", Value: "value2"},
+				},
+				ValidateOnly: true,
+			},
+		},
+		{
+			name: "TimestampOutOfSkew",
+			error: api.NewInvalidParameterValueError(
+				"timestamp 1234567890 of metric 'key3' for run '%s' is out of allowed clock skew 1h0m0s from server time",
+				run.ID,
+			),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Metrics: []request.MetricPartialRequest{
+					{Key: "key3", Value: 1.1, Timestamp: 1234567890, Step: 1},
+				},
+				ValidateOnly: true,
+			},
+		},
+		{
+			name:  "MissingParamKey",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'params' supplied"),
+			request: &request.LogBatchRequest{
+				RunID: run.ID,
+				Params: []request.ParamPartialRequest{
+					{Value: "value1"},
+				},
+				ValidateOnly: true,
+			},
+		},
+		{
+			name:  "NotFoundRun",
+			error: api.NewResourceDoesNotExistError(fmt.Sprintf("Run '%s' not found", "not-existing-id")),
+			request: &request.LogBatchRequest{
+				RunID:        "not-existing-id",
+				ValidateOnly: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+
+			// stored params should stay untouched.
+			params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Equal([]models.Param{{Key: "key1", Value: "value1", RunID: run.ID}}, params)
+		})
+	}
+}
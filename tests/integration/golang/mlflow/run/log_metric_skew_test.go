@@ -0,0 +1,151 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogMetricSkewRejectTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogMetricSkewRejectTestSuite(t *testing.T) {
+	testSuite := new(LogMetricSkewRejectTestSuite)
+	testSuite.Config = config.Config{
+		MetricTimestampMaxSkew: time.Hour,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *LogMetricSkewRejectTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name      string
+		timestamp int64
+	}{
+		{
+			name:      "FutureSkew",
+			timestamp: time.Now().Add(24 * time.Hour).UnixMilli(),
+		},
+		{
+			name:      "PastSkew",
+			timestamp: 1234567890,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					&request.LogBatchRequest{
+						RunID: run.ID,
+						Metrics: []request.MetricPartialRequest{
+							{Key: "key1", Value: 1.1, Timestamp: time.Now().UnixMilli(), Step: 1},
+							{Key: "key2", Value: 1.1, Timestamp: tt.timestamp, Step: 1},
+						},
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+				),
+			)
+			s.Equal(api.ErrorCodeInvalidParameterValue, string(resp.ErrorCode))
+			s.Contains(resp.Message, "of metric 'key2' for run '"+run.ID+"' is out of allowed clock skew")
+
+			metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Empty(metrics)
+		})
+	}
+}
+
+type LogMetricSkewClampTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogMetricSkewClampTestSuite(t *testing.T) {
+	testSuite := new(LogMetricSkewClampTestSuite)
+	testSuite.Config = config.Config{
+		MetricTimestampMaxSkew:    time.Hour,
+		MetricTimestampSkewPolicy: config.MetricTimestampSkewPolicyClamp,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *LogMetricSkewClampTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		offset    time.Duration
+	}{
+		{
+			name:      "FutureSkew",
+			timestamp: time.Now().Add(24 * time.Hour).UnixMilli(),
+			offset:    time.Hour,
+		},
+		{
+			name:      "PastSkew",
+			timestamp: 1234567890,
+			offset:    -time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			before := time.Now()
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					&request.LogMetricRequest{
+						RunID:     run.ID,
+						Key:       tt.name,
+						Value:     1.1,
+						Timestamp: tt.timestamp,
+						Step:      1,
+					},
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogMetricRoute,
+				),
+			)
+			after := time.Now()
+
+			metric, err := s.MetricFixtures.GetLatestMetricByKey(context.Background(), tt.name)
+			s.Require().Nil(err)
+			s.GreaterOrEqual(metric.Timestamp, before.Add(tt.offset).UnixMilli())
+			s.LessOrEqual(metric.Timestamp, after.Add(tt.offset).UnixMilli())
+		})
+	}
+}
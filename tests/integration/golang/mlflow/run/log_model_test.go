@@ -0,0 +1,179 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogModelTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogModelTestSuite(t *testing.T) {
+	suite.Run(t, new(LogModelTestSuite))
+}
+
+func (s *LogModelTestSuite) Test_Ok() {
+	// create test run.
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:     strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:   "TestRun",
+		Status: models.StatusRunning,
+		StartTime: sql.NullInt64{
+			Int64: 1234567890,
+			Valid: true,
+		},
+		SourceType:     "JOB",
+		ArtifactURI:    "artifact_uri",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// log two models, the same way MLflow client does it.
+	for _, modelJSON := range []string{
+		`{"run_id":"` + run.ID + `","artifact_path":"model","utc_time_created":"2024-01-01 00:00:00.000000",` +
+			`"model_uuid":"uuid1","flavors":{"python_function":{"loader_module":"mlflow.sklearn",` +
+			`"python_version":"3.10.0"},"sklearn":{"sklearn_version":"1.3.0","serialization_format":"cloudpickle"}},` +
+			`"signature":{"inputs":"[{\"type\": \"double\"}]","outputs":"[{\"type\": \"long\"}]"}}`,
+		`{"artifact_path":"other-model","utc_time_created":"2024-01-02 00:00:00.000000",` +
+			`"flavors":{"pytorch":{"pytorch_version":"2.1.0"}}}`,
+	} {
+		resp := fiber.Map{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.LogModelRequest{
+					RunID:     run.ID,
+					ModelJSON: modelJSON,
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogModelRoute,
+			),
+		)
+		s.Equal(fiber.Map{}, resp)
+	}
+
+	// make sure that flavors metadata of the logged models is retrievable.
+	resp := response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetRunRequest{
+				RunID: run.ID,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Equal([]response.RunModelPartialResponse{
+		{
+			ArtifactPath:   "model",
+			ModelURI:       "runs:/" + run.ID + "/model",
+			ModelUUID:      "uuid1",
+			UTCTimeCreated: "2024-01-01 00:00:00.000000",
+			Flavors: map[string]any{
+				"python_function": map[string]any{
+					"loader_module":  "mlflow.sklearn",
+					"python_version": "3.10.0",
+				},
+				"sklearn": map[string]any{
+					"sklearn_version":      "1.3.0",
+					"serialization_format": "cloudpickle",
+				},
+			},
+			Signature: map[string]any{
+				"inputs":  `[{"type": "double"}]`,
+				"outputs": `[{"type": "long"}]`,
+			},
+		},
+		{
+			ArtifactPath:   "other-model",
+			ModelURI:       "runs:/" + run.ID + "/other-model",
+			UTCTimeCreated: "2024-01-02 00:00:00.000000",
+			Flavors: map[string]any{
+				"pytorch": map[string]any{
+					"pytorch_version": "2.1.0",
+				},
+			},
+		},
+	}, resp.Run.Data.Models)
+
+	// history of the logged models is kept in the system tag, as MLflow does.
+	tags, err := s.TagFixtures.GetByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Require().Len(tags, 1)
+	s.Equal(models.TagKeyLogModelHistory, tags[0].Key)
+}
+
+func (s *LogModelTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.LogModelRequest
+	}{
+		{
+			name:    "EmptyOrIncorrectRunID",
+			request: request.LogModelRequest{},
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+		},
+		{
+			name: "EmptyOrIncorrectModelJSON",
+			request: request.LogModelRequest{
+				RunID: "id",
+			},
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'model_json'"),
+		},
+		{
+			name: "MissingFlavors",
+			request: request.LogModelRequest{
+				RunID:     "id",
+				ModelJSON: `{"artifact_path":"model"}`,
+			},
+			error: api.NewInvalidParameterValueError("Model json is missing mandatory field 'flavors'"),
+		},
+		{
+			name: "NotFoundRun",
+			request: request.LogModelRequest{
+				RunID:     "id",
+				ModelJSON: `{"artifact_path":"model","flavors":{"sklearn":{}}}`,
+			},
+			error: api.NewResourceDoesNotExistError("Run 'id' not found"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogModelRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogTerminatedRunAllowTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogTerminatedRunAllowTestSuite(t *testing.T) {
+	suite.Run(t, new(LogTerminatedRunAllowTestSuite))
+}
+
+func (s *LogTerminatedRunAllowTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusFinished,
+	})
+	s.Require().Nil(err)
+
+	// by default, metrics and params are logged to the finished run as usual.
+	for route, req := range map[string]any{
+		mlflow.RunsLogMetricRoute: request.LogMetricRequest{
+			RunID:     run.ID,
+			Key:       "loss",
+			Value:     0.5,
+			Timestamp: time.Now().UnixMilli(),
+		},
+		mlflow.RunsLogParameterRoute: request.LogParamRequest{
+			RunID: run.ID,
+			Key:   "lr",
+			Value: "0.01",
+		},
+	} {
+		resp := fiber.Map{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				req,
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, route,
+			),
+		)
+		s.Equal(fiber.Map{}, resp)
+	}
+
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(metrics, 1)
+	params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(params, 1)
+}
+
+type LogTerminatedRunRejectTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogTerminatedRunRejectTestSuite(t *testing.T) {
+	testSuite := new(LogTerminatedRunRejectTestSuite)
+	testSuite.Config = config.Config{
+		TerminatedRunLogPolicy: config.TerminatedRunLogPolicyReject,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *LogTerminatedRunRejectTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	// running run accepts logging as usual.
+	resp := fiber.Map{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogMetricRequest{
+				RunID:     run.ID,
+				Key:       "loss",
+				Value:     0.5,
+				Timestamp: time.Now().UnixMilli(),
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogMetricRoute,
+		),
+	)
+	s.Equal(fiber.Map{}, resp)
+
+	// finished run still accepts batches with tags only.
+	finishedRun, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusFinished,
+	})
+	s.Require().Nil(err)
+	resp = fiber.Map{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogBatchRequest{
+				RunID: finishedRun.ID,
+				Tags: []request.TagPartialRequest{
+					{Key: "note", Value: "reviewed"},
+				},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Equal(fiber.Map{}, resp)
+}
+
+func (s *LogTerminatedRunRejectTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusFinished,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		route   string
+		request any
+	}{
+		{
+			name:  "LogMetric",
+			route: mlflow.RunsLogMetricRoute,
+			request: request.LogMetricRequest{
+				RunID:     run.ID,
+				Key:       "loss",
+				Value:     0.5,
+				Timestamp: time.Now().UnixMilli(),
+			},
+		},
+		{
+			name:  "LogParam",
+			route: mlflow.RunsLogParameterRoute,
+			request: request.LogParamRequest{
+				RunID: run.ID,
+				Key:   "lr",
+				Value: "0.01",
+			},
+		},
+		{
+			name:  "LogBatch",
+			route: mlflow.RunsLogBatchRoute,
+			request: request.LogBatchRequest{
+				RunID: run.ID,
+				Metrics: []request.MetricPartialRequest{
+					{Key: "loss", Value: 0.5, Timestamp: time.Now().UnixMilli()},
+				},
+				Params: []request.ParamPartialRequest{
+					{Key: "lr", Value: "0.01"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				tt.request,
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, tt.route))
+			s.Equal(http.StatusBadRequest, client.GetStatusCode())
+			s.Equal(api.NewInvalidParameterValueError(
+				"run '%s' is already in terminal status 'FINISHED' and doesn't accept logging", run.ID,
+			).Error(), resp.Error())
+		})
+	}
+
+	// nothing is logged to the finished run.
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(metrics)
+	params, err := s.ParamFixtures.GetParamsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Empty(params)
+}
@@ -0,0 +1,157 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RunNameLimitsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRunNameLimitsTestSuite(t *testing.T) {
+	testSuite := new(RunNameLimitsTestSuite)
+	testSuite.Config = config.Config{
+		MaxRunNameLength: 10,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *RunNameLimitsTestSuite) Test_Ok() {
+	// run, which name is already longer than the limit, stays readable and updatable.
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "very-long-existing-name",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	getResp := response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetRunRequest{RunID: run.ID},
+		).WithResponse(
+			&getResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Equal("very-long-existing-name", getResp.Run.Info.Name)
+
+	updateResp := response.UpdateRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.UpdateRunRequest{RunID: run.ID, Status: string(models.StatusFinished)},
+		).WithResponse(
+			&updateResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsUpdateRoute,
+		),
+	)
+	s.Equal(string(models.StatusFinished), updateResp.RunInfo.Status)
+
+	getResp = response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetRunRequest{RunID: run.ID},
+		).WithResponse(
+			&getResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.Equal("very-long-existing-name", getResp.Run.Info.Name)
+}
+
+func (s *RunNameLimitsTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "run",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	overLongNameError := api.NewInvalidParameterValueError(
+		"run name is 11 characters long, which exceeds the maximum length of 10 characters",
+	)
+	tests := []struct {
+		name    string
+		route   string
+		request any
+		error   *api.ErrorResponse
+	}{
+		{
+			name:  "CreateRunWithOverLongName",
+			route: mlflow.RunsCreateRoute,
+			request: request.CreateRunRequest{
+				ExperimentID: "0",
+				Name:         "eleven-char",
+			},
+			error: overLongNameError,
+		},
+		{
+			name:  "CreateRunWithOverLongRunNameTag",
+			route: mlflow.RunsCreateRoute,
+			request: request.CreateRunRequest{
+				ExperimentID: "0",
+				Tags:         []request.RunTagPartialRequest{{Key: "mlflow.runName", Value: "eleven-char"}},
+			},
+			error: overLongNameError,
+		},
+		{
+			name:  "UpdateRunWithControlCharacters",
+			route: mlflow.RunsUpdateRoute,
+			request: request.UpdateRunRequest{
+				RunID: run.ID,
+				Name:  "run\tname",
+			},
+			error: api.NewInvalidParameterValueError("run name should not contain control characters"),
+		},
+		{
+			name:  "SetRunNameTagWithOverLongName",
+			route: mlflow.RunsSetTagRoute,
+			request: request.SetRunTagRequest{
+				RunID: run.ID,
+				Key:   "mlflow.runName",
+				Value: "eleven-char",
+			},
+			error: overLongNameError,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				tt.request,
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, tt.route))
+			s.Equal(http.StatusBadRequest, client.GetStatusCode())
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RetentionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRetentionTestSuite(t *testing.T) {
+	testSuite := new(RetentionTestSuite)
+	testSuite.Config = config.Config{
+		RetentionInterval: 50 * time.Millisecond,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *RetentionTestSuite) Test_Ok() {
+	// 1. create namespace without retention policy and its old run, which has to be kept.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "other",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	now := time.Now().UTC()
+	createRun := func(experimentID int32, status models.Status, startTime time.Time, loss *float64) *models.Run {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+			Name:           "TestRun",
+			Status:         status,
+			StartTime:      sql.NullInt64{Int64: startTime.UnixMilli(), Valid: true},
+			SourceType:     "JOB",
+			ExperimentID:   experimentID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+		if loss != nil {
+			_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+				Key:       "loss",
+				Value:     *loss,
+				Timestamp: startTime.UnixMilli(),
+				RunID:     run.ID,
+			})
+			s.Require().Nil(err)
+		}
+		return run
+	}
+	otherNamespaceRun := createRun(*experiment.ID, models.StatusFinished, now.AddDate(0, 0, -10), nil)
+
+	// 2. create runs of the default namespace, which are archived by age and by metric, and which are kept.
+	expiredRun := createRun(*s.DefaultExperiment.ID, models.StatusFinished, now.AddDate(0, 0, -10), nil)
+	bestRun := createRun(*s.DefaultExperiment.ID, models.StatusFinished, now.AddDate(0, 0, -1), common.GetPointer(0.1))
+	secondRun := createRun(*s.DefaultExperiment.ID, models.StatusFinished, now.AddDate(0, 0, -1), common.GetPointer(0.2))
+	worstRun := createRun(*s.DefaultExperiment.ID, models.StatusFinished, now.AddDate(0, 0, -1), common.GetPointer(0.3))
+	runningRun := createRun(*s.DefaultExperiment.ID, models.StatusRunning, now.AddDate(0, 0, -1), common.GetPointer(0.4))
+	// runs, which are still in progress, are kept however long ago they have been started.
+	expiredRunningRun := createRun(*s.DefaultExperiment.ID, models.StatusRunning, now.AddDate(0, 0, -10), nil)
+
+	// 3. set retention policy of the default namespace once all the runs exist,
+	// so the scheduler of the server applies it to all of them at once.
+	s.DefaultNamespace.RetentionPolicy = models.RetentionPolicy{
+		MaxAgeDays:      common.GetPointer(int32(7)),
+		KeepBest:        common.GetPointer(int32(2)),
+		MetricKey:       "loss",
+		MetricDirection: models.MetricDirectionMin,
+	}
+	_, err = s.NamespaceFixtures.UpdateNamespace(context.Background(), s.DefaultNamespace)
+	s.Require().Nil(err)
+
+	// 4. wait for the retention pass and check which runs have been archived and which have been kept.
+	lifecycleStages := func() (map[string]models.LifecycleStage, error) {
+		stages := map[string]models.LifecycleStage{}
+		for _, run := range []*models.Run{
+			otherNamespaceRun, expiredRun, bestRun, secondRun, worstRun, runningRun, expiredRunningRun,
+		} {
+			run, err := s.RunFixtures.GetRun(context.Background(), run.ID)
+			if err != nil {
+				return nil, err
+			}
+			stages[run.ID] = run.LifecycleStage
+		}
+		return stages, nil
+	}
+	expectedLifecycleStages := map[string]models.LifecycleStage{
+		otherNamespaceRun.ID: models.LifecycleStageActive,
+		expiredRun.ID:        models.LifecycleStageDeleted,
+		bestRun.ID:           models.LifecycleStageActive,
+		secondRun.ID:         models.LifecycleStageActive,
+		worstRun.ID:          models.LifecycleStageDeleted,
+		runningRun.ID:        models.LifecycleStageActive,
+		expiredRunningRun.ID: models.LifecycleStageActive,
+	}
+	s.Eventually(func() bool {
+		stages, err := lifecycleStages()
+		return err == nil && maps.Equal(expectedLifecycleStages, stages)
+	}, 5*time.Second, 50*time.Millisecond)
+	stages, err := lifecycleStages()
+	s.Require().Nil(err)
+	s.Equal(expectedLifecycleStages, stages)
+}
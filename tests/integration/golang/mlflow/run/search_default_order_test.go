@@ -0,0 +1,136 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchDefaultOrderTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchDefaultOrderTestSuite(t *testing.T) {
+	testSuite := new(SearchDefaultOrderTestSuite)
+	testSuite.Config = config.Config{
+		RunSearchDefaultOrderBy:   "metrics.accuracy DESC",
+		NamespaceRunSearchOrderBy: []string{"custom:attributes.start_time ASC"},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *SearchDefaultOrderTestSuite) Test_Ok() {
+	// runs of the default namespace are sorted by the metric, which doesn't follow their start time.
+	for i, accuracy := range []float64{0.5, 0.9, 0.7} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", i+1),
+			Name:           fmt.Sprintf("TestRun%d", i+1),
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: int64(i + 1), Valid: true},
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+		_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+			Key:       "accuracy",
+			Value:     accuracy,
+			Timestamp: 1,
+			RunID:     run.ID,
+		})
+		s.Require().Nil(err)
+	}
+
+	// runs of the custom namespace are sorted by start time in ascending order, which is set for the namespace.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	for i, startTime := range []int64{3, 1, 2} {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("custom%d", i+1),
+			Name:           fmt.Sprintf("CustomRun%d", i+1),
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: startTime, Valid: true},
+			ExperimentID:   *experiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name        string
+		namespace   string
+		request     request.SearchRunsRequest
+		expectedIDs []string
+	}{
+		{
+			name:      "DefaultByMetric",
+			namespace: "",
+			request: request.SearchRunsRequest{
+				ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+			},
+			expectedIDs: []string{"id2", "id3", "id1"},
+		},
+		{
+			name:      "DefaultByTimeForNamespace",
+			namespace: "custom",
+			request: request.SearchRunsRequest{
+				ExperimentIDs: []string{fmt.Sprintf("%d", *experiment.ID)},
+			},
+			expectedIDs: []string{"custom2", "custom3", "custom1"},
+		},
+		{
+			name:      "RequestedOrderOverridesDefault",
+			namespace: "",
+			request: request.SearchRunsRequest{
+				ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+				OrderBy:       []string{"attributes.start_time DESC"},
+			},
+			expectedIDs: []string{"id3", "id2", "id1"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchRunsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					tt.namespace,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			ids := make([]string, len(resp.Runs))
+			for i, run := range resp.Runs {
+				ids[i] = run.Info.ID
+			}
+			s.Equal(tt.expectedIDs, ids)
+		})
+	}
+}
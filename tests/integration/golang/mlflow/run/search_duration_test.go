@@ -0,0 +1,115 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchDurationTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchDurationTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchDurationTestSuite))
+}
+
+func (s *SearchDurationTestSuite) Test_Ok() {
+	now := time.Now().UnixMilli()
+	for _, run := range []struct {
+		id        string
+		status    models.Status
+		startTime int64
+		endTime   sql.NullInt64
+	}{
+		// finished runs, lasting 10 seconds, 2 minutes and 1 hour.
+		{id: "short", status: models.StatusFinished, startTime: now - 600000, endTime: sql.NullInt64{
+			Int64: now - 590000, Valid: true,
+		}},
+		{id: "medium", status: models.StatusFinished, startTime: now - 600000, endTime: sql.NullInt64{
+			Int64: now - 480000, Valid: true,
+		}},
+		{id: "long", status: models.StatusFailed, startTime: now - 7200000, endTime: sql.NullInt64{
+			Int64: now - 3600000, Valid: true,
+		}},
+		// running runs last up to now, so they are 5 minutes and 5 seconds long.
+		{id: "running", status: models.StatusRunning, startTime: now - 300000},
+		{id: "started", status: models.StatusRunning, startTime: now - 5000},
+	} {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             run.id,
+			Name:           run.id,
+			Status:         run.status,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: run.startTime, Valid: true},
+			EndTime:        run.endTime,
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name        string
+		request     request.SearchRunsRequest
+		expectedIDs []string
+	}{
+		{
+			name: "MinimumDuration",
+			request: request.SearchRunsRequest{
+				Filter:  "attributes.duration >= 60000",
+				OrderBy: []string{"attributes.duration"},
+			},
+			expectedIDs: []string{"medium", "running", "long"},
+		},
+		{
+			name: "MinimumDurationOfRunningRuns",
+			request: request.SearchRunsRequest{
+				Filter:  "attributes.duration > 60000 AND attributes.status = 'RUNNING'",
+				OrderBy: []string{"attributes.duration"},
+			},
+			expectedIDs: []string{"running"},
+		},
+		{
+			name: "DurationRange",
+			request: request.SearchRunsRequest{
+				Filter:  "attributes.duration > 1000 AND attributes.duration < 200000",
+				OrderBy: []string{"attributes.duration DESC"},
+			},
+			expectedIDs: []string{"medium", "short", "started"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.request.ExperimentIDs = []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)}
+			resp := response.SearchRunsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			ids := make([]string, len(resp.Runs))
+			for i, run := range resp.Runs {
+				ids[i] = run.Info.ID
+			}
+			s.Equal(tt.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchMultipleExperimentsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchMultipleExperimentsTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchMultipleExperimentsTestSuite))
+}
+
+func (s *SearchMultipleExperimentsTestSuite) Test_Ok() {
+	// create second experiment and runs of both experiments with interleaving start times.
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	runExperimentIDs := []int32{*s.DefaultExperiment.ID, *experiment.ID, *s.DefaultExperiment.ID, *experiment.ID}
+	for i, experimentID := range runExperimentIDs {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", i+1),
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: int64(i + 1), Valid: true},
+			ExperimentID:   experimentID,
+			ArtifactURI:    "artifact_uri",
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	experimentIDs := []string{
+		fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+		fmt.Sprintf("%d", *experiment.ID),
+	}
+	tests := []struct {
+		name    string
+		orderBy []string
+		pages   [][]string
+	}{
+		{
+			name:  "DefaultOrderByStartTime",
+			pages: [][]string{{"id4", "id3"}, {"id2", "id1"}},
+		},
+		{
+			name:    "OrderByExperimentID",
+			orderBy: []string{"attributes.experiment_id ASC"},
+			pages:   [][]string{{"id3", "id1"}, {"id4", "id2"}},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var pageToken string
+			for _, page := range tt.pages {
+				resp := response.SearchRunsResponse{}
+				s.Require().Nil(
+					s.MlflowClient().WithMethod(
+						http.MethodPost,
+					).WithRequest(
+						request.SearchRunsRequest{
+							ExperimentIDs: experimentIDs,
+							OrderBy:       tt.orderBy,
+							MaxResults:    2,
+							PageToken:     pageToken,
+						},
+					).WithResponse(
+						&resp,
+					).DoRequest(
+						"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+					),
+				)
+				ids := make([]string, 0, len(resp.Runs))
+				for _, run := range resp.Runs {
+					ids = append(ids, run.Info.ID)
+				}
+				s.Equal(page, ids)
+				pageToken = resp.NextPageToken
+			}
+		})
+	}
+}
+
+func (s *SearchMultipleExperimentsTestSuite) Test_Error() {
+	// create experiment in the other namespace, which is inaccessible from the default namespace.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name          string
+		error         *api.ErrorResponse
+		experimentIDs []string
+	}{
+		{
+			name: "ExperimentFromOtherNamespace",
+			error: api.NewResourceDoesNotExistError(
+				"unable to find experiment with id '%d'", *experiment.ID,
+			),
+			experimentIDs: []string{
+				fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+				fmt.Sprintf("%d", *experiment.ID),
+			},
+		},
+		{
+			name:  "NotExistingExperiment",
+			error: api.NewResourceDoesNotExistError("unable to find experiment with id '1000'"),
+			experimentIDs: []string{
+				fmt.Sprintf("%d", *s.DefaultExperiment.ID),
+				"1000",
+			},
+		},
+		{
+			name:          "IncorrectExperimentID",
+			error:         api.NewInvalidParameterValueError("unable to parse experiment id 'incorrect'"),
+			experimentIDs: []string{"incorrect"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.SearchRunsRequest{
+						ExperimentIDs: tt.experimentIDs,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchPageSizeTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchPageSizeTestSuite(t *testing.T) {
+	testSuite := new(SearchPageSizeTestSuite)
+	testSuite.Config = config.Config{
+		MaxPageSize: 2,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *SearchPageSizeTestSuite) Test_Ok() {
+	for i := 0; i < 5; i++ {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", i+1),
+			Name:           fmt.Sprintf("TestRun%d", i+1),
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: int64(i + 1), Valid: true},
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name       string
+		maxResults int32
+		pageSize   string
+		pages      [][]string
+	}{
+		{
+			name:       "OverLimitIsClamped",
+			maxResults: 100,
+			pageSize:   "2",
+			pages:      [][]string{{"id5", "id4"}, {"id3", "id2"}, {"id1"}},
+		},
+		{
+			name:       "NotSetIsClamped",
+			maxResults: 0,
+			pageSize:   "2",
+			pages:      [][]string{{"id5", "id4"}, {"id3", "id2"}, {"id1"}},
+		},
+		{
+			name:       "WithinLimitIsKept",
+			maxResults: 1,
+			pageSize:   "1",
+			pages:      [][]string{{"id5"}, {"id4"}, {"id3"}, {"id2"}, {"id1"}, {}},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// walk through all the pages using returned page tokens.
+			pageToken := ""
+			for _, page := range tt.pages {
+				resp := response.SearchRunsResponse{}
+				client := s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.SearchRunsRequest{
+						ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+						MaxResults:    tt.maxResults,
+						PageToken:     pageToken,
+					},
+				).WithResponse(
+					&resp,
+				)
+				s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute))
+				s.Equal(tt.pageSize, client.GetResponseHeaders().Get(api.PageSizeHeader))
+
+				runIDs := make([]string, 0, len(resp.Runs))
+				for _, run := range resp.Runs {
+					runIDs = append(runIDs, run.Info.ID)
+				}
+				s.Equal(page, runIDs)
+				pageToken = resp.NextPageToken
+			}
+			s.Empty(pageToken)
+		})
+	}
+}
@@ -77,6 +77,283 @@ func (s *SearchTestSuite) Test_CustomNamespaceExperimentZero_Ok() {
 	s.testCases(namespace, experiment, true, int32(0))
 }
 
+func (s *SearchTestSuite) Test_FilterAndOrder_Ok() {
+	// create 3 test runs with different params, tags and metrics.
+	for i, optimizer := range []string{"adam", "sgd", "rmsprop"} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", i+1),
+			Name:           fmt.Sprintf("TestRun%d", i+1),
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: int64(i + 1), Valid: true},
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+		_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+			Key:   "optimizer",
+			Value: optimizer,
+			RunID: run.ID,
+		})
+		s.Require().Nil(err)
+		_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+			Key:   "env and stage",
+			Value: fmt.Sprintf("prod and stage%d", i+1),
+			RunID: run.ID,
+		})
+		s.Require().Nil(err)
+		_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+			Key:   "accuracy",
+			Value: []float64{0.7, 0.9, 0.5}[i],
+			RunID: run.ID,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name    string
+		request request.SearchRunsRequest
+		runIDs  []string
+	}{
+		{
+			name: "MetricAndParam",
+			request: request.SearchRunsRequest{
+				Filter:  `metrics.accuracy > 0.6 and params.optimizer = "sgd"`,
+				OrderBy: []string{"attributes.start_time"},
+			},
+			runIDs: []string{"id2"},
+		},
+		{
+			name: "ParamInListOrderedByMetric",
+			request: request.SearchRunsRequest{
+				Filter:  `params.optimizer IN ('adam', 'rmsprop', 'unknown')`,
+				OrderBy: []string{"metrics.accuracy DESC"},
+			},
+			runIDs: []string{"id1", "id3"},
+		},
+		{
+			name: "ParamNotInListOrderedByParam",
+			request: request.SearchRunsRequest{
+				Filter:  `params.optimizer NOT IN ('adam')`,
+				OrderBy: []string{"params.optimizer"},
+			},
+			runIDs: []string{"id3", "id2"},
+		},
+		{
+			name: "QuotedTagKeyAndValueWithKeyword",
+			request: request.SearchRunsRequest{
+				Filter:  `tags."env and stage" LIKE 'prod and stage%' AND tags."env and stage" != 'prod and stage2'`,
+				OrderBy: []string{"attributes.run_name DESC"},
+			},
+			runIDs: []string{"id3", "id1"},
+		},
+		{
+			name: "InjectionAttemptInValueIsTreatedAsValue",
+			request: request.SearchRunsRequest{
+				Filter: `params.optimizer = 'adam\'; DELETE FROM runs; --'`,
+			},
+			runIDs: []string{},
+		},
+		{
+			name: "InjectionAttemptInKeyIsTreatedAsKey",
+			request: request.SearchRunsRequest{
+				Filter: `params."optimizer' OR '1'='1" = 'adam'`,
+			},
+			runIDs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.request.ExperimentIDs = []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)}
+			resp := &response.SearchRunsResponse{}
+			client := s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				tt.request,
+			).WithResponse(
+				&resp,
+			)
+			s.Require().Nil(client.DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+			))
+			runIDs := make([]string, 0, len(resp.Runs))
+			for _, run := range resp.Runs {
+				runIDs = append(runIDs, run.Info.ID)
+			}
+			s.Equal(tt.runIDs, runIDs)
+		})
+	}
+
+	// make sure that nothing has been changed by the malicious filters.
+	runs, err := s.RunFixtures.GetRuns(context.Background(), *s.DefaultExperiment.ID)
+	s.Require().Nil(err)
+	s.Equal(3, len(runs))
+}
+
+func (s *SearchTestSuite) Test_FilterByTags_Ok() {
+	// create 4 test runs with overlapping tags, the last one doesn't have `dataset` tag at all.
+	for i, tags := range []map[string]string{
+		{"dataset": "mnist", "owner": "alice"},
+		{"dataset": "mnist", "owner": "bob"},
+		{"dataset": "cifar", "owner": "Alice"},
+		{"owner": "alice"},
+	} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("id%d", i+1),
+			Name:           fmt.Sprintf("TestRun%d", i+1),
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: int64(i + 1), Valid: true},
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+		for key, value := range tags {
+			_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+				Key:   key,
+				Value: value,
+				RunID: run.ID,
+			})
+			s.Require().Nil(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		runIDs []string
+	}{
+		{
+			name:   "Equal",
+			filter: `tags.dataset = 'mnist'`,
+			runIDs: []string{"id2", "id1"},
+		},
+		{
+			name:   "EqualIsCaseSensitive",
+			filter: `tags.owner = 'alice'`,
+			runIDs: []string{"id4", "id1"},
+		},
+		{
+			name:   "NotEqualSkipsRunsWithoutTag",
+			filter: `tags.dataset != 'mnist'`,
+			runIDs: []string{"id3"},
+		},
+		{
+			name:   "ILike",
+			filter: `tags.owner ILIKE 'ALI%'`,
+			runIDs: []string{"id4", "id3", "id1"},
+		},
+		{
+			name:   "InList",
+			filter: `tags.dataset IN ('cifar', 'unknown')`,
+			runIDs: []string{"id3"},
+		},
+		{
+			name:   "SeveralTags",
+			filter: `tags.dataset = 'mnist' AND tags.owner = 'bob'`,
+			runIDs: []string{"id2"},
+		},
+		{
+			name:   "SameTagTwice",
+			filter: `tags.dataset LIKE '%i%' AND tags.dataset != 'cifar'`,
+			runIDs: []string{"id2", "id1"},
+		},
+		{
+			name:   "TagAndAttribute",
+			filter: `tags.owner = 'alice' AND attributes.start_time > 1`,
+			runIDs: []string{"id4"},
+		},
+		{
+			name:   "UnknownTag",
+			filter: `tags.unknown = 'mnist'`,
+			runIDs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := &response.SearchRunsResponse{}
+			s.Require().Nil(s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.SearchRunsRequest{
+					ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+					Filter:        tt.filter,
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+			))
+			runIDs := make([]string, 0, len(resp.Runs))
+			for _, run := range resp.Runs {
+				runIDs = append(runIDs, run.Info.ID)
+			}
+			s.Equal(tt.runIDs, runIDs)
+		})
+	}
+}
+
+func (s *SearchTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.SearchRunsRequest
+	}{
+		{
+			name: "FilterWithOrInjection",
+			request: request.SearchRunsRequest{
+				Filter: `params.optimizer = 'adam' OR 1 = 1`,
+			},
+			error: api.NewInvalidParameterValueError("malformed filter 'params.optimizer = 'adam' OR 1 = 1'"),
+		},
+		{
+			name: "FilterWithStatementInjection",
+			request: request.SearchRunsRequest{
+				Filter: `metrics.accuracy > 0.5; DELETE FROM runs`,
+			},
+			error: api.NewInvalidParameterValueError("malformed filter 'metrics.accuracy > 0.5; DELETE FROM runs'"),
+		},
+		{
+			name: "OrderByWithInjection",
+			request: request.SearchRunsRequest{
+				OrderBy: []string{"attributes.start_time; DELETE FROM runs"},
+			},
+			error: api.NewInvalidParameterValueError(
+				"invalid order_by clause 'attributes.start_time; DELETE FROM runs'",
+			),
+		},
+		{
+			name: "OrderByWithUnknownAttribute",
+			request: request.SearchRunsRequest{
+				OrderBy: []string{"attributes.lifecycle_stage"},
+			},
+			error: api.NewInvalidParameterValueError(
+				"invalid order_by attribute 'lifecycle_stage'. Valid values are " +
+					"['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id', 'experiment_id', " +
+					"'duration']",
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.request.ExperimentIDs = []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)}
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
+
 func (s *SearchTestSuite) testCases(
 	namespace *models.Namespace,
 	experiment *models.Experiment,
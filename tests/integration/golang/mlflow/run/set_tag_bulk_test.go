@@ -0,0 +1,270 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SetRunsTagTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSetRunsTagTestSuite(t *testing.T) {
+	suite.Run(t, new(SetRunsTagTestSuite))
+}
+
+func (s *SetRunsTagTestSuite) createRuns(experimentID int32, names ...string) []string {
+	ids := make([]string, len(names))
+	for i, name := range names {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             fmt.Sprintf("%s-id", name),
+			Name:           name,
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			ExperimentID:   experimentID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+		ids[i] = run.ID
+	}
+	return ids
+}
+
+func (s *SetRunsTagTestSuite) getTagValues(runIDs []string, key string) map[string]string {
+	values := map[string]string{}
+	for _, runID := range runIDs {
+		tags, err := s.TagFixtures.GetByRunID(context.Background(), runID)
+		s.Require().Nil(err)
+		for _, tag := range tags {
+			if tag.Key == key {
+				values[runID] = tag.Value
+			}
+		}
+	}
+	return values
+}
+
+func (s *SetRunsTagTestSuite) Test_Ok() {
+	runIDs := s.createRuns(*s.DefaultExperiment.ID, "run1", "run2", "run3")
+
+	// set tag on three runs.
+	resp := response.UpdateRunsTagResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: []string{runIDs[2], runIDs[0], runIDs[1], runIDs[0]},
+				},
+				Key:   "sweep_id",
+				Value: "42",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSetTagBulkRoute,
+		),
+	)
+	s.Equal(runIDs, resp.RunIDs)
+	s.Equal(map[string]string{
+		runIDs[0]: "42",
+		runIDs[1]: "42",
+		runIDs[2]: "42",
+	}, s.getTagValues(runIDs, "sweep_id"))
+
+	// remove tag from one of the runs.
+	resp = response.UpdateRunsTagResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.DeleteRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: []string{runIDs[1]},
+				},
+				Key: "sweep_id",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsDeleteTagBulkRoute,
+		),
+	)
+	s.Equal([]string{runIDs[1]}, resp.RunIDs)
+	s.Equal(map[string]string{
+		runIDs[0]: "42",
+		runIDs[2]: "42",
+	}, s.getTagValues(runIDs, "sweep_id"))
+
+	// set run name through the system tag, so it is kept in sync with the run.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: runIDs[:2],
+				},
+				Key:   "mlflow.runName",
+				Value: "renamed",
+			},
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSetTagBulkRoute,
+		),
+	)
+	for i, expectedName := range []string{"renamed", "renamed", "run3"} {
+		run, err := s.RunFixtures.GetRun(context.Background(), runIDs[i])
+		s.Require().Nil(err)
+		s.Equal(expectedName, run.Name)
+	}
+}
+
+func (s *SetRunsTagTestSuite) Test_Filter() {
+	runIDs := s.createRuns(*s.DefaultExperiment.ID, "sweep1", "sweep2", "other")
+	for _, runID := range runIDs[:2] {
+		_, err := s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+			Key:   "group",
+			Value: "sweep",
+			RunID: runID,
+		})
+		s.Require().Nil(err)
+	}
+
+	resp := response.UpdateRunsTagResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+					Filter:        `tags.group = 'sweep'`,
+				},
+				Key:   "sweep_id",
+				Value: "42",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSetTagBulkRoute,
+		),
+	)
+	s.Equal(runIDs[:2], resp.RunIDs)
+	s.Equal(map[string]string{
+		runIDs[0]: "42",
+		runIDs[1]: "42",
+	}, s.getTagValues(runIDs, "sweep_id"))
+}
+
+func (s *SetRunsTagTestSuite) Test_Error() {
+	runIDs := s.createRuns(*s.DefaultExperiment.ID, "run1", "run2")
+
+	// run of another namespace is not accessible from the default namespace.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "custom",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	otherRunIDs := s.createRuns(*experiment.ID, "other")
+
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.SetRunsTagRequest
+	}{
+		{
+			name:  "RunFromOtherNamespace",
+			error: api.NewResourceDoesNotExistError("Run '%s' not found", otherRunIDs[0]),
+			request: request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: append(runIDs, otherRunIDs...),
+				},
+				Key:   "sweep_id",
+				Value: "42",
+			},
+		},
+		{
+			name:  "NotExistingRun",
+			error: api.NewResourceDoesNotExistError("Run 'not-existing' not found"),
+			request: request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: append(runIDs, "not-existing"),
+				},
+				Key:   "sweep_id",
+				Value: "42",
+			},
+		},
+		{
+			name: "MissingRuns",
+			error: api.NewInvalidParameterValueError(
+				"Missing value for required parameter 'run_ids' or 'experiment_ids'",
+			),
+			request: request.SetRunsTagRequest{
+				Key:   "sweep_id",
+				Value: "42",
+			},
+		},
+		{
+			name: "RunIDsWithFilter",
+			error: api.NewInvalidParameterValueError(
+				"Parameter 'run_ids' can't be combined with 'experiment_ids' and 'filter'",
+			),
+			request: request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: runIDs,
+					Filter: `attributes.run_name = 'run1'`,
+				},
+				Key:   "sweep_id",
+				Value: "42",
+			},
+		},
+		{
+			name:  "MissingKey",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'key'"),
+			request: request.SetRunsTagRequest{
+				RunSelectionPartialRequest: request.RunSelectionPartialRequest{
+					RunIDs: runIDs,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSetTagBulkRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+
+			// failed batch must not tag any of the runs.
+			s.Empty(s.getTagValues(append(runIDs, otherRunIDs...), "sweep_id"))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SetRunSystemTagRejectTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSetRunSystemTagRejectTestSuite(t *testing.T) {
+	testSuite := new(SetRunSystemTagRejectTestSuite)
+	testSuite.Config = config.Config{
+		SystemTagPolicy: config.SystemTagPolicyReject,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *SetRunSystemTagRejectTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	// unprotected tag is set as usual.
+	resp := fiber.Map{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRunTagRequest{
+				RunID: run.ID,
+				Key:   "team",
+				Value: "research",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSetTagRoute,
+		),
+	)
+	s.Equal(fiber.Map{}, resp)
+
+	tags, err := s.TagFixtures.GetByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal([]models.Tag{{RunID: run.ID, Key: "team", Value: "research"}}, tags)
+}
+
+func (s *SetRunSystemTagRejectTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "TestRun",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		route   string
+		request any
+	}{
+		{
+			name:  "SetTag",
+			route: mlflow.RunsSetTagRoute,
+			request: request.SetRunTagRequest{
+				RunID: run.ID,
+				Key:   "mlflow.runName",
+				Value: "renamed",
+			},
+		},
+		{
+			name:  "LogBatch",
+			route: mlflow.RunsLogBatchRoute,
+			request: request.LogBatchRequest{
+				RunID: run.ID,
+				Tags: []request.TagPartialRequest{
+					{Key: "team", Value: "research"},
+					{Key: "mlflow.runName", Value: "renamed"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, tt.route,
+				),
+			)
+			s.Equal(api.ErrorCodeInvalidParameterValue, string(resp.ErrorCode))
+			s.Equal(
+				"tag 'mlflow.runName' of run '"+run.ID+"' is a protected system tag and can't be modified",
+				resp.Message,
+			)
+
+			// make sure that neither tags nor run name have been changed.
+			tags, err := s.TagFixtures.GetByRunID(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Empty(tags)
+			updatedRun, err := s.RunFixtures.GetRun(context.Background(), run.ID)
+			s.Require().Nil(err)
+			s.Equal("TestRun", updatedRun.Name)
+		})
+	}
+}
+
+type SetRunSystemTagIgnoreTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSetRunSystemTagIgnoreTestSuite(t *testing.T) {
+	testSuite := new(SetRunSystemTagIgnoreTestSuite)
+	testSuite.Config = config.Config{
+		SystemTagPolicy: config.SystemTagPolicyIgnore,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *SetRunSystemTagIgnoreTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "TestRun",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	// protected tag is silently dropped, while unprotected one is set.
+	resp := fiber.Map{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogBatchRequest{
+				RunID: run.ID,
+				Tags: []request.TagPartialRequest{
+					{Key: "team", Value: "research"},
+					{Key: "mlflow.runName", Value: "renamed"},
+				},
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+	s.Equal(fiber.Map{}, resp)
+
+	tags, err := s.TagFixtures.GetByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal([]models.Tag{{RunID: run.ID, Key: "team", Value: "research"}}, tags)
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal("TestRun", run.Name)
+}
@@ -0,0 +1,97 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type UpdateRunStrictStatusTransitionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestUpdateRunStrictStatusTransitionTestSuite(t *testing.T) {
+	testSuite := new(UpdateRunStrictStatusTransitionTestSuite)
+	testSuite.Config = config.Config{
+		StrictRunStatusTransitions: true,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *UpdateRunStrictStatusTransitionTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	resp := response.UpdateRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.UpdateRunRequest{
+				RunID:   run.ID,
+				Status:  string(models.StatusFinished),
+				EndTime: 1111111111,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsUpdateRoute,
+		),
+	)
+	s.Equal(string(models.StatusFinished), resp.RunInfo.Status)
+
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(models.StatusFinished, run.Status)
+}
+
+func (s *UpdateRunStrictStatusTransitionTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusFinished,
+	})
+	s.Require().Nil(err)
+
+	resp := api.ErrorResponse{}
+	client := s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.UpdateRunRequest{
+			RunID:  run.ID,
+			Status: string(models.StatusRunning),
+		},
+	).WithResponse(
+		&resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.RunsRoutePrefix, mlflow.RunsUpdateRoute))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal(api.NewInvalidParameterValueError(
+		"run '%s' can't be moved from status 'FINISHED' to status 'RUNNING'", run.ID,
+	).Error(), resp.Error())
+
+	// run keeps its status.
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(models.StatusFinished, run.Status)
+}
@@ -3,6 +3,7 @@ package run
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
@@ -142,6 +144,169 @@ func (s *UpdateRunTestSuite) Test_Ok() {
 	}
 }
 
+func (s *UpdateRunTestSuite) Test_ConcurrentUpdate() {
+	// 1. create test run and read it as the first client.
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "TestRun",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ArtifactURI:    "artifact_uri",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	staleRun, err := s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+
+	// 2. the second client renames the run in the meantime.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.UpdateRunRequest{
+				RunID: run.ID,
+				Name:  "RenamedRun",
+			},
+		).WithResponse(
+			&response.UpdateRunResponse{},
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsUpdateRoute,
+		),
+	)
+
+	// 3. update from the first client has to be rejected, as it is based on the stale run.
+	staleRun.Status = models.StatusFinished
+	err = s.RunFixtures.UpdateRunWithVersionCheck(context.Background(), staleRun)
+	s.True(errors.As(err, &repositories.RunVersionConflictError{}))
+
+	// 4. check that changes of the second client have not been overwritten.
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal("RenamedRun", run.Name)
+	s.Equal(models.StatusRunning, run.Status)
+}
+
+func (s *UpdateRunTestSuite) Test_ConcurrentArchiveAndRestore() {
+	// 1. create test run and read it as the first client.
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "TestRun",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ArtifactURI:    "artifact_uri",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	staleRun, err := s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+
+	// 2. the second client deletes and restores the run in the meantime.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.DeleteRunRequest{
+				RunID: run.ID,
+			},
+		).WithResponse(
+			&struct{}{},
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsDeleteRoute,
+		),
+	)
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.RestoreRunRequest{
+				RunID: run.ID,
+			},
+		).WithResponse(
+			&struct{}{},
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsRestoreRoute,
+		),
+	)
+	s.Require().Nil(s.RunFixtures.ArchiveRuns(context.Background(), s.DefaultNamespace.ID, []string{run.ID}))
+
+	// 3. update from the first client has to be rejected, as it is based on the stale run.
+	staleRun.Status = models.StatusFinished
+	err = s.RunFixtures.UpdateRunWithVersionCheck(context.Background(), staleRun)
+	s.True(errors.As(err, &repositories.RunVersionConflictError{}))
+
+	// 4. check that every change has incremented the version.
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(models.LifecycleStageDeleted, run.LifecycleStage)
+	s.Equal(models.StatusRunning, run.Status)
+	s.Equal(int64(3), run.Version)
+}
+
+func (s *UpdateRunTestSuite) Test_LogBatchAndTerminate() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Name:           "TestRun",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ArtifactURI:    "artifact_uri",
+		ExperimentID:   *s.DefaultExperiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 1. log batch, which updates the run itself via the special tags.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogBatchRequest{
+				RunID: run.ID,
+				Tags: []request.TagPartialRequest{
+					{
+						Key:   "mlflow.user",
+						Value: "user",
+					},
+					{
+						Key:   "mlflow.runName",
+						Value: "BatchRun",
+					},
+				},
+			},
+		).WithResponse(
+			&struct{}{},
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+		),
+	)
+
+	// 2. set run as terminated, which must not conflict with the previous update.
+	resp := response.UpdateRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.UpdateRunRequest{
+				RunID:   run.ID,
+				Status:  string(models.StatusFinished),
+				EndTime: 1111111111,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsUpdateRoute,
+		),
+	)
+	s.Equal(string(models.StatusFinished), resp.RunInfo.Status)
+
+	run, err = s.RunFixtures.GetRun(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal("BatchRun", run.Name)
+	s.Equal(models.StatusFinished, run.Status)
+	s.Equal(int64(3), run.Version)
+}
+
 func (s *UpdateRunTestSuite) Test_Error() {
 	tests := []struct {
 		name    string